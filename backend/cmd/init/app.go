@@ -9,6 +9,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/limiters"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils/censor"
 
 	"github.com/Global-Optima/zeep-web/backend/api/storage"
@@ -103,7 +104,7 @@ func InitializeRouter(dbHandler *database.DBHandler, redisClient *database.Redis
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{cfg.Server.ClientURL},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Terminal-Token"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -133,6 +134,7 @@ func InitializeRouter(dbHandler *database.DBHandler, redisClient *database.Redis
 	apiRouter := routes.NewRouter(router, "/api", "/v1")
 	employeeTokenManager := employeeToken.NewEmployeeTokenManager(dbHandler.DB)
 	apiRouter.EmployeeRoutes.Use(middleware.EmployeeAuth(employeeTokenManager))
+	apiRouter.CustomerRoutes.Use(middleware.CustomerAuth(customers.NewCustomerRepository(dbHandler.DB)))
 
 	storageHandler := storage.NewStorageHandler(storageRepo)                // temp
 	storage.RegisterStorageRoutes(apiRouter.EmployeeRoutes, storageHandler) // temp
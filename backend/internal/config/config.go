@@ -33,6 +33,12 @@ type Config struct {
 	Kafka     KafkaConfig     `mapstructure:",squash"`
 	Filtering FilteringConfig `mapstructure:",squash"`
 	Payment   PaymentConfig   `mapstructure:",squash"`
+	Loyalty   LoyaltyConfig   `mapstructure:",squash"`
+	Delivery  DeliveryConfig  `mapstructure:",squash"`
+	Receipt   ReceiptConfig   `mapstructure:",squash"`
+
+	Verification VerificationConfig `mapstructure:",squash"`
+	Pickup       PickupConfig       `mapstructure:",squash"`
 }
 
 var (
@@ -0,0 +1,8 @@
+package config
+
+type DeliveryConfig struct {
+	MaxDistanceKm   float64 `mapstructure:"DELIVERY_MAX_DISTANCE_KM" default:"10"`
+	BaseFee         float64 `mapstructure:"DELIVERY_BASE_FEE" default:"500"`
+	FeePerKm        float64 `mapstructure:"DELIVERY_FEE_PER_KM" default:"100"`
+	FreeDeliveryMin float64 `mapstructure:"DELIVERY_FREE_FROM" default:"0"` // order total starting from which delivery is free, 0 disables it
+}
@@ -3,8 +3,9 @@ package config
 import "time"
 
 type JWTConfig struct {
-	CustomerSecretKey string        `mapstructure:"JWT_CUSTOMER_SECRET_KEY" validate:"required"`
-	EmployeeSecretKey string        `mapstructure:"JWT_EMPLOYEE_SECRET_KEY" validate:"required"`
-	CustomerTokenTTL  time.Duration `mapstructure:"JWT_CUSTOMER_TOKEN_TTL" default:"168h"`
-	EmployeeTokenTTL  time.Duration `mapstructure:"JWT_EMPLOYEE_TOKEN_TTL" default:"168h"`
+	CustomerSecretKey       string        `mapstructure:"JWT_CUSTOMER_SECRET_KEY" validate:"required"`
+	EmployeeSecretKey       string        `mapstructure:"JWT_EMPLOYEE_SECRET_KEY" validate:"required"`
+	CustomerTokenTTL        time.Duration `mapstructure:"JWT_CUSTOMER_TOKEN_TTL" default:"168h"`
+	EmployeeTokenTTL        time.Duration `mapstructure:"JWT_EMPLOYEE_TOKEN_TTL" default:"15m"`          // lifetime of the access token
+	EmployeeRefreshTokenTTL time.Duration `mapstructure:"JWT_EMPLOYEE_REFRESH_TOKEN_TTL" default:"168h"` // lifetime of the session on a device
 }
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type LoyaltyConfig struct {
+	AccrualPercent   float64       `mapstructure:"LOYALTY_ACCRUAL_PERCENT" default:"5"`
+	MaxRedeemPercent float64       `mapstructure:"LOYALTY_MAX_REDEEM_PERCENT" default:"50"`
+	ReferralBonus    float64       `mapstructure:"LOYALTY_REFERRAL_BONUS" default:"500"`
+	BonusTTL         time.Duration `mapstructure:"LOYALTY_BONUS_TTL" default:"8760h"`
+}
@@ -3,6 +3,9 @@ package config
 import "time"
 
 type PaymentConfig struct {
-	SecretKey   string        `mapstructure:"PAYMENT_SECRET" validate:"required"`
-	WaitingTime time.Duration `mapstructure:"PAYMENT_WAIT_TIME" default:"3m"`
+	SecretKey        string        `mapstructure:"PAYMENT_SECRET" validate:"required"`
+	WaitingTime      time.Duration `mapstructure:"PAYMENT_WAIT_TIME" default:"3m"`
+	Provider         string        `mapstructure:"PAYMENT_PROVIDER" default:"POS"`
+	Currency         string        `mapstructure:"PAYMENT_CURRENCY" default:"KZT"`
+	WebhookTolerance time.Duration `mapstructure:"PAYMENT_WEBHOOK_TOLERANCE" default:"5m"`
 }
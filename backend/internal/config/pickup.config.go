@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type PickupConfig struct {
+	SlotDuration time.Duration `mapstructure:"PICKUP_SLOT_DURATION" default:"15m"`
+	LeadTime     time.Duration `mapstructure:"PICKUP_LEAD_TIME" default:"15m"` // scheduled orders reach the barista queue this long before the slot
+	DaysAhead    int           `mapstructure:"PICKUP_DAYS_AHEAD" default:"1"`  // 0 allows scheduling for today only
+}
@@ -0,0 +1,5 @@
+package config
+
+type ReceiptConfig struct {
+	PrinterWidth int `mapstructure:"RECEIPT_PRINTER_WIDTH" default:"48"` // characters per line of the thermal printer
+}
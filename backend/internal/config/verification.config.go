@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type VerificationConfig struct {
+	SMSProvider     string        `mapstructure:"SMS_PROVIDER" default:"LOG"`
+	CodeTTL         time.Duration `mapstructure:"OTP_CODE_TTL" default:"5m"`
+	ResendCooldown  time.Duration `mapstructure:"OTP_RESEND_COOLDOWN" default:"1m"`
+	MaxCodesPerHour int           `mapstructure:"OTP_MAX_CODES_PER_HOUR" default:"5"` // codes sent to one phone, all purposes together
+	MaxAttempts     int           `mapstructure:"OTP_MAX_ATTEMPTS" default:"5"`
+}
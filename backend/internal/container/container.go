@@ -6,6 +6,8 @@ import (
 	asynqManager "github.com/Global-Optima/zeep-web/backend/internal/asynqTasks"
 	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/verificationCode"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/sms"
 
 	"github.com/Global-Optima/zeep-web/backend/api/storage"
 
@@ -39,15 +41,24 @@ type Container struct {
 	IngredientCategories    *modules.IngredientCategoriesModule
 	Orders                  *modules.OrdersModule
 	Products                *modules.ProductsModule
+	Promotions              *modules.PromotionsModule
 	Provisions              *modules.ProvisionsModule
+	Receipts                *modules.ReceiptsModule
 	Regions                 *modules.RegionsModule
+	Shifts                  *modules.ShiftsModule
 	Stores                  *modules.StoresModule
 	StoreInventoryManager   *modules.StoreInventoryManagerModule
 	StoreStocks             *modules.StoreStockModule
 	StoreSynchronizer       *modules.StoreSynchronizerModule
+	StoreTerminals          *modules.StoreTerminalsModule
+	StoreStations           *modules.StoreStationsModule
 	Suppliers               *modules.SuppliersModule
+	Taxes                   *modules.TaxesModule
+	StockMovements          *modules.StockMovementsModule
 	StockRequests           *modules.StockRequestsModule
+	Stocktakes              *modules.StocktakesModule
 	Warehouses              *modules.WarehousesModule
+	PurchaseOrders          *modules.PurchaseOrdersModule
 	StockMaterials          *modules.StockMaterialsModule
 	StockMaterialCategories *modules.StockMaterialCategoriesModule
 	Units                   *modules.UnitsModule
@@ -76,12 +87,19 @@ func (c *Container) mustInit() {
 		c.logger.Fatalf("Failed to create asynq manager: %v", err)
 	}
 
+	smsSender, err := sms.NewSender(cfg.Verification.SMSProvider, cfg.IsDevelopment || cfg.IsTest, c.logger)
+	if err != nil {
+		c.logger.Fatalf("Failed to create SMS sender: %v", err)
+	}
+	verificationCodeManager := verificationCode.NewVerificationCodeManager(c.DbHandler.DB, c.RedisClient.Client, smsSender, &cfg.Verification)
+
 	c.Audits = modules.NewAuditsModule(baseModule)
 	c.Franchisees = modules.NewFranchiseesModule(baseModule, c.Audits.Service)
 	c.Regions = modules.NewRegionsModule(baseModule, c.Audits.Service)
 	c.Notifications = modules.NewNotificationModule(baseModule)
+	c.StockMovements = modules.NewStockMovementsModule(baseModule, c.Franchisees.Service, c.Regions.Service)
 	c.Categories = modules.NewCategoriesModule(baseModule, c.Audits.Service)
-	c.Customers = modules.NewCustomersModule(baseModule)
+	c.Customers = modules.NewCustomersModule(baseModule, cronManager)
 	c.Employees = modules.NewEmployeesModule(baseModule, c.Audits.Service, c.Franchisees.Service, c.Regions.Service, *c.employeeTokenManager)
 	c.Ingredients = modules.NewIngredientsModule(baseModule, c.Audits.Service)
 	c.Suppliers = modules.NewSuppliersModule(baseModule, c.Audits.Service)
@@ -89,19 +107,29 @@ func (c *Container) mustInit() {
 	c.StockMaterialCategories = modules.NewStockMaterialCategoriesModule(baseModule, c.Audits.Service)
 	c.Units = modules.NewUnitsModule(baseModule, c.Audits.Service)
 	c.IngredientCategories = modules.NewIngredientCategoriesModule(baseModule, c.Audits.Service)
-	c.Warehouses = modules.NewWarehousesModule(baseModule, c.StockMaterials.Repo, c.Notifications.Service, cronManager, c.Regions.Service, c.Franchisees.Service, c.Audits.Service)
+	c.PurchaseOrders = modules.NewPurchaseOrdersModule(baseModule, c.Suppliers.Repo, c.Audits.Service)
+	c.Warehouses = modules.NewWarehousesModule(baseModule, c.StockMaterials.Repo, c.PurchaseOrders.Repo, c.StockMovements.Repo, c.Notifications.Service, cronManager, c.Regions.Service, c.Franchisees.Service, c.Audits.Service)
 	c.Stores = modules.NewStoresModule(baseModule, c.Franchisees.Service, c.Audits.Service)
 
-	c.StoreInventoryManager = modules.NewStoreInventoryManagersModule(baseModule, c.Notifications.Service)
+	c.StoreInventoryManager = modules.NewStoreInventoryManagersModule(baseModule, c.Notifications.Service, c.StockMovements.Repo)
 
-	c.StoreStocks = modules.NewStoreStockModule(baseModule, c.Ingredients.Service, c.Franchisees.Service, c.Audits.Service, c.Notifications.Service, c.Stores.Service, c.StoreInventoryManager.Repo, cronManager)
+	c.StoreStocks = modules.NewStoreStockModule(baseModule, c.Ingredients.Service, c.Franchisees.Service, c.Audits.Service, c.Notifications.Service, c.Stores.Service, c.StoreInventoryManager.Repo, c.StockMovements.Repo, cronManager)
 	c.Additives = modules.NewAdditivesModule(baseModule, c.Audits.Service, c.Franchisees.Service, c.Ingredients.Repo, c.StoreStocks.Repo, c.StoreInventoryManager.Repo, *c.storageRepo, c.Notifications.Service)
 	c.Products = modules.NewProductsModule(baseModule, c.Audits.Service, c.Franchisees.Service, c.Ingredients.Repo, c.Additives.StoreAdditivesModule.Repo, c.StoreStocks.Repo, c.StoreInventoryManager.Repo, *c.storageRepo, c.Notifications.Service)
 	c.Provisions = modules.NewProvisionsModule(baseModule, c.Audits.Service, c.Franchisees.Service, c.Stores.Service, c.Notifications.Service, c.Ingredients.Repo, c.StoreStocks.Repo, c.StoreInventoryManager.Repo, cronManager)
-	c.Auth = modules.NewAuthModule(baseModule, c.Customers.Repo, c.Employees.Repo, *c.employeeTokenManager)
+	c.StoreTerminals = modules.NewStoreTerminalsModule(baseModule, c.Audits.Service)
+	c.StoreStations = modules.NewStoreStationsModule(baseModule, c.Audits.Service)
+	c.Auth = modules.NewAuthModule(baseModule, c.Customers.Repo, c.Employees.Repo, c.StoreTerminals.Repo, *c.employeeTokenManager, verificationCodeManager)
+
+	c.Promotions = modules.NewPromotionsModule(baseModule, c.Audits.Service)
+
+	c.Receipts = modules.NewReceiptsModule(baseModule, c.Audits.Service)
+	c.Taxes = modules.NewTaxesModule(baseModule, c.Audits.Service)
 
-	c.Orders = modules.NewOrdersModule(baseModule, c.AsynqManager, c.Products.StoreProductsModule.Repo, c.Additives.StoreAdditivesModule.Repo, c.StoreStocks.Repo, c.StoreInventoryManager.Repo, c.Products.StoreProductsModule.Service, c.Additives.StoreAdditivesModule.Service, c.Notifications.Service)
-	c.StockRequests = modules.NewStockRequestsModule(baseModule, c.Franchisees.Service, c.Regions.Service, c.StockMaterials.Repo, c.StoreInventoryManager.Repo, c.Notifications.Service, c.Audits.Service)
+	c.Orders = modules.NewOrdersModule(baseModule, c.Audits.Service, c.AsynqManager, c.Products.StoreProductsModule.Repo, c.Additives.StoreAdditivesModule.Repo, c.StoreStocks.Repo, c.StoreInventoryManager.Repo, c.Products.StoreProductsModule.Service, c.Additives.StoreAdditivesModule.Service, c.Notifications.Service, c.Promotions.Service, c.Customers.BonusesModule.Repo, c.Customers.BonusesModule.Service, c.Receipts.Service, c.Taxes.Service, c.Stores.Service, cronManager)
+	c.Shifts = modules.NewShiftsModule(baseModule, c.Audits.Service)
+	c.StockRequests = modules.NewStockRequestsModule(baseModule, c.Franchisees.Service, c.Regions.Service, c.StockMaterials.Repo, c.StoreInventoryManager.Repo, c.StockMovements.Repo, c.Notifications.Service, c.Audits.Service)
+	c.Stocktakes = modules.NewStocktakesModule(baseModule, c.Franchisees.Service, c.Regions.Service, c.StockMaterials.Service, c.StoreInventoryManager.Repo, c.StockMovements.Repo, c.Audits.Service)
 	c.StoreSynchronizer = modules.NewStoreSynchronizerSynchronizerModule(baseModule, c.Stores.Repo, c.Additives.StoreAdditivesModule.Repo, c.StoreStocks.Repo, c.Ingredients.Repo, c.StoreInventoryManager.Repo)
 	c.Analytics = modules.NewAnalyticsModule(baseModule)
 
@@ -4,8 +4,10 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/verificationCode"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/employees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals"
 )
 
 type AuthModule struct {
@@ -20,10 +22,12 @@ func NewAuthModule(
 	base *common.BaseModule,
 	customersRepo customers.CustomerRepository,
 	employeesRepo employees.EmployeeRepository,
+	storeTerminalsRepo storeTerminals.StoreTerminalRepository,
 	employeeTokenManager employeeToken.EmployeeTokenManager,
+	verificationCodeManager verificationCode.VerificationCodeManager,
 ) *AuthModule {
 	repo := auth.NewAuthenticationRepository(base.DB)
-	service := auth.NewAuthenticationService(repo, customersRepo, employeesRepo, employeeTokenManager, base.Logger)
+	service := auth.NewAuthenticationService(repo, customersRepo, employeesRepo, storeTerminalsRepo, employeeTokenManager, verificationCodeManager, base.Logger)
 	handler := auth.NewAuthenticationHandler(service)
 
 	base.Router.RegisterAuthenticationRoutes(handler)
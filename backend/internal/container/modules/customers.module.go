@@ -3,23 +3,60 @@ package modules
 import (
 	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
+	"github.com/Global-Optima/zeep-web/backend/internal/scheduler"
 )
 
 type CustomersModule struct {
 	*common.BaseModule
-	Repo    customers.CustomerRepository
-	Service customers.CustomerService
-	Handler *customers.CustomerHandler
+	Repo          customers.CustomerRepository
+	Service       customers.CustomerService
+	Handler       *customers.CustomerHandler
+	BonusesModule *BonusesModule
 }
 
-func NewCustomersModule(base *common.BaseModule) *CustomersModule {
+func NewCustomersModule(base *common.BaseModule, cronManager *scheduler.CronManager) *CustomersModule {
 	repo := customers.NewCustomerRepository(base.DB)
 	service := customers.NewCustomerService(repo, base.Logger)
 	handler := customers.NewCustomerHandler(service)
 
-	// base.Router.RegisterCustomerRoutes(handler)
+	base.Router.RegisterCustomerRoutes(handler)
+
+	bonusesModule := NewBonusesModule(base, cronManager)
 
 	return &CustomersModule{
+		BaseModule:    base,
+		Repo:          repo,
+		Service:       service,
+		Handler:       handler,
+		BonusesModule: bonusesModule,
+	}
+}
+
+type BonusesModule struct {
+	*common.BaseModule
+	Repo    bonuses.BonusRepository
+	Service bonuses.BonusService
+	Handler *bonuses.BonusHandler
+}
+
+func NewBonusesModule(base *common.BaseModule, cronManager *scheduler.CronManager) *BonusesModule {
+	repo := bonuses.NewBonusRepository(base.DB)
+	service := bonuses.NewBonusService(repo, base.Logger)
+	handler := bonuses.NewBonusHandler(service)
+
+	base.Router.RegisterBonusRoutes(handler)
+	base.Router.RegisterCustomerBonusRoutes(handler)
+
+	bonusCronTasks := scheduler.NewBonusCronTasks(service, base.Logger)
+	err := cronManager.RegisterJob(scheduler.HourlyJob, func() {
+		bonusCronTasks.ExpireBonuses()
+	})
+	if err != nil {
+		base.Logger.Errorf("Failed to register bonus expiration cron job: %v", err)
+	}
+
+	return &BonusesModule{
 		BaseModule: base,
 		Repo:       repo,
 		Service:    service,
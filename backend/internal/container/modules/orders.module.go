@@ -2,13 +2,22 @@ package modules
 
 import (
 	"github.com/Global-Optima/zeep-web/backend/internal/asynqTasks"
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
 	storeAdditives "github.com/Global-Optima/zeep-web/backend/internal/modules/additives/storeAdditivies"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/product/storeProducts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes"
+	"github.com/Global-Optima/zeep-web/backend/internal/scheduler"
 )
 
 type OrdersModule struct {
@@ -20,6 +29,7 @@ type OrdersModule struct {
 
 func NewOrdersModule(
 	base *common.BaseModule,
+	auditService audit.AuditService,
 	asynqManager *asynqTasks.AsynqManager,
 	productRepo storeProducts.StoreProductRepository,
 	additiveRepo storeAdditives.StoreAdditiveRepository,
@@ -28,7 +38,19 @@ func NewOrdersModule(
 	storeProductService storeProducts.StoreProductService,
 	storeAdditiveService storeAdditives.StoreAdditiveService,
 	notificationService notifications.NotificationService,
+	promotionService promotions.PromotionService,
+	bonusRepo bonuses.BonusRepository,
+	bonusService bonuses.BonusService,
+	receiptService receipts.ReceiptService,
+	taxService taxes.TaxService,
+	storeService stores.StoreService,
+	cronManager *scheduler.CronManager,
 ) *OrdersModule {
+	paymentProviders, err := payments.NewPaymentProvidersFromConfig(&config.GetConfig().Payment)
+	if err != nil {
+		base.Logger.Fatalf("Failed to initialize payment providers: %v", err)
+	}
+
 	repo := orders.NewOrderRepository(base.DB)
 	service := orders.NewOrderService(
 		asynqManager,
@@ -40,20 +62,52 @@ func NewOrdersModule(
 		storeProductService,
 		storeAdditiveService,
 		notificationService,
+		promotionService,
+		bonusService,
+		receiptService,
+		taxService,
+		paymentProviders,
 		orders.NewTransactionManager(
 			base.DB,
 			repo,
 			storeInventoryManagerRepo,
+			bonusRepo,
 			notificationService,
 			base.Logger,
 		),
 		base.Logger,
 	)
-	handler := orders.NewOrderHandler(service)
+	handler := orders.NewOrderHandler(service, auditService)
 
 	ordersAsynqTasks := asynqTasks.NewOrderAsynqTasks(service, base.Logger)
 	asynqManager.RegisterTask(orders.OrderPaymentFailure, ordersAsynqTasks.HandleOrderPaymentFailureTask)
 	base.Router.RegisterOrderRoutes(handler)
+	base.Router.RegisterPaymentWebhookRoutes(handler)
+	base.Router.RegisterCustomerOrderRoutes(handler)
+	base.Router.RegisterStatusBoardRoutes(handler)
+
+	paymentCronTasks := scheduler.NewPaymentCronTasks(service, storeService, base.Logger)
+	err = cronManager.RegisterJob(scheduler.HourlyJob, func() {
+		paymentCronTasks.ReconcileTransactions()
+	})
+	if err != nil {
+		base.Logger.Errorf("Failed to register payment reconciliation cron job: %v", err)
+	}
+
+	err = cronManager.RegisterJob(scheduler.StoreDailyJob, func() {
+		paymentCronTasks.ReconcileOrderPayments()
+	})
+	if err != nil {
+		base.Logger.Errorf("Failed to register order payments reconciliation cron job: %v", err)
+	}
+
+	orderCronTasks := scheduler.NewOrderCronTasks(service, base.Logger)
+	err = cronManager.RegisterJob(scheduler.MinutelyJob, func() {
+		orderCronTasks.ReleaseScheduledOrders()
+	})
+	if err != nil {
+		base.Logger.Errorf("Failed to register scheduled orders release cron job: %v", err)
+	}
 
 	return &OrdersModule{
 		BaseModule: base,
@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions"
+)
+
+type PromotionsModule struct {
+	*common.BaseModule
+	Repo    promotions.PromotionRepository
+	Service promotions.PromotionService
+	Handler *promotions.PromotionHandler
+}
+
+func NewPromotionsModule(base *common.BaseModule, auditService audit.AuditService) *PromotionsModule {
+	repo := promotions.NewPromotionRepository(base.DB)
+	service := promotions.NewPromotionService(repo, base.Logger)
+	handler := promotions.NewPromotionHandler(service, auditService)
+
+	base.Router.RegisterPromotionRoutes(handler)
+
+	return &PromotionsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,30 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/supplier"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders"
+)
+
+type PurchaseOrdersModule struct {
+	*common.BaseModule
+	Repo    purchaseOrders.PurchaseOrderRepository
+	Service purchaseOrders.PurchaseOrderService
+	Handler *purchaseOrders.PurchaseOrderHandler
+}
+
+func NewPurchaseOrdersModule(base *common.BaseModule, supplierRepo supplier.SupplierRepository, auditService audit.AuditService) *PurchaseOrdersModule {
+	repo := purchaseOrders.NewPurchaseOrderRepository(base.DB)
+	service := purchaseOrders.NewPurchaseOrderService(repo, supplierRepo, base.Logger)
+	handler := purchaseOrders.NewPurchaseOrderHandler(service, auditService)
+
+	base.Router.RegisterPurchaseOrderRoutes(handler)
+
+	return &PurchaseOrdersModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,30 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts"
+)
+
+type ReceiptsModule struct {
+	*common.BaseModule
+	Repo    receipts.ReceiptRepository
+	Service receipts.ReceiptService
+	Handler *receipts.ReceiptHandler
+}
+
+func NewReceiptsModule(base *common.BaseModule, auditService audit.AuditService) *ReceiptsModule {
+	repo := receipts.NewReceiptRepository(base.DB)
+	service := receipts.NewReceiptService(repo, config.GetConfig().Receipt.PrinterWidth, base.Logger)
+	handler := receipts.NewReceiptHandler(service, auditService)
+
+	base.Router.RegisterReceiptRoutes(handler)
+
+	return &ReceiptsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
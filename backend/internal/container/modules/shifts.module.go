@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/shifts"
+)
+
+type ShiftsModule struct {
+	*common.BaseModule
+	Repo    shifts.ShiftRepository
+	Service shifts.ShiftService
+	Handler *shifts.ShiftHandler
+}
+
+func NewShiftsModule(base *common.BaseModule, auditService audit.AuditService) *ShiftsModule {
+	repo := shifts.NewShiftRepository(base.DB)
+	service := shifts.NewShiftService(repo, base.Logger)
+	handler := shifts.NewShiftHandler(service, auditService)
+
+	base.Router.RegisterShiftRoutes(handler)
+
+	return &ShiftsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
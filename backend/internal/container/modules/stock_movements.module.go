@@ -0,0 +1,34 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+)
+
+type StockMovementsModule struct {
+	*common.BaseModule
+	Repo    stockMovements.StockMovementRepository
+	Service stockMovements.StockMovementService
+	Handler *stockMovements.StockMovementHandler
+}
+
+func NewStockMovementsModule(
+	base *common.BaseModule,
+	franchiseeService franchisees.FranchiseeService,
+	regionService regions.RegionService,
+) *StockMovementsModule {
+	repo := stockMovements.NewStockMovementRepository(base.DB)
+	service := stockMovements.NewStockMovementService(repo, base.Logger)
+	handler := stockMovements.NewStockMovementHandler(service, franchiseeService, regionService)
+
+	base.Router.RegisterStockMovementRoutes(handler)
+
+	return &StockMovementsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
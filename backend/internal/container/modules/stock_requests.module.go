@@ -6,6 +6,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
@@ -24,10 +25,11 @@ func NewStockRequestsModule(
 	regionService regions.RegionService,
 	stockMaterialRepo stockMaterial.StockMaterialRepository,
 	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository,
+	stockMovementRepo stockMovements.StockMovementRepository,
 	notificationService notifications.NotificationService,
 	auditService audit.AuditService,
 ) *StockRequestsModule {
-	repo := stockRequests.NewStockRequestRepository(base.DB)
+	repo := stockRequests.NewStockRequestRepository(base.DB, stockMovementRepo)
 	service := stockRequests.NewStockRequestService(
 		repo,
 		stockMaterialRepo,
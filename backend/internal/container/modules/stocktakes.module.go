@@ -0,0 +1,50 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock"
+)
+
+type StocktakesModule struct {
+	*common.BaseModule
+	Repo    stocktakes.StocktakeRepository
+	Service stocktakes.StocktakeService
+	Handler *stocktakes.StocktakeHandler
+}
+
+func NewStocktakesModule(
+	base *common.BaseModule,
+	franchiseeService franchisees.FranchiseeService,
+	regionService regions.RegionService,
+	stockMaterialService stockMaterial.StockMaterialService,
+	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository,
+	stockMovementRepo stockMovements.StockMovementRepository,
+	auditService audit.AuditService,
+) *StocktakesModule {
+	repo := stocktakes.NewStocktakeRepository(base.DB, stockMovementRepo)
+	warehouseStockRepo := warehouseStock.NewWarehouseStockRepository(base.DB, stockMovementRepo)
+	service := stocktakes.NewStocktakeService(
+		repo,
+		stocktakes.NewTransactionManager(base.DB, repo, warehouseStockRepo),
+		stockMaterialService,
+		storeInventoryManagerRepo,
+		base.Logger,
+	)
+	handler := stocktakes.NewStocktakeHandler(service, franchiseeService, regionService, auditService)
+
+	base.Router.RegisterStocktakeRoutes(handler)
+
+	return &StocktakesModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -3,6 +3,7 @@ package modules
 import (
 	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 )
 
@@ -16,8 +17,9 @@ type StoreInventoryManagerModule struct {
 func NewStoreInventoryManagersModule(
 	base *common.BaseModule,
 	notificationService notifications.NotificationService,
+	stockMovementRepo stockMovements.StockMovementRepository,
 ) *StoreInventoryManagerModule {
-	repo := storeInventoryManagers.NewStoreInventoryManagerRepository(base.DB)
+	repo := storeInventoryManagers.NewStoreInventoryManagerRepository(base.DB, stockMovementRepo)
 	service := storeInventoryManagers.NewStoreInventoryManagerService(
 		repo,
 		notificationService,
@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStations"
+)
+
+type StoreStationsModule struct {
+	*common.BaseModule
+	Repo    storeStations.StoreStationRepository
+	Service storeStations.StoreStationService
+	Handler *storeStations.StoreStationHandler
+}
+
+func NewStoreStationsModule(base *common.BaseModule, auditService audit.AuditService) *StoreStationsModule {
+	repo := storeStations.NewStoreStationRepository(base.DB)
+	service := storeStations.NewStoreStationService(repo, base.Logger)
+	handler := storeStations.NewStoreStationHandler(service, auditService)
+
+	base.Router.RegisterStoreStationRoutes(handler)
+
+	return &StoreStationsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals"
+)
+
+type StoreTerminalsModule struct {
+	*common.BaseModule
+	Repo    storeTerminals.StoreTerminalRepository
+	Service storeTerminals.StoreTerminalService
+	Handler *storeTerminals.StoreTerminalHandler
+}
+
+func NewStoreTerminalsModule(base *common.BaseModule, auditService audit.AuditService) *StoreTerminalsModule {
+	repo := storeTerminals.NewStoreTerminalRepository(base.DB)
+	service := storeTerminals.NewStoreTerminalService(repo, base.Logger)
+	handler := storeTerminals.NewStoreTerminalHandler(service, auditService)
+
+	base.Router.RegisterStoreTerminalRoutes(handler)
+
+	return &StoreTerminalsModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
@@ -26,9 +27,10 @@ func NewStoreStockModule(
 	notificationService notifications.NotificationService,
 	storeService stores.StoreService,
 	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository,
+	stockMovementRepo stockMovements.StockMovementRepository,
 	cronManager *scheduler.CronManager,
 ) *StoreStockModule {
-	repo := storeStocks.NewStoreStockRepository(base.DB)
+	repo := storeStocks.NewStoreStockRepository(base.DB, stockMovementRepo)
 	service := storeStocks.NewStoreStockService(repo, storeInventoryManagerRepo, notificationService, base.Logger)
 	handler := storeStocks.NewStoreStockHandler(service, ingredientService, auditService, franchiseeService, base.Logger)
 
@@ -36,7 +38,7 @@ func NewStoreStockModule(
 
 	storeWarehouseCronTasks := scheduler.NewStoreStockCronTasks(service, repo, storeService, base.Logger)
 
-	err := cronManager.RegisterJob(scheduler.DailyJob, func() {
+	err := cronManager.RegisterJob(scheduler.StoreDailyJob, func() {
 		storeWarehouseCronTasks.CheckStockNotifications()
 	})
 	if err != nil {
@@ -0,0 +1,29 @@
+package modules
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/container/common"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes"
+)
+
+type TaxesModule struct {
+	*common.BaseModule
+	Repo    taxes.TaxRepository
+	Service taxes.TaxService
+	Handler *taxes.TaxHandler
+}
+
+func NewTaxesModule(base *common.BaseModule, auditService audit.AuditService) *TaxesModule {
+	repo := taxes.NewTaxRepository(base.DB)
+	service := taxes.NewTaxService(repo, base.Logger)
+	handler := taxes.NewTaxHandler(service, auditService)
+
+	base.Router.RegisterTaxRoutes(handler)
+
+	return &TaxesModule{
+		BaseModule: base,
+		Repo:       repo,
+		Service:    service,
+		Handler:    handler,
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock"
 	"github.com/Global-Optima/zeep-web/backend/internal/scheduler"
@@ -22,6 +24,8 @@ type WarehousesModule struct {
 func NewWarehousesModule(
 	base *common.BaseModule,
 	stockMaterialRepo stockMaterial.StockMaterialRepository,
+	purchaseOrderRepo purchaseOrders.PurchaseOrderRepository,
+	stockMovementRepo stockMovements.StockMovementRepository,
 	notificationService notifications.NotificationService,
 	cronManager *scheduler.CronManager,
 	regionService regions.RegionService,
@@ -29,9 +33,11 @@ func NewWarehousesModule(
 	auditService audit.AuditService,
 ) *WarehousesModule {
 	warehouseRepo := warehouse.NewWarehouseRepository(base.DB)
-	warehouseStockRepo := warehouseStock.NewWarehouseStockRepository(base.DB)
+	warehouseStockRepo := warehouseStock.NewWarehouseStockRepository(base.DB, stockMovementRepo)
 
-	warehouseStockService := warehouseStock.NewWarehouseStockService(warehouseStockRepo, stockMaterialRepo, notificationService, base.Logger)
+	warehouseStockTransactionManager := warehouseStock.NewTransactionManager(base.DB, warehouseStockRepo, purchaseOrderRepo)
+
+	warehouseStockService := warehouseStock.NewWarehouseStockService(warehouseStockRepo, warehouseStockTransactionManager, stockMaterialRepo, notificationService, base.Logger)
 	warehouseService := warehouse.NewWarehouseService(warehouseRepo, base.Logger)
 
 	warehouseHandler := warehouse.NewWarehouseHandler(warehouseService, regionService, auditService)
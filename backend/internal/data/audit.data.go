@@ -55,6 +55,24 @@ const (
 	SupplierComponent              ComponentName = "SUPPLIER"
 	UnitComponent                  ComponentName = "UNIT"
 	OrderComponent                 ComponentName = "ORDER"
+	OrderRefundComponent           ComponentName = "ORDER_REFUND"
+	OrderCancellationComponent     ComponentName = "ORDER_CANCELLATION"
+	OrderDeliveryComponent         ComponentName = "ORDER_DELIVERY"
+	PaymentComponent               ComponentName = "PAYMENT"
+	PromotionComponent             ComponentName = "PROMOTION"
+	BonusComponent                 ComponentName = "BONUS"
+	CustomerComponent              ComponentName = "CUSTOMER"
+	CustomerAddressComponent       ComponentName = "CUSTOMER_ADDRESS"
+	CashShiftComponent             ComponentName = "CASH_SHIFT"
+	ReceiptComponent               ComponentName = "RECEIPT"
+	TaxRateComponent               ComponentName = "TAX_RATE"
+	StoreTerminalComponent         ComponentName = "STORE_TERMINAL"
+	StoreStationComponent          ComponentName = "STORE_STATION"
+	PurchaseOrderComponent         ComponentName = "PURCHASE_ORDER"
+	StocktakeComponent             ComponentName = "STOCKTAKE"
+	StoreStocktakeComponent        ComponentName = "STORE_STOCKTAKE"
+	WarehouseStocktakeComponent    ComponentName = "WAREHOUSE_STOCKTAKE"
+	StockMovementComponent         ComponentName = "STOCK_MOVEMENT"
 
 	AuthenticationComponent ComponentName = "AUTH"
 	TechnicalMapComponent   ComponentName = "TECHNICAL_MAP"
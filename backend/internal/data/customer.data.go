@@ -19,18 +19,28 @@ type Customer struct {
 
 type Referral struct {
 	BaseEntity
-	CustomerID uint     `gorm:"index;not null"`
-	RefereeID  uint     `gorm:"index;not null"`
-	Customer   Customer `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" sort:"customers"`
-	Referee    Customer `gorm:"foreignKey:RefereeID;constraint:OnDelete:CASCADE" sort:"referees"`
+	CustomerID uint       `gorm:"index;not null"`
+	RefereeID  uint       `gorm:"index;not null"`
+	Customer   Customer   `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" sort:"customers"`
+	Referee    Customer   `gorm:"foreignKey:RefereeID;constraint:OnDelete:CASCADE" sort:"referees"`
+	RewardedAt *time.Time `gorm:"index"` // set once the referrer got the bonus for the first completed order of the referee
 }
 
+type VerificationPurpose string
+
+const (
+	VerificationPurposePhone         VerificationPurpose = "PHONE_VERIFICATION"
+	VerificationPurposePasswordReset VerificationPurpose = "PASSWORD_RESET"
+)
+
 type VerificationCode struct {
 	BaseEntity
-	CustomerID uint      `gorm:"index;not null"`
-	Customer   Customer  `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE"`
-	Code       string    `gorm:"size:6;not null"`
-	ExpiresAt  time.Time `gorm:"not null"`
+	CustomerID uint                `gorm:"index;not null"`
+	Customer   Customer            `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE"`
+	Purpose    VerificationPurpose `gorm:"size:30;not null;default:PHONE_VERIFICATION"`
+	Code       string              `gorm:"size:6;not null"`
+	Attempts   int                 `gorm:"not null;default:0"` // wrong codes entered, the code is dropped after too many of them
+	ExpiresAt  time.Time           `gorm:"not null"`
 }
 
 type CustomerAddress struct {
@@ -42,10 +52,39 @@ type CustomerAddress struct {
 	Latitude   string   `gorm:"size:20"`
 }
 
+type BonusType string
+
+const (
+	BonusTypeAccrual    BonusType = "ACCRUAL"
+	BonusTypeReferral   BonusType = "REFERRAL"
+	BonusTypeReturn     BonusType = "RETURN"
+	BonusTypeRedemption BonusType = "REDEMPTION"
+	BonusTypeExpiration BonusType = "EXPIRATION"
+	BonusTypeReversal   BonusType = "REVERSAL"
+)
+
+// IsCredit reports whether the bonus entry adds points to the balance
+func (t BonusType) IsCredit() bool {
+	switch t {
+	case BonusTypeAccrual, BonusTypeReferral, BonusTypeReturn:
+		return true
+	default:
+		return false
+	}
+}
+
+// Bonus is an entry of the customer loyalty ledger. Bonuses is always positive, the sign is defined by Type.
+// Remaining keeps the unspent part of credit entries, redemptions and expirations consume it
 type Bonus struct {
 	BaseEntity
-	Bonuses    float64    `gorm:"type:decimal(10,2);check:bonuses >= 0"`
+	Bonuses    float64    `gorm:"type:decimal(10,2);check:bonuses >= 0" sort:"bonuses"`
+	Remaining  float64    `gorm:"type:decimal(10,2);not null;default:0;check:remaining >= 0"`
+	Type       BonusType  `gorm:"size:50;not null;default:ACCRUAL" sort:"type"`
 	CustomerID uint       `gorm:"index;not null"`
 	Customer   Customer   `gorm:"foreignKey:CustomerID;constraint:OnDelete:CASCADE" sort:"customers"`
+	OrderID    *uint      `gorm:"index"`
+	Order      *Order     `gorm:"foreignKey:OrderID;constraint:OnDelete:SET NULL"`
+	ReferralID *uint      `gorm:"index"`
+	Referral   *Referral  `gorm:"foreignKey:ReferralID;constraint:OnDelete:SET NULL"`
 	ExpiresAt  *time.Time `sort:"expiresAt"`
 }
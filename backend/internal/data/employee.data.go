@@ -297,11 +297,14 @@ func (e *Employee) GetType() EmployeeType {
 
 type StoreEmployee struct {
 	BaseEntity
-	EmployeeID uint              `gorm:"index,not null"`
-	StoreID    uint              `gorm:"index,not null"`
-	Role       StoreEmployeeRole `gorm:"type:store_employee_role;not null" sort:"role"`
-	Employee   Employee          `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE" sort:"employee"`
-	Store      Store             `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE" sort:"store"`
+	EmployeeID        uint              `gorm:"index,not null"`
+	StoreID           uint              `gorm:"index,not null"`
+	Role              StoreEmployeeRole `gorm:"type:store_employee_role;not null" sort:"role"`
+	PinHash           *string           `gorm:"size:255"` // PIN to switch the operator of a store terminal
+	PinFailedAttempts int               `gorm:"not null;default:0"`
+	PinLockedUntil    *time.Time        `gorm:"type:timestamp"`
+	Employee          Employee          `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE" sort:"employee"`
+	Store             Store             `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE" sort:"store"`
 }
 
 type WarehouseEmployee struct {
@@ -355,10 +358,18 @@ type EmployeeWorkday struct {
 	Employee   Employee `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE" sort:"employees"`
 }
 
+// EmployeeToken is a login session of an employee on a single device, only the hashes of the refresh tokens are stored
 type EmployeeToken struct {
 	BaseEntity
-	Token      string    `gorm:"size:255;not null"`
-	ExpiresAt  time.Time `gorm:"type:timestamp;not null"`
-	EmployeeID uint      `gorm:"unique;index;not null"`
-	Employee   Employee  `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
+	RefreshTokenHash         string         `gorm:"size:64;not null;uniqueIndex"`
+	PreviousRefreshTokenHash *string        `gorm:"size:64;index"`
+	ExpiresAt                time.Time      `gorm:"type:timestamp;not null"`
+	LastUsedAt               time.Time      `gorm:"type:timestamp;not null" sort:"lastUsedAt"`
+	DeviceName               string         `gorm:"size:255"`
+	UserAgent                string         `gorm:"size:512"`
+	IPAddress                string         `gorm:"size:45"`
+	StoreTerminalID          *uint          `gorm:"index"` // set for the PIN sessions, a terminal has one active operator at a time
+	StoreTerminal            *StoreTerminal `gorm:"foreignKey:StoreTerminalID;constraint:OnDelete:CASCADE"`
+	EmployeeID               uint           `gorm:"index;not null"`
+	Employee                 Employee       `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
 }
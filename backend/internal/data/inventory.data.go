@@ -56,7 +56,19 @@ type StockRequestIngredient struct {
 	StockMaterial   StockMaterial `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:CASCADE"`
 	Quantity        float64       `gorm:"type:decimal(10,2);not null;check:quantity > 0" sort:"quantity"`
 	DeliveredDate   time.Time     `gorm:"not null;default:CURRENT_TIMESTAMP" sort:"deliveryDate"` // Delivery start date
-	ExpirationDate  time.Time     `gorm:"not null" sort:"expirationDate"`                         // Calculated from DeliveredDate + ExpirationPeriodInDays
+	ExpirationDate  time.Time     `gorm:"not null" sort:"expirationDate"`                         // The earliest expiration of the picked lots, DeliveredDate + ExpirationPeriodInDays without lots
+
+	Lots []StockRequestIngredientLot `gorm:"foreignKey:StockRequestIngredientID;constraint:OnDelete:CASCADE"` // the warehouse lots the material was picked from
+}
+
+// StockRequestIngredientLot is the part of the requested stock material picked from the warehouse lot when the request is sent
+type StockRequestIngredientLot struct {
+	BaseEntity
+	StockRequestIngredientID uint              `gorm:"not null;index"`
+	LotID                    uint              `gorm:"not null;index"`
+	Lot                      WarehouseStockLot `gorm:"foreignKey:LotID;constraint:OnDelete:CASCADE"`
+	Quantity                 float64           `gorm:"type:decimal(10,2);not null;check:quantity > 0"`
+	ExpirationDate           time.Time         `gorm:"not null"`
 }
 
 // Hooks for StockRequestIngredient
@@ -148,12 +160,13 @@ type SupplierPrice struct {
 
 type SupplierWarehouseDelivery struct {
 	BaseEntity
-	SupplierID   uint                                `gorm:"not null"`
-	Supplier     Supplier                            `gorm:"foreignKey:SupplierID;constraint:OnDelete:CASCADE"`
-	WarehouseID  uint                                `gorm:"not null"`
-	Warehouse    Warehouse                           `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
-	Materials    []SupplierWarehouseDeliveryMaterial `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
-	DeliveryDate time.Time                           `gorm:"not null;default:CURRENT_TIMESTAMP" sort:"deliveryDate"`
+	SupplierID      uint                                `gorm:"not null"`
+	Supplier        Supplier                            `gorm:"foreignKey:SupplierID;constraint:OnDelete:CASCADE"`
+	WarehouseID     uint                                `gorm:"not null"`
+	Warehouse       Warehouse                           `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+	Materials       []SupplierWarehouseDeliveryMaterial `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+	DeliveryDate    time.Time                           `gorm:"not null;default:CURRENT_TIMESTAMP" sort:"deliveryDate"`
+	PurchaseOrderID *uint                               `gorm:"index"` // the purchase order fulfilled by the delivery, nil when received without an order
 }
 
 // Hooks for SupplierWarehouseDelivery
@@ -190,10 +203,38 @@ func (s *SupplierWarehouseDeliveryMaterial) AfterFind(tx *gorm.DB) (err error) {
 	return
 }
 
+// WarehouseStockLot is a batch of the stock material received by the warehouse, the warehouse stock quantity is the sum of its lots.
+// The stock is picked from the lots expiring first
+type WarehouseStockLot struct {
+	BaseEntity
+	WarehouseID        uint                               `gorm:"not null;index"`
+	Warehouse          Warehouse                          `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+	StockMaterialID    uint                               `gorm:"not null;index"`
+	StockMaterial      StockMaterial                      `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:CASCADE"`
+	DeliveryMaterialID *uint                              `gorm:"index"` // nil for the stock added by hand
+	DeliveryMaterial   *SupplierWarehouseDeliveryMaterial `gorm:"foreignKey:DeliveryMaterialID;constraint:OnDelete:SET NULL"`
+	Barcode            string                             `gorm:"size:255;not null"`
+	ExpirationDate     time.Time                          `gorm:"not null" sort:"expirationDate"`
+	ReceivedQuantity   float64                            `gorm:"type:decimal(10,2);not null"`
+	Quantity           float64                            `gorm:"type:decimal(10,2);not null;check:quantity >= 0" sort:"quantity"`
+}
+
+// Hooks for WarehouseStockLot
+func (s *WarehouseStockLot) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ExpirationDate = toUTC(s.ExpirationDate)
+	return
+}
+
+func (s *WarehouseStockLot) AfterFind(tx *gorm.DB) (err error) {
+	s.ExpirationDate = toUTC(s.ExpirationDate)
+	return
+}
+
 type AggregatedWarehouseStock struct {
-	WarehouseID            uint          `json:"warehouseId"`
-	StockMaterialID        uint          `json:"stockMaterialId"`
-	StockMaterial          StockMaterial `gorm:"foreignKey:StockMaterialID;references:ID;preload:true" json:"stockMaterial"`
-	TotalQuantity          float64       `json:"totalQuantity"`
-	EarliestExpirationDate *time.Time    `json:"earliestExpirationDate"`
+	WarehouseID            uint                `json:"warehouseId"`
+	StockMaterialID        uint                `json:"stockMaterialId"`
+	StockMaterial          StockMaterial       `gorm:"foreignKey:StockMaterialID;references:ID;preload:true" json:"stockMaterial"`
+	TotalQuantity          float64             `json:"totalQuantity"`
+	EarliestExpirationDate *time.Time          `json:"earliestExpirationDate"`
+	Lots                   []WarehouseStockLot `gorm:"-" json:"lots"` // the lots holding stock, loaded for a single material only
 }
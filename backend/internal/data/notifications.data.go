@@ -14,19 +14,20 @@ const (
 )
 
 const (
-	STOCK_REQUEST_STATUS_UPDATED NotificationEventType = "STOCK_REQUEST_STATUS_UPDATED"
-	NEW_ORDER                    NotificationEventType = "NEW_ORDER"
-	NEW_PRODUCT_SIZE             NotificationEventType = "NEW_PRODUCT_SIZE"
-	NEW_PRODUCT                  NotificationEventType = "NEW_PRODUCT"
-	NEW_ADDITIVE                 NotificationEventType = "NEW_ADDITIVE"
-	STORE_WAREHOUSE_RUN_OUT      NotificationEventType = "STORE_WAREHOUSE_RUN_OUT"
-	CENTRAL_CATALOG_UPDATE       NotificationEventType = "CENTRAL_CATALOG_UPDATE"
-	STORE_STOCK_EXPIRATION       NotificationEventType = "STORE_STOCK_EXPIRATION"
-	STORE_PROVISION_EXPIRATION   NotificationEventType = "STORE_PROVISION_EXPIRATION"
-	WAREHOUSE_STOCK_EXPIRATION   NotificationEventType = "WAREHOUSE_STOCK_EXPIRATION"
-	WAREHOUSE_OUT_OF_STOCK       NotificationEventType = "WAREHOUSE_OUT_OF_STOCK"
-	NEW_STOCK_REQUEST            NotificationEventType = "NEW_STOCK_REQUEST"
-	PRICE_CHANGE                 NotificationEventType = "PRICE_CHANGE"
+	STOCK_REQUEST_STATUS_UPDATED    NotificationEventType = "STOCK_REQUEST_STATUS_UPDATED"
+	NEW_ORDER                       NotificationEventType = "NEW_ORDER"
+	NEW_PRODUCT_SIZE                NotificationEventType = "NEW_PRODUCT_SIZE"
+	NEW_PRODUCT                     NotificationEventType = "NEW_PRODUCT"
+	NEW_ADDITIVE                    NotificationEventType = "NEW_ADDITIVE"
+	STORE_WAREHOUSE_RUN_OUT         NotificationEventType = "STORE_WAREHOUSE_RUN_OUT"
+	CENTRAL_CATALOG_UPDATE          NotificationEventType = "CENTRAL_CATALOG_UPDATE"
+	STORE_STOCK_EXPIRATION          NotificationEventType = "STORE_STOCK_EXPIRATION"
+	STORE_PROVISION_EXPIRATION      NotificationEventType = "STORE_PROVISION_EXPIRATION"
+	WAREHOUSE_STOCK_EXPIRATION      NotificationEventType = "WAREHOUSE_STOCK_EXPIRATION"
+	WAREHOUSE_OUT_OF_STOCK          NotificationEventType = "WAREHOUSE_OUT_OF_STOCK"
+	NEW_STOCK_REQUEST               NotificationEventType = "NEW_STOCK_REQUEST"
+	PRICE_CHANGE                    NotificationEventType = "PRICE_CHANGE"
+	PAYMENT_RECONCILIATION_MISMATCH NotificationEventType = "PAYMENT_RECONCILIATION_MISMATCH"
 )
 
 func (nt NotificationEventType) ToString() string {
@@ -1,6 +1,10 @@
 package data
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
 
 type OrderStatus string
 
@@ -12,6 +16,7 @@ const (
 	OrderStatusInDelivery        OrderStatus = "IN_DELIVERY"
 	OrderStatusDelivered         OrderStatus = "DELIVERED"
 	OrderStatusCancelled         OrderStatus = "CANCELLED"
+	OrderStatusRefunded          OrderStatus = "REFUNDED"
 )
 
 type SubOrderStatus string
@@ -20,6 +25,47 @@ const (
 	SubOrderStatusPending   SubOrderStatus = "PENDING"
 	SubOrderStatusPreparing SubOrderStatus = "PREPARING"
 	SubOrderStatusCompleted SubOrderStatus = "COMPLETED"
+	SubOrderStatusRefunded  SubOrderStatus = "REFUNDED"
+	SubOrderStatusCancelled SubOrderStatus = "CANCELLED"
+)
+
+// IsClosed reports whether the suborder is refunded or cancelled, so nothing of it is kept by the customer
+func (s SubOrderStatus) IsClosed() bool {
+	return s == SubOrderStatusRefunded || s == SubOrderStatusCancelled
+}
+
+type OrderCancellationReason string
+
+const (
+	OrderCancellationReasonCustomerLeft OrderCancellationReason = "CUSTOMER_LEFT"
+	OrderCancellationReasonWrongDrink   OrderCancellationReason = "WRONG_DRINK"
+	OrderCancellationReasonOutOfStock   OrderCancellationReason = "OUT_OF_STOCK"
+	OrderCancellationReasonOther        OrderCancellationReason = "OTHER"
+)
+
+func IsValidOrderCancellationReason(reason OrderCancellationReason) bool {
+	switch reason {
+	case OrderCancellationReasonCustomerLeft, OrderCancellationReasonWrongDrink,
+		OrderCancellationReasonOutOfStock, OrderCancellationReasonOther:
+		return true
+	}
+	return false
+}
+
+type OrderCancellationStatus string
+
+const (
+	OrderCancellationStatusPendingApproval OrderCancellationStatus = "PENDING_APPROVAL"
+	OrderCancellationStatusApproved        OrderCancellationStatus = "APPROVED"
+	OrderCancellationStatusRejected        OrderCancellationStatus = "REJECTED"
+)
+
+type OrderRefundStatus string
+
+const (
+	OrderRefundStatusPending   OrderRefundStatus = "PENDING"
+	OrderRefundStatusCompleted OrderRefundStatus = "COMPLETED"
+	OrderRefundStatusFailed    OrderRefundStatus = "FAILED"
 )
 
 type TransactionType string
@@ -29,6 +75,29 @@ const (
 	TransactionTypeRefund  TransactionType = "REFUND"
 )
 
+type PaymentProviderName string
+
+const (
+	PaymentProviderPOS  PaymentProviderName = "POS"
+	PaymentProviderMock PaymentProviderName = "MOCK"
+)
+
+type PaymentIntentStatus string
+
+const (
+	PaymentIntentStatusPending   PaymentIntentStatus = "PENDING"
+	PaymentIntentStatusSucceeded PaymentIntentStatus = "SUCCEEDED"
+	PaymentIntentStatusFailed    PaymentIntentStatus = "FAILED"
+)
+
+type TransactionReconciliationStatus string
+
+const (
+	TransactionReconciliationStatusMatched    TransactionReconciliationStatus = "MATCHED"
+	TransactionReconciliationStatusMismatched TransactionReconciliationStatus = "MISMATCHED"
+	TransactionReconciliationStatusNotFound   TransactionReconciliationStatus = "NOT_FOUND"
+)
+
 type Order struct {
 	BaseEntity
 	CustomerID        *uint           `gorm:"index"`
@@ -39,24 +108,57 @@ type Order struct {
 	Store             Store           `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
 	DeliveryAddressID *uint           `gorm:"index"`
 	DeliveryAddress   CustomerAddress `gorm:"foreignKey:DeliveryAddressID;constraint:OnDelete:CASCADE"`
+	DeliveryFee       float64         `gorm:"type:decimal(10,2);not null;default:0;check:delivery_fee >= 0"`
+	DeliveryDistance  *float64        `gorm:"type:decimal(10,2)"` // kilometers from the store to the delivery address
+	CourierID         *uint           `gorm:"index"`
+	Courier           *StoreEmployee  `gorm:"foreignKey:CourierID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	CashShiftID       *uint           `gorm:"index"`
+	CashShift         *CashShift      `gorm:"foreignKey:CashShiftID;constraint:OnDelete:SET NULL"`
 	Status            OrderStatus     `gorm:"size:50;not null" sort:"orderStatus"`
 	Total             float64         `gorm:"type:decimal(10,2);not null;check:total >= 0" sort:"total"`
+	DiscountTotal     float64         `gorm:"type:decimal(10,2);not null;default:0;check:discount_total >= 0"`
+	TaxTotal          float64         `gorm:"type:decimal(10,2);not null;default:0;check:tax_total >= 0"`
+	BonusesRedeemed   float64         `gorm:"type:decimal(10,2);not null;default:0;check:bonuses_redeemed >= 0"`
 	Suborders         []Suborder      `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
 	DisplayNumber     int             `gorm:"not null;index"`
 	Transactions      []Transaction   `gorm:"foreignKey:OrderID;constraint:OnDelete:SET NULL"`
 	CompletedAt       *time.Time      `gorm:"index;null"`
+	DeliveredAt       *time.Time      `gorm:"null"`
+	PickupAt          *time.Time      `gorm:"null"` // scheduled pickup slot of a customer order, nil for "as soon as possible"
+	ReleasedAt        *time.Time      `gorm:"null"` // when the scheduled order was pushed to the barista queue
+	EstimatedReadyAt  *time.Time      `gorm:"null"` // refreshed as the suborders of the store queue progress
 }
 
 // Suborder Model
 type Suborder struct {
 	BaseEntity
-	OrderID            uint               `gorm:"index;not null"`
-	StoreProductSizeID uint               `gorm:"index;not null"`
-	StoreProductSize   StoreProductSize   `gorm:"foreignKey:StoreProductSizeID;constraint:OnDelete:CASCADE"`
-	Price              float64            `gorm:"type:decimal(10,2);not null;check:price >= 0"`
-	Status             SubOrderStatus     `gorm:"size:50;not null"`
-	SuborderAdditives  []SuborderAdditive `gorm:"foreignKey:SuborderID;constraint:OnDelete:CASCADE"`
-	CompletedAt        *time.Time         `gorm:"index;null"`
+	OrderID            uint                   `gorm:"index;not null"`
+	StoreProductSizeID uint                   `gorm:"index;not null"`
+	StoreProductSize   StoreProductSize       `gorm:"foreignKey:StoreProductSizeID;constraint:OnDelete:CASCADE"`
+	Price              float64                `gorm:"type:decimal(10,2);not null;check:price >= 0"`
+	DiscountAmount     float64                `gorm:"type:decimal(10,2);not null;default:0;check:discount_amount >= 0"`
+	TaxRate            float64                `gorm:"type:decimal(5,2);not null;default:0"` // percent, copied from the tax rate at order creation
+	TaxMode            TaxMode                `gorm:"size:20;not null;default:'INCLUSIVE'"`
+	TaxAmount          float64                `gorm:"type:decimal(10,2);not null;default:0;check:tax_amount >= 0"`
+	Status             SubOrderStatus         `gorm:"size:50;not null"`
+	SuborderAdditives  []SuborderAdditive     `gorm:"foreignKey:SuborderID;constraint:OnDelete:CASCADE"`
+	Discounts          []SuborderDiscount     `gorm:"foreignKey:SuborderID;constraint:OnDelete:CASCADE"`
+	StartedAt          *time.Time             `gorm:"null"` // when the suborder went to PREPARING
+	CompletedAt        *time.Time             `gorm:"index;null"`
+	CancellationID     *uint                  `gorm:"index"`
+	StationID          *uint                  `gorm:"index"` // nil when no station of the store prepares the category
+	Station            *StoreStation          `gorm:"foreignKey:StationID;constraint:OnDelete:SET NULL"`
+	StatusChanges      []SuborderStatusChange `gorm:"foreignKey:SuborderID;constraint:OnDelete:CASCADE"`
+}
+
+// SuborderStatusChange records the store employee who moved the suborder to the status
+type SuborderStatusChange struct {
+	BaseEntity
+	SuborderID      uint           `gorm:"index;not null"`
+	Suborder        Suborder       `gorm:"foreignKey:SuborderID;constraint:OnDelete:CASCADE"`
+	Status          SubOrderStatus `gorm:"size:50;not null"`
+	StoreEmployeeID *uint          `gorm:"index"`
+	StoreEmployee   *StoreEmployee `gorm:"foreignKey:StoreEmployeeID;constraint:OnDelete:SET NULL"`
 }
 
 // SuborderAdditive Model
@@ -82,4 +184,55 @@ type Transaction struct {
 	QRNumber      *string         `gorm:"type:varchar(16)"`
 	CardMask      *string         `gorm:"type:varchar(16)"`
 	ICC           *string         `gorm:"type:varchar(255)"`
+
+	// the payments go to the shift of the order, the refunds to the shift open when the money is returned
+	CashShiftID *uint      `gorm:"index"`
+	CashShift   *CashShift `gorm:"foreignKey:CashShiftID;constraint:OnDelete:SET NULL"`
+
+	Provider             PaymentProviderName              `gorm:"type:varchar(50);not null;default:'POS'"`
+	ReconciliationStatus *TransactionReconciliationStatus `gorm:"type:varchar(50)"`
+	ReconciledAt         *time.Time
+}
+
+// PaymentIntent is the payment requested from a provider, the order is paid when the provider confirms it by a signed webhook
+type PaymentIntent struct {
+	BaseEntity
+	OrderID    uint                `gorm:"index;not null"`
+	Order      Order               `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	Provider   PaymentProviderName `gorm:"type:varchar(50);not null"`
+	ExternalID string              `gorm:"type:varchar(64);unique;not null"`
+	Amount     float64             `gorm:"type:decimal(10,2);not null"`
+	Currency   string              `gorm:"type:char(3);not null"`
+	Status     PaymentIntentStatus `gorm:"type:varchar(50);not null"`
+}
+
+// OrderRefund is recorded before the provider returns the money and completed once the suborders are refunded.
+// A refund left PENDING may be paid out by the provider already, it blocks the next refunds of the order until resolved
+type OrderRefund struct {
+	BaseEntity
+	OrderID               uint                      `gorm:"index;not null"`
+	Order                 Order                     `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	SuborderIDs           datatypes.JSONSlice[uint] `gorm:"type:jsonb;not null"`
+	Amount                float64                   `gorm:"type:decimal(10,2);not null"`
+	Status                OrderRefundStatus         `gorm:"type:varchar(50);not null" sort:"status"`
+	ProviderTransactionID *string                   `gorm:"type:varchar(64)"`
+	TransactionID         *uint                     `gorm:"index"`
+	Transaction           *Transaction              `gorm:"foreignKey:TransactionID;constraint:OnDelete:SET NULL"`
+}
+
+// OrderCancellation groups the suborders cancelled by one request.
+// Requests touching PREPARING suborders wait for a store manager approval.
+type OrderCancellation struct {
+	BaseEntity
+	OrderID       uint                    `gorm:"index;not null"`
+	Order         Order                   `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	Reason        OrderCancellationReason `gorm:"size:50;not null" sort:"reason"`
+	Comment       *string                 `gorm:"type:text"`
+	Status        OrderCancellationStatus `gorm:"size:50;not null" sort:"status"`
+	RequestedByID uint                    `gorm:"index;not null"`
+	RequestedBy   Employee                `gorm:"foreignKey:RequestedByID;constraint:OnDelete:CASCADE"`
+	ReviewedByID  *uint                   `gorm:"index"`
+	ReviewedBy    *Employee               `gorm:"foreignKey:ReviewedByID;constraint:OnDelete:SET NULL"`
+	ReviewedAt    *time.Time
+	Suborders     []Suborder `gorm:"foreignKey:CancellationID"`
 }
@@ -43,7 +43,6 @@ type ProductSize struct {
 	Size                   float64                 `gorm:"not null"`
 	ProductID              uint                    `gorm:"index;not null"`
 	Product                Product                 `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" sort:"product"`
-	DiscountID             uint                    `gorm:"index"`
 	MachineId              string                  `gorm:"size:40;not null;unique" sort:"machineId"`
 	AdditivesUpdatedAt     time.Time               `gorm:"index" sort:"additivesUpdatedAt"`
 	ProvisionsUpdatedAt    time.Time               `gorm:"index" sort:"provisionsUpdatedAt"`
@@ -0,0 +1,56 @@
+package data
+
+import "time"
+
+type PromotionType string
+
+const (
+	PromotionTypePercentage  PromotionType = "PERCENTAGE"
+	PromotionTypeFixedAmount PromotionType = "FIXED_AMOUNT"
+	PromotionTypeBuyXGetY    PromotionType = "BUY_X_GET_Y"
+	PromotionTypeHappyHour   PromotionType = "HAPPY_HOUR"
+)
+
+func IsValidPromotionType(promotionType PromotionType) bool {
+	switch promotionType {
+	case PromotionTypePercentage, PromotionTypeFixedAmount, PromotionTypeBuyXGetY, PromotionTypeHappyHour:
+		return true
+	}
+	return false
+}
+
+// Promotion is applied to the suborders matching its scope. Empty scope fields mean no restriction:
+// FranchiseeID/StoreID limit where the promotion works, ProductCategoryID/ProductSizeID limit what it discounts.
+type Promotion struct {
+	BaseEntity
+	Name              string           `gorm:"size:255;not null" sort:"name"`
+	Description       string           `gorm:"type:text"`
+	Type              PromotionType    `gorm:"size:50;not null" sort:"type"`
+	Value             float64          `gorm:"type:decimal(10,2);not null;default:0" sort:"value"` // percent for PERCENTAGE and HAPPY_HOUR, amount for FIXED_AMOUNT
+	BuyQuantity       *int             `gorm:"check:buy_quantity > 0"`
+	GetQuantity       *int             `gorm:"check:get_quantity > 0"`
+	StartTime         *string          `gorm:"size:5"` // HH:MM, daily window of HAPPY_HOUR
+	EndTime           *string          `gorm:"size:5"`
+	StartsAt          *time.Time       `gorm:"index" sort:"startsAt"`
+	EndsAt            *time.Time       `gorm:"index" sort:"endsAt"`
+	IsActive          bool             `gorm:"not null;default:true" sort:"isActive"`
+	FranchiseeID      *uint            `gorm:"index"`
+	Franchisee        *Franchisee      `gorm:"foreignKey:FranchiseeID;constraint:OnDelete:CASCADE"`
+	StoreID           *uint            `gorm:"index"`
+	Store             *Store           `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	ProductCategoryID *uint            `gorm:"index"`
+	ProductCategory   *ProductCategory `gorm:"foreignKey:ProductCategoryID;constraint:OnDelete:CASCADE"`
+	ProductSizeID     *uint            `gorm:"index"`
+	ProductSize       *ProductSize     `gorm:"foreignKey:ProductSizeID;constraint:OnDelete:CASCADE"`
+}
+
+// SuborderDiscount keeps a copy of the promotion name and type, so reports stay readable after the promotion changes
+type SuborderDiscount struct {
+	BaseEntity
+	SuborderID    uint          `gorm:"index;not null"`
+	PromotionID   *uint         `gorm:"index"`
+	Promotion     *Promotion    `gorm:"foreignKey:PromotionID;constraint:OnDelete:SET NULL"`
+	PromotionName string        `gorm:"size:255;not null"`
+	Type          PromotionType `gorm:"size:50;not null"`
+	Amount        float64       `gorm:"type:decimal(10,2);not null;check:amount >= 0"`
+}
@@ -0,0 +1,43 @@
+package data
+
+import "time"
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusSent              PurchaseOrderStatus = "SENT"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusCancelled         PurchaseOrderStatus = "CANCELLED"
+)
+
+// PurchaseOrder is an order of stock materials placed by a warehouse to a supplier,
+// the supplier deliveries received against it are linked to it
+type PurchaseOrder struct {
+	BaseEntity
+	WarehouseID          uint                        `gorm:"index;not null"`
+	Warehouse            Warehouse                   `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+	SupplierID           uint                        `gorm:"index;not null"`
+	Supplier             Supplier                    `gorm:"foreignKey:SupplierID;constraint:OnDelete:CASCADE"`
+	Status               PurchaseOrderStatus         `gorm:"type:varchar(30);not null" sort:"status"`
+	Comment              string                      `gorm:"type:text"`
+	ExpectedDeliveryDate *time.Time                  `sort:"expectedDeliveryDate"`
+	Total                float64                     `gorm:"type:decimal(12,2);not null;default:0" sort:"total"`
+	SentAt               *time.Time                  `sort:"sentAt"`
+	ReceivedAt           *time.Time                  `sort:"receivedAt"` // set when the order is fully received or closed with a shortage
+	CancelledAt          *time.Time                  `sort:"cancelledAt"`
+	Lines                []PurchaseOrderLine         `gorm:"foreignKey:PurchaseOrderID;constraint:OnDelete:CASCADE"`
+	Deliveries           []SupplierWarehouseDelivery `gorm:"foreignKey:PurchaseOrderID"`
+}
+
+// PurchaseOrderLine is an ordered stock material, the unit price is taken from the supplier price when the line is created
+type PurchaseOrderLine struct {
+	BaseEntity
+	PurchaseOrderID  uint          `gorm:"index;not null"`
+	StockMaterialID  uint          `gorm:"index;not null"`
+	StockMaterial    StockMaterial `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:CASCADE"`
+	Quantity         float64       `gorm:"type:decimal(10,2);not null;check:quantity > 0"`
+	ReceivedQuantity float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	UnitPrice        float64       `gorm:"type:decimal(10,2);not null"`
+}
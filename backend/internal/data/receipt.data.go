@@ -0,0 +1,56 @@
+package data
+
+import "time"
+
+type ReceiptType string
+
+const (
+	ReceiptTypeSale   ReceiptType = "SALE"
+	ReceiptTypeRefund ReceiptType = "REFUND"
+)
+
+// Receipt is the fiscal receipt issued for a payment or a refund, its number is sequential within a store
+type Receipt struct {
+	BaseEntity
+	StoreID       uint          `gorm:"index;not null"`
+	Store         Store         `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	OrderID       uint          `gorm:"index;not null"`
+	Order         Order         `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	TransactionID uint          `gorm:"uniqueIndex;not null"`
+	Transaction   Transaction   `gorm:"foreignKey:TransactionID;constraint:OnDelete:CASCADE"`
+	Type          ReceiptType   `gorm:"type:varchar(20);not null" sort:"type"`
+	Number        uint          `gorm:"not null" sort:"number"`
+	Subtotal      float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	DiscountTotal float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	DeliveryFee   float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	BonusesPaid   float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	Total         float64       `gorm:"type:decimal(10,2);not null" sort:"total"`
+	TaxAmount     float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	PaymentMethod string        `gorm:"type:varchar(50);not null"`
+	CardMask      *string       `gorm:"type:varchar(16)"`
+	PrintCount    int           `gorm:"not null;default:0"`
+	LastPrintedAt *time.Time    `sort:"lastPrintedAt"`
+	Items         []ReceiptItem `gorm:"foreignKey:ReceiptID;constraint:OnDelete:CASCADE"`
+}
+
+// ReceiptItem is a snapshot of a receipt line, so the receipt stays the same after the menu changes
+type ReceiptItem struct {
+	BaseEntity
+	ReceiptID  uint     `gorm:"index;not null"`
+	SuborderID *uint    `gorm:"index"`
+	Suborder   Suborder `gorm:"foreignKey:SuborderID;constraint:OnDelete:SET NULL"`
+	Name       string   `gorm:"size:255;not null"`
+	Size       string   `gorm:"size:100"`
+	Additives  string   `gorm:"size:1024"`
+	Price      float64  `gorm:"type:decimal(10,2);not null"`
+	Discount   float64  `gorm:"type:decimal(10,2);not null;default:0"`
+	Total      float64  `gorm:"type:decimal(10,2);not null"`
+	TaxRate    float64  `gorm:"type:decimal(5,2);not null;default:0"`
+	TaxAmount  float64  `gorm:"type:decimal(10,2);not null;default:0"`
+}
+
+// ReceiptSequence keeps the last receipt number issued by a store
+type ReceiptSequence struct {
+	StoreID    uint `gorm:"primaryKey"`
+	LastNumber uint `gorm:"not null;default:0"`
+}
@@ -0,0 +1,35 @@
+package data
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type CashShiftStatus string
+
+const (
+	CashShiftStatusOpen   CashShiftStatus = "OPEN"
+	CashShiftStatusClosed CashShiftStatus = "CLOSED"
+)
+
+// PaymentMethodCash is the payment method of the transactions paid in cash at the register
+const PaymentMethodCash = "CASH"
+
+// CashShift is a cash register session of a store employee, the orders created during the shift reference it
+type CashShift struct {
+	BaseEntity
+	StoreID         uint            `gorm:"index;not null"`
+	Store           Store           `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	StoreEmployeeID uint            `gorm:"index;not null"`
+	StoreEmployee   StoreEmployee   `gorm:"foreignKey:StoreEmployeeID;constraint:OnDelete:CASCADE"`
+	Status          CashShiftStatus `gorm:"type:varchar(20);not null" sort:"status"`
+	OpeningFloat    float64         `gorm:"type:decimal(10,2);not null;default:0;check:opening_float >= 0"`
+	CountedCash     *float64        `gorm:"type:decimal(10,2)"`
+	ExpectedCash    *float64        `gorm:"type:decimal(10,2)"`
+	CashVariance    *float64        `gorm:"type:decimal(10,2)"`
+	OpenedAt        time.Time       `gorm:"not null" sort:"openedAt"`
+	ClosedAt        *time.Time      `sort:"closedAt"`
+	Report          datatypes.JSON  `gorm:"type:jsonb"` // the Z report saved on close, the closed shift is reported from it
+	Orders          []Order         `gorm:"foreignKey:CashShiftID"`
+}
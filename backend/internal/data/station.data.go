@@ -0,0 +1,19 @@
+package data
+
+// StoreStation is a preparation station of a store, e.g. a coffee machine or a tea bar.
+// The suborders are routed to the station subscribed to the machine category of their product
+type StoreStation struct {
+	BaseEntity
+	Name       string                 `gorm:"size:255;not null" sort:"name"`
+	StoreID    uint                   `gorm:"index;not null"`
+	Store      Store                  `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	Categories []StoreStationCategory `gorm:"foreignKey:StationID;constraint:OnDelete:CASCADE"`
+}
+
+// StoreStationCategory subscribes the station to a machine category, a category is prepared by one station of the store
+type StoreStationCategory struct {
+	BaseEntity
+	StationID       uint            `gorm:"index;not null"`
+	StoreID         uint            `gorm:"index;not null"`
+	MachineCategory MachineCategory `gorm:"type:varchar(20);not null"`
+}
@@ -0,0 +1,62 @@
+package data
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockMovementSourceType string
+
+const (
+	StockMovementSourceOrder            StockMovementSourceType = "ORDER"
+	StockMovementSourceOrderRefund      StockMovementSourceType = "ORDER_REFUND"
+	StockMovementSourceStoreProvision   StockMovementSourceType = "STORE_PROVISION"
+	StockMovementSourceStockRequest     StockMovementSourceType = "STOCK_REQUEST"
+	StockMovementSourceSupplierDelivery StockMovementSourceType = "SUPPLIER_DELIVERY"
+	StockMovementSourceStocktake        StockMovementSourceType = "STOCKTAKE"
+	StockMovementSourceManual           StockMovementSourceType = "MANUAL"
+)
+
+// StockMovementSource is what changed the stock: the order, the request or the delivery and the employee behind it.
+// It is passed to the places changing the quantities, the manual changes have no source ID
+type StockMovementSource struct {
+	Type       StockMovementSourceType
+	ID         *uint
+	EmployeeID *uint
+}
+
+// StockMovement is an append-only record of a change of the store stock of an ingredient
+// or the warehouse stock of a stock material, exactly one of the pairs is set.
+// The quantities are kept in the units of the stock: ingredient units in stores and packages in warehouses.
+// Rows are never updated or deleted, so it has no BaseEntity timestamps and its references restrict deletes
+type StockMovement struct {
+	ID              uint                    `gorm:"primaryKey;autoIncrement"`
+	StoreID         *uint                   `gorm:"index"`
+	Store           *Store                  `gorm:"foreignKey:StoreID;constraint:OnDelete:RESTRICT"`
+	IngredientID    *uint                   `gorm:"index"`
+	Ingredient      *Ingredient             `gorm:"foreignKey:IngredientID;constraint:OnDelete:RESTRICT"`
+	WarehouseID     *uint                   `gorm:"index"`
+	Warehouse       *Warehouse              `gorm:"foreignKey:WarehouseID;constraint:OnDelete:RESTRICT"`
+	StockMaterialID *uint                   `gorm:"index"`
+	StockMaterial   *StockMaterial          `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:RESTRICT"`
+	SourceType      StockMovementSourceType `gorm:"type:varchar(30);not null" sort:"sourceType"`
+	SourceID        *uint                   `gorm:"index"`
+	QuantityBefore  float64                 `gorm:"type:decimal(10,2);not null"`
+	QuantityAfter   float64                 `gorm:"type:decimal(10,2);not null"`
+	Quantity        float64                 `gorm:"type:decimal(10,2);not null" sort:"quantity"` // negative when the stock went down
+	EmployeeID      *uint                   `gorm:"index"`
+	Employee        *Employee               `gorm:"foreignKey:EmployeeID;constraint:OnDelete:RESTRICT"`
+	CreatedAt       time.Time               `gorm:"autoCreateTime" sort:"createdAt"`
+}
+
+// Hooks for StockMovement
+func (m *StockMovement) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedAt = toUTC(m.CreatedAt)
+	return
+}
+
+func (m *StockMovement) AfterFind(tx *gorm.DB) (err error) {
+	m.CreatedAt = toUTC(m.CreatedAt)
+	return
+}
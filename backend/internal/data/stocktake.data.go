@@ -0,0 +1,79 @@
+package data
+
+import "time"
+
+type StocktakeStatus string
+
+const (
+	StocktakeStatusInProgress StocktakeStatus = "IN_PROGRESS"
+	StocktakeStatusSubmitted  StocktakeStatus = "SUBMITTED"
+	StocktakeStatusApproved   StocktakeStatus = "APPROVED"
+	StocktakeStatusCancelled  StocktakeStatus = "CANCELLED"
+)
+
+type StockAdjustmentReason string
+
+const (
+	StockAdjustmentReasonCountCorrection StockAdjustmentReason = "COUNT_CORRECTION"
+	StockAdjustmentReasonDamaged         StockAdjustmentReason = "DAMAGED"
+	StockAdjustmentReasonExpired         StockAdjustmentReason = "EXPIRED"
+	StockAdjustmentReasonWaste           StockAdjustmentReason = "WASTE"
+	StockAdjustmentReasonTheft           StockAdjustmentReason = "THEFT"
+	StockAdjustmentReasonUnrecorded      StockAdjustmentReason = "UNRECORDED_MOVEMENT"
+)
+
+// Stocktake is a physical count of the stock of a store or a warehouse, exactly one of the facilities is set.
+// The expected quantities are frozen when the stocktake starts and the variances are posted when it is approved
+type Stocktake struct {
+	BaseEntity
+	StoreID      *uint           `gorm:"index"`
+	Store        *Store          `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	WarehouseID  *uint           `gorm:"index"`
+	Warehouse    *Warehouse      `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+	Status       StocktakeStatus `gorm:"type:varchar(30);not null" sort:"status"`
+	Comment      string          `gorm:"type:text"`
+	StartedByID  uint            `gorm:"index;not null"`
+	StartedBy    Employee        `gorm:"foreignKey:StartedByID;constraint:OnDelete:CASCADE"`
+	ApprovedByID *uint           `gorm:"index"`
+	ApprovedBy   *Employee       `gorm:"foreignKey:ApprovedByID;constraint:OnDelete:SET NULL"`
+	SubmittedAt  *time.Time      `sort:"submittedAt"`
+	ApprovedAt   *time.Time      `sort:"approvedAt"`
+	CancelledAt  *time.Time      `sort:"cancelledAt"`
+	Lines        []StocktakeLine `gorm:"foreignKey:StocktakeID;constraint:OnDelete:CASCADE"`
+}
+
+// StocktakeLine is a counted item, the ingredient of the store stock or the stock material of the warehouse stock.
+// The quantities are kept in the units of the stock: ingredient units in stores and packages in warehouses
+type StocktakeLine struct {
+	BaseEntity
+	StocktakeID      uint                   `gorm:"index;not null"`
+	IngredientID     *uint                  `gorm:"index"`
+	Ingredient       *Ingredient            `gorm:"foreignKey:IngredientID;constraint:OnDelete:CASCADE"`
+	StockMaterialID  *uint                  `gorm:"index"`
+	StockMaterial    *StockMaterial         `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:CASCADE"`
+	ExpectedQuantity float64                `gorm:"type:decimal(10,2);not null"`
+	CountedQuantity  *float64               `gorm:"type:decimal(10,2)"` // nil until the item is counted
+	CountedByID      *uint                  `gorm:"index"`
+	CountedBy        *Employee              `gorm:"foreignKey:CountedByID;constraint:OnDelete:SET NULL"`
+	Reason           *StockAdjustmentReason `gorm:"type:varchar(30)"` // set by the manager on review
+	Comment          string                 `gorm:"type:text"`
+	CountedAt        *time.Time
+}
+
+// StockAdjustment is a correction of a stock quantity made outside of the orders, requests and deliveries,
+// the quantity is negative for a shortage. The adjustments of an approved stocktake are linked to it
+type StockAdjustment struct {
+	BaseEntity
+	StoreID         *uint                 `gorm:"index"`
+	WarehouseID     *uint                 `gorm:"index"`
+	IngredientID    *uint                 `gorm:"index"`
+	Ingredient      *Ingredient           `gorm:"foreignKey:IngredientID;constraint:OnDelete:CASCADE"`
+	StockMaterialID *uint                 `gorm:"index"`
+	StockMaterial   *StockMaterial        `gorm:"foreignKey:StockMaterialID;constraint:OnDelete:CASCADE"`
+	StocktakeID     *uint                 `gorm:"index"`
+	Quantity        float64               `gorm:"type:decimal(10,2);not null"`
+	Reason          StockAdjustmentReason `gorm:"type:varchar(30);not null"`
+	Comment         string                `gorm:"type:text"`
+	EmployeeID      uint                  `gorm:"index;not null"`
+	Employee        Employee              `gorm:"foreignKey:EmployeeID;constraint:OnDelete:CASCADE"`
+}
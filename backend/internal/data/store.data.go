@@ -39,21 +39,44 @@ type Franchisee struct {
 
 type Store struct {
 	BaseEntity
-	Name                string          `gorm:"size:255;not null" sort:"name"`
-	FacilityAddressID   uint            `gorm:"index;not null"`
-	FacilityAddress     FacilityAddress `gorm:"foreignKey:FacilityAddressID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	FranchiseeID        *uint           `gorm:"index"`
-	Franchisee          *Franchisee     `gorm:"foreignKey:FranchiseeID" sort:"franchisees"`
-	WarehouseID         uint            `gorm:"not null;index"` // New Warehouse Reference
-	Warehouse           Warehouse       `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
-	IsActive            bool            `gorm:"default:true" sort:"isActive"`
-	ContactPhone        string          `gorm:"size:16"`
-	ContactEmail        string          `gorm:"size:255"`
-	StoreHours          string          `gorm:"size:255"`
-	Additives           []StoreAdditive `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
-	Products            []StoreProduct  `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
-	Stocks              []StoreStock    `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"` // Linked Store Stocks
-	LastInventorySyncAt time.Time       `gorm:"autoCreateTime" sort:"lastInventorySyncAt"`
+	Name                string                `gorm:"size:255;not null" sort:"name"`
+	FacilityAddressID   uint                  `gorm:"index;not null"`
+	FacilityAddress     FacilityAddress       `gorm:"foreignKey:FacilityAddressID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	FranchiseeID        *uint                 `gorm:"index"`
+	Franchisee          *Franchisee           `gorm:"foreignKey:FranchiseeID" sort:"franchisees"`
+	WarehouseID         uint                  `gorm:"not null;index"` // New Warehouse Reference
+	Warehouse           Warehouse             `gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+	IsActive            bool                  `gorm:"default:true" sort:"isActive"`
+	ContactPhone        string                `gorm:"size:16"`
+	ContactEmail        string                `gorm:"size:255"`
+	Timezone            string                `gorm:"size:64;not null;default:'UTC'"` // IANA name, the store hours are local to it
+	WorkingHours        []StoreWorkingHours   `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	HoursExceptions     []StoreHoursException `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	PickupSlotCapacity  int                   `gorm:"not null;default:10"` // customer orders accepted per pickup slot
+	Additives           []StoreAdditive       `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	Products            []StoreProduct        `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+	Stocks              []StoreStock          `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"` // Linked Store Stocks
+	LastInventorySyncAt time.Time             `gorm:"autoCreateTime" sort:"lastInventorySyncAt"`
+}
+
+// StoreWorkingHours is the weekly schedule of a store, a weekday without hours is a day off
+type StoreWorkingHours struct {
+	BaseEntity
+	StoreID uint    `gorm:"index;not null"`
+	Day     Weekday `gorm:"size:15;not null" sort:"day"`
+	OpenAt  string  `gorm:"type:time;not null"`
+	CloseAt string  `gorm:"type:time;not null"` // not after OpenAt when the store closes after the midnight
+}
+
+// StoreHoursException overrides the weekly schedule on a date, e.g. a holiday or an early closing
+type StoreHoursException struct {
+	BaseEntity
+	StoreID  uint      `gorm:"index;not null"`
+	Date     time.Time `gorm:"type:date;not null" sort:"date"`
+	IsClosed bool      `gorm:"not null;default:false"`
+	OpenAt   *string   `gorm:"type:time"`
+	CloseAt  *string   `gorm:"type:time"`
+	Reason   string    `gorm:"size:255"`
 }
 
 type StoreStock struct {
@@ -0,0 +1,34 @@
+package data
+
+type TaxMode string
+
+const (
+	// TaxModeInclusive means the store prices already include the tax
+	TaxModeInclusive TaxMode = "INCLUSIVE"
+	// TaxModeExclusive means the tax is added on top of the store prices
+	TaxModeExclusive TaxMode = "EXCLUSIVE"
+)
+
+func IsValidTaxMode(mode TaxMode) bool {
+	switch mode {
+	case TaxModeInclusive, TaxModeExclusive:
+		return true
+	}
+	return false
+}
+
+// TaxRate is applied to the suborders matching its scope. A rate is set either for a region or for a franchisee,
+// without both it is global; ProductCategoryID limits it to a single category. The most specific rate wins.
+type TaxRate struct {
+	BaseEntity
+	Name              string           `gorm:"size:255;not null" sort:"name"`
+	Rate              float64          `gorm:"type:decimal(5,2);not null;check:rate >= 0 AND rate <= 100" sort:"rate"` // percent
+	Mode              TaxMode          `gorm:"size:20;not null" sort:"mode"`
+	IsActive          bool             `gorm:"not null;default:true" sort:"isActive"`
+	RegionID          *uint            `gorm:"index"`
+	Region            *Region          `gorm:"foreignKey:RegionID;constraint:OnDelete:CASCADE"`
+	FranchiseeID      *uint            `gorm:"index"`
+	Franchisee        *Franchisee      `gorm:"foreignKey:FranchiseeID;constraint:OnDelete:CASCADE"`
+	ProductCategoryID *uint            `gorm:"index"`
+	ProductCategory   *ProductCategory `gorm:"foreignKey:ProductCategoryID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,14 @@
+package data
+
+import "time"
+
+// StoreTerminal is a device shared by the employees of a store. It is authenticated by a long-lived device token,
+// only the hash of which is stored, and the employees switch the active operator on it with their PINs
+type StoreTerminal struct {
+	BaseEntity
+	Name       string     `gorm:"size:255;not null" sort:"name"`
+	TokenHash  string     `gorm:"size:64;not null;uniqueIndex"`
+	LastUsedAt *time.Time `gorm:"type:timestamp" sort:"lastUsedAt"`
+	StoreID    uint       `gorm:"index;not null"`
+	Store      Store      `gorm:"foreignKey:StoreID;constraint:OnDelete:CASCADE"`
+}
@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
 	authTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
+	customersTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/customers/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils/logger"
 	"github.com/gin-gonic/gin"
@@ -16,7 +19,7 @@ func EmployeeAuth(employeeTokenManager employeeToken.EmployeeTokenManager) gin.H
 	return func(c *gin.Context) {
 		zapLogger := logger.GetZapSugaredLogger()
 
-		claims, token, err := authTypes.ExtractEmployeeSessionTokenAndValidate(c)
+		claims, _, err := authTypes.ExtractEmployeeSessionTokenAndValidate(c)
 		if err != nil {
 			zapLogger.Warn("missing or invalid token")
 			utils.SendErrorWithStatus(c, "missing or invalid token", http.StatusUnauthorized)
@@ -24,49 +27,56 @@ func EmployeeAuth(employeeTokenManager employeeToken.EmployeeTokenManager) gin.H
 			return
 		}
 
-		savedToken, err := employeeTokenManager.GetTokenByEmployeeID(claims.EmployeeID)
+		if claims.ExpiresAt == nil || claims.ExpiresAt.Before(time.Now()) {
+			utils.SendErrorWithStatus(c, "token expired", http.StatusUnauthorized)
+			c.Abort()
+			return
+		}
+
+		session, err := employeeTokenManager.GetTokenByID(claims.SessionID)
 		if err != nil {
-			zapLogger.Error("error getting token from db")
-			utils.SendErrorWithStatus(c, "error getting token from db", http.StatusInternalServerError)
+			zapLogger.Error("error getting session from db")
+			utils.SendErrorWithStatus(c, "error getting session from db", http.StatusInternalServerError)
 			c.Abort()
 			return
 		}
 
-		if savedToken == nil {
-			zapLogger.Warn("token not found")
-			utils.SendErrorWithStatus(c, "token not found, re-login", http.StatusUnauthorized)
+		if session == nil {
+			zapLogger.Warn("session not found")
+			utils.SendErrorWithStatus(c, "session not found, re-login", http.StatusUnauthorized)
 			c.Abort()
 			return
 		}
 
-		if savedToken.Token != token {
-			zapLogger.Warn("token mismatch, re login")
-			utils.SendErrorWithStatus(c, "token mismatch", http.StatusUnauthorized)
+		if session.EmployeeID != claims.EmployeeID {
+			zapLogger.Warn("session mismatch, re login")
+			utils.SendErrorWithStatus(c, "session mismatch", http.StatusUnauthorized)
 			c.Abort()
 			return
 		}
 
-		if savedToken.ExpiresAt.Before(time.Now()) {
-			zapLogger.Warn("token expired")
-			utils.SendErrorWithStatus(c, "token expired", http.StatusUnauthorized)
+		if session.ExpiresAt.Before(time.Now()) {
+			zapLogger.Warn("session expired")
+			utils.SendErrorWithStatus(c, "session expired", http.StatusUnauthorized)
 			c.Abort()
 			return
 		}
 
-		employeeSessionData, err := authTypes.MapEmployeeToEmployeeSessionData(&savedToken.Employee)
+		employeeSessionData, err := authTypes.MapEmployeeToEmployeeSessionData(&session.Employee)
 		if err != nil {
 			zapLogger.Error("error mapping employee to employee session data")
 			utils.SendErrorWithStatus(c, "error mapping employee to employee session data", http.StatusInternalServerError)
 			c.Abort()
 			return
 		}
+		employeeSessionData.SessionID = session.ID
 
 		contexts.SetEmployeeCtx(c, employeeSessionData)
 		c.Next()
 	}
 }
 
-func CustomerAuth() gin.HandlerFunc {
+func CustomerAuth(customerRepo customers.CustomerRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		zapLogger := logger.GetZapSugaredLogger()
 
@@ -78,6 +88,20 @@ func CustomerAuth() gin.HandlerFunc {
 			return
 		}
 
+		// the token outlives the account, a deleted customer must not use it
+		if _, err := customerRepo.GetCustomerByID(claims.CustomerID); err != nil {
+			if errors.Is(err, customersTypes.ErrCustomerNotFound) {
+				zapLogger.Warn("customer not found")
+				utils.SendErrorWithStatus(c, "customer not found, re-login", http.StatusUnauthorized)
+				c.Abort()
+				return
+			}
+			zapLogger.Error("error getting customer from db")
+			utils.SendErrorWithStatus(c, "error getting customer from db", http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
 		contexts.SetCustomerCtx(c, claims)
 		c.Next()
 	}
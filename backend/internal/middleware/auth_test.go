@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
+	authTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "middleware-test")
+	if err != nil {
+		log.Fatal(err)
+	}
+	envPath := filepath.Join(dir, "test.env")
+	env := "JWT_EMPLOYEE_SECRET_KEY=test-secret\nJWT_EMPLOYEE_TOKEN_TTL=15m\n"
+	if err := os.WriteFile(envPath, []byte(env), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := config.LoadTestConfig(envPath); err != nil {
+		log.Fatal(err)
+	}
+	if err := logger.InitLogger(logger.ErrorLevelStr, filepath.Join(dir, "test.log"), false); err != nil {
+		log.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeEmployeeTokenManager finds the sessions in memory, a revoked session is the one missing from the map
+type fakeEmployeeTokenManager struct {
+	employeeToken.EmployeeTokenManager
+	sessions map[uint]*data.EmployeeToken
+}
+
+func (f *fakeEmployeeTokenManager) GetTokenByID(tokenID uint) (*data.EmployeeToken, error) {
+	return f.sessions[tokenID], nil
+}
+
+func TestEmployeeAuth(t *testing.T) {
+	tokenManager := &fakeEmployeeTokenManager{
+		sessions: map[uint]*data.EmployeeToken{
+			1: {
+				BaseEntity: data.BaseEntity{ID: 1},
+				EmployeeID: 7,
+				ExpiresAt:  time.Now().Add(time.Hour),
+				Employee: data.Employee{
+					BaseEntity:    data.BaseEntity{ID: 7},
+					AdminEmployee: &data.AdminEmployee{Role: data.RoleAdmin},
+				},
+			},
+		},
+	}
+
+	router := gin.New()
+	router.GET("/protected", EmployeeAuth(tokenManager), func(c *gin.Context) {
+		claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"sessionId": claims.SessionID})
+	})
+
+	request := func(sessionID uint) *httptest.ResponseRecorder {
+		sessionToken, err := authTypes.GenerateEmployeeJWT(7, sessionID)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.AddCookie(&http.Cookie{Name: authTypes.EMPLOYEE_SESSION_COOKIE_KEY, Value: sessionToken})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Active session should be accepted", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(1).Code)
+	})
+
+	t.Run("Revoked session should be rejected while its access token is valid", func(t *testing.T) {
+		delete(tokenManager.sessions, 1)
+
+		assert.Equal(t, http.StatusUnauthorized, request(1).Code)
+	})
+
+	t.Run("Request without a token should be rejected", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/protected", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -63,3 +63,19 @@ func (h *AnalyticsHandler) GetPopularProducts(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, data)
 }
+
+func (h *AnalyticsHandler) GetDiscountsByPromotion(c *gin.Context) {
+	var filter types.AnalyticsFilterQuery
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.SendBadRequestError(c, "Invalid filter parameters")
+		return
+	}
+
+	data, err := h.service.GetDiscountsByPromotion(&filter.StartDate, &filter.EndDate, &filter.StoreID)
+	if err != nil {
+		utils.SendInternalServerError(c, "Failed to fetch discounts by promotion")
+		return
+	}
+
+	utils.SendSuccessResponse(c, data)
+}
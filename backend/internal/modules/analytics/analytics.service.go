@@ -12,6 +12,7 @@ type AnalyticsService interface {
 	GetSalesByMonth(startDate, endDate *time.Time, storeID *uint) ([]types.MonthlySalesDTO, error)
 	GetPopularProducts(startDate, endDate *time.Time, storeID *uint) ([]types.PopularProductDTO, error)
 	GetProductsSold(startDate, endDate *time.Time, storeID *uint) ([]types.ProductSoldDTO, error)
+	GetDiscountsByPromotion(startDate, endDate *time.Time, storeID *uint) ([]types.PromotionDiscountDTO, error)
 }
 
 type analyticsService struct {
@@ -27,7 +28,7 @@ func NewAnalyticsService(repo AnalyticsRepo, logger *zap.SugaredLogger) Analytic
 }
 
 func (s *analyticsService) GetSummary(startDate, endDate *time.Time, storeID *uint) (types.SummaryDTO, error) {
-	totalSales, totalOrders, totalProductsSold, totalAdditivesSold, err := s.repo.GetOrdersForSummary(startDate, endDate, storeID)
+	totalSales, totalDiscounts, totalTax, totalOrders, totalProductsSold, totalAdditivesSold, err := s.repo.GetOrdersForSummary(startDate, endDate, storeID)
 	if err != nil {
 		return types.SummaryDTO{}, err
 	}
@@ -35,12 +36,12 @@ func (s *analyticsService) GetSummary(startDate, endDate *time.Time, storeID *ui
 	previousMonthStart := startDate.AddDate(0, -1, 0)
 	previousMonthEnd := endDate.AddDate(0, -1, 0)
 
-	prevMonthSales, prevMonthOrders, _, _, err := s.repo.GetOrdersForSummary(&previousMonthStart, &previousMonthEnd, storeID)
+	prevMonthSales, _, _, prevMonthOrders, _, _, err := s.repo.GetOrdersForSummary(&previousMonthStart, &previousMonthEnd, storeID)
 	if err != nil {
 		return types.SummaryDTO{}, err
 	}
 
-	return types.ToSummaryDTO(totalSales, totalOrders, totalProductsSold, totalAdditivesSold, prevMonthSales, prevMonthOrders), nil
+	return types.ToSummaryDTO(totalSales, totalDiscounts, totalTax, totalOrders, totalProductsSold, totalAdditivesSold, prevMonthSales, prevMonthOrders), nil
 }
 
 func (s *analyticsService) GetSalesByMonth(startDate, endDate *time.Time, storeID *uint) ([]types.MonthlySalesDTO, error) {
@@ -85,3 +86,16 @@ func (s *analyticsService) GetProductsSold(startDate, endDate *time.Time, storeI
 	}
 	return result, nil
 }
+
+func (s *analyticsService) GetDiscountsByPromotion(startDate, endDate *time.Time, storeID *uint) ([]types.PromotionDiscountDTO, error) {
+	data, err := s.repo.GetDiscountsByPromotion(startDate, endDate, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []types.PromotionDiscountDTO{}
+	for _, item := range data {
+		result = append(result, types.ToPromotionDiscountDTO(item.PromotionName, item.Type, item.TimesApplied, item.TotalDiscounts))
+	}
+	return result, nil
+}
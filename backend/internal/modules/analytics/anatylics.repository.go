@@ -8,10 +8,11 @@ import (
 )
 
 type AnalyticsRepo interface {
-	GetOrdersForSummary(startDate, endDate *time.Time, storeID *uint) (totalSales float64, totalOrders, totalProductsSold, totalAdditivesSold int, err error)
+	GetOrdersForSummary(startDate, endDate *time.Time, storeID *uint) (totalSales, totalDiscounts, totalTax float64, totalOrders, totalProductsSold, totalAdditivesSold int, err error)
 	GetOrdersForMonthlySales(startDate, endDate *time.Time, storeID *uint) ([]MonthlySalesData, error)
 	GetPopularProducts(startDate, endDate *time.Time, storeID *uint) ([]PopularProductData, error)
 	GetProductsSold(startDate, endDate *time.Time, storeID *uint) ([]ProductSoldData, error)
+	GetDiscountsByPromotion(startDate, endDate *time.Time, storeID *uint) ([]PromotionDiscountData, error)
 }
 
 type analyticsRepo struct {
@@ -48,17 +49,28 @@ type AdditiveSoldData struct {
 	Revenue  float64 `json:"revenue"`
 }
 
+type PromotionDiscountData struct {
+	PromotionName  string  `json:"promotionName"`
+	Type           string  `json:"type"`
+	TimesApplied   int     `json:"timesApplied"`
+	TotalDiscounts float64 `json:"totalDiscounts"`
+}
+
 func completedOrDeliveredScope(db *gorm.DB) *gorm.DB {
 	return db.Model(&models.Order{}).Where("status IN ?", []models.OrderStatus{models.OrderStatusCompleted, models.OrderStatusDelivered})
 }
 
+// localCreatedAtSQL is the creation time of the order in the timezone of its store, the days and months are bucketed by it
+const localCreatedAtSQL = "(orders.created_at AT TIME ZONE (SELECT stores.timezone FROM stores WHERE stores.id = orders.store_id))"
+
+// dateRangeScope takes the dates as the local dates of the stores, both ends are included
 func dateRangeScope(startDate, endDate *time.Time) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		if startDate != nil {
-			db = db.Where("created_at >= ?", startDate)
+			db = db.Where(localCreatedAtSQL+" >= ?::date", startDate.Format(time.DateOnly))
 		}
 		if endDate != nil {
-			db = db.Where("created_at <= ?", endDate)
+			db = db.Where(localCreatedAtSQL+" < ?::date + 1", endDate.Format(time.DateOnly))
 		}
 		return db
 	}
@@ -67,16 +79,18 @@ func dateRangeScope(startDate, endDate *time.Time) func(db *gorm.DB) *gorm.DB {
 func storeScope(storeID *uint) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		if storeID != nil {
-			db = db.Where("store_id = ?", storeID)
+			db = db.Where("orders.store_id = ?", storeID)
 		}
 		return db
 	}
 }
 
-func (r *analyticsRepo) GetOrdersForSummary(startDate, endDate *time.Time, storeID *uint) (float64, int, int, int, error) {
+func (r *analyticsRepo) GetOrdersForSummary(startDate, endDate *time.Time, storeID *uint) (float64, float64, float64, int, int, int, error) {
 	var order models.Order
 	var result struct {
 		TotalSales         float64
+		TotalDiscounts     float64
+		TotalTax           float64
 		TotalOrders        int64
 		TotalProductsSold  int64
 		TotalAdditivesSold int64
@@ -90,6 +104,8 @@ func (r *analyticsRepo) GetOrdersForSummary(startDate, endDate *time.Time, store
 		).
 		Select(`
             COALESCE(SUM(total), 0) as total_sales,
+            COALESCE(SUM(discount_total), 0) as total_discounts,
+            COALESCE(SUM(tax_total), 0) as total_tax,
             COUNT(*) as total_orders,
             (SELECT COUNT(*) FROM suborders WHERE order_id IN (SELECT id FROM orders)) as total_products_sold,
             (SELECT COUNT(*) FROM suborder_additives WHERE suborder_id IN 
@@ -97,7 +113,7 @@ func (r *analyticsRepo) GetOrdersForSummary(startDate, endDate *time.Time, store
         `).
 		Scan(&result).Error
 
-	return result.TotalSales, int(result.TotalOrders), int(result.TotalProductsSold), int(result.TotalAdditivesSold), err
+	return result.TotalSales, result.TotalDiscounts, result.TotalTax, int(result.TotalOrders), int(result.TotalProductsSold), int(result.TotalAdditivesSold), err
 }
 
 func (r *analyticsRepo) GetPopularProducts(startDate, endDate *time.Time, storeID *uint) ([]PopularProductData, error) {
@@ -186,14 +202,41 @@ func (r *analyticsRepo) GetOrdersForMonthlySales(startDate, endDate *time.Time,
 			storeScope(storeID),
 		).
 		Select(`
-            TO_CHAR(created_at, 'Month') as month,
-            EXTRACT(YEAR FROM created_at) as year,
+            TO_CHAR(` + localCreatedAtSQL + `, 'Month') as month,
+            EXTRACT(YEAR FROM ` + localCreatedAtSQL + `) as year,
             COUNT(*) as orders,
             SUM(total) as sales
         `).
-		Group("TO_CHAR(created_at, 'Month'), EXTRACT(YEAR FROM created_at)").
+		Group("TO_CHAR(" + localCreatedAtSQL + ", 'Month'), EXTRACT(YEAR FROM " + localCreatedAtSQL + ")").
 		Order("year, month").
 		Scan(&results).Error
 
 	return results, err
 }
+
+func (r *analyticsRepo) GetDiscountsByPromotion(startDate, endDate *time.Time, storeID *uint) ([]PromotionDiscountData, error) {
+	var results []PromotionDiscountData
+
+	ordersQuery := r.db.Model(&models.Order{}).
+		Scopes(
+			completedOrDeliveredScope,
+			dateRangeScope(startDate, endDate),
+			storeScope(storeID),
+		).
+		Select("id")
+
+	err := r.db.Model(&models.SuborderDiscount{}).
+		Joins("JOIN suborders ON suborders.id = suborder_discounts.suborder_id").
+		Where("suborders.order_id IN (?)", ordersQuery).
+		Group("suborder_discounts.promotion_name, suborder_discounts.type").
+		Select(`
+			suborder_discounts.promotion_name AS promotion_name,
+			suborder_discounts.type AS type,
+			COUNT(suborder_discounts.id) AS times_applied,
+			COALESCE(SUM(suborder_discounts.amount), 0) AS total_discounts
+		`).
+		Order("total_discounts DESC").
+		Scan(&results).Error
+
+	return results, err
+}
@@ -6,6 +6,8 @@ import (
 
 type SummaryDTO struct {
 	TotalSales          float64 `json:"totalSales"`
+	TotalDiscounts      float64 `json:"totalDiscounts"`
+	TotalTax            float64 `json:"totalTax"`
 	TotalOrders         int     `json:"totalOrders"`
 	TotalProductsSold   int     `json:"totalProductsSold"`
 	TotalAdditivesSold  int     `json:"totalAdditivesSold"`
@@ -41,6 +43,13 @@ type AdditiveSoldDTO struct {
 	Revenue      float64 `json:"revenue"`
 }
 
+type PromotionDiscountDTO struct {
+	PromotionName  string  `json:"promotionName"`
+	Type           string  `json:"type"`
+	TimesApplied   int     `json:"timesApplied"`
+	TotalDiscounts float64 `json:"totalDiscounts"`
+}
+
 type AnalyticsFilterQuery struct {
 	StartDate time.Time `form:"startDate" binding:"required" time_format:"2006-01-02"`
 	EndDate   time.Time `form:"endDate" binding:"required" time_format:"2006-01-02"`
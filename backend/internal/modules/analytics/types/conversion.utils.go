@@ -1,6 +1,6 @@
 package types
 
-func ToSummaryDTO(totalSales float64, totalOrders, totalProductsSold, totalAdditivesSold int, previousMonthSales float64, previousMonthOrders int) SummaryDTO {
+func ToSummaryDTO(totalSales, totalDiscounts, totalTax float64, totalOrders, totalProductsSold, totalAdditivesSold int, previousMonthSales float64, previousMonthOrders int) SummaryDTO {
 	salesComparison := 0.0
 	if previousMonthSales > 0 {
 		salesComparison = ((totalSales - previousMonthSales) / previousMonthSales) * 100
@@ -13,6 +13,8 @@ func ToSummaryDTO(totalSales float64, totalOrders, totalProductsSold, totalAddit
 
 	return SummaryDTO{
 		TotalSales:          totalSales,
+		TotalDiscounts:      totalDiscounts,
+		TotalTax:            totalTax,
 		TotalOrders:         totalOrders,
 		TotalProductsSold:   totalProductsSold,
 		TotalAdditivesSold:  totalAdditivesSold,
@@ -56,3 +58,12 @@ func ToAdditiveSoldDTO(additiveName string, quantity int, revenue float64) Addit
 		Revenue:      revenue,
 	}
 }
+
+func ToPromotionDiscountDTO(promotionName, promotionType string, timesApplied int, totalDiscounts float64) PromotionDiscountDTO {
+	return PromotionDiscountDTO{
+		PromotionName:  promotionName,
+		Type:           promotionType,
+		TimesApplied:   timesApplied,
+		TotalDiscounts: totalDiscounts,
+	}
+}
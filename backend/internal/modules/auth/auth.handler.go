@@ -10,10 +10,13 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	storeTerminalsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
+const maxUserAgentLength = 512
+
 type AuthenticationHandler struct {
 	service AuthenticationService
 }
@@ -77,6 +80,85 @@ func (h *AuthenticationHandler) CustomerLogin(c *gin.Context) {
 	})
 }
 
+func (h *AuthenticationHandler) SendCustomerVerificationCode(c *gin.Context) {
+	var input types.CustomerPhoneDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SendCustomerVerificationCode(input.Phone); err != nil {
+		sendVerificationError(c, err)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200VerificationSent)
+}
+
+func (h *AuthenticationHandler) VerifyCustomerPhone(c *gin.Context) {
+	var input types.VerifyCustomerPhoneDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.VerifyCustomerPhone(&input); err != nil {
+		sendVerificationError(c, err)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200PhoneVerified)
+}
+
+func (h *AuthenticationHandler) SendCustomerPasswordResetCode(c *gin.Context) {
+	var input types.CustomerPhoneDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SendCustomerPasswordResetCode(input.Phone); err != nil {
+		sendVerificationError(c, err)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200VerificationSent)
+}
+
+func (h *AuthenticationHandler) ResetCustomerPassword(c *gin.Context) {
+	var input types.ResetCustomerPasswordDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ResetCustomerPassword(&input); err != nil {
+		sendVerificationError(c, err)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200PasswordReset)
+}
+
+func sendVerificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, moduleErrors.ErrValidation):
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+	case errors.Is(err, types.ErrInvalidVerificationCode):
+		localization.SendLocalizedResponseWithKey(c, types.Response400VerificationCode)
+	case errors.Is(err, types.ErrBannedCustomer):
+		localization.SendLocalizedResponseWithKey(c, types.Response403BannedCustomer)
+	case errors.Is(err, types.ErrVerificationCooldown):
+		localization.SendLocalizedResponseWithKey(c, types.Response429VerificationCooldown)
+	case errors.Is(err, types.ErrVerificationLimit):
+		localization.SendLocalizedResponseWithKey(c, types.Response429VerificationLimit)
+	case errors.Is(err, types.ErrVerificationAttempts):
+		localization.SendLocalizedResponseWithKey(c, types.Response429VerificationAttempts)
+	default:
+		localization.SendLocalizedResponseWithStatus(c, http.StatusInternalServerError)
+	}
+}
+
 func (h *AuthenticationHandler) CustomerLogout(c *gin.Context) {
 	utils.ClearCookie(c, types.CUSTOMER_SESSION_COOKIE_KEY)
 
@@ -91,11 +173,57 @@ func (h *AuthenticationHandler) EmployeeLogin(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.EmployeeLogin(input.Email, input.Password)
+	device := getDeviceInfo(c)
+	device.Name = input.DeviceName
+
+	token, err := h.service.EmployeeLogin(&input, device)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrInvalidCredentials):
+			localization.SendLocalizedResponseWithKey(c, types.Response400IncorrectCredentials)
+		case errors.Is(err, types.ErrInactiveEmployee):
+			utils.SendErrorWithStatus(c, "inactive employee", http.StatusForbidden)
+		default:
+			utils.SendErrorWithStatus(c, "unexpected error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendEmployeeToken(c, token, "login successful")
+}
+
+func (h *AuthenticationHandler) GetTerminal(c *gin.Context) {
+	terminal, err := h.service.GetTerminal(getTerminalToken(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrInvalidTerminalToken):
+			localization.SendLocalizedResponseWithKey(c, types.Response401Terminal)
+		default:
+			utils.SendErrorWithStatus(c, "unexpected error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, terminal)
+}
+
+// EmployeePinLogin switches the active operator of the store terminal the request comes from
+func (h *AuthenticationHandler) EmployeePinLogin(c *gin.Context) {
+	var input types.EmployeePinLoginDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.SendBadRequestError(c, err.Error())
+		return
+	}
+
+	token, err := h.service.EmployeePinLogin(getTerminalToken(c), &input, getDeviceInfo(c))
 	if err != nil {
 		switch {
+		case errors.Is(err, types.ErrInvalidTerminalToken):
+			localization.SendLocalizedResponseWithKey(c, types.Response401Terminal)
 		case errors.Is(err, types.ErrInvalidCredentials):
 			localization.SendLocalizedResponseWithKey(c, types.Response400IncorrectCredentials)
+		case errors.Is(err, types.ErrPinLocked):
+			localization.SendLocalizedResponseWithKey(c, types.Response429PinLocked)
 		case errors.Is(err, types.ErrInactiveEmployee):
 			utils.SendErrorWithStatus(c, "inactive employee", http.StatusForbidden)
 		default:
@@ -104,6 +232,54 @@ func (h *AuthenticationHandler) EmployeeLogin(c *gin.Context) {
 		return
 	}
 
+	sendEmployeeToken(c, token, "operator switched")
+}
+
+func (h *AuthenticationHandler) RefreshEmployeeToken(c *gin.Context) {
+	var input types.EmployeeRefreshDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.SendBadRequestError(c, err.Error())
+			return
+		}
+	}
+
+	refreshToken := input.RefreshToken
+	if refreshToken == "" {
+		refreshToken, _ = types.ExtractToken(c, types.EMPLOYEE_REFRESH_COOKIE_KEY)
+	}
+
+	token, err := h.service.RefreshEmployeeToken(refreshToken, getDeviceInfo(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrInvalidRefreshToken), errors.Is(err, types.ErrRefreshTokenReused):
+			clearEmployeeCookies(c)
+			localization.SendLocalizedResponseWithKey(c, types.Response401Refresh)
+		default:
+			utils.SendErrorWithStatus(c, "unexpected error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	sendEmployeeToken(c, token, "token refreshed")
+}
+
+func (h *AuthenticationHandler) EmployeeLogout(c *gin.Context) {
+	sessionToken, _ := types.ExtractToken(c, types.EMPLOYEE_SESSION_COOKIE_KEY)
+	refreshToken, _ := types.ExtractToken(c, types.EMPLOYEE_REFRESH_COOKIE_KEY)
+
+	if err := h.service.EmployeeLogout(sessionToken, refreshToken); err != nil {
+		utils.SendErrorWithStatus(c, "unexpected error", http.StatusInternalServerError)
+		return
+	}
+
+	c.Set(contexts.EMPLOYEE_CONTEXT, nil)
+
+	clearEmployeeCookies(c)
+	utils.SendSuccessResponse(c, gin.H{"message": "logout successful"})
+}
+
+func sendEmployeeToken(c *gin.Context, token *types.Token, message string) {
 	var claims types.EmployeeClaims
 	if err := types.ValidateEmployeeToken(token.SessionToken, &claims); err != nil {
 		utils.SendInternalServerError(c, "failed to validate token")
@@ -113,18 +289,39 @@ func (h *AuthenticationHandler) EmployeeLogin(c *gin.Context) {
 	cfg := config.GetConfig()
 
 	utils.SetCookie(c, types.EMPLOYEE_SESSION_COOKIE_KEY, token.SessionToken, cfg.JWT.EmployeeTokenTTL)
+	utils.SetCookie(c, types.EMPLOYEE_REFRESH_COOKIE_KEY, token.RefreshToken, cfg.JWT.EmployeeRefreshTokenTTL)
 
 	utils.SendSuccessResponse(c, gin.H{
-		"message": "login successful",
+		"message": message,
 		"data": gin.H{
 			"sessionToken": token.SessionToken,
+			"refreshToken": token.RefreshToken,
 		},
 	})
 }
 
-func (h *AuthenticationHandler) EmployeeLogout(c *gin.Context) {
-	c.Set(contexts.EMPLOYEE_CONTEXT, nil)
-
+func clearEmployeeCookies(c *gin.Context) {
 	utils.ClearCookie(c, types.EMPLOYEE_SESSION_COOKIE_KEY)
-	utils.SendSuccessResponse(c, gin.H{"message": "logout successful"})
+	utils.ClearCookie(c, types.EMPLOYEE_REFRESH_COOKIE_KEY)
+}
+
+func getTerminalToken(c *gin.Context) string {
+	if token := c.GetHeader(storeTerminalsTypes.TERMINAL_TOKEN_HEADER); token != "" {
+		return token
+	}
+
+	token, _ := utils.GetCookie(c, storeTerminalsTypes.TERMINAL_TOKEN_COOKIE_KEY)
+	return token
+}
+
+func getDeviceInfo(c *gin.Context) *types.DeviceInfo {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return &types.DeviceInfo{
+		UserAgent: userAgent,
+		IPAddress: c.ClientIP(),
+	}
 }
@@ -1,14 +1,24 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AuthenticationRepository interface {
 	CreateCustomer(customer *data.Customer) (uint, error)
 	GetCustomerByPhone(phone string) (*data.Customer, error)
+	SetCustomerVerified(customerID uint) error
+
+	GetStoreEmployeesWithPin(storeID uint) ([]data.StoreEmployee, error)
+	GetStoreEmployeeByEmployeeID(storeID, employeeID uint) (*data.StoreEmployee, error)
+	AttemptPinLogin(storeEmployeeID uint, pin string, now time.Time) error
 }
 
 type authenticationRepository struct {
@@ -42,3 +52,91 @@ func (r *authenticationRepository) GetCustomerByPhone(phone string) (*data.Custo
 	}
 	return &customer, err
 }
+
+func (r *authenticationRepository) SetCustomerVerified(customerID uint) error {
+	return r.db.Model(&data.Customer{}).
+		Where("id = ?", customerID).
+		Update("is_verified", true).Error
+}
+
+// GetStoreEmployeesWithPin returns the active employees of the store who can switch to a terminal by PIN
+func (r *authenticationRepository) GetStoreEmployeesWithPin(storeID uint) ([]data.StoreEmployee, error) {
+	var storeEmployees []data.StoreEmployee
+	err := r.db.
+		Joins("JOIN employees ON employees.id = store_employees.employee_id AND employees.deleted_at IS NULL").
+		Preload("Employee").
+		Where("store_employees.store_id = ? AND store_employees.pin_hash IS NOT NULL AND employees.is_active = ?", storeID, true).
+		Order("employees.first_name, employees.last_name").
+		Find(&storeEmployees).Error
+	if err != nil {
+		return nil, err
+	}
+	return storeEmployees, nil
+}
+
+func (r *authenticationRepository) GetStoreEmployeeByEmployeeID(storeID, employeeID uint) (*data.StoreEmployee, error) {
+	var storeEmployee data.StoreEmployee
+	err := r.db.
+		Preload("Employee").
+		Where("store_id = ? AND employee_id = ?", storeID, employeeID).
+		First(&storeEmployee).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &storeEmployee, err
+}
+
+// AttemptPinLogin checks the PIN while the store employee is locked, so concurrent attempts can not pass the lock or get lost.
+// The attempt is counted before the comparison and the counter is kept until a successful login
+func (r *authenticationRepository) AttemptPinLogin(storeEmployeeID uint, pin string, now time.Time) error {
+	var attemptErr error
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var storeEmployee data.StoreEmployee
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&storeEmployee, storeEmployeeID).Error; err != nil {
+			return err
+		}
+
+		if types.IsPinLocked(&storeEmployee, now) {
+			attemptErr = types.ErrPinLocked
+			return nil
+		}
+		if storeEmployee.PinHash == nil {
+			attemptErr = types.ErrInvalidCredentials
+			return nil
+		}
+
+		failedAttempts := storeEmployee.PinFailedAttempts + 1
+		err := tx.Model(&data.StoreEmployee{}).
+			Where("id = ?", storeEmployeeID).
+			Update("pin_failed_attempts", failedAttempts).Error
+		if err != nil {
+			return err
+		}
+
+		if utils.ComparePassword(*storeEmployee.PinHash, pin) == nil {
+			return tx.Model(&data.StoreEmployee{}).
+				Where("id = ?", storeEmployeeID).
+				Updates(map[string]interface{}{
+					"pin_failed_attempts": 0,
+					"pin_locked_until":    nil,
+				}).Error
+		}
+
+		attemptErr = types.ErrInvalidCredentials
+		if lockedUntil := types.PinLockedUntil(failedAttempts, now); lockedUntil != nil {
+			attemptErr = types.ErrPinLocked
+			return tx.Model(&data.StoreEmployee{}).
+				Where("id = ?", storeEmployeeID).
+				Update("pin_locked_until", *lockedUntil).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return attemptErr
+}
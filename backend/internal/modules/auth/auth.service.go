@@ -6,10 +6,14 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/verificationCode"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/employees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals"
+	storeTerminalsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -17,17 +21,27 @@ import (
 )
 
 type AuthenticationService interface {
-	EmployeeLogin(email, password string) (*types.Token, error)
+	EmployeeLogin(dto *types.EmployeeLoginDTO, device *types.DeviceInfo) (*types.Token, error)
+	GetTerminal(terminalToken string) (*types.TerminalDTO, error)
+	EmployeePinLogin(terminalToken string, dto *types.EmployeePinLoginDTO, device *types.DeviceInfo) (*types.Token, error)
+	RefreshEmployeeToken(refreshToken string, device *types.DeviceInfo) (*types.Token, error)
+	EmployeeLogout(sessionToken, refreshToken string) error
 
 	CustomerRegister(input *types.CustomerRegisterDTO) (uint, error)
 	CustomerLogin(email, password string) (*types.Token, error)
+	SendCustomerVerificationCode(phone string) error
+	VerifyCustomerPhone(dto *types.VerifyCustomerPhoneDTO) error
+	SendCustomerPasswordResetCode(phone string) error
+	ResetCustomerPassword(dto *types.ResetCustomerPasswordDTO) error
 }
 
 type authenticationService struct {
 	repo                 AuthenticationRepository
 	customersRepo        customers.CustomerRepository
 	employeesRepo        employees.EmployeeRepository
+	storeTerminalsRepo   storeTerminals.StoreTerminalRepository
 	employeeTokenManager employeeToken.EmployeeTokenManager
+	verificationManager  verificationCode.VerificationCodeManager
 	logger               *zap.SugaredLogger
 }
 
@@ -35,30 +49,253 @@ func NewAuthenticationService(
 	repo AuthenticationRepository,
 	customersRepo customers.CustomerRepository,
 	employeesRepo employees.EmployeeRepository,
+	storeTerminalsRepo storeTerminals.StoreTerminalRepository,
 	employeeTokenManager employeeToken.EmployeeTokenManager,
+	verificationManager verificationCode.VerificationCodeManager,
 	logger *zap.SugaredLogger,
 ) AuthenticationService {
 	return &authenticationService{
 		repo:                 repo,
 		customersRepo:        customersRepo,
 		employeesRepo:        employeesRepo,
+		storeTerminalsRepo:   storeTerminalsRepo,
 		employeeTokenManager: employeeTokenManager,
+		verificationManager:  verificationManager,
 		logger:               logger,
 	}
 }
 
-func (s *authenticationService) EmployeeLogin(email, password string) (*types.Token, error) {
-	employee, err := s.checkEmployeeCredentials(email, password)
+// EmployeeLogin opens a new session on the device, the sessions on the other devices stay active
+func (s *authenticationService) EmployeeLogin(dto *types.EmployeeLoginDTO, device *types.DeviceInfo) (*types.Token, error) {
+	employee, err := s.checkEmployeeCredentials(dto.Email, dto.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionToken, err := s.handleEmployeeToken(employee.ID)
+	return s.openEmployeeSession(&data.EmployeeToken{
+		EmployeeID: employee.ID,
+		DeviceName: device.Name,
+		UserAgent:  device.UserAgent,
+		IPAddress:  device.IPAddress,
+	})
+}
+
+// GetTerminal returns the store terminal with the employees who can switch to it by PIN
+func (s *authenticationService) GetTerminal(terminalToken string) (*types.TerminalDTO, error) {
+	terminal, err := s.getTerminal(terminalToken)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.Token{SessionToken: sessionToken}, nil
+	storeEmployees, err := s.repo.GetStoreEmployeesWithPin(terminal.StoreID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to fetch the operators of store terminal %d: %w", terminal.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return types.MapToTerminalDTO(terminal, storeEmployees, time.Now()), nil
+}
+
+// EmployeePinLogin makes the employee the active operator of the store terminal, the session of the previous
+// operator is closed, so everything done on the terminal afterwards is attributed to the new one
+func (s *authenticationService) EmployeePinLogin(terminalToken string, dto *types.EmployeePinLoginDTO, device *types.DeviceInfo) (*types.Token, error) {
+	terminal, err := s.getTerminal(terminalToken)
+	if err != nil {
+		return nil, err
+	}
+
+	storeEmployee, err := s.repo.GetStoreEmployeeByEmployeeID(terminal.StoreID, dto.EmployeeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to fetch store employee: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+	if storeEmployee == nil || storeEmployee.PinHash == nil {
+		return nil, types.ErrInvalidCredentials
+	}
+	if !storeEmployee.Employee.IsActive {
+		return nil, types.ErrInactiveEmployee
+	}
+
+	now := time.Now()
+	if err := s.repo.AttemptPinLogin(storeEmployee.ID, dto.Pin, now); err != nil {
+		switch {
+		case errors.Is(err, types.ErrPinLocked):
+			s.logger.Warnf("PIN login of store employee %d is rejected: %v", storeEmployee.ID, err)
+			return nil, err
+		case errors.Is(err, types.ErrInvalidCredentials):
+			return nil, err
+		}
+		wrappedErr := fmt.Errorf("failed to check PIN of store employee %d: %w", storeEmployee.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if err := s.employeeTokenManager.DeleteTokensByStoreTerminalID(terminal.ID); err != nil {
+		wrappedErr := fmt.Errorf("failed to close the operator session of store terminal %d: %w", terminal.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if err := s.storeTerminalsRepo.UpdateLastUsedAt(terminal.ID, now); err != nil {
+		s.logger.Warnf("failed to update last usage of store terminal %d: %v", terminal.ID, err)
+	}
+
+	return s.openEmployeeSession(&data.EmployeeToken{
+		EmployeeID:      storeEmployee.EmployeeID,
+		StoreTerminalID: &terminal.ID,
+		DeviceName:      terminal.Name,
+		UserAgent:       device.UserAgent,
+		IPAddress:       device.IPAddress,
+	})
+}
+
+func (s *authenticationService) getTerminal(terminalToken string) (*data.StoreTerminal, error) {
+	if terminalToken == "" {
+		return nil, types.ErrInvalidTerminalToken
+	}
+
+	terminal, err := s.storeTerminalsRepo.GetTerminalByTokenHash(storeTerminalsTypes.HashTerminalToken(terminalToken))
+	if err != nil {
+		if errors.Is(err, storeTerminalsTypes.ErrStoreTerminalNotFound) {
+			return nil, types.ErrInvalidTerminalToken
+		}
+		wrappedErr := fmt.Errorf("failed to fetch store terminal: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+	return terminal, nil
+}
+
+// openEmployeeSession saves the session with a new refresh token and issues the access token bound to it
+func (s *authenticationService) openEmployeeSession(session *data.EmployeeToken) (*types.Token, error) {
+	if err := s.employeeTokenManager.DeleteExpiredTokensByEmployeeID(session.EmployeeID); err != nil {
+		s.logger.Warnf("failed to delete expired sessions of employee %d: %v", session.EmployeeID, err)
+	}
+
+	refreshToken, refreshTokenHash, err := types.GenerateRefreshToken()
+	if err != nil {
+		return nil, utils.WrapError("failed to generate refresh token", err)
+	}
+
+	now := time.Now()
+	session.RefreshTokenHash = refreshTokenHash
+	session.ExpiresAt = now.Add(config.GetConfig().JWT.EmployeeRefreshTokenTTL)
+	session.LastUsedAt = now
+	if err := s.employeeTokenManager.CreateToken(session); err != nil {
+		wrappedErr := fmt.Errorf("failed to save employee session: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	sessionToken, err := types.GenerateEmployeeJWT(session.EmployeeID, session.ID)
+	if err != nil {
+		return nil, utils.WrapError("failed to generate session token", err)
+	}
+
+	return &types.Token{SessionToken: sessionToken, RefreshToken: refreshToken}, nil
+}
+
+// RefreshEmployeeToken rotates the refresh token of the session and issues a new access token.
+// A refresh token that was already rotated means it leaked, so the whole session is revoked.
+func (s *authenticationService) RefreshEmployeeToken(refreshToken string, device *types.DeviceInfo) (*types.Token, error) {
+	if refreshToken == "" {
+		return nil, types.ErrInvalidRefreshToken
+	}
+
+	refreshTokenHash := types.HashRefreshToken(refreshToken)
+	session, err := s.employeeTokenManager.GetTokenByRefreshTokenHash(refreshTokenHash)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to retrieve employee session: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if session == nil {
+		return nil, s.revokeReusedSession(refreshTokenHash)
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		if err := s.employeeTokenManager.DeleteToken(session); err != nil {
+			s.logger.Warnf("failed to delete expired session %d: %v", session.ID, err)
+		}
+		return nil, types.ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, newRefreshTokenHash, err := types.GenerateRefreshToken()
+	if err != nil {
+		return nil, utils.WrapError("failed to generate refresh token", err)
+	}
+
+	session.UserAgent = device.UserAgent
+	session.IPAddress = device.IPAddress
+	expiresAt := time.Now().Add(config.GetConfig().JWT.EmployeeRefreshTokenTTL)
+	if err := s.employeeTokenManager.RotateRefreshToken(session, newRefreshTokenHash, expiresAt); err != nil {
+		if errors.Is(err, employeeToken.ErrTokenNotFound) {
+			return nil, types.ErrInvalidRefreshToken
+		}
+		wrappedErr := fmt.Errorf("failed to rotate the refresh token of session %d: %w", session.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	sessionToken, err := types.GenerateEmployeeJWT(session.EmployeeID, session.ID)
+	if err != nil {
+		return nil, utils.WrapError("failed to generate session token", err)
+	}
+
+	return &types.Token{SessionToken: sessionToken, RefreshToken: newRefreshToken}, nil
+}
+
+func (s *authenticationService) revokeReusedSession(refreshTokenHash string) error {
+	session, err := s.employeeTokenManager.GetTokenByPreviousRefreshTokenHash(refreshTokenHash)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to retrieve employee session: %w", err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+	if session == nil {
+		return types.ErrInvalidRefreshToken
+	}
+
+	s.logger.Warnf("refresh token of session %d of employee %d was reused, revoking the session", session.ID, session.EmployeeID)
+	if err := s.employeeTokenManager.DeleteToken(session); err != nil {
+		wrappedErr := fmt.Errorf("failed to revoke session %d: %w", session.ID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+	return types.ErrRefreshTokenReused
+}
+
+// EmployeeLogout closes the session of the current device, it is found by the access token even if it has expired
+// and by the refresh token otherwise
+func (s *authenticationService) EmployeeLogout(sessionToken, refreshToken string) error {
+	var session *data.EmployeeToken
+	var err error
+
+	var claims types.EmployeeClaims
+	if sessionToken != "" && types.ValidateEmployeeToken(sessionToken, &claims) == nil && claims.SessionID != 0 {
+		session, err = s.employeeTokenManager.GetTokenByID(claims.SessionID)
+	} else if refreshToken != "" {
+		session, err = s.employeeTokenManager.GetTokenByRefreshTokenHash(types.HashRefreshToken(refreshToken))
+	}
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to retrieve employee session: %w", err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	if session == nil {
+		return nil
+	}
+
+	if err := s.employeeTokenManager.DeleteToken(session); err != nil {
+		wrappedErr := fmt.Errorf("failed to delete employee session %d: %w", session.ID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+	return nil
 }
 
 func (s *authenticationService) CustomerRegister(input *types.CustomerRegisterDTO) (uint, error) {
@@ -99,6 +336,11 @@ func (s *authenticationService) CustomerRegister(input *types.CustomerRegisterDT
 
 	s.logger.Infof("customer with ID=%d CREATED successfully", id)
 
+	customer.ID = id
+	if err := s.verificationManager.SendCode(customer, data.VerificationPurposePhone); err != nil {
+		s.logger.Error(utils.WrapError("failed to send the phone verification code after registration", err))
+	}
+
 	return id, nil
 }
 
@@ -134,71 +376,129 @@ func (s *authenticationService) CustomerLogin(phone, password string) (*types.To
 	return &token, nil
 }
 
-func (s *authenticationService) checkEmployeeCredentials(email, password string) (*data.Employee, error) {
-	employee, err := s.employeesRepo.GetEmployeeByEmailOrPhone(email, "")
+// SendCustomerVerificationCode sends the code again, the unknown and the verified phones are ignored to not disclose the registered ones
+func (s *authenticationService) SendCustomerVerificationCode(phone string) error {
+	customer, err := s.getCustomerByPhone(phone)
+	if err != nil || customer == nil || customer.IsVerified {
+		return err
+	}
+
+	return s.sendCustomerCode(customer, data.VerificationPurposePhone)
+}
+
+func (s *authenticationService) VerifyCustomerPhone(dto *types.VerifyCustomerPhoneDTO) error {
+	customer, err := s.getCustomerByPhone(dto.Phone)
 	if err != nil {
-		s.logger.Error(err)
-		return nil, types.ErrInvalidCredentials
+		return err
 	}
-	if employee == nil {
-		return nil, errors.New("this employee is not registered")
+	// a verified phone has no code, it fails as an unknown one
+	if customer == nil || customer.IsVerified {
+		return types.ErrInvalidVerificationCode
 	}
-	if !employee.IsActive {
-		return nil, types.ErrInactiveEmployee
+
+	if err := s.verifyCustomerCode(customer.ID, data.VerificationPurposePhone, dto.Code); err != nil {
+		return err
 	}
 
-	if err := utils.ComparePassword(employee.HashedPassword, password); err != nil {
-		return nil, types.ErrInvalidCredentials
+	if err := s.repo.SetCustomerVerified(customer.ID); err != nil {
+		wrappedErr := utils.WrapError("failed to verify customer", err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
 	}
-	return employee, nil
+	return nil
 }
 
-func (s *authenticationService) handleEmployeeToken(employeeID uint) (string, error) {
-	existingToken, err := s.employeeTokenManager.GetTokenByEmployeeID(employeeID)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return "", fmt.Errorf("failed to retrieve existing token: %w", err)
+func (s *authenticationService) SendCustomerPasswordResetCode(phone string) error {
+	customer, err := s.getCustomerByPhone(phone)
+	if err != nil || customer == nil {
+		return err
+	}
+
+	if customer.IsBanned {
+		return types.ErrBannedCustomer
 	}
 
-	if existingToken != nil && existingToken.ExpiresAt.After(time.Now()) {
-		return existingToken.Token, nil
+	return s.sendCustomerCode(customer, data.VerificationPurposePasswordReset)
+}
+
+// ResetCustomerPassword also verifies the phone, the code proves the customer owns it
+func (s *authenticationService) ResetCustomerPassword(dto *types.ResetCustomerPasswordDTO) error {
+	if err := utils.IsValidPassword(dto.NewPassword); err != nil {
+		return moduleErrors.ErrValidation.WithDetails("newPassword", fmt.Sprintf("password validation failed: %v", err))
 	}
 
-	if existingToken == nil {
-		return s.createEmployeeToken(employeeID)
+	customer, err := s.getCustomerByPhone(dto.Phone)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return types.ErrInvalidVerificationCode
 	}
 
-	if err := s.employeeTokenManager.DeleteTokenByEmployeeID(employeeID); err != nil {
-		return "", fmt.Errorf("failed to delete old/expired token: %w", err)
+	if err := s.verifyCustomerCode(customer.ID, data.VerificationPurposePasswordReset, dto.Code); err != nil {
+		return err
 	}
 
-	return s.createEmployeeToken(employeeID)
+	hashedPassword, err := utils.HashPassword(dto.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.customersRepo.UpdateCustomerPassword(customer.ID, hashedPassword); err != nil {
+		wrappedErr := utils.WrapError("failed to reset customer password", err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	if !customer.IsVerified {
+		if err := s.repo.SetCustomerVerified(customer.ID); err != nil {
+			s.logger.Error(utils.WrapError("failed to verify customer", err))
+		}
+	}
+	return nil
 }
 
-func (s *authenticationService) createEmployeeToken(employeeID uint) (string, error) {
-	sessionToken, err := types.GenerateEmployeeJWT(employeeID)
+func (s *authenticationService) getCustomerByPhone(phone string) (*data.Customer, error) {
+	customer, err := s.repo.GetCustomerByPhone(phone)
 	if err != nil {
-		return "", utils.WrapError("failed to generate session token", err)
+		wrappedErr := utils.WrapError("error retrieving customer", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
 	}
+	return customer, nil
+}
 
-	if err := s.saveEmployeeToken(employeeID, sessionToken); err != nil {
-		return "", fmt.Errorf("failed to save new token: %w", err)
+func (s *authenticationService) sendCustomerCode(customer *data.Customer, purpose data.VerificationPurpose) error {
+	err := s.verificationManager.SendCode(customer, purpose)
+	if err != nil && !errors.Is(err, types.ErrVerificationCooldown) && !errors.Is(err, types.ErrVerificationLimit) {
+		s.logger.Error(err)
 	}
-
-	return sessionToken, nil
+	return err
 }
 
-func (s *authenticationService) saveEmployeeToken(employeeID uint, token string) error {
-	cfg := config.GetConfig()
-	now := time.Now()
-	expirationTime := now.Add(cfg.JWT.EmployeeTokenTTL)
+func (s *authenticationService) verifyCustomerCode(customerID uint, purpose data.VerificationPurpose, code string) error {
+	err := s.verificationManager.VerifyCode(customerID, purpose, code)
+	if err != nil && !errors.Is(err, types.ErrInvalidVerificationCode) && !errors.Is(err, types.ErrVerificationAttempts) {
+		s.logger.Error(err)
+	}
+	return err
+}
 
-	employeeToken := &data.EmployeeToken{
-		EmployeeID: employeeID,
-		Token:      token,
-		ExpiresAt:  expirationTime,
+func (s *authenticationService) checkEmployeeCredentials(email, password string) (*data.Employee, error) {
+	employee, err := s.employeesRepo.GetEmployeeByEmailOrPhone(email, "")
+	if err != nil {
+		s.logger.Error(err)
+		return nil, types.ErrInvalidCredentials
+	}
+	if employee == nil {
+		return nil, errors.New("this employee is not registered")
 	}
-	if err := s.employeeTokenManager.CreateToken(employeeToken); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+	if !employee.IsActive {
+		return nil, types.ErrInactiveEmployee
 	}
-	return nil
+
+	if err := utils.ComparePassword(employee.HashedPassword, password); err != nil {
+		return nil, types.ErrInvalidCredentials
+	}
+	return employee, nil
 }
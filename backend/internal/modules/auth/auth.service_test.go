@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/employeeToken"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "auth-test")
+	if err != nil {
+		log.Fatal(err)
+	}
+	envPath := filepath.Join(dir, "test.env")
+	env := "JWT_EMPLOYEE_SECRET_KEY=test-secret\nJWT_EMPLOYEE_TOKEN_TTL=15m\nJWT_EMPLOYEE_REFRESH_TOKEN_TTL=168h\n"
+	if err := os.WriteFile(envPath, []byte(env), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := config.LoadTestConfig(envPath); err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeEmployeeTokenManager keeps the sessions in memory, the methods the refresh does not use are left unimplemented
+type fakeEmployeeTokenManager struct {
+	employeeToken.EmployeeTokenManager
+	sessions map[uint]*data.EmployeeToken
+}
+
+func (f *fakeEmployeeTokenManager) findToken(match func(*data.EmployeeToken) bool) (*data.EmployeeToken, error) {
+	for _, session := range f.sessions {
+		if match(session) {
+			found := *session
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeEmployeeTokenManager) GetTokenByRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error) {
+	return f.findToken(func(session *data.EmployeeToken) bool {
+		return session.RefreshTokenHash == refreshTokenHash
+	})
+}
+
+func (f *fakeEmployeeTokenManager) GetTokenByPreviousRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error) {
+	return f.findToken(func(session *data.EmployeeToken) bool {
+		return session.PreviousRefreshTokenHash != nil && *session.PreviousRefreshTokenHash == refreshTokenHash
+	})
+}
+
+func (f *fakeEmployeeTokenManager) RotateRefreshToken(token *data.EmployeeToken, refreshTokenHash string, expiresAt time.Time) error {
+	session, ok := f.sessions[token.ID]
+	if !ok || session.RefreshTokenHash != token.RefreshTokenHash {
+		return employeeToken.ErrTokenNotFound
+	}
+
+	previousHash := session.RefreshTokenHash
+	session.PreviousRefreshTokenHash = &previousHash
+	session.RefreshTokenHash = refreshTokenHash
+	session.ExpiresAt = expiresAt
+	return nil
+}
+
+func (f *fakeEmployeeTokenManager) DeleteToken(token *data.EmployeeToken) error {
+	delete(f.sessions, token.ID)
+	return nil
+}
+
+func setupRefreshTest(refreshToken string, expiresAt time.Time) (*authenticationService, *fakeEmployeeTokenManager) {
+	tokenManager := &fakeEmployeeTokenManager{
+		sessions: map[uint]*data.EmployeeToken{
+			1: {
+				BaseEntity:       data.BaseEntity{ID: 1},
+				EmployeeID:       7,
+				RefreshTokenHash: types.HashRefreshToken(refreshToken),
+				ExpiresAt:        expiresAt,
+			},
+		},
+	}
+
+	service := &authenticationService{
+		employeeTokenManager: tokenManager,
+		logger:               zap.NewNop().Sugar(),
+	}
+	return service, tokenManager
+}
+
+func TestRefreshEmployeeToken(t *testing.T) {
+	device := &types.DeviceInfo{UserAgent: "test-agent", IPAddress: "127.0.0.1"}
+
+	t.Run("Refresh should rotate the refresh token of the session", func(t *testing.T) {
+		service, tokenManager := setupRefreshTest("first-refresh-token", time.Now().Add(time.Hour))
+
+		token, err := service.RefreshEmployeeToken("first-refresh-token", device)
+		require.NoError(t, err)
+		assert.NotEqual(t, "first-refresh-token", token.RefreshToken)
+
+		session := tokenManager.sessions[1]
+		assert.Equal(t, types.HashRefreshToken(token.RefreshToken), session.RefreshTokenHash)
+		if assert.NotNil(t, session.PreviousRefreshTokenHash) {
+			assert.Equal(t, types.HashRefreshToken("first-refresh-token"), *session.PreviousRefreshTokenHash)
+		}
+
+		var claims types.EmployeeClaims
+		require.NoError(t, types.ValidateEmployeeToken(token.SessionToken, &claims))
+		assert.Equal(t, uint(7), claims.EmployeeID)
+		assert.Equal(t, uint(1), claims.SessionID)
+
+		_, err = service.RefreshEmployeeToken(token.RefreshToken, device)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Reuse of the rotated refresh token should revoke the session", func(t *testing.T) {
+		service, tokenManager := setupRefreshTest("first-refresh-token", time.Now().Add(time.Hour))
+
+		token, err := service.RefreshEmployeeToken("first-refresh-token", device)
+		require.NoError(t, err)
+
+		_, err = service.RefreshEmployeeToken("first-refresh-token", device)
+		assert.ErrorIs(t, err, types.ErrRefreshTokenReused)
+		assert.Empty(t, tokenManager.sessions)
+
+		_, err = service.RefreshEmployeeToken(token.RefreshToken, device)
+		assert.ErrorIs(t, err, types.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Unknown refresh token should be rejected", func(t *testing.T) {
+		service, tokenManager := setupRefreshTest("first-refresh-token", time.Now().Add(time.Hour))
+
+		_, err := service.RefreshEmployeeToken("unknown-refresh-token", device)
+		assert.ErrorIs(t, err, types.ErrInvalidRefreshToken)
+		assert.Len(t, tokenManager.sessions, 1)
+	})
+
+	t.Run("Expired session should be deleted", func(t *testing.T) {
+		service, tokenManager := setupRefreshTest("first-refresh-token", time.Now().Add(-time.Minute))
+
+		_, err := service.RefreshEmployeeToken("first-refresh-token", device)
+		assert.ErrorIs(t, err, types.ErrInvalidRefreshToken)
+		assert.Empty(t, tokenManager.sessions)
+	})
+}
@@ -2,16 +2,28 @@ package employeeToken
 
 import (
 	"errors"
+	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"gorm.io/gorm"
 )
 
+var ErrTokenNotFound = errors.New("employee token not found")
+
+// EmployeeTokenManager keeps the login sessions of the employees, an employee may have a session on every device
 type EmployeeTokenManager interface {
 	CreateToken(token *data.EmployeeToken) error
-	GetTokenByEmployeeID(employeeID uint) (*data.EmployeeToken, error)
+	GetTokenByID(tokenID uint) (*data.EmployeeToken, error)
+	GetTokenByRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error)
+	GetTokenByPreviousRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error)
+	GetTokensByEmployeeID(employeeID uint) ([]data.EmployeeToken, error)
+	RotateRefreshToken(token *data.EmployeeToken, refreshTokenHash string, expiresAt time.Time) error
 	DeleteToken(token *data.EmployeeToken) error
+	DeleteEmployeeToken(employeeID, tokenID uint) error
+	DeleteOtherEmployeeTokens(employeeID, keepTokenID uint) error
+	DeleteExpiredTokensByEmployeeID(employeeID uint) error
 	DeleteTokenByEmployeeID(employeeID uint) error
+	DeleteTokensByStoreTerminalID(storeTerminalID uint) error
 	DeleteTokenByStoreEmployeeID(storeEmployeeID uint) error
 	DeleteTokenByWarehouseEmployeeID(warehouseEmployeeID uint) error
 	DeleteTokenByRegionEmployeeID(regionEmployeeID uint) error
@@ -30,7 +42,8 @@ func (r *employeeTokenManager) CreateToken(token *data.EmployeeToken) error {
 	return r.db.Create(token).Error
 }
 
-func (r *employeeTokenManager) GetTokenByEmployeeID(employeeID uint) (*data.EmployeeToken, error) {
+// GetTokenByID loads the session together with the workplace and role of the employee
+func (r *employeeTokenManager) GetTokenByID(tokenID uint) (*data.EmployeeToken, error) {
 	var token data.EmployeeToken
 	err := r.db.Model(&data.EmployeeToken{}).
 		Preload("Employee", func(db *gorm.DB) *gorm.DB {
@@ -51,7 +64,7 @@ func (r *employeeTokenManager) GetTokenByEmployeeID(employeeID uint) (*data.Empl
 		Preload("Employee.AdminEmployee", func(db *gorm.DB) *gorm.DB {
 			return db.Select("employee_id, role")
 		}).
-		Where("employee_id = ?", employeeID).
+		Where("id = ?", tokenID).
 		First(&token).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -63,14 +76,108 @@ func (r *employeeTokenManager) GetTokenByEmployeeID(employeeID uint) (*data.Empl
 	return &token, nil
 }
 
+func (r *employeeTokenManager) GetTokenByRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error) {
+	return r.getToken("refresh_token_hash = ?", refreshTokenHash)
+}
+
+func (r *employeeTokenManager) GetTokenByPreviousRefreshTokenHash(refreshTokenHash string) (*data.EmployeeToken, error) {
+	return r.getToken("previous_refresh_token_hash = ?", refreshTokenHash)
+}
+
+func (r *employeeTokenManager) getToken(query string, args ...interface{}) (*data.EmployeeToken, error) {
+	var token data.EmployeeToken
+	err := r.db.Where(query, args...).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *employeeTokenManager) GetTokensByEmployeeID(employeeID uint) ([]data.EmployeeToken, error) {
+	var tokens []data.EmployeeToken
+	err := r.db.
+		Where("employee_id = ? AND expires_at > ?", employeeID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RotateRefreshToken replaces the refresh token of the session, the update only applies to the token that was presented
+// so the concurrent refreshes with the same token cannot both succeed
+func (r *employeeTokenManager) RotateRefreshToken(token *data.EmployeeToken, refreshTokenHash string, expiresAt time.Time) error {
+	now := time.Now()
+	res := r.db.Model(&data.EmployeeToken{}).
+		Where("id = ? AND refresh_token_hash = ?", token.ID, token.RefreshTokenHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":          refreshTokenHash,
+			"previous_refresh_token_hash": token.RefreshTokenHash,
+			"expires_at":                  expiresAt,
+			"last_used_at":                now,
+			"device_name":                 token.DeviceName,
+			"user_agent":                  token.UserAgent,
+			"ip_address":                  token.IPAddress,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+
+	previousHash := token.RefreshTokenHash
+	token.PreviousRefreshTokenHash = &previousHash
+	token.RefreshTokenHash = refreshTokenHash
+	token.ExpiresAt = expiresAt
+	token.LastUsedAt = now
+	return nil
+}
+
 func (r *employeeTokenManager) DeleteToken(token *data.EmployeeToken) error {
 	return r.db.Unscoped().Delete(token).Error
 }
 
+func (r *employeeTokenManager) DeleteEmployeeToken(employeeID, tokenID uint) error {
+	res := r.db.Unscoped().
+		Where("id = ? AND employee_id = ?", tokenID, employeeID).
+		Delete(&data.EmployeeToken{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func (r *employeeTokenManager) DeleteOtherEmployeeTokens(employeeID, keepTokenID uint) error {
+	return r.db.Unscoped().
+		Where("employee_id = ? AND id <> ?", employeeID, keepTokenID).
+		Delete(&data.EmployeeToken{}).Error
+}
+
+func (r *employeeTokenManager) DeleteExpiredTokensByEmployeeID(employeeID uint) error {
+	return r.db.Unscoped().
+		Where("employee_id = ? AND expires_at <= ?", employeeID, time.Now()).
+		Delete(&data.EmployeeToken{}).Error
+}
+
 func (r *employeeTokenManager) DeleteTokenByEmployeeID(employeeID uint) error {
 	return r.db.Unscoped().Where("employee_id = ?", employeeID).Delete(&data.EmployeeToken{}).Error
 }
 
+// DeleteTokensByStoreTerminalID closes the session of the operator active on the terminal
+func (r *employeeTokenManager) DeleteTokensByStoreTerminalID(storeTerminalID uint) error {
+	return r.db.Unscoped().Where("store_terminal_id = ?", storeTerminalID).Delete(&data.EmployeeToken{}).Error
+}
+
 func (r *employeeTokenManager) DeleteTokenByStoreEmployeeID(storeEmployeeID uint) error {
 	var storeEmp data.StoreEmployee
 	if err := r.db.
@@ -11,3 +11,18 @@ type CustomerLoginDTO struct {
 	Phone    string `json:"phone" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type CustomerPhoneDTO struct {
+	Phone string `json:"phone" binding:"required"`
+}
+
+type VerifyCustomerPhoneDTO struct {
+	Phone string `json:"phone" binding:"required"`
+	Code  string `json:"code" binding:"required,numeric,len=6"`
+}
+
+type ResetCustomerPasswordDTO struct {
+	Phone       string `json:"phone" binding:"required"`
+	Code        string `json:"code" binding:"required,numeric,len=6"`
+	NewPassword string `json:"newPassword" binding:"required"`
+}
@@ -1,6 +1,53 @@
 package types
 
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+const (
+	MaxPinAttempts        = 5
+	PinLockoutDuration    = 15 * time.Minute
+	MaxPinLockoutDuration = 24 * time.Hour
+)
+
 type EmployeeLoginDTO struct {
-	Email    string `json:"email" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Email      string `json:"email" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"deviceName" binding:"omitempty,max=255"`
+}
+
+// EmployeeRefreshDTO carries the refresh token for clients that do not keep cookies, the cookie is used otherwise
+type EmployeeRefreshDTO struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// DeviceInfo describes the device a session is opened or refreshed from
+type DeviceInfo struct {
+	Name      string
+	UserAgent string
+	IPAddress string
+}
+
+// EmployeePinLoginDTO switches the operator of a store terminal, the terminal is identified by its device token
+type EmployeePinLoginDTO struct {
+	EmployeeID uint   `json:"employeeId" binding:"required,gt=0"`
+	Pin        string `json:"pin" binding:"required,numeric,min=4,max=6"`
+}
+
+type TerminalOperatorDTO struct {
+	EmployeeID uint              `json:"employeeId"`
+	FirstName  string            `json:"firstName"`
+	LastName   string            `json:"lastName"`
+	Role       data.EmployeeRole `json:"role"`
+	IsLocked   bool              `json:"isLocked"`
+}
+
+type TerminalDTO struct {
+	ID        uint                  `json:"id"`
+	Name      string                `json:"name"`
+	StoreID   uint                  `json:"storeId"`
+	StoreName string                `json:"storeName"`
+	Operators []TerminalOperatorDTO `json:"operators"`
 }
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/config"
@@ -9,12 +13,16 @@ import (
 
 const (
 	EMPLOYEE_SESSION_COOKIE_KEY = "ZEEP_EMPLOYEE_SESSION"
+	EMPLOYEE_REFRESH_COOKIE_KEY = "ZEEP_EMPLOYEE_REFRESH"
 	CUSTOMER_SESSION_COOKIE_KEY = "ZEEP_CUSTOMER_SESSION"
 )
 
+const refreshTokenBytes = 32
+
 type EmployeeClaims struct {
 	jwt.RegisteredClaims
 	EmployeeID uint `json:"employeeId"`
+	SessionID  uint `json:"sessionId"`
 }
 
 type CustomerClaims struct {
@@ -24,14 +32,17 @@ type CustomerClaims struct {
 
 type Token struct {
 	SessionToken string `json:"sessionToken"`
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
-func GenerateEmployeeJWT(employeeID uint) (string, error) {
+// GenerateEmployeeJWT issues a short-lived access token bound to the login session
+func GenerateEmployeeJWT(employeeID, sessionID uint) (string, error) {
 	cfg := config.GetConfig()
 	ttl := cfg.JWT.EmployeeTokenTTL
 
 	session := EmployeeClaims{
 		EmployeeID: employeeID,
+		SessionID:  sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			Issuer:    "zeep-web",
@@ -45,7 +56,7 @@ func GenerateEmployeeJWT(employeeID uint) (string, error) {
 
 func GenerateCustomerJWT(customerID uint) (string, error) {
 	cfg := config.GetConfig()
-	ttl := cfg.JWT.EmployeeTokenTTL
+	ttl := cfg.JWT.CustomerTokenTTL
 
 	session := CustomerClaims{
 		CustomerID: customerID,
@@ -59,3 +70,19 @@ func GenerateCustomerJWT(customerID uint) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, session)
 	return token.SignedString([]byte(cfg.JWT.EmployeeSecretKey))
 }
+
+// GenerateRefreshToken returns an opaque refresh token and the hash to store
+func GenerateRefreshToken() (token, hash string, err error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
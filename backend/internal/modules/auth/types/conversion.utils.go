@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	employeesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/employees/types"
 
@@ -10,6 +11,7 @@ import (
 
 type EmployeeSession struct {
 	EmployeeID   uint
+	SessionID    uint
 	WorkplaceID  uint
 	Role         data.EmployeeRole
 	EmployeeType data.EmployeeType
@@ -54,6 +56,46 @@ func MapEmployeeToEmployeeSessionData(employee *data.Employee) (*EmployeeSession
 	return &employeeData, nil
 }
 
+func MapToTerminalDTO(terminal *data.StoreTerminal, storeEmployees []data.StoreEmployee, now time.Time) *TerminalDTO {
+	operators := make([]TerminalOperatorDTO, len(storeEmployees))
+	for i, storeEmployee := range storeEmployees {
+		operators[i] = TerminalOperatorDTO{
+			EmployeeID: storeEmployee.EmployeeID,
+			FirstName:  storeEmployee.Employee.FirstName,
+			LastName:   storeEmployee.Employee.LastName,
+			Role:       storeEmployee.Role,
+			IsLocked:   IsPinLocked(&storeEmployee, now),
+		}
+	}
+
+	return &TerminalDTO{
+		ID:        terminal.ID,
+		Name:      terminal.Name,
+		StoreID:   terminal.StoreID,
+		StoreName: terminal.Store.Name,
+		Operators: operators,
+	}
+}
+
+func IsPinLocked(storeEmployee *data.StoreEmployee, now time.Time) bool {
+	return storeEmployee.PinLockedUntil != nil && now.Before(*storeEmployee.PinLockedUntil)
+}
+
+// PinLockedUntil locks the PIN after every MaxPinAttempts failed attempts in a row, each next lock lasts twice as long
+func PinLockedUntil(failedAttempts int, now time.Time) *time.Time {
+	if failedAttempts <= 0 || failedAttempts%MaxPinAttempts != 0 {
+		return nil
+	}
+
+	duration := PinLockoutDuration
+	for i := 1; i < failedAttempts/MaxPinAttempts && duration < MaxPinLockoutDuration; i++ {
+		duration *= 2
+	}
+
+	lockedUntil := now.Add(min(duration, MaxPinLockoutDuration))
+	return &lockedUntil
+}
+
 func MapCustomerToClaimsData(customer *data.Customer) *CustomerSession {
 	return &CustomerSession{
 		CustomerID: customer.ID,
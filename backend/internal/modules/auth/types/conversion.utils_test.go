@@ -0,0 +1,28 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinLockedUntil(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Attempts below the limit should not lock the PIN", func(t *testing.T) {
+		assert.Nil(t, PinLockedUntil(0, now))
+		assert.Nil(t, PinLockedUntil(MaxPinAttempts-1, now))
+		assert.Nil(t, PinLockedUntil(MaxPinAttempts+1, now))
+	})
+
+	t.Run("Each next lock should last twice as long", func(t *testing.T) {
+		assert.Equal(t, now.Add(PinLockoutDuration), *PinLockedUntil(MaxPinAttempts, now))
+		assert.Equal(t, now.Add(2*PinLockoutDuration), *PinLockedUntil(2*MaxPinAttempts, now))
+		assert.Equal(t, now.Add(4*PinLockoutDuration), *PinLockedUntil(3*MaxPinAttempts, now))
+	})
+
+	t.Run("Lock should be capped", func(t *testing.T) {
+		assert.Equal(t, now.Add(MaxPinLockoutDuration), *PinLockedUntil(100*MaxPinAttempts, now))
+	})
+}
@@ -10,4 +10,15 @@ var (
 	ErrBannedCustomer     = moduleErrors.NewModuleError(errors.New("banned customer"))
 	ErrInvalidCredentials = moduleErrors.NewModuleError(errors.New("invalid credentials"))
 	ErrFailedToHashToken  = moduleErrors.NewModuleError(errors.New("failed to hash token"))
+
+	ErrInvalidRefreshToken = moduleErrors.NewModuleError(errors.New("invalid or expired refresh token"))
+	ErrRefreshTokenReused  = moduleErrors.NewModuleError(errors.New("refresh token reused, the session is revoked"))
+
+	ErrInvalidTerminalToken = moduleErrors.NewModuleError(errors.New("invalid store terminal token"))
+	ErrPinLocked            = moduleErrors.NewModuleError(errors.New("PIN is locked after too many failed attempts"))
+
+	ErrVerificationCooldown    = moduleErrors.NewModuleError(errors.New("verification code was sent recently"))
+	ErrVerificationLimit       = moduleErrors.NewModuleError(errors.New("too many verification codes requested"))
+	ErrInvalidVerificationCode = moduleErrors.NewModuleError(errors.New("invalid or expired verification code"))
+	ErrVerificationAttempts    = moduleErrors.NewModuleError(errors.New("too many wrong verification codes"))
 )
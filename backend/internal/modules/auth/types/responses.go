@@ -5,4 +5,18 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
 )
 
-var Response400IncorrectCredentials = localization.NewResponseKey(400, data.AuthenticationComponent)
+var (
+	Response400IncorrectCredentials = localization.NewResponseKey(400, data.AuthenticationComponent)
+	Response401Refresh              = localization.NewResponseKey(401, data.AuthenticationComponent, "refresh")
+	Response401Terminal             = localization.NewResponseKey(401, data.AuthenticationComponent, "terminal")
+	Response429PinLocked            = localization.NewResponseKey(429, data.AuthenticationComponent, "PIN_LOCKED")
+
+	Response200VerificationSent     = localization.NewResponseKey(200, data.AuthenticationComponent, "VERIFICATION_SENT")
+	Response200PhoneVerified        = localization.NewResponseKey(200, data.AuthenticationComponent, "PHONE_VERIFIED")
+	Response200PasswordReset        = localization.NewResponseKey(200, data.AuthenticationComponent, "PASSWORD_RESET")
+	Response400VerificationCode     = localization.NewResponseKey(400, data.AuthenticationComponent, "VERIFICATION_CODE")
+	Response429VerificationCooldown = localization.NewResponseKey(429, data.AuthenticationComponent, "VERIFICATION_COOLDOWN")
+	Response429VerificationLimit    = localization.NewResponseKey(429, data.AuthenticationComponent, "VERIFICATION_LIMIT")
+	Response429VerificationAttempts = localization.NewResponseKey(429, data.AuthenticationComponent, "VERIFICATION_ATTEMPTS")
+	Response403BannedCustomer       = localization.NewResponseKey(403, data.AuthenticationComponent, "BANNED_CUSTOMER")
+)
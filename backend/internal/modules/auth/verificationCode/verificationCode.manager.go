@@ -0,0 +1,160 @@
+package verificationCode
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/sms"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	codeDigits        = 6
+	rateLimitWindow   = time.Hour
+	cooldownKeyPrefix = "otp:cooldown:"
+	countKeyPrefix    = "otp:count:"
+)
+
+var codeMessages = map[data.VerificationPurpose]string{
+	data.VerificationPurposePhone:         "Zeep verification code: %s",
+	data.VerificationPurposePasswordReset: "Zeep password reset code: %s",
+}
+
+// VerificationCodeManager sends the one-time codes to the customer phones and checks them.
+// The sending is rate limited in Redis: one code per cooldown for a purpose and a limited number of codes per hour for a phone.
+type VerificationCodeManager interface {
+	SendCode(customer *data.Customer, purpose data.VerificationPurpose) error
+	VerifyCode(customerID uint, purpose data.VerificationPurpose, code string) error
+}
+
+type verificationCodeManager struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	sender sms.Sender
+	cfg    *config.VerificationConfig
+}
+
+func NewVerificationCodeManager(db *gorm.DB, redisClient *redis.Client, sender sms.Sender, cfg *config.VerificationConfig) VerificationCodeManager {
+	return &verificationCodeManager{
+		db:     db,
+		redis:  redisClient,
+		sender: sender,
+		cfg:    cfg,
+	}
+}
+
+// SendCode replaces the previous code of the purpose, so only the last sent code is valid
+func (m *verificationCodeManager) SendCode(customer *data.Customer, purpose data.VerificationPurpose) error {
+	if err := m.reserveSending(customer.Phone, purpose); err != nil {
+		return err
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+
+	verificationCode := &data.VerificationCode{
+		CustomerID: customer.ID,
+		Purpose:    purpose,
+		Code:       code,
+		ExpiresAt:  time.Now().UTC().Add(m.cfg.CodeTTL),
+	}
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteCodes(tx, customer.ID, purpose); err != nil {
+			return err
+		}
+		return tx.Create(verificationCode).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save verification code of customer %d: %w", customer.ID, err)
+	}
+
+	if err := m.sender.Send(customer.Phone, fmt.Sprintf(codeMessages[purpose], code)); err != nil {
+		_ = deleteCodes(m.db, customer.ID, purpose)
+		return fmt.Errorf("failed to send verification code to customer %d: %w", customer.ID, err)
+	}
+	return nil
+}
+
+// VerifyCode consumes the code, every check counts as an attempt and the code is dropped after too many wrong ones.
+// The attempt is taken before the code is compared, so the concurrent checks can not exceed the limit
+func (m *verificationCodeManager) VerifyCode(customerID uint, purpose data.VerificationPurpose, code string) error {
+	var verificationCode data.VerificationCode
+	result := m.db.Model(&verificationCode).
+		Clauses(clause.Returning{}).
+		Where("customer_id = ? AND purpose = ? AND expires_at > ? AND attempts < ?", customerID, purpose, time.Now().UTC(), m.cfg.MaxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("failed to count verification attempt of customer %d: %w", customerID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return types.ErrInvalidVerificationCode
+	}
+
+	if subtle.ConstantTimeCompare([]byte(verificationCode.Code), []byte(code)) == 1 {
+		if err := deleteCodes(m.db, customerID, purpose); err != nil {
+			return fmt.Errorf("failed to consume verification code of customer %d: %w", customerID, err)
+		}
+		return nil
+	}
+
+	if verificationCode.Attempts >= m.cfg.MaxAttempts {
+		if err := deleteCodes(m.db, customerID, purpose); err != nil {
+			return fmt.Errorf("failed to drop verification code of customer %d: %w", customerID, err)
+		}
+		return types.ErrVerificationAttempts
+	}
+	return types.ErrInvalidVerificationCode
+}
+
+func (m *verificationCodeManager) reserveSending(phone string, purpose data.VerificationPurpose) error {
+	ctx := context.Background()
+
+	reserved, err := m.redis.SetNX(ctx, cooldownKeyPrefix+string(purpose)+":"+phone, 1, m.cfg.ResendCooldown).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check verification cooldown: %w", err)
+	}
+	if !reserved {
+		return types.ErrVerificationCooldown
+	}
+
+	countKey := countKeyPrefix + phone
+	count, err := m.redis.Incr(ctx, countKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count verification codes: %w", err)
+	}
+	if count == 1 {
+		if err := m.redis.Expire(ctx, countKey, rateLimitWindow).Err(); err != nil {
+			return fmt.Errorf("failed to count verification codes: %w", err)
+		}
+	}
+	if count > int64(m.cfg.MaxCodesPerHour) {
+		return types.ErrVerificationLimit
+	}
+	return nil
+}
+
+func deleteCodes(db *gorm.DB, customerID uint, purpose data.VerificationPurpose) error {
+	return db.Unscoped().
+		Where("customer_id = ? AND purpose = ?", customerID, purpose).
+		Delete(&data.VerificationCode{}).Error
+}
+
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", codeDigits, n.Int64()), nil
+}
@@ -0,0 +1,84 @@
+package bonuses
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type BonusHandler struct {
+	service BonusService
+}
+
+func NewBonusHandler(service BonusService) *BonusHandler {
+	return &BonusHandler{service: service}
+}
+
+func (h *BonusHandler) GetCustomerBalance(c *gin.Context) {
+	customerID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Bonus)
+		return
+	}
+
+	h.sendBalance(c, customerID)
+}
+
+func (h *BonusHandler) GetCustomerBonuses(c *gin.Context) {
+	customerID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Bonus)
+		return
+	}
+
+	h.sendBonuses(c, customerID)
+}
+
+func (h *BonusHandler) GetMyBalance(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	h.sendBalance(c, claims.CustomerID)
+}
+
+func (h *BonusHandler) GetMyBonuses(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	h.sendBonuses(c, claims.CustomerID)
+}
+
+func (h *BonusHandler) sendBalance(c *gin.Context, customerID uint) {
+	balance, err := h.service.GetBalance(customerID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500BonusGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, balance)
+}
+
+func (h *BonusHandler) sendBonuses(c *gin.Context, customerID uint) {
+	var filter types.BonusesFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.Bonus{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	bonuses, err := h.service.GetBonuses(customerID, &filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500BonusGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, bonuses, filter.Pagination)
+}
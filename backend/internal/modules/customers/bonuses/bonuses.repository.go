@@ -0,0 +1,347 @@
+package bonuses
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var creditBonusTypes = []data.BonusType{
+	data.BonusTypeAccrual,
+	data.BonusTypeReferral,
+	data.BonusTypeReturn,
+}
+
+type BonusRepository interface {
+	CloneWithTransaction(tx *gorm.DB) BonusRepository
+
+	GetBalance(customerID uint, at time.Time) (float64, error)
+	GetNextExpiration(customerID uint, at time.Time) (*types.BonusExpirationDTO, error)
+	GetBonuses(customerID uint, filter *types.BonusesFilter) ([]data.Bonus, error)
+	CreateBonus(bonus *data.Bonus) error
+	HasOrderBonus(orderID uint, bonusType data.BonusType) (bool, error)
+
+	RedeemBonuses(customerID, orderID uint, amount float64, at time.Time) error
+	ReturnRedeemedBonuses(orderID uint, cfg *config.LoyaltyConfig, at time.Time) error
+	ReverseOrderBonus(orderID uint, bonusType data.BonusType, kept float64, at time.Time) error
+	ExpireBonuses(at time.Time) (int, error)
+
+	GetUnrewardedReferral(refereeID uint) (*data.Referral, error)
+	MarkReferralRewarded(referralID uint, at time.Time) error
+}
+
+type bonusRepository struct {
+	db *gorm.DB
+}
+
+func NewBonusRepository(db *gorm.DB) BonusRepository {
+	return &bonusRepository{db: db}
+}
+
+func (r *bonusRepository) CloneWithTransaction(tx *gorm.DB) BonusRepository {
+	return &bonusRepository{db: tx}
+}
+
+func (r *bonusRepository) spendableBonusesQuery(customerID uint, at time.Time) *gorm.DB {
+	return r.db.Model(&data.Bonus{}).
+		Where("customer_id = ?", customerID).
+		Where("type IN ?", creditBonusTypes).
+		Where("remaining > 0").
+		Where("(expires_at IS NULL OR expires_at > ?)", at)
+}
+
+func (r *bonusRepository) GetBalance(customerID uint, at time.Time) (float64, error) {
+	var balance float64
+	err := r.spendableBonusesQuery(customerID, at).
+		Select("COALESCE(SUM(remaining), 0)").
+		Scan(&balance).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate bonus balance of customer %d: %w", customerID, err)
+	}
+	return balance, nil
+}
+
+func (r *bonusRepository) GetNextExpiration(customerID uint, at time.Time) (*types.BonusExpirationDTO, error) {
+	var bonus data.Bonus
+	err := r.spendableBonusesQuery(customerID, at).
+		Where("expires_at IS NOT NULL").
+		Order("expires_at ASC").
+		First(&bonus).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch next bonus expiration of customer %d: %w", customerID, err)
+	}
+
+	var amount float64
+	err = r.spendableBonusesQuery(customerID, at).
+		Where("expires_at = ?", bonus.ExpiresAt).
+		Select("COALESCE(SUM(remaining), 0)").
+		Scan(&amount).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next bonus expiration of customer %d: %w", customerID, err)
+	}
+
+	return &types.BonusExpirationDTO{
+		Amount:    amount,
+		ExpiresAt: *bonus.ExpiresAt,
+	}, nil
+}
+
+func (r *bonusRepository) GetBonuses(customerID uint, filter *types.BonusesFilter) ([]data.Bonus, error) {
+	var bonuses []data.Bonus
+
+	query := r.db.Model(&data.Bonus{}).Where("customer_id = ?", customerID)
+
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.Bonus{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&bonuses).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bonuses of customer %d: %w", customerID, err)
+	}
+
+	return bonuses, nil
+}
+
+func (r *bonusRepository) CreateBonus(bonus *data.Bonus) error {
+	if err := r.db.Create(bonus).Error; err != nil {
+		return fmt.Errorf("failed to create bonus: %w", err)
+	}
+	return nil
+}
+
+func (r *bonusRepository) HasOrderBonus(orderID uint, bonusType data.BonusType) (bool, error) {
+	var count int64
+	err := r.db.Model(&data.Bonus{}).
+		Where("order_id = ? AND type = ?", orderID, bonusType).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s bonus of order %d: %w", bonusType, orderID, err)
+	}
+	return count > 0, nil
+}
+
+// RedeemBonuses spends the credit entries which expire first and writes a single REDEMPTION entry
+func (r *bonusRepository) RedeemBonuses(customerID, orderID uint, amount float64, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var credits []data.Bonus
+		err := (&bonusRepository{db: tx}).spendableBonusesQuery(customerID, at).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Order("expires_at ASC NULLS LAST, id ASC").
+			Find(&credits).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch bonuses of customer %d: %w", customerID, err)
+		}
+
+		left := amount
+		for i := range credits {
+			if left <= 0 {
+				break
+			}
+
+			spent := min(credits[i].Remaining, left)
+			remaining := utils.RoundToDecimal(credits[i].Remaining-spent, 2)
+			if err := tx.Model(&credits[i]).Update("remaining", remaining).Error; err != nil {
+				return fmt.Errorf("failed to spend bonus %d: %w", credits[i].ID, err)
+			}
+			left = utils.RoundToDecimal(left-spent, 2)
+		}
+
+		if left > 0 {
+			return types.ErrInsufficientBonuses
+		}
+
+		redemption := &data.Bonus{
+			Bonuses:    amount,
+			Type:       data.BonusTypeRedemption,
+			CustomerID: customerID,
+			OrderID:    &orderID,
+		}
+		if err := tx.Create(redemption).Error; err != nil {
+			return fmt.Errorf("failed to create bonus redemption: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReturnRedeemedBonuses credits the bonuses spent on the order back once, the redemption is locked so concurrent returns wait for each other
+func (r *bonusRepository) ReturnRedeemedBonuses(orderID uint, cfg *config.LoyaltyConfig, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var redemption data.Bonus
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? AND type = ?", orderID, data.BonusTypeRedemption).
+			First(&redemption).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch redeemed bonuses of order %d: %w", orderID, err)
+		}
+
+		returned, err := (&bonusRepository{db: tx}).HasOrderBonus(orderID, data.BonusTypeReturn)
+		if err != nil || returned {
+			return err
+		}
+
+		bonus := types.NewCreditBonus(redemption.CustomerID, data.BonusTypeReturn, redemption.Bonuses, cfg, at)
+		bonus.OrderID = &orderID
+		if err := tx.Create(bonus).Error; err != nil {
+			return fmt.Errorf("failed to return redeemed bonuses of order %d: %w", orderID, err)
+		}
+		return nil
+	})
+}
+
+// ReverseOrderBonus takes back the part of the order credit above the kept amount which is not reversed yet,
+// the credit is locked so concurrent refunds of the order reverse it only once
+func (r *bonusRepository) ReverseOrderBonus(orderID uint, bonusType data.BonusType, kept float64, at time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var credit data.Bonus
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? AND type = ?", orderID, bonusType).
+			First(&credit).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch %s bonus of order %d: %w", bonusType, orderID, err)
+		}
+
+		var reversed float64
+		err = tx.Model(&data.Bonus{}).
+			Where("order_id = ? AND customer_id = ? AND type = ?", orderID, credit.CustomerID, data.BonusTypeReversal).
+			Select("COALESCE(SUM(bonuses), 0)").
+			Scan(&reversed).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch reversed bonuses of order %d: %w", orderID, err)
+		}
+
+		amount := types.CalculateReversal(credit.Bonuses, kept, reversed)
+		if amount <= 0 {
+			return nil
+		}
+
+		// the credit itself is spent first, the rest is taken from the other credits which expire first
+		credits := []data.Bonus{credit}
+		var others []data.Bonus
+		err = (&bonusRepository{db: tx}).spendableBonusesQuery(credit.CustomerID, at).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id <> ?", credit.ID).
+			Order("expires_at ASC NULLS LAST, id ASC").
+			Find(&others).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch bonuses of customer %d: %w", credit.CustomerID, err)
+		}
+		credits = append(credits, others...)
+
+		left := amount
+		for i := range credits {
+			if left <= 0 {
+				break
+			}
+
+			spent := min(credits[i].Remaining, left)
+			if spent <= 0 {
+				continue
+			}
+			remaining := utils.RoundToDecimal(credits[i].Remaining-spent, 2)
+			if err := tx.Model(&credits[i]).Update("remaining", remaining).Error; err != nil {
+				return fmt.Errorf("failed to reverse bonus %d: %w", credits[i].ID, err)
+			}
+			left = utils.RoundToDecimal(left-spent, 2)
+		}
+
+		// the part which was already spent by the customer can not be taken back and is written off with the reversal
+		reversal := &data.Bonus{
+			Bonuses:    amount,
+			Type:       data.BonusTypeReversal,
+			CustomerID: credit.CustomerID,
+			OrderID:    &orderID,
+			ReferralID: credit.ReferralID,
+		}
+		if err := tx.Create(reversal).Error; err != nil {
+			return fmt.Errorf("failed to reverse %s bonus of order %d: %w", bonusType, orderID, err)
+		}
+
+		return nil
+	})
+}
+
+// ExpireBonuses writes off the unspent part of expired credit entries and returns the number of written off entries
+func (r *bonusRepository) ExpireBonuses(at time.Time) (int, error) {
+	var expired int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var credits []data.Bonus
+		err := tx.Model(&data.Bonus{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("type IN ?", creditBonusTypes).
+			Where("remaining > 0").
+			Where("expires_at IS NOT NULL AND expires_at <= ?", at).
+			Find(&credits).Error
+		if err != nil {
+			return fmt.Errorf("failed to fetch expired bonuses: %w", err)
+		}
+
+		for i := range credits {
+			expiration := &data.Bonus{
+				Bonuses:    credits[i].Remaining,
+				Type:       data.BonusTypeExpiration,
+				CustomerID: credits[i].CustomerID,
+				OrderID:    credits[i].OrderID,
+				ReferralID: credits[i].ReferralID,
+			}
+			if err := tx.Create(expiration).Error; err != nil {
+				return fmt.Errorf("failed to create bonus expiration: %w", err)
+			}
+
+			if err := tx.Model(&credits[i]).Update("remaining", 0).Error; err != nil {
+				return fmt.Errorf("failed to expire bonus %d: %w", credits[i].ID, err)
+			}
+		}
+
+		expired = len(credits)
+		return nil
+	})
+
+	return expired, err
+}
+
+func (r *bonusRepository) GetUnrewardedReferral(refereeID uint) (*data.Referral, error) {
+	var referral data.Referral
+	err := r.db.
+		Where("referee_id = ? AND rewarded_at IS NULL", refereeID).
+		First(&referral).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch referral of customer %d: %w", refereeID, err)
+	}
+	return &referral, nil
+}
+
+func (r *bonusRepository) MarkReferralRewarded(referralID uint, at time.Time) error {
+	err := r.db.Model(&data.Referral{}).
+		Where("id = ?", referralID).
+		Update("rewarded_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark referral %d as rewarded: %w", referralID, err)
+	}
+	return nil
+}
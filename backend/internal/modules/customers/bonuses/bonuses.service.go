@@ -0,0 +1,89 @@
+package bonuses
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
+	"go.uber.org/zap"
+)
+
+type BonusService interface {
+	GetBalance(customerID uint) (*types.BonusBalanceDTO, error)
+	GetBonuses(customerID uint, filter *types.BonusesFilter) ([]types.BonusDTO, error)
+	ReturnRedeemedBonuses(orderID uint) error
+	ExpireBonuses() error
+}
+
+type bonusService struct {
+	repo   BonusRepository
+	logger *zap.SugaredLogger
+}
+
+func NewBonusService(repo BonusRepository, logger *zap.SugaredLogger) BonusService {
+	return &bonusService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *bonusService) GetBalance(customerID uint) (*types.BonusBalanceDTO, error) {
+	now := time.Now()
+
+	balance, err := s.repo.GetBalance(customerID, now)
+	if err != nil {
+		wrappedErr := fmt.Errorf("%w: %w", types.ErrFailedToFetchBonuses, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	nextExpiration, err := s.repo.GetNextExpiration(customerID, now)
+	if err != nil {
+		wrappedErr := fmt.Errorf("%w: %w", types.ErrFailedToFetchBonuses, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return &types.BonusBalanceDTO{
+		CustomerID:     customerID,
+		Balance:        balance,
+		NextExpiration: nextExpiration,
+	}, nil
+}
+
+func (s *bonusService) GetBonuses(customerID uint, filter *types.BonusesFilter) ([]types.BonusDTO, error) {
+	bonuses, err := s.repo.GetBonuses(customerID, filter)
+	if err != nil {
+		wrappedErr := fmt.Errorf("%w: %w", types.ErrFailedToFetchBonuses, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	dtos := make([]types.BonusDTO, len(bonuses))
+	for i := range bonuses {
+		dtos[i] = types.ConvertToBonusDTO(&bonuses[i])
+	}
+	return dtos, nil
+}
+
+// ReturnRedeemedBonuses gives the bonuses spent on an order back to the customer, e.g. when the order was never paid
+func (s *bonusService) ReturnRedeemedBonuses(orderID uint) error {
+	if err := s.repo.ReturnRedeemedBonuses(orderID, &config.GetConfig().Loyalty, time.Now()); err != nil {
+		s.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (s *bonusService) ExpireBonuses() error {
+	expired, err := s.repo.ExpireBonuses(time.Now())
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to expire bonuses: %w", err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	s.logger.Infof("expired %d bonus entries", expired)
+	return nil
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type BonusBalanceDTO struct {
+	CustomerID     uint                `json:"customerId"`
+	Balance        float64             `json:"balance"`
+	NextExpiration *BonusExpirationDTO `json:"nextExpiration,omitempty"`
+}
+
+type BonusExpirationDTO struct {
+	Amount    float64   `json:"amount"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type BonusDTO struct {
+	ID        uint           `json:"id"`
+	Type      data.BonusType `json:"type"`
+	Amount    float64        `json:"amount"`
+	Remaining float64        `json:"remaining"`
+	OrderID   *uint          `json:"orderId,omitempty"`
+	ExpiresAt *time.Time     `json:"expiresAt,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+type BonusesFilter struct {
+	Type *data.BonusType `form:"type"`
+	utils.BaseFilter
+}
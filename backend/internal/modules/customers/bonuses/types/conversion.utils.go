@@ -0,0 +1,59 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+func ConvertToBonusDTO(bonus *data.Bonus) BonusDTO {
+	return BonusDTO{
+		ID:        bonus.ID,
+		Type:      bonus.Type,
+		Amount:    bonus.Bonuses,
+		Remaining: bonus.Remaining,
+		OrderID:   bonus.OrderID,
+		ExpiresAt: bonus.ExpiresAt,
+		CreatedAt: bonus.CreatedAt,
+	}
+}
+
+// NewCreditBonus builds a spendable ledger entry which expires after the configured bonus TTL
+func NewCreditBonus(customerID uint, bonusType data.BonusType, amount float64, cfg *config.LoyaltyConfig, now time.Time) *data.Bonus {
+	bonus := &data.Bonus{
+		Bonuses:    utils.RoundToDecimal(amount, 2),
+		Remaining:  utils.RoundToDecimal(amount, 2),
+		Type:       bonusType,
+		CustomerID: customerID,
+	}
+
+	if cfg.BonusTTL > 0 {
+		expiresAt := now.Add(cfg.BonusTTL)
+		bonus.ExpiresAt = &expiresAt
+	}
+
+	return bonus
+}
+
+// CalculateOrderAccrual returns the bonuses earned by the money actually paid for the goods of the order
+func CalculateOrderAccrual(paid float64, cfg *config.LoyaltyConfig) float64 {
+	if cfg.AccrualPercent <= 0 || paid <= 0 {
+		return 0
+	}
+	return utils.RoundToDecimal(paid*cfg.AccrualPercent/100, 2)
+}
+
+// CalculateReversal returns the part of the credited bonuses above the kept amount which is not reversed yet
+func CalculateReversal(credited, kept, reversed float64) float64 {
+	return utils.RoundToDecimal(max(credited-min(max(kept, 0), credited)-reversed, 0), 2)
+}
+
+// CalculateMaxRedeemable limits the part of the order total which can be paid by bonuses
+func CalculateMaxRedeemable(total float64, cfg *config.LoyaltyConfig) float64 {
+	if cfg.MaxRedeemPercent <= 0 || total <= 0 {
+		return 0
+	}
+	return utils.RoundToDecimal(total*min(cfg.MaxRedeemPercent, 100)/100, 2)
+}
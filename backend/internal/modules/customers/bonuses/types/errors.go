@@ -0,0 +1,12 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrInsufficientBonuses  = moduleErrors.NewModuleError(errors.New("insufficient bonuses"))
+	ErrFailedToFetchBonuses = moduleErrors.NewModuleError(errors.New("failed to fetch bonuses"))
+)
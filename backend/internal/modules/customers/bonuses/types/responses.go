@@ -0,0 +1,13 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500BonusGet = localization.NewResponseKey(http.StatusInternalServerError, data.BonusComponent, data.GetOperation.ToString())
+	Response400Bonus    = localization.NewResponseKey(http.StatusBadRequest, data.BonusComponent)
+)
@@ -1,5 +1,18 @@
 package customers
 
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	authTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/auth/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
 type CustomerHandler struct {
 	service CustomerService
 }
@@ -7,3 +20,216 @@ type CustomerHandler struct {
 func NewCustomerHandler(service CustomerService) *CustomerHandler {
 	return &CustomerHandler{service: service}
 }
+
+func (h *CustomerHandler) GetMyProfile(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetProfile(customerID)
+	if err != nil {
+		if errors.Is(err, types.ErrCustomerNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Customer)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500CustomerGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, profile)
+}
+
+func (h *CustomerHandler) UpdateMyProfile(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	var dto types.UpdateCustomerProfileDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(customerID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrCustomerNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Customer)
+		case errors.Is(err, moduleErrors.ErrValidation):
+			localization.SendLocalizedResponseWithKey(c, types.Response400Customer)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500CustomerUpdate)
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, profile)
+}
+
+func (h *CustomerHandler) UpdateMyPassword(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	var dto types.UpdateCustomerPasswordDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	if err := h.service.UpdatePassword(customerID, &dto); err != nil {
+		switch {
+		case errors.Is(err, types.ErrCustomerNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Customer)
+		case errors.Is(err, types.ErrIncorrectPassword), errors.Is(err, moduleErrors.ErrValidation):
+			localization.SendLocalizedResponseWithKey(c, types.Response400CustomerPassword)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500CustomerUpdate)
+		}
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200CustomerUpdatePassword)
+}
+
+func (h *CustomerHandler) DeleteMyAccount(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	var dto types.DeleteCustomerDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	if err := h.service.DeleteAccount(customerID, &dto); err != nil {
+		switch {
+		case errors.Is(err, types.ErrCustomerNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Customer)
+		case errors.Is(err, types.ErrIncorrectPassword):
+			localization.SendLocalizedResponseWithKey(c, types.Response400CustomerPassword)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500CustomerDelete)
+		}
+		return
+	}
+
+	utils.ClearCookie(c, authTypes.CUSTOMER_SESSION_COOKIE_KEY)
+	localization.SendLocalizedResponseWithKey(c, types.Response200CustomerDelete)
+}
+
+func (h *CustomerHandler) GetMyAddresses(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	addresses, err := h.service.GetAddresses(customerID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500CustomerAddressGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, addresses)
+}
+
+func (h *CustomerHandler) CreateMyAddress(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	var dto types.CreateCustomerAddressDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	address, err := h.service.CreateAddress(customerID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrInvalidCustomerAddress):
+			localization.SendLocalizedResponseWithKey(c, types.Response400CustomerAddress)
+		case errors.Is(err, types.ErrCustomerAddressExists):
+			localization.SendLocalizedResponseWithKey(c, types.Response409CustomerAddress)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500CustomerAddressCreate)
+		}
+		return
+	}
+
+	utils.SendResponseWithStatus(c, address, http.StatusCreated)
+}
+
+func (h *CustomerHandler) UpdateMyAddress(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	addressID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400CustomerAddress)
+		return
+	}
+
+	var dto types.UpdateCustomerAddressDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	if err := h.service.UpdateAddress(customerID, addressID, &dto); err != nil {
+		switch {
+		case errors.Is(err, types.ErrCustomerAddressNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404CustomerAddress)
+		case errors.Is(err, types.ErrInvalidCustomerAddress):
+			localization.SendLocalizedResponseWithKey(c, types.Response400CustomerAddress)
+		case errors.Is(err, types.ErrCustomerAddressExists):
+			localization.SendLocalizedResponseWithKey(c, types.Response409CustomerAddress)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500CustomerAddressUpdate)
+		}
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200CustomerAddressUpdate)
+}
+
+func (h *CustomerHandler) DeleteMyAddress(c *gin.Context) {
+	customerID, ok := getCustomerID(c)
+	if !ok {
+		return
+	}
+
+	addressID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400CustomerAddress)
+		return
+	}
+
+	if err := h.service.DeleteAddress(customerID, addressID); err != nil {
+		if errors.Is(err, types.ErrCustomerAddressNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404CustomerAddress)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500CustomerAddressDelete)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200CustomerAddressDelete)
+}
+
+func getCustomerID(c *gin.Context) (uint, bool) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return 0, false
+	}
+	return claims.CustomerID, true
+}
@@ -1,12 +1,26 @@
 package customers
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/types"
 	"gorm.io/gorm"
 )
 
 type CustomerRepository interface {
 	GetCustomerByID(customerID uint) (*data.Customer, error)
+	UpdateCustomer(customer *data.Customer) error
+	UpdateCustomerPassword(customerID uint, hashedPassword string) error
+	DeleteCustomer(customerID uint) error
+
+	GetCustomerAddresses(customerID uint) ([]data.CustomerAddress, error)
+	GetCustomerAddressByID(customerID, addressID uint) (*data.CustomerAddress, error)
+	CreateCustomerAddress(address *data.CustomerAddress) error
+	UpdateCustomerAddress(address *data.CustomerAddress) error
+	DeleteCustomerAddress(customerID, addressID uint) error
 }
 
 type customerRepository struct {
@@ -20,5 +34,123 @@ func NewCustomerRepository(db *gorm.DB) CustomerRepository {
 func (r *customerRepository) GetCustomerByID(customerID uint) (*data.Customer, error) {
 	var customer data.Customer
 	err := r.db.First(&customer, customerID).Error
-	return &customer, err
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *customerRepository) UpdateCustomer(customer *data.Customer) error {
+	return r.db.Model(&data.Customer{}).
+		Where("id = ?", customer.ID).
+		Updates(map[string]interface{}{
+			"first_name": customer.FirstName,
+			"last_name":  customer.LastName,
+		}).Error
+}
+
+func (r *customerRepository) UpdateCustomerPassword(customerID uint, hashedPassword string) error {
+	return r.db.Model(&data.Customer{}).
+		Where("id = ?", customerID).
+		Update("password", hashedPassword).Error
+}
+
+// DeleteCustomer removes the account with its addresses, the orders stay in the history of the stores.
+// The customer row is soft deleted for the orders referencing it, so its personal data is erased before,
+// the addresses are hard deleted and the orders delivered to them keep no address
+func (r *customerRepository) DeleteCustomer(customerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&data.Customer{}).
+			Where("id = ?", customerID).
+			Updates(map[string]interface{}{
+				"first_name": "",
+				"last_name":  "",
+				"phone":      types.AnonymizedPhone(customerID),
+				"password":   "",
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to anonymize customer %d: %w", customerID, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return types.ErrCustomerNotFound
+		}
+
+		if err := tx.Unscoped().Where("customer_id = ?", customerID).Delete(&data.CustomerAddress{}).Error; err != nil {
+			return fmt.Errorf("failed to delete addresses of customer %d: %w", customerID, err)
+		}
+
+		if err := tx.Delete(&data.Customer{}, customerID).Error; err != nil {
+			return fmt.Errorf("failed to delete customer %d: %w", customerID, err)
+		}
+		return nil
+	})
+}
+
+func (r *customerRepository) GetCustomerAddresses(customerID uint) ([]data.CustomerAddress, error) {
+	var addresses []data.CustomerAddress
+	err := r.db.
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch addresses of customer %d: %w", customerID, err)
+	}
+	return addresses, nil
+}
+
+func (r *customerRepository) GetCustomerAddressByID(customerID, addressID uint) (*data.CustomerAddress, error) {
+	var address data.CustomerAddress
+	err := r.db.
+		Where("id = ? AND customer_id = ?", addressID, customerID).
+		First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrCustomerAddressNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch address %d of customer %d: %w", addressID, customerID, err)
+	}
+	return &address, nil
+}
+
+func (r *customerRepository) CreateCustomerAddress(address *data.CustomerAddress) error {
+	if err := r.db.Create(address).Error; err != nil {
+		if strings.Contains(err.Error(), "23505") { // unique constraint
+			return types.ErrCustomerAddressExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *customerRepository) UpdateCustomerAddress(address *data.CustomerAddress) error {
+	err := r.db.Model(&data.CustomerAddress{}).
+		Where("id = ? AND customer_id = ?", address.ID, address.CustomerID).
+		Updates(map[string]interface{}{
+			"address":   address.Address,
+			"longitude": address.Longitude,
+			"latitude":  address.Latitude,
+		}).Error
+	if err != nil {
+		if strings.Contains(err.Error(), "23505") { // unique constraint
+			return types.ErrCustomerAddressExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *customerRepository) DeleteCustomerAddress(customerID, addressID uint) error {
+	res := r.db.
+		Where("id = ? AND customer_id = ?", addressID, customerID).
+		Delete(&data.CustomerAddress{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete address %d of customer %d: %w", addressID, customerID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return types.ErrCustomerAddressNotFound
+	}
+	return nil
 }
@@ -1,14 +1,27 @@
 package customers
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"go.uber.org/zap"
 )
 
 type CustomerService interface {
 	GetCustomerById(id uint) (*types.CustomerAdminDTO, error)
+
+	GetProfile(customerID uint) (*types.CustomerProfileDTO, error)
+	UpdateProfile(customerID uint, dto *types.UpdateCustomerProfileDTO) (*types.CustomerProfileDTO, error)
+	UpdatePassword(customerID uint, dto *types.UpdateCustomerPasswordDTO) error
+	DeleteAccount(customerID uint, dto *types.DeleteCustomerDTO) error
+
+	GetAddresses(customerID uint) ([]types.CustomerAddressDTO, error)
+	CreateAddress(customerID uint, dto *types.CreateCustomerAddressDTO) (*types.CustomerAddressDTO, error)
+	UpdateAddress(customerID, addressID uint, dto *types.UpdateCustomerAddressDTO) error
+	DeleteAddress(customerID, addressID uint) error
 }
 
 type customerService struct {
@@ -33,3 +46,150 @@ func (s *customerService) GetCustomerById(customerID uint) (*types.CustomerAdmin
 
 	return types.MapToCustomerDTO(customer), nil
 }
+
+func (s *customerService) GetProfile(customerID uint) (*types.CustomerProfileDTO, error) {
+	customer, err := s.repo.GetCustomerByID(customerID)
+	if err != nil {
+		if !errors.Is(err, types.ErrCustomerNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	return types.MapToCustomerProfileDTO(customer), nil
+}
+
+func (s *customerService) UpdateProfile(customerID uint, dto *types.UpdateCustomerProfileDTO) (*types.CustomerProfileDTO, error) {
+	customer, err := s.repo.GetCustomerByID(customerID)
+	if err != nil {
+		if !errors.Is(err, types.ErrCustomerNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	if err := types.UpdateCustomerProfileFields(customer, dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCustomer(customer); err != nil {
+		wrappedErr := fmt.Errorf("failed to update customer %d: %w", customerID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return types.MapToCustomerProfileDTO(customer), nil
+}
+
+func (s *customerService) UpdatePassword(customerID uint, dto *types.UpdateCustomerPasswordDTO) error {
+	if err := s.checkPassword(customerID, dto.CurrentPassword); err != nil {
+		return err
+	}
+
+	if err := utils.IsValidPassword(dto.NewPassword); err != nil {
+		return moduleErrors.ErrValidation.WithDetails("newPassword", fmt.Sprintf("password validation failed: %v", err))
+	}
+
+	hashedPassword, err := utils.HashPassword(dto.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.UpdateCustomerPassword(customerID, hashedPassword); err != nil {
+		wrappedErr := fmt.Errorf("failed to update password of customer %d: %w", customerID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+	return nil
+}
+
+// DeleteAccount requires the password, the deleted customer can register again with the same phone
+func (s *customerService) DeleteAccount(customerID uint, dto *types.DeleteCustomerDTO) error {
+	if err := s.checkPassword(customerID, dto.Password); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCustomer(customerID); err != nil {
+		if !errors.Is(err, types.ErrCustomerNotFound) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+
+	s.logger.Infof("customer with ID=%d DELETED their account", customerID)
+	return nil
+}
+
+func (s *customerService) checkPassword(customerID uint, password string) error {
+	customer, err := s.repo.GetCustomerByID(customerID)
+	if err != nil {
+		if !errors.Is(err, types.ErrCustomerNotFound) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+
+	if err := utils.ComparePassword(customer.Password, password); err != nil {
+		return types.ErrIncorrectPassword
+	}
+	return nil
+}
+
+func (s *customerService) GetAddresses(customerID uint) ([]types.CustomerAddressDTO, error) {
+	addresses, err := s.repo.GetCustomerAddresses(customerID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return types.ConvertToCustomerAddressDTOs(addresses), nil
+}
+
+func (s *customerService) CreateAddress(customerID uint, dto *types.CreateCustomerAddressDTO) (*types.CustomerAddressDTO, error) {
+	address, err := types.CreateToCustomerAddressModel(customerID, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCustomerAddress(address); err != nil {
+		if !errors.Is(err, types.ErrCustomerAddressExists) {
+			s.logger.Error(fmt.Errorf("failed to create address of customer %d: %w", customerID, err))
+		}
+		return nil, err
+	}
+
+	response := types.ConvertToCustomerAddressDTO(address)
+	return &response, nil
+}
+
+func (s *customerService) UpdateAddress(customerID, addressID uint, dto *types.UpdateCustomerAddressDTO) error {
+	address, err := s.repo.GetCustomerAddressByID(customerID, addressID)
+	if err != nil {
+		if !errors.Is(err, types.ErrCustomerAddressNotFound) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+
+	if err := types.UpdateCustomerAddressFields(address, dto); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateCustomerAddress(address); err != nil {
+		if !errors.Is(err, types.ErrCustomerAddressExists) {
+			s.logger.Error(fmt.Errorf("failed to update address %d of customer %d: %w", addressID, customerID, err))
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *customerService) DeleteAddress(customerID, addressID uint) error {
+	if err := s.repo.DeleteCustomerAddress(customerID, addressID); err != nil {
+		if !errors.Is(err, types.ErrCustomerAddressNotFound) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+	return nil
+}
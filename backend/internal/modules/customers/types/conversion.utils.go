@@ -1,18 +1,120 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 )
 
 func MapToCustomerDTO(customer *data.Customer) *CustomerAdminDTO {
 	return &CustomerAdminDTO{
-		CustomerDTO: CustomerDTO{
-			ID:        customer.ID,
-			FirstName: customer.FirstName,
-			LastName:  customer.LastName,
-			Phone:     customer.Phone,
-		},
-		IsVerified: customer.IsVerified,
-		IsBanned:   customer.IsBanned,
+		CustomerDTO: mapToBaseCustomerDTO(customer),
+		IsVerified:  customer.IsVerified,
+		IsBanned:    customer.IsBanned,
+	}
+}
+
+func MapToCustomerProfileDTO(customer *data.Customer) *CustomerProfileDTO {
+	return &CustomerProfileDTO{
+		CustomerDTO: mapToBaseCustomerDTO(customer),
+		IsVerified:  customer.IsVerified,
+		CreatedAt:   customer.CreatedAt,
+	}
+}
+
+func mapToBaseCustomerDTO(customer *data.Customer) CustomerDTO {
+	return CustomerDTO{
+		ID:        customer.ID,
+		FirstName: customer.FirstName,
+		LastName:  customer.LastName,
+		Phone:     customer.Phone,
+	}
+}
+
+func ConvertToCustomerAddressDTO(address *data.CustomerAddress) CustomerAddressDTO {
+	return CustomerAddressDTO{
+		ID:        address.ID,
+		Address:   address.Address,
+		Longitude: address.Longitude,
+		Latitude:  address.Latitude,
 	}
 }
+
+func ConvertToCustomerAddressDTOs(addresses []data.CustomerAddress) []CustomerAddressDTO {
+	dtos := make([]CustomerAddressDTO, len(addresses))
+	for i := range addresses {
+		dtos[i] = ConvertToCustomerAddressDTO(&addresses[i])
+	}
+	return dtos
+}
+
+func UpdateCustomerProfileFields(customer *data.Customer, dto *UpdateCustomerProfileDTO) error {
+	if dto.FirstName != nil {
+		if strings.TrimSpace(*dto.FirstName) == "" {
+			return moduleErrors.ErrValidation.WithDetails("firstName", "customer first name cannot contain empty values")
+		}
+		customer.FirstName = strings.TrimSpace(*dto.FirstName)
+	}
+
+	if dto.LastName != nil {
+		if strings.TrimSpace(*dto.LastName) == "" {
+			return moduleErrors.ErrValidation.WithDetails("lastName", "customer last name cannot contain empty values")
+		}
+		customer.LastName = strings.TrimSpace(*dto.LastName)
+	}
+
+	return nil
+}
+
+func CreateToCustomerAddressModel(customerID uint, dto *CreateCustomerAddressDTO) (*data.CustomerAddress, error) {
+	address := &data.CustomerAddress{
+		CustomerID: customerID,
+		Address:    strings.TrimSpace(dto.Address),
+		Longitude:  dto.Longitude,
+		Latitude:   dto.Latitude,
+	}
+
+	if err := validateCustomerAddress(address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func UpdateCustomerAddressFields(address *data.CustomerAddress, dto *UpdateCustomerAddressDTO) error {
+	if dto.Address != nil {
+		address.Address = strings.TrimSpace(*dto.Address)
+	}
+	if dto.Longitude != nil {
+		address.Longitude = *dto.Longitude
+	}
+	if dto.Latitude != nil {
+		address.Latitude = *dto.Latitude
+	}
+
+	return validateCustomerAddress(address)
+}
+
+// validateCustomerAddress requires both coordinates or none, the delivery fee is calculated from them
+func validateCustomerAddress(address *data.CustomerAddress) error {
+	if address.Address == "" {
+		return ErrInvalidCustomerAddress
+	}
+
+	if address.Longitude == "" && address.Latitude == "" {
+		return nil
+	}
+
+	if _, _, err := utils.ParseCoordinates(address.Latitude, address.Longitude); err != nil {
+		return ErrInvalidCustomerAddress
+	}
+	return nil
+}
+
+// AnonymizedPhone replaces the phone of a deleted customer. It passes the phone check of the schema,
+// is unique per customer and uses the unassigned +999 country code, so it never belongs to a real person
+func AnonymizedPhone(customerID uint) string {
+	return fmt.Sprintf("+999%011d", customerID)
+}
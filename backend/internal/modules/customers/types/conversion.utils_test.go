@@ -0,0 +1,50 @@
+package types
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomerAddressValidation(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+
+	t.Run("Address without coordinates should be accepted", func(t *testing.T) {
+		address, err := CreateToCustomerAddressModel(1, &CreateCustomerAddressDTO{Address: " Abay 10 "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Abay 10", address.Address)
+		assert.Equal(t, uint(1), address.CustomerID)
+	})
+
+	t.Run("Address with a single coordinate should be rejected", func(t *testing.T) {
+		_, err := CreateToCustomerAddressModel(1, &CreateCustomerAddressDTO{Address: "Abay 10", Latitude: "43.24"})
+
+		assert.ErrorIs(t, err, ErrInvalidCustomerAddress)
+	})
+
+	t.Run("Update should validate the resulting coordinates", func(t *testing.T) {
+		address := &data.CustomerAddress{Address: "Abay 10", Latitude: "43.24", Longitude: "76.91"}
+
+		assert.ErrorIs(t, UpdateCustomerAddressFields(address, &UpdateCustomerAddressDTO{Latitude: strPtr("95")}), ErrInvalidCustomerAddress)
+		assert.NoError(t, UpdateCustomerAddressFields(address, &UpdateCustomerAddressDTO{Latitude: strPtr("43.25")}))
+		assert.Equal(t, "43.25", address.Latitude)
+	})
+
+	t.Run("Blank address should be rejected", func(t *testing.T) {
+		address := &data.CustomerAddress{Address: "Abay 10"}
+
+		assert.ErrorIs(t, UpdateCustomerAddressFields(address, &UpdateCustomerAddressDTO{Address: strPtr("  ")}), ErrInvalidCustomerAddress)
+	})
+}
+
+func TestAnonymizedPhone(t *testing.T) {
+	// the valid_phone domain of the schema
+	validPhone := regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+	assert.Regexp(t, validPhone, AnonymizedPhone(1))
+	assert.Regexp(t, validPhone, AnonymizedPhone(4294967295))
+	assert.NotEqual(t, AnonymizedPhone(1), AnonymizedPhone(2))
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type CustomerDTO struct {
 	ID        uint   `json:"id" binding:"required"`
 	FirstName string `json:"firstName" binding:"required"`
@@ -12,3 +14,43 @@ type CustomerAdminDTO struct {
 	IsVerified bool `json:"isVerified" binding:"required"`
 	IsBanned   bool `json:"isBanned" binding:"required"`
 }
+
+type CustomerProfileDTO struct {
+	CustomerDTO
+	IsVerified bool      `json:"isVerified"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type UpdateCustomerProfileDTO struct {
+	FirstName *string `json:"firstName" binding:"omitempty,min=1,max=255"`
+	LastName  *string `json:"lastName" binding:"omitempty,min=1,max=255"`
+}
+
+type UpdateCustomerPasswordDTO struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+// DeleteCustomerDTO confirms the account deletion by the customer password
+type DeleteCustomerDTO struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type CustomerAddressDTO struct {
+	ID        uint   `json:"id"`
+	Address   string `json:"address"`
+	Longitude string `json:"longitude"`
+	Latitude  string `json:"latitude"`
+}
+
+type CreateCustomerAddressDTO struct {
+	Address   string `json:"address" binding:"required,max=255"`
+	Longitude string `json:"longitude" binding:"omitempty,max=20"`
+	Latitude  string `json:"latitude" binding:"omitempty,max=20"`
+}
+
+type UpdateCustomerAddressDTO struct {
+	Address   *string `json:"address" binding:"omitempty,min=1,max=255"`
+	Longitude *string `json:"longitude" binding:"omitempty,max=20"`
+	Latitude  *string `json:"latitude" binding:"omitempty,max=20"`
+}
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrCustomerNotFound        = moduleErrors.NewModuleError(errors.New("customer not found"))
+	ErrIncorrectPassword       = moduleErrors.NewModuleError(errors.New("incorrect customer password"))
+	ErrCustomerAddressNotFound = moduleErrors.NewModuleError(errors.New("customer address not found"))
+	ErrCustomerAddressExists   = moduleErrors.NewModuleError(errors.New("customer address already exists"))
+	ErrInvalidCustomerAddress  = moduleErrors.NewModuleError(errors.New("invalid customer address"))
+)
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500CustomerGet            = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerComponent, data.GetOperation.ToString())
+	Response500CustomerUpdate         = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerComponent, data.UpdateOperation.ToString())
+	Response500CustomerDelete         = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerComponent, data.DeleteOperation.ToString())
+	Response400Customer               = localization.NewResponseKey(http.StatusBadRequest, data.CustomerComponent)
+	Response400CustomerPassword       = localization.NewResponseKey(http.StatusBadRequest, data.CustomerComponent, "PASSWORD")
+	Response404Customer               = localization.NewResponseKey(http.StatusNotFound, data.CustomerComponent)
+	Response200CustomerUpdate         = localization.NewResponseKey(http.StatusOK, data.CustomerComponent, data.UpdateOperation.ToString())
+	Response200CustomerUpdatePassword = localization.NewResponseKey(http.StatusOK, data.CustomerComponent, "UPDATE_PASSWORD")
+	Response200CustomerDelete         = localization.NewResponseKey(http.StatusOK, data.CustomerComponent, data.DeleteOperation.ToString())
+
+	Response500CustomerAddressCreate = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerAddressComponent, data.CreateOperation.ToString())
+	Response500CustomerAddressGet    = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerAddressComponent, data.GetOperation.ToString())
+	Response500CustomerAddressUpdate = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerAddressComponent, data.UpdateOperation.ToString())
+	Response500CustomerAddressDelete = localization.NewResponseKey(http.StatusInternalServerError, data.CustomerAddressComponent, data.DeleteOperation.ToString())
+	Response400CustomerAddress       = localization.NewResponseKey(http.StatusBadRequest, data.CustomerAddressComponent)
+	Response404CustomerAddress       = localization.NewResponseKey(http.StatusNotFound, data.CustomerAddressComponent)
+	Response409CustomerAddress       = localization.NewResponseKey(http.StatusConflict, data.CustomerAddressComponent)
+	Response200CustomerAddressUpdate = localization.NewResponseKey(http.StatusOK, data.CustomerAddressComponent, data.UpdateOperation.ToString())
+	Response200CustomerAddressDelete = localization.NewResponseKey(http.StatusOK, data.CustomerAddressComponent, data.DeleteOperation.ToString())
+)
@@ -1,6 +1,7 @@
 package employees
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -59,6 +60,31 @@ func (h *EmployeeHandler) UpdatePassword(c *gin.Context) {
 	utils.SendSuccessResponse(c, gin.H{"message": "password updated successfully"})
 }
 
+func (h *EmployeeHandler) UpdateCurrentEmployeePin(c *gin.Context) {
+	var input types.UpdateEmployeePinDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response401Employee)
+		return
+	}
+
+	if err := h.service.UpdateEmployeePin(employeeID, &input); err != nil {
+		if errors.Is(err, types.ErrIncorrectPassword) || errors.Is(err, types.ErrPinNotSupported) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400EmployeePin)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeUpdatePin)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200EmployeeUpdatePin)
+}
+
 func (h *EmployeeHandler) GetAllRoles(c *gin.Context) {
 	roles, err := h.service.GetAllRoles()
 	if err != nil {
@@ -208,3 +234,91 @@ func (h *EmployeeHandler) GetMyWorkdays(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, workdays)
 }
+
+func (h *EmployeeHandler) GetCurrentEmployeeSessions(c *gin.Context) {
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response401Employee)
+		return
+	}
+
+	sessions, err := h.service.GetEmployeeSessions(claims.EmployeeID, claims.SessionID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeGetSessions)
+		return
+	}
+
+	utils.SendSuccessResponse(c, sessions)
+}
+
+func (h *EmployeeHandler) RevokeCurrentEmployeeSession(c *gin.Context) {
+	sessionID, err := utils.ParseParam(c, "sessionId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Employee)
+		return
+	}
+
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response401Employee)
+		return
+	}
+
+	if err := h.service.RevokeEmployeeSession(claims.EmployeeID, sessionID); err != nil {
+		if errors.Is(err, types.ErrEmployeeSessionNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404EmployeeSession)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200EmployeeRevokeSessions)
+}
+
+// RevokeOtherCurrentEmployeeSessions logs the current employee out on all the other devices
+func (h *EmployeeHandler) RevokeOtherCurrentEmployeeSessions(c *gin.Context) {
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response401Employee)
+		return
+	}
+
+	if err := h.service.RevokeEmployeeSessions(claims.EmployeeID, claims.SessionID); err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200EmployeeRevokeSessions)
+}
+
+func (h *EmployeeHandler) GetEmployeeSessions(c *gin.Context) {
+	employeeID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Employee)
+		return
+	}
+
+	sessions, err := h.service.GetEmployeeSessions(employeeID, 0)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeGetSessions)
+		return
+	}
+
+	utils.SendSuccessResponse(c, sessions)
+}
+
+func (h *EmployeeHandler) RevokeEmployeeSessions(c *gin.Context) {
+	employeeID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Employee)
+		return
+	}
+
+	if err := h.service.RevokeEmployeeSessions(employeeID, 0); err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200EmployeeRevokeSessions)
+}
@@ -27,6 +27,8 @@ type EmployeeRepository interface {
 	GetEmployeeWorkdayByEmployeeAndDay(employeeID uint, day data.Weekday) (*data.EmployeeWorkday, error)
 	GetEmployeeWorkdayByID(workdayID uint) (*data.EmployeeWorkday, error)
 	GetEmployeeWorkdaysByEmployeeID(employeeID uint) ([]data.EmployeeWorkday, error)
+
+	UpdateStoreEmployeePin(employeeID uint, pinHash string) error
 }
 
 type employeeRepository struct {
@@ -328,3 +330,14 @@ func (r *employeeRepository) GetEmployeeWorkdaysByEmployeeID(employeeID uint) ([
 	}
 	return workdays, nil
 }
+
+// UpdateStoreEmployeePin replaces the PIN and lifts the lock left by the failed attempts
+func (r *employeeRepository) UpdateStoreEmployeePin(employeeID uint, pinHash string) error {
+	return r.db.Model(&data.StoreEmployee{}).
+		Where("employee_id = ?", employeeID).
+		Updates(map[string]interface{}{
+			"pin_hash":            pinHash,
+			"pin_failed_attempts": 0,
+			"pin_locked_until":    nil,
+		}).Error
+}
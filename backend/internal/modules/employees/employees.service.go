@@ -21,10 +21,15 @@ type EmployeeService interface {
 	ReassignEmployeeType(employeeID uint, dto *types.ReassignEmployeeTypeDTO) error
 	DeleteTypedEmployee(employeeID, workplaceID uint, employeeType data.EmployeeType) error
 	UpdatePassword(employeeID uint, input *types.UpdatePasswordDTO) error
+	UpdateEmployeePin(employeeID uint, input *types.UpdateEmployeePinDTO) error
 
 	GetAllRoles() ([]types.EmployeeTypeRoles, error)
 	GetEmployeeWorkday(workdayID uint) (*types.EmployeeWorkdayDTO, error)
 	GetEmployeeWorkdays(employeeID uint) ([]types.EmployeeWorkdayDTO, error)
+
+	GetEmployeeSessions(employeeID, currentSessionID uint) ([]types.EmployeeSessionDTO, error)
+	RevokeEmployeeSession(employeeID, sessionID uint) error
+	RevokeEmployeeSessions(employeeID, keepSessionID uint) error
 }
 
 type employeeService struct {
@@ -206,6 +211,33 @@ func (s *employeeService) UpdatePassword(employeeID uint, input *types.UpdatePas
 	return nil
 }
 
+func (s *employeeService) UpdateEmployeePin(employeeID uint, input *types.UpdateEmployeePinDTO) error {
+	employee, err := s.repo.GetEmployeeWithDetailsByID(employeeID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve employee: %w", err)
+	}
+	if employee == nil || employee.StoreEmployee == nil {
+		return types.ErrPinNotSupported
+	}
+
+	if err := utils.ComparePassword(employee.HashedPassword, input.Password); err != nil {
+		return types.ErrIncorrectPassword
+	}
+
+	pinHash, err := utils.HashPassword(input.Pin)
+	if err != nil {
+		return fmt.Errorf("failed to hash PIN: %w", err)
+	}
+
+	if err := s.repo.UpdateStoreEmployeePin(employeeID, pinHash); err != nil {
+		wrappedErr := fmt.Errorf("failed to update PIN of employee with ID = %d: %w", employeeID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	return nil
+}
+
 func (s *employeeService) GetAllRoles() ([]types.EmployeeTypeRoles, error) {
 	var employeeTypeRoles []types.EmployeeTypeRoles
 
@@ -245,3 +277,45 @@ func (s *employeeService) GetEmployeeWorkdays(employeeID uint) ([]types.Employee
 
 	return dtos, nil
 }
+
+func (s *employeeService) GetEmployeeSessions(employeeID, currentSessionID uint) ([]types.EmployeeSessionDTO, error) {
+	sessions, err := s.employeeTokenManager.GetTokensByEmployeeID(employeeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to retrieve sessions of employee with ID = %d: %w", employeeID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return types.MapToEmployeeSessionDTOs(sessions, currentSessionID), nil
+}
+
+func (s *employeeService) RevokeEmployeeSession(employeeID, sessionID uint) error {
+	err := s.employeeTokenManager.DeleteEmployeeToken(employeeID, sessionID)
+	if err != nil {
+		if errors.Is(err, employeeToken.ErrTokenNotFound) {
+			return types.ErrEmployeeSessionNotFound
+		}
+		wrappedErr := fmt.Errorf("failed to revoke session %d of employee with ID = %d: %w", sessionID, employeeID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	return nil
+}
+
+// RevokeEmployeeSessions logs the employee out on every device except the kept session, keepSessionID 0 revokes all of them
+func (s *employeeService) RevokeEmployeeSessions(employeeID, keepSessionID uint) error {
+	var err error
+	if keepSessionID == 0 {
+		err = s.employeeTokenManager.DeleteTokenByEmployeeID(employeeID)
+	} else {
+		err = s.employeeTokenManager.DeleteOtherEmployeeTokens(employeeID, keepSessionID)
+	}
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to revoke sessions of employee with ID = %d: %w", employeeID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
+	return nil
+}
@@ -246,3 +246,65 @@ func (h *FranchiseeEmployeeHandler) GetFranchiseeAccounts(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, franchiseeEmployees)
 }
+
+func (h *FranchiseeEmployeeHandler) GetFranchiseeEmployeeSessions(c *gin.Context) {
+	franchiseeEmployee, ok := h.getManagedFranchiseeEmployee(c, "id")
+	if !ok {
+		return
+	}
+
+	sessions, err := h.employeeService.GetEmployeeSessions(franchiseeEmployee.EmployeeID, 0)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeGetSessions)
+		return
+	}
+
+	utils.SendSuccessResponse(c, sessions)
+}
+
+// RevokeFranchiseeEmployeeSessions logs the employee out on every device
+func (h *FranchiseeEmployeeHandler) RevokeFranchiseeEmployeeSessions(c *gin.Context) {
+	franchiseeEmployee, ok := h.getManagedFranchiseeEmployee(c, "employeeId")
+	if !ok {
+		return
+	}
+
+	if err := h.employeeService.RevokeEmployeeSessions(franchiseeEmployee.EmployeeID, 0); err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, employeesTypes.Response200EmployeeRevokeSessions)
+}
+
+func (h *FranchiseeEmployeeHandler) getManagedFranchiseeEmployee(c *gin.Context, idParam string) (*types.FranchiseeEmployeeDetailsDTO, bool) {
+	id, err := utils.ParseParam(c, idParam)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400FranchiseeEmployee)
+		return nil, false
+	}
+
+	franchiseeID, role, errH := contexts.GetFranchiseeIdWithRole(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return nil, false
+	}
+
+	franchiseeEmployee, err := h.service.GetFranchiseeEmployeeByID(id, franchiseeID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500FranchiseeEmployeeGet)
+		return nil, false
+	}
+
+	if franchiseeEmployee == nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusNotFound)
+		return nil, false
+	}
+
+	if !data.CanManageRole(role, franchiseeEmployee.Role) {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusForbidden)
+		return nil, false
+	}
+
+	return franchiseeEmployee, true
+}
@@ -252,3 +252,71 @@ func (h *StoreEmployeeHandler) GetStoreAccounts(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, storeEmployees)
 }
+
+func (h *StoreEmployeeHandler) GetStoreEmployeeSessions(c *gin.Context) {
+	storeEmployee, ok := h.getManagedStoreEmployee(c, "id")
+	if !ok {
+		return
+	}
+
+	sessions, err := h.employeeService.GetEmployeeSessions(storeEmployee.EmployeeID, 0)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeGetSessions)
+		return
+	}
+
+	utils.SendSuccessResponse(c, sessions)
+}
+
+// RevokeStoreEmployeeSessions logs the employee out on every device
+func (h *StoreEmployeeHandler) RevokeStoreEmployeeSessions(c *gin.Context) {
+	storeEmployee, ok := h.getManagedStoreEmployee(c, "employeeId")
+	if !ok {
+		return
+	}
+
+	if err := h.employeeService.RevokeEmployeeSessions(storeEmployee.EmployeeID, 0); err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, employeesTypes.Response200EmployeeRevokeSessions)
+}
+
+func (h *StoreEmployeeHandler) getManagedStoreEmployee(c *gin.Context, idParam string) (*types.StoreEmployeeDetailsDTO, bool) {
+	id, err := utils.ParseParam(c, idParam)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StoreEmployee)
+		return nil, false
+	}
+
+	filter, errH := contexts.GetStoreContextFilter(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return nil, false
+	}
+
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	storeEmployee, err := h.service.GetStoreEmployeeByID(id, filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreEmployeeGet)
+		return nil, false
+	}
+
+	if storeEmployee == nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusNotFound)
+		return nil, false
+	}
+
+	if !data.CanManageRole(claims.Role, storeEmployee.Role) {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusForbidden)
+		return nil, false
+	}
+
+	return storeEmployee, true
+}
@@ -24,6 +24,7 @@ func MapToStoreEmployeeDetailsDTO(storeEmployee *data.StoreEmployee) *StoreEmplo
 		BaseEmployeeDetailsDTO: *employeesTypes.MapToBaseEmployeeDetailsDTO(&storeEmployee.Employee),
 		EmployeeID:             storeEmployee.EmployeeID,
 		Store:                  *storeTypes.MapToStoreDTO(&storeEmployee.Store),
+		HasPin:                 storeEmployee.PinHash != nil,
 	}
 	return dto
 }
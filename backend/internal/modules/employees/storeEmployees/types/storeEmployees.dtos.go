@@ -23,4 +23,5 @@ type StoreEmployeeDetailsDTO struct {
 	employeesTypes.BaseEmployeeDetailsDTO
 	EmployeeID uint                `json:"employeeId"`
 	Store      storeTypes.StoreDTO `json:"store"`
+	HasPin     bool                `json:"hasPin"`
 }
@@ -107,6 +107,25 @@ func MapToEmployeeAccountDTO(employee *data.Employee) *EmployeeAccountDTO {
 	}
 }
 
+// MapToEmployeeSessionDTOs marks the session of the current request, currentSessionID is 0 when the sessions of another employee are listed
+func MapToEmployeeSessionDTOs(sessions []data.EmployeeToken, currentSessionID uint) []EmployeeSessionDTO {
+	dtos := make([]EmployeeSessionDTO, len(sessions))
+	for i, session := range sessions {
+		dtos[i] = EmployeeSessionDTO{
+			ID:              session.ID,
+			DeviceName:      session.DeviceName,
+			UserAgent:       session.UserAgent,
+			IPAddress:       session.IPAddress,
+			StoreTerminalID: session.StoreTerminalID,
+			IsCurrent:       currentSessionID != 0 && session.ID == currentSessionID,
+			CreatedAt:       session.CreatedAt,
+			LastUsedAt:      session.LastUsedAt,
+			ExpiresAt:       session.ExpiresAt,
+		}
+	}
+	return dtos
+}
+
 func MapToEmployeeWorkdayDTO(workday *data.EmployeeWorkday) *EmployeeWorkdayDTO {
 	dto := &EmployeeWorkdayDTO{
 		ID:         workday.ID,
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 )
@@ -73,6 +75,12 @@ type UpdatePasswordDTO struct {
 	NewPassword string `json:"newPassword" binding:"required"`
 }
 
+// UpdateEmployeePinDTO sets the PIN the store employee switches to a store terminal with, the password confirms the change
+type UpdateEmployeePinDTO struct {
+	Pin      string `json:"pin" binding:"required,numeric,min=4,max=6"`
+	Password string `json:"password" binding:"required"`
+}
+
 type EmployeeTypeRoles struct {
 	EmployeeType data.EmployeeType   `json:"employeeType" binding:"required"`
 	Roles        []data.EmployeeRole `json:"roles" binding:"required"`
@@ -85,6 +93,18 @@ type EmployeesFilter struct {
 	Search   *string            `form:"search,omitempty"`
 }
 
+type EmployeeSessionDTO struct {
+	ID              uint      `json:"id"`
+	DeviceName      string    `json:"deviceName"`
+	UserAgent       string    `json:"userAgent"`
+	IPAddress       string    `json:"ipAddress"`
+	StoreTerminalID *uint     `json:"storeTerminalId,omitempty"`
+	IsCurrent       bool      `json:"isCurrent"`
+	CreatedAt       time.Time `json:"createdAt"`
+	LastUsedAt      time.Time `json:"lastUsedAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
+
 type EmployeeWorkdayDTO struct {
 	ID         uint   `json:"id"`
 	Day        string `json:"day"`
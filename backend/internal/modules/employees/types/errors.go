@@ -15,4 +15,7 @@ var (
 	ErrNothingToUpdate             = moduleErrors.NewModuleError(errors.New("nothing to update"))
 	ErrEmployeeTypeAndRoleMismatch = moduleErrors.NewModuleError(errors.New("employee type and role mismatch"))
 	ErrNotAllowedToManageTheRole   = moduleErrors.NewModuleError(errors.New("not allowed to manage the role"))
+	ErrEmployeeSessionNotFound     = moduleErrors.NewModuleError(errors.New("employee session not found"))
+	ErrIncorrectPassword           = moduleErrors.NewModuleError(errors.New("incorrect password"))
+	ErrPinNotSupported             = moduleErrors.NewModuleError(errors.New("only store employees can set a PIN"))
 )
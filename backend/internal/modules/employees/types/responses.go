@@ -11,6 +11,13 @@ var (
 	Response500EmployeeReassignType   = localization.NewResponseKey(500, data.EmployeeComponent, "reassignType")
 	Response500EmployeeGetWorkday     = localization.NewResponseKey(500, data.EmployeeComponent, "getWorkday")
 	Response500EmployeeGetWorkdays    = localization.NewResponseKey(500, data.EmployeeComponent, "getWorkdays")
+	Response500EmployeeGetSessions    = localization.NewResponseKey(500, data.EmployeeComponent, "GET_SESSIONS")
+	Response500EmployeeRevokeSessions = localization.NewResponseKey(500, data.EmployeeComponent, "REVOKE_SESSIONS")
+	Response500EmployeeUpdatePin      = localization.NewResponseKey(500, data.EmployeeComponent, "UPDATE_PIN")
 	Response400Employee               = localization.NewResponseKey(400, data.EmployeeComponent)
 	Response401Employee               = localization.NewResponseKey(401, data.EmployeeComponent)
+	Response404EmployeeSession        = localization.NewResponseKey(404, data.EmployeeComponent, "session")
+	Response200EmployeeRevokeSessions = localization.NewResponseKey(200, data.EmployeeComponent, "REVOKE_SESSIONS")
+	Response400EmployeePin            = localization.NewResponseKey(400, data.EmployeeComponent, "pin")
+	Response200EmployeeUpdatePin      = localization.NewResponseKey(200, data.EmployeeComponent, "UPDATE_PIN")
 )
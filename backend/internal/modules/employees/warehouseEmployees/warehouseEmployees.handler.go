@@ -250,3 +250,71 @@ func (h *WarehouseEmployeeHandler) GetWarehouseAccounts(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, warehouseEmployees)
 }
+
+func (h *WarehouseEmployeeHandler) GetWarehouseEmployeeSessions(c *gin.Context) {
+	warehouseEmployee, ok := h.getManagedWarehouseEmployee(c, "id")
+	if !ok {
+		return
+	}
+
+	sessions, err := h.employeeService.GetEmployeeSessions(warehouseEmployee.EmployeeID, 0)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeGetSessions)
+		return
+	}
+
+	utils.SendSuccessResponse(c, sessions)
+}
+
+// RevokeWarehouseEmployeeSessions logs the employee out on every device
+func (h *WarehouseEmployeeHandler) RevokeWarehouseEmployeeSessions(c *gin.Context) {
+	warehouseEmployee, ok := h.getManagedWarehouseEmployee(c, "employeeId")
+	if !ok {
+		return
+	}
+
+	if err := h.employeeService.RevokeEmployeeSessions(warehouseEmployee.EmployeeID, 0); err != nil {
+		localization.SendLocalizedResponseWithKey(c, employeesTypes.Response500EmployeeRevokeSessions)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, employeesTypes.Response200EmployeeRevokeSessions)
+}
+
+func (h *WarehouseEmployeeHandler) getManagedWarehouseEmployee(c *gin.Context, idParam string) (*types.WarehouseEmployeeDetailsDTO, bool) {
+	id, err := utils.ParseParam(c, idParam)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400WarehouseEmployee)
+		return nil, false
+	}
+
+	filter, errH := contexts.GetWarehouseContextFilter(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return nil, false
+	}
+
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	warehouseEmployee, err := h.service.GetWarehouseEmployeeByID(id, filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseEmployeeGet)
+		return nil, false
+	}
+
+	if warehouseEmployee == nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusNotFound)
+		return nil, false
+	}
+
+	if !data.CanManageRole(claims.Role, warehouseEmployee.Role) {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusForbidden)
+		return nil, false
+	}
+
+	return warehouseEmployee, true
+}
@@ -284,5 +284,17 @@ func checkIngredientReferences(db *gorm.DB, ingredientID uint) error {
 		return types.ErrIngredientIsInUse
 	}
 
+	// the stock movements keep the history of the ingredient and are never deleted
+	var movementsCount int64
+	if err := db.Model(&data.StockMovement{}).
+		Where("ingredient_id = ?", ingredientID).
+		Limit(1).
+		Count(&movementsCount).Error; err != nil {
+		return err
+	}
+	if movementsCount > 0 {
+		return types.ErrIngredientIsInUse
+	}
+
 	return nil
 }
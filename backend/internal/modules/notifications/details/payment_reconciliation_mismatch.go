@@ -0,0 +1,63 @@
+package details
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+type PaymentReconciliationMismatchDetails struct {
+	BaseNotificationDetails
+	Date              string  `json:"date"`
+	DiscrepancyCount  int     `json:"discrepancyCount"`
+	DiscrepancyAmount float64 `json:"discrepancyAmount"`
+	StalePaymentCount int     `json:"stalePaymentCount"`
+}
+
+func (p *PaymentReconciliationMismatchDetails) ToDetails() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *PaymentReconciliationMismatchDetails) GetBaseDetails() *BaseNotificationDetails {
+	return &p.BaseNotificationDetails
+}
+
+func BuildPaymentReconciliationMismatchDetails(storeID uint, storeName, date string, discrepancyCount, stalePaymentCount int, discrepancyAmount float64) (*PaymentReconciliationMismatchDetails, error) {
+	if storeID == 0 || storeName == "" || date == "" || discrepancyCount <= 0 {
+		return nil, fmt.Errorf("invalid input: storeID, storeName, date and discrepancyCount are required")
+	}
+
+	return &PaymentReconciliationMismatchDetails{
+		BaseNotificationDetails: BaseNotificationDetails{
+			ID:           storeID,
+			FacilityName: storeName,
+		},
+		Date:              date,
+		DiscrepancyCount:  discrepancyCount,
+		DiscrepancyAmount: discrepancyAmount,
+		StalePaymentCount: stalePaymentCount,
+	}, nil
+}
+
+func BuildPaymentReconciliationMismatchMessage(details *PaymentReconciliationMismatchDetails) (localization.LocalizedMessage, error) {
+	if details == nil {
+		return localization.LocalizedMessage{}, fmt.Errorf("details cannot be nil")
+	}
+
+	key := localization.FormTranslationKey("notification", data.PAYMENT_RECONCILIATION_MISMATCH.ToString())
+	messages, err := localization.Translate(key, map[string]interface{}{
+		"FacilityName":      details.FacilityName,
+		"ID":                details.ID,
+		"Date":              details.Date,
+		"DiscrepancyCount":  details.DiscrepancyCount,
+		"DiscrepancyAmount": details.DiscrepancyAmount,
+		"StalePaymentCount": details.StalePaymentCount,
+	})
+	if err != nil {
+		return localization.LocalizedMessage{}, fmt.Errorf("failed to build PaymentReconciliationMismatch message: %w", err)
+	}
+
+	return *messages, nil
+}
@@ -20,6 +20,7 @@ type NotificationService interface {
 	NotifyOutOfStock(details details.NotificationDetails) error
 	NotifyNewStockRequest(details details.NotificationDetails) error
 	NotifyPriceChange(details details.NotificationDetails) error
+	NotifyPaymentReconciliationMismatch(details details.NotificationDetails) error
 	NotifyNewProductAdded(details details.NotificationDetails) error
 	NotifyNewProductSizeAdded(details details.NotificationDetails) error
 	NotifyNewAdditiveAdded(details details.NotificationDetails) error
@@ -249,6 +250,16 @@ func (s *notificationService) NotifyPriceChange(details details.NotificationDeta
 	return nil
 }
 
+func (s *notificationService) NotifyPaymentReconciliationMismatch(details details.NotificationDetails) error {
+	notificationDetails, err := details.ToDetails()
+	if err != nil {
+		return err
+	}
+
+	s.createNotificationAsync(data.PAYMENT_RECONCILIATION_MISMATCH, data.HIGH, notificationDetails, details.GetBaseDetails())
+	return nil
+}
+
 func (s *notificationService) NotifyNewProductSize(details details.NotificationDetails) error {
 	notificationDetails, err := details.ToDetails()
 	if err != nil {
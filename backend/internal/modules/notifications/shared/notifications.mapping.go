@@ -71,6 +71,10 @@ func NewNotificationRoleMappingManager() *NotificationRoleMappingManager {
 					EventType:     data.PRICE_CHANGE,
 					EmployeeRoles: []data.EmployeeRole{data.RoleStoreManager, data.RoleBarista},
 				},
+				{
+					EventType:     data.PAYMENT_RECONCILIATION_MISMATCH,
+					EmployeeRoles: []data.EmployeeRole{data.RoleStoreManager},
+				},
 			},
 		}
 	}
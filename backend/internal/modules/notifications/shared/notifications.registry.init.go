@@ -190,4 +190,18 @@ func InitNotificationRegistry() {
 			return details.BuildStoreWarehouseRunOutMessage(warehouseDetails)
 		},
 	)
+
+	RegisterNotification(
+		data.PAYMENT_RECONCILIATION_MISMATCH,
+		func() details.NotificationDetails {
+			return &details.PaymentReconciliationMismatchDetails{}
+		},
+		func(baseDetails details.NotificationDetails) (localization.LocalizedMessage, error) {
+			reconciliationDetails, ok := baseDetails.(*details.PaymentReconciliationMismatchDetails)
+			if !ok {
+				return localization.LocalizedMessage{}, fmt.Errorf("invalid details type for PAYMENT_RECONCILIATION_MISMATCH")
+			}
+			return details.BuildPaymentReconciliationMismatchMessage(reconciliationDetails)
+		},
+	)
 }
@@ -64,6 +64,21 @@ func addOrdersData(sheet *xlsx.Sheet, data []types.OrderExportDTO, headers []str
 			totalCell := row.AddCell()
 			totalCell.SetFloat(total)
 
+			discountCell := row.AddCell()
+			discountCell.SetFloat(suborder.Discount)
+
+			var promotionNames []string
+			for _, discount := range suborder.Discounts {
+				promotionNames = append(promotionNames, discount.PromotionName)
+			}
+			row.AddCell().Value = strings.Join(promotionNames, "\n")
+
+			taxRateCell := row.AddCell()
+			taxRateCell.SetFloat(suborder.TaxRate)
+
+			taxCell := row.AddCell()
+			taxCell.SetFloat(suborder.TaxAmount)
+
 			row.AddCell().Value = strings.Join(additivesDetails, "\n")
 			row.AddCell().Value = order.CreatedAt.Format("2006-01-02 15:04:05")
 		}
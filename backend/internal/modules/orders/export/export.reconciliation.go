@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/logger"
+	"github.com/tealeg/xlsx"
+)
+
+var discrepancyLabels = map[string]map[types.PaymentDiscrepancyType]string{
+	"kk": {
+		types.PaymentDiscrepancyMissingPayment:  "Төлем жоқ",
+		types.PaymentDiscrepancyAmountMismatch:  "Төлем сомасы сәйкес емес",
+		types.PaymentDiscrepancyOverRefunded:    "Қайтарым төлемнен асады",
+		types.PaymentDiscrepancyRefundedBalance: "Қайтарылған тапсырыстың қалдығы бар",
+		types.PaymentDiscrepancyStalePayment:    "Төлемді күтуде қалып қойды",
+	},
+	"ru": {
+		types.PaymentDiscrepancyMissingPayment:  "Нет оплаты",
+		types.PaymentDiscrepancyAmountMismatch:  "Сумма оплаты не совпадает",
+		types.PaymentDiscrepancyOverRefunded:    "Возврат превышает оплату",
+		types.PaymentDiscrepancyRefundedBalance: "Остаток по возвращенному заказу",
+		types.PaymentDiscrepancyStalePayment:    "Завис в ожидании оплаты",
+	},
+	"en": {
+		types.PaymentDiscrepancyMissingPayment:  "Missing payment",
+		types.PaymentDiscrepancyAmountMismatch:  "Paid amount mismatch",
+		types.PaymentDiscrepancyOverRefunded:    "Refunds exceed payments",
+		types.PaymentDiscrepancyRefundedBalance: "Refunded order has a balance",
+		types.PaymentDiscrepancyStalePayment:    "Stuck waiting for payment",
+	},
+}
+
+func GenerateReconciliationExcel(data []types.PaymentDiscrepancyDTO, language string) ([]byte, error) {
+	headers := ReconciliationRusHeaders
+	switch language {
+	case "kk":
+		headers = ReconciliationKazHeaders
+	case "en":
+		headers = ReconciliationEngHeaders
+	default:
+		language = "ru"
+	}
+
+	file := xlsx.NewFile()
+
+	sheet, err := file.AddSheet("Сверка оплат")
+	if err != nil {
+		return nil, err
+	}
+
+	addReconciliationData(sheet, data, headers, discrepancyLabels[language])
+
+	buffer := bytes.NewBuffer(nil)
+	if err := file.Write(buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func addReconciliationData(sheet *xlsx.Sheet, data []types.PaymentDiscrepancyDTO, headers []string, labels map[types.PaymentDiscrepancyType]string) {
+	headerRow := sheet.AddRow()
+	for _, header := range headers {
+		cell := headerRow.AddCell()
+		cell.Value = header
+	}
+
+	err := setColumnWidths(sheet)
+	if err != nil {
+		logger.GetZapSugaredLogger().Errorln(err.Error())
+		return
+	}
+
+	setHeadersStyle(headerRow)
+	for _, discrepancy := range data {
+		row := sheet.AddRow()
+		row.AddCell().Value = fmt.Sprintf("%d", discrepancy.OrderID)
+		row.AddCell().Value = fmt.Sprintf("%d", discrepancy.DisplayNumber)
+		row.AddCell().Value = discrepancy.StoreName
+		row.AddCell().Value = string(discrepancy.Status)
+		row.AddCell().Value = labels[discrepancy.Type]
+		row.AddCell().SetFloat(discrepancy.Total)
+		row.AddCell().SetFloat(discrepancy.Paid)
+		row.AddCell().SetFloat(discrepancy.Refunded)
+		row.AddCell().SetFloat(discrepancy.Difference)
+		row.AddCell().Value = discrepancy.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+}
@@ -3,9 +3,13 @@ package export
 import "github.com/tealeg/xlsx"
 
 var (
-	KazHeaders = []string{"Тапсырыс нөмірі", "Тапсырыс берушінің аты", "Филиал атауы", "Тапсырыс тауар нөмірі", "Тауар атауы", "Өлшемі", "Бағасы", "Жалпы баға (қоспалардың бағасын қоса)", "Қоспалар", "Тапсырыс күні"}
-	RusHeaders = []string{"Номер заказа", "Имя покупателя", "Название филиала", "Номер подзаказа", "Имя продукта", "Размер", "Цена", "Итого (с учетом цены добавок)", "Добавки", "Дата заказа"}
-	EngHeaders = []string{"Order ID", "Customer Name", "Store Name", "Suborder ID", "Product Name", "Size", "StorePrice", "Total (with additive prices added)", "Additives", "Order Date"}
+	KazHeaders = []string{"Тапсырыс нөмірі", "Тапсырыс берушінің аты", "Филиал атауы", "Тапсырыс тауар нөмірі", "Тауар атауы", "Өлшемі", "Бағасы", "Жалпы баға (қоспалардың бағасын қоса)", "Жеңілдік", "Акциялар", "ҚҚС мөлшерлемесі (%)", "ҚҚС", "Қоспалар", "Тапсырыс күні"}
+	RusHeaders = []string{"Номер заказа", "Имя покупателя", "Название филиала", "Номер подзаказа", "Имя продукта", "Размер", "Цена", "Итого (с учетом цены добавок)", "Скидка", "Акции", "Ставка НДС (%)", "НДС", "Добавки", "Дата заказа"}
+	EngHeaders = []string{"Order ID", "Customer Name", "Store Name", "Suborder ID", "Product Name", "Size", "StorePrice", "Total (with additive prices added)", "Discount", "Promotions", "VAT Rate (%)", "VAT", "Additives", "Order Date"}
+
+	ReconciliationKazHeaders = []string{"Тапсырыс нөмірі", "Көрсетілетін нөмір", "Филиал атауы", "Күйі", "Сәйкессіздік", "Тапсырыс сомасы", "Төленді", "Қайтарылды", "Айырмашылық", "Тапсырыс күні"}
+	ReconciliationRusHeaders = []string{"Номер заказа", "Номер на экране", "Название филиала", "Статус", "Расхождение", "Сумма заказа", "Оплачено", "Возвращено", "Разница", "Дата заказа"}
+	ReconciliationEngHeaders = []string{"Order ID", "Display Number", "Store Name", "Status", "Discrepancy", "Order Total", "Paid", "Refunded", "Difference", "Order Date"}
 )
 
 func setHeadersStyle(headerRow *xlsx.Row) {
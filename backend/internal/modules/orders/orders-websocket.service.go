@@ -19,6 +19,10 @@ const (
 	EventTypeOrderSucceeded EventType = "order_succeeded"
 	EventTypeOrderUpdated   EventType = "order_updated"
 	EventTypeOrderDeleted   EventType = "order_deleted"
+	EventTypeOrderCancelled EventType = "order_cancelled"
+	EventTypeOrderEstimates EventType = "order_estimates_updated"
+	EventTypeStatusBoard    EventType = "status_board_updated"
+	EventTypeOrderReady     EventType = "order_ready"
 )
 
 type WebSocketMessage struct {
@@ -27,19 +31,25 @@ type WebSocketMessage struct {
 }
 
 type Client struct {
-	Conn    *websocket.Conn
-	StoreID uint
+	Conn      *websocket.Conn
+	StoreID   uint
+	StationID *uint // the client gets only the suborders of the station, all of them when nil
+	channel   uint  // the key the client is connected with, the store or the customer depending on the hub
 }
 
 type Hub struct {
 	mu          sync.RWMutex
-	connections map[uint]map[*Client]bool // StoreID -> Set of connected clients
+	connections map[uint]map[*Client]bool // StoreID (CustomerID for the customer hub) -> Set of connected clients
 }
 
 var (
-	hubInstance *Hub
-	once        sync.Once
-	upgrader    = websocket.Upgrader{
+	hubInstance         *Hub
+	once                sync.Once
+	statusBoardHub      *Hub
+	statusBoardHubOnce  sync.Once
+	customerHubInstance *Hub
+	customerHubOnce     sync.Once
+	upgrader            = websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 		CheckOrigin: func(r *http.Request) bool {
@@ -49,25 +59,46 @@ var (
 	}
 )
 
+func newHub() *Hub {
+	return &Hub{
+		connections: make(map[uint]map[*Client]bool),
+	}
+}
+
 // GetHubInstance ensures there is only one Hub instance.
 func GetHubInstance() *Hub {
 	once.Do(func() {
-		hubInstance = &Hub{
-			connections: make(map[uint]map[*Client]bool),
-		}
+		hubInstance = newHub()
 	})
 	return hubInstance
 }
 
+// GetStatusBoardHubInstance returns the hub of the public status boards of the stores.
+func GetStatusBoardHubInstance() *Hub {
+	statusBoardHubOnce.Do(func() {
+		statusBoardHub = newHub()
+	})
+	return statusBoardHub
+}
+
+// GetCustomerHubInstance returns the hub of the customer app connections, the clients are grouped by customer.
+func GetCustomerHubInstance() *Hub {
+	customerHubOnce.Do(func() {
+		customerHubInstance = newHub()
+	})
+	return customerHubInstance
+}
+
 // AddClient adds a WebSocket client to the hub.
-func (h *Hub) AddClient(storeID uint, client *Client) {
+func (h *Hub) AddClient(channel uint, client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if h.connections[storeID] == nil {
-		h.connections[storeID] = make(map[*Client]bool)
+	client.channel = channel
+	if h.connections[channel] == nil {
+		h.connections[channel] = make(map[*Client]bool)
 	}
-	h.connections[storeID][client] = true
+	h.connections[channel][client] = true
 }
 
 // RemoveClient removes a WebSocket client from the hub.
@@ -75,11 +106,11 @@ func (h *Hub) RemoveClient(client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	clients, exists := h.connections[client.StoreID]
+	clients, exists := h.connections[client.channel]
 	if exists {
 		delete(clients, client)
 		if len(clients) == 0 {
-			delete(h.connections, client.StoreID)
+			delete(h.connections, client.channel)
 		}
 	}
 
@@ -90,43 +121,60 @@ func (h *Hub) RemoveClient(client *Client) {
 
 // BroadcastMessage broadcasts a message to all clients connected to a specific store.
 func (h *Hub) BroadcastMessage(storeID uint, eventType EventType, payload interface{}) error {
-	message := WebSocketMessage{
-		Type:    eventType,
-		Payload: payload,
-	}
+	return h.broadcast(storeID, eventType, func(*Client) (interface{}, bool) {
+		return payload, true
+	})
+}
 
-	data, err := json.Marshal(message)
-	if err != nil {
-		return fmt.Errorf("failed to marshal WebSocket message: %w", err)
-	}
+// broadcastOrder sends every client the part of the order prepared by its station, the clients of the stations without suborders in the order are skipped
+func (h *Hub) broadcastOrder(storeID uint, eventType EventType, order types.OrderDTO) error {
+	return h.broadcast(storeID, eventType, func(client *Client) (interface{}, bool) {
+		return types.FilterOrderByStation(order, client.StationID)
+	})
+}
 
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+func (h *Hub) broadcast(storeID uint, eventType EventType, payloadFor func(client *Client) (interface{}, bool)) error {
+	var failedClients []*Client
 
-	clients, exists := h.connections[storeID]
-	if !exists {
-		return nil // No clients connected to the store
-	}
+	h.mu.RLock()
+	for client := range h.connections[storeID] {
+		payload, ok := payloadFor(client)
+		if !ok {
+			continue
+		}
 
-	for client := range clients {
-		err := client.Conn.WriteMessage(websocket.TextMessage, data)
+		data, err := json.Marshal(WebSocketMessage{
+			Type:    eventType,
+			Payload: payload,
+		})
 		if err != nil {
+			h.mu.RUnlock()
+			return fmt.Errorf("failed to marshal WebSocket message: %w", err)
+		}
+
+		if err := client.Conn.WriteMessage(websocket.TextMessage, data); err != nil {
 			log.Printf("Error broadcasting message to store %d: %v", storeID, err)
-			h.RemoveClient(client)
+			failedClients = append(failedClients, client)
 		}
 	}
+	h.mu.RUnlock()
+
+	// Removal takes the write lock, so it is done after the read lock is released
+	for _, client := range failedClients {
+		h.RemoveClient(client)
+	}
 
 	return nil
 }
 
 // BroadcastOrderSucceeded broadcasts an order creation event.
 func BroadcastOrderSucceeded(storeID uint, order types.OrderDTO) {
-	_ = GetHubInstance().BroadcastMessage(storeID, EventTypeOrderSucceeded, order)
+	_ = GetHubInstance().broadcastOrder(storeID, EventTypeOrderSucceeded, order)
 }
 
 // BroadcastOrderUpdated broadcasts an order update event.
 func BroadcastOrderUpdated(storeID uint, order types.OrderDTO) {
-	_ = GetHubInstance().BroadcastMessage(storeID, EventTypeOrderUpdated, order)
+	_ = GetHubInstance().broadcastOrder(storeID, EventTypeOrderUpdated, order)
 }
 
 // BroadcastOrderDeleted broadcasts an order deletion event.
@@ -134,30 +182,72 @@ func BroadcastOrderDeleted(storeID uint, orderID uint) {
 	_ = GetHubInstance().BroadcastMessage(storeID, EventTypeOrderDeleted, map[string]uint{"orderId": orderID})
 }
 
+// BroadcastOrderCancelled broadcasts cancelled suborders so the kiosk can remove their tickets.
+func BroadcastOrderCancelled(storeID uint, payload types.OrderCancelledPayload) {
+	_ = GetHubInstance().BroadcastMessage(storeID, EventTypeOrderCancelled, payload)
+}
+
+// BroadcastOrderEstimates broadcasts the refreshed ready time estimates of the store queue.
+func BroadcastOrderEstimates(storeID uint, estimates []types.OrderReadyEstimateDTO) {
+	_ = GetHubInstance().BroadcastMessage(storeID, EventTypeOrderEstimates, estimates)
+}
+
+// BroadcastStatusBoard sends the refreshed public status board to the boards of the store.
+func BroadcastStatusBoard(storeID uint, board types.StatusBoardDTO) {
+	_ = GetStatusBoardHubInstance().BroadcastMessage(storeID, EventTypeStatusBoard, board)
+}
+
+// NotifyOrderReady sends the pickup notification to the app of the customer.
+func NotifyOrderReady(customerID uint, notification types.OrderReadyNotificationDTO) {
+	_ = GetCustomerHubInstance().BroadcastMessage(customerID, EventTypeOrderReady, notification)
+}
+
 // HandleClient initializes and manages a WebSocket client connection.
-func HandleClient(storeID uint, conn *websocket.Conn, initialData []types.OrderDTO) {
-	hub := GetHubInstance()
+func HandleClient(storeID uint, stationID *uint, conn *websocket.Conn, initialData []types.OrderDTO) {
+	client := &Client{
+		Conn:      conn,
+		StoreID:   storeID,
+		StationID: stationID,
+	}
+	serveClient(GetHubInstance(), storeID, client, initialData)
+}
+
+// HandleStatusBoardClient serves the public read-only status board of the store.
+func HandleStatusBoardClient(storeID uint, conn *websocket.Conn, initialBoard types.StatusBoardDTO) {
 	client := &Client{
 		Conn:    conn,
 		StoreID: storeID,
 	}
+	serveClient(GetStatusBoardHubInstance(), storeID, client, initialBoard)
+}
+
+// HandleCustomerClient serves the customer app, which only listens for the notifications about the customer orders.
+func HandleCustomerClient(customerID uint, conn *websocket.Conn) {
+	client := &Client{
+		Conn: conn,
+	}
+	serveClient(GetCustomerHubInstance(), customerID, client, nil)
+}
 
+func serveClient(hub *Hub, channel uint, client *Client, initialData interface{}) {
 	// Add the client to the hub
-	hub.AddClient(storeID, client)
+	hub.AddClient(channel, client)
 	defer hub.RemoveClient(client)
 
 	// Send initial data
-	initialMessage := WebSocketMessage{
-		Type:    EventTypeInitialData,
-		Payload: initialData,
+	if initialData != nil {
+		initialMessage := WebSocketMessage{
+			Type:    EventTypeInitialData,
+			Payload: initialData,
+		}
+		data, _ := json.Marshal(initialMessage)
+		_ = client.Conn.WriteMessage(websocket.TextMessage, data)
 	}
-	data, _ := json.Marshal(initialMessage)
-	_ = client.Conn.WriteMessage(websocket.TextMessage, data)
 
 	for {
-		_, _, err := conn.ReadMessage()
+		_, _, err := client.Conn.ReadMessage()
 		if err != nil {
-			log.Printf("Error reading WebSocket message for store %d: %v", storeID, err)
+			log.Printf("Error reading WebSocket message for channel %d: %v", channel, err)
 			break
 		}
 	}
@@ -1,19 +1,20 @@
 package orders
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/errors/handlerErrors"
 
+	bonusesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
 	storeProvisionsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/provisions/storeProvisions/types"
 
 	storeStocksTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks/types"
 
-	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	"github.com/pkg/errors"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
@@ -21,19 +22,25 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/export"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
+	paymentsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type OrderHandler struct {
-	service OrderService
+	service      OrderService
+	auditService audit.AuditService
 }
 
-func NewOrderHandler(service OrderService) *OrderHandler {
-	return &OrderHandler{service: service}
+func NewOrderHandler(service OrderService, auditService audit.AuditService) *OrderHandler {
+	return &OrderHandler{
+		service:      service,
+		auditService: auditService,
+	}
 }
 
 func (h *OrderHandler) GetOrders(c *gin.Context) {
@@ -61,7 +68,7 @@ func (h *OrderHandler) GetOrders(c *gin.Context) {
 }
 
 func (h *OrderHandler) GetAllBaristaOrders(c *gin.Context) {
-	var filter types.OrdersTimeZoneFilter
+	var filter types.BaristaOrdersFilter
 
 	if err := c.ShouldBindQuery(&filter); err != nil {
 		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
@@ -131,30 +138,77 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+	orderDTO.EmployeeID = employeeID
+
 	createdOrder, err := h.service.CreateOrder(&orderDTO)
 	if err != nil || createdOrder == nil {
-		if errors.Is(err, storeStocksTypes.ErrInsufficientStock) ||
-			errors.Is(err, storeProvisionsTypes.ErrInsufficientStoreProvision) {
-			localization.SendLocalizedResponseWithKey(c, types.Response409InsufficientStock)
-			return
-		}
-		if errors.Is(err, types.ErrMultipleSelect) {
-			localization.SendLocalizedResponseWithKey(c, types.Response400MultipleSelect)
-			return
-		}
-		if errors.Is(err, types.ErrInvalidCustomerNameCensor) {
-			localization.SendLocalizedResponseWithKey(c, types.Response400OrderCustomerName)
-			return
-		}
-		localization.SendLocalizedResponseWithKey(c, types.Response500OrderCreate)
+		sendCreateOrderError(c, err)
 		return
 	}
 
 	utils.SendSuccessResponse(c, types.ConvertOrderToDTO(createdOrder))
 }
 
+func sendCreateOrderError(c *gin.Context, err error) {
+	if errors.Is(err, storeStocksTypes.ErrInsufficientStock) ||
+		errors.Is(err, storeProvisionsTypes.ErrInsufficientStoreProvision) {
+		localization.SendLocalizedResponseWithKey(c, types.Response409InsufficientStock)
+		return
+	}
+	if errors.Is(err, types.ErrMultipleSelect) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400MultipleSelect)
+		return
+	}
+	if errors.Is(err, types.ErrInvalidCustomerNameCensor) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderCustomerName)
+		return
+	}
+	if errors.Is(err, types.ErrCustomerNotVerified) {
+		localization.SendLocalizedResponseWithKey(c, types.Response403OrderCustomer)
+		return
+	}
+	if errors.Is(err, types.ErrBonusesWithoutCustomer) || errors.Is(err, types.ErrBonusesRedeemLimit) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderBonuses)
+		return
+	}
+	if errors.Is(err, bonusesTypes.ErrInsufficientBonuses) {
+		localization.SendLocalizedResponseWithKey(c, types.Response409OrderBonuses)
+		return
+	}
+	if errors.Is(err, types.ErrInvalidDeliveryAddress) || errors.Is(err, types.ErrStoreLocationUnknown) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderDelivery)
+		return
+	}
+	if errors.Is(err, types.ErrDeliveryDistanceExceeded) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderDeliveryDistance)
+		return
+	}
+	if errors.Is(err, types.ErrStoreUnavailable) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderStore)
+		return
+	}
+	if errors.Is(err, types.ErrStoreClosed) {
+		localization.SendLocalizedResponseWithKey(c, types.Response409OrderStoreClose)
+		return
+	}
+	if errors.Is(err, types.ErrInvalidPickupSlot) {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderPickupSlot)
+		return
+	}
+	if errors.Is(err, types.ErrPickupSlotFull) {
+		localization.SendLocalizedResponseWithKey(c, types.Response409OrderPickupFull)
+		return
+	}
+	localization.SendLocalizedResponseWithKey(c, types.Response500OrderCreate)
+}
+
 func (h *OrderHandler) ServeWS(c *gin.Context) {
-	var filter types.OrdersTimeZoneFilter
+	var filter types.BaristaOrdersFilter
 	if err := c.ShouldBindQuery(&filter); err != nil {
 		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
 		return
@@ -180,7 +234,72 @@ func (h *OrderHandler) ServeWS(c *gin.Context) {
 		return
 	}
 
-	HandleClient(storeID, conn, initialOrders)
+	HandleClient(storeID, filter.StationID, conn, initialOrders)
+}
+
+// GetStatusBoard returns the public status board of the store, no authorization is required
+func (h *OrderHandler) GetStatusBoard(c *gin.Context) {
+	storeID, err := utils.ParseParam(c, "storeId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response404OrderStatusBoard)
+		return
+	}
+
+	board, err := h.service.GetStatusBoard(storeID)
+	if err != nil {
+		sendStatusBoardError(c, err)
+		return
+	}
+
+	utils.SendSuccessResponse(c, board)
+}
+
+// ServeStatusBoardWS streams the public status board of the store to the TV boards
+func (h *OrderHandler) ServeStatusBoardWS(c *gin.Context) {
+	storeID, err := utils.ParseParam(c, "storeId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response404OrderStatusBoard)
+		return
+	}
+
+	board, err := h.service.GetStatusBoard(storeID)
+	if err != nil {
+		sendStatusBoardError(c, err)
+		return
+	}
+
+	conn, err := UpgradeConnection(c)
+	if err != nil {
+		utils.SendInternalServerError(c, "failed to upgrade WebSocket connection")
+		return
+	}
+
+	HandleStatusBoardClient(storeID, conn, *board)
+}
+
+func sendStatusBoardError(c *gin.Context, err error) {
+	if errors.Is(err, types.ErrStoreUnavailable) {
+		localization.SendLocalizedResponseWithKey(c, types.Response404OrderStatusBoard)
+		return
+	}
+	localization.SendLocalizedResponseWithKey(c, types.Response500OrderStatusBoard)
+}
+
+// ServeCustomerWS keeps the customer app connected for the pickup notifications of the customer orders
+func (h *OrderHandler) ServeCustomerWS(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	conn, err := UpgradeConnection(c)
+	if err != nil {
+		utils.SendInternalServerError(c, "failed to upgrade WebSocket connection")
+		return
+	}
+
+	HandleCustomerClient(claims.CustomerID, conn)
 }
 
 func (h *OrderHandler) GetOrderDetails(c *gin.Context) {
@@ -252,6 +371,40 @@ func (h *OrderHandler) ExportOrders(c *gin.Context) {
 	c.Data(200, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelData)
 }
 
+func (h *OrderHandler) ExportPaymentReconciliation(c *gin.Context) {
+	var filter types.PaymentReconciliationFilterQuery
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	filter.StoreID = &storeID
+
+	discrepancies, err := h.service.GetPaymentReconciliationReport(&filter)
+	if err != nil {
+		utils.SendInternalServerError(c, "Failed to reconcile order payments")
+		return
+	}
+
+	excelData, err := export.GenerateReconciliationExcel(discrepancies, filter.Language)
+	if err != nil {
+		utils.SendInternalServerError(c, "Failed to generate Excel file")
+		return
+	}
+
+	filename := fmt.Sprintf("payment_reconciliation_%s.xlsx", filter.Date.Format("02_01_2006"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Length", fmt.Sprintf("%d", len(excelData)))
+	c.Data(200, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelData)
+}
+
 func (h *OrderHandler) SetNextSubOrderStatus(c *gin.Context) {
 	subOrderID, err := utils.ParseParam(c, "subOrderId")
 	if err != nil {
@@ -265,7 +418,19 @@ func (h *OrderHandler) SetNextSubOrderStatus(c *gin.Context) {
 		return
 	}
 
-	updatedSuborderDTO, err := h.service.SetNextSubOrderStatus(subOrderID, &options)
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	updatedSuborderDTO, err := h.service.SetNextSubOrderStatus(subOrderID, storeID, employeeID, &options)
 	if err != nil {
 		if errors.Is(err, storeStocksTypes.ErrInsufficientStock) {
 			localization.SendLocalizedResponseWithKey(c, types.Response409InsufficientStock)
@@ -283,46 +448,79 @@ func (h *OrderHandler) SetNextSubOrderStatus(c *gin.Context) {
 	utils.SendSuccessResponse(c, updatedSuborderDTO)
 }
 
-func (h *OrderHandler) SuccessOrderPayment(c *gin.Context) {
-	orderID, errH := utils.ParseParam(c, "orderId")
-	if errH != nil {
+func (h *OrderHandler) CreatePaymentIntent(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
 		return
 	}
 
-	var encryptedData utils.EncryptedData
-	if err := c.ShouldBindJSON(&encryptedData); err != nil {
-		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
 		return
 	}
 
-	decryptedJSON, err := utils.DecryptPayload(encryptedData, config.GetConfig().Payment.SecretKey)
+	intent, err := h.service.CreatePaymentIntent(orderID, storeID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Decryption failed: " + err.Error()})
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrInappropriateOrderStatus):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderPaymentIntent)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderPaymentIntent)
+		}
 		return
 	}
 
-	var dto types.TransactionDTO
-	if err := json.Unmarshal(decryptedJSON, &dto); err != nil {
-		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
-		return
-	}
+	utils.SendSuccessResponse(c, intent)
+}
 
-	err = h.service.SuccessOrderPayment(orderID, &dto)
+// HandlePaymentWebhook is called by the payment providers, the request is trusted only when its signature is valid
+func (h *OrderHandler) HandlePaymentWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
 	if err != nil {
-		localization.SendLocalizedResponseWithKey(c, types.Response500OrderPaymentSuccess)
+		localization.SendLocalizedResponseWithKey(c, types.Response400PaymentWebhook)
 		return
 	}
 
-	order, err := h.service.GetOrderById(orderID)
+	headers := &paymentsTypes.WebhookHeaders{
+		Signature: c.GetHeader(paymentsTypes.WebhookSignatureHeader),
+		Timestamp: c.GetHeader(paymentsTypes.WebhookTimestampHeader),
+	}
+	provider := data.PaymentProviderName(strings.ToUpper(c.Param("provider")))
+
+	order, err := h.service.HandlePaymentWebhook(provider, payload, headers)
 	if err != nil {
-		utils.SendInternalServerError(c, fmt.Sprintf("failed to fetch created order with ID %d: %s", orderID, err.Error()))
+		switch {
+		case errors.Is(err, types.ErrPaymentIntentProcessed):
+			localization.SendLocalizedResponseWithKey(c, types.Response200PaymentWebhook)
+		case errors.Is(err, paymentsTypes.ErrInvalidWebhookSignature):
+			localization.SendLocalizedResponseWithKey(c, types.Response401PaymentWebhook)
+		case errors.Is(err, paymentsTypes.ErrInvalidWebhookEvent),
+			errors.Is(err, paymentsTypes.ErrPaymentAmountMismatch),
+			errors.Is(err, paymentsTypes.ErrPaymentNotAuthorized):
+			localization.SendLocalizedResponseWithKey(c, types.Response400PaymentWebhook)
+		case errors.Is(err, paymentsTypes.ErrUnknownPaymentProvider),
+			errors.Is(err, types.ErrPaymentIntentNotFound),
+			errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404PaymentWebhook)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500PaymentWebhook)
+		}
 		return
 	}
 
-	BroadcastOrderSucceeded(order.StoreID, order)
+	// the scheduled pickup orders are pushed to the kiosk by ReleaseScheduledOrders
+	if order != nil && order.PickupAt == nil {
+		orderDTO, err := h.service.GetOrderById(order.ID)
+		if err == nil {
+			BroadcastOrderSucceeded(orderDTO.StoreID, orderDTO)
+		}
+	}
 
-	localization.SendLocalizedResponseWithKey(c, types.Response200OrderPaymentSuccess)
+	localization.SendLocalizedResponseWithKey(c, types.Response200PaymentWebhook)
 }
 
 func (h *OrderHandler) FailOrderPayment(c *gin.Context) {
@@ -345,3 +543,471 @@ func (h *OrderHandler) FailOrderPayment(c *gin.Context) {
 
 	localization.SendLocalizedResponseWithKey(c, types.Response200OrderPaymentFail)
 }
+
+func (h *OrderHandler) RefundOrder(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
+		return
+	}
+
+	var dto types.RefundOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	order, err := h.service.RefundOrder(orderID, storeID, employeeID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrInappropriateOrderStatus),
+			errors.Is(err, types.ErrSuborderNotRefundable),
+			errors.Is(err, types.ErrRefundInProgress):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderRefundStatus)
+		case errors.Is(err, types.ErrRefundAmountExceeded):
+			localization.SendLocalizedResponseWithKey(c, types.Response400OrderRefundAmount)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderRefund)
+		}
+		return
+	}
+
+	action := types.RefundOrderAuditFactory(
+		&data.BaseDetails{
+			ID:   order.ID,
+			Name: fmt.Sprintf("#%d %s", order.DisplayNumber, order.CustomerName),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	BroadcastOrderUpdated(order.StoreID, types.ConvertOrderToDTO(order))
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200OrderRefund)
+}
+
+func (h *OrderHandler) AssignCourier(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
+		return
+	}
+
+	var dto types.AssignCourierDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	order, err := h.service.AssignCourier(orderID, storeID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrNotDeliveryOrder),
+			errors.Is(err, types.ErrInappropriateOrderStatus):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderDeliveryStatus)
+		case errors.Is(err, types.ErrCourierNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response400OrderCourier)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderDelivery)
+		}
+		return
+	}
+
+	action := types.AssignCourierAuditFactory(
+		&data.BaseDetails{
+			ID:   order.ID,
+			Name: fmt.Sprintf("#%d %s", order.DisplayNumber, order.CustomerName),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	BroadcastOrderUpdated(order.StoreID, types.ConvertOrderToDTO(order))
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200OrderCourier)
+}
+
+func (h *OrderHandler) CompleteDelivery(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	order, err := h.service.CompleteDelivery(orderID, storeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrNotDeliveryOrder),
+			errors.Is(err, types.ErrInappropriateOrderStatus),
+			errors.Is(err, types.ErrCourierNotAssigned):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderDeliveryStatus)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderDelivery)
+		}
+		return
+	}
+
+	BroadcastOrderUpdated(order.StoreID, types.ConvertOrderToDTO(order))
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200OrderDelivered)
+}
+
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
+		return
+	}
+
+	var dto types.CancelOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, role, errH := contexts.GetStoreIdWithRole(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	requester := &types.CancellationRequester{
+		EmployeeID: claims.EmployeeID,
+		CanApprove: slices.Contains(data.StoreManagementPermissions, role),
+	}
+
+	cancellation, err := h.service.CancelOrder(orderID, storeID, requester, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrInvalidCancellationReason):
+			localization.SendLocalizedResponseWithKey(c, types.Response400OrderCancellationReason)
+		case errors.Is(err, types.ErrInappropriateOrderStatus),
+			errors.Is(err, types.ErrSuborderNotCancellable):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderCancellationStatus)
+		case errors.Is(err, types.ErrCancellationPending):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderCancellationPending)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderCancellation)
+		}
+		return
+	}
+
+	action := types.CancelOrderAuditFactory(
+		&data.BaseDetails{
+			ID:   cancellation.ID,
+			Name: fmt.Sprintf("#%d", cancellation.DisplayNumber),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	h.broadcastCancellation(storeID, cancellation)
+
+	utils.SendSuccessResponse(c, cancellation)
+}
+
+func (h *OrderHandler) GetOrderCancellations(c *gin.Context) {
+	var filter types.OrderCancellationsFilterQuery
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.OrderCancellation{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+	filter.StoreID = &storeID
+
+	cancellations, err := h.service.GetOrderCancellations(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500OrderCancellation)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, cancellations, filter.Pagination)
+}
+
+func (h *OrderHandler) ReviewOrderCancellation(c *gin.Context) {
+	cancellationID, err := utils.ParseParam(c, "cancellationId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400OrderCancellation)
+		return
+	}
+
+	var dto types.ReviewOrderCancellationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	claims, err := contexts.GetEmployeeClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	cancellation, err := h.service.ReviewOrderCancellation(cancellationID, storeID, claims.EmployeeID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrCancellationNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404OrderCancellation)
+		case errors.Is(err, types.ErrCancellationReviewed):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderCancellationReviewed)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderCancellation)
+		}
+		return
+	}
+
+	action := types.ReviewOrderCancellationAuditFactory(
+		&data.BaseDetails{
+			ID:   cancellation.ID,
+			Name: fmt.Sprintf("#%d", cancellation.DisplayNumber),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	h.broadcastCancellation(storeID, cancellation)
+
+	utils.SendSuccessResponse(c, cancellation)
+}
+
+// broadcastCancellation notifies the kiosk only about the approved cancellations
+func (h *OrderHandler) broadcastCancellation(storeID uint, cancellation *types.OrderCancellationDTO) {
+	if cancellation.Status != data.OrderCancellationStatusApproved {
+		return
+	}
+
+	BroadcastOrderCancelled(storeID, types.OrderCancelledPayload{
+		OrderID:     cancellation.OrderID,
+		SuborderIDs: cancellation.SuborderIDs,
+		Reason:      cancellation.Reason,
+	})
+
+	order, err := h.service.GetOrderById(cancellation.OrderID)
+	if err != nil {
+		return
+	}
+	BroadcastOrderUpdated(storeID, order)
+}
+
+func (h *OrderHandler) GetMyOrders(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	var filter types.OrdersFilterQuery
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.Order{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+	filter.CustomerID = &claims.CustomerID
+
+	orders, err := h.service.GetOrders(filter)
+	if err != nil {
+		utils.SendInternalServerError(c, "Failed to fetch orders")
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, orders, filter.Pagination)
+}
+
+func (h *OrderHandler) GetMyActiveOrders(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	orders, err := h.service.GetCustomerActiveOrders(claims.CustomerID)
+	if err != nil {
+		utils.SendInternalServerError(c, "Failed to fetch orders")
+		return
+	}
+
+	utils.SendSuccessResponse(c, orders)
+}
+
+func (h *OrderHandler) GetMyOrderDetails(c *gin.Context) {
+	orderID, customerID, ok := parseCustomerOrder(c)
+	if !ok {
+		return
+	}
+
+	orderDetails, err := h.service.GetCustomerOrderDetails(orderID, customerID)
+	if err != nil {
+		if errors.Is(err, types.ErrOrderNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+			return
+		}
+		utils.SendInternalServerError(c, "Failed to fetch order details")
+		return
+	}
+
+	if orderDetails == nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		return
+	}
+
+	utils.SendSuccessResponse(c, orderDetails)
+}
+
+func (h *OrderHandler) GetPickupSlots(c *gin.Context) {
+	var filter types.PickupSlotsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	slots, err := h.service.GetPickupSlots(filter.StoreID)
+	if err != nil {
+		if errors.Is(err, types.ErrStoreUnavailable) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400OrderStore)
+			return
+		}
+		utils.SendInternalServerError(c, "failed to fetch pickup slots")
+		return
+	}
+
+	utils.SendSuccessResponse(c, slots)
+}
+
+func (h *OrderHandler) CreateMyOrder(c *gin.Context) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	var dto types.CreateCustomerOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	createdOrder, err := h.service.CreateCustomerOrder(claims.CustomerID, &dto)
+	if err != nil || createdOrder == nil {
+		sendCreateOrderError(c, err)
+		return
+	}
+
+	utils.SendResponseWithStatus(c, types.ConvertOrderToDTO(createdOrder), http.StatusCreated)
+}
+
+func (h *OrderHandler) Reorder(c *gin.Context) {
+	orderID, customerID, ok := parseCustomerOrder(c)
+	if !ok {
+		return
+	}
+
+	createdOrder, err := h.service.Reorder(orderID, customerID)
+	if err != nil || createdOrder == nil {
+		if errors.Is(err, types.ErrOrderNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+			return
+		}
+		sendCreateOrderError(c, err)
+		return
+	}
+
+	utils.SendResponseWithStatus(c, types.ConvertOrderToDTO(createdOrder), http.StatusCreated)
+}
+
+func (h *OrderHandler) CreateMyPaymentIntent(c *gin.Context) {
+	orderID, customerID, ok := parseCustomerOrder(c)
+	if !ok {
+		return
+	}
+
+	intent, err := h.service.CreateCustomerPaymentIntent(orderID, customerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Order)
+		case errors.Is(err, types.ErrInappropriateOrderStatus):
+			localization.SendLocalizedResponseWithKey(c, types.Response409OrderPaymentIntent)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500OrderPaymentIntent)
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, intent)
+}
+
+func parseCustomerOrder(c *gin.Context) (orderID, customerID uint, ok bool) {
+	claims, err := contexts.GetCustomerClaimsFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return 0, 0, false
+	}
+
+	orderID, err = utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Order)
+		return 0, 0, false
+	}
+
+	return orderID, claims.CustomerID, true
+}
@@ -11,16 +11,20 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository interface {
 	GetOrders(filter types.OrdersFilterQuery) ([]data.Order, error)
-	GetAllBaristaOrders(filter types.OrdersTimeZoneFilter) ([]data.Order, error)
+	GetCustomerActiveOrders(customerID uint) ([]data.Order, error)
+	GetAllBaristaOrders(filter types.BaristaOrdersFilter) ([]data.Order, error)
 	GetRawOrderById(orderID uint) (*data.Order, error)
 	GetOrderById(orderID uint) (*data.Order, error)
 	CreateOrder(order *data.Order) (uint, error)
+	AssignSuborderStations(orderID uint) error
 	UpdateOrderStatus(suborderID uint, updateData types.UpdateOrderDTO) error
 	DeleteOrder(orderID uint) error
 
@@ -35,7 +39,51 @@ type OrderRepository interface {
 	GetSuborderByID(suborderID uint) (*data.Suborder, error)
 	GetOrderInventory(orderID uint) (*storeInventoryManagersTypes.InventoryIDsList, error)
 
-	HandlePaymentSuccess(orderID uint, paymentTransaction *data.Transaction) (*data.Order, error)
+	HandlePaymentSuccess(intent *data.PaymentIntent, paymentTransaction *data.Transaction) (*data.Order, error)
+	CreateTransaction(transaction *data.Transaction) error
+	GetPaymentTransaction(orderID uint) (*data.Transaction, error)
+	GetUnreconciledTransactions(provider data.PaymentProviderName, createdBefore time.Time, limit int) ([]data.Transaction, error)
+	GetOrderPaymentSummaries(date time.Time, storeID *uint) ([]types.OrderPaymentSummary, error)
+	UpdateTransactionReconciliation(transactionID uint, status data.TransactionReconciliationStatus, reconciledAt time.Time) error
+
+	LockOrderWithSuborders(orderID uint) (*data.Order, error)
+	RefundSubOrder(suborderID uint) error
+	CreateOrderRefund(refund *data.OrderRefund) error
+	HasPendingOrderRefund(orderID uint) (bool, error)
+	LockPendingOrderRefund(refundID uint) (*data.OrderRefund, error)
+	SetOrderRefundProviderTransaction(refundID uint, providerTransactionID string) error
+	FinishOrderRefund(refundID uint, status data.OrderRefundStatus, transactionID *uint) error
+
+	CreatePaymentIntent(intent *data.PaymentIntent) error
+	GetPaymentIntent(provider data.PaymentProviderName, externalID string) (*data.PaymentIntent, error)
+	UpdatePaymentIntentStatus(intentID uint, status data.PaymentIntentStatus) error
+
+	CreateOrderCancellation(cancellation *data.OrderCancellation) error
+	GetOrderCancellationByID(cancellationID uint) (*data.OrderCancellation, error)
+	GetOrderCancellations(filter *types.OrderCancellationsFilterQuery) ([]data.OrderCancellation, error)
+	UpdateOrderCancellation(cancellation *data.OrderCancellation) error
+	SetSubordersCancellation(suborderIDs []uint, cancellationID *uint) error
+	CancelSubordersByCancellationID(cancellationID uint) ([]uint, error)
+	HasPendingCancellation(suborderIDs []uint) (bool, error)
+
+	GetCustomerAddress(customerID, addressID uint) (*data.CustomerAddress, error)
+	GetCustomer(customerID uint) (*data.Customer, error)
+	GetStoreWithSchedule(storeID uint) (*data.Store, error)
+	LockPickupSlotCapacity(storeID uint) (int, error)
+	CountPickupOrders(storeID uint, from, to time.Time) ([]types.PickupOrdersCount, error)
+	GetPreparationQueue(storeID uint) ([]data.Order, error)
+	GetStatusBoardOrders(storeID uint, readySince time.Time) ([]data.Order, error)
+	GetPreparationDurations(storeID uint, since time.Time) (types.PreparationDurations, error)
+	UpdateOrdersEstimatedReadyAt(estimates map[uint]time.Time) error
+	GetScheduledOrdersToRelease(releaseUntil time.Time) ([]data.Order, error)
+	SetOrdersReleased(orderIDs []uint, releasedAt time.Time) error
+	GetStoreFacilityAddress(storeID uint) (*data.FacilityAddress, error)
+	GetStoreEmployee(storeID, storeEmployeeID uint) (*data.StoreEmployee, error)
+	AssignCourier(orderID, courierID uint) error
+	GetOpenCashShiftID(storeID, employeeID uint) (*uint, error)
+	GetStoreEmployeeID(storeID, employeeID uint) (*uint, error)
+	CreateSuborderStatusChange(statusChange *data.SuborderStatusChange) error
+
 	HardDeleteOrderByID(orderID uint) error
 	CloneWithTransaction(tx *gorm.DB) orderRepository
 }
@@ -57,10 +105,15 @@ func (r *orderRepository) CloneWithTransaction(tx *gorm.DB) orderRepository {
 }
 
 func (r *orderRepository) CreateOrder(order *data.Order) (uint, error) {
-	// Decide at what hour in UTC the "day" (or shift) resets
-	const shiftStartHour = 0 // 0 means midnight UTC, 4 would mean 4:00 AM UTC, etc.
+	// Decide at what hour in the store timezone the "day" (or shift) resets
+	const shiftStartHour = 0 // 0 means the local midnight, 4 would mean 4:00 AM of the store, etc.
 	const maxDisplayNumber = 99
 
+	location, err := r.getStoreLocation(order.StoreID)
+	if err != nil {
+		return 0, err
+	}
+
 	// (A) Lock rows for the store
 	if err := r.db.Exec(`
 					SELECT 1
@@ -99,8 +152,8 @@ func (r *orderRepository) CreateOrder(order *data.Order) (uint, error) {
 		nextDisplayNumber = 1
 	} else {
 		// Compare "shift date" of now and the last order
-		lastShiftDate := getShiftDate(lastUTC, shiftStartHour)
-		currShiftDate := getShiftDate(nowUTC, shiftStartHour)
+		lastShiftDate := getShiftDate(lastUTC, location, shiftStartHour)
+		currShiftDate := getShiftDate(nowUTC, location, shiftStartHour)
 
 		if lastShiftDate != currShiftDate {
 			// It's a new day/shift, reset to 1
@@ -127,14 +180,36 @@ func (r *orderRepository) CreateOrder(order *data.Order) (uint, error) {
 	return order.ID, nil
 }
 
-// getShiftDate normalizes a time to the "shift start" boundary in UTC.
-func getShiftDate(t time.Time, shiftStartHour int) time.Time {
-	// Force t to UTC (in case it's not already)
-	t = t.UTC()
+// AssignSuborderStations routes every suborder of the order to the store station subscribed to the machine category of its product,
+// the suborders of the categories without a station stay unrouted and are shown only in the whole store queue
+func (r *orderRepository) AssignSuborderStations(orderID uint) error {
+	err := r.db.Exec(`
+		UPDATE suborders
+		SET station_id = store_station_categories.station_id
+		FROM orders, store_product_sizes, product_sizes, products, product_categories, store_station_categories
+		WHERE suborders.order_id = ?
+			AND orders.id = suborders.order_id
+			AND store_product_sizes.id = suborders.store_product_size_id
+			AND product_sizes.id = store_product_sizes.product_size_id
+			AND products.id = product_sizes.product_id
+			AND product_categories.id = products.category_id
+			AND store_station_categories.store_id = orders.store_id
+			AND store_station_categories.machine_category = product_categories.machine_category
+			AND store_station_categories.deleted_at IS NULL
+	`, orderID).Error
+	if err != nil {
+		return fmt.Errorf("failed to assign suborder stations: %w", err)
+	}
+	return nil
+}
+
+// getShiftDate normalizes a time to the "shift start" boundary in the store timezone.
+func getShiftDate(t time.Time, location *time.Location, shiftStartHour int) time.Time {
+	t = t.In(location)
 
-	// Build a time at shiftStartHour:00 in UTC for the same calendar day as t
-	// Example: if shiftStartHour=4, then "today" starts at 04:00 UTC.
-	shifted := time.Date(t.Year(), t.Month(), t.Day(), shiftStartHour, 0, 0, 0, time.UTC)
+	// Build a time at shiftStartHour:00 of the store for the same calendar day as t
+	// Example: if shiftStartHour=4, then "today" starts at 04:00 of the store.
+	shifted := time.Date(t.Year(), t.Month(), t.Day(), shiftStartHour, 0, 0, 0, location)
 
 	// If t is earlier in the day than shiftStartHour, that means the "shift date"
 	// is actually the previous day. So we subtract one day.
@@ -274,6 +349,7 @@ func (r *orderRepository) GetOrders(filter types.OrdersFilterQuery) ([]data.Orde
 		Preload("Suborders.StoreProductSize.ProductSize.Unit").
 		Preload("Suborders.StoreProductSize.ProductSize.Product.Category").
 		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
+		Preload("Suborders.Discounts").
 		Order("created_at DESC")
 
 	if filter.Search != nil && *filter.Search != "" {
@@ -292,6 +368,10 @@ func (r *orderRepository) GetOrders(filter types.OrdersFilterQuery) ([]data.Orde
 		query = query.Where("store_id = ?", *filter.StoreID)
 	}
 
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+
 	var err error
 	query, err = utils.ApplyPagination(query, filter.Pagination, &data.Order{})
 	if err != nil {
@@ -305,7 +385,25 @@ func (r *orderRepository) GetOrders(filter types.OrdersFilterQuery) ([]data.Orde
 	return orders, nil
 }
 
-func (r *orderRepository) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter) ([]data.Order, error) {
+func (r *orderRepository) GetCustomerActiveOrders(customerID uint) ([]data.Order, error) {
+	var orders []data.Order
+
+	err := r.db.
+		Preload("Suborders.StoreProductSize.ProductSize.Unit").
+		Preload("Suborders.StoreProductSize.ProductSize.Product.Category").
+		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
+		Preload("Suborders.Discounts").
+		Where("customer_id = ? AND status IN (?)", customerID, types.CustomerActiveOrderStatuses).
+		Order("created_at DESC").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active orders of customer %d: %w", customerID, err)
+	}
+
+	return orders, nil
+}
+
+func (r *orderRepository) GetAllBaristaOrders(filter types.BaristaOrdersFilter) ([]data.Order, error) {
 	var orders []data.Order
 
 	// Validate StoreID first
@@ -313,22 +411,20 @@ func (r *orderRepository) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter)
 		return nil, fmt.Errorf("storeID is required")
 	}
 
-	// Get the correct timezone location
-	location, err := getTimeZoneLocation(filter)
+	// The day of the orders is the current date of the store
+	location, err := r.getStoreLocation(*filter.StoreID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the current date in the specified timezone
 	now := time.Now().In(location)
-	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	startOfToday := storesTypes.StartOfLocalDay(now, location)
 
 	if filter.IncludeYesterdayOrders != nil && *filter.IncludeYesterdayOrders {
-		yesterday := now.AddDate(0, 0, -1)
-		startOfToday = time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, location)
+		startOfToday = storesTypes.StartOfLocalDay(now.AddDate(0, 0, -1), location)
 	}
 
-	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 999999999, location)
+	endOfToday := storesTypes.StartOfLocalDay(now.AddDate(0, 0, 1), location).Add(-time.Nanosecond)
 
 	// Convert start and end of today to UTC for database querying
 	startOfTodayUTC := startOfToday.UTC()
@@ -340,9 +436,21 @@ func (r *orderRepository) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter)
 		Preload("Suborders.StoreProductSize.ProductSize.Unit").
 		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
 		Where("store_id = ?", *filter.StoreID).
-		Where("status NOT IN (?)", []data.OrderStatus{data.OrderStatusWaitingForPayment}).
-		Where("created_at BETWEEN ? AND ?", startOfTodayUTC, endOfTodayUTC).
-		Order("created_at ASC")
+		Where("status NOT IN (?)", []data.OrderStatus{data.OrderStatusWaitingForPayment, data.OrderStatusCancelled}).
+		Where("((pickup_at IS NULL AND created_at BETWEEN ? AND ?) OR pickup_at BETWEEN ? AND ?)", startOfTodayUTC, endOfTodayUTC, startOfTodayUTC, endOfTodayUTC).
+		Order("COALESCE(pickup_at, created_at) ASC")
+
+	// The station queue holds only the orders with suborders routed to the station, and only those suborders
+	if filter.StationID != nil {
+		query = query.
+			Preload("Suborders", "station_id = ?", *filter.StationID).
+			Where("EXISTS (SELECT 1 FROM suborders WHERE suborders.order_id = orders.id AND suborders.station_id = ? AND suborders.deleted_at IS NULL)", *filter.StationID)
+	}
+
+	// Scheduled pickup orders are held until the lead time before their slot
+	if filter.ReleaseUntil != nil {
+		query = query.Where("(pickup_at IS NULL OR pickup_at <= ?)", filter.ReleaseUntil.UTC())
+	}
 
 	// Optionally filter by an array of statuses if provided
 	if len(filter.Statuses) > 0 {
@@ -353,7 +461,9 @@ func (r *orderRepository) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter)
 	if filter.TimeGapMinutes != nil && *filter.TimeGapMinutes > 0 {
 		cutoffTime := now.Add(-time.Duration(*filter.TimeGapMinutes) * time.Minute)
 		cutoffTimeUTC := cutoffTime.UTC()
-		query = query.Where("(status != ? OR completed_at >= ?)", data.OrderStatusCompleted, cutoffTimeUTC)
+		query = query.
+			Where("(status != ? OR completed_at >= ?)", data.OrderStatusCompleted, cutoffTimeUTC).
+			Where("(status != ? OR delivered_at >= ?)", data.OrderStatusDelivered, cutoffTimeUTC)
 	}
 
 	// Execute the query
@@ -365,26 +475,17 @@ func (r *orderRepository) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter)
 	return orders, nil
 }
 
-// Helper function to get the timezone location
-func getTimeZoneLocation(filter types.OrdersTimeZoneFilter) (*time.Location, error) {
-	// Default to UTC
-	location := time.UTC
-
-	// If timezone location is specified, use that
-	if filter.TimeZoneLocation != nil && *filter.TimeZoneLocation != "" {
-		var err error
-		location, err = time.LoadLocation(*filter.TimeZoneLocation)
-		if err != nil {
-			return nil, fmt.Errorf("invalid timezone location: %w", err)
-		}
-	} else if filter.TimeZoneOffset != nil {
-		// If timezone offset is specified, create a fixed timezone
-		hours := int(*filter.TimeZoneOffset) / 60
-		minutes := int(*filter.TimeZoneOffset) % 60
-		location = time.FixedZone(fmt.Sprintf("UTC%+d:%02d", hours, minutes), int(*filter.TimeZoneOffset)*60)
+// getStoreLocation loads the timezone of the store, the days of its orders start at the local midnight
+func (r *orderRepository) getStoreLocation(storeID uint) (*time.Location, error) {
+	var timezone string
+	err := r.db.Model(&data.Store{}).
+		Where("id = ?", storeID).
+		Select("timezone").
+		Scan(&timezone).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the timezone of store %d: %w", storeID, err)
 	}
-
-	return location, nil
+	return storesTypes.StoreLocation(timezone), nil
 }
 
 func (r *orderRepository) GetOrderById(orderId uint) (*data.Order, error) {
@@ -392,6 +493,7 @@ func (r *orderRepository) GetOrderById(orderId uint) (*data.Order, error) {
 	err := r.db.Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
 		Preload("Suborders.StoreProductSize.ProductSize.Unit").
 		Preload("Suborders.StoreProductSize.ProductSize.Product.Category").
+		Preload("Suborders.Discounts").
 		Preload("Store").
 		Where("id = ?", orderId).
 		First(&order).Error
@@ -471,6 +573,7 @@ func (r *orderRepository) GetOrderBySubOrderID(subOrderID uint) (*data.Order, er
 	var order data.Order
 
 	err := r.db.
+		Preload("Store").
 		Preload("Suborders").
 		Preload("Suborders.StoreProductSize").
 		Preload("Suborders.StoreProductSize.ProductSize").
@@ -494,7 +597,15 @@ func (r *orderRepository) GetOrderDetails(orderID uint, filter *contexts.StoreCo
 	query := r.db.Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
 		Preload("Suborders.StoreProductSize.ProductSize.Product").
 		Preload("Suborders.StoreProductSize.ProductSize.Unit").
-		Preload("DeliveryAddress").
+		Preload("Suborders.Discounts").
+		Preload("Suborders.StatusChanges", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Suborders.StatusChanges.StoreEmployee.Employee").
+		Preload("DeliveryAddress", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped() // the customer may delete the address after the order
+		}).
+		Preload("Courier.Employee").
 		Preload("Transactions").
 		Where(&data.Order{
 			BaseEntity: data.BaseEntity{
@@ -528,42 +639,26 @@ func (r *orderRepository) GetOrderDetails(orderID uint, filter *contexts.StoreCo
 	return &order, nil
 }
 
+// orderLocalCreatedAtSQL is the creation time of the order in the timezone of its store
+const orderLocalCreatedAtSQL = "(orders.created_at AT TIME ZONE (SELECT stores.timezone FROM stores WHERE stores.id = orders.store_id))"
+
 func (r *orderRepository) GetOrdersForExport(filter *types.OrdersExportFilterQuery) ([]data.Order, error) {
 	var orders []data.Order
 
 	query := r.db.
 		Preload("Suborders.StoreProductSize.ProductSize.Product").
 		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
+		Preload("Suborders.Discounts").
 		Preload("Store").
 		Preload("DeliveryAddress")
 
-	clientLoc := time.Local
-	if filter.TimeZoneLocation != nil && *filter.TimeZoneLocation != "" {
-		if loc, err := time.LoadLocation(*filter.TimeZoneLocation); err == nil {
-			clientLoc = loc
-		}
-	}
-
+	// The dates are the local dates of the stores of the orders
 	if filter.StartDate != nil {
-		startLocal := time.Date(
-			filter.StartDate.Year(),
-			filter.StartDate.Month(),
-			filter.StartDate.Day(),
-			0, 0, 0, 0,
-			clientLoc,
-		)
-		query = query.Where("created_at >= ?", startLocal.UTC())
+		query = query.Where(orderLocalCreatedAtSQL+" >= ?::date", filter.StartDate.Format(time.DateOnly))
 	}
 
 	if filter.EndDate != nil {
-		endLocal := time.Date(
-			filter.EndDate.Year(),
-			filter.EndDate.Month(),
-			filter.EndDate.Day(),
-			23, 59, 59, 999999999,
-			clientLoc,
-		)
-		query = query.Where("created_at <= ?", endLocal.UTC())
+		query = query.Where(orderLocalCreatedAtSQL+" < ?::date + 1", filter.EndDate.Format(time.DateOnly))
 	}
 
 	if filter.StoreID != nil {
@@ -574,15 +669,16 @@ func (r *orderRepository) GetOrdersForExport(filter *types.OrdersExportFilterQue
 		return nil, err
 	}
 
-	orders = convertOrdersToLocalTime(orders, clientLoc)
+	orders = convertOrdersToLocalTime(orders)
 
 	return orders, nil
 }
 
-func convertOrdersToLocalTime(orders []data.Order, loc *time.Location) []data.Order {
+// convertOrdersToLocalTime expects the stores of the orders preloaded
+func convertOrdersToLocalTime(orders []data.Order) []data.Order {
 	for i, order := range orders {
 		if !order.CreatedAt.IsZero() {
-			orders[i].CreatedAt = order.CreatedAt.In(loc)
+			orders[i].CreatedAt = order.CreatedAt.In(storesTypes.StoreLocation(order.Store.Timezone))
 		}
 	}
 	return orders
@@ -603,7 +699,8 @@ func (r *orderRepository) GetSuborderByID(suborderID uint) (*data.Suborder, erro
 	return &suborder, nil
 }
 
-func (r *orderRepository) HandlePaymentSuccess(orderID uint, paymentTransaction *data.Transaction) (*data.Order, error) {
+func (r *orderRepository) HandlePaymentSuccess(intent *data.PaymentIntent, paymentTransaction *data.Transaction) (*data.Order, error) {
+	orderID := intent.OrderID
 	order, err := r.GetRawOrderById(orderID)
 	if err != nil {
 		return nil, err
@@ -613,6 +710,7 @@ func (r *orderRepository) HandlePaymentSuccess(orderID uint, paymentTransaction
 		return nil, types.ErrInappropriateOrderStatus
 	}
 	order.Status = data.OrderStatusPending
+	paymentTransaction.CashShiftID = order.CashShiftID
 
 	err = r.db.Transaction(func(tx *gorm.DB) error {
 		err := tx.Model(&data.Order{}).
@@ -625,6 +723,13 @@ func (r *orderRepository) HandlePaymentSuccess(orderID uint, paymentTransaction
 		if err := tx.Create(paymentTransaction).Error; err != nil {
 			return fmt.Errorf("failed to create transaction: %w", err)
 		}
+
+		err = tx.Model(&data.PaymentIntent{}).
+			Where("id = ?", intent.ID).
+			Update("status", data.PaymentIntentStatusSucceeded).Error
+		if err != nil {
+			return fmt.Errorf("failed to update payment intent %d: %w", intent.ID, err)
+		}
 		return nil
 	})
 	if err != nil {
@@ -634,6 +739,588 @@ func (r *orderRepository) HandlePaymentSuccess(orderID uint, paymentTransaction
 	return order, nil
 }
 
+func (r *orderRepository) CreateTransaction(transaction *data.Transaction) error {
+	if err := r.db.Create(transaction).Error; err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *orderRepository) GetPaymentTransaction(orderID uint) (*data.Transaction, error) {
+	var transaction data.Transaction
+	err := r.db.
+		Where("order_id = ? AND type = ?", orderID, data.TransactionTypePayment).
+		Order("created_at DESC").
+		First(&transaction).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch payment transaction of order %d: %w", orderID, err)
+	}
+	return &transaction, nil
+}
+
+// LockOrderWithSuborders locks the order and its suborders until the end of the transaction
+func (r *orderRepository) LockOrderWithSuborders(orderID uint) (*data.Order, error) {
+	var order data.Order
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Suborders", func(db *gorm.DB) *gorm.DB {
+			return db.Clauses(clause.Locking{Strength: "UPDATE"}).Order("id ASC")
+		}).
+		Where("id = ?", orderID).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock order %d: %w", orderID, err)
+	}
+	return &order, nil
+}
+
+// RefundSubOrder moves the suborder to REFUNDED only from a refundable status
+func (r *orderRepository) RefundSubOrder(suborderID uint) error {
+	res := r.db.Model(&data.Suborder{}).
+		Where("id = ? AND status NOT IN ?", suborderID, []data.SubOrderStatus{data.SubOrderStatusRefunded, data.SubOrderStatusCancelled}).
+		Update("status", data.SubOrderStatusRefunded)
+	if res.Error != nil {
+		return fmt.Errorf("failed to refund suborder %d: %w", suborderID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: suborder %d", types.ErrSuborderNotRefundable, suborderID)
+	}
+	return nil
+}
+
+func (r *orderRepository) CreateOrderRefund(refund *data.OrderRefund) error {
+	if err := r.db.Create(refund).Error; err != nil {
+		return fmt.Errorf("failed to create refund of order %d: %w", refund.OrderID, err)
+	}
+	return nil
+}
+
+func (r *orderRepository) HasPendingOrderRefund(orderID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&data.OrderRefund{}).
+		Where("order_id = ? AND status = ?", orderID, data.OrderRefundStatusPending).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending refunds of order %d: %w", orderID, err)
+	}
+	return count > 0, nil
+}
+
+func (r *orderRepository) LockPendingOrderRefund(refundID uint) (*data.OrderRefund, error) {
+	var refund data.OrderRefund
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ?", refundID, data.OrderRefundStatusPending).
+		First(&refund).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrRefundNotPending
+		}
+		return nil, fmt.Errorf("failed to lock refund %d: %w", refundID, err)
+	}
+	return &refund, nil
+}
+
+func (r *orderRepository) SetOrderRefundProviderTransaction(refundID uint, providerTransactionID string) error {
+	return r.db.Model(&data.OrderRefund{}).
+		Where("id = ?", refundID).
+		Update("provider_transaction_id", providerTransactionID).Error
+}
+
+func (r *orderRepository) FinishOrderRefund(refundID uint, status data.OrderRefundStatus, transactionID *uint) error {
+	res := r.db.Model(&data.OrderRefund{}).
+		Where("id = ? AND status = ?", refundID, data.OrderRefundStatusPending).
+		Updates(map[string]interface{}{
+			"status":         status,
+			"transaction_id": transactionID,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to finish refund %d: %w", refundID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return types.ErrRefundNotPending
+	}
+	return nil
+}
+
+func (r *orderRepository) GetUnreconciledTransactions(provider data.PaymentProviderName, createdBefore time.Time, limit int) ([]data.Transaction, error) {
+	var transactions []data.Transaction
+	err := r.db.
+		Where("provider = ? AND reconciled_at IS NULL AND created_at < ?", provider, createdBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch unreconciled %s transactions: %w", provider, err)
+	}
+	return transactions, nil
+}
+
+// GetOrderPaymentSummaries sums the payments and refunds of the orders created on the local date of their stores
+func (r *orderRepository) GetOrderPaymentSummaries(date time.Time, storeID *uint) ([]types.OrderPaymentSummary, error) {
+	var summaries []types.OrderPaymentSummary
+
+	query := r.db.Model(&data.Order{}).
+		Select(`orders.id AS order_id, orders.store_id, stores.name AS store_name, stores.timezone AS store_timezone, orders.display_number,
+			orders.status, orders.total, orders.created_at,
+			COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.type = ?), 0) AS paid,
+			COALESCE(SUM(transactions.amount) FILTER (WHERE transactions.type = ?), 0) AS refunded,
+			COUNT(transactions.id) FILTER (WHERE transactions.type = ?) AS payments_count,
+			COALESCE((
+				SELECT SUM(CASE WHEN suborders.tax_mode = ? THEN suborders.price + suborders.tax_amount ELSE suborders.price END)
+				FROM suborders
+				WHERE suborders.order_id = orders.id AND suborders.status = ? AND suborders.deleted_at IS NULL
+			), 0) AS refunded_suborders_total`,
+			data.TransactionTypePayment, data.TransactionTypeRefund, data.TransactionTypePayment,
+			data.TaxModeExclusive, data.SubOrderStatusRefunded).
+		Joins("JOIN stores ON stores.id = orders.store_id").
+		Joins("LEFT JOIN transactions ON transactions.order_id = orders.id AND transactions.deleted_at IS NULL").
+		Where("(orders.created_at AT TIME ZONE stores.timezone)::date = ?::date", date.Format(time.DateOnly))
+
+	if storeID != nil {
+		query = query.Where("orders.store_id = ?", *storeID)
+	}
+
+	err := query.
+		Group("orders.id, stores.name, stores.timezone").
+		Order("orders.store_id, orders.id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order payment summaries: %w", err)
+	}
+	return summaries, nil
+}
+
+func (r *orderRepository) UpdateTransactionReconciliation(transactionID uint, status data.TransactionReconciliationStatus, reconciledAt time.Time) error {
+	err := r.db.Model(&data.Transaction{}).
+		Where("id = ?", transactionID).
+		Updates(map[string]interface{}{
+			"reconciliation_status": status,
+			"reconciled_at":         reconciledAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update reconciliation of transaction %d: %w", transactionID, err)
+	}
+	return nil
+}
+
+func (r *orderRepository) CreatePaymentIntent(intent *data.PaymentIntent) error {
+	if err := r.db.Create(intent).Error; err != nil {
+		return fmt.Errorf("failed to create payment intent: %w", err)
+	}
+	return nil
+}
+
+func (r *orderRepository) GetPaymentIntent(provider data.PaymentProviderName, externalID string) (*data.PaymentIntent, error) {
+	var intent data.PaymentIntent
+	err := r.db.
+		Where("provider = ? AND external_id = ?", provider, externalID).
+		First(&intent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrPaymentIntentNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch payment intent %s: %w", externalID, err)
+	}
+	return &intent, nil
+}
+
+func (r *orderRepository) UpdatePaymentIntentStatus(intentID uint, status data.PaymentIntentStatus) error {
+	err := r.db.Model(&data.PaymentIntent{}).
+		Where("id = ?", intentID).
+		Update("status", status).Error
+	if err != nil {
+		return fmt.Errorf("failed to update payment intent %d: %w", intentID, err)
+	}
+	return nil
+}
+
+func (r *orderRepository) CreateOrderCancellation(cancellation *data.OrderCancellation) error {
+	if err := r.db.Create(cancellation).Error; err != nil {
+		return fmt.Errorf("failed to create order cancellation: %w", err)
+	}
+	return nil
+}
+
+func (r *orderRepository) GetOrderCancellationByID(cancellationID uint) (*data.OrderCancellation, error) {
+	var cancellation data.OrderCancellation
+	err := r.db.
+		Preload("Order.Store").
+		Preload("Suborders").
+		Preload("RequestedBy").
+		Preload("ReviewedBy").
+		Where("id = ?", cancellationID).
+		First(&cancellation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrCancellationNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch order cancellation with ID %d: %w", cancellationID, err)
+	}
+
+	return &cancellation, nil
+}
+
+func (r *orderRepository) GetOrderCancellations(filter *types.OrderCancellationsFilterQuery) ([]data.OrderCancellation, error) {
+	var cancellations []data.OrderCancellation
+
+	query := r.db.Model(&data.OrderCancellation{}).
+		Preload("Order").
+		Preload("Suborders").
+		Preload("RequestedBy").
+		Preload("ReviewedBy")
+
+	if filter.StoreID != nil {
+		query = query.Where("order_id IN (?)",
+			r.db.Model(&data.Order{}).Select("id").Where("store_id = ?", *filter.StoreID))
+	}
+
+	if filter.OrderID != nil {
+		query = query.Where("order_id = ?", *filter.OrderID)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	if filter.Reason != nil {
+		query = query.Where("reason = ?", *filter.Reason)
+	}
+
+	var err error
+	query, err = utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.OrderCancellation{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&cancellations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch order cancellations: %w", err)
+	}
+
+	return cancellations, nil
+}
+
+func (r *orderRepository) UpdateOrderCancellation(cancellation *data.OrderCancellation) error {
+	return r.db.Model(&data.OrderCancellation{}).
+		Where("id = ?", cancellation.ID).
+		Updates(map[string]interface{}{
+			"status":         cancellation.Status,
+			"reviewed_by_id": cancellation.ReviewedByID,
+			"reviewed_at":    cancellation.ReviewedAt,
+		}).Error
+}
+
+func (r *orderRepository) SetSubordersCancellation(suborderIDs []uint, cancellationID *uint) error {
+	return r.db.Model(&data.Suborder{}).
+		Where("id IN (?)", suborderIDs).
+		Update("cancellation_id", cancellationID).Error
+}
+
+// CancelSubordersByCancellationID cancels only the suborders that are still active,
+// the ones completed while the request was pending are left untouched
+func (r *orderRepository) CancelSubordersByCancellationID(cancellationID uint) ([]uint, error) {
+	var suborderIDs []uint
+	err := r.db.Model(&data.Suborder{}).
+		Where("cancellation_id = ? AND status IN (?)", cancellationID,
+			[]data.SubOrderStatus{data.SubOrderStatusPending, data.SubOrderStatusPreparing}).
+		Pluck("id", &suborderIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch suborders of cancellation %d: %w", cancellationID, err)
+	}
+
+	if len(suborderIDs) == 0 {
+		return suborderIDs, nil
+	}
+
+	err = r.db.Model(&data.Suborder{}).
+		Where("id IN (?)", suborderIDs).
+		Update("status", data.SubOrderStatusCancelled).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel suborders: %w", err)
+	}
+
+	return suborderIDs, nil
+}
+
+func (r *orderRepository) HasPendingCancellation(suborderIDs []uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&data.Suborder{}).
+		Joins("JOIN order_cancellations ON order_cancellations.id = suborders.cancellation_id").
+		Where("suborders.id IN (?) AND order_cancellations.status = ?", suborderIDs, data.OrderCancellationStatusPendingApproval).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending cancellations: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *orderRepository) GetCustomerAddress(customerID, addressID uint) (*data.CustomerAddress, error) {
+	var address data.CustomerAddress
+	err := r.db.
+		Where("id = ? AND customer_id = ?", addressID, customerID).
+		First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrInvalidDeliveryAddress
+		}
+		return nil, fmt.Errorf("failed to fetch address %d of customer %d: %w", addressID, customerID, err)
+	}
+	return &address, nil
+}
+
+func (r *orderRepository) GetCustomer(customerID uint) (*data.Customer, error) {
+	var customer data.Customer
+	err := r.db.Where("id = ?", customerID).First(&customer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrCustomerNotVerified
+		}
+		return nil, fmt.Errorf("failed to fetch customer %d: %w", customerID, err)
+	}
+	return &customer, nil
+}
+
+// GetStoreWithSchedule loads the exceptions starting from yesterday, which may still last after the midnight
+func (r *orderRepository) GetStoreWithSchedule(storeID uint) (*data.Store, error) {
+	var store data.Store
+	err := r.db.
+		Preload("WorkingHours").
+		Preload("HoursExceptions", "date >= CURRENT_DATE - 1").
+		Where("id = ?", storeID).
+		First(&store).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreUnavailable
+		}
+		return nil, fmt.Errorf("failed to fetch store %d: %w", storeID, err)
+	}
+	return &store, nil
+}
+
+// LockPickupSlotCapacity locks the store row for the rest of the transaction and returns its pickup slot capacity
+func (r *orderRepository) LockPickupSlotCapacity(storeID uint) (int, error) {
+	var store data.Store
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "pickup_slot_capacity").
+		Where("id = ?", storeID).
+		First(&store).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, types.ErrStoreUnavailable
+		}
+		return 0, fmt.Errorf("failed to lock store %d: %w", storeID, err)
+	}
+	return store.PickupSlotCapacity, nil
+}
+
+func (r *orderRepository) CountPickupOrders(storeID uint, from, to time.Time) ([]types.PickupOrdersCount, error) {
+	var counts []types.PickupOrdersCount
+	err := r.db.Model(&data.Order{}).
+		Select("pickup_at, COUNT(*) AS count").
+		Where("store_id = ? AND pickup_at BETWEEN ? AND ?", storeID, from.UTC(), to.UTC()).
+		Where("status NOT IN (?)", []data.OrderStatus{data.OrderStatusCancelled, data.OrderStatusRefunded}).
+		Group("pickup_at").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pickup orders of store %d: %w", storeID, err)
+	}
+	return counts, nil
+}
+
+// GetPreparationQueue returns the paid orders of the store in the barista queue order with their suborders still to be prepared,
+// the scheduled orders are taken once they are released
+func (r *orderRepository) GetPreparationQueue(storeID uint) ([]data.Order, error) {
+	var orders []data.Order
+	err := r.db.
+		Preload("Suborders", "status IN (?)", []data.SubOrderStatus{data.SubOrderStatusPending, data.SubOrderStatusPreparing}).
+		Where("store_id = ?", storeID).
+		Where("status IN (?)", []data.OrderStatus{data.OrderStatusPending, data.OrderStatusPreparing}).
+		Where("(pickup_at IS NULL OR released_at IS NOT NULL)").
+		Order("COALESCE(pickup_at, created_at) ASC").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preparation queue of store %d: %w", storeID, err)
+	}
+	return orders, nil
+}
+
+// GetStatusBoardOrders returns the orders of the store to be picked up, which are in the queue or were completed since the given time
+func (r *orderRepository) GetStatusBoardOrders(storeID uint, readySince time.Time) ([]data.Order, error) {
+	var orders []data.Order
+	err := r.db.
+		Where("store_id = ? AND delivery_address_id IS NULL", storeID).
+		Where("((status IN (?) AND (pickup_at IS NULL OR released_at IS NOT NULL)) OR (status = ? AND completed_at >= ?))",
+			[]data.OrderStatus{data.OrderStatusPending, data.OrderStatusPreparing},
+			data.OrderStatusCompleted, readySince.UTC()).
+		Order("COALESCE(completed_at, pickup_at, created_at) ASC").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch status board orders of store %d: %w", storeID, err)
+	}
+	return orders, nil
+}
+
+// GetPreparationDurations returns the median preparation duration of every product size of the store completed since the given time
+func (r *orderRepository) GetPreparationDurations(storeID uint, since time.Time) (types.PreparationDurations, error) {
+	var rows []struct {
+		StoreProductSizeID uint
+		Seconds            float64
+	}
+
+	err := r.db.Model(&data.Suborder{}).
+		Select(`suborders.store_product_size_id,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM suborders.completed_at - suborders.started_at)) AS seconds`).
+		Joins("JOIN orders ON orders.id = suborders.order_id").
+		Where("orders.store_id = ?", storeID).
+		Where("suborders.status = ?", data.SubOrderStatusCompleted).
+		Where("suborders.started_at IS NOT NULL AND suborders.completed_at >= ?", since.UTC()).
+		Group("suborders.store_product_size_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preparation durations of store %d: %w", storeID, err)
+	}
+
+	durations := make(types.PreparationDurations, len(rows))
+	for _, row := range rows {
+		durations[row.StoreProductSizeID] = time.Duration(row.Seconds * float64(time.Second))
+	}
+	return durations, nil
+}
+
+func (r *orderRepository) UpdateOrdersEstimatedReadyAt(estimates map[uint]time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for orderID, readyAt := range estimates {
+			err := tx.Model(&data.Order{}).
+				Where("id = ?", orderID).
+				Update("estimated_ready_at", readyAt.UTC()).Error
+			if err != nil {
+				return fmt.Errorf("failed to update estimated ready time of order %d: %w", orderID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *orderRepository) GetScheduledOrdersToRelease(releaseUntil time.Time) ([]data.Order, error) {
+	var orders []data.Order
+	err := r.db.
+		Preload("Suborders.StoreProductSize.ProductSize.Product.Category").
+		Preload("Suborders.StoreProductSize.ProductSize.Unit").
+		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
+		Where("pickup_at IS NOT NULL AND released_at IS NULL").
+		Where("pickup_at <= ?", releaseUntil.UTC()).
+		Where("status NOT IN (?)", []data.OrderStatus{data.OrderStatusWaitingForPayment, data.OrderStatusCancelled, data.OrderStatusRefunded}).
+		Order("pickup_at ASC").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled orders to release: %w", err)
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) SetOrdersReleased(orderIDs []uint, releasedAt time.Time) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	err := r.db.Model(&data.Order{}).
+		Where("id IN (?)", orderIDs).
+		Update("released_at", releasedAt.UTC()).Error
+	if err != nil {
+		return fmt.Errorf("failed to release scheduled orders: %w", err)
+	}
+	return nil
+}
+
+func (r *orderRepository) GetStoreFacilityAddress(storeID uint) (*data.FacilityAddress, error) {
+	var address data.FacilityAddress
+	err := r.db.
+		Joins("JOIN stores ON stores.facility_address_id = facility_addresses.id").
+		Where("stores.id = ?", storeID).
+		First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreLocationUnknown
+		}
+		return nil, fmt.Errorf("failed to fetch facility address of store %d: %w", storeID, err)
+	}
+	return &address, nil
+}
+
+func (r *orderRepository) GetStoreEmployee(storeID, storeEmployeeID uint) (*data.StoreEmployee, error) {
+	var storeEmployee data.StoreEmployee
+	err := r.db.
+		Preload("Employee").
+		Joins("JOIN employees ON employees.id = store_employees.employee_id").
+		Where("store_employees.id = ? AND store_employees.store_id = ?", storeEmployeeID, storeID).
+		Where("employees.is_active = ?", true).
+		First(&storeEmployee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store employee %d: %w", storeEmployeeID, err)
+	}
+	return &storeEmployee, nil
+}
+
+func (r *orderRepository) AssignCourier(orderID, courierID uint) error {
+	err := r.db.Model(&data.Order{}).
+		Where("id = ?", orderID).
+		Update("courier_id", courierID).Error
+	if err != nil {
+		return fmt.Errorf("failed to assign courier %d to order %d: %w", courierID, orderID, err)
+	}
+	return nil
+}
+
+// GetOpenCashShiftID picks the open shift of the employee, the orders of the employees without a shift (e.g. kiosks) go to the latest opened shift of the store
+func (r *orderRepository) GetOpenCashShiftID(storeID, employeeID uint) (*uint, error) {
+	var shift data.CashShift
+	err := r.db.Model(&data.CashShift{}).
+		Select("cash_shifts.id").
+		Joins("JOIN store_employees ON store_employees.id = cash_shifts.store_employee_id").
+		Where("cash_shifts.store_id = ? AND cash_shifts.status = ?", storeID, data.CashShiftStatusOpen).
+		Order(gorm.Expr("store_employees.employee_id = ? DESC, cash_shifts.opened_at DESC", employeeID)).
+		Limit(1).
+		Find(&shift).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open cash shift of store %d: %w", storeID, err)
+	}
+
+	if shift.ID == 0 {
+		return nil, nil
+	}
+	return &shift.ID, nil
+}
+
+// GetStoreEmployeeID returns nil for the employees who do not work in the store, e.g. the franchisee managers
+func (r *orderRepository) GetStoreEmployeeID(storeID, employeeID uint) (*uint, error) {
+	var storeEmployee data.StoreEmployee
+	err := r.db.Model(&data.StoreEmployee{}).
+		Select("id").
+		Where("store_id = ? AND employee_id = ?", storeID, employeeID).
+		Limit(1).
+		Find(&storeEmployee).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch store employee of employee %d in store %d: %w", employeeID, storeID, err)
+	}
+
+	if storeEmployee.ID == 0 {
+		return nil, nil
+	}
+	return &storeEmployee.ID, nil
+}
+
+func (r *orderRepository) CreateSuborderStatusChange(statusChange *data.SuborderStatusChange) error {
+	return r.db.Create(statusChange).Error
+}
+
 func (r *orderRepository) HardDeleteOrderByID(orderID uint) error {
 	if err := r.db.Unscoped().Where("id = ?", orderID).Delete(&data.Order{}).Error; err != nil {
 		return err
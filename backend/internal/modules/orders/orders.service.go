@@ -2,33 +2,47 @@ package orders
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/config"
 	storeAdditivesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/additives/storeAdditivies/types"
 	storeStocksTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks/types"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	storeInventoryManagersTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils/taskqueue"
 
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils/censor"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	storeAdditives "github.com/Global-Optima/zeep-web/backend/internal/modules/additives/storeAdditivies"
+	bonusesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications/details"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments"
+	paymentsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/product/storeProducts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions"
+	promotionsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes"
+	taxesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
 	"go.uber.org/zap"
 )
 
 type OrderService interface {
 	GetOrders(filter types.OrdersFilterQuery) ([]types.OrderDTO, error)
-	GetAllBaristaOrders(filter types.OrdersTimeZoneFilter) ([]types.OrderDTO, error)
+	GetAllBaristaOrders(filter types.BaristaOrdersFilter) ([]types.OrderDTO, error)
 	GetSubOrders(orderID uint) ([]types.SuborderDTO, error)
 	CreateOrder(createOrderDTO *types.CreateOrderDTO) (*data.Order, error)
 	GetOrderBySubOrder(subOrderID uint) (*data.Order, error)
@@ -37,10 +51,31 @@ type OrderService interface {
 	GetOrderDetails(orderID uint, filter *contexts.StoreContextFilter) (*types.OrderDetailsDTO, error)
 	ExportOrders(filter *types.OrdersExportFilterQuery) ([]types.OrderExportDTO, error)
 
-	SetNextSubOrderStatus(subOrderID uint, options *types.ToggleNextSuborderStatusOptions) (*types.SuborderDTO, error)
+	SetNextSubOrderStatus(subOrderID, storeID, employeeID uint, options *types.ToggleNextSuborderStatusOptions) (*types.SuborderDTO, error)
 
-	SuccessOrderPayment(orderID uint, dto *types.TransactionDTO) error
+	CreatePaymentIntent(orderID, storeID uint) (*types.PaymentIntentDTO, error)
+	HandlePaymentWebhook(provider data.PaymentProviderName, payload []byte, headers *paymentsTypes.WebhookHeaders) (*data.Order, error)
 	FailOrderPayment(orderID uint) error
+	ReconcileTransactions() error
+	GetPaymentReconciliationReport(filter *types.PaymentReconciliationFilterQuery) ([]types.PaymentDiscrepancyDTO, error)
+	ReconcileOrderPayments(date time.Time, storeID *uint) error
+	RefundOrder(orderID, storeID, employeeID uint, dto *types.RefundOrderDTO) (*data.Order, error)
+
+	CancelOrder(orderID, storeID uint, requester *types.CancellationRequester, dto *types.CancelOrderDTO) (*types.OrderCancellationDTO, error)
+	GetOrderCancellations(filter *types.OrderCancellationsFilterQuery) ([]types.OrderCancellationDTO, error)
+	ReviewOrderCancellation(cancellationID, storeID, reviewerID uint, dto *types.ReviewOrderCancellationDTO) (*types.OrderCancellationDTO, error)
+
+	AssignCourier(orderID, storeID uint, dto *types.AssignCourierDTO) (*data.Order, error)
+	CompleteDelivery(orderID, storeID uint) (*data.Order, error)
+
+	GetCustomerActiveOrders(customerID uint) ([]types.OrderDTO, error)
+	GetCustomerOrderDetails(orderID, customerID uint) (*types.OrderDetailsDTO, error)
+	Reorder(orderID, customerID uint) (*data.Order, error)
+	GetPickupSlots(storeID uint) ([]types.PickupSlotDTO, error)
+	CreateCustomerOrder(customerID uint, dto *types.CreateCustomerOrderDTO) (*data.Order, error)
+	ReleaseScheduledOrders() error
+	GetStatusBoard(storeID uint) (*types.StatusBoardDTO, error)
+	CreateCustomerPaymentIntent(orderID, customerID uint) (*types.PaymentIntentDTO, error)
 }
 
 type orderService struct {
@@ -53,6 +88,11 @@ type orderService struct {
 	storeProductService       storeProducts.StoreProductService
 	storeAdditiveService      storeAdditives.StoreAdditiveService
 	notificationService       notifications.NotificationService
+	promotionService          promotions.PromotionService
+	bonusService              bonuses.BonusService
+	receiptService            receipts.ReceiptService
+	taxService                taxes.TaxService
+	paymentProviders          *payments.PaymentProviders
 	transactionManager        TransactionManager
 	logger                    *zap.SugaredLogger
 }
@@ -67,6 +107,11 @@ func NewOrderService(
 	storeProductService storeProducts.StoreProductService,
 	storeAdditiveService storeAdditives.StoreAdditiveService,
 	notificationService notifications.NotificationService,
+	promotionService promotions.PromotionService,
+	bonusService bonuses.BonusService,
+	receiptService receipts.ReceiptService,
+	taxService taxes.TaxService,
+	paymentProviders *payments.PaymentProviders,
 	transactionManager TransactionManager,
 	logger *zap.SugaredLogger,
 ) OrderService {
@@ -80,6 +125,11 @@ func NewOrderService(
 		storeProductService:       storeProductService,
 		storeAdditiveService:      storeAdditiveService,
 		notificationService:       notificationService,
+		promotionService:          promotionService,
+		bonusService:              bonusService,
+		receiptService:            receiptService,
+		taxService:                taxService,
+		paymentProviders:          paymentProviders,
 		transactionManager:        transactionManager,
 		logger:                    logger,
 	}
@@ -99,7 +149,10 @@ func (s *orderService) GetOrders(filter types.OrdersFilterQuery) ([]types.OrderD
 	return orderDTOs, nil
 }
 
-func (s *orderService) GetAllBaristaOrders(filter types.OrdersTimeZoneFilter) ([]types.OrderDTO, error) {
+func (s *orderService) GetAllBaristaOrders(filter types.BaristaOrdersFilter) ([]types.OrderDTO, error) {
+	releaseUntil := time.Now().Add(config.GetConfig().Pickup.LeadTime)
+	filter.ReleaseUntil = &releaseUntil
+
 	orders, err := s.orderRepo.GetAllBaristaOrders(filter)
 	if err != nil {
 		wrappedErr := fmt.Errorf("error getting barista orders: %w", err)
@@ -158,6 +211,17 @@ func (s *orderService) CreateOrder(createOrderDTO *types.CreateOrderDTO) (*data.
 		return nil, fmt.Errorf("order can not be empty")
 	}
 
+	if err := s.checkCustomerCanOrder(createOrderDTO.CustomerID); err != nil {
+		return nil, err
+	}
+
+	// the scheduled pickup orders are placed in advance, their slots are checked against the store hours
+	if createOrderDTO.PickupAt == nil {
+		if err := s.checkStoreOpen(createOrderDTO.StoreID); err != nil {
+			return nil, err
+		}
+	}
+
 	validationRes, err := validateSuborders(createOrderDTO, s.storeProductRepo, s.storeAdditiveRepo)
 	if err != nil {
 		wrappedErr := fmt.Errorf("suborders validation failed: %v", err)
@@ -183,16 +247,63 @@ func (s *orderService) CreateOrder(createOrderDTO *types.CreateOrderDTO) (*data.
 		validationRes.productPrices,
 		validationRes.additivePrices,
 	)
+
+	discountTotal, err := s.applyPromotions(&order, validationRes.subordersCtx.storeProductSizesList)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to apply promotions: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	exclusiveTaxTotal, err := s.applyTaxes(&order, validationRes.subordersCtx.storeProductSizesList)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to apply taxes: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
 	order.Status = data.OrderStatusWaitingForPayment
-	order.Total = total
+	order.Total = utils.RoundToDecimal(total-discountTotal+exclusiveTaxTotal, 2)
+	order.DiscountTotal = discountTotal
+
+	if err := s.applyDelivery(&order); err != nil {
+		return nil, err
+	}
+
+	if err := applyBonusRedemption(&order, createOrderDTO.BonusesToRedeem); err != nil {
+		return nil, err
+	}
 
-	id, err := s.orderRepo.CreateOrder(&order)
+	// the orders placed by customers themselves are not taken at the till
+	if createOrderDTO.EmployeeID != 0 {
+		order.StoreEmployeeID, err = s.orderRepo.GetStoreEmployeeID(createOrderDTO.StoreID, createOrderDTO.EmployeeID)
+		if err != nil {
+			s.logger.Error(err)
+			return nil, err
+		}
+
+		order.CashShiftID, err = s.orderRepo.GetOpenCashShiftID(createOrderDTO.StoreID, createOrderDTO.EmployeeID)
+		if err != nil {
+			s.logger.Error(err)
+			return nil, err
+		}
+	}
+
+	id, err := s.transactionManager.CreateOrder(&order)
 	if err != nil {
+		if errors.Is(err, types.ErrPickupSlotFull) {
+			return nil, err
+		}
 		wrappedErr := fmt.Errorf("failed to create order: %w", err)
 		s.logger.Error(wrappedErr)
 		return nil, wrappedErr
 	}
 
+	// the estimate is informational, the order is kept even if it fails
+	if err := s.estimateOrderReadyAt(&order); err != nil {
+		s.logger.Errorf("failed to estimate the ready time of the order %d: %v", id, err)
+	}
+
 	go func() {
 		inventoryLists, err := s.orderRepo.GetOrderInventory(id)
 		if err != nil {
@@ -228,6 +339,183 @@ func (s *orderService) CreateOrder(createOrderDTO *types.CreateOrderDTO) (*data.
 	return &order, nil
 }
 
+// applyPromotions reduces the suborder prices by the discounts of the running promotions and returns the order discount
+func (s *orderService) applyPromotions(order *data.Order, storeProductSizes []data.StoreProductSize) (float64, error) {
+	storeProductSizesMap := mapStoreProductSizes(storeProductSizes)
+
+	items := make([]promotionsTypes.DiscountableItem, 0, len(order.Suborders))
+	for i, suborder := range order.Suborders {
+		sps, ok := storeProductSizesMap[suborder.StoreProductSizeID]
+		if !ok {
+			return 0, fmt.Errorf("storeProductSize with ID %d is not validated", suborder.StoreProductSizeID)
+		}
+
+		items = append(items, promotionsTypes.DiscountableItem{
+			Index:             i,
+			ProductSizeID:     sps.ProductSizeID,
+			ProductCategoryID: sps.ProductSize.Product.CategoryID,
+			Price:             suborder.Price,
+		})
+	}
+
+	discounts, err := s.promotionService.CalculateDiscounts(order.StoreID, items)
+	if err != nil {
+		return 0, err
+	}
+
+	var discountTotal float64
+	for _, discount := range discounts {
+		suborder := &order.Suborders[discount.Index]
+		suborder.Price = utils.RoundToDecimal(suborder.Price-discount.Amount, 2)
+		suborder.DiscountAmount += discount.Amount
+		suborder.Discounts = append(suborder.Discounts, types.ConvertAppliedDiscountToModel(&discount))
+		discountTotal += discount.Amount
+	}
+
+	return utils.RoundToDecimal(discountTotal, 2), nil
+}
+
+// applyTaxes stores the tax of every suborder and the order tax total, and returns the exclusive tax to add to the order total.
+// It must run after applyPromotions, since the tax is calculated on the discounted price.
+func (s *orderService) applyTaxes(order *data.Order, storeProductSizes []data.StoreProductSize) (float64, error) {
+	storeProductSizesMap := mapStoreProductSizes(storeProductSizes)
+
+	items := make([]taxesTypes.TaxableItem, 0, len(order.Suborders))
+	for i, suborder := range order.Suborders {
+		sps, ok := storeProductSizesMap[suborder.StoreProductSizeID]
+		if !ok {
+			return 0, fmt.Errorf("storeProductSize with ID %d is not validated", suborder.StoreProductSizeID)
+		}
+
+		items = append(items, taxesTypes.TaxableItem{
+			Index:             i,
+			ProductCategoryID: sps.ProductSize.Product.CategoryID,
+			Amount:            suborder.Price,
+		})
+	}
+
+	taxes, err := s.taxService.CalculateTaxes(order.StoreID, items)
+	if err != nil {
+		return 0, err
+	}
+
+	var taxTotal, exclusiveTaxTotal float64
+	for _, tax := range taxes {
+		suborder := &order.Suborders[tax.Index]
+		suborder.TaxRate = tax.Rate
+		suborder.TaxMode = tax.Mode
+		suborder.TaxAmount = tax.Amount
+		taxTotal += tax.Amount
+		if tax.Mode == data.TaxModeExclusive {
+			exclusiveTaxTotal += tax.Amount
+		}
+	}
+
+	order.TaxTotal = utils.RoundToDecimal(taxTotal, 2)
+	return utils.RoundToDecimal(exclusiveTaxTotal, 2), nil
+}
+
+func mapStoreProductSizes(storeProductSizes []data.StoreProductSize) map[uint]*data.StoreProductSize {
+	storeProductSizesMap := make(map[uint]*data.StoreProductSize, len(storeProductSizes))
+	for i := range storeProductSizes {
+		storeProductSizesMap[storeProductSizes[i].ID] = &storeProductSizes[i]
+	}
+	return storeProductSizesMap
+}
+
+func (s *orderService) checkStoreOpen(storeID uint) error {
+	store, err := s.getStoreWithSchedule(storeID)
+	if err != nil {
+		return err
+	}
+
+	if !storesTypes.NewStoreSchedule(store).IsOpen(time.Now()) {
+		return types.ErrStoreClosed
+	}
+	return nil
+}
+
+// checkCustomerCanOrder allows the orders of the customers who confirmed their phone, the anonymous orders are not restricted
+func (s *orderService) checkCustomerCanOrder(customerID *uint) error {
+	if customerID == nil {
+		return nil
+	}
+
+	customer, err := s.orderRepo.GetCustomer(*customerID)
+	if err != nil {
+		if !errors.Is(err, types.ErrCustomerNotVerified) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+
+	if !customer.IsVerified || customer.IsBanned {
+		return types.ErrCustomerNotVerified
+	}
+	return nil
+}
+
+// applyDelivery checks that the delivery address is within the store delivery zone and adds the delivery fee to the order total
+func (s *orderService) applyDelivery(order *data.Order) error {
+	if order.DeliveryAddressID == nil {
+		return nil
+	}
+
+	if order.CustomerID == nil {
+		return types.ErrInvalidDeliveryAddress
+	}
+
+	address, err := s.orderRepo.GetCustomerAddress(*order.CustomerID, *order.DeliveryAddressID)
+	if err != nil {
+		return err
+	}
+
+	lat, lon, err := utils.ParseCoordinates(address.Latitude, address.Longitude)
+	if err != nil {
+		s.logger.Warnf("delivery address %d: %v", address.ID, err)
+		return types.ErrInvalidDeliveryAddress
+	}
+
+	facilityAddress, err := s.orderRepo.GetStoreFacilityAddress(order.StoreID)
+	if err != nil {
+		return err
+	}
+	if facilityAddress.Latitude == nil || facilityAddress.Longitude == nil {
+		return types.ErrStoreLocationUnknown
+	}
+
+	cfg := &config.GetConfig().Delivery
+	distance := utils.RoundToDecimal(utils.DistanceKm(*facilityAddress.Latitude, *facilityAddress.Longitude, lat, lon), 2)
+	if cfg.MaxDistanceKm > 0 && distance > cfg.MaxDistanceKm {
+		return types.ErrDeliveryDistanceExceeded
+	}
+
+	order.DeliveryDistance = &distance
+	order.DeliveryFee = types.CalculateDeliveryFee(distance, order.Total, cfg)
+	order.Total = utils.RoundToDecimal(order.Total+order.DeliveryFee, 2)
+	return nil
+}
+
+// applyBonusRedemption pays the part of the order total by customer bonuses, the balance itself is spent on order creation
+func applyBonusRedemption(order *data.Order, bonusesToRedeem float64) error {
+	if bonusesToRedeem <= 0 {
+		return nil
+	}
+
+	if order.CustomerID == nil {
+		return types.ErrBonusesWithoutCustomer
+	}
+
+	redeemed := utils.RoundToDecimal(bonusesToRedeem, 2)
+	if redeemed > bonusesTypes.CalculateMaxRedeemable(order.Total, &config.GetConfig().Loyalty) {
+		return types.ErrBonusesRedeemLimit
+	}
+
+	order.BonusesRedeemed = redeemed
+	order.Total = utils.RoundToDecimal(order.Total-redeemed, 2)
+	return nil
+}
+
 func validateSuborders(
 	order *types.CreateOrderDTO,
 	storeProductRepo storeProducts.StoreProductRepository,
@@ -649,7 +937,8 @@ var allowedTransitions = map[data.SubOrderStatus]data.SubOrderStatus{
 	data.SubOrderStatusPreparing: data.SubOrderStatusCompleted,
 }
 
-func (s *orderService) SetNextSubOrderStatus(subOrderID uint, options *types.ToggleNextSuborderStatusOptions) (*types.SuborderDTO, error) {
+// SetNextSubOrderStatus moves the suborder to the next status on behalf of the employee, the change is attributed to them
+func (s *orderService) SetNextSubOrderStatus(subOrderID, storeID, employeeID uint, options *types.ToggleNextSuborderStatusOptions) (*types.SuborderDTO, error) {
 	suborder, err := s.orderRepo.GetSuborderByID(subOrderID)
 	if err != nil || suborder == nil {
 		return nil, fmt.Errorf("failed to retrieve suborder %d: %w", subOrderID, err)
@@ -660,12 +949,21 @@ func (s *orderService) SetNextSubOrderStatus(subOrderID uint, options *types.Tog
 		return dto, nil
 	}
 
-	if err := s.transactionManager.SetNextSubOrderStatus(suborder); err != nil {
+	storeEmployeeID, err := s.orderRepo.GetStoreEmployeeID(storeID, employeeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	if err := s.transactionManager.SetNextSubOrderStatus(suborder, employeeID, storeEmployeeID); err != nil {
 		wrappedErr := fmt.Errorf("failed to set next suborder status suborder %d: %w", subOrderID, err)
 		s.logger.Error(wrappedErr.Error())
 		return nil, wrappedErr
 	}
 
+	s.refreshStoreQueue(storeID)
+	s.notifyOrderReady(subOrderID)
+
 	// Return updated suborder
 	updatedSuborder, err := s.orderRepo.GetSuborderByID(subOrderID)
 	if err != nil {
@@ -690,12 +988,112 @@ func (s *orderService) returnIfRecentlyCompleted(suborder *data.Suborder, option
 	return nil
 }
 
-func (s *orderService) SuccessOrderPayment(orderID uint, dto *types.TransactionDTO) error {
-	paymentTransaction := types.ToTransactionModel(dto, orderID, data.TransactionTypePayment)
-	order, err := s.orderRepo.HandlePaymentSuccess(orderID, paymentTransaction)
+func (s *orderService) CreatePaymentIntent(orderID, storeID uint) (*types.PaymentIntentDTO, error) {
+	order, err := s.getPayableStoreOrder(orderID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentIntent, details, err := s.createPaymentIntent(order, s.paymentProviders.Default())
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ConvertPaymentIntentToDTO(paymentIntent, details), nil
+}
+
+func (s *orderService) getPayableStoreOrder(orderID, storeID uint) (*data.Order, error) {
+	order, err := s.orderRepo.GetRawOrderById(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.StoreID != storeID {
+		return nil, types.ErrOrderNotFound
+	}
+
+	if order.Status != data.OrderStatusWaitingForPayment {
+		return nil, types.ErrInappropriateOrderStatus
+	}
+	return order, nil
+}
+
+func (s *orderService) createPaymentIntent(order *data.Order, provider payments.PaymentProvider) (*data.PaymentIntent, map[string]string, error) {
+	currency := config.GetConfig().Payment.Currency
+
+	intent, err := provider.CreateIntent(&paymentsTypes.IntentRequest{
+		OrderID:  order.ID,
+		Amount:   order.Total,
+		Currency: currency,
+	})
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to create %s payment intent for the order %d: %w", provider.Name(), order.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, nil, wrappedErr
+	}
+
+	paymentIntent := &data.PaymentIntent{
+		OrderID:    order.ID,
+		Provider:   provider.Name(),
+		ExternalID: intent.ExternalID,
+		Amount:     order.Total,
+		Currency:   currency,
+		Status:     data.PaymentIntentStatusPending,
+	}
+	if err := s.orderRepo.CreatePaymentIntent(paymentIntent); err != nil {
+		s.logger.Error(err)
+		return nil, nil, err
+	}
+
+	return paymentIntent, intent.Details, nil
+}
+
+// HandlePaymentWebhook applies the provider callback to the intent order, the order is nil when the payment failed
+func (s *orderService) HandlePaymentWebhook(providerName data.PaymentProviderName, payload []byte, headers *paymentsTypes.WebhookHeaders) (*data.Order, error) {
+	provider, err := s.paymentProviders.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := provider.VerifyWebhook(payload, headers)
+	if err != nil {
+		s.logger.Warnf("rejected %s payment webhook: %v", providerName, err)
+		return nil, err
+	}
+
+	intent, err := s.orderRepo.GetPaymentIntent(providerName, event.IntentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if intent.Status != data.PaymentIntentStatusPending {
+		return nil, types.ErrPaymentIntentProcessed
+	}
+
+	if event.Type == paymentsTypes.WebhookEventPaymentFailed {
+		if err := s.orderRepo.UpdatePaymentIntentStatus(intent.ID, data.PaymentIntentStatusFailed); err != nil {
+			return nil, err
+		}
+		return nil, s.FailOrderPayment(intent.OrderID)
+	}
+
+	transaction, err := provider.Capture(intent, event.Transaction)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to capture %s payment %s: %w", providerName, intent.ExternalID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return s.completeOrderPayment(intent, transaction)
+}
+
+func (s *orderService) completeOrderPayment(intent *data.PaymentIntent, dto *types.TransactionDTO) (*data.Order, error) {
+	orderID := intent.OrderID
+	paymentTransaction := types.ToTransactionModel(dto, orderID, data.TransactionTypePayment, intent.Provider)
+	order, err := s.orderRepo.HandlePaymentSuccess(intent, paymentTransaction)
 	if err != nil {
 		s.logger.Errorf("failed to handle the order %d success: %v", orderID, err)
-		return err
+		return nil, err
 	}
 
 	notificationDetails := &details.NewOrderNotificationDetails{
@@ -711,7 +1109,16 @@ func (s *orderService) SuccessOrderPayment(orderID uint, dto *types.TransactionD
 		s.logger.Errorf("failed to notify new order: %w", err)
 	}
 
-	return nil
+	if _, err := s.receiptService.CreateSaleReceipt(orderID, paymentTransaction); err != nil {
+		s.logger.Errorf("failed to issue the receipt of the order %d payment: %v", orderID, err)
+	}
+
+	// the paid order joins the queue, the scheduled ones join it when released
+	if order.PickupAt == nil {
+		s.refreshStoreQueue(order.StoreID)
+	}
+
+	return order, nil
 }
 
 func (s *orderService) FailOrderPayment(orderID uint) error {
@@ -733,6 +1140,12 @@ func (s *orderService) FailOrderPayment(orderID uint) error {
 		return types.ErrInappropriateOrderStatus
 	}
 
+	if err := s.bonusService.ReturnRedeemedBonuses(orderID); err != nil {
+		wrappedErr := fmt.Errorf("failed to delete the order %d after payment refuse: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return wrappedErr
+	}
+
 	err = s.orderRepo.HardDeleteOrderByID(orderID)
 	if err != nil {
 		wrappedErr := fmt.Errorf("failed to delete the order %d after payment refuse: %w", orderID, err)
@@ -753,62 +1166,552 @@ func (s *orderService) FailOrderPayment(orderID uint) error {
 	return nil
 }
 
-// in your orderService (or wherever it belongs)
+func (s *orderService) RefundOrder(orderID, storeID, employeeID uint, dto *types.RefundOrderDTO) (*data.Order, error) {
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to refund the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
 
-func (s *orderService) checkAndAccumulateInventory(
-	storeID uint,
-	ctx *subordersContext,
-	frozen *storeInventoryManagersTypes.FrozenInventory,
-) error {
-	// 1) Build the two requirement maps
-	requiredIngredientQty := make(map[uint]float64)
-	requiredProvisionVol := make(map[uint]float64)
+	if order.StoreID != storeID {
+		return nil, types.ErrOrderNotFound
+	}
 
-	// From each StoreProductSize → its ProductSizeIngredients & ProductSizeProvisions
-	for _, sps := range ctx.storeProductSizesList {
-		// how many of this productSize was ordered?
-		count := float64(ctx.subordersQuantities.storeProductSizesQty[sps.ID])
-		if count == 0 {
-			continue
-		}
+	// the money leaves the till of the shift open now, the shift of the sale may be closed already
+	refundShiftID, err := s.orderRepo.GetOpenCashShiftID(order.StoreID, employeeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
 
-		// productSize direct ingredients and provisions
-		for _, psi := range sps.ProductSize.ProductSizeIngredients {
-			requiredIngredientQty[psi.IngredientID] += psi.Quantity * count
-		}
-		for _, psp := range sps.ProductSize.ProductSizeProvisions {
-			requiredProvisionVol[psp.ProvisionID] += psp.Volume * count
-		}
+	storeEmployeeID, err := s.orderRepo.GetStoreEmployeeID(order.StoreID, employeeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
 
-		// productSize default additives ingredients and provisions
-		for _, psa := range sps.ProductSize.Additives {
-			if !psa.IsDefault {
-				continue
-			}
-			for _, ai := range psa.Additive.Ingredients {
-				requiredIngredientQty[ai.IngredientID] += ai.Quantity * count
-			}
-			for _, ap := range psa.Additive.AdditiveProvisions {
-				requiredProvisionVol[ap.ProvisionID] += ap.Volume * count
-			}
-		}
+	// the refund is recorded before the provider is called, so the money returned by the provider is always tracked
+	refund, err := s.transactionManager.StartRefund(orderID, dto.SuborderIDs, dto.Transaction.Amount)
+	if err != nil {
+		return nil, err
 	}
 
-	// From each StoreAdditive → its Additive.Ingredients & Additive.AdditiveProvisions
-	for _, sa := range ctx.storeAdditivesList {
-		count := float64(ctx.subordersQuantities.storeAdditivesQty[sa.ID])
-		if count == 0 {
-			continue
-		}
-		for _, ai := range sa.Additive.Ingredients {
-			requiredIngredientQty[ai.IngredientID] += ai.Quantity * count
-		}
-		for _, ap := range sa.Additive.AdditiveProvisions {
-			requiredProvisionVol[ap.ProvisionID] += ap.Volume * count
+	refundTransaction, err := s.refundPayment(orderID, dto)
+	if err != nil {
+		if finishErr := s.orderRepo.FinishOrderRefund(refund.ID, data.OrderRefundStatusFailed, nil); finishErr != nil {
+			s.logger.Error(finishErr)
 		}
+		return nil, err
+	}
+	if err := s.orderRepo.SetOrderRefundProviderTransaction(refund.ID, refundTransaction.TransactionID); err != nil {
+		s.logger.Error(err)
 	}
+	refundTransaction.CashShiftID = refundShiftID
 
-	if err := s.storeInventoryManagerRepo.CheckStoreStocks(
+	if err := s.transactionManager.RefundSuborders(refund, refundTransaction, dto.RestoreInventory, employeeID, storeEmployeeID); err != nil {
+		wrappedErr := fmt.Errorf("failed to refund the order %d, the refund %d paid out by the provider is left pending: %w", orderID, refund.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	go s.recalculateOrderInventory(order.StoreID, orderID)
+	s.refreshStoreQueue(order.StoreID)
+
+	if _, err := s.receiptService.CreateRefundReceipt(orderID, refundTransaction, refund.SuborderIDs); err != nil {
+		s.logger.Errorf("failed to issue the refund receipt of the order %d: %v", orderID, err)
+	}
+
+	updatedOrder, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to fetch refunded order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return updatedOrder, nil
+}
+
+func (s *orderService) AssignCourier(orderID, storeID uint, dto *types.AssignCourierDTO) (*data.Order, error) {
+	order, err := s.getStoreDeliveryOrder(orderID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case data.OrderStatusWaitingForPayment, data.OrderStatusDelivered, data.OrderStatusCancelled, data.OrderStatusRefunded:
+		return nil, types.ErrInappropriateOrderStatus
+	}
+
+	if _, err := s.orderRepo.GetStoreEmployee(storeID, dto.CourierID); err != nil {
+		return nil, err
+	}
+
+	if err := s.orderRepo.AssignCourier(orderID, dto.CourierID); err != nil {
+		wrappedErr := fmt.Errorf("failed to assign courier to the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return s.orderRepo.GetOrderById(orderID)
+}
+
+func (s *orderService) CompleteDelivery(orderID, storeID uint) (*data.Order, error) {
+	order, err := s.getStoreDeliveryOrder(orderID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != data.OrderStatusInDelivery {
+		return nil, types.ErrInappropriateOrderStatus
+	}
+
+	if order.CourierID == nil {
+		return nil, types.ErrCourierNotAssigned
+	}
+
+	if err := s.transactionManager.CompleteDelivery(order); err != nil {
+		wrappedErr := fmt.Errorf("failed to complete delivery of the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return s.orderRepo.GetOrderById(orderID)
+}
+
+func (s *orderService) getStoreDeliveryOrder(orderID, storeID uint) (*data.Order, error) {
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.StoreID != storeID {
+		return nil, types.ErrOrderNotFound
+	}
+
+	if order.DeliveryAddressID == nil {
+		return nil, types.ErrNotDeliveryOrder
+	}
+
+	return order, nil
+}
+
+// refundPayment refunds the amount through the provider the order was paid with
+func (s *orderService) refundPayment(orderID uint, dto *types.RefundOrderDTO) (*data.Transaction, error) {
+	payment, err := s.orderRepo.GetPaymentTransaction(orderID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+	if payment == nil {
+		return nil, types.ErrInappropriateOrderStatus
+	}
+
+	provider, err := s.paymentProviders.Get(payment.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	refund, err := provider.Refund(payment, &paymentsTypes.RefundRequest{
+		Amount:      dto.Transaction.Amount,
+		Transaction: &dto.Transaction,
+	})
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to refund %s payment of the order %d: %w", provider.Name(), orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return types.ToTransactionModel(refund, orderID, data.TransactionTypeRefund, provider.Name()), nil
+}
+
+// ReconcileTransactions compares the stored transactions with the provider records, the providers without a remote API are skipped.
+// The POS terminals are one of them: their payments are confirmed by the signed webhook and settled by the bank reports
+func (s *orderService) ReconcileTransactions() error {
+	const batchSize = 500
+	// the recent transactions may be not settled by the provider yet
+	createdBefore := time.Now().Add(-time.Hour)
+
+	for name, reconciler := range s.paymentProviders.Reconcilers() {
+		transactions, err := s.orderRepo.GetUnreconciledTransactions(name, createdBefore, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range transactions {
+			transaction := &transactions[i]
+
+			status, err := reconciler.Reconcile(transaction)
+			if err != nil {
+				s.logger.Errorf("failed to reconcile %s transaction %s: %v", name, transaction.TransactionID, err)
+				continue
+			}
+
+			if status != data.TransactionReconciliationStatusMatched {
+				s.logger.Warnf("%s transaction %s of order %d is %s", name, transaction.TransactionID, transaction.OrderID, status)
+			}
+
+			if err := s.orderRepo.UpdateTransactionReconciliation(transaction.ID, status, time.Now()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *orderService) GetPaymentReconciliationReport(filter *types.PaymentReconciliationFilterQuery) ([]types.PaymentDiscrepancyDTO, error) {
+	discrepancies, err := s.findPaymentDiscrepancies(filter.Date, filter.StoreID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to build the payment reconciliation report: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+	return discrepancies, nil
+}
+
+// ReconcileOrderPayments checks the orders of the local date of the stores against their transactions and alerts the managers of the stores with discrepancies
+func (s *orderService) ReconcileOrderPayments(date time.Time, storeID *uint) error {
+	discrepancies, err := s.findPaymentDiscrepancies(date, storeID)
+	if err != nil {
+		return err
+	}
+
+	type storeDiscrepancies struct {
+		name       string
+		count      int
+		stale      int
+		difference float64
+	}
+
+	storeIDs := make([]uint, 0)
+	stores := make(map[uint]*storeDiscrepancies)
+	for _, discrepancy := range discrepancies {
+		store, ok := stores[discrepancy.StoreID]
+		if !ok {
+			store = &storeDiscrepancies{name: discrepancy.StoreName}
+			stores[discrepancy.StoreID] = store
+			storeIDs = append(storeIDs, discrepancy.StoreID)
+		}
+
+		store.count++
+		store.difference += math.Abs(discrepancy.Difference)
+		if discrepancy.Type == types.PaymentDiscrepancyStalePayment {
+			store.stale++
+		}
+	}
+
+	dateStr := date.Format(time.DateOnly)
+	for _, storeID := range storeIDs {
+		store := stores[storeID]
+		s.logger.Warnf("payment reconciliation for %s found %d discrepancies in the store %d", dateStr, store.count, storeID)
+
+		notificationDetails, err := details.BuildPaymentReconciliationMismatchDetails(
+			storeID, store.name, dateStr, store.count, store.stale, utils.RoundToDecimal(store.difference, 2),
+		)
+		if err != nil {
+			s.logger.Errorf("failed to build payment reconciliation notification details: %v", err)
+			continue
+		}
+
+		if err := s.notificationService.NotifyPaymentReconciliationMismatch(notificationDetails); err != nil {
+			s.logger.Errorf("failed to notify payment reconciliation mismatch: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *orderService) findPaymentDiscrepancies(date time.Time, storeID *uint) ([]types.PaymentDiscrepancyDTO, error) {
+	summaries, err := s.orderRepo.GetOrderPaymentSummaries(date, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the failure task deletes the unpaid orders after the waiting time, the remaining ones were missed by the queue
+	staleBefore := time.Now().Add(-2 * config.GetConfig().Payment.WaitingTime)
+	return types.DetectPaymentDiscrepancies(summaries, staleBefore), nil
+}
+
+func (s *orderService) CancelOrder(orderID, storeID uint, requester *types.CancellationRequester, dto *types.CancelOrderDTO) (*types.OrderCancellationDTO, error) {
+	if !data.IsValidOrderCancellationReason(dto.Reason) {
+		return nil, types.ErrInvalidCancellationReason
+	}
+
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to cancel the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if order.StoreID != storeID {
+		return nil, types.ErrOrderNotFound
+	}
+
+	if order.Status != data.OrderStatusPending && order.Status != data.OrderStatusPreparing {
+		return nil, types.ErrInappropriateOrderStatus
+	}
+
+	suborders, err := selectCancellableSuborders(order.Suborders, dto.SuborderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	suborderIDs := make([]uint, len(suborders))
+	needsApproval := false
+	for i, suborder := range suborders {
+		suborderIDs[i] = suborder.ID
+		if suborder.Status == data.SubOrderStatusPreparing && !requester.CanApprove {
+			needsApproval = true
+		}
+	}
+
+	pending, err := s.orderRepo.HasPendingCancellation(suborderIDs)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to cancel the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+	if pending {
+		return nil, types.ErrCancellationPending
+	}
+
+	cancellation := &data.OrderCancellation{
+		OrderID:       orderID,
+		Reason:        dto.Reason,
+		Comment:       dto.Comment,
+		Status:        data.OrderCancellationStatusPendingApproval,
+		RequestedByID: requester.EmployeeID,
+	}
+	if !needsApproval {
+		now := time.Now()
+		cancellation.Status = data.OrderCancellationStatusApproved
+		cancellation.ReviewedByID = &requester.EmployeeID
+		cancellation.ReviewedAt = &now
+	}
+
+	storeEmployeeID, err := s.orderRepo.GetStoreEmployeeID(order.StoreID, requester.EmployeeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	cancelledIDs, err := s.transactionManager.RequestCancellation(cancellation, suborderIDs, storeEmployeeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to cancel the order %d: %w", orderID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if len(cancelledIDs) > 0 {
+		go s.recalculateOrderInventory(order.StoreID, orderID)
+		s.refreshStoreQueue(order.StoreID)
+	}
+
+	return s.getOrderCancellationDTO(cancellation.ID)
+}
+
+func (s *orderService) GetOrderCancellations(filter *types.OrderCancellationsFilterQuery) ([]types.OrderCancellationDTO, error) {
+	cancellations, err := s.orderRepo.GetOrderCancellations(filter)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to fetch order cancellations: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	dtos := make([]types.OrderCancellationDTO, len(cancellations))
+	for i := range cancellations {
+		dtos[i] = types.ConvertOrderCancellationToDTO(&cancellations[i])
+	}
+
+	return dtos, nil
+}
+
+func (s *orderService) ReviewOrderCancellation(cancellationID, storeID, reviewerID uint, dto *types.ReviewOrderCancellationDTO) (*types.OrderCancellationDTO, error) {
+	cancellation, err := s.orderRepo.GetOrderCancellationByID(cancellationID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to review the order cancellation %d: %w", cancellationID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if cancellation.Order.StoreID != storeID {
+		return nil, types.ErrCancellationNotFound
+	}
+
+	if cancellation.Status != data.OrderCancellationStatusPendingApproval {
+		return nil, types.ErrCancellationReviewed
+	}
+
+	now := time.Now()
+	cancellation.Status = dto.Status
+	cancellation.ReviewedByID = &reviewerID
+	cancellation.ReviewedAt = &now
+
+	storeEmployeeID, err := s.orderRepo.GetStoreEmployeeID(storeID, reviewerID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	cancelledIDs, err := s.transactionManager.ReviewCancellation(cancellation, storeEmployeeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to review the order cancellation %d: %w", cancellationID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if len(cancelledIDs) > 0 {
+		go s.recalculateOrderInventory(cancellation.Order.StoreID, cancellation.OrderID)
+		s.refreshStoreQueue(cancellation.Order.StoreID)
+	}
+
+	return s.getOrderCancellationDTO(cancellationID)
+}
+
+func (s *orderService) getOrderCancellationDTO(cancellationID uint) (*types.OrderCancellationDTO, error) {
+	cancellation, err := s.orderRepo.GetOrderCancellationByID(cancellationID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to fetch order cancellation %d: %w", cancellationID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	dto := types.ConvertOrderCancellationToDTO(cancellation)
+	return &dto, nil
+}
+
+// recalculateOrderInventory refreshes out of stock flags of the order inventory after suborders leave the queue
+func (s *orderService) recalculateOrderInventory(storeID, orderID uint) {
+	inventoryLists, err := s.orderRepo.GetOrderInventory(orderID)
+	if err != nil {
+		s.logger.Errorf("could not get inventory lists for order id %d: %v", orderID, err)
+		return
+	}
+
+	err = s.storeInventoryManagerRepo.RecalculateStoreInventory(storeID, &storeInventoryManagersTypes.RecalculateInput{
+		IngredientIDs: inventoryLists.IngredientIDs,
+		ProvisionIDs:  inventoryLists.ProvisionIDs,
+	})
+	if err != nil {
+		s.logger.Errorf("could not recalculate stock for order id %d: %v", orderID, err)
+	}
+}
+
+// selectCancellableSuborders returns the requested suborders, or every active suborder if none are requested
+func selectCancellableSuborders(suborders []data.Suborder, suborderIDs []uint) ([]data.Suborder, error) {
+	return selectSuborders(suborders, suborderIDs, isCancellableSuborder, types.ErrSuborderNotCancellable)
+}
+
+func isCancellableSuborder(suborder *data.Suborder) bool {
+	return suborder.Status == data.SubOrderStatusPending || suborder.Status == data.SubOrderStatusPreparing
+}
+
+// selectRefundableSuborders returns the requested suborders, or every suborder which is neither refunded nor cancelled if none are requested
+func selectRefundableSuborders(suborders []data.Suborder, suborderIDs []uint) ([]data.Suborder, error) {
+	return selectSuborders(suborders, suborderIDs, isRefundableSuborder, types.ErrSuborderNotRefundable)
+}
+
+func isRefundableSuborder(suborder *data.Suborder) bool {
+	return !suborder.Status.IsClosed()
+}
+
+// selectSuborders returns the requested suborders once each, or every selectable suborder if none are requested.
+// A requested suborder which does not belong to the order or is not selectable fails the whole selection with notSelectableErr
+func selectSuborders(suborders []data.Suborder, suborderIDs []uint, isSelectable func(*data.Suborder) bool, notSelectableErr error) ([]data.Suborder, error) {
+	var selected []data.Suborder
+
+	if len(suborderIDs) == 0 {
+		for _, suborder := range suborders {
+			if isSelectable(&suborder) {
+				selected = append(selected, suborder)
+			}
+		}
+	} else {
+		suborderMap := make(map[uint]data.Suborder, len(suborders))
+		for _, suborder := range suborders {
+			suborderMap[suborder.ID] = suborder
+		}
+
+		seen := make(map[uint]struct{}, len(suborderIDs))
+		for _, id := range suborderIDs {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+
+			suborder, ok := suborderMap[id]
+			if !ok || !isSelectable(&suborder) {
+				return nil, fmt.Errorf("%w: suborder %d", notSelectableErr, id)
+			}
+			selected = append(selected, suborder)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, notSelectableErr
+	}
+
+	return selected, nil
+}
+
+// in your orderService (or wherever it belongs)
+
+func (s *orderService) checkAndAccumulateInventory(
+	storeID uint,
+	ctx *subordersContext,
+	frozen *storeInventoryManagersTypes.FrozenInventory,
+) error {
+	// 1) Build the two requirement maps
+	requiredIngredientQty := make(map[uint]float64)
+	requiredProvisionVol := make(map[uint]float64)
+
+	// From each StoreProductSize → its ProductSizeIngredients & ProductSizeProvisions
+	for _, sps := range ctx.storeProductSizesList {
+		// how many of this productSize was ordered?
+		count := float64(ctx.subordersQuantities.storeProductSizesQty[sps.ID])
+		if count == 0 {
+			continue
+		}
+
+		// productSize direct ingredients and provisions
+		for _, psi := range sps.ProductSize.ProductSizeIngredients {
+			requiredIngredientQty[psi.IngredientID] += psi.Quantity * count
+		}
+		for _, psp := range sps.ProductSize.ProductSizeProvisions {
+			requiredProvisionVol[psp.ProvisionID] += psp.Volume * count
+		}
+
+		// productSize default additives ingredients and provisions
+		for _, psa := range sps.ProductSize.Additives {
+			if !psa.IsDefault {
+				continue
+			}
+			for _, ai := range psa.Additive.Ingredients {
+				requiredIngredientQty[ai.IngredientID] += ai.Quantity * count
+			}
+			for _, ap := range psa.Additive.AdditiveProvisions {
+				requiredProvisionVol[ap.ProvisionID] += ap.Volume * count
+			}
+		}
+	}
+
+	// From each StoreAdditive → its Additive.Ingredients & Additive.AdditiveProvisions
+	for _, sa := range ctx.storeAdditivesList {
+		count := float64(ctx.subordersQuantities.storeAdditivesQty[sa.ID])
+		if count == 0 {
+			continue
+		}
+		for _, ai := range sa.Additive.Ingredients {
+			requiredIngredientQty[ai.IngredientID] += ai.Quantity * count
+		}
+		for _, ap := range sa.Additive.AdditiveProvisions {
+			requiredProvisionVol[ap.ProvisionID] += ap.Volume * count
+		}
+	}
+
+	if err := s.storeInventoryManagerRepo.CheckStoreStocks(
 		storeID, requiredIngredientQty, frozen,
 	); err != nil {
 		return err
@@ -821,3 +1724,327 @@ func (s *orderService) checkAndAccumulateInventory(
 
 	return nil
 }
+
+func (s *orderService) GetCustomerActiveOrders(customerID uint) ([]types.OrderDTO, error) {
+	orders, err := s.orderRepo.GetCustomerActiveOrders(customerID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	orderDTOs := make([]types.OrderDTO, len(orders))
+	for i, order := range orders {
+		orderDTOs[i] = types.ConvertOrderToDTO(&order)
+	}
+	return orderDTOs, nil
+}
+
+func (s *orderService) GetCustomerOrderDetails(orderID, customerID uint) (*types.OrderDetailsDTO, error) {
+	if _, err := s.getCustomerOrder(orderID, customerID); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderDetails(orderID, nil)
+}
+
+// Reorder places a new order with the same products and additives in the same store, the prices are the current ones
+func (s *orderService) Reorder(orderID, customerID uint) (*data.Order, error) {
+	if _, err := s.getCustomerOrder(orderID, customerID); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	if _, err := s.getPickupStore(order.StoreID); err != nil {
+		return nil, err
+	}
+
+	return s.CreateOrder(types.ConvertOrderToReorderDTO(order))
+}
+
+func (s *orderService) GetPickupSlots(storeID uint) ([]types.PickupSlotDTO, error) {
+	store, err := s.getPickupStore(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := types.BuildPickupSlots(storesTypes.NewStoreSchedule(store), time.Now(), &config.GetConfig().Pickup)
+	if len(slots) == 0 {
+		return []types.PickupSlotDTO{}, nil
+	}
+
+	ordersCount, err := s.countPickupOrders(store.ID, slots[0].StartAt, slots[len(slots)-1].StartAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ConvertToPickupSlotDTOs(slots, ordersCount, store.PickupSlotCapacity), nil
+}
+
+// CreateCustomerOrder places a pickup order from the customer app, a scheduled order takes a place in its slot
+func (s *orderService) CreateCustomerOrder(customerID uint, dto *types.CreateCustomerOrderDTO) (*data.Order, error) {
+	customerName := strings.TrimSpace(dto.CustomerName)
+	if customerName == "" {
+		customer, err := s.orderRepo.GetCustomer(customerID)
+		if err != nil {
+			return nil, err
+		}
+		customerName = customer.FirstName
+	}
+
+	store, err := s.getPickupStore(dto.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pickupAt *time.Time
+	if dto.PickupAt != nil {
+		if pickupAt, err = s.findPickupSlot(store, *dto.PickupAt); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.CreateOrder(&types.CreateOrderDTO{
+		CustomerID:      &customerID,
+		CustomerName:    customerName,
+		BonusesToRedeem: dto.BonusesToRedeem,
+		Suborders:       dto.Suborders,
+		StoreID:         dto.StoreID,
+		PickupAt:        pickupAt,
+	})
+}
+
+// ReleaseScheduledOrders pushes the scheduled orders to the barista queue when their slot is within the lead time
+func (s *orderService) ReleaseScheduledOrders() error {
+	now := time.Now()
+
+	orders, err := s.orderRepo.GetScheduledOrdersToRelease(now.Add(config.GetConfig().Pickup.LeadTime))
+	if err != nil {
+		return err
+	}
+
+	orderIDs := make([]uint, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order.ID
+	}
+
+	if err := s.orderRepo.SetOrdersReleased(orderIDs, now); err != nil {
+		return err
+	}
+
+	storeIDs := make(map[uint]struct{})
+	for _, order := range orders {
+		BroadcastOrderSucceeded(order.StoreID, types.ConvertOrderToDTO(&order))
+		storeIDs[order.StoreID] = struct{}{}
+	}
+
+	for storeID := range storeIDs {
+		s.refreshStoreQueue(storeID)
+	}
+	return nil
+}
+
+// estimateOrderReadyAt estimates the new order as the last one of the store queue and stores the estimate
+func (s *orderService) estimateOrderReadyAt(order *data.Order) error {
+	queue, err := s.orderRepo.GetPreparationQueue(order.StoreID)
+	if err != nil {
+		return err
+	}
+
+	// the suborders are read back for the stations they were routed to
+	suborders, err := s.orderRepo.GetSubOrdersByOrderID(order.ID)
+	if err != nil {
+		return err
+	}
+	queue = append(queue, data.Order{
+		BaseEntity: data.BaseEntity{ID: order.ID},
+		PickupAt:   order.PickupAt,
+		Suborders:  suborders,
+	})
+
+	estimates, err := s.estimateReadyTimes(order.StoreID, queue)
+	if err != nil {
+		return err
+	}
+
+	readyAt := estimates[order.ID]
+	if err := s.orderRepo.UpdateOrdersEstimatedReadyAt(map[uint]time.Time{order.ID: readyAt}); err != nil {
+		return err
+	}
+	order.EstimatedReadyAt = &readyAt
+	return nil
+}
+
+// refreshStoreQueue updates the estimates and the status board after the store queue has changed
+func (s *orderService) refreshStoreQueue(storeID uint) {
+	s.refreshReadyEstimates(storeID)
+
+	board, err := s.GetStatusBoard(storeID)
+	if err != nil {
+		s.logger.Errorf("failed to refresh the status board of store %d: %v", storeID, err)
+		return
+	}
+	BroadcastStatusBoard(storeID, *board)
+}
+
+// GetStatusBoard returns the public board of the store with the masked customer names
+func (s *orderService) GetStatusBoard(storeID uint) (*types.StatusBoardDTO, error) {
+	if _, err := s.orderRepo.GetStoreWithSchedule(storeID); err != nil {
+		return nil, err
+	}
+
+	orders, err := s.orderRepo.GetStatusBoardOrders(storeID, time.Now().Add(-types.StatusBoardReadyPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	board := types.ConvertOrdersToStatusBoard(storeID, orders, censor.GetCensorValidator().ValidateText)
+	return &board, nil
+}
+
+// notifyOrderReady sends the pickup notification to the customer app when the suborder has completed the order
+func (s *orderService) notifyOrderReady(subOrderID uint) {
+	order, err := s.orderRepo.GetOrderBySubOrderID(subOrderID)
+	if err != nil {
+		s.logger.Errorf("failed to get the order of suborder %d for the pickup notification: %v", subOrderID, err)
+		return
+	}
+
+	if order.Status != data.OrderStatusCompleted || order.CustomerID == nil || order.CompletedAt == nil {
+		return
+	}
+
+	NotifyOrderReady(*order.CustomerID, types.OrderReadyNotificationDTO{
+		OrderID:       order.ID,
+		DisplayNumber: order.DisplayNumber,
+		StoreID:       order.StoreID,
+		StoreName:     order.Store.Name,
+		ReadyAt:       *order.CompletedAt,
+	})
+}
+
+// refreshReadyEstimates re-estimates the orders of the store queue and pushes the estimates to the store clients
+func (s *orderService) refreshReadyEstimates(storeID uint) {
+	queue, err := s.orderRepo.GetPreparationQueue(storeID)
+	if err != nil {
+		s.logger.Errorf("failed to refresh ready estimates of store %d: %v", storeID, err)
+		return
+	}
+
+	estimates, err := s.estimateReadyTimes(storeID, queue)
+	if err != nil {
+		s.logger.Errorf("failed to refresh ready estimates of store %d: %v", storeID, err)
+		return
+	}
+
+	if err := s.orderRepo.UpdateOrdersEstimatedReadyAt(estimates); err != nil {
+		s.logger.Errorf("failed to refresh ready estimates of store %d: %v", storeID, err)
+		return
+	}
+
+	dtos := make([]types.OrderReadyEstimateDTO, 0, len(queue))
+	for _, order := range queue {
+		dtos = append(dtos, types.OrderReadyEstimateDTO{
+			OrderID:          order.ID,
+			EstimatedReadyAt: estimates[order.ID],
+		})
+	}
+	BroadcastOrderEstimates(storeID, dtos)
+}
+
+func (s *orderService) estimateReadyTimes(storeID uint, queue []data.Order) (map[uint]time.Time, error) {
+	now := time.Now()
+
+	durations, err := s.orderRepo.GetPreparationDurations(storeID, now.AddDate(0, 0, -types.PreparationHistoryDays))
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make([]types.QueuedOrder, len(queue))
+	for i := range queue {
+		queued[i] = types.ConvertOrderToQueued(&queue[i])
+	}
+
+	return types.EstimateReadyTimes(now, queued, durations), nil
+}
+
+// findPickupSlot returns the start of the slot if it is one of the available slots,
+// the place in the slot is taken together with the order, see transactionManager.CreateOrder
+func (s *orderService) findPickupSlot(store *data.Store, pickupAt time.Time) (*time.Time, error) {
+	slots := types.BuildPickupSlots(storesTypes.NewStoreSchedule(store), time.Now(), &config.GetConfig().Pickup)
+
+	slot, err := types.FindPickupSlot(slots, pickupAt)
+	if err != nil {
+		return nil, err
+	}
+
+	startAt := slot.StartAt.UTC()
+	return &startAt, nil
+}
+
+// getPickupStore returns the store with its schedule if it accepts the orders from the customer app
+func (s *orderService) getPickupStore(storeID uint) (*data.Store, error) {
+	store, err := s.getStoreWithSchedule(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !store.IsActive {
+		return nil, types.ErrStoreUnavailable
+	}
+	return store, nil
+}
+
+func (s *orderService) getStoreWithSchedule(storeID uint) (*data.Store, error) {
+	store, err := s.orderRepo.GetStoreWithSchedule(storeID)
+	if err != nil {
+		if !errors.Is(err, types.ErrStoreUnavailable) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *orderService) countPickupOrders(storeID uint, from, to time.Time) (map[int64]int, error) {
+	counts, err := s.orderRepo.CountPickupOrders(storeID, from, to)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	ordersCount := make(map[int64]int, len(counts))
+	for _, count := range counts {
+		ordersCount[count.PickupAt.Unix()] = count.Count
+	}
+	return ordersCount, nil
+}
+
+func (s *orderService) CreateCustomerPaymentIntent(orderID, customerID uint) (*types.PaymentIntentDTO, error) {
+	order, err := s.getCustomerOrder(orderID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreatePaymentIntent(order.ID, order.StoreID)
+}
+
+// getCustomerOrder hides the orders of other customers as not found ones
+func (s *orderService) getCustomerOrder(orderID, customerID uint) (*data.Order, error) {
+	order, err := s.orderRepo.GetRawOrderById(orderID)
+	if err != nil {
+		if !errors.Is(err, types.ErrOrderNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	if order.CustomerID == nil || *order.CustomerID != customerID {
+		return nil, types.ErrOrderNotFound
+	}
+	return order, nil
+}
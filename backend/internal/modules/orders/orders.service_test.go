@@ -0,0 +1,62 @@
+package orders
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectSuborders(t *testing.T) {
+	suborders := []data.Suborder{
+		{BaseEntity: data.BaseEntity{ID: 1}, Status: data.SubOrderStatusPending},
+		{BaseEntity: data.BaseEntity{ID: 2}, Status: data.SubOrderStatusCompleted},
+		{BaseEntity: data.BaseEntity{ID: 3}, Status: data.SubOrderStatusRefunded},
+		{BaseEntity: data.BaseEntity{ID: 4}, Status: data.SubOrderStatusCancelled},
+	}
+
+	ids := func(selected []data.Suborder) []uint {
+		result := make([]uint, len(selected))
+		for i, suborder := range selected {
+			result[i] = suborder.ID
+		}
+		return result
+	}
+
+	t.Run("Refund without requested suborders should skip the refunded and cancelled ones", func(t *testing.T) {
+		selected, err := selectRefundableSuborders(suborders, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 2}, ids(selected))
+	})
+
+	t.Run("Refund of a cancelled suborder should fail", func(t *testing.T) {
+		_, err := selectRefundableSuborders(suborders, []uint{2, 4})
+		assert.ErrorIs(t, err, types.ErrSuborderNotRefundable)
+	})
+
+	t.Run("Requested suborders should be selected once", func(t *testing.T) {
+		selected, err := selectRefundableSuborders(suborders, []uint{2, 2, 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{2, 1}, ids(selected))
+	})
+
+	t.Run("Suborder of another order should fail", func(t *testing.T) {
+		_, err := selectRefundableSuborders(suborders, []uint{5})
+		assert.ErrorIs(t, err, types.ErrSuborderNotRefundable)
+	})
+
+	t.Run("Cancellation should select only the active suborders", func(t *testing.T) {
+		selected, err := selectCancellableSuborders(suborders, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, ids(selected))
+
+		_, err = selectCancellableSuborders(suborders, []uint{2})
+		assert.ErrorIs(t, err, types.ErrSuborderNotCancellable)
+	})
+
+	t.Run("Order without selectable suborders should fail", func(t *testing.T) {
+		_, err := selectRefundableSuborders(suborders[2:], nil)
+		assert.ErrorIs(t, err, types.ErrSuborderNotRefundable)
+	})
+}
@@ -2,8 +2,12 @@ package orders
 
 import (
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
+	bonusesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	storeInventoryManagersTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers/types"
 
@@ -12,17 +16,25 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications/details"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders/types"
 	"go.uber.org/zap"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
 type TransactionManager interface {
-	SetNextSubOrderStatus(suborder *data.Suborder) error
+	CreateOrder(order *data.Order) (uint, error)
+	SetNextSubOrderStatus(suborder *data.Suborder, employeeID uint, storeEmployeeID *uint) error
+	StartRefund(orderID uint, suborderIDs []uint, amount float64) (*data.OrderRefund, error)
+	RefundSuborders(refund *data.OrderRefund, refundTransaction *data.Transaction, restoreInventory bool, employeeID uint, storeEmployeeID *uint) error
+	RequestCancellation(cancellation *data.OrderCancellation, suborderIDs []uint, storeEmployeeID *uint) ([]uint, error)
+	ReviewCancellation(cancellation *data.OrderCancellation, storeEmployeeID *uint) ([]uint, error)
+	CompleteDelivery(order *data.Order) error
 }
 
 type transactionManager struct {
 	db                        *gorm.DB
 	repo                      OrderRepository
 	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository
+	bonusRepo                 bonuses.BonusRepository
 	notificationService       notifications.NotificationService
 	logger                    *zap.SugaredLogger
 }
@@ -31,6 +43,7 @@ func NewTransactionManager(
 	db *gorm.DB,
 	repo OrderRepository,
 	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository,
+	bonusRepo bonuses.BonusRepository,
 	notificationService notifications.NotificationService,
 	logger *zap.SugaredLogger,
 ) TransactionManager {
@@ -38,12 +51,75 @@ func NewTransactionManager(
 		db:                        db,
 		repo:                      repo,
 		storeInventoryManagerRepo: storeInventoryManagerRepo,
+		bonusRepo:                 bonusRepo,
 		notificationService:       notificationService,
 		logger:                    logger,
 	}
 }
 
-func (m *transactionManager) SetNextSubOrderStatus(suborder *data.Suborder) error {
+// CreateOrder stores the order and spends the customer bonuses redeemed for it, nothing is stored if the balance is insufficient
+// or the pickup slot of the order is fully booked
+func (m *transactionManager) CreateOrder(order *data.Order) (uint, error) {
+	var id uint
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+
+		if order.PickupAt != nil {
+			if err := m.reservePickupSlot(&repoTx, order); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		id, err = repoTx.CreateOrder(order)
+		if err != nil {
+			return err
+		}
+
+		if err := repoTx.AssignSuborderStations(id); err != nil {
+			return err
+		}
+
+		if order.BonusesRedeemed > 0 && order.CustomerID != nil {
+			bonusRepoTx := m.bonusRepo.CloneWithTransaction(tx)
+			if err := bonusRepoTx.RedeemBonuses(*order.CustomerID, id, order.BonusesRedeemed, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// reservePickupSlot locks the store until the end of the transaction, so the concurrent orders take the places of the slot one by one
+func (m *transactionManager) reservePickupSlot(repoTx OrderRepository, order *data.Order) error {
+	capacity, err := repoTx.LockPickupSlotCapacity(order.StoreID)
+	if err != nil {
+		return err
+	}
+
+	counts, err := repoTx.CountPickupOrders(order.StoreID, *order.PickupAt, *order.PickupAt)
+	if err != nil {
+		return err
+	}
+
+	booked := 0
+	for _, count := range counts {
+		booked += count.Count
+	}
+	if booked >= capacity {
+		return types.ErrPickupSlotFull
+	}
+	return nil
+}
+
+func (m *transactionManager) SetNextSubOrderStatus(suborder *data.Suborder, employeeID uint, storeEmployeeID *uint) error {
 	if suborder == nil {
 		return fmt.Errorf("suborder ID is nil")
 	}
@@ -52,12 +128,12 @@ func (m *transactionManager) SetNextSubOrderStatus(suborder *data.Suborder) erro
 		repoTx := m.repo.CloneWithTransaction(tx)
 		storeInventoryManagerRepoTx := m.storeInventoryManagerRepo.CloneWithTransaction(tx)
 		// Attempt to advance suborder status
-		if err := m.nextSuborderStatus(&repoTx, storeInventoryManagerRepoTx, suborder); err != nil {
+		if err := m.nextSuborderStatus(&repoTx, storeInventoryManagerRepoTx, suborder, employeeID, storeEmployeeID); err != nil {
 			return err
 		}
 
 		// Sync and update order status
-		if err := m.updateOrderStatusBySuborder(&repoTx, suborder.ID); err != nil {
+		if err := m.updateOrderStatusBySuborder(&repoTx, m.bonusRepo.CloneWithTransaction(tx), suborder.ID); err != nil {
 			return err
 		}
 
@@ -65,25 +141,253 @@ func (m *transactionManager) SetNextSubOrderStatus(suborder *data.Suborder) erro
 	}) // Handle fallback if suborder is already completed within time gap
 }
 
-func (m *transactionManager) nextSuborderStatus(repoTx OrderRepository, storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, suborder *data.Suborder) error {
+// StartRefund locks the order with its suborders, validates the refund against them and records it as pending.
+// A concurrent refund of the order waits for the lock and is rejected while this one is pending
+func (m *transactionManager) StartRefund(orderID uint, suborderIDs []uint, amount float64) (*data.OrderRefund, error) {
+	var refund *data.OrderRefund
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+
+		order, err := repoTx.LockOrderWithSuborders(orderID)
+		if err != nil {
+			return err
+		}
+
+		switch order.Status {
+		case data.OrderStatusWaitingForPayment, data.OrderStatusRefunded:
+			return types.ErrInappropriateOrderStatus
+		}
+
+		pending, err := repoTx.HasPendingOrderRefund(orderID)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return types.ErrRefundInProgress
+		}
+
+		suborders, err := selectRefundableSuborders(order.Suborders, suborderIDs)
+		if err != nil {
+			return err
+		}
+
+		if math.Round(amount*100) > math.Round(types.CalculateRefundableAmount(order, suborders)*100) {
+			return types.ErrRefundAmountExceeded
+		}
+
+		refundedIDs := make([]uint, len(suborders))
+		for i, suborder := range suborders {
+			refundedIDs[i] = suborder.ID
+		}
+
+		refund = &data.OrderRefund{
+			OrderID:     orderID,
+			SuborderIDs: datatypes.NewJSONSlice(refundedIDs),
+			Amount:      amount,
+			Status:      data.OrderRefundStatusPending,
+		}
+		return repoTx.CreateOrderRefund(refund)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// RefundSuborders completes the pending refund once the provider has returned the money,
+// the suborders are locked and checked again so they are refunded and restored to the stock only once
+func (m *transactionManager) RefundSuborders(refund *data.OrderRefund, refundTransaction *data.Transaction, restoreInventory bool, employeeID uint, storeEmployeeID *uint) error {
+	if refund == nil || len(refund.SuborderIDs) == 0 || refundTransaction == nil {
+		return fmt.Errorf("failed to refund suborders: invalid input parameters passed")
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+		storeInventoryManagerRepoTx := m.storeInventoryManagerRepo.CloneWithTransaction(tx)
+
+		if _, err := repoTx.LockPendingOrderRefund(refund.ID); err != nil {
+			return err
+		}
+
+		order, err := repoTx.LockOrderWithSuborders(refund.OrderID)
+		if err != nil {
+			return err
+		}
+
+		suborders, err := selectRefundableSuborders(order.Suborders, refund.SuborderIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := repoTx.CreateTransaction(refundTransaction); err != nil {
+			return err
+		}
+
+		for i := range suborders {
+			suborder := &suborders[i]
+
+			// Only completed suborders have their inventory deducted
+			if restoreInventory && suborder.Status == data.SubOrderStatusCompleted {
+				if err := m.restoreSuborderInventoryToStock(storeInventoryManagerRepoTx, order, suborder, employeeID); err != nil {
+					return err
+				}
+			}
+
+			if err := repoTx.RefundSubOrder(suborder.ID); err != nil {
+				return err
+			}
+
+			if err := recordStatusChange(&repoTx, suborder.ID, data.SubOrderStatusRefunded, storeEmployeeID); err != nil {
+				return err
+			}
+		}
+
+		if err := repoTx.FinishOrderRefund(refund.ID, data.OrderRefundStatusCompleted, &refundTransaction.ID); err != nil {
+			return err
+		}
+
+		bonusRepoTx := m.bonusRepo.CloneWithTransaction(tx)
+		if err := m.updateOrderStatusBySuborder(&repoTx, bonusRepoTx, suborders[0].ID); err != nil {
+			return err
+		}
+
+		return m.reverseOrderBonuses(&repoTx, bonusRepoTx, suborders[0].ID)
+	})
+}
+
+// RequestCancellation links the suborders to the cancellation and, if it is already approved,
+// cancels them right away on behalf of the store employee. Returns IDs of the cancelled suborders
+func (m *transactionManager) RequestCancellation(cancellation *data.OrderCancellation, suborderIDs []uint, storeEmployeeID *uint) ([]uint, error) {
+	if cancellation == nil || len(suborderIDs) == 0 {
+		return nil, fmt.Errorf("failed to request cancellation: invalid input parameters passed")
+	}
+
+	var cancelledIDs []uint
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+
+		if err := repoTx.CreateOrderCancellation(cancellation); err != nil {
+			return err
+		}
+
+		if err := repoTx.SetSubordersCancellation(suborderIDs, &cancellation.ID); err != nil {
+			return fmt.Errorf("failed to link suborders to cancellation: %w", err)
+		}
+
+		if cancellation.Status != data.OrderCancellationStatusApproved {
+			return nil
+		}
+
+		var err error
+		cancelledIDs, err = m.applyCancellation(&repoTx, m.bonusRepo.CloneWithTransaction(tx), cancellation.ID, suborderIDs[0], storeEmployeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelledIDs, nil
+}
+
+// ReviewCancellation persists the review decision, approved cancellations are applied to the suborders
+// which are still active on behalf of the reviewing store employee, rejected ones release the suborders for another request
+func (m *transactionManager) ReviewCancellation(cancellation *data.OrderCancellation, storeEmployeeID *uint) ([]uint, error) {
+	if cancellation == nil || len(cancellation.Suborders) == 0 {
+		return nil, fmt.Errorf("failed to review cancellation: invalid input parameters passed")
+	}
+
+	var cancelledIDs []uint
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+
+		if err := repoTx.UpdateOrderCancellation(cancellation); err != nil {
+			return fmt.Errorf("failed to update order cancellation: %w", err)
+		}
+
+		if cancellation.Status == data.OrderCancellationStatusRejected {
+			suborderIDs := make([]uint, len(cancellation.Suborders))
+			for i, suborder := range cancellation.Suborders {
+				suborderIDs[i] = suborder.ID
+			}
+			return repoTx.SetSubordersCancellation(suborderIDs, nil)
+		}
+
+		var err error
+		cancelledIDs, err = m.applyCancellation(&repoTx, m.bonusRepo.CloneWithTransaction(tx), cancellation.ID, cancellation.Suborders[0].ID, storeEmployeeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelledIDs, nil
+}
+
+func (m *transactionManager) applyCancellation(repoTx OrderRepository, bonusRepoTx bonuses.BonusRepository, cancellationID, anySuborderID uint, storeEmployeeID *uint) ([]uint, error) {
+	cancelledIDs, err := repoTx.CancelSubordersByCancellationID(cancellationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, suborderID := range cancelledIDs {
+		if err := recordStatusChange(repoTx, suborderID, data.SubOrderStatusCancelled, storeEmployeeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.updateOrderStatusBySuborder(repoTx, bonusRepoTx, anySuborderID); err != nil {
+		return nil, err
+	}
+
+	if err := m.reverseOrderBonuses(repoTx, bonusRepoTx, anySuborderID); err != nil {
+		return nil, err
+	}
+
+	return cancelledIDs, nil
+}
+
+// recordStatusChange keeps the store employee who moved the suborder to the status, nil when it was not done at the store
+func recordStatusChange(repoTx OrderRepository, suborderID uint, status data.SubOrderStatus, storeEmployeeID *uint) error {
+	statusChange := &data.SuborderStatusChange{
+		SuborderID:      suborderID,
+		Status:          status,
+		StoreEmployeeID: storeEmployeeID,
+	}
+	if err := repoTx.CreateSuborderStatusChange(statusChange); err != nil {
+		return fmt.Errorf("failed to record suborder %d status change: %w", suborderID, err)
+	}
+	return nil
+}
+
+func (m *transactionManager) nextSuborderStatus(repoTx OrderRepository, storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, suborder *data.Suborder, employeeID uint, storeEmployeeID *uint) error {
 	currentStatus := suborder.Status
 	nextStatus, ok := allowedTransitions[currentStatus]
 	if !ok {
 		return fmt.Errorf("no allowed transition from status %s", currentStatus)
 	}
 
-	completedAt := time.Now()
+	changedAt := time.Now()
 	update := types.UpdateSubOrderDTO{
-		Status:      nextStatus,
-		CompletedAt: &completedAt,
+		Status: nextStatus,
+	}
+	if nextStatus == data.SubOrderStatusPreparing {
+		update.StartedAt = &changedAt
+	} else {
+		update.CompletedAt = &changedAt
 	}
 	if err := repoTx.UpdateSubOrderStatus(suborder.ID, update); err != nil {
 		return fmt.Errorf("failed to update suborder status: %w", err)
 	}
 
+	if err := recordStatusChange(repoTx, suborder.ID, nextStatus, storeEmployeeID); err != nil {
+		return err
+	}
+
 	// If suborder is completed, deduct ingredients
 	if nextStatus == data.SubOrderStatusCompleted {
-		if err := m.handleSuborderCompletion(repoTx, storeInventoryManagerRepoTx, suborder); err != nil {
+		if err := m.handleSuborderCompletion(repoTx, storeInventoryManagerRepoTx, suborder, employeeID); err != nil {
 			return err
 		}
 	}
@@ -91,13 +395,18 @@ func (m *transactionManager) nextSuborderStatus(repoTx OrderRepository, storeInv
 	return nil
 }
 
-func (m *transactionManager) handleSuborderCompletion(repoTx OrderRepository, storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, suborder *data.Suborder) error {
+func (m *transactionManager) handleSuborderCompletion(repoTx OrderRepository, storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, suborder *data.Suborder, employeeID uint) error {
 	order, err := repoTx.GetOrderBySubOrderID(suborder.ID)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve order for suborder %d: %w", suborder.ID, err)
 	}
 
-	inventoryMap, err := m.deductSuborderInventoryFromStock(storeInventoryManagerRepoTx, order.StoreID, suborder)
+	source := data.StockMovementSource{
+		Type:       data.StockMovementSourceOrder,
+		ID:         &order.ID,
+		EmployeeID: &employeeID,
+	}
+	inventoryMap, err := m.deductSuborderInventoryFromStock(storeInventoryManagerRepoTx, order.StoreID, suborder, source)
 	if err != nil {
 		return fmt.Errorf("failed to deduct ingredients: %w", err)
 	}
@@ -129,7 +438,7 @@ func (m *transactionManager) handleSuborderCompletion(repoTx OrderRepository, st
 	return nil
 }
 
-func (m *transactionManager) updateOrderStatusBySuborder(repoTx OrderRepository, subOrderID uint) error {
+func (m *transactionManager) updateOrderStatusBySuborder(repoTx OrderRepository, bonusRepoTx bonuses.BonusRepository, subOrderID uint) error {
 	order, err := repoTx.GetOrderBySubOrderID(subOrderID)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve order for suborder %d: %w", subOrderID, err)
@@ -140,9 +449,19 @@ func (m *transactionManager) updateOrderStatusBySuborder(repoTx OrderRepository,
 		return fmt.Errorf("failed to fetch suborders for order %d: %w", order.ID, err)
 	}
 
-	hasPreparing, allCompleted := m.evaluateSuborderStatuses(suborders)
+	hasPreparing, allCompleted, allPending, allClosed := m.evaluateSuborderStatuses(suborders)
 
 	switch {
+	case allClosed:
+		if err := m.ensureOrderStatus(repoTx, order, closedOrderStatus(suborders), nil); err != nil {
+			return err
+		}
+		// nothing of the order is kept, so the bonuses spent on it are returned with the same transaction
+		return bonusRepoTx.ReturnRedeemedBonuses(order.ID, &config.GetConfig().Loyalty, time.Now())
+
+	case allPending:
+		return nil
+
 	case hasPreparing:
 		return m.ensureOrderStatus(repoTx, order, data.OrderStatusPreparing, nil)
 
@@ -151,14 +470,47 @@ func (m *transactionManager) updateOrderStatusBySuborder(repoTx OrderRepository,
 		if order.DeliveryAddressID != nil {
 			newStatus = data.OrderStatusInDelivery
 		}
+		if order.Status == newStatus {
+			return nil
+		}
 		now := time.Now()
-		return m.ensureOrderStatus(repoTx, order, newStatus, &now)
+		if err := m.ensureOrderStatus(repoTx, order, newStatus, &now); err != nil {
+			return err
+		}
+		if newStatus == data.OrderStatusCompleted {
+			return m.accrueOrderBonuses(bonusRepoTx, order, suborders, now)
+		}
+		return nil
 
 	default:
 		return m.ensureOrderStatus(repoTx, order, data.OrderStatusPreparing, nil)
 	}
 }
 
+// CompleteDelivery closes the delivered order and accrues the customer bonuses which are postponed until the delivery
+func (m *transactionManager) CompleteDelivery(order *data.Order) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+		bonusRepoTx := m.bonusRepo.CloneWithTransaction(tx)
+
+		now := time.Now()
+		update := types.UpdateOrderDTO{
+			Status:      data.OrderStatusDelivered,
+			DeliveredAt: &now,
+		}
+		if err := repoTx.UpdateOrderStatus(order.ID, update); err != nil {
+			return fmt.Errorf("failed to update order status to %s: %w", data.OrderStatusDelivered, err)
+		}
+
+		suborders, err := repoTx.GetSubOrdersByOrderID(order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch suborders for order %d: %w", order.ID, err)
+		}
+
+		return m.accrueOrderBonuses(bonusRepoTx, order, suborders, now)
+	})
+}
+
 func (m *transactionManager) ensureOrderStatus(repoTx OrderRepository, order *data.Order, status data.OrderStatus, completedAt *time.Time) error {
 	if order.Status == status {
 		return nil
@@ -174,21 +526,103 @@ func (m *transactionManager) ensureOrderStatus(repoTx OrderRepository, order *da
 	return nil
 }
 
-func (m *transactionManager) evaluateSuborderStatuses(suborders []data.Suborder) (hasPreparing bool, allCompleted bool) {
+// accrueOrderBonuses credits the customer for the paid part of the completed order and rewards the referrer for the first completed order of the referee
+func (m *transactionManager) accrueOrderBonuses(bonusRepoTx bonuses.BonusRepository, order *data.Order, suborders []data.Suborder, now time.Time) error {
+	if order.CustomerID == nil {
+		return nil
+	}
+
+	cfg := &config.GetConfig().Loyalty
+
+	accrued, err := bonusRepoTx.HasOrderBonus(order.ID, data.BonusTypeAccrual)
+	if err != nil {
+		return err
+	}
+
+	if amount := bonusesTypes.CalculateOrderAccrual(types.CalculateNetPaidAmount(order, suborders), cfg); amount > 0 && !accrued {
+		bonus := bonusesTypes.NewCreditBonus(*order.CustomerID, data.BonusTypeAccrual, amount, cfg, now)
+		bonus.OrderID = &order.ID
+		if err := bonusRepoTx.CreateBonus(bonus); err != nil {
+			return fmt.Errorf("failed to accrue bonuses for order %d: %w", order.ID, err)
+		}
+	}
+
+	referral, err := bonusRepoTx.GetUnrewardedReferral(*order.CustomerID)
+	if err != nil || referral == nil || cfg.ReferralBonus <= 0 {
+		return err
+	}
+
+	bonus := bonusesTypes.NewCreditBonus(referral.CustomerID, data.BonusTypeReferral, cfg.ReferralBonus, cfg, now)
+	bonus.OrderID = &order.ID
+	bonus.ReferralID = &referral.ID
+	if err := bonusRepoTx.CreateBonus(bonus); err != nil {
+		return fmt.Errorf("failed to credit referral %d: %w", referral.ID, err)
+	}
+
+	return bonusRepoTx.MarkReferralRewarded(referral.ID, now)
+}
+
+// reverseOrderBonuses takes back the accrual for the refunded and cancelled part of the order,
+// the referral reward is taken back only when nothing of the order is kept
+func (m *transactionManager) reverseOrderBonuses(repoTx OrderRepository, bonusRepoTx bonuses.BonusRepository, subOrderID uint) error {
+	order, err := repoTx.GetOrderBySubOrderID(subOrderID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve order for suborder %d: %w", subOrderID, err)
+	}
+
+	suborders, err := repoTx.GetSubOrdersByOrderID(order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch suborders for order %d: %w", order.ID, err)
+	}
+
+	now := time.Now()
+	kept := bonusesTypes.CalculateOrderAccrual(types.CalculateNetPaidAmount(order, suborders), &config.GetConfig().Loyalty)
+	if err := bonusRepoTx.ReverseOrderBonus(order.ID, data.BonusTypeAccrual, kept, now); err != nil {
+		return err
+	}
+
+	if _, _, _, allClosed := m.evaluateSuborderStatuses(suborders); !allClosed {
+		return nil
+	}
+	return bonusRepoTx.ReverseOrderBonus(order.ID, data.BonusTypeReferral, 0, now)
+}
+
+// evaluateSuborderStatuses ignores refunded and cancelled suborders unless every suborder of the order is closed
+func (m *transactionManager) evaluateSuborderStatuses(suborders []data.Suborder) (hasPreparing, allCompleted, allPending, allClosed bool) {
 	hasPreparing = false
 	allCompleted = true
+	allPending = true
+	allClosed = true
 	for _, so := range suborders {
+		if so.Status.IsClosed() {
+			continue
+		}
+		allClosed = false
+
 		if so.Status == data.SubOrderStatusPreparing {
 			hasPreparing = true
 		}
 		if so.Status != data.SubOrderStatusCompleted {
 			allCompleted = false
 		}
+		if so.Status != data.SubOrderStatusPending {
+			allPending = false
+		}
 	}
 	return
 }
 
-func (m *transactionManager) deductSuborderInventoryFromStock(storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, storeID uint, suborder *data.Suborder) (*storeInventoryManagersTypes.DeductedInventoryMap, error) {
+// closedOrderStatus expects every suborder to be closed: the order is refunded only when nothing was cancelled
+func closedOrderStatus(suborders []data.Suborder) data.OrderStatus {
+	for _, so := range suborders {
+		if so.Status == data.SubOrderStatusCancelled {
+			return data.OrderStatusCancelled
+		}
+	}
+	return data.OrderStatusRefunded
+}
+
+func (m *transactionManager) deductSuborderInventoryFromStock(storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, storeID uint, suborder *data.Suborder, source data.StockMovementSource) (*storeInventoryManagersTypes.DeductedInventoryMap, error) {
 	if storeID == 0 || suborder == nil {
 		return nil, fmt.Errorf("failed to deduct suborder: invalid input parameters passed")
 	}
@@ -198,7 +632,7 @@ func (m *transactionManager) deductSuborderInventoryFromStock(storeInventoryMana
 		return nil, fmt.Errorf("failed to deduct suborder: %w", err)
 	}
 
-	deductedInventoryMap, err := storeInventoryManagerRepoTx.DeductStoreInventory(storeID, inventory)
+	deductedInventoryMap, err := storeInventoryManagerRepoTx.DeductStoreInventory(storeID, inventory, source)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deduct suborder: %w", err)
 	}
@@ -206,6 +640,28 @@ func (m *transactionManager) deductSuborderInventoryFromStock(storeInventoryMana
 	return deductedInventoryMap, nil
 }
 
+func (m *transactionManager) restoreSuborderInventoryToStock(storeInventoryManagerRepoTx storeInventoryManagers.StoreInventoryManagerRepository, order *data.Order, suborder *data.Suborder, employeeID uint) error {
+	if order.StoreID == 0 || suborder == nil {
+		return fmt.Errorf("failed to restore suborder inventory: invalid input parameters passed")
+	}
+
+	inventory, err := storeInventoryManagerRepoTx.GetSuborderInventoryUsage(suborder)
+	if err != nil {
+		return fmt.Errorf("failed to restore suborder inventory: %w", err)
+	}
+
+	source := data.StockMovementSource{
+		Type:       data.StockMovementSourceOrderRefund,
+		ID:         &order.ID,
+		EmployeeID: &employeeID,
+	}
+	if _, err := storeInventoryManagerRepoTx.RestoreStoreInventory(order.StoreID, inventory, source); err != nil {
+		return fmt.Errorf("failed to restore suborder inventory: %w", err)
+	}
+
+	return nil
+}
+
 func (m *transactionManager) notifyLowStockIngredients(order *data.Order, stockMap map[uint]*data.StoreStock) {
 	for _, stock := range stockMap {
 		if stock.Quantity <= stock.LowStockThreshold {
@@ -2,6 +2,7 @@ package types
 
 import (
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	promotionsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
 	unitTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/units/types"
 )
 
@@ -38,9 +39,9 @@ func ConvertCreateOrderDTOToOrder(createOrderDTO *CreateOrderDTO, productPrices
 	return data.Order{
 		CustomerID:        createOrderDTO.CustomerID,
 		CustomerName:      createOrderDTO.CustomerName,
-		StoreEmployeeID:   createOrderDTO.StoreEmployeeID,
 		StoreID:           createOrderDTO.StoreID,
 		DeliveryAddressID: createOrderDTO.DeliveryAddressID,
+		PickupAt:          createOrderDTO.PickupAt,
 		Status:            data.OrderStatusPending,
 		Suborders:         suborders,
 	}, total
@@ -58,6 +59,15 @@ func ConvertOrderToDTO(order *data.Order) OrderDTO {
 		CreatedAt:         order.CreatedAt,
 		CompletedAt:       order.CompletedAt,
 		Total:             order.Total,
+		DiscountTotal:     order.DiscountTotal,
+		TaxTotal:          order.TaxTotal,
+		BonusesRedeemed:   order.BonusesRedeemed,
+		DeliveryFee:       order.DeliveryFee,
+		CourierID:         order.CourierID,
+		DeliveredAt:       order.DeliveredAt,
+		PickupAt:          order.PickupAt,
+		EstimatedReadyAt:  order.EstimatedReadyAt,
+		CashShiftID:       order.CashShiftID,
 		SubordersQuantity: len(order.Suborders),
 		Suborders:         []SuborderDTO{},
 		DisplayNumber:     order.DisplayNumber,
@@ -84,7 +94,14 @@ func ConvertSuborderToDTO(suborder *data.Suborder) SuborderDTO {
 			MachineCategory: suborder.StoreProductSize.ProductSize.Product.Category.MachineCategory,
 		},
 		Price:       suborder.Price,
+		Discount:    suborder.DiscountAmount,
+		Discounts:   ConvertSuborderDiscountsToDTO(suborder.Discounts),
+		TaxRate:     suborder.TaxRate,
+		TaxMode:     suborder.TaxMode,
+		TaxAmount:   suborder.TaxAmount,
 		Status:      suborder.Status,
+		StationID:   suborder.StationID,
+		StartedAt:   suborder.StartedAt,
 		CreatedAt:   suborder.CreatedAt,
 		UpdatedAt:   suborder.UpdatedAt,
 		CompletedAt: suborder.CompletedAt,
@@ -98,6 +115,29 @@ func ConvertSuborderToDTO(suborder *data.Suborder) SuborderDTO {
 	return suborderDTO
 }
 
+func ConvertSuborderDiscountsToDTO(discounts []data.SuborderDiscount) []SuborderDiscountDTO {
+	dtos := make([]SuborderDiscountDTO, len(discounts))
+	for i, discount := range discounts {
+		dtos[i] = SuborderDiscountDTO{
+			PromotionID:   discount.PromotionID,
+			PromotionName: discount.PromotionName,
+			Type:          discount.Type,
+			Amount:        discount.Amount,
+		}
+	}
+	return dtos
+}
+
+func ConvertAppliedDiscountToModel(discount *promotionsTypes.AppliedDiscount) data.SuborderDiscount {
+	promotionID := discount.PromotionID
+	return data.SuborderDiscount{
+		PromotionID:   &promotionID,
+		PromotionName: discount.PromotionName,
+		Type:          discount.Type,
+		Amount:        discount.Amount,
+	}
+}
+
 func ConvertSuborderAdditiveToDTO(suborderAdditive *data.SuborderAdditive) SuborderStoreAdditiveDTO {
 	return SuborderStoreAdditiveDTO{
 		ID:         suborderAdditive.ID,
@@ -133,9 +173,14 @@ func MapToOrderDetailsDTO(order *data.Order) *OrderDetailsDTO {
 		}
 
 		suborders[i] = SuborderDetailsDTO{
-			ID:     sub.ID,
-			Price:  sub.Price,
-			Status: sub.Status,
+			ID:        sub.ID,
+			Price:     sub.Price,
+			Discount:  sub.DiscountAmount,
+			Discounts: ConvertSuborderDiscountsToDTO(sub.Discounts),
+			TaxRate:   sub.TaxRate,
+			TaxMode:   sub.TaxMode,
+			TaxAmount: sub.TaxAmount,
+			Status:    sub.Status,
 			StoreProductSize: OrderProductSizeDetailsDTO{
 				ID:         sub.StoreProductSize.ID,
 				Name:       sub.StoreProductSize.ProductSize.Name,
@@ -149,6 +194,7 @@ func MapToOrderDetailsDTO(order *data.Order) *OrderDetailsDTO {
 				},
 			},
 			StoreAdditives: storeAdditives,
+			StatusChanges:  ToSuborderStatusChangesDTO(sub.StatusChanges),
 		}
 	}
 
@@ -162,23 +208,34 @@ func MapToOrderDetailsDTO(order *data.Order) *OrderDetailsDTO {
 		}
 	}
 
+	var delivery *OrderDeliveryDTO
+	if order.DeliveryAddressID != nil {
+		delivery = ToOrderDeliveryDTO(order)
+	}
+
 	var customerName *string
 	if order.CustomerName != "" {
 		customerName = &order.CustomerName
 	}
 
-	transactions := make([]TransactionDTO, len(order.Transactions))
+	transactions := make([]OrderTransactionDTO, len(order.Transactions))
 	for i, transaction := range order.Transactions {
-		transactions[i] = TransactionDTO{
-			Bin:           transaction.Bin,
-			TransactionID: transaction.TransactionID,
-			ProcessID:     transaction.ProcessID,
-			PaymentMethod: transaction.PaymentMethod,
-			Amount:        transaction.Amount,
-			Currency:      transaction.Currency,
-			QRNumber:      transaction.QRNumber,
-			CardMask:      transaction.CardMask,
-			ICC:           transaction.ICC,
+		transactions[i] = OrderTransactionDTO{
+			Type: transaction.Type,
+			TransactionDTO: TransactionDTO{
+				Bin:           transaction.Bin,
+				TransactionID: transaction.TransactionID,
+				ProcessID:     transaction.ProcessID,
+				PaymentMethod: transaction.PaymentMethod,
+				Amount:        transaction.Amount,
+				Currency:      transaction.Currency,
+				QRNumber:      transaction.QRNumber,
+				CardMask:      transaction.CardMask,
+				ICC:           transaction.ICC,
+			},
+			Provider:             transaction.Provider,
+			ReconciliationStatus: transaction.ReconciliationStatus,
+			CreatedAt:            transaction.CreatedAt,
 		}
 	}
 
@@ -187,9 +244,14 @@ func MapToOrderDetailsDTO(order *data.Order) *OrderDetailsDTO {
 		CustomerName:    customerName,
 		Status:          order.Status,
 		Total:           order.Total,
+		DiscountTotal:   order.DiscountTotal,
+		TaxTotal:        order.TaxTotal,
+		BonusesRedeemed: order.BonusesRedeemed,
 		Suborders:       suborders,
 		DeliveryAddress: deliveryAddress,
+		Delivery:        delivery,
 		CompletedAt:     order.CompletedAt,
+		PickupAt:        order.PickupAt,
 		DisplayNumber:   order.DisplayNumber,
 		CreatedAt:       order.CreatedAt,
 		Transactions:    transactions,
@@ -208,6 +270,9 @@ func ToOrderExportDTO(order *data.Order, storeName string) OrderExportDTO {
 		CustomerName:    order.CustomerName,
 		Status:          order.Status,
 		Total:           order.Total,
+		DiscountTotal:   order.DiscountTotal,
+		TaxTotal:        order.TaxTotal,
+		BonusesRedeemed: order.BonusesRedeemed,
 		CreatedAt:       order.CreatedAt,
 		StoreName:       storeName,
 		Suborders:       suborders,
@@ -215,6 +280,65 @@ func ToOrderExportDTO(order *data.Order, storeName string) OrderExportDTO {
 	}
 }
 
+func ToOrderDeliveryDTO(order *data.Order) *OrderDeliveryDTO {
+	delivery := &OrderDeliveryDTO{
+		Fee:         order.DeliveryFee,
+		DistanceKm:  order.DeliveryDistance,
+		DeliveredAt: order.DeliveredAt,
+	}
+
+	if order.Courier != nil {
+		delivery.Courier = &OrderCourierDTO{
+			ID:        order.Courier.ID,
+			FirstName: order.Courier.Employee.FirstName,
+			LastName:  order.Courier.Employee.LastName,
+			Phone:     order.Courier.Employee.Phone,
+		}
+	}
+
+	return delivery
+}
+
+// ConvertOrderToReorderDTO repeats every suborder of the order with its additives
+func ConvertOrderToReorderDTO(order *data.Order) *CreateOrderDTO {
+	suborders := make([]CreateSubOrderDTO, len(order.Suborders))
+	for i, suborder := range order.Suborders {
+		storeAdditivesIDs := make([]uint, len(suborder.SuborderAdditives))
+		for j, suborderAdditive := range suborder.SuborderAdditives {
+			storeAdditivesIDs[j] = suborderAdditive.StoreAdditiveID
+		}
+
+		suborders[i] = CreateSubOrderDTO{
+			StoreProductSizeID: suborder.StoreProductSizeID,
+			Quantity:           1,
+			StoreAdditivesIDs:  storeAdditivesIDs,
+		}
+	}
+
+	return &CreateOrderDTO{
+		CustomerID:        order.CustomerID,
+		CustomerName:      order.CustomerName,
+		DeliveryAddressID: order.DeliveryAddressID,
+		Suborders:         suborders,
+		StoreID:           order.StoreID,
+	}
+}
+
+func ToSuborderStatusChangesDTO(statusChanges []data.SuborderStatusChange) []SuborderStatusChangeDTO {
+	dtos := make([]SuborderStatusChangeDTO, len(statusChanges))
+	for i, statusChange := range statusChanges {
+		dtos[i] = SuborderStatusChangeDTO{
+			Status:          statusChange.Status,
+			StoreEmployeeID: statusChange.StoreEmployeeID,
+			CreatedAt:       statusChange.CreatedAt,
+		}
+		if statusChange.StoreEmployee != nil {
+			dtos[i].EmployeeName = statusChange.StoreEmployee.Employee.FirstName + " " + statusChange.StoreEmployee.Employee.LastName
+		}
+	}
+	return dtos
+}
+
 func ToOrderAdditivesDTO(additives []data.SuborderAdditive) []SuborderStoreAdditiveDTO {
 	dtos := make([]SuborderStoreAdditiveDTO, len(additives))
 	for i, additive := range additives {
@@ -235,9 +359,10 @@ func ToOrderDeliveryAddressDTO(address *data.CustomerAddress) *OrderDeliveryAddr
 	}
 }
 
-func ToTransactionModel(dto *TransactionDTO, orderID uint, transactionType data.TransactionType) *data.Transaction {
+func ToTransactionModel(dto *TransactionDTO, orderID uint, transactionType data.TransactionType, provider data.PaymentProviderName) *data.Transaction {
 	return &data.Transaction{
 		Type:          transactionType,
+		Provider:      provider,
 		OrderID:       orderID,
 		Bin:           dto.Bin,
 		TransactionID: dto.TransactionID,
@@ -250,3 +375,50 @@ func ToTransactionModel(dto *TransactionDTO, orderID uint, transactionType data.
 		ICC:           dto.ICC,
 	}
 }
+
+func ConvertPaymentIntentToDTO(intent *data.PaymentIntent, details map[string]string) *PaymentIntentDTO {
+	return &PaymentIntentDTO{
+		ID:         intent.ID,
+		OrderID:    intent.OrderID,
+		Provider:   intent.Provider,
+		ExternalID: intent.ExternalID,
+		Amount:     intent.Amount,
+		Currency:   intent.Currency,
+		Status:     intent.Status,
+		Details:    details,
+	}
+}
+
+func ConvertOrderCancellationToDTO(cancellation *data.OrderCancellation) OrderCancellationDTO {
+	suborderIDs := make([]uint, len(cancellation.Suborders))
+	for i, suborder := range cancellation.Suborders {
+		suborderIDs[i] = suborder.ID
+	}
+
+	var reviewedBy *OrderEmployeeDTO
+	if cancellation.ReviewedBy != nil {
+		reviewedBy = ConvertOrderEmployeeToDTO(cancellation.ReviewedBy)
+	}
+
+	return OrderCancellationDTO{
+		ID:            cancellation.ID,
+		OrderID:       cancellation.OrderID,
+		DisplayNumber: cancellation.Order.DisplayNumber,
+		Reason:        cancellation.Reason,
+		Comment:       cancellation.Comment,
+		Status:        cancellation.Status,
+		RequestedBy:   *ConvertOrderEmployeeToDTO(&cancellation.RequestedBy),
+		ReviewedBy:    reviewedBy,
+		ReviewedAt:    cancellation.ReviewedAt,
+		SuborderIDs:   suborderIDs,
+		CreatedAt:     cancellation.CreatedAt,
+	}
+}
+
+func ConvertOrderEmployeeToDTO(employee *data.Employee) *OrderEmployeeDTO {
+	return &OrderEmployeeDTO{
+		ID:        employee.ID,
+		FirstName: employee.FirstName,
+		LastName:  employee.LastName,
+	}
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// CalculateDeliveryFee charges the base fee plus the distance part, orders starting from the free delivery total are delivered for free
+func CalculateDeliveryFee(distanceKm, orderTotal float64, cfg *config.DeliveryConfig) float64 {
+	if cfg.FreeDeliveryMin > 0 && orderTotal >= cfg.FreeDeliveryMin {
+		return 0
+	}
+	return utils.RoundToDecimal(cfg.BaseFee+distanceKm*cfg.FeePerKm, 2)
+}
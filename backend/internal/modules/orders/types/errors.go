@@ -11,4 +11,28 @@ var (
 	ErrInsufficientStock         = moduleErrors.NewModuleError(errors.New("insufficient stock to fulfill the order"))
 	ErrMultipleSelect            = moduleErrors.NewModuleError(errors.New("multiple select on this additive category is not allowed"))
 	ErrInvalidCustomerNameCensor = moduleErrors.NewModuleError(errors.New("invalid customer name"))
+	ErrSuborderNotRefundable     = moduleErrors.NewModuleError(errors.New("suborder can not be refunded"))
+	ErrRefundAmountExceeded      = moduleErrors.NewModuleError(errors.New("refund amount exceeds the refundable amount"))
+	ErrRefundInProgress          = moduleErrors.NewModuleError(errors.New("another refund of the order is in progress"))
+	ErrRefundNotPending          = moduleErrors.NewModuleError(errors.New("refund is not pending"))
+	ErrInvalidCancellationReason = moduleErrors.NewModuleError(errors.New("invalid cancellation reason"))
+	ErrSuborderNotCancellable    = moduleErrors.NewModuleError(errors.New("suborder can not be cancelled"))
+	ErrCancellationPending       = moduleErrors.NewModuleError(errors.New("suborder already has a pending cancellation"))
+	ErrCancellationNotFound      = moduleErrors.NewModuleError(errors.New("order cancellation not found"))
+	ErrCancellationReviewed      = moduleErrors.NewModuleError(errors.New("order cancellation is already reviewed"))
+	ErrBonusesWithoutCustomer    = moduleErrors.NewModuleError(errors.New("bonuses can be redeemed only by a customer"))
+	ErrBonusesRedeemLimit        = moduleErrors.NewModuleError(errors.New("redeemed bonuses exceed the allowed part of the order total"))
+	ErrInvalidDeliveryAddress    = moduleErrors.NewModuleError(errors.New("invalid delivery address"))
+	ErrStoreLocationUnknown      = moduleErrors.NewModuleError(errors.New("store coordinates are not set"))
+	ErrDeliveryDistanceExceeded  = moduleErrors.NewModuleError(errors.New("delivery address is too far from the store"))
+	ErrNotDeliveryOrder          = moduleErrors.NewModuleError(errors.New("order is not a delivery order"))
+	ErrCourierNotFound           = moduleErrors.NewModuleError(errors.New("courier is not an employee of the store"))
+	ErrCourierNotAssigned        = moduleErrors.NewModuleError(errors.New("courier is not assigned to the order"))
+	ErrPaymentIntentNotFound     = moduleErrors.NewModuleError(errors.New("payment intent not found"))
+	ErrPaymentIntentProcessed    = moduleErrors.NewModuleError(errors.New("payment intent is already processed"))
+	ErrCustomerNotVerified       = moduleErrors.NewModuleError(errors.New("customer is not verified or banned"))
+	ErrStoreUnavailable          = moduleErrors.NewModuleError(errors.New("store does not accept online orders"))
+	ErrStoreClosed               = moduleErrors.NewModuleError(errors.New("store is closed"))
+	ErrInvalidPickupSlot         = moduleErrors.NewModuleError(errors.New("pickup time is not an available slot"))
+	ErrPickupSlotFull            = moduleErrors.NewModuleError(errors.New("pickup slot is fully booked"))
 )
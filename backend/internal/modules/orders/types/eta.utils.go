@@ -0,0 +1,109 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+const (
+	// DefaultPreparationDuration is used for the product sizes without the preparation history in the store
+	DefaultPreparationDuration = 3 * time.Minute
+	// PreparationHistoryDays is how far back the completed suborders are taken for the preparation durations
+	PreparationHistoryDays = 30
+)
+
+// PreparationDurations holds the typical preparation duration by the store product size
+type PreparationDurations map[uint]time.Duration
+
+func (d PreparationDurations) Of(storeProductSizeID uint) time.Duration {
+	if duration, ok := d[storeProductSizeID]; ok && duration > 0 {
+		return duration
+	}
+	return DefaultPreparationDuration
+}
+
+type QueuedSuborder struct {
+	StoreProductSizeID uint
+	StationID          *uint
+	StartedAt          *time.Time
+}
+
+type QueuedOrder struct {
+	OrderID   uint
+	PickupAt  *time.Time
+	Suborders []QueuedSuborder
+}
+
+type OrderReadyEstimateDTO struct {
+	OrderID          uint      `json:"orderId"`
+	EstimatedReadyAt time.Time `json:"estimatedReadyAt"`
+}
+
+// ConvertOrderToQueued keeps only the suborders, which are still to be prepared
+func ConvertOrderToQueued(order *data.Order) QueuedOrder {
+	queued := QueuedOrder{
+		OrderID:   order.ID,
+		PickupAt:  order.PickupAt,
+		Suborders: make([]QueuedSuborder, 0, len(order.Suborders)),
+	}
+
+	for _, suborder := range order.Suborders {
+		if suborder.Status != data.SubOrderStatusPending && suborder.Status != data.SubOrderStatusPreparing {
+			continue
+		}
+		queued.Suborders = append(queued.Suborders, QueuedSuborder{
+			StoreProductSizeID: suborder.StoreProductSizeID,
+			StationID:          suborder.StationID,
+			StartedAt:          suborder.StartedAt,
+		})
+	}
+
+	return queued
+}
+
+// EstimateReadyTimes walks the queue in its order, every station prepares its suborders one after another,
+// the unrouted suborders share one common line. The order is ready when the last of its stations finishes,
+// a scheduled order is not ready before its pickup time
+func EstimateReadyTimes(now time.Time, queue []QueuedOrder, durations PreparationDurations) map[uint]time.Time {
+	const unroutedStation = 0
+	stationsFreeAt := make(map[uint]time.Time)
+	estimates := make(map[uint]time.Time, len(queue))
+
+	for _, order := range queue {
+		readyAt := now
+
+		for _, suborder := range order.Suborders {
+			station := uint(unroutedStation)
+			if suborder.StationID != nil {
+				station = *suborder.StationID
+			}
+
+			startAt := now
+			if freeAt, ok := stationsFreeAt[station]; ok && freeAt.After(startAt) {
+				startAt = freeAt
+			}
+
+			remaining := durations.Of(suborder.StoreProductSizeID)
+			if suborder.StartedAt != nil {
+				remaining -= now.Sub(*suborder.StartedAt)
+				if remaining < 0 {
+					remaining = 0
+				}
+			}
+
+			finishAt := startAt.Add(remaining)
+			stationsFreeAt[station] = finishAt
+			if finishAt.After(readyAt) {
+				readyAt = finishAt
+			}
+		}
+
+		if order.PickupAt != nil && order.PickupAt.After(readyAt) {
+			readyAt = *order.PickupAt
+		}
+		estimates[order.OrderID] = readyAt
+	}
+
+	return estimates
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateReadyTimes(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	barID, kitchenID := uint(1), uint(2)
+	latte, cake := uint(10), uint(20)
+	durations := PreparationDurations{latte: 4 * time.Minute, cake: 2 * time.Minute}
+
+	startedAt := now.Add(-3 * time.Minute)
+	longAgo := now.Add(-10 * time.Minute)
+	pickupAt := now.Add(30 * time.Minute)
+
+	tests := []struct {
+		description string
+		queue       []QueuedOrder
+		expected    map[uint]time.Time
+	}{
+		{
+			description: "Suborders of one station should be prepared one after another",
+			queue: []QueuedOrder{
+				{OrderID: 1, Suborders: []QueuedSuborder{{StoreProductSizeID: latte, StationID: &barID}}},
+				{OrderID: 2, Suborders: []QueuedSuborder{{StoreProductSizeID: latte, StationID: &barID}}},
+			},
+			expected: map[uint]time.Time{1: now.Add(4 * time.Minute), 2: now.Add(8 * time.Minute)},
+		},
+		{
+			description: "Order should be ready when its slowest station finishes",
+			queue: []QueuedOrder{
+				{OrderID: 1, Suborders: []QueuedSuborder{{StoreProductSizeID: latte, StationID: &barID}}},
+				{OrderID: 2, Suborders: []QueuedSuborder{
+					{StoreProductSizeID: cake, StationID: &kitchenID},
+					{StoreProductSizeID: latte, StationID: &barID},
+				}},
+			},
+			expected: map[uint]time.Time{1: now.Add(4 * time.Minute), 2: now.Add(8 * time.Minute)},
+		},
+		{
+			description: "Started suborder should count only its remaining time",
+			queue: []QueuedOrder{
+				{OrderID: 1, Suborders: []QueuedSuborder{{StoreProductSizeID: latte, StartedAt: &startedAt}}},
+				{OrderID: 2, Suborders: []QueuedSuborder{{StoreProductSizeID: cake, StartedAt: &longAgo}}},
+			},
+			expected: map[uint]time.Time{1: now.Add(time.Minute), 2: now.Add(time.Minute)},
+		},
+		{
+			description: "Product size without history should take the default duration",
+			queue: []QueuedOrder{
+				{OrderID: 1, Suborders: []QueuedSuborder{{StoreProductSizeID: 99}}},
+			},
+			expected: map[uint]time.Time{1: now.Add(DefaultPreparationDuration)},
+		},
+		{
+			description: "Scheduled order should not be ready before its pickup time",
+			queue: []QueuedOrder{
+				{OrderID: 1, PickupAt: &pickupAt, Suborders: []QueuedSuborder{{StoreProductSizeID: latte}}},
+			},
+			expected: map[uint]time.Time{1: pickupAt},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, EstimateReadyTimes(now, tc.queue, durations))
+		})
+	}
+}
+
+func TestConvertOrderToQueued(t *testing.T) {
+	order := &data.Order{
+		BaseEntity: data.BaseEntity{ID: 5},
+		Suborders: []data.Suborder{
+			{StoreProductSizeID: 1, Status: data.SubOrderStatusPending},
+			{StoreProductSizeID: 2, Status: data.SubOrderStatusCompleted},
+			{StoreProductSizeID: 3, Status: data.SubOrderStatusPreparing},
+			{StoreProductSizeID: 4, Status: data.SubOrderStatusCancelled},
+		},
+	}
+
+	queued := ConvertOrderToQueued(order)
+	assert.Equal(t, uint(5), queued.OrderID)
+	assert.Len(t, queued.Suborders, 2)
+	assert.Equal(t, uint(1), queued.Suborders[0].StoreProductSizeID)
+	assert.Equal(t, uint(3), queued.Suborders[1].StoreProductSizeID)
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	RefundOrderAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.OrderRefundComponent, &RefundOrderDTO{})
+
+	CancelOrderAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.OrderCancellationComponent, &CancelOrderDTO{})
+
+	ReviewOrderCancellationAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.UpdateOperation, data.OrderCancellationComponent, &ReviewOrderCancellationDTO{})
+
+	AssignCourierAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.UpdateOperation, data.OrderDeliveryComponent, &AssignCourierDTO{})
+)
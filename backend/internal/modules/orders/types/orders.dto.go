@@ -3,6 +3,7 @@ package types
 import (
 	"time"
 
+	paymentsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
 	unitTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/units/types"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
@@ -10,20 +11,56 @@ import (
 )
 
 type OrdersFilterQuery struct {
-	Search  *string           `form:"search"`
-	Status  *data.OrderStatus `form:"status"`
-	StoreID *uint             `form:"storeId"`
+	Search     *string           `form:"search"`
+	Status     *data.OrderStatus `form:"status"`
+	StoreID    *uint             `form:"storeId"`
+	CustomerID *uint             `form:"customerId"`
 	utils.BaseFilter
 }
 
+// CustomerActiveOrderStatuses are tracked by the customer until the order is given out or delivered
+var CustomerActiveOrderStatuses = []data.OrderStatus{
+	data.OrderStatusWaitingForPayment,
+	data.OrderStatusPending,
+	data.OrderStatusPreparing,
+	data.OrderStatusInDelivery,
+}
+
 type CreateOrderDTO struct {
 	CustomerID        *uint               `json:"customerId,omitempty"`
 	CustomerName      string              `json:"customerName" binding:"required"`
-	StoreEmployeeID   *uint               `json:"storeEmployeeId,omitempty"`
 	DeliveryAddressID *uint               `json:"deliveryAddressId"`
+	BonusesToRedeem   float64             `json:"bonusesToRedeem" binding:"gte=0"`
 	Suborders         []CreateSubOrderDTO `json:"subOrders"`
 
-	StoreID uint
+	StoreID    uint
+	EmployeeID uint       // the employee creating the order, the order is attributed to them and added to their cash shift
+	PickupAt   *time.Time `json:"-"`
+}
+
+// CreateCustomerOrderDTO is an order placed from the customer app for the pickup at the store
+type CreateCustomerOrderDTO struct {
+	StoreID         uint                `json:"storeId" binding:"required"`
+	CustomerName    string              `json:"customerName"` // the first name of the customer if empty
+	PickupAt        *time.Time          `json:"pickupAt"`     // start of the pickup slot, nil for "as soon as possible"
+	BonusesToRedeem float64             `json:"bonusesToRedeem" binding:"gte=0"`
+	Suborders       []CreateSubOrderDTO `json:"subOrders" binding:"required,min=1"`
+}
+
+type PickupSlotsFilter struct {
+	StoreID uint `form:"storeId" binding:"required"`
+}
+
+type PickupSlotDTO struct {
+	StartAt   time.Time `json:"startAt"`
+	EndAt     time.Time `json:"endAt"`
+	Remaining int       `json:"remaining"`
+	Available bool      `json:"available"`
+}
+
+type PickupOrdersCount struct {
+	PickupAt time.Time
+	Count    int
 }
 
 type ValidateCustomerNameDTO struct {
@@ -57,6 +94,15 @@ type OrderDTO struct {
 	CreatedAt         time.Time        `json:"createdAt"`
 	CompletedAt       *time.Time       `json:"completedAt,omitempty"`
 	Total             float64          `json:"total"`
+	DiscountTotal     float64          `json:"discountTotal"`
+	TaxTotal          float64          `json:"taxTotal"`
+	BonusesRedeemed   float64          `json:"bonusesRedeemed"`
+	DeliveryFee       float64          `json:"deliveryFee"`
+	CourierID         *uint            `json:"courierId,omitempty"`
+	DeliveredAt       *time.Time       `json:"deliveredAt,omitempty"`
+	PickupAt          *time.Time       `json:"pickupAt,omitempty"`
+	EstimatedReadyAt  *time.Time       `json:"estimatedReadyAt,omitempty"`
+	CashShiftID       *uint            `json:"cashShiftId,omitempty"`
 	DisplayNumber     int              `json:"displayNumber"`
 	SubordersQuantity int              `json:"subOrdersQuantity"`
 	Suborders         []SuborderDTO    `json:"subOrders"`
@@ -65,6 +111,11 @@ type OrderDTO struct {
 type UpdateOrderDTO struct {
 	Status      data.OrderStatus `json:"status"`
 	CompletedAt *time.Time       `json:"completedAt,omitempty"`
+	DeliveredAt *time.Time       `json:"deliveredAt,omitempty"`
+}
+
+type AssignCourierDTO struct {
+	CourierID uint `json:"courierId" binding:"required,gt=0"`
 }
 
 type SuborderDTO struct {
@@ -72,15 +123,30 @@ type SuborderDTO struct {
 	OrderID     uint                       `json:"orderId"`
 	ProductSize OrderStoreProductSizeDTO   `json:"productSize"`
 	Price       float64                    `json:"price"`
+	Discount    float64                    `json:"discount"`
+	Discounts   []SuborderDiscountDTO      `json:"discounts"`
+	TaxRate     float64                    `json:"taxRate"`
+	TaxMode     data.TaxMode               `json:"taxMode"`
+	TaxAmount   float64                    `json:"taxAmount"`
 	Status      data.SubOrderStatus        `json:"status"`
+	StationID   *uint                      `json:"stationId,omitempty"`
+	StartedAt   *time.Time                 `json:"startedAt,omitempty"`
 	Additives   []SuborderStoreAdditiveDTO `json:"additives"`
 	CreatedAt   time.Time                  `json:"createdAt"`
 	UpdatedAt   time.Time                  `json:"updatedAt"`
 	CompletedAt *time.Time                 `json:"completedAt,omitempty"`
 }
 
+type SuborderDiscountDTO struct {
+	PromotionID   *uint              `json:"promotionId,omitempty"`
+	PromotionName string             `json:"promotionName"`
+	Type          data.PromotionType `json:"type"`
+	Amount        float64            `json:"amount"`
+}
+
 type UpdateSubOrderDTO struct {
 	Status      data.SubOrderStatus `json:"status"`
+	StartedAt   *time.Time          `json:"startedAt,omitempty"`
 	CompletedAt *time.Time          `json:"completedAt,omitempty"`
 }
 
@@ -117,22 +183,41 @@ type OrderDetailsDTO struct {
 	CustomerName    *string                  `json:"customerName,omitempty"`
 	Status          data.OrderStatus         `json:"status"`
 	Total           float64                  `json:"total"`
+	DiscountTotal   float64                  `json:"discountTotal"`
+	TaxTotal        float64                  `json:"taxTotal"`
+	BonusesRedeemed float64                  `json:"bonusesRedeemed"`
 	Suborders       []SuborderDetailsDTO     `json:"suborders"`
 	DeliveryAddress *OrderDeliveryAddressDTO `json:"deliveryAddress,omitempty"`
+	Delivery        *OrderDeliveryDTO        `json:"delivery,omitempty"`
 	CompletedAt     *time.Time               `json:"completedAt,omitempty"`
-	Transactions    []TransactionDTO         `json:"transactions"`
+	PickupAt        *time.Time               `json:"pickupAt,omitempty"`
+	Transactions    []OrderTransactionDTO    `json:"transactions"`
 	CreatedAt       time.Time                `json:"createdAt"`
 }
 
 type SuborderDetailsDTO struct {
 	ID               uint                       `json:"id"`
 	Price            float64                    `json:"price"`
+	Discount         float64                    `json:"discount"`
+	Discounts        []SuborderDiscountDTO      `json:"discounts"`
+	TaxRate          float64                    `json:"taxRate"`
+	TaxMode          data.TaxMode               `json:"taxMode"`
+	TaxAmount        float64                    `json:"taxAmount"`
 	Status           data.SubOrderStatus        `json:"status"`
 	StoreProductSize OrderProductSizeDetailsDTO `json:"storeProductSize"`
 	StoreAdditives   []OrderAdditiveDetailsDTO  `json:"storeAdditives"`
+	StatusChanges    []SuborderStatusChangeDTO  `json:"statusChanges"`
 	CompletedAt      *time.Time                 `json:"completedAt,omitempty"`
 }
 
+// SuborderStatusChangeDTO shows which operator moved the suborder to the status
+type SuborderStatusChangeDTO struct {
+	Status          data.SubOrderStatus `json:"status"`
+	StoreEmployeeID *uint               `json:"storeEmployeeId,omitempty"`
+	EmployeeName    string              `json:"employeeName,omitempty"`
+	CreatedAt       time.Time           `json:"createdAt"`
+}
+
 type OrderProductSizeDetailsDTO struct {
 	ID         uint                   `json:"id"`
 	Name       string                 `json:"name"`
@@ -162,12 +247,25 @@ type OrderDeliveryAddressDTO struct {
 	Latitude  string `json:"latitude"`
 }
 
+type OrderDeliveryDTO struct {
+	Fee         float64          `json:"fee"`
+	DistanceKm  *float64         `json:"distanceKm,omitempty"`
+	Courier     *OrderCourierDTO `json:"courier,omitempty"`
+	DeliveredAt *time.Time       `json:"deliveredAt,omitempty"`
+}
+
+type OrderCourierDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Phone     string `json:"phone"`
+}
+
 type OrdersExportFilterQuery struct {
-	StartDate        *time.Time `form:"startDate" binding:"omitempty"`
-	EndDate          *time.Time `form:"endDate" binding:"omitempty"`
-	StoreID          *uint      `form:"storeId" binding:"omitempty"`
-	Language         string     `form:"language" binding:"omitempty,oneof=kk ru en"` // Optional language filter
-	TimeZoneLocation *string    `form:"timezoneLocation" binding:"omitempty"`
+	StartDate *time.Time `form:"startDate" binding:"omitempty"`
+	EndDate   *time.Time `form:"endDate" binding:"omitempty"`
+	StoreID   *uint      `form:"storeId" binding:"omitempty"`
+	Language  string     `form:"language" binding:"omitempty,oneof=kk ru en"` // Optional language filter
 }
 
 type OrderExportDTO struct {
@@ -175,35 +273,143 @@ type OrderExportDTO struct {
 	CustomerName    string                   `json:"customerName"`
 	Status          data.OrderStatus         `json:"status"`
 	Total           float64                  `json:"total"`
+	DiscountTotal   float64                  `json:"discountTotal"`
+	TaxTotal        float64                  `json:"taxTotal"`
+	BonusesRedeemed float64                  `json:"bonusesRedeemed"`
 	CreatedAt       time.Time                `json:"createdAt"`
 	StoreName       string                   `json:"storeName"`
 	Suborders       []SuborderDTO            `json:"suborders"`
 	DeliveryAddress *OrderDeliveryAddressDTO `json:"deliveryAddress,omitempty"`
 }
 
-type OrdersTimeZoneFilter struct {
+type PaymentReconciliationFilterQuery struct {
+	Date     time.Time `form:"date" binding:"required" time_format:"2006-01-02"`
+	StoreID  *uint     `form:"storeId" binding:"omitempty"`
+	Language string    `form:"language" binding:"omitempty,oneof=kk ru en"`
+}
+
+// OrderPaymentSummary is an order with the sums of its transactions
+type OrderPaymentSummary struct {
+	OrderID       uint
+	StoreID       uint
+	StoreName     string
+	StoreTimezone string
+	DisplayNumber int
+	Status        data.OrderStatus
+	Total         float64
+	Paid          float64
+	Refunded      float64
+	PaymentsCount int
+	CreatedAt     time.Time
+
+	// RefundedSubordersTotal is what the customer paid for the refunded suborders
+	RefundedSubordersTotal float64
+}
+
+type PaymentDiscrepancyDTO struct {
+	OrderID       uint                   `json:"orderId"`
+	StoreID       uint                   `json:"storeId"`
+	StoreName     string                 `json:"storeName"`
+	DisplayNumber int                    `json:"displayNumber"`
+	Status        data.OrderStatus       `json:"status"`
+	Type          PaymentDiscrepancyType `json:"type"`
+	Total         float64                `json:"total"`
+	Paid          float64                `json:"paid"`
+	Refunded      float64                `json:"refunded"`
+	Difference    float64                `json:"difference"`
+	CreatedAt     time.Time              `json:"createdAt"`
+}
+
+type BaristaOrdersFilter struct {
 	StoreID                *uint              `form:"storeId" binding:"omitempty"`
-	TimeZoneLocation       *string            `form:"timezone" binding:"omitempty"`
-	TimeZoneOffset         *uint              `form:"timezoneOffset" binding:"omitempty"`
 	TimeGapMinutes         *uint              `form:"timeGapMinutes" binding:"omitempty"`
 	IncludeYesterdayOrders *bool              `form:"includeYesterdayOrders" binding:"omitempty"`
 	Statuses               []data.OrderStatus `form:"statuses" binding:"omitempty"`
+	StationID              *uint              `form:"stationId" binding:"omitempty"` // only the suborders routed to the preparation station
+	ReleaseUntil           *time.Time         `form:"-"`                             // scheduled pickup orders up to this time are shown
 }
 
 type ToggleNextSuborderStatusOptions struct {
 	IncludeIfCompletedGapMinutes *int `form:"includeIfCompletedGapMinutes"`
 }
 
-type TransactionDTO struct {
-	Bin           string  `json:"bin" binding:"required,len=12"`
-	TransactionID string  `json:"transactionId" binding:"required,max=20"`
-	ProcessID     *string `json:"processId" binding:"omitempty,max=20"`
-	PaymentMethod string  `json:"paymentMethod" binding:"required"`
-	Amount        float64 `json:"amount" binding:"required,gt=0"`
-	Currency      string  `json:"currency" binding:"required,len=3"`
-	QRNumber      *string `json:"qrNumber" binding:"omitempty,min=7,max=16"`
-	CardMask      *string `json:"cardMask" binding:"omitempty,len=16"`
-	ICC           *string `json:"icc" binding:"omitempty"`
+// TransactionDTO is shared with the payment providers which report the transactions in the same format
+type TransactionDTO = paymentsTypes.TransactionDTO
+
+type PaymentIntentDTO struct {
+	ID         uint                     `json:"id"`
+	OrderID    uint                     `json:"orderId"`
+	Provider   data.PaymentProviderName `json:"provider"`
+	ExternalID string                   `json:"externalId"`
+	Amount     float64                  `json:"amount"`
+	Currency   string                   `json:"currency"`
+	Status     data.PaymentIntentStatus `json:"status"`
+	Details    map[string]string        `json:"details,omitempty"`
+}
+
+type RefundOrderDTO struct {
+	SuborderIDs      []uint         `json:"subOrderIds" binding:"omitempty,dive,gt=0"` // empty means the whole order
+	RestoreInventory bool           `json:"restoreInventory"`
+	Transaction      TransactionDTO `json:"transaction" binding:"required"`
+}
+
+type CancelOrderDTO struct {
+	SuborderIDs []uint                       `json:"subOrderIds" binding:"omitempty,dive,gt=0"` // empty means every active suborder
+	Reason      data.OrderCancellationReason `json:"reason" binding:"required"`
+	Comment     *string                      `json:"comment" binding:"omitempty,max=500"`
+}
+
+// CancellationRequester describes who asks for the cancellation, requests of employees
+// allowed to approve cancellations are applied immediately
+type CancellationRequester struct {
+	EmployeeID uint
+	CanApprove bool
+}
+
+type ReviewOrderCancellationDTO struct {
+	Status data.OrderCancellationStatus `json:"status" binding:"required,oneof=APPROVED REJECTED"`
+}
+
+type OrderCancellationsFilterQuery struct {
+	OrderID *uint                         `form:"orderId"`
+	Status  *data.OrderCancellationStatus `form:"status"`
+	Reason  *data.OrderCancellationReason `form:"reason"`
+	StoreID *uint
+	utils.BaseFilter
+}
+
+type OrderCancellationDTO struct {
+	ID            uint                         `json:"id"`
+	OrderID       uint                         `json:"orderId"`
+	DisplayNumber int                          `json:"displayNumber"`
+	Reason        data.OrderCancellationReason `json:"reason"`
+	Comment       *string                      `json:"comment,omitempty"`
+	Status        data.OrderCancellationStatus `json:"status"`
+	RequestedBy   OrderEmployeeDTO             `json:"requestedBy"`
+	ReviewedBy    *OrderEmployeeDTO            `json:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time                   `json:"reviewedAt,omitempty"`
+	SuborderIDs   []uint                       `json:"subOrderIds"`
+	CreatedAt     time.Time                    `json:"createdAt"`
+}
+
+type OrderEmployeeDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type OrderCancelledPayload struct {
+	OrderID     uint                         `json:"orderId"`
+	SuborderIDs []uint                       `json:"subOrderIds"`
+	Reason      data.OrderCancellationReason `json:"reason"`
+}
+
+type OrderTransactionDTO struct {
+	Type data.TransactionType `json:"type"`
+	TransactionDTO
+	Provider             data.PaymentProviderName              `json:"provider"`
+	ReconciliationStatus *data.TransactionReconciliationStatus `json:"reconciliationStatus,omitempty"`
+	CreatedAt            time.Time                             `json:"createdAt"`
 }
 
 type WaitingOrderPayload struct {
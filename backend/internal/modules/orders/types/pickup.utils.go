@@ -0,0 +1,70 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+)
+
+type PickupSlot struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// BuildPickupSlots splits the store hours into slots starting from the lead time after now until the closing of the last allowed day
+func BuildPickupSlots(schedule *storesTypes.StoreSchedule, now time.Time, cfg *config.PickupConfig) []PickupSlot {
+	var slots []PickupSlot
+	if schedule == nil || !schedule.IsSet() || cfg.SlotDuration <= 0 {
+		return slots
+	}
+
+	earliest := now.Add(cfg.LeadTime)
+	local := now.In(schedule.Location)
+
+	// the hours of yesterday are included for the stores open after the midnight
+	for offset := -1; offset <= cfg.DaysAhead; offset++ {
+		day := local.AddDate(0, 0, offset)
+		hours := schedule.HoursOn(day)
+		if hours == nil {
+			continue
+		}
+
+		openAt, closeAt := hours.Window(day)
+		for startAt := openAt; !startAt.Add(cfg.SlotDuration).After(closeAt); startAt = startAt.Add(cfg.SlotDuration) {
+			if startAt.Before(earliest) {
+				continue
+			}
+			slots = append(slots, PickupSlot{StartAt: startAt, EndAt: startAt.Add(cfg.SlotDuration)})
+		}
+	}
+
+	return slots
+}
+
+func FindPickupSlot(slots []PickupSlot, pickupAt time.Time) (*PickupSlot, error) {
+	for i := range slots {
+		if slots[i].StartAt.Equal(pickupAt) {
+			return &slots[i], nil
+		}
+	}
+	return nil, ErrInvalidPickupSlot
+}
+
+func ConvertToPickupSlotDTOs(slots []PickupSlot, ordersCount map[int64]int, capacity int) []PickupSlotDTO {
+	dtos := make([]PickupSlotDTO, len(slots))
+	for i, slot := range slots {
+		remaining := capacity - ordersCount[slot.StartAt.Unix()]
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		dtos[i] = PickupSlotDTO{
+			StartAt:   slot.StartAt.UTC(),
+			EndAt:     slot.EndAt.UTC(),
+			Remaining: remaining,
+			Available: remaining > 0,
+		}
+	}
+	return dtos
+}
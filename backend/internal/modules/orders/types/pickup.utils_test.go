@@ -0,0 +1,77 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func weeklySchedule(openAt, closeAt string, exceptions ...data.StoreHoursException) *storesTypes.StoreSchedule {
+	store := &data.Store{Timezone: "UTC", HoursExceptions: exceptions}
+	for _, day := range []data.Weekday{data.Monday, data.Tuesday, data.Wednesday, data.Thursday, data.Friday, data.Saturday, data.Sunday} {
+		store.WorkingHours = append(store.WorkingHours, data.StoreWorkingHours{Day: day, OpenAt: openAt, CloseAt: closeAt})
+	}
+	return storesTypes.NewStoreSchedule(store)
+}
+
+func TestBuildPickupSlots(t *testing.T) {
+	cfg := &config.PickupConfig{SlotDuration: 15 * time.Minute, LeadTime: 15 * time.Minute, DaysAhead: 0}
+
+	t.Run("Slots should start after the lead time and end at the closing", func(t *testing.T) {
+		now := time.Date(2026, 10, 18, 10, 7, 0, 0, time.UTC)
+		slots := BuildPickupSlots(weeklySchedule("08:00", "20:00"), now, cfg)
+
+		assert.Len(t, slots, 38)
+		assert.Equal(t, time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC), slots[0].StartAt)
+		assert.Equal(t, time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC), slots[len(slots)-1].EndAt)
+	})
+
+	t.Run("Store open after the midnight should keep the slots of the previous day", func(t *testing.T) {
+		schedule := weeklySchedule("20:00", "02:00")
+
+		now := time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+		slots := BuildPickupSlots(schedule, now, cfg)
+
+		assert.Len(t, slots, 27)
+		assert.Equal(t, time.Date(2026, 10, 18, 1, 15, 0, 0, time.UTC), slots[0].StartAt)
+		assert.Equal(t, time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC), slots[3].StartAt)
+		assert.True(t, schedule.IsOpen(now))
+	})
+
+	t.Run("Closed date should have no slots", func(t *testing.T) {
+		closed := data.StoreHoursException{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), IsClosed: true}
+
+		now := time.Date(2026, 10, 18, 10, 7, 0, 0, time.UTC)
+		assert.Empty(t, BuildPickupSlots(weeklySchedule("08:00", "20:00", closed), now, cfg))
+	})
+
+	t.Run("Unknown store hours should have no slots", func(t *testing.T) {
+		assert.Empty(t, BuildPickupSlots(nil, time.Now(), cfg))
+		assert.Empty(t, BuildPickupSlots(storesTypes.NewStoreSchedule(&data.Store{}), time.Now(), cfg))
+	})
+}
+
+func TestPickupSlotsAvailability(t *testing.T) {
+	startAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	slots := []PickupSlot{
+		{StartAt: startAt, EndAt: startAt.Add(15 * time.Minute)},
+		{StartAt: startAt.Add(15 * time.Minute), EndAt: startAt.Add(30 * time.Minute)},
+	}
+
+	slot, err := FindPickupSlot(slots, startAt.In(time.FixedZone("UTC+5", 5*60*60)))
+	assert.NoError(t, err)
+	assert.Equal(t, startAt, slot.StartAt)
+
+	_, err = FindPickupSlot(slots, startAt.Add(5*time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidPickupSlot)
+
+	dtos := ConvertToPickupSlotDTOs(slots, map[int64]int{startAt.Unix(): 3}, 3)
+	assert.False(t, dtos[0].Available)
+	assert.Equal(t, 0, dtos[0].Remaining)
+	assert.True(t, dtos[1].Available)
+	assert.Equal(t, 3, dtos[1].Remaining)
+}
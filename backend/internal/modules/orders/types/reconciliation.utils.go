@@ -0,0 +1,94 @@
+package types
+
+import (
+	"math"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type PaymentDiscrepancyType string
+
+const (
+	PaymentDiscrepancyMissingPayment  PaymentDiscrepancyType = "MISSING_PAYMENT"
+	PaymentDiscrepancyAmountMismatch  PaymentDiscrepancyType = "AMOUNT_MISMATCH"
+	PaymentDiscrepancyOverRefunded    PaymentDiscrepancyType = "OVER_REFUNDED"
+	PaymentDiscrepancyRefundedBalance PaymentDiscrepancyType = "REFUNDED_BALANCE"
+	PaymentDiscrepancyStalePayment    PaymentDiscrepancyType = "STALE_PAYMENT"
+)
+
+// paymentTolerance ignores the float errors, the amounts are stored with 2 decimals
+const paymentTolerance = 0.005
+
+// DetectPaymentDiscrepancy checks the order against its transactions, the orders created before staleBefore must not wait for the payment anymore.
+// The money kept for the order, the payments without the refunds, is compared with the amount expected by its status
+func DetectPaymentDiscrepancy(summary *OrderPaymentSummary, staleBefore time.Time) *PaymentDiscrepancyDTO {
+	var (
+		discrepancyType PaymentDiscrepancyType
+		difference      float64
+	)
+
+	expected := ExpectedPaidAmount(summary)
+	net := summary.Paid - summary.Refunded
+
+	switch {
+	case summary.Status == data.OrderStatusWaitingForPayment:
+		if !summary.CreatedAt.Before(staleBefore) {
+			return nil
+		}
+		discrepancyType, difference = PaymentDiscrepancyStalePayment, summary.Total
+	case summary.PaymentsCount == 0 && expected > paymentTolerance:
+		discrepancyType, difference = PaymentDiscrepancyMissingPayment, expected
+	case summary.Refunded-summary.Paid > paymentTolerance:
+		discrepancyType, difference = PaymentDiscrepancyOverRefunded, summary.Refunded-summary.Paid
+	case amountsEqual(net, expected):
+		return nil
+	case isClosedOrderStatus(summary.Status):
+		discrepancyType, difference = PaymentDiscrepancyRefundedBalance, net
+	default:
+		discrepancyType, difference = PaymentDiscrepancyAmountMismatch, net-expected
+	}
+
+	return &PaymentDiscrepancyDTO{
+		OrderID:       summary.OrderID,
+		StoreID:       summary.StoreID,
+		StoreName:     summary.StoreName,
+		DisplayNumber: summary.DisplayNumber,
+		Status:        summary.Status,
+		Type:          discrepancyType,
+		Total:         summary.Total,
+		Paid:          summary.Paid,
+		Refunded:      summary.Refunded,
+		Difference:    utils.RoundToDecimal(difference, 2),
+		CreatedAt:     summary.CreatedAt.In(storesTypes.StoreLocation(summary.StoreTimezone)),
+	}
+}
+
+// ExpectedPaidAmount is the money the order should keep: nothing once it is cancelled or refunded,
+// otherwise the total without the refunded suborders
+func ExpectedPaidAmount(summary *OrderPaymentSummary) float64 {
+	if isClosedOrderStatus(summary.Status) {
+		return 0
+	}
+	return utils.RoundToDecimal(max(summary.Total-summary.RefundedSubordersTotal, 0), 2)
+}
+
+func isClosedOrderStatus(status data.OrderStatus) bool {
+	return status == data.OrderStatusCancelled || status == data.OrderStatusRefunded
+}
+
+func DetectPaymentDiscrepancies(summaries []OrderPaymentSummary, staleBefore time.Time) []PaymentDiscrepancyDTO {
+	discrepancies := make([]PaymentDiscrepancyDTO, 0)
+	for i := range summaries {
+		if discrepancy := DetectPaymentDiscrepancy(&summaries[i], staleBefore); discrepancy != nil {
+			discrepancies = append(discrepancies, *discrepancy)
+		}
+	}
+	return discrepancies
+}
+
+func amountsEqual(a, b float64) bool {
+	return math.Abs(a-b) <= paymentTolerance
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPaymentDiscrepancy(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	staleBefore := now.Add(-10 * time.Minute)
+
+	tests := []struct {
+		description        string
+		summary            OrderPaymentSummary
+		expectedType       PaymentDiscrepancyType
+		expectedDifference float64
+	}{
+		{
+			description: "Paid order should match",
+			summary:     OrderPaymentSummary{Status: data.OrderStatusCompleted, Total: 1500, Paid: 1500, PaymentsCount: 1, CreatedAt: now},
+		},
+		{
+			description: "Partially refunded order should keep the total without the refunded suborders",
+			summary:     OrderPaymentSummary{Status: data.OrderStatusCompleted, Total: 1500, Paid: 1500, Refunded: 500, RefundedSubordersTotal: 500, PaymentsCount: 1, CreatedAt: now},
+		},
+		{
+			description:        "Refund without refunded suborders should be reported",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusCompleted, Total: 1500, Paid: 1500, Refunded: 500, PaymentsCount: 1, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyAmountMismatch,
+			expectedDifference: -500,
+		},
+		{
+			description: "Refunded order should keep nothing",
+			summary:     OrderPaymentSummary{Status: data.OrderStatusRefunded, Total: 1500, Paid: 1500, Refunded: 1500, RefundedSubordersTotal: 1500, PaymentsCount: 1, CreatedAt: now},
+		},
+		{
+			description: "Cancelled order without payment should match",
+			summary:     OrderPaymentSummary{Status: data.OrderStatusCancelled, Total: 1500, CreatedAt: now},
+		},
+		{
+			description:        "Cancelled order which is paid should be reported",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusCancelled, Total: 1500, Paid: 1500, PaymentsCount: 1, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyRefundedBalance,
+			expectedDifference: 1500,
+		},
+		{
+			description: "Recent order waiting for payment should be skipped",
+			summary:     OrderPaymentSummary{Status: data.OrderStatusWaitingForPayment, Total: 1500, CreatedAt: now},
+		},
+		{
+			description:        "Old order waiting for payment should be stale",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusWaitingForPayment, Total: 1500, CreatedAt: staleBefore.Add(-time.Minute)},
+			expectedType:       PaymentDiscrepancyStalePayment,
+			expectedDifference: 1500,
+		},
+		{
+			description:        "Order without payment transactions should be reported",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusPending, Total: 1500, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyMissingPayment,
+			expectedDifference: 1500,
+		},
+		{
+			description:        "Partially refunded order without payment transactions should miss the rest of the total",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusCompleted, Total: 1500, RefundedSubordersTotal: 500, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyMissingPayment,
+			expectedDifference: 1000,
+		},
+		{
+			description:        "Paid amount should match the total",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusCompleted, Total: 1500, Paid: 1499.99, PaymentsCount: 1, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyAmountMismatch,
+			expectedDifference: -0.01,
+		},
+		{
+			description:        "Refunds should not exceed payments",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusRefunded, Total: 1500, Paid: 1500, Refunded: 1600, PaymentsCount: 1, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyOverRefunded,
+			expectedDifference: 100,
+		},
+		{
+			description:        "Refunded order should have no balance",
+			summary:            OrderPaymentSummary{Status: data.OrderStatusRefunded, Total: 1500, Paid: 1500, Refunded: 1000, PaymentsCount: 1, CreatedAt: now},
+			expectedType:       PaymentDiscrepancyRefundedBalance,
+			expectedDifference: 500,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			discrepancy := DetectPaymentDiscrepancy(&tc.summary, staleBefore)
+			if tc.expectedType == "" {
+				assert.Nil(t, discrepancy)
+				return
+			}
+
+			if assert.NotNil(t, discrepancy) {
+				assert.Equal(t, tc.expectedType, discrepancy.Type)
+				assert.InDelta(t, tc.expectedDifference, discrepancy.Difference, 0.001)
+			}
+		})
+	}
+}
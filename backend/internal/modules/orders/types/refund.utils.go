@@ -0,0 +1,51 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// SuborderPaidAmount is the price of the suborder with the exclusive tax charged on top of it
+func SuborderPaidAmount(suborder *data.Suborder) float64 {
+	if suborder.TaxMode == data.TaxModeExclusive {
+		return suborder.Price + suborder.TaxAmount
+	}
+	return suborder.Price
+}
+
+// IsLastRefund tells if the suborders are the last ones of the order not refunded or cancelled yet
+func IsLastRefund(order *data.Order, suborders []data.Suborder) bool {
+	remaining := 0
+	for _, suborder := range order.Suborders {
+		if !suborder.Status.IsClosed() {
+			remaining++
+		}
+	}
+	return remaining == len(suborders)
+}
+
+// CalculateNetPaidAmount is the money kept for the goods of the order: the total without the delivery fee
+// and without the refunded and cancelled suborders
+func CalculateNetPaidAmount(order *data.Order, suborders []data.Suborder) float64 {
+	paid := order.Total - order.DeliveryFee
+	for i := range suborders {
+		if suborders[i].Status.IsClosed() {
+			paid -= SuborderPaidAmount(&suborders[i])
+		}
+	}
+	return utils.RoundToDecimal(max(paid, 0), 2)
+}
+
+// CalculateRefundableAmount caps the refund of the suborders by what the customer paid for them.
+// The last refund of the order returns the delivery fee too, the redeemed bonuses are returned as bonuses and not as money
+func CalculateRefundableAmount(order *data.Order, suborders []data.Suborder) float64 {
+	var amount float64
+	for i := range suborders {
+		amount += SuborderPaidAmount(&suborders[i])
+	}
+
+	if IsLastRefund(order, suborders) {
+		amount += order.DeliveryFee - order.BonusesRedeemed
+	}
+	return utils.RoundToDecimal(max(amount, 0), 2)
+}
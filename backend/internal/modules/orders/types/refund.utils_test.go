@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateRefundableAmount(t *testing.T) {
+	order := &data.Order{
+		DeliveryFee:     500,
+		BonusesRedeemed: 200,
+		Suborders: []data.Suborder{
+			{BaseEntity: data.BaseEntity{ID: 1}, Price: 1500, TaxMode: data.TaxModeInclusive, TaxAmount: 160.71, Status: data.SubOrderStatusCompleted},
+			{BaseEntity: data.BaseEntity{ID: 2}, Price: 1200, TaxMode: data.TaxModeExclusive, TaxAmount: 144, Status: data.SubOrderStatusCompleted},
+		},
+	}
+
+	t.Run("Partial refund should include the exclusive tax only", func(t *testing.T) {
+		assert.Equal(t, 1344.0, CalculateRefundableAmount(order, order.Suborders[1:]))
+		assert.Equal(t, 1500.0, CalculateRefundableAmount(order, order.Suborders[:1]))
+	})
+
+	t.Run("Full refund should return the paid total", func(t *testing.T) {
+		// 1500 + 1200 + 144 tax + 500 fee - 200 bonuses
+		assert.Equal(t, 3144.0, CalculateRefundableAmount(order, order.Suborders))
+	})
+
+	t.Run("Refund of the last suborders should return the delivery fee", func(t *testing.T) {
+		partlyRefunded := *order
+		partlyRefunded.Suborders = []data.Suborder{order.Suborders[0], order.Suborders[1]}
+		partlyRefunded.Suborders[0].Status = data.SubOrderStatusRefunded
+
+		assert.Equal(t, 1644.0, CalculateRefundableAmount(&partlyRefunded, partlyRefunded.Suborders[1:]))
+	})
+}
+
+func TestIsLastRefund(t *testing.T) {
+	order := &data.Order{
+		Suborders: []data.Suborder{
+			{BaseEntity: data.BaseEntity{ID: 1}, Status: data.SubOrderStatusCompleted},
+			{BaseEntity: data.BaseEntity{ID: 2}, Status: data.SubOrderStatusRefunded},
+			{BaseEntity: data.BaseEntity{ID: 3}, Status: data.SubOrderStatusCancelled},
+			{BaseEntity: data.BaseEntity{ID: 4}, Status: data.SubOrderStatusCompleted},
+		},
+	}
+
+	t.Run("Refund of the remaining suborders should be the last one", func(t *testing.T) {
+		assert.True(t, IsLastRefund(order, []data.Suborder{order.Suborders[0], order.Suborders[3]}))
+	})
+
+	t.Run("Refund leaving a suborder should not be the last one", func(t *testing.T) {
+		assert.False(t, IsLastRefund(order, order.Suborders[:1]))
+	})
+}
+
+func TestCalculateNetPaidAmount(t *testing.T) {
+	// 1500 + 1200 + 144 tax + 500 fee - 200 bonuses
+	order := &data.Order{Total: 3144, DeliveryFee: 500, BonusesRedeemed: 200}
+	suborders := []data.Suborder{
+		{Price: 1500, TaxMode: data.TaxModeInclusive, TaxAmount: 160.71, Status: data.SubOrderStatusCompleted},
+		{Price: 1200, TaxMode: data.TaxModeExclusive, TaxAmount: 144, Status: data.SubOrderStatusCompleted},
+	}
+
+	t.Run("Completed order should exclude the delivery fee", func(t *testing.T) {
+		assert.Equal(t, 2644.0, CalculateNetPaidAmount(order, suborders))
+	})
+
+	t.Run("Refunded and cancelled suborders should be excluded", func(t *testing.T) {
+		partlyRefunded := []data.Suborder{suborders[0], suborders[1]}
+		partlyRefunded[1].Status = data.SubOrderStatusRefunded
+		assert.Equal(t, 1300.0, CalculateNetPaidAmount(order, partlyRefunded))
+
+		partlyRefunded[0].Status = data.SubOrderStatusCancelled
+		assert.Equal(t, 0.0, CalculateNetPaidAmount(order, partlyRefunded))
+	})
+}
@@ -18,7 +18,46 @@ var (
 	Response201Order               = localization.NewResponseKey(http.StatusCreated, data.OrderComponent)
 	Response200OrderPaymentSuccess = localization.NewResponseKey(http.StatusOK, data.OrderComponent, "payment", "success")
 	Response200OrderPaymentFail    = localization.NewResponseKey(http.StatusOK, data.OrderComponent, "payment", "fail")
-	Response200OrderUpdate         = localization.NewResponseKey(http.StatusOK, data.OrderComponent, data.UpdateOperation.ToString())
-	Response409InsufficientStock   = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "INSUFFICIENT_STOCK")
-	Response400MultipleSelect      = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "MULTIPLE_SELECT")
+
+	Response409OrderPaymentIntent = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "payment", "INTENT")
+	Response500OrderPaymentIntent = localization.NewResponseKey(http.StatusInternalServerError, data.OrderComponent, "payment", "INTENT")
+	Response400PaymentWebhook     = localization.NewResponseKey(http.StatusBadRequest, data.PaymentComponent, "webhook")
+	Response401PaymentWebhook     = localization.NewResponseKey(http.StatusUnauthorized, data.PaymentComponent, "webhook")
+	Response404PaymentWebhook     = localization.NewResponseKey(http.StatusNotFound, data.PaymentComponent, "webhook")
+	Response500PaymentWebhook     = localization.NewResponseKey(http.StatusInternalServerError, data.PaymentComponent, "webhook")
+	Response200PaymentWebhook     = localization.NewResponseKey(http.StatusOK, data.PaymentComponent, "webhook")
+
+	Response200OrderUpdate       = localization.NewResponseKey(http.StatusOK, data.OrderComponent, data.UpdateOperation.ToString())
+	Response409InsufficientStock = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "INSUFFICIENT_STOCK")
+	Response400MultipleSelect    = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "MULTIPLE_SELECT")
+	Response400OrderBonuses      = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "BONUSES")
+	Response409OrderBonuses      = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "INSUFFICIENT_BONUSES")
+	Response403OrderCustomer     = localization.NewResponseKey(http.StatusForbidden, data.OrderComponent, "CUSTOMER_NOT_VERIFIED")
+
+	Response404OrderStatusBoard  = localization.NewResponseKey(http.StatusNotFound, data.OrderComponent, "STATUS_BOARD")
+	Response500OrderStatusBoard  = localization.NewResponseKey(http.StatusInternalServerError, data.OrderComponent, "STATUS_BOARD")
+	Response400OrderStore        = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "pickup", "STORE")
+	Response409OrderStoreClose   = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "STORE_CLOSED")
+	Response400OrderPickupSlot   = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "pickup", "SLOT")
+	Response409OrderPickupFull   = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "pickup", "SLOT_FULL")
+	Response500OrderRefund       = localization.NewResponseKey(http.StatusInternalServerError, data.OrderComponent, "refund")
+	Response200OrderRefund       = localization.NewResponseKey(http.StatusOK, data.OrderComponent, "refund")
+	Response409OrderRefundStatus = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "refund", "STATUS")
+	Response400OrderRefundAmount = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "refund", "AMOUNT")
+
+	Response400OrderDelivery         = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "delivery")
+	Response400OrderDeliveryDistance = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "delivery", "DISTANCE")
+	Response400OrderCourier          = localization.NewResponseKey(http.StatusBadRequest, data.OrderComponent, "courier")
+	Response409OrderDeliveryStatus   = localization.NewResponseKey(http.StatusConflict, data.OrderComponent, "delivery", "STATUS")
+	Response500OrderDelivery         = localization.NewResponseKey(http.StatusInternalServerError, data.OrderComponent, "delivery")
+	Response200OrderCourier          = localization.NewResponseKey(http.StatusOK, data.OrderComponent, "courier")
+	Response200OrderDelivered        = localization.NewResponseKey(http.StatusOK, data.OrderComponent, "delivery")
+
+	Response500OrderCancellation         = localization.NewResponseKey(http.StatusInternalServerError, data.OrderCancellationComponent)
+	Response400OrderCancellation         = localization.NewResponseKey(http.StatusBadRequest, data.OrderCancellationComponent)
+	Response400OrderCancellationReason   = localization.NewResponseKey(http.StatusBadRequest, data.OrderCancellationComponent, "REASON")
+	Response404OrderCancellation         = localization.NewResponseKey(http.StatusNotFound, data.OrderCancellationComponent)
+	Response409OrderCancellationStatus   = localization.NewResponseKey(http.StatusConflict, data.OrderCancellationComponent, "STATUS")
+	Response409OrderCancellationPending  = localization.NewResponseKey(http.StatusConflict, data.OrderCancellationComponent, "PENDING")
+	Response409OrderCancellationReviewed = localization.NewResponseKey(http.StatusConflict, data.OrderCancellationComponent, "REVIEWED")
 )
@@ -0,0 +1,23 @@
+package types
+
+// FilterOrderByStation keeps only the suborders routed to the station, the order is skipped when the station has nothing to prepare in it.
+// Without a station the whole order is returned
+func FilterOrderByStation(order OrderDTO, stationID *uint) (OrderDTO, bool) {
+	if stationID == nil {
+		return order, true
+	}
+
+	suborders := make([]SuborderDTO, 0, len(order.Suborders))
+	for _, suborder := range order.Suborders {
+		if suborder.StationID != nil && *suborder.StationID == *stationID {
+			suborders = append(suborders, suborder)
+		}
+	}
+
+	if len(suborders) == 0 {
+		return order, false
+	}
+
+	order.Suborders = suborders
+	return order, true
+}
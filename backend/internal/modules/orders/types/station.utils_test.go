@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterOrderByStation(t *testing.T) {
+	barID, kitchenID, dessertID := uint(1), uint(2), uint(3)
+	order := OrderDTO{
+		ID: 10,
+		Suborders: []SuborderDTO{
+			{ID: 1, StationID: &barID},
+			{ID: 2, StationID: &kitchenID},
+			{ID: 3, StationID: &barID},
+			{ID: 4},
+		},
+	}
+
+	tests := []struct {
+		description       string
+		stationID         *uint
+		expectedOk        bool
+		expectedSuborders []uint
+	}{
+		{
+			description:       "Without a station the whole order should be kept",
+			expectedOk:        true,
+			expectedSuborders: []uint{1, 2, 3, 4},
+		},
+		{
+			description:       "Station should get only its suborders",
+			stationID:         &barID,
+			expectedOk:        true,
+			expectedSuborders: []uint{1, 3},
+		},
+		{
+			description: "Order should be skipped when the station has nothing to prepare",
+			stationID:   &dessertID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			filtered, ok := FilterOrderByStation(order, tc.stationID)
+			assert.Equal(t, tc.expectedOk, ok)
+			if !ok {
+				return
+			}
+
+			ids := make([]uint, 0, len(filtered.Suborders))
+			for _, suborder := range filtered.Suborders {
+				ids = append(ids, suborder.ID)
+			}
+			assert.Equal(t, tc.expectedSuborders, ids)
+		})
+	}
+
+	assert.Len(t, order.Suborders, 4, "source order should not be changed")
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// StatusBoardReadyPeriod is how long the completed order stays in the ready column of the status board
+const StatusBoardReadyPeriod = 10 * time.Minute
+
+// statusBoardVisibleRunes is how many first letters of the customer name are shown on the status board
+const statusBoardVisibleRunes = 2
+
+type StatusBoardOrderDTO struct {
+	DisplayNumber    int        `json:"displayNumber"`
+	CustomerName     string     `json:"customerName"`
+	EstimatedReadyAt *time.Time `json:"estimatedReadyAt,omitempty"`
+	ReadyAt          *time.Time `json:"readyAt,omitempty"`
+}
+
+type StatusBoardDTO struct {
+	StoreID   uint                  `json:"storeId"`
+	Preparing []StatusBoardOrderDTO `json:"preparing"`
+	Ready     []StatusBoardOrderDTO `json:"ready"`
+}
+
+type OrderReadyNotificationDTO struct {
+	OrderID       uint      `json:"orderId"`
+	DisplayNumber int       `json:"displayNumber"`
+	StoreID       uint      `json:"storeId"`
+	StoreName     string    `json:"storeName"`
+	ReadyAt       time.Time `json:"readyAt"`
+}
+
+// MaskCustomerName leaves only the first letters of the first word of the name for the public board,
+// the names rejected by the censor are not shown at all
+func MaskCustomerName(name string, validateText func(text string) error) string {
+	sanitized, ok := utils.SanitizeString(name)
+	if !ok || validateText(sanitized) != nil {
+		return ""
+	}
+
+	firstWord := []rune(strings.Fields(sanitized)[0])
+	visible := statusBoardVisibleRunes
+	if len(firstWord) <= visible {
+		visible = 1
+	}
+
+	return string(firstWord[:visible]) + strings.Repeat("*", len(firstWord)-visible)
+}
+
+// ConvertOrdersToStatusBoard splits the orders into the preparing and ready columns, other orders are skipped
+func ConvertOrdersToStatusBoard(storeID uint, orders []data.Order, validateText func(text string) error) StatusBoardDTO {
+	board := StatusBoardDTO{
+		StoreID:   storeID,
+		Preparing: []StatusBoardOrderDTO{},
+		Ready:     []StatusBoardOrderDTO{},
+	}
+
+	for _, order := range orders {
+		dto := StatusBoardOrderDTO{
+			DisplayNumber: order.DisplayNumber,
+			CustomerName:  MaskCustomerName(order.CustomerName, validateText),
+		}
+
+		switch order.Status {
+		case data.OrderStatusPending, data.OrderStatusPreparing:
+			dto.EstimatedReadyAt = order.EstimatedReadyAt
+			board.Preparing = append(board.Preparing, dto)
+		case data.OrderStatusCompleted:
+			dto.ReadyAt = order.CompletedAt
+			board.Ready = append(board.Ready, dto)
+		}
+	}
+
+	return board
+}
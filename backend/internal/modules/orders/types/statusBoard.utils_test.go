@@ -0,0 +1,56 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskCustomerName(t *testing.T) {
+	validateText := func(text string) error {
+		if text == "Badword" {
+			return errors.New("inappropriate name")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		description string
+		name        string
+		expected    string
+	}{
+		{description: "Only first letters of the name should be shown", name: "Aigerim", expected: "Ai*****"},
+		{description: "Only the first word should be kept", name: "  Данияр   Ахметов ", expected: "Да****"},
+		{description: "Short name should keep one letter", name: "Al", expected: "A*"},
+		{description: "Empty name should stay empty", name: "   ", expected: ""},
+		{description: "Censored name should be hidden", name: "Badword", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MaskCustomerName(tc.name, validateText))
+		})
+	}
+}
+
+func TestConvertOrdersToStatusBoard(t *testing.T) {
+	orders := []data.Order{
+		{DisplayNumber: 1, CustomerName: "Aigerim", Status: data.OrderStatusPending},
+		{DisplayNumber: 2, CustomerName: "Bolat", Status: data.OrderStatusCompleted},
+		{DisplayNumber: 3, CustomerName: "Timur", Status: data.OrderStatusPreparing},
+		{DisplayNumber: 4, CustomerName: "Dana", Status: data.OrderStatusCancelled},
+	}
+
+	board := ConvertOrdersToStatusBoard(7, orders, func(string) error { return nil })
+
+	assert.Equal(t, uint(7), board.StoreID)
+	assert.Equal(t, []StatusBoardOrderDTO{
+		{DisplayNumber: 1, CustomerName: "Ai*****"},
+		{DisplayNumber: 3, CustomerName: "Ti***"},
+	}, board.Preparing)
+	assert.Equal(t, []StatusBoardOrderDTO{
+		{DisplayNumber: 2, CustomerName: "Bo***"},
+	}, board.Ready)
+}
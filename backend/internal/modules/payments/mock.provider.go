@@ -0,0 +1,199 @@
+package payments
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+const (
+	mockBin      = "000000000000"
+	mockCardMask = "400000******0002"
+)
+
+// MockProvider is an in-process acquirer which keeps the payments in memory.
+// It is meant for tests and local development: Approve and Decline produce the webhooks a real acquirer would send.
+type MockProvider struct {
+	secret    string
+	tolerance time.Duration
+
+	mu           sync.Mutex
+	intents      map[string]*mockIntent
+	transactions map[string]*types.TransactionDTO
+	refunded     map[string]float64
+}
+
+type mockIntent struct {
+	amount        float64
+	currency      string
+	transactionID string
+	captured      bool
+}
+
+func NewMockProvider(cfg *config.PaymentConfig) *MockProvider {
+	return &MockProvider{
+		secret:       cfg.SecretKey,
+		tolerance:    cfg.WebhookTolerance,
+		intents:      make(map[string]*mockIntent),
+		transactions: make(map[string]*types.TransactionDTO),
+		refunded:     make(map[string]float64),
+	}
+}
+
+func (p *MockProvider) Name() data.PaymentProviderName {
+	return data.PaymentProviderMock
+}
+
+func (p *MockProvider) CreateIntent(req *types.IntentRequest) (*types.Intent, error) {
+	externalID, err := generateExternalID("mock_")
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.intents[externalID] = &mockIntent{
+		amount:   req.Amount,
+		currency: req.Currency,
+	}
+
+	return &types.Intent{ExternalID: externalID}, nil
+}
+
+// Approve authorizes the intent and returns the signed PAYMENT_SUCCEEDED webhook
+func (p *MockProvider) Approve(externalID string) ([]byte, *types.WebhookHeaders, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[externalID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, nil, types.ErrPaymentIntentNotFound
+	}
+
+	if intent.transactionID == "" {
+		transactionID, err := generateExternalID("")
+		if err != nil {
+			p.mu.Unlock()
+			return nil, nil, err
+		}
+		intent.transactionID = transactionID[:20]
+		p.transactions[intent.transactionID] = p.newTransaction(intent.transactionID, intent.amount, intent.currency)
+	}
+	transaction := *p.transactions[intent.transactionID]
+	p.mu.Unlock()
+
+	return p.signEvent(&types.WebhookEvent{
+		Type:        types.WebhookEventPaymentSucceeded,
+		IntentID:    externalID,
+		Transaction: &transaction,
+	})
+}
+
+// Decline returns the signed PAYMENT_FAILED webhook of the intent
+func (p *MockProvider) Decline(externalID string) ([]byte, *types.WebhookHeaders, error) {
+	p.mu.Lock()
+	_, ok := p.intents[externalID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, nil, types.ErrPaymentIntentNotFound
+	}
+
+	return p.signEvent(&types.WebhookEvent{
+		Type:     types.WebhookEventPaymentFailed,
+		IntentID: externalID,
+	})
+}
+
+func (p *MockProvider) Capture(intent *data.PaymentIntent, _ *types.TransactionDTO) (*types.TransactionDTO, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stored, ok := p.intents[intent.ExternalID]
+	if !ok {
+		return nil, types.ErrPaymentIntentNotFound
+	}
+	if stored.transactionID == "" {
+		return nil, types.ErrPaymentNotAuthorized
+	}
+
+	stored.captured = true
+	transaction := *p.transactions[stored.transactionID]
+	return &transaction, nil
+}
+
+func (p *MockProvider) Refund(payment *data.Transaction, req *types.RefundRequest) (*types.TransactionDTO, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	captured, ok := p.transactions[payment.TransactionID]
+	if !ok {
+		return nil, types.ErrRefundNotAllowed
+	}
+
+	left := captured.Amount - p.refunded[payment.TransactionID]
+	if req.Amount <= 0 || math.Round(req.Amount*100) > math.Round(left*100) {
+		return nil, types.ErrRefundNotAllowed
+	}
+	refundID, err := generateExternalID("")
+	if err != nil {
+		return nil, err
+	}
+	refundID = refundID[:20]
+	p.refunded[payment.TransactionID] = utils.RoundToDecimal(p.refunded[payment.TransactionID]+req.Amount, 2)
+
+	refund := p.newTransaction(refundID, req.Amount, captured.Currency)
+	p.transactions[refundID] = refund
+
+	result := *refund
+	return &result, nil
+}
+
+func (p *MockProvider) VerifyWebhook(payload []byte, headers *types.WebhookHeaders) (*types.WebhookEvent, error) {
+	if err := types.VerifyWebhookSignature(p.secret, payload, headers, p.tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	return types.ParseWebhookEvent(payload)
+}
+
+func (p *MockProvider) Reconcile(transaction *data.Transaction) (data.TransactionReconciliationStatus, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stored, ok := p.transactions[transaction.TransactionID]
+	if !ok {
+		return data.TransactionReconciliationStatusNotFound, nil
+	}
+
+	if math.Round(stored.Amount*100) != math.Round(transaction.Amount*100) || stored.Currency != transaction.Currency {
+		return data.TransactionReconciliationStatusMismatched, nil
+	}
+
+	return data.TransactionReconciliationStatusMatched, nil
+}
+
+func (p *MockProvider) signEvent(event *types.WebhookEvent) ([]byte, *types.WebhookHeaders, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal mock webhook: %w", err)
+	}
+	return payload, types.SignWebhook(p.secret, time.Now(), payload), nil
+}
+
+func (p *MockProvider) newTransaction(transactionID string, amount float64, currency string) *types.TransactionDTO {
+	cardMask := mockCardMask
+	return &types.TransactionDTO{
+		Bin:           mockBin,
+		TransactionID: transactionID,
+		PaymentMethod: "CARD",
+		Amount:        amount,
+		Currency:      currency,
+		CardMask:      &cardMask,
+	}
+}
@@ -0,0 +1,108 @@
+package payments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockProviderPaymentFlow(t *testing.T) {
+	provider := NewMockProvider(&config.PaymentConfig{SecretKey: "secret", WebhookTolerance: time.Minute})
+
+	intent, err := provider.CreateIntent(&types.IntentRequest{OrderID: 1, Amount: 1500, Currency: "KZT"})
+	require.NoError(t, err)
+
+	paymentIntent := &data.PaymentIntent{OrderID: 1, ExternalID: intent.ExternalID, Amount: 1500, Currency: "KZT"}
+
+	_, err = provider.Capture(paymentIntent, nil)
+	assert.ErrorIs(t, err, types.ErrPaymentNotAuthorized, "Capture should fail before the approval")
+
+	payload, headers, err := provider.Approve(intent.ExternalID)
+	require.NoError(t, err)
+
+	event, err := provider.VerifyWebhook(payload, headers)
+	require.NoError(t, err)
+	assert.Equal(t, types.WebhookEventPaymentSucceeded, event.Type)
+	assert.Equal(t, intent.ExternalID, event.IntentID)
+
+	_, err = provider.VerifyWebhook(append(payload, ' '), headers)
+	assert.ErrorIs(t, err, types.ErrInvalidWebhookSignature, "Tampered payload should be rejected")
+
+	transaction, err := provider.Capture(paymentIntent, event.Transaction)
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, transaction.Amount)
+
+	payment := &data.Transaction{TransactionID: transaction.TransactionID, Amount: transaction.Amount, Currency: transaction.Currency}
+	status, err := provider.Reconcile(payment)
+	require.NoError(t, err)
+	assert.Equal(t, data.TransactionReconciliationStatusMatched, status)
+
+	payment.Amount = 1000
+	status, err = provider.Reconcile(payment)
+	require.NoError(t, err)
+	assert.Equal(t, data.TransactionReconciliationStatusMismatched, status)
+	payment.Amount = 1500
+
+	_, err = provider.Refund(payment, &types.RefundRequest{Amount: 1000})
+	require.NoError(t, err)
+	_, err = provider.Refund(payment, &types.RefundRequest{Amount: 600})
+	assert.ErrorIs(t, err, types.ErrRefundNotAllowed, "Refunds should not exceed the captured amount")
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"event":"PAYMENT_FAILED","intentId":"pos_1"}`)
+	now := time.Now()
+
+	tests := []struct {
+		description string
+		headers     *types.WebhookHeaders
+		expectedErr error
+	}{
+		{
+			description: "Valid signature should be accepted",
+			headers:     types.SignWebhook("secret", now, payload),
+		},
+		{
+			description: "Signature with another secret should be rejected",
+			headers:     types.SignWebhook("another", now, payload),
+			expectedErr: types.ErrInvalidWebhookSignature,
+		},
+		{
+			description: "Outdated signature should be rejected",
+			headers:     types.SignWebhook("secret", now.Add(-time.Hour), payload),
+			expectedErr: types.ErrInvalidWebhookSignature,
+		},
+		{
+			description: "Missing headers should be rejected",
+			headers:     &types.WebhookHeaders{},
+			expectedErr: types.ErrInvalidWebhookSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := types.VerifyWebhookSignature("secret", payload, test.headers, 5*time.Minute, now)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPaymentProvidersReconcilers(t *testing.T) {
+	cfg := &config.PaymentConfig{Provider: string(data.PaymentProviderMock), SecretKey: "secret"}
+
+	providers, err := NewPaymentProvidersFromConfig(cfg)
+	require.NoError(t, err)
+
+	reconcilers := providers.Reconcilers()
+	assert.Contains(t, reconcilers, data.PaymentProviderMock)
+	assert.NotContains(t, reconcilers, data.PaymentProviderPOS, "POS terminals have no remote record to reconcile against")
+}
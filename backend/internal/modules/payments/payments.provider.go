@@ -0,0 +1,95 @@
+package payments
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
+)
+
+// PaymentProvider is an acquirer the orders are paid through. The order is paid only after
+// the provider confirms the intent by a signed webhook, the client can not report the payment by itself.
+type PaymentProvider interface {
+	Name() data.PaymentProviderName
+
+	CreateIntent(req *types.IntentRequest) (*types.Intent, error)
+	Capture(intent *data.PaymentIntent, transaction *types.TransactionDTO) (*types.TransactionDTO, error)
+	Refund(payment *data.Transaction, req *types.RefundRequest) (*types.TransactionDTO, error)
+
+	VerifyWebhook(payload []byte, headers *types.WebhookHeaders) (*types.WebhookEvent, error)
+}
+
+// TransactionReconciler is implemented by the providers having a remote record of the transactions,
+// the providers without it are left out of the reconciliation
+type TransactionReconciler interface {
+	Reconcile(transaction *data.Transaction) (data.TransactionReconciliationStatus, error)
+}
+
+type PaymentProviders struct {
+	providers   map[data.PaymentProviderName]PaymentProvider
+	defaultName data.PaymentProviderName
+}
+
+func NewPaymentProviders(defaultName data.PaymentProviderName, providers ...PaymentProvider) (*PaymentProviders, error) {
+	registry := &PaymentProviders{
+		providers:   make(map[data.PaymentProviderName]PaymentProvider, len(providers)),
+		defaultName: defaultName,
+	}
+
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+	}
+
+	if _, ok := registry.providers[defaultName]; !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrUnknownPaymentProvider, defaultName)
+	}
+
+	return registry, nil
+}
+
+// NewPaymentProvidersFromConfig registers the POS provider, the mock provider is registered only when it is the configured one
+func NewPaymentProvidersFromConfig(cfg *config.PaymentConfig) (*PaymentProviders, error) {
+	name := data.PaymentProviderName(cfg.Provider)
+
+	providers := []PaymentProvider{NewPOSProvider(cfg)}
+	if name == data.PaymentProviderMock {
+		providers = append(providers, NewMockProvider(cfg))
+	}
+
+	return NewPaymentProviders(name, providers...)
+}
+
+// Default returns the provider new payments are created with
+func (r *PaymentProviders) Default() PaymentProvider {
+	return r.providers[r.defaultName]
+}
+
+func (r *PaymentProviders) Get(name data.PaymentProviderName) (PaymentProvider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, types.ErrUnknownPaymentProvider
+	}
+	return provider, nil
+}
+
+// Reconcilers returns the registered providers supporting the reconciliation
+func (r *PaymentProviders) Reconcilers() map[data.PaymentProviderName]TransactionReconciler {
+	reconcilers := make(map[data.PaymentProviderName]TransactionReconciler)
+	for name, provider := range r.providers {
+		if reconciler, ok := provider.(TransactionReconciler); ok {
+			reconcilers[name] = reconciler
+		}
+	}
+	return reconcilers
+}
+
+func generateExternalID(prefix string) (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate payment intent id: %w", err)
+	}
+	return prefix + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,72 @@
+package payments
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/config"
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/payments/types"
+)
+
+// posProvider serves the card and QR payments made on the kiosk POS terminals.
+// The terminal charges and refunds the card itself, the terminal integration reports the result by a signed webhook.
+// The terminals have no remote API, so their transactions are not reconciled: they are settled by the bank reports
+type posProvider struct {
+	secret    string
+	tolerance time.Duration
+}
+
+func NewPOSProvider(cfg *config.PaymentConfig) PaymentProvider {
+	return &posProvider{
+		secret:    cfg.SecretKey,
+		tolerance: cfg.WebhookTolerance,
+	}
+}
+
+func (p *posProvider) Name() data.PaymentProviderName {
+	return data.PaymentProviderPOS
+}
+
+func (p *posProvider) CreateIntent(req *types.IntentRequest) (*types.Intent, error) {
+	externalID, err := generateExternalID("pos_")
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Intent{
+		ExternalID: externalID,
+		Details: map[string]string{
+			"amount":   strconv.FormatFloat(req.Amount, 'f', 2, 64),
+			"currency": req.Currency,
+		},
+	}, nil
+}
+
+// Capture accepts the terminal transaction, the terminal captures the payment right after the authorization
+func (p *posProvider) Capture(intent *data.PaymentIntent, transaction *types.TransactionDTO) (*types.TransactionDTO, error) {
+	if transaction == nil {
+		return nil, types.ErrPaymentNotAuthorized
+	}
+
+	if math.Round(transaction.Amount*100) != math.Round(intent.Amount*100) {
+		return nil, types.ErrPaymentAmountMismatch
+	}
+
+	return transaction, nil
+}
+
+func (p *posProvider) Refund(_ *data.Transaction, req *types.RefundRequest) (*types.TransactionDTO, error) {
+	if req.Transaction == nil {
+		return nil, types.ErrRefundNotAllowed
+	}
+	return req.Transaction, nil
+}
+
+func (p *posProvider) VerifyWebhook(payload []byte, headers *types.WebhookHeaders) (*types.WebhookEvent, error) {
+	if err := types.VerifyWebhookSignature(p.secret, payload, headers, p.tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	return types.ParseWebhookEvent(payload)
+}
@@ -0,0 +1,17 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrUnknownPaymentProvider  = moduleErrors.NewModuleError(errors.New("unknown payment provider"))
+	ErrInvalidWebhookSignature = moduleErrors.NewModuleError(errors.New("invalid webhook signature"))
+	ErrInvalidWebhookEvent     = moduleErrors.NewModuleError(errors.New("invalid webhook event"))
+	ErrPaymentIntentNotFound   = moduleErrors.NewModuleError(errors.New("payment intent not found"))
+	ErrPaymentNotAuthorized    = moduleErrors.NewModuleError(errors.New("payment is not authorized by the provider"))
+	ErrPaymentAmountMismatch   = moduleErrors.NewModuleError(errors.New("paid amount does not match the payment intent"))
+	ErrRefundNotAllowed        = moduleErrors.NewModuleError(errors.New("refund is not allowed for the payment"))
+)
@@ -0,0 +1,54 @@
+package types
+
+type IntentRequest struct {
+	OrderID  uint
+	Amount   float64
+	Currency string
+}
+
+type Intent struct {
+	ExternalID string
+	// Details are passed to the client to complete the payment, e.g. the amount to charge on the terminal
+	Details map[string]string
+}
+
+type RefundRequest struct {
+	Amount float64
+	// Transaction holds the refund reported by the terminal for the providers which do not refund remotely
+	Transaction *TransactionDTO
+}
+
+type TransactionDTO struct {
+	Bin           string  `json:"bin" binding:"required,len=12"`
+	TransactionID string  `json:"transactionId" binding:"required,max=20"`
+	ProcessID     *string `json:"processId" binding:"omitempty,max=20"`
+	PaymentMethod string  `json:"paymentMethod" binding:"required"`
+	Amount        float64 `json:"amount" binding:"required,gt=0"`
+	Currency      string  `json:"currency" binding:"required,len=3"`
+	QRNumber      *string `json:"qrNumber" binding:"omitempty,min=7,max=16"`
+	CardMask      *string `json:"cardMask" binding:"omitempty,len=16"`
+	ICC           *string `json:"icc" binding:"omitempty"`
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventPaymentSucceeded WebhookEventType = "PAYMENT_SUCCEEDED"
+	WebhookEventPaymentFailed    WebhookEventType = "PAYMENT_FAILED"
+)
+
+type WebhookEvent struct {
+	Type        WebhookEventType `json:"event"`
+	IntentID    string           `json:"intentId"`
+	Transaction *TransactionDTO  `json:"transaction,omitempty"`
+}
+
+const (
+	WebhookSignatureHeader = "X-Payment-Signature"
+	WebhookTimestampHeader = "X-Payment-Timestamp"
+)
+
+type WebhookHeaders struct {
+	Signature string
+	Timestamp string
+}
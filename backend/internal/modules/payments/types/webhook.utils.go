@@ -0,0 +1,73 @@
+package types
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// SignWebhook signs the "<unix timestamp>.<payload>" string with HMAC-SHA256, the timestamp protects against replays
+func SignWebhook(secret string, timestamp time.Time, payload []byte) *WebhookHeaders {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return &WebhookHeaders{
+		Signature: computeSignature(secret, ts, payload),
+		Timestamp: ts,
+	}
+}
+
+func VerifyWebhookSignature(secret string, payload []byte, headers *WebhookHeaders, tolerance time.Duration, now time.Time) error {
+	if headers == nil || headers.Signature == "" || headers.Timestamp == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	unix, err := strconv.ParseInt(headers.Timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	if tolerance > 0 && math.Abs(now.Sub(time.Unix(unix, 0)).Seconds()) > tolerance.Seconds() {
+		return ErrInvalidWebhookSignature
+	}
+
+	expected := computeSignature(secret, headers.Timestamp, payload)
+	if !hmac.Equal([]byte(expected), []byte(headers.Signature)) {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, ErrInvalidWebhookEvent
+	}
+
+	switch event.Type {
+	case WebhookEventPaymentSucceeded:
+		if event.Transaction == nil {
+			return nil, ErrInvalidWebhookEvent
+		}
+	case WebhookEventPaymentFailed:
+	default:
+		return nil, ErrInvalidWebhookEvent
+	}
+
+	if event.IntentID == "" {
+		return nil, ErrInvalidWebhookEvent
+	}
+
+	return &event, nil
+}
+
+func computeSignature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
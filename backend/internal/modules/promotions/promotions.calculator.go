@@ -0,0 +1,150 @@
+package promotions
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// calculateDiscounts applies the most profitable running promotion to every item, promotions are never stacked.
+// The happy hour windows are the local time of the store location
+func calculateDiscounts(promotions []data.Promotion, items []types.DiscountableItem, now time.Time, location *time.Location) []types.AppliedDiscount {
+	best := make(map[int]types.AppliedDiscount)
+
+	for i := range promotions {
+		promotion := &promotions[i]
+		if !isPromotionRunning(promotion, now, location) {
+			continue
+		}
+
+		matched := matchPromotionItems(promotion, items)
+		if len(matched) == 0 {
+			continue
+		}
+
+		for _, discount := range promotionDiscounts(promotion, matched) {
+			if discount.Amount <= 0 {
+				continue
+			}
+			if current, ok := best[discount.Index]; !ok || discount.Amount > current.Amount {
+				best[discount.Index] = discount
+			}
+		}
+	}
+
+	discounts := make([]types.AppliedDiscount, 0, len(best))
+	for _, discount := range best {
+		discounts = append(discounts, discount)
+	}
+	sort.Slice(discounts, func(i, j int) bool {
+		return discounts[i].Index < discounts[j].Index
+	})
+
+	return discounts
+}
+
+func isPromotionRunning(promotion *data.Promotion, now time.Time, location *time.Location) bool {
+	if !promotion.IsActive {
+		return false
+	}
+	if promotion.StartsAt != nil && now.Before(*promotion.StartsAt) {
+		return false
+	}
+	if promotion.EndsAt != nil && now.After(*promotion.EndsAt) {
+		return false
+	}
+	if promotion.Type == data.PromotionTypeHappyHour {
+		return isWithinHappyHour(promotion, now.In(location))
+	}
+	return true
+}
+
+// isWithinHappyHour supports windows passing midnight, e.g. 22:00 - 02:00, now is expected in the store location
+func isWithinHappyHour(promotion *data.Promotion, now time.Time) bool {
+	if promotion.StartTime == nil || promotion.EndTime == nil {
+		return false
+	}
+
+	start, err := time.Parse(types.PromotionTimeLayout, *promotion.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(types.PromotionTimeLayout, *promotion.EndTime)
+	if err != nil {
+		return false
+	}
+
+	current := now.Hour()*60 + now.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from < to {
+		return current >= from && current < to
+	}
+	return current >= from || current < to
+}
+
+func matchPromotionItems(promotion *data.Promotion, items []types.DiscountableItem) []types.DiscountableItem {
+	var matched []types.DiscountableItem
+	for _, item := range items {
+		if promotion.ProductSizeID != nil && *promotion.ProductSizeID != item.ProductSizeID {
+			continue
+		}
+		if promotion.ProductCategoryID != nil && *promotion.ProductCategoryID != item.ProductCategoryID {
+			continue
+		}
+		matched = append(matched, item)
+	}
+	return matched
+}
+
+func promotionDiscounts(promotion *data.Promotion, items []types.DiscountableItem) []types.AppliedDiscount {
+	var discounts []types.AppliedDiscount
+
+	newDiscount := func(item types.DiscountableItem, amount float64) types.AppliedDiscount {
+		return types.AppliedDiscount{
+			Index:         item.Index,
+			PromotionID:   promotion.ID,
+			PromotionName: promotion.Name,
+			Type:          promotion.Type,
+			Amount:        utils.RoundToDecimal(amount, 2),
+		}
+	}
+
+	switch promotion.Type {
+	case data.PromotionTypePercentage, data.PromotionTypeHappyHour:
+		for _, item := range items {
+			discounts = append(discounts, newDiscount(item, item.Price*promotion.Value/100))
+		}
+
+	case data.PromotionTypeFixedAmount:
+		for _, item := range items {
+			discounts = append(discounts, newDiscount(item, min(promotion.Value, item.Price)))
+		}
+
+	case data.PromotionTypeBuyXGetY:
+		if promotion.BuyQuantity == nil || promotion.GetQuantity == nil {
+			return nil
+		}
+		buy, get := *promotion.BuyQuantity, *promotion.GetQuantity
+
+		// the most expensive items are paid, the cheapest of every complete group are free
+		sorted := make([]types.DiscountableItem, len(items))
+		copy(sorted, items)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Price > sorted[j].Price
+		})
+
+		groupSize := buy + get
+		for start := 0; start+groupSize <= len(sorted); start += groupSize {
+			for _, item := range sorted[start+buy : start+groupSize] {
+				discounts = append(discounts, newDiscount(item, item.Price))
+			}
+		}
+	}
+
+	return discounts
+}
@@ -0,0 +1,119 @@
+package promotions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateDiscounts(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+	uintPtr := func(v uint) *uint { return &v }
+
+	noon := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := noon.AddDate(0, 0, -1)
+
+	items := []types.DiscountableItem{
+		{Index: 0, ProductSizeID: 1, ProductCategoryID: 1, Price: 1000},
+		{Index: 1, ProductSizeID: 2, ProductCategoryID: 1, Price: 600},
+		{Index: 2, ProductSizeID: 3, ProductCategoryID: 2, Price: 400},
+	}
+
+	tests := []struct {
+		description string
+		promotions  []data.Promotion
+		expected    map[int]float64
+	}{
+		{
+			description: "Percentage promotion should discount every item",
+			promotions: []data.Promotion{
+				{Name: "10%", Type: data.PromotionTypePercentage, Value: 10, IsActive: true},
+			},
+			expected: map[int]float64{0: 100, 1: 60, 2: 40},
+		},
+		{
+			description: "The most profitable promotion should be picked without stacking",
+			promotions: []data.Promotion{
+				{Name: "10%", Type: data.PromotionTypePercentage, Value: 10, IsActive: true},
+				{Name: "150 off", Type: data.PromotionTypeFixedAmount, Value: 150, IsActive: true, ProductCategoryID: uintPtr(1)},
+			},
+			expected: map[int]float64{0: 150, 1: 150, 2: 40},
+		},
+		{
+			description: "Fixed amount should not exceed item price",
+			promotions: []data.Promotion{
+				{Name: "500 off", Type: data.PromotionTypeFixedAmount, Value: 500, IsActive: true, ProductSizeID: uintPtr(3)},
+			},
+			expected: map[int]float64{2: 400},
+		},
+		{
+			description: "Buy 2 get 1 should make the cheapest item free",
+			promotions: []data.Promotion{
+				{Name: "2+1", Type: data.PromotionTypeBuyXGetY, BuyQuantity: intPtr(2), GetQuantity: intPtr(1), IsActive: true},
+			},
+			expected: map[int]float64{2: 400},
+		},
+		{
+			description: "Buy X get Y should be skipped for incomplete groups",
+			promotions: []data.Promotion{
+				{Name: "2+1", Type: data.PromotionTypeBuyXGetY, BuyQuantity: intPtr(2), GetQuantity: intPtr(1), IsActive: true, ProductCategoryID: uintPtr(1)},
+			},
+			expected: map[int]float64{},
+		},
+		{
+			description: "Happy hour should work inside the daily window",
+			promotions: []data.Promotion{
+				{Name: "Lunch", Type: data.PromotionTypeHappyHour, Value: 50, IsActive: true, StartTime: strPtr("11:30"), EndTime: strPtr("13:00")},
+			},
+			expected: map[int]float64{0: 500, 1: 300, 2: 200},
+		},
+		{
+			description: "Happy hour should be ignored outside the daily window",
+			promotions: []data.Promotion{
+				{Name: "Night", Type: data.PromotionTypeHappyHour, Value: 50, IsActive: true, StartTime: strPtr("22:00"), EndTime: strPtr("02:00")},
+			},
+			expected: map[int]float64{},
+		},
+		{
+			description: "Inactive and expired promotions should be ignored",
+			promotions: []data.Promotion{
+				{Name: "Inactive", Type: data.PromotionTypePercentage, Value: 10, IsActive: false},
+				{Name: "Expired", Type: data.PromotionTypePercentage, Value: 10, IsActive: true, EndsAt: &yesterday},
+			},
+			expected: map[int]float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			discounts := calculateDiscounts(test.promotions, items, noon, time.UTC)
+
+			actual := make(map[int]float64, len(discounts))
+			for _, discount := range discounts {
+				actual[discount.Index] = discount.Amount
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestHappyHourStoreLocation(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+
+	// 12:00 UTC is 17:00 in Almaty
+	noon := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	almaty := time.FixedZone("Asia/Almaty", 5*60*60)
+	evening := &data.Promotion{Name: "Evening", Type: data.PromotionTypeHappyHour, IsActive: true, StartTime: strPtr("16:00"), EndTime: strPtr("18:00")}
+
+	t.Run("Happy hour should follow the local time of the store", func(t *testing.T) {
+		assert.True(t, isPromotionRunning(evening, noon, almaty))
+	})
+
+	t.Run("Happy hour should not follow the server time", func(t *testing.T) {
+		assert.False(t, isPromotionRunning(evening, noon, time.UTC))
+	})
+}
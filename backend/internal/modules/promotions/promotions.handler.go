@@ -0,0 +1,172 @@
+package promotions
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	service      PromotionService
+	auditService audit.AuditService
+}
+
+func NewPromotionHandler(service PromotionService, auditService audit.AuditService) *PromotionHandler {
+	return &PromotionHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var dto types.CreatePromotionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	promotion, err := h.service.CreatePromotion(&dto)
+	if err != nil {
+		if isPromotionValidationError(err) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400Promotion)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500PromotionCreate)
+		return
+	}
+
+	action := types.CreatePromotionAuditFactory(
+		&data.BaseDetails{
+			ID:   promotion.ID,
+			Name: promotion.Name,
+		})
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response201Promotion)
+}
+
+func (h *PromotionHandler) GetPromotionByID(c *gin.Context) {
+	promotionID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Promotion)
+		return
+	}
+
+	promotion, err := h.service.GetPromotionByID(promotionID)
+	if err != nil {
+		if errors.Is(err, types.ErrPromotionNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Promotion)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500PromotionGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, promotion)
+}
+
+func (h *PromotionHandler) GetPromotions(c *gin.Context) {
+	var filter types.PromotionsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.Promotion{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	promotions, err := h.service.GetPromotions(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PromotionGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, promotions, filter.Pagination)
+}
+
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	promotionID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Promotion)
+		return
+	}
+
+	var dto types.UpdatePromotionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	promotion, err := h.service.UpdatePromotion(promotionID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrPromotionNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Promotion)
+		case isPromotionValidationError(err):
+			localization.SendLocalizedResponseWithKey(c, types.Response400Promotion)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500PromotionUpdate)
+		}
+		return
+	}
+
+	action := types.UpdatePromotionAuditFactory(
+		&data.BaseDetails{
+			ID:   promotion.ID,
+			Name: promotion.Name,
+		}, &dto)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200PromotionUpdate)
+}
+
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	promotionID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Promotion)
+		return
+	}
+
+	promotion, err := h.service.GetPromotionByID(promotionID)
+	if err != nil {
+		if errors.Is(err, types.ErrPromotionNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Promotion)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500PromotionGet)
+		return
+	}
+
+	if err := h.service.DeletePromotion(promotionID); err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PromotionDelete)
+		return
+	}
+
+	action := types.DeletePromotionAuditFactory(
+		&data.BaseDetails{
+			ID:   promotion.ID,
+			Name: promotion.Name,
+		})
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200PromotionDelete)
+}
+
+func isPromotionValidationError(err error) bool {
+	return errors.Is(err, types.ErrInvalidPromotionType) ||
+		errors.Is(err, types.ErrInvalidPromotionValue) ||
+		errors.Is(err, types.ErrInvalidBuyXGetY) ||
+		errors.Is(err, types.ErrInvalidHappyHour) ||
+		errors.Is(err, types.ErrInvalidPromotionPeriod)
+}
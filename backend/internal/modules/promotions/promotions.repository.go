@@ -0,0 +1,121 @@
+package promotions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(promotion *data.Promotion) error
+	GetPromotionByID(id uint) (*data.Promotion, error)
+	GetPromotions(filter *types.PromotionsFilter) ([]data.Promotion, error)
+	SavePromotion(promotion *data.Promotion) error
+	DeletePromotion(id uint) error
+
+	GetActiveStorePromotions(storeID uint, at time.Time) ([]data.Promotion, error)
+	GetStoreTimezone(storeID uint) (string, error)
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) CreatePromotion(promotion *data.Promotion) error {
+	return r.db.Create(promotion).Error
+}
+
+func (r *promotionRepository) GetPromotionByID(id uint) (*data.Promotion, error) {
+	var promotion data.Promotion
+	if err := r.db.First(&promotion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch promotion with ID %d: %w", id, err)
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) GetPromotions(filter *types.PromotionsFilter) ([]data.Promotion, error) {
+	var promotions []data.Promotion
+
+	query := r.db.Model(&data.Promotion{})
+
+	if filter.Search != nil && *filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+*filter.Search+"%")
+	}
+
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	if filter.FranchiseeID != nil {
+		query = query.Where("franchisee_id = ?", *filter.FranchiseeID)
+	}
+
+	if filter.StoreID != nil {
+		query = query.Where("store_id = ?", *filter.StoreID)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.Promotion{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&promotions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch promotions: %w", err)
+	}
+
+	return promotions, nil
+}
+
+func (r *promotionRepository) SavePromotion(promotion *data.Promotion) error {
+	return r.db.Omit("Franchisee", "Store", "ProductCategory", "ProductSize").Save(promotion).Error
+}
+
+func (r *promotionRepository) DeletePromotion(id uint) error {
+	return r.db.Delete(&data.Promotion{}, id).Error
+}
+
+// GetActiveStorePromotions returns the promotions valid at the given moment for the store itself,
+// for its franchisee and the global ones. Happy hour windows are checked by the calculator.
+func (r *promotionRepository) GetActiveStorePromotions(storeID uint, at time.Time) ([]data.Promotion, error) {
+	var promotions []data.Promotion
+
+	err := r.db.
+		Where("is_active = ?", true).
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at >= ?", at).
+		Where("store_id IS NULL OR store_id = ?", storeID).
+		Where("franchisee_id IS NULL OR franchisee_id = (?)",
+			r.db.Model(&data.Store{}).Select("franchisee_id").Where("id = ?", storeID)).
+		Order("id").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active promotions of store %d: %w", storeID, err)
+	}
+
+	return promotions, nil
+}
+
+func (r *promotionRepository) GetStoreTimezone(storeID uint) (string, error) {
+	var store data.Store
+	err := r.db.Select("id", "timezone").Where("id = ?", storeID).First(&store).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the timezone of store %d: %w", storeID, err)
+	}
+	return store.Timezone, nil
+}
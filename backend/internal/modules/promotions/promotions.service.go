@@ -0,0 +1,126 @@
+package promotions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions/types"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+	"go.uber.org/zap"
+)
+
+type PromotionService interface {
+	CreatePromotion(dto *types.CreatePromotionDTO) (*types.PromotionDTO, error)
+	GetPromotionByID(id uint) (*types.PromotionDTO, error)
+	GetPromotions(filter *types.PromotionsFilter) ([]types.PromotionDTO, error)
+	UpdatePromotion(id uint, dto *types.UpdatePromotionDTO) (*types.PromotionDTO, error)
+	DeletePromotion(id uint) error
+
+	CalculateDiscounts(storeID uint, items []types.DiscountableItem) ([]types.AppliedDiscount, error)
+}
+
+type promotionService struct {
+	repo   PromotionRepository
+	logger *zap.SugaredLogger
+}
+
+func NewPromotionService(repo PromotionRepository, logger *zap.SugaredLogger) PromotionService {
+	return &promotionService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *promotionService) CreatePromotion(dto *types.CreatePromotionDTO) (*types.PromotionDTO, error) {
+	promotion := types.ConvertToPromotionModel(dto)
+	if err := types.ValidatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreatePromotion(promotion); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreatePromotion, err))
+		return nil, types.ErrFailedToCreatePromotion
+	}
+
+	response := types.ConvertToPromotionDTO(promotion)
+	return &response, nil
+}
+
+func (s *promotionService) GetPromotionByID(id uint) (*types.PromotionDTO, error) {
+	promotion, err := s.repo.GetPromotionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertToPromotionDTO(promotion)
+	return &response, nil
+}
+
+func (s *promotionService) GetPromotions(filter *types.PromotionsFilter) ([]types.PromotionDTO, error) {
+	promotions, err := s.repo.GetPromotions(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchPromotions, err))
+		return nil, types.ErrFailedToFetchPromotions
+	}
+
+	responses := make([]types.PromotionDTO, len(promotions))
+	for i := range promotions {
+		responses[i] = types.ConvertToPromotionDTO(&promotions[i])
+	}
+	return responses, nil
+}
+
+func (s *promotionService) UpdatePromotion(id uint, dto *types.UpdatePromotionDTO) (*types.PromotionDTO, error) {
+	promotion, err := s.repo.GetPromotionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	types.ApplyUpdatePromotionDTO(promotion, dto)
+	if err := types.ValidatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SavePromotion(promotion); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdatePromotion, err))
+		return nil, types.ErrFailedToUpdatePromotion
+	}
+
+	response := types.ConvertToPromotionDTO(promotion)
+	return &response, nil
+}
+
+func (s *promotionService) DeletePromotion(id uint) error {
+	if _, err := s.repo.GetPromotionByID(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePromotion(id); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToDeletePromotion, err))
+		return types.ErrFailedToDeletePromotion
+	}
+	return nil
+}
+
+func (s *promotionService) CalculateDiscounts(storeID uint, items []types.DiscountableItem) ([]types.AppliedDiscount, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	promotions, err := s.repo.GetActiveStorePromotions(storeID, now)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to calculate discounts: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	timezone, err := s.repo.GetStoreTimezone(storeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to calculate discounts: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return calculateDiscounts(promotions, items, now, storesTypes.StoreLocation(timezone)), nil
+}
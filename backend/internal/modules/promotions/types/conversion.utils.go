@@ -0,0 +1,101 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+func ConvertToPromotionModel(dto *CreatePromotionDTO) *data.Promotion {
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+
+	return &data.Promotion{
+		Name:              dto.Name,
+		Description:       dto.Description,
+		Type:              dto.Type,
+		Value:             dto.Value,
+		BuyQuantity:       dto.BuyQuantity,
+		GetQuantity:       dto.GetQuantity,
+		StartTime:         dto.StartTime,
+		EndTime:           dto.EndTime,
+		StartsAt:          dto.StartsAt,
+		EndsAt:            dto.EndsAt,
+		IsActive:          isActive,
+		FranchiseeID:      dto.FranchiseeID,
+		StoreID:           dto.StoreID,
+		ProductCategoryID: dto.ProductCategoryID,
+		ProductSizeID:     dto.ProductSizeID,
+	}
+}
+
+func ApplyUpdatePromotionDTO(promotion *data.Promotion, dto *UpdatePromotionDTO) {
+	if dto.Name != nil {
+		promotion.Name = *dto.Name
+	}
+	if dto.Description != nil {
+		promotion.Description = *dto.Description
+	}
+	if dto.Type != nil {
+		promotion.Type = *dto.Type
+	}
+	if dto.Value != nil {
+		promotion.Value = *dto.Value
+	}
+	if dto.BuyQuantity != nil {
+		promotion.BuyQuantity = dto.BuyQuantity
+	}
+	if dto.GetQuantity != nil {
+		promotion.GetQuantity = dto.GetQuantity
+	}
+	if dto.StartTime != nil {
+		promotion.StartTime = dto.StartTime
+	}
+	if dto.EndTime != nil {
+		promotion.EndTime = dto.EndTime
+	}
+	if dto.StartsAt != nil {
+		promotion.StartsAt = dto.StartsAt
+	}
+	if dto.EndsAt != nil {
+		promotion.EndsAt = dto.EndsAt
+	}
+	if dto.IsActive != nil {
+		promotion.IsActive = *dto.IsActive
+	}
+	if dto.FranchiseeID != nil {
+		promotion.FranchiseeID = dto.FranchiseeID
+	}
+	if dto.StoreID != nil {
+		promotion.StoreID = dto.StoreID
+	}
+	if dto.ProductCategoryID != nil {
+		promotion.ProductCategoryID = dto.ProductCategoryID
+	}
+	if dto.ProductSizeID != nil {
+		promotion.ProductSizeID = dto.ProductSizeID
+	}
+}
+
+func ConvertToPromotionDTO(promotion *data.Promotion) PromotionDTO {
+	return PromotionDTO{
+		ID:                promotion.ID,
+		Name:              promotion.Name,
+		Description:       promotion.Description,
+		Type:              promotion.Type,
+		Value:             promotion.Value,
+		BuyQuantity:       promotion.BuyQuantity,
+		GetQuantity:       promotion.GetQuantity,
+		StartTime:         promotion.StartTime,
+		EndTime:           promotion.EndTime,
+		StartsAt:          promotion.StartsAt,
+		EndsAt:            promotion.EndsAt,
+		IsActive:          promotion.IsActive,
+		FranchiseeID:      promotion.FranchiseeID,
+		StoreID:           promotion.StoreID,
+		ProductCategoryID: promotion.ProductCategoryID,
+		ProductSizeID:     promotion.ProductSizeID,
+		CreatedAt:         promotion.CreatedAt,
+		UpdatedAt:         promotion.UpdatedAt,
+	}
+}
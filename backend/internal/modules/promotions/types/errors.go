@@ -0,0 +1,20 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrPromotionNotFound       = moduleErrors.NewModuleError(errors.New("promotion not found"))
+	ErrInvalidPromotionType    = moduleErrors.NewModuleError(errors.New("invalid promotion type"))
+	ErrInvalidPromotionValue   = moduleErrors.NewModuleError(errors.New("invalid promotion value"))
+	ErrInvalidBuyXGetY         = moduleErrors.NewModuleError(errors.New("buy and get quantities are required for buy X get Y promotion"))
+	ErrInvalidHappyHour        = moduleErrors.NewModuleError(errors.New("valid start and end time are required for happy hour promotion"))
+	ErrInvalidPromotionPeriod  = moduleErrors.NewModuleError(errors.New("promotion end must be after its start"))
+	ErrFailedToCreatePromotion = moduleErrors.NewModuleError(errors.New("failed to create promotion"))
+	ErrFailedToUpdatePromotion = moduleErrors.NewModuleError(errors.New("failed to update promotion"))
+	ErrFailedToDeletePromotion = moduleErrors.NewModuleError(errors.New("failed to delete promotion"))
+	ErrFailedToFetchPromotions = moduleErrors.NewModuleError(errors.New("failed to fetch promotions"))
+)
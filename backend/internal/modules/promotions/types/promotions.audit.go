@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	CreatePromotionAuditFactory = shared.NewAuditActionBaseFactory(
+		data.CreateOperation, data.PromotionComponent)
+
+	UpdatePromotionAuditFactory = shared.NewAuditActionExtendedFactory(
+		data.UpdateOperation, data.PromotionComponent, &UpdatePromotionDTO{})
+
+	DeletePromotionAuditFactory = shared.NewAuditActionBaseFactory(
+		data.DeleteOperation, data.PromotionComponent)
+)
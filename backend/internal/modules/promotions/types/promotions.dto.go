@@ -0,0 +1,90 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type CreatePromotionDTO struct {
+	Name              string             `json:"name" binding:"required,max=255"`
+	Description       string             `json:"description"`
+	Type              data.PromotionType `json:"type" binding:"required"`
+	Value             float64            `json:"value" binding:"gte=0"`
+	BuyQuantity       *int               `json:"buyQuantity" binding:"omitempty,gt=0"`
+	GetQuantity       *int               `json:"getQuantity" binding:"omitempty,gt=0"`
+	StartTime         *string            `json:"startTime" binding:"omitempty,len=5"`
+	EndTime           *string            `json:"endTime" binding:"omitempty,len=5"`
+	StartsAt          *time.Time         `json:"startsAt"`
+	EndsAt            *time.Time         `json:"endsAt"`
+	IsActive          *bool              `json:"isActive"`
+	FranchiseeID      *uint              `json:"franchiseeId" binding:"omitempty,gt=0"`
+	StoreID           *uint              `json:"storeId" binding:"omitempty,gt=0"`
+	ProductCategoryID *uint              `json:"productCategoryId" binding:"omitempty,gt=0"`
+	ProductSizeID     *uint              `json:"productSizeId" binding:"omitempty,gt=0"`
+}
+
+type UpdatePromotionDTO struct {
+	Name              *string             `json:"name" binding:"omitempty,max=255"`
+	Description       *string             `json:"description"`
+	Type              *data.PromotionType `json:"type"`
+	Value             *float64            `json:"value" binding:"omitempty,gte=0"`
+	BuyQuantity       *int                `json:"buyQuantity" binding:"omitempty,gt=0"`
+	GetQuantity       *int                `json:"getQuantity" binding:"omitempty,gt=0"`
+	StartTime         *string             `json:"startTime" binding:"omitempty,len=5"`
+	EndTime           *string             `json:"endTime" binding:"omitempty,len=5"`
+	StartsAt          *time.Time          `json:"startsAt"`
+	EndsAt            *time.Time          `json:"endsAt"`
+	IsActive          *bool               `json:"isActive"`
+	FranchiseeID      *uint               `json:"franchiseeId" binding:"omitempty,gt=0"`
+	StoreID           *uint               `json:"storeId" binding:"omitempty,gt=0"`
+	ProductCategoryID *uint               `json:"productCategoryId" binding:"omitempty,gt=0"`
+	ProductSizeID     *uint               `json:"productSizeId" binding:"omitempty,gt=0"`
+}
+
+type PromotionDTO struct {
+	ID                uint               `json:"id"`
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	Type              data.PromotionType `json:"type"`
+	Value             float64            `json:"value"`
+	BuyQuantity       *int               `json:"buyQuantity,omitempty"`
+	GetQuantity       *int               `json:"getQuantity,omitempty"`
+	StartTime         *string            `json:"startTime,omitempty"`
+	EndTime           *string            `json:"endTime,omitempty"`
+	StartsAt          *time.Time         `json:"startsAt,omitempty"`
+	EndsAt            *time.Time         `json:"endsAt,omitempty"`
+	IsActive          bool               `json:"isActive"`
+	FranchiseeID      *uint              `json:"franchiseeId,omitempty"`
+	StoreID           *uint              `json:"storeId,omitempty"`
+	ProductCategoryID *uint              `json:"productCategoryId,omitempty"`
+	ProductSizeID     *uint              `json:"productSizeId,omitempty"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+}
+
+type PromotionsFilter struct {
+	Search       *string             `form:"search"`
+	Type         *data.PromotionType `form:"type"`
+	IsActive     *bool               `form:"isActive"`
+	FranchiseeID *uint               `form:"franchiseeId"`
+	StoreID      *uint               `form:"storeId"`
+	utils.BaseFilter
+}
+
+// DiscountableItem is a single priced unit of an order, Index links the calculated discount back to the caller's item
+type DiscountableItem struct {
+	Index             int
+	ProductSizeID     uint
+	ProductCategoryID uint
+	Price             float64
+}
+
+type AppliedDiscount struct {
+	Index         int
+	PromotionID   uint
+	PromotionName string
+	Type          data.PromotionType
+	Amount        float64
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500PromotionCreate = localization.NewResponseKey(http.StatusInternalServerError, data.PromotionComponent, data.CreateOperation.ToString())
+	Response500PromotionGet    = localization.NewResponseKey(http.StatusInternalServerError, data.PromotionComponent, data.GetOperation.ToString())
+	Response500PromotionUpdate = localization.NewResponseKey(http.StatusInternalServerError, data.PromotionComponent, data.UpdateOperation.ToString())
+	Response500PromotionDelete = localization.NewResponseKey(http.StatusInternalServerError, data.PromotionComponent, data.DeleteOperation.ToString())
+
+	Response400Promotion = localization.NewResponseKey(http.StatusBadRequest, data.PromotionComponent)
+	Response404Promotion = localization.NewResponseKey(http.StatusNotFound, data.PromotionComponent)
+
+	Response201Promotion       = localization.NewResponseKey(http.StatusCreated, data.PromotionComponent)
+	Response200PromotionUpdate = localization.NewResponseKey(http.StatusOK, data.PromotionComponent, data.UpdateOperation.ToString())
+	Response200PromotionDelete = localization.NewResponseKey(http.StatusOK, data.PromotionComponent, data.DeleteOperation.ToString())
+)
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+const PromotionTimeLayout = "15:04"
+
+// ValidatePromotion checks the rule specific fields, it is used for both created and updated promotions
+func ValidatePromotion(promotion *data.Promotion) error {
+	if !data.IsValidPromotionType(promotion.Type) {
+		return ErrInvalidPromotionType
+	}
+
+	switch promotion.Type {
+	case data.PromotionTypePercentage:
+		if promotion.Value <= 0 || promotion.Value > 100 {
+			return ErrInvalidPromotionValue
+		}
+	case data.PromotionTypeFixedAmount:
+		if promotion.Value <= 0 {
+			return ErrInvalidPromotionValue
+		}
+	case data.PromotionTypeBuyXGetY:
+		if promotion.BuyQuantity == nil || promotion.GetQuantity == nil {
+			return ErrInvalidBuyXGetY
+		}
+	case data.PromotionTypeHappyHour:
+		if promotion.Value <= 0 || promotion.Value > 100 {
+			return ErrInvalidPromotionValue
+		}
+		if promotion.StartTime == nil || promotion.EndTime == nil {
+			return ErrInvalidHappyHour
+		}
+		if _, err := time.Parse(PromotionTimeLayout, *promotion.StartTime); err != nil {
+			return ErrInvalidHappyHour
+		}
+		if _, err := time.Parse(PromotionTimeLayout, *promotion.EndTime); err != nil {
+			return ErrInvalidHappyHour
+		}
+		if *promotion.StartTime == *promotion.EndTime {
+			return ErrInvalidHappyHour
+		}
+	}
+
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return ErrInvalidPromotionPeriod
+	}
+
+	return nil
+}
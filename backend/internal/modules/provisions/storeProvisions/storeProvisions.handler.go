@@ -7,6 +7,7 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/provisions"
@@ -158,7 +159,13 @@ func (h *StoreProvisionHandler) CompleteStoreProvisionByID(c *gin.Context) {
 		return
 	}
 
-	storeProvision, err := h.service.CompleteStoreProvision(storeID, storeProvisionID)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	storeProvision, err := h.service.CompleteStoreProvision(storeID, storeProvisionID, employeeID)
 	if err != nil {
 		switch {
 		case errors.Is(err, types.ErrStoreProvisionNotFound):
@@ -19,7 +19,7 @@ type StoreProvisionService interface {
 	GetStoreProvisions(storeID uint, filter *types.StoreProvisionFilterDTO) ([]types.StoreProvisionDTO, error)
 	CreateStoreProvision(storeID uint, dto *types.CreateStoreProvisionDTO) (*types.StoreProvisionDTO, error)
 	UpdateStoreProvision(storeID, storeProvisionID uint, dto *types.UpdateStoreProvisionDTO) (*types.StoreProvisionDTO, error)
-	CompleteStoreProvision(storeID, storeProvisionID uint) (*types.StoreProvisionDTO, error)
+	CompleteStoreProvision(storeID, storeProvisionID, employeeID uint) (*types.StoreProvisionDTO, error)
 	DeleteStoreProvision(storeID, storeProvisionID uint) (*types.StoreProvisionDTO, error)
 }
 
@@ -150,7 +150,7 @@ func (s *storeProvisionService) UpdateStoreProvision(storeID, storeProvisionID u
 	return types.MapToStoreProvisionDTO(storeProvision), nil
 }
 
-func (s *storeProvisionService) CompleteStoreProvision(storeID, storeProvisionID uint) (*types.StoreProvisionDTO, error) {
+func (s *storeProvisionService) CompleteStoreProvision(storeID, storeProvisionID, employeeID uint) (*types.StoreProvisionDTO, error) {
 	storeProvision, err := s.repo.GetStoreProvisionByID(storeID, storeProvisionID)
 	if err != nil {
 		wrapped := fmt.Errorf("failed to find store provision: %w", err)
@@ -158,7 +158,7 @@ func (s *storeProvisionService) CompleteStoreProvision(storeID, storeProvisionID
 		return nil, wrapped
 	}
 
-	deductedStocks, err := s.transactionManager.CompleteStoreProvision(storeProvision)
+	deductedStocks, err := s.transactionManager.CompleteStoreProvision(storeProvision, employeeID)
 	if err != nil {
 		wrapped := fmt.Errorf("failed to complete store provision: %w", err)
 		s.logger.Error(wrapped)
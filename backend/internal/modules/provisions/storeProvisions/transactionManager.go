@@ -15,7 +15,7 @@ import (
 
 type TransactionManager interface {
 	CreateStoreProvisionWithStocks(storeProvision *data.StoreProvision, ingredientIDs []uint) (storeProvisionID uint, err error)
-	CompleteStoreProvision(existingProvision *data.StoreProvision) (map[uint]*data.StoreStock, error)
+	CompleteStoreProvision(existingProvision *data.StoreProvision, employeeID uint) (map[uint]*data.StoreStock, error)
 }
 
 type transactionManager struct {
@@ -98,7 +98,7 @@ func (m *transactionManager) addStocks(storeStockRepo storeStocks.StoreStockRepo
 	return ids, nil
 }
 
-func (m *transactionManager) CompleteStoreProvision(provision *data.StoreProvision) (map[uint]*data.StoreStock, error) {
+func (m *transactionManager) CompleteStoreProvision(provision *data.StoreProvision, employeeID uint) (map[uint]*data.StoreStock, error) {
 	if provision == nil || provision.ID == 0 || provision.StoreID == 0 {
 		return nil, fmt.Errorf("invalid input arguments")
 	}
@@ -122,7 +122,7 @@ func (m *transactionManager) CompleteStoreProvision(provision *data.StoreProvisi
 		var err error
 
 		storeInventoryManagerRepoTx := m.storeInventoryManagerRepo.CloneWithTransaction(tx)
-		deductedStocks, err = storeInventoryManagerRepoTx.DeductStoreStocksByStoreProvision(provision)
+		deductedStocks, err = storeInventoryManagerRepoTx.DeductStoreStocksByStoreProvision(provision, employeeID)
 		if err != nil {
 			wrapped := fmt.Errorf("failed to deduct store stocks: %w", err)
 			return wrapped
@@ -0,0 +1,160 @@
+package receipts
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ReceiptHandler struct {
+	service      ReceiptService
+	auditService audit.AuditService
+}
+
+func NewReceiptHandler(service ReceiptService, auditService audit.AuditService) *ReceiptHandler {
+	return &ReceiptHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *ReceiptHandler) GetOrderReceipts(c *gin.Context) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Receipt)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	receipts, err := h.service.GetOrderReceipts(orderID, storeID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500ReceiptGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, receipts)
+}
+
+// GetReceipt returns the receipt as JSON or renders it as PDF or ESC/POS without counting the print
+func (h *ReceiptHandler) GetReceipt(c *gin.Context) {
+	var query types.ReceiptFormatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	orderID, receiptID, storeID, ok := parseReceiptParams(c)
+	if !ok {
+		return
+	}
+
+	receipt, err := h.service.GetReceipt(receiptID, orderID, storeID)
+	if err != nil {
+		sendReceiptError(c, err, types.Response500ReceiptGet)
+		return
+	}
+
+	if query.Format == "" || query.Format == types.ReceiptFormatJSON {
+		utils.SendSuccessResponse(c, receipt)
+		return
+	}
+
+	h.sendRenderedReceipt(c, receipt, query.Format)
+}
+
+// PrintReceipt renders the receipt for printing, every print after the first one is marked as a duplicate
+func (h *ReceiptHandler) PrintReceipt(c *gin.Context) {
+	var dto types.PrintReceiptDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	orderID, receiptID, storeID, ok := parseReceiptParams(c)
+	if !ok {
+		return
+	}
+
+	receipt, err := h.service.PrintReceipt(receiptID, orderID, storeID)
+	if err != nil {
+		sendReceiptError(c, err, types.Response500ReceiptPrint)
+		return
+	}
+
+	action := types.PrintReceiptAuditFactory(
+		&data.BaseDetails{
+			ID:   receipt.ID,
+			Name: receipt.Number,
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	h.sendRenderedReceipt(c, receipt, dto.Format)
+}
+
+func (h *ReceiptHandler) sendRenderedReceipt(c *gin.Context, receipt *types.ReceiptDTO, format types.ReceiptFormat) {
+	content, err := h.service.RenderReceipt(receipt, format)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500ReceiptRender)
+		return
+	}
+
+	contentType := "application/pdf"
+	filename := fmt.Sprintf("receipt_%s.pdf", receipt.Number)
+	if format == types.ReceiptFormatEscPos {
+		contentType = "application/octet-stream"
+		filename = fmt.Sprintf("receipt_%s.bin", receipt.Number)
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Length", fmt.Sprintf("%d", len(content)))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+func parseReceiptParams(c *gin.Context) (orderID, receiptID, storeID uint, ok bool) {
+	orderID, err := utils.ParseParam(c, "orderId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Receipt)
+		return 0, 0, 0, false
+	}
+
+	receiptID, err = utils.ParseParam(c, "receiptId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Receipt)
+		return 0, 0, 0, false
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return 0, 0, 0, false
+	}
+
+	return orderID, receiptID, storeID, true
+}
+
+func sendReceiptError(c *gin.Context, err error, internalErrorKey *localization.ResponseKey) {
+	if errors.Is(err, types.ErrReceiptNotFound) {
+		localization.SendLocalizedResponseWithKey(c, types.Response404Receipt)
+		return
+	}
+	localization.SendLocalizedResponseWithKey(c, internalErrorKey)
+}
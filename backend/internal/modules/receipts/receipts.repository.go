@@ -0,0 +1,133 @@
+package receipts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReceiptRepository interface {
+	GetOrderForReceipt(orderID uint) (*data.Order, error)
+	CreateReceipt(receipt *data.Receipt) error
+	GetReceiptByTransactionID(transactionID uint) (*data.Receipt, error)
+	GetReceiptByID(receiptID, orderID, storeID uint) (*data.Receipt, error)
+	GetOrderReceipts(orderID, storeID uint) ([]data.Receipt, error)
+	MarkReceiptPrinted(receiptID uint, printedAt time.Time) error
+}
+
+type receiptRepository struct {
+	db *gorm.DB
+}
+
+func NewReceiptRepository(db *gorm.DB) ReceiptRepository {
+	return &receiptRepository{db: db}
+}
+
+func (r *receiptRepository) GetOrderForReceipt(orderID uint) (*data.Order, error) {
+	var order data.Order
+	err := r.db.
+		Preload("Suborders", func(db *gorm.DB) *gorm.DB {
+			return db.Order("suborders.id ASC")
+		}).
+		Preload("Suborders.SuborderAdditives.StoreAdditive.Additive").
+		Preload("Suborders.StoreProductSize.ProductSize.Product").
+		Where("id = ?", orderID).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrReceiptOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch order %d for receipt: %w", orderID, err)
+	}
+	return &order, nil
+}
+
+// CreateReceipt takes the next number of the store sequence and saves the receipt with its items
+func (r *receiptRepository) CreateReceipt(receipt *data.Receipt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		sequence := data.ReceiptSequence{StoreID: receipt.StoreID, LastNumber: 1}
+		err := tx.Clauses(
+			clause.OnConflict{
+				Columns:   []clause.Column{{Name: "store_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"last_number": gorm.Expr("receipt_sequences.last_number + 1")}),
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "last_number"}}},
+		).Create(&sequence).Error
+		if err != nil {
+			return fmt.Errorf("failed to take the receipt number of store %d: %w", receipt.StoreID, err)
+		}
+
+		receipt.Number = sequence.LastNumber
+		if err := tx.Create(receipt).Error; err != nil {
+			return fmt.Errorf("failed to create receipt: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *receiptRepository) GetReceiptByTransactionID(transactionID uint) (*data.Receipt, error) {
+	var receipt data.Receipt
+	err := r.preloadReceipt(r.db).
+		Where("transaction_id = ?", transactionID).
+		First(&receipt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrReceiptNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch receipt of transaction %d: %w", transactionID, err)
+	}
+	return &receipt, nil
+}
+
+func (r *receiptRepository) GetReceiptByID(receiptID, orderID, storeID uint) (*data.Receipt, error) {
+	var receipt data.Receipt
+	err := r.preloadReceipt(r.db).
+		Where("id = ? AND order_id = ? AND store_id = ?", receiptID, orderID, storeID).
+		First(&receipt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrReceiptNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch receipt %d: %w", receiptID, err)
+	}
+	return &receipt, nil
+}
+
+func (r *receiptRepository) GetOrderReceipts(orderID, storeID uint) ([]data.Receipt, error) {
+	var receipts []data.Receipt
+	err := r.preloadReceipt(r.db).
+		Where("order_id = ? AND store_id = ?", orderID, storeID).
+		Order("number ASC").
+		Find(&receipts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receipts of order %d: %w", orderID, err)
+	}
+	return receipts, nil
+}
+
+func (r *receiptRepository) MarkReceiptPrinted(receiptID uint, printedAt time.Time) error {
+	err := r.db.Model(&data.Receipt{}).
+		Where("id = ?", receiptID).
+		Updates(map[string]interface{}{
+			"print_count":     gorm.Expr("print_count + 1"),
+			"last_printed_at": printedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark receipt %d as printed: %w", receiptID, err)
+	}
+	return nil
+}
+
+func (r *receiptRepository) preloadReceipt(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Store.FacilityAddress").
+		Preload("Order").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("receipt_items.id ASC")
+		})
+}
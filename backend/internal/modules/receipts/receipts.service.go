@@ -0,0 +1,164 @@
+package receipts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/escpos"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+	"go.uber.org/zap"
+)
+
+type ReceiptService interface {
+	CreateSaleReceipt(orderID uint, transaction *data.Transaction) (*types.ReceiptDTO, error)
+	CreateRefundReceipt(orderID uint, transaction *data.Transaction, suborderIDs []uint) (*types.ReceiptDTO, error)
+
+	GetOrderReceipts(orderID, storeID uint) ([]types.ReceiptDTO, error)
+	GetReceipt(receiptID, orderID, storeID uint) (*types.ReceiptDTO, error)
+	PrintReceipt(receiptID, orderID, storeID uint) (*types.ReceiptDTO, error)
+	RenderReceipt(receipt *types.ReceiptDTO, format types.ReceiptFormat) ([]byte, error)
+}
+
+type receiptService struct {
+	repo         ReceiptRepository
+	printerWidth int
+	logger       *zap.SugaredLogger
+}
+
+func NewReceiptService(repo ReceiptRepository, printerWidth int, logger *zap.SugaredLogger) ReceiptService {
+	return &receiptService{
+		repo:         repo,
+		printerWidth: printerWidth,
+		logger:       logger,
+	}
+}
+
+// CreateSaleReceipt issues the receipt of the order payment, a transaction gets a single receipt
+func (s *receiptService) CreateSaleReceipt(orderID uint, transaction *data.Transaction) (*types.ReceiptDTO, error) {
+	if existing, err := s.getTransactionReceipt(transaction.ID); existing != nil || err != nil {
+		return existing, err
+	}
+
+	order, err := s.repo.GetOrderForReceipt(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := types.BuildSaleReceipt(order, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createReceipt(receipt)
+}
+
+// CreateRefundReceipt issues the receipt of the refunded suborders
+func (s *receiptService) CreateRefundReceipt(orderID uint, transaction *data.Transaction, suborderIDs []uint) (*types.ReceiptDTO, error) {
+	if existing, err := s.getTransactionReceipt(transaction.ID); existing != nil || err != nil {
+		return existing, err
+	}
+
+	order, err := s.repo.GetOrderForReceipt(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundedIDs := make(map[uint]struct{}, len(suborderIDs))
+	for _, id := range suborderIDs {
+		refundedIDs[id] = struct{}{}
+	}
+
+	suborders := make([]data.Suborder, 0, len(suborderIDs))
+	for _, suborder := range order.Suborders {
+		if _, ok := refundedIDs[suborder.ID]; ok {
+			suborders = append(suborders, suborder)
+		}
+	}
+
+	receipt, err := types.BuildRefundReceipt(order, suborders, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createReceipt(receipt)
+}
+
+func (s *receiptService) GetOrderReceipts(orderID, storeID uint) ([]types.ReceiptDTO, error) {
+	receipts, err := s.repo.GetOrderReceipts(orderID, storeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return types.ConvertReceiptsToDTOs(receipts), nil
+}
+
+func (s *receiptService) GetReceipt(receiptID, orderID, storeID uint) (*types.ReceiptDTO, error) {
+	receipt, err := s.repo.GetReceiptByID(receiptID, orderID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertReceiptToDTO(receipt)
+	return &response, nil
+}
+
+// PrintReceipt counts the print of the receipt, the returned receipt keeps the number of the previous prints
+// so the reprinted receipt is rendered as a duplicate
+func (s *receiptService) PrintReceipt(receiptID, orderID, storeID uint) (*types.ReceiptDTO, error) {
+	receipt, err := s.repo.GetReceiptByID(receiptID, orderID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.MarkReceiptPrinted(receipt.ID, time.Now().UTC()); err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	response := types.ConvertReceiptToDTO(receipt)
+	return &response, nil
+}
+
+func (s *receiptService) RenderReceipt(receipt *types.ReceiptDTO, format types.ReceiptFormat) ([]byte, error) {
+	switch format {
+	case types.ReceiptFormatPDF:
+		return pdf.GeneratePDFReceipt(types.ToPDFReceipt(receipt))
+	case types.ReceiptFormatEscPos:
+		return escpos.GenerateEscPosReceipt(types.ToEscPosReceipt(receipt), s.printerWidth), nil
+	default:
+		return nil, fmt.Errorf("unsupported receipt format %q", format)
+	}
+}
+
+func (s *receiptService) getTransactionReceipt(transactionID uint) (*types.ReceiptDTO, error) {
+	receipt, err := s.repo.GetReceiptByTransactionID(transactionID)
+	if err != nil {
+		if errors.Is(err, types.ErrReceiptNotFound) {
+			return nil, nil
+		}
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	response := types.ConvertReceiptToDTO(receipt)
+	return &response, nil
+}
+
+func (s *receiptService) createReceipt(receipt *data.Receipt) (*types.ReceiptDTO, error) {
+	if err := s.repo.CreateReceipt(receipt); err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	createdReceipt, err := s.repo.GetReceiptByID(receipt.ID, receipt.OrderID, receipt.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertReceiptToDTO(createdReceipt)
+	return &response, nil
+}
@@ -0,0 +1,156 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/escpos"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+)
+
+const receiptDateLayout = "2006-01-02 15:04:05"
+
+var receiptTitles = map[data.ReceiptType]string{
+	data.ReceiptTypeSale:   "SALE RECEIPT",
+	data.ReceiptTypeRefund: "REFUND RECEIPT",
+}
+
+func ConvertReceiptToDTO(receipt *data.Receipt) ReceiptDTO {
+	items := make([]ReceiptItemDTO, len(receipt.Items))
+	for i, item := range receipt.Items {
+		items[i] = ReceiptItemDTO{
+			ID:         item.ID,
+			SuborderID: item.SuborderID,
+			Name:       item.Name,
+			Size:       item.Size,
+			Additives:  item.Additives,
+			Price:      item.Price,
+			Discount:   item.Discount,
+			Total:      item.Total,
+			TaxRate:    item.TaxRate,
+			TaxAmount:  item.TaxAmount,
+		}
+	}
+
+	return ReceiptDTO{
+		ID:                 receipt.ID,
+		Number:             FormatReceiptNumber(receipt.Number),
+		Type:               receipt.Type,
+		StoreID:            receipt.StoreID,
+		StoreName:          receipt.Store.Name,
+		StoreAddress:       receipt.Store.FacilityAddress.Address,
+		StorePhone:         receipt.Store.ContactPhone,
+		OrderID:            receipt.OrderID,
+		OrderDisplayNumber: receipt.Order.DisplayNumber,
+		TransactionID:      receipt.TransactionID,
+		Items:              items,
+		Subtotal:           receipt.Subtotal,
+		DiscountTotal:      receipt.DiscountTotal,
+		DeliveryFee:        receipt.DeliveryFee,
+		BonusesPaid:        receipt.BonusesPaid,
+		Total:              receipt.Total,
+		TaxAmount:          receipt.TaxAmount,
+		Taxes:              GroupReceiptTaxes(receipt.Items),
+		PaymentMethod:      receipt.PaymentMethod,
+		CardMask:           receipt.CardMask,
+		PrintCount:         receipt.PrintCount,
+		LastPrintedAt:      receipt.LastPrintedAt,
+		IssuedAt:           receipt.CreatedAt,
+	}
+}
+
+func ConvertReceiptsToDTOs(receipts []data.Receipt) []ReceiptDTO {
+	dtos := make([]ReceiptDTO, len(receipts))
+	for i := range receipts {
+		dtos[i] = ConvertReceiptToDTO(&receipts[i])
+	}
+	return dtos
+}
+
+// ToPDFReceipt maps the receipt to the PDF details, a receipt printed before is marked as a duplicate
+func ToPDFReceipt(receipt *ReceiptDTO) pdf.PDFReceiptDetails {
+	subOrders := make([]pdf.PDFSubOrder, len(receipt.Items))
+	for i, item := range receipt.Items {
+		subOrders[i] = pdf.PDFSubOrder{
+			ProductName: item.Name,
+			Size:        item.Size,
+			Price:       item.Price,
+			Note:        item.Additives,
+		}
+	}
+
+	taxes := make([]pdf.PDFReceiptTax, len(receipt.Taxes))
+	for i, tax := range receipt.Taxes {
+		taxes[i] = pdf.PDFReceiptTax{Rate: tax.Rate, Amount: tax.Amount}
+	}
+
+	return pdf.PDFReceiptDetails{
+		OrderID:       receipt.OrderID,
+		StoreID:       receipt.StoreID,
+		OrderDate:     receipt.IssuedAt.Format(receiptDateLayout),
+		Total:         receipt.Total,
+		SubOrders:     subOrders,
+		ReceiptNumber: receipt.Number,
+		ReceiptTitle:  receiptTitles[receipt.Type],
+		StoreName:     receipt.StoreName,
+		StoreAddress:  receipt.StoreAddress,
+		Subtotal:      receipt.Subtotal,
+		DiscountTotal: receipt.DiscountTotal,
+		DeliveryFee:   receipt.DeliveryFee,
+		BonusesPaid:   receipt.BonusesPaid,
+		Taxes:         taxes,
+		PaymentMethod: receipt.PaymentMethod,
+		CardMask:      derefString(receipt.CardMask),
+		IsDuplicate:   receipt.PrintCount > 0,
+	}
+}
+
+// ToEscPosReceipt maps the receipt to the thermal printer details, a receipt printed before is marked as a duplicate
+func ToEscPosReceipt(receipt *ReceiptDTO) escpos.EscPosReceiptDetails {
+	items := make([]escpos.EscPosReceiptItem, len(receipt.Items))
+	for i, item := range receipt.Items {
+		name := item.Name
+		if item.Size != "" {
+			name = fmt.Sprintf("%s (%s)", item.Name, item.Size)
+		}
+		items[i] = escpos.EscPosReceiptItem{
+			Name:      name,
+			Additives: item.Additives,
+			Price:     item.Price,
+			Discount:  item.Discount,
+		}
+	}
+
+	taxes := make([]escpos.EscPosReceiptTax, len(receipt.Taxes))
+	for i, tax := range receipt.Taxes {
+		taxes[i] = escpos.EscPosReceiptTax{Rate: tax.Rate, Amount: tax.Amount}
+	}
+
+	return escpos.EscPosReceiptDetails{
+		Title:         receiptTitles[receipt.Type],
+		Number:        receipt.Number,
+		StoreName:     receipt.StoreName,
+		StoreAddress:  receipt.StoreAddress,
+		StorePhone:    receipt.StorePhone,
+		OrderNumber:   strconv.Itoa(receipt.OrderDisplayNumber),
+		IssuedAt:      receipt.IssuedAt.Format(receiptDateLayout),
+		Items:         items,
+		Subtotal:      receipt.Subtotal,
+		DiscountTotal: receipt.DiscountTotal,
+		DeliveryFee:   receipt.DeliveryFee,
+		BonusesPaid:   receipt.BonusesPaid,
+		Total:         receipt.Total,
+		Taxes:         taxes,
+		PaymentMethod: receipt.PaymentMethod,
+		CardMask:      derefString(receipt.CardMask),
+		IsDuplicate:   receipt.PrintCount > 0,
+	}
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrReceiptNotFound      = moduleErrors.NewModuleError(errors.New("receipt not found"))
+	ErrReceiptOrderNotFound = moduleErrors.NewModuleError(errors.New("order of the receipt not found"))
+	ErrNoReceiptItems       = moduleErrors.NewModuleError(errors.New("receipt must contain at least one item"))
+)
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+func FormatReceiptNumber(number uint) string {
+	return fmt.Sprintf("%08d", number)
+}
+
+// BuildSaleReceipt composes the receipt of the order payment, the total is the amount of the payment transaction
+func BuildSaleReceipt(order *data.Order, transaction *data.Transaction) (*data.Receipt, error) {
+	receipt, err := buildReceipt(order, order.Suborders, transaction, data.ReceiptTypeSale)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt.DeliveryFee = order.DeliveryFee
+	receipt.BonusesPaid = order.BonusesRedeemed
+	return receipt, nil
+}
+
+// BuildRefundReceipt composes the receipt of the refunded suborders, the total is the refunded amount.
+// The items carry their taxes, the receipt of the last refund of the order returns the delivery fee and the bonuses too
+func BuildRefundReceipt(order *data.Order, suborders []data.Suborder, transaction *data.Transaction) (*data.Receipt, error) {
+	receipt, err := buildReceipt(order, suborders, transaction, data.ReceiptTypeRefund)
+	if err != nil {
+		return nil, err
+	}
+
+	if isOrderRefunded(order) {
+		receipt.DeliveryFee = order.DeliveryFee
+		receipt.BonusesPaid = order.BonusesRedeemed
+	}
+	return receipt, nil
+}
+
+// isOrderRefunded is checked after the refund is saved, so the refunded suborders are already marked,
+// the cancelled suborders are not kept by the customer either
+func isOrderRefunded(order *data.Order) bool {
+	for _, suborder := range order.Suborders {
+		if !suborder.Status.IsClosed() {
+			return false
+		}
+	}
+	return true
+}
+
+func buildReceipt(order *data.Order, suborders []data.Suborder, transaction *data.Transaction, receiptType data.ReceiptType) (*data.Receipt, error) {
+	if len(suborders) == 0 {
+		return nil, ErrNoReceiptItems
+	}
+
+	receipt := &data.Receipt{
+		StoreID:       order.StoreID,
+		OrderID:       order.ID,
+		TransactionID: transaction.ID,
+		Type:          receiptType,
+		Total:         transaction.Amount,
+		PaymentMethod: transaction.PaymentMethod,
+		CardMask:      transaction.CardMask,
+		Items:         make([]data.ReceiptItem, len(suborders)),
+	}
+
+	for i := range suborders {
+		item := buildReceiptItem(&suborders[i])
+		receipt.Subtotal += item.Price
+		receipt.DiscountTotal += item.Discount
+		receipt.TaxAmount += item.TaxAmount
+		receipt.Items[i] = item
+	}
+	receipt.Subtotal = roundAmount(receipt.Subtotal)
+	receipt.DiscountTotal = roundAmount(receipt.DiscountTotal)
+	receipt.TaxAmount = roundAmount(receipt.TaxAmount)
+
+	return receipt, nil
+}
+
+func buildReceiptItem(suborder *data.Suborder) data.ReceiptItem {
+	suborderID := suborder.ID
+	productSize := suborder.StoreProductSize.ProductSize
+
+	additives := make([]string, 0, len(suborder.SuborderAdditives))
+	for _, additive := range suborder.SuborderAdditives {
+		additives = append(additives, additive.StoreAdditive.Additive.Name)
+	}
+
+	// the suborder price is already reduced by the promotion discounts, an exclusive tax is charged on top of it
+	total := suborder.Price
+	if suborder.TaxMode == data.TaxModeExclusive {
+		total = roundAmount(total + suborder.TaxAmount)
+	}
+
+	return data.ReceiptItem{
+		SuborderID: &suborderID,
+		Name:       productSize.Product.Name,
+		Size:       productSize.Name,
+		Additives:  strings.Join(additives, ", "),
+		Price:      roundAmount(suborder.Price + suborder.DiscountAmount),
+		Discount:   suborder.DiscountAmount,
+		Total:      total,
+		TaxRate:    suborder.TaxRate,
+		TaxAmount:  suborder.TaxAmount,
+	}
+}
+
+// GroupReceiptTaxes sums the item taxes per rate, the untaxed items are skipped
+func GroupReceiptTaxes(items []data.ReceiptItem) []ReceiptTaxDTO {
+	amounts := make(map[float64]float64)
+	for _, item := range items {
+		if item.TaxRate <= 0 {
+			continue
+		}
+		amounts[item.TaxRate] += item.TaxAmount
+	}
+
+	taxes := make([]ReceiptTaxDTO, 0, len(amounts))
+	for rate, amount := range amounts {
+		taxes = append(taxes, ReceiptTaxDTO{Rate: rate, Amount: roundAmount(amount)})
+	}
+	sort.Slice(taxes, func(i, j int) bool {
+		return taxes[i].Rate < taxes[j].Rate
+	})
+	return taxes
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
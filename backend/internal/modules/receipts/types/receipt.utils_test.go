@@ -0,0 +1,124 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReceipts(t *testing.T) {
+	cardMask := "4400****1234"
+	latte := data.StoreProductSize{ProductSize: data.ProductSize{Name: "M", Product: data.Product{Name: "Latte"}}}
+
+	order := &data.Order{
+		BaseEntity:      data.BaseEntity{ID: 7},
+		StoreID:         3,
+		DeliveryFee:     500,
+		BonusesRedeemed: 200,
+		Suborders: []data.Suborder{
+			{
+				BaseEntity:       data.BaseEntity{ID: 1},
+				StoreProductSize: latte,
+				Price:            1500,
+				DiscountAmount:   300,
+				TaxRate:          12,
+				TaxMode:          data.TaxModeInclusive,
+				TaxAmount:        160.71,
+				SuborderAdditives: []data.SuborderAdditive{
+					{StoreAdditive: data.StoreAdditive{Additive: data.Additive{Name: "Vanilla syrup"}}},
+					{StoreAdditive: data.StoreAdditive{Additive: data.Additive{Name: "Oat milk"}}},
+				},
+			},
+			{BaseEntity: data.BaseEntity{ID: 2}, StoreProductSize: latte, Price: 1200, TaxRate: 12, TaxMode: data.TaxModeExclusive, TaxAmount: 144},
+		},
+	}
+	payment := &data.Transaction{BaseEntity: data.BaseEntity{ID: 11}, Amount: 3144, PaymentMethod: "CARD", CardMask: &cardMask}
+
+	t.Run("Sale receipt should include all suborders and the paid amount", func(t *testing.T) {
+		receipt, err := BuildSaleReceipt(order, payment)
+
+		assert.NoError(t, err)
+		assert.Equal(t, data.ReceiptTypeSale, receipt.Type)
+		assert.Equal(t, uint(11), receipt.TransactionID)
+		assert.Equal(t, 3144.0, receipt.Total)
+		assert.Equal(t, 3000.0, receipt.Subtotal)
+		assert.Equal(t, 300.0, receipt.DiscountTotal)
+		assert.Equal(t, 500.0, receipt.DeliveryFee)
+		assert.Equal(t, 200.0, receipt.BonusesPaid)
+		assert.Equal(t, 304.71, receipt.TaxAmount)
+		assert.Equal(t, &cardMask, receipt.CardMask)
+
+		if assert.Len(t, receipt.Items, 2) {
+			assert.Equal(t, "Latte", receipt.Items[0].Name)
+			assert.Equal(t, "M", receipt.Items[0].Size)
+			assert.Equal(t, "Vanilla syrup, Oat milk", receipt.Items[0].Additives)
+			assert.Equal(t, 1800.0, receipt.Items[0].Price)
+			assert.Equal(t, 1500.0, receipt.Items[0].Total)
+			assert.Equal(t, 160.71, receipt.Items[0].TaxAmount)
+			assert.Equal(t, 1344.0, receipt.Items[1].Total)
+			assert.Equal(t, 12.0, receipt.Items[1].TaxRate)
+		}
+	})
+
+	t.Run("Refund receipt should include only the refunded suborders", func(t *testing.T) {
+		refund := &data.Transaction{BaseEntity: data.BaseEntity{ID: 12}, Amount: 1344, PaymentMethod: "CARD"}
+
+		receipt, err := BuildRefundReceipt(order, order.Suborders[1:], refund)
+
+		assert.NoError(t, err)
+		assert.Equal(t, data.ReceiptTypeRefund, receipt.Type)
+		assert.Equal(t, 1344.0, receipt.Total)
+		assert.Equal(t, 144.0, receipt.TaxAmount)
+		assert.Equal(t, 0.0, receipt.DeliveryFee)
+		assert.Len(t, receipt.Items, 1)
+	})
+
+	t.Run("Last refund receipt should return the delivery fee and the bonuses", func(t *testing.T) {
+		refunded := *order
+		refunded.Suborders = []data.Suborder{order.Suborders[0], order.Suborders[1]}
+		for i := range refunded.Suborders {
+			refunded.Suborders[i].Status = data.SubOrderStatusRefunded
+		}
+		refund := &data.Transaction{BaseEntity: data.BaseEntity{ID: 13}, Amount: 3144, PaymentMethod: "CARD"}
+
+		receipt, err := BuildRefundReceipt(&refunded, refunded.Suborders, refund)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3144.0, receipt.Total)
+		assert.Equal(t, 304.71, receipt.TaxAmount)
+		assert.Equal(t, 500.0, receipt.DeliveryFee)
+		assert.Equal(t, 200.0, receipt.BonusesPaid)
+	})
+
+	t.Run("Refund of the suborders left after a cancellation should be the last one", func(t *testing.T) {
+		refunded := *order
+		refunded.Suborders = []data.Suborder{order.Suborders[0], order.Suborders[1]}
+		refunded.Suborders[0].Status = data.SubOrderStatusCancelled
+		refunded.Suborders[1].Status = data.SubOrderStatusRefunded
+		refund := &data.Transaction{BaseEntity: data.BaseEntity{ID: 14}, Amount: 1644, PaymentMethod: "CARD"}
+
+		receipt, err := BuildRefundReceipt(&refunded, refunded.Suborders[1:], refund)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 500.0, receipt.DeliveryFee)
+		assert.Equal(t, 200.0, receipt.BonusesPaid)
+	})
+
+	t.Run("Receipt without suborders should fail", func(t *testing.T) {
+		_, err := BuildRefundReceipt(order, nil, payment)
+
+		assert.ErrorIs(t, err, ErrNoReceiptItems)
+	})
+
+	t.Run("Taxes should be grouped by rate without the untaxed items", func(t *testing.T) {
+		items := []data.ReceiptItem{
+			{TaxRate: 12, TaxAmount: 160.71},
+			{TaxRate: 0, TaxAmount: 0},
+			{TaxRate: 5, TaxAmount: 50},
+			{TaxRate: 12, TaxAmount: 144},
+		}
+
+		assert.Equal(t, []ReceiptTaxDTO{{Rate: 5, Amount: 50}, {Rate: 12, Amount: 304.71}}, GroupReceiptTaxes(items))
+	})
+}
@@ -0,0 +1,9 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var PrintReceiptAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+	data.UpdateOperation, data.ReceiptComponent, &PrintReceiptDTO{})
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+type ReceiptFormat string
+
+const (
+	ReceiptFormatJSON   ReceiptFormat = "json"
+	ReceiptFormatPDF    ReceiptFormat = "pdf"
+	ReceiptFormatEscPos ReceiptFormat = "escpos"
+)
+
+type ReceiptFormatQuery struct {
+	Format ReceiptFormat `form:"format" binding:"omitempty,oneof=json pdf escpos"`
+}
+
+type PrintReceiptDTO struct {
+	Format ReceiptFormat `json:"format" binding:"required,oneof=pdf escpos"`
+}
+
+type ReceiptDTO struct {
+	ID                 uint             `json:"id"`
+	Number             string           `json:"number"`
+	Type               data.ReceiptType `json:"type"`
+	StoreID            uint             `json:"storeId"`
+	StoreName          string           `json:"storeName"`
+	StoreAddress       string           `json:"storeAddress"`
+	StorePhone         string           `json:"storePhone"`
+	OrderID            uint             `json:"orderId"`
+	OrderDisplayNumber int              `json:"orderDisplayNumber"`
+	TransactionID      uint             `json:"transactionId"`
+	Items              []ReceiptItemDTO `json:"items"`
+	Subtotal           float64          `json:"subtotal"`
+	DiscountTotal      float64          `json:"discountTotal"`
+	DeliveryFee        float64          `json:"deliveryFee"`
+	BonusesPaid        float64          `json:"bonusesPaid"`
+	Total              float64          `json:"total"`
+	TaxAmount          float64          `json:"taxAmount"`
+	Taxes              []ReceiptTaxDTO  `json:"taxes"`
+	PaymentMethod      string           `json:"paymentMethod"`
+	CardMask           *string          `json:"cardMask,omitempty"`
+	PrintCount         int              `json:"printCount"`
+	LastPrintedAt      *time.Time       `json:"lastPrintedAt,omitempty"`
+	IssuedAt           time.Time        `json:"issuedAt"`
+}
+
+type ReceiptItemDTO struct {
+	ID         uint    `json:"id"`
+	SuborderID *uint   `json:"suborderId,omitempty"`
+	Name       string  `json:"name"`
+	Size       string  `json:"size"`
+	Additives  string  `json:"additives,omitempty"`
+	Price      float64 `json:"price"`
+	Discount   float64 `json:"discount"`
+	Total      float64 `json:"total"`
+	TaxRate    float64 `json:"taxRate"`
+	TaxAmount  float64 `json:"taxAmount"`
+}
+
+type ReceiptTaxDTO struct {
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+}
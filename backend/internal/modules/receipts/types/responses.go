@@ -0,0 +1,17 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500ReceiptGet    = localization.NewResponseKey(http.StatusInternalServerError, data.ReceiptComponent, data.GetOperation.ToString())
+	Response500ReceiptRender = localization.NewResponseKey(http.StatusInternalServerError, data.ReceiptComponent, "RENDER")
+	Response500ReceiptPrint  = localization.NewResponseKey(http.StatusInternalServerError, data.ReceiptComponent, "PRINT")
+
+	Response400Receipt = localization.NewResponseKey(http.StatusBadRequest, data.ReceiptComponent)
+	Response404Receipt = localization.NewResponseKey(http.StatusNotFound, data.ReceiptComponent)
+)
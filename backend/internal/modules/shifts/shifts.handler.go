@@ -0,0 +1,243 @@
+package shifts
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/shifts/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftHandler struct {
+	service      ShiftService
+	auditService audit.AuditService
+}
+
+func NewShiftHandler(service ShiftService, auditService audit.AuditService) *ShiftHandler {
+	return &ShiftHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *ShiftHandler) OpenShift(c *gin.Context) {
+	var dto types.OpenShiftDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	shift, err := h.service.OpenShift(storeID, employeeID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrStoreEmployeeNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response400Shift)
+		case errors.Is(err, types.ErrShiftAlreadyOpen):
+			localization.SendLocalizedResponseWithKey(c, types.Response409ShiftOpen)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500ShiftOpen)
+		}
+		return
+	}
+
+	action := types.OpenShiftAuditFactory(
+		&data.BaseDetails{
+			ID:   shift.ID,
+			Name: shiftName(shift),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SendResponseWithStatus(c, shift, http.StatusCreated)
+}
+
+func (h *ShiftHandler) GetCurrentShift(c *gin.Context) {
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	shift, err := h.service.GetCurrentShift(storeID, employeeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrShiftNotFound), errors.Is(err, types.ErrStoreEmployeeNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Shift)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500ShiftGet)
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, shift)
+}
+
+func (h *ShiftHandler) GetShifts(c *gin.Context) {
+	var filter types.ShiftsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.CashShift{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+	filter.StoreID = &storeID
+
+	shifts, err := h.service.GetShifts(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500ShiftGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, shifts, filter.Pagination)
+}
+
+func (h *ShiftHandler) CloseShift(c *gin.Context) {
+	shiftID, err := utils.ParseParam(c, "shiftId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Shift)
+		return
+	}
+
+	var dto types.CloseShiftDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, role, errH := contexts.GetStoreIdWithRole(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		utils.SendErrorWithStatus(c, contexts.ErrUnauthorizedAccess.Error(), contexts.ErrUnauthorizedAccess.Status())
+		return
+	}
+
+	closer := &types.ShiftCloser{
+		EmployeeID:    employeeID,
+		CanCloseOther: role != data.RoleBarista,
+	}
+
+	report, err := h.service.CloseShift(shiftID, storeID, closer, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrShiftNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Shift)
+		case errors.Is(err, types.ErrShiftNotOwned):
+			localization.SendLocalizedResponseWithKey(c, types.Response403Shift)
+		case errors.Is(err, types.ErrShiftClosed):
+			localization.SendLocalizedResponseWithKey(c, types.Response409ShiftClosed)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500ShiftClose)
+		}
+		return
+	}
+
+	action := types.CloseShiftAuditFactory(
+		&data.BaseDetails{
+			ID:   report.Shift.ID,
+			Name: shiftName(&report.Shift),
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SendSuccessResponse(c, report)
+}
+
+func (h *ShiftHandler) GetShiftReport(c *gin.Context) {
+	report, ok := h.getShiftReport(c)
+	if !ok {
+		return
+	}
+
+	utils.SendSuccessResponse(c, report)
+}
+
+func (h *ShiftHandler) GetShiftReportPDF(c *gin.Context) {
+	report, ok := h.getShiftReport(c)
+	if !ok {
+		return
+	}
+
+	pdfData, err := pdf.GeneratePDFShiftReport(types.ToPDFShiftReport(report))
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500ShiftReport)
+		return
+	}
+
+	filename := fmt.Sprintf("shift_%d_%s_report.pdf", report.Shift.ID, report.Type)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", fmt.Sprintf("%d", len(pdfData)))
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
+
+func (h *ShiftHandler) getShiftReport(c *gin.Context) (*types.ShiftReportDTO, bool) {
+	shiftID, err := utils.ParseParam(c, "shiftId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Shift)
+		return nil, false
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return nil, false
+	}
+
+	report, err := h.service.GetShiftReport(shiftID, storeID)
+	if err != nil {
+		if errors.Is(err, types.ErrShiftNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404Shift)
+			return nil, false
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500ShiftReport)
+		return nil, false
+	}
+
+	return report, true
+}
+
+func shiftName(shift *types.CashShiftDTO) string {
+	return fmt.Sprintf("#%d %s %s", shift.ID, shift.Employee.FirstName, shift.Employee.LastName)
+}
@@ -0,0 +1,165 @@
+package shifts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/shifts/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type ShiftRepository interface {
+	GetStoreEmployeeByEmployeeID(storeID, employeeID uint) (*data.StoreEmployee, error)
+	CreateShift(shift *data.CashShift) error
+	GetShiftByID(shiftID, storeID uint) (*data.CashShift, error)
+	GetOpenShift(storeEmployeeID uint) (*data.CashShift, error)
+	GetShifts(filter *types.ShiftsFilter) ([]data.CashShift, error)
+	CloseShift(shift *data.CashShift) error
+
+	GetShiftPaymentTotals(shiftID uint) ([]types.ShiftPaymentTotal, error)
+	GetShiftOrderTotals(shiftID uint) ([]types.ShiftOrderTotal, error)
+}
+
+type shiftRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) ShiftRepository {
+	return &shiftRepository{db: db}
+}
+
+func (r *shiftRepository) GetStoreEmployeeByEmployeeID(storeID, employeeID uint) (*data.StoreEmployee, error) {
+	var storeEmployee data.StoreEmployee
+	err := r.db.
+		Preload("Employee").
+		Joins("JOIN employees ON employees.id = store_employees.employee_id").
+		Where("store_employees.employee_id = ? AND store_employees.store_id = ?", employeeID, storeID).
+		Where("employees.is_active = ?", true).
+		First(&storeEmployee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store employee of employee %d: %w", employeeID, err)
+	}
+	return &storeEmployee, nil
+}
+
+func (r *shiftRepository) CreateShift(shift *data.CashShift) error {
+	if err := r.db.Create(shift).Error; err != nil {
+		return fmt.Errorf("failed to create cash shift: %w", err)
+	}
+	return nil
+}
+
+func (r *shiftRepository) GetShiftByID(shiftID, storeID uint) (*data.CashShift, error) {
+	var shift data.CashShift
+	err := r.db.
+		Preload("Store").
+		Preload("StoreEmployee.Employee").
+		Where("id = ? AND store_id = ?", shiftID, storeID).
+		First(&shift).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrShiftNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch cash shift %d: %w", shiftID, err)
+	}
+	return &shift, nil
+}
+
+func (r *shiftRepository) GetOpenShift(storeEmployeeID uint) (*data.CashShift, error) {
+	var shift data.CashShift
+	err := r.db.
+		Preload("Store").
+		Preload("StoreEmployee.Employee").
+		Where("store_employee_id = ? AND status = ?", storeEmployeeID, data.CashShiftStatusOpen).
+		First(&shift).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrShiftNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch open cash shift of store employee %d: %w", storeEmployeeID, err)
+	}
+	return &shift, nil
+}
+
+func (r *shiftRepository) GetShifts(filter *types.ShiftsFilter) ([]data.CashShift, error) {
+	var shifts []data.CashShift
+
+	query := r.db.Model(&data.CashShift{}).
+		Preload("Store").
+		Preload("StoreEmployee.Employee")
+
+	if filter.StoreID != nil {
+		query = query.Where("store_id = ?", *filter.StoreID)
+	}
+
+	if filter.StoreEmployeeID != nil {
+		query = query.Where("store_employee_id = ?", *filter.StoreEmployeeID)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.CashShift{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&shifts).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch cash shifts: %w", err)
+	}
+	return shifts, nil
+}
+
+// CloseShift saves the closing figures only if the shift is still open, so the concurrent closes can not overwrite the Z report
+func (r *shiftRepository) CloseShift(shift *data.CashShift) error {
+	res := r.db.Model(&data.CashShift{}).
+		Where("id = ? AND status = ?", shift.ID, data.CashShiftStatusOpen).
+		Updates(map[string]interface{}{
+			"status":        data.CashShiftStatusClosed,
+			"counted_cash":  shift.CountedCash,
+			"expected_cash": shift.ExpectedCash,
+			"cash_variance": shift.CashVariance,
+			"closed_at":     shift.ClosedAt,
+			"report":        shift.Report,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to close cash shift %d: %w", shift.ID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return types.ErrShiftClosed
+	}
+	return nil
+}
+
+func (r *shiftRepository) GetShiftPaymentTotals(shiftID uint) ([]types.ShiftPaymentTotal, error) {
+	var totals []types.ShiftPaymentTotal
+	err := r.db.Model(&data.Transaction{}).
+		Select("UPPER(transactions.payment_method) AS payment_method, transactions.type, COUNT(*) AS count, SUM(transactions.amount) AS amount").
+		Where("transactions.cash_shift_id = ?", shiftID).
+		Group("UPPER(transactions.payment_method), transactions.type").
+		Order("payment_method").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum transactions of cash shift %d: %w", shiftID, err)
+	}
+	return totals, nil
+}
+
+func (r *shiftRepository) GetShiftOrderTotals(shiftID uint) ([]types.ShiftOrderTotal, error) {
+	var totals []types.ShiftOrderTotal
+	err := r.db.Model(&data.Order{}).
+		Select("status, COUNT(*) AS count, SUM(total) AS amount").
+		Where("cash_shift_id = ?", shiftID).
+		Group("status").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum orders of cash shift %d: %w", shiftID, err)
+	}
+	return totals, nil
+}
@@ -0,0 +1,178 @@
+package shifts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/shifts/types"
+	"go.uber.org/zap"
+)
+
+type ShiftService interface {
+	OpenShift(storeID, employeeID uint, dto *types.OpenShiftDTO) (*types.CashShiftDTO, error)
+	GetCurrentShift(storeID, employeeID uint) (*types.CashShiftDTO, error)
+	GetShifts(filter *types.ShiftsFilter) ([]types.CashShiftDTO, error)
+	CloseShift(shiftID, storeID uint, closer *types.ShiftCloser, dto *types.CloseShiftDTO) (*types.ShiftReportDTO, error)
+	GetShiftReport(shiftID, storeID uint) (*types.ShiftReportDTO, error)
+}
+
+type shiftService struct {
+	repo   ShiftRepository
+	logger *zap.SugaredLogger
+}
+
+func NewShiftService(repo ShiftRepository, logger *zap.SugaredLogger) ShiftService {
+	return &shiftService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *shiftService) OpenShift(storeID, employeeID uint, dto *types.OpenShiftDTO) (*types.CashShiftDTO, error) {
+	storeEmployee, err := s.repo.GetStoreEmployeeByEmployeeID(storeID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	openShift, err := s.repo.GetOpenShift(storeEmployee.ID)
+	if err != nil && !errors.Is(err, types.ErrShiftNotFound) {
+		s.logger.Error(err)
+		return nil, err
+	}
+	if openShift != nil {
+		return nil, types.ErrShiftAlreadyOpen
+	}
+
+	shift := &data.CashShift{
+		StoreID:         storeID,
+		StoreEmployeeID: storeEmployee.ID,
+		Status:          data.CashShiftStatusOpen,
+		OpeningFloat:    dto.OpeningFloat,
+		OpenedAt:        time.Now().UTC(),
+	}
+	if err := s.repo.CreateShift(shift); err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	createdShift, err := s.repo.GetShiftByID(shift.ID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertCashShiftToDTO(createdShift)
+	return &response, nil
+}
+
+func (s *shiftService) GetCurrentShift(storeID, employeeID uint) (*types.CashShiftDTO, error) {
+	storeEmployee, err := s.repo.GetStoreEmployeeByEmployeeID(storeID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	shift, err := s.repo.GetOpenShift(storeEmployee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertCashShiftToDTO(shift)
+	return &response, nil
+}
+
+func (s *shiftService) GetShifts(filter *types.ShiftsFilter) ([]types.CashShiftDTO, error) {
+	shifts, err := s.repo.GetShifts(filter)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return types.ConvertCashShiftsToDTOs(shifts), nil
+}
+
+// CloseShift counts the cash and saves the Z report figures
+func (s *shiftService) CloseShift(shiftID, storeID uint, closer *types.ShiftCloser, dto *types.CloseShiftDTO) (*types.ShiftReportDTO, error) {
+	shift, err := s.repo.GetShiftByID(shiftID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shift.Status != data.CashShiftStatusOpen {
+		return nil, types.ErrShiftClosed
+	}
+
+	if shift.StoreEmployee.EmployeeID != closer.EmployeeID && !closer.CanCloseOther {
+		return nil, types.ErrShiftNotOwned
+	}
+
+	countedCash := dto.CountedCash
+	report, err := s.buildReport(shift, &countedCash)
+	if err != nil {
+		return nil, err
+	}
+
+	closedAt := report.GeneratedAt
+	shift.Status = data.CashShiftStatusClosed
+	shift.CountedCash = report.Cash.CountedCash
+	shift.ExpectedCash = &report.Cash.ExpectedCash
+	shift.CashVariance = report.Cash.Variance
+	shift.ClosedAt = &closedAt
+	report.Shift = types.ConvertCashShiftToDTO(shift)
+
+	// the Z report is frozen at the close, the later refunds and cancellations do not change it
+	shift.Report, err = json.Marshal(report)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to save the report of cash shift %d: %w", shift.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if err := s.repo.CloseShift(shift); err != nil {
+		if !errors.Is(err, types.ErrShiftClosed) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GetShiftReport returns the X report of an open shift and the Z report of a closed one
+func (s *shiftService) GetShiftReport(shiftID, storeID uint) (*types.ShiftReportDTO, error) {
+	shift, err := s.repo.GetShiftByID(shiftID, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shift.Status == data.CashShiftStatusClosed && len(shift.Report) > 0 {
+		report, err := types.ConvertStoredShiftReport(shift)
+		if err != nil {
+			wrappedErr := fmt.Errorf("failed to read the saved report of cash shift %d: %w", shift.ID, err)
+			s.logger.Error(wrappedErr)
+			return nil, wrappedErr
+		}
+		return report, nil
+	}
+
+	return s.buildReport(shift, nil)
+}
+
+func (s *shiftService) buildReport(shift *data.CashShift, countedCash *float64) (*types.ShiftReportDTO, error) {
+	payments, err := s.repo.GetShiftPaymentTotals(shift.ID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to build the report of cash shift %d: %w", shift.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	orders, err := s.repo.GetShiftOrderTotals(shift.ID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to build the report of cash shift %d: %w", shift.ID, err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return types.BuildShiftReport(shift, payments, orders, countedCash, time.Now().UTC()), nil
+}
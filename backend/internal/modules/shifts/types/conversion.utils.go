@@ -0,0 +1,79 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+)
+
+func ConvertCashShiftToDTO(shift *data.CashShift) CashShiftDTO {
+	return CashShiftDTO{
+		ID:        shift.ID,
+		StoreID:   shift.StoreID,
+		StoreName: shift.Store.Name,
+		Employee: ShiftEmployeeDTO{
+			StoreEmployeeID: shift.StoreEmployeeID,
+			EmployeeID:      shift.StoreEmployee.EmployeeID,
+			FirstName:       shift.StoreEmployee.Employee.FirstName,
+			LastName:        shift.StoreEmployee.Employee.LastName,
+		},
+		Status:       shift.Status,
+		OpeningFloat: shift.OpeningFloat,
+		CountedCash:  shift.CountedCash,
+		ExpectedCash: shift.ExpectedCash,
+		CashVariance: shift.CashVariance,
+		OpenedAt:     shift.OpenedAt,
+		ClosedAt:     shift.ClosedAt,
+	}
+}
+
+func ConvertCashShiftsToDTOs(shifts []data.CashShift) []CashShiftDTO {
+	dtos := make([]CashShiftDTO, len(shifts))
+	for i := range shifts {
+		dtos[i] = ConvertCashShiftToDTO(&shifts[i])
+	}
+	return dtos
+}
+
+func ToPDFShiftReport(report *ShiftReportDTO) pdf.PDFShiftReportDetails {
+	const dateLayout = "2006-01-02 15:04:05"
+
+	details := pdf.PDFShiftReportDetails{
+		ReportType:      string(report.Type),
+		ShiftID:         report.Shift.ID,
+		StoreName:       report.Shift.StoreName,
+		EmployeeName:    report.Shift.Employee.FirstName + " " + report.Shift.Employee.LastName,
+		OpenedAt:        report.Shift.OpenedAt.Format(dateLayout),
+		GeneratedAt:     report.GeneratedAt.Format(dateLayout),
+		OrdersCount:     report.OrdersCount,
+		SalesTotal:      report.SalesTotal,
+		Payments:        toPDFPaymentMethodTotals(report.Payments),
+		PaymentsTotal:   report.PaymentsTotal,
+		Refunds:         toPDFPaymentMethodTotals(report.Refunds),
+		RefundsTotal:    report.RefundsTotal,
+		CancelledCount:  report.CancelledOrders.Count,
+		CancelledAmount: report.CancelledOrders.Amount,
+		OpeningFloat:    report.Cash.OpeningFloat,
+		CashPayments:    report.Cash.CashPayments,
+		CashRefunds:     report.Cash.CashRefunds,
+		ExpectedCash:    report.Cash.ExpectedCash,
+		CountedCash:     report.Cash.CountedCash,
+		CashVariance:    report.Cash.Variance,
+	}
+	if report.Shift.ClosedAt != nil {
+		details.ClosedAt = report.Shift.ClosedAt.Format(dateLayout)
+	}
+
+	return details
+}
+
+func toPDFPaymentMethodTotals(totals []PaymentMethodTotalDTO) []pdf.PDFPaymentMethodTotal {
+	pdfTotals := make([]pdf.PDFPaymentMethodTotal, len(totals))
+	for i, total := range totals {
+		pdfTotals[i] = pdf.PDFPaymentMethodTotal{
+			PaymentMethod: total.PaymentMethod,
+			Count:         total.Count,
+			Amount:        total.Amount,
+		}
+	}
+	return pdfTotals
+}
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrShiftNotFound         = moduleErrors.NewModuleError(errors.New("cash shift not found"))
+	ErrShiftAlreadyOpen      = moduleErrors.NewModuleError(errors.New("employee already has an open cash shift"))
+	ErrShiftClosed           = moduleErrors.NewModuleError(errors.New("cash shift is already closed"))
+	ErrShiftNotOwned         = moduleErrors.NewModuleError(errors.New("cash shift belongs to another employee"))
+	ErrStoreEmployeeNotFound = moduleErrors.NewModuleError(errors.New("store employee not found"))
+)
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// BuildShiftReport sums up the shift, the cash variance is known only when the cash was counted
+func BuildShiftReport(shift *data.CashShift, payments []ShiftPaymentTotal, orders []ShiftOrderTotal, countedCash *float64, generatedAt time.Time) *ShiftReportDTO {
+	report := &ShiftReportDTO{
+		Type:        ShiftReportTypeX,
+		Shift:       ConvertCashShiftToDTO(shift),
+		Payments:    make([]PaymentMethodTotalDTO, 0),
+		Refunds:     make([]PaymentMethodTotalDTO, 0),
+		GeneratedAt: generatedAt,
+	}
+	if shift.Status == data.CashShiftStatusClosed || countedCash != nil {
+		report.Type = ShiftReportTypeZ
+	}
+
+	for _, total := range orders {
+		switch total.Status {
+		case data.OrderStatusWaitingForPayment:
+			continue
+		case data.OrderStatusCancelled:
+			report.CancelledOrders.Count += total.Count
+			report.CancelledOrders.Amount += total.Amount
+		default:
+			report.OrdersCount += total.Count
+			report.SalesTotal += total.Amount
+		}
+	}
+
+	cash := ShiftCashDTO{OpeningFloat: shift.OpeningFloat}
+	for _, total := range payments {
+		methodTotal := PaymentMethodTotalDTO{
+			PaymentMethod: total.PaymentMethod,
+			Count:         total.Count,
+			Amount:        utils.RoundToDecimal(total.Amount, 2),
+		}
+		isCash := total.PaymentMethod == data.PaymentMethodCash
+
+		switch total.Type {
+		case data.TransactionTypePayment:
+			report.Payments = append(report.Payments, methodTotal)
+			report.PaymentsTotal += total.Amount
+			if isCash {
+				cash.CashPayments += total.Amount
+			}
+		case data.TransactionTypeRefund:
+			report.Refunds = append(report.Refunds, methodTotal)
+			report.RefundsTotal += total.Amount
+			if isCash {
+				cash.CashRefunds += total.Amount
+			}
+		}
+	}
+
+	cash.CashPayments = utils.RoundToDecimal(cash.CashPayments, 2)
+	cash.CashRefunds = utils.RoundToDecimal(cash.CashRefunds, 2)
+	cash.ExpectedCash = utils.RoundToDecimal(cash.OpeningFloat+cash.CashPayments-cash.CashRefunds, 2)
+
+	if countedCash == nil {
+		countedCash = shift.CountedCash
+	}
+	// the closed shift keeps the expected cash and the variance it was closed with
+	if shift.Status == data.CashShiftStatusClosed && shift.ExpectedCash != nil {
+		cash.ExpectedCash = *shift.ExpectedCash
+		cash.CountedCash = countedCash
+		cash.Variance = shift.CashVariance
+	} else if countedCash != nil {
+		variance := utils.RoundToDecimal(*countedCash-cash.ExpectedCash, 2)
+		cash.CountedCash = countedCash
+		cash.Variance = &variance
+	}
+
+	report.SalesTotal = utils.RoundToDecimal(report.SalesTotal, 2)
+	report.PaymentsTotal = utils.RoundToDecimal(report.PaymentsTotal, 2)
+	report.RefundsTotal = utils.RoundToDecimal(report.RefundsTotal, 2)
+	report.CancelledOrders.Amount = utils.RoundToDecimal(report.CancelledOrders.Amount, 2)
+	report.Cash = cash
+
+	return report
+}
+
+// ConvertStoredShiftReport returns the Z report saved on the close of the shift, only the shift details are refreshed
+func ConvertStoredShiftReport(shift *data.CashShift) (*ShiftReportDTO, error) {
+	var report ShiftReportDTO
+	if err := json.Unmarshal(shift.Report, &report); err != nil {
+		return nil, err
+	}
+
+	report.Type = ShiftReportTypeZ
+	report.Shift = ConvertCashShiftToDTO(shift)
+	return &report, nil
+}
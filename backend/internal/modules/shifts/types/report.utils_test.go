@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildShiftReport(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+
+	openedAt := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	generatedAt := openedAt.Add(8 * time.Hour)
+
+	payments := []ShiftPaymentTotal{
+		{PaymentMethod: "CARD", Type: data.TransactionTypePayment, Count: 3, Amount: 4500},
+		{PaymentMethod: data.PaymentMethodCash, Type: data.TransactionTypePayment, Count: 2, Amount: 2000},
+		{PaymentMethod: data.PaymentMethodCash, Type: data.TransactionTypeRefund, Count: 1, Amount: 500},
+	}
+	orders := []ShiftOrderTotal{
+		{Status: data.OrderStatusCompleted, Count: 4, Amount: 6000},
+		{Status: data.OrderStatusRefunded, Count: 1, Amount: 500},
+		{Status: data.OrderStatusCancelled, Count: 1, Amount: 700},
+		{Status: data.OrderStatusWaitingForPayment, Count: 2, Amount: 1200},
+	}
+
+	t.Run("Open shift should produce X report without variance", func(t *testing.T) {
+		shift := &data.CashShift{Status: data.CashShiftStatusOpen, OpeningFloat: 10000, OpenedAt: openedAt}
+
+		report := BuildShiftReport(shift, payments, orders, nil, generatedAt)
+
+		assert.Equal(t, ShiftReportTypeX, report.Type)
+		assert.Equal(t, 5, report.OrdersCount)
+		assert.Equal(t, 6500.0, report.SalesTotal)
+		assert.Equal(t, 6500.0, report.PaymentsTotal)
+		assert.Equal(t, 500.0, report.RefundsTotal)
+		assert.Equal(t, ShiftCountTotalDTO{Count: 1, Amount: 700}, report.CancelledOrders)
+		assert.Equal(t, 11500.0, report.Cash.ExpectedCash)
+		assert.Nil(t, report.Cash.Variance)
+	})
+
+	t.Run("Closing shift should produce Z report with variance", func(t *testing.T) {
+		shift := &data.CashShift{Status: data.CashShiftStatusOpen, OpeningFloat: 10000, OpenedAt: openedAt}
+
+		report := BuildShiftReport(shift, payments, orders, floatPtr(11450), generatedAt)
+
+		assert.Equal(t, ShiftReportTypeZ, report.Type)
+		if assert.NotNil(t, report.Cash.Variance) {
+			assert.Equal(t, -50.0, *report.Cash.Variance)
+		}
+	})
+
+	t.Run("Closed shift should reuse the counted cash", func(t *testing.T) {
+		shift := &data.CashShift{Status: data.CashShiftStatusClosed, OpeningFloat: 10000, CountedCash: floatPtr(11500), OpenedAt: openedAt}
+
+		report := BuildShiftReport(shift, payments, orders, nil, generatedAt)
+
+		assert.Equal(t, ShiftReportTypeZ, report.Type)
+		if assert.NotNil(t, report.Cash.Variance) {
+			assert.Equal(t, 0.0, *report.Cash.Variance)
+		}
+	})
+	t.Run("Closed shift should keep the cash figures of the close", func(t *testing.T) {
+		shift := &data.CashShift{
+			Status:       data.CashShiftStatusClosed,
+			OpeningFloat: 10000,
+			CountedCash:  floatPtr(11450),
+			ExpectedCash: floatPtr(11500),
+			CashVariance: floatPtr(-50),
+			OpenedAt:     openedAt,
+		}
+		laterRefund := append(payments, ShiftPaymentTotal{PaymentMethod: data.PaymentMethodCash, Type: data.TransactionTypeRefund, Count: 1, Amount: 300})
+
+		report := BuildShiftReport(shift, laterRefund, orders, nil, generatedAt)
+
+		assert.Equal(t, 11500.0, report.Cash.ExpectedCash)
+		if assert.NotNil(t, report.Cash.Variance) {
+			assert.Equal(t, -50.0, *report.Cash.Variance)
+		}
+	})
+}
+
+func TestConvertStoredShiftReport(t *testing.T) {
+	openedAt := time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	closedAt := openedAt.Add(8 * time.Hour)
+	shift := &data.CashShift{Status: data.CashShiftStatusClosed, OpeningFloat: 10000, OpenedAt: openedAt, ClosedAt: &closedAt}
+
+	closed := BuildShiftReport(shift, []ShiftPaymentTotal{
+		{PaymentMethod: data.PaymentMethodCash, Type: data.TransactionTypePayment, Count: 1, Amount: 1500},
+	}, nil, nil, closedAt)
+
+	stored, err := json.Marshal(closed)
+	assert.NoError(t, err)
+	shift.Report = stored
+
+	report, err := ConvertStoredShiftReport(shift)
+	assert.NoError(t, err)
+	assert.Equal(t, ShiftReportTypeZ, report.Type)
+	assert.Equal(t, closed.PaymentsTotal, report.PaymentsTotal)
+	assert.Equal(t, closed.Cash, report.Cash)
+	assert.True(t, closedAt.Equal(report.GeneratedAt), "The report should keep the time of the close")
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500ShiftOpen   = localization.NewResponseKey(http.StatusInternalServerError, data.CashShiftComponent, data.CreateOperation.ToString())
+	Response500ShiftGet    = localization.NewResponseKey(http.StatusInternalServerError, data.CashShiftComponent, data.GetOperation.ToString())
+	Response500ShiftClose  = localization.NewResponseKey(http.StatusInternalServerError, data.CashShiftComponent, data.UpdateOperation.ToString())
+	Response500ShiftReport = localization.NewResponseKey(http.StatusInternalServerError, data.CashShiftComponent, "REPORT")
+
+	Response400Shift       = localization.NewResponseKey(http.StatusBadRequest, data.CashShiftComponent)
+	Response403Shift       = localization.NewResponseKey(http.StatusForbidden, data.CashShiftComponent)
+	Response404Shift       = localization.NewResponseKey(http.StatusNotFound, data.CashShiftComponent)
+	Response409ShiftOpen   = localization.NewResponseKey(http.StatusConflict, data.CashShiftComponent, "OPEN")
+	Response409ShiftClosed = localization.NewResponseKey(http.StatusConflict, data.CashShiftComponent, "CLOSED")
+
+	Response201Shift      = localization.NewResponseKey(http.StatusCreated, data.CashShiftComponent)
+	Response200ShiftClose = localization.NewResponseKey(http.StatusOK, data.CashShiftComponent, data.UpdateOperation.ToString())
+)
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	OpenShiftAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.CashShiftComponent, &OpenShiftDTO{})
+
+	CloseShiftAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.UpdateOperation, data.CashShiftComponent, &CloseShiftDTO{})
+)
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type ShiftReportType string
+
+const (
+	// ShiftReportTypeX is the interim report of an open shift, it can be printed any number of times
+	ShiftReportTypeX ShiftReportType = "X"
+	// ShiftReportTypeZ is the final report of a closed shift
+	ShiftReportTypeZ ShiftReportType = "Z"
+)
+
+type OpenShiftDTO struct {
+	OpeningFloat float64 `json:"openingFloat" binding:"gte=0"`
+}
+
+type CloseShiftDTO struct {
+	CountedCash float64 `json:"countedCash" binding:"gte=0"`
+}
+
+type ShiftsFilter struct {
+	StoreID         *uint                 `form:"storeId" binding:"omitempty"`
+	StoreEmployeeID *uint                 `form:"storeEmployeeId" binding:"omitempty,gt=0"`
+	Status          *data.CashShiftStatus `form:"status" binding:"omitempty,oneof=OPEN CLOSED"`
+	utils.BaseFilter
+}
+
+// ShiftCloser is the employee closing the shift, only the store management can close the shifts of the other employees
+type ShiftCloser struct {
+	EmployeeID    uint
+	CanCloseOther bool
+}
+
+type CashShiftDTO struct {
+	ID           uint                 `json:"id"`
+	StoreID      uint                 `json:"storeId"`
+	StoreName    string               `json:"storeName"`
+	Employee     ShiftEmployeeDTO     `json:"employee"`
+	Status       data.CashShiftStatus `json:"status"`
+	OpeningFloat float64              `json:"openingFloat"`
+	CountedCash  *float64             `json:"countedCash,omitempty"`
+	ExpectedCash *float64             `json:"expectedCash,omitempty"`
+	CashVariance *float64             `json:"cashVariance,omitempty"`
+	OpenedAt     time.Time            `json:"openedAt"`
+	ClosedAt     *time.Time           `json:"closedAt,omitempty"`
+}
+
+type ShiftEmployeeDTO struct {
+	StoreEmployeeID uint   `json:"storeEmployeeId"`
+	EmployeeID      uint   `json:"employeeId"`
+	FirstName       string `json:"firstName"`
+	LastName        string `json:"lastName"`
+}
+
+// ShiftPaymentTotal is the sum of the shift transactions of one type paid with one method
+type ShiftPaymentTotal struct {
+	PaymentMethod string
+	Type          data.TransactionType
+	Count         int
+	Amount        float64
+}
+
+// ShiftOrderTotal is the sum of the shift orders in one status
+type ShiftOrderTotal struct {
+	Status data.OrderStatus
+	Count  int
+	Amount float64
+}
+
+type ShiftReportDTO struct {
+	Type            ShiftReportType         `json:"type"`
+	Shift           CashShiftDTO            `json:"shift"`
+	OrdersCount     int                     `json:"ordersCount"`
+	SalesTotal      float64                 `json:"salesTotal"`
+	Payments        []PaymentMethodTotalDTO `json:"payments"`
+	PaymentsTotal   float64                 `json:"paymentsTotal"`
+	Refunds         []PaymentMethodTotalDTO `json:"refunds"`
+	RefundsTotal    float64                 `json:"refundsTotal"`
+	CancelledOrders ShiftCountTotalDTO      `json:"cancelledOrders"`
+	Cash            ShiftCashDTO            `json:"cash"`
+	GeneratedAt     time.Time               `json:"generatedAt"`
+}
+
+type PaymentMethodTotalDTO struct {
+	PaymentMethod string  `json:"paymentMethod"`
+	Count         int     `json:"count"`
+	Amount        float64 `json:"amount"`
+}
+
+type ShiftCountTotalDTO struct {
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+type ShiftCashDTO struct {
+	OpeningFloat float64  `json:"openingFloat"`
+	CashPayments float64  `json:"cashPayments"`
+	CashRefunds  float64  `json:"cashRefunds"`
+	ExpectedCash float64  `json:"expectedCash"`
+	CountedCash  *float64 `json:"countedCash,omitempty"`
+	Variance     *float64 `json:"variance,omitempty"`
+}
@@ -0,0 +1,91 @@
+package stockMovements
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type StockMovementHandler struct {
+	service           StockMovementService
+	franchiseeService franchisees.FranchiseeService
+	regionService     regions.RegionService
+}
+
+func NewStockMovementHandler(
+	service StockMovementService,
+	franchiseeService franchisees.FranchiseeService,
+	regionService regions.RegionService,
+) *StockMovementHandler {
+	return &StockMovementHandler{
+		service:           service,
+		franchiseeService: franchiseeService,
+		regionService:     regionService,
+	}
+}
+
+func (h *StockMovementHandler) GetStoreStockMovements(c *gin.Context) {
+	storeStockID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StockMovement)
+		return
+	}
+
+	storeID, errH := h.franchiseeService.CheckFranchiseeStore(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, errH.Status())
+		return
+	}
+
+	var filter types.StockMovementsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.StockMovement{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	movements, err := h.service.GetStoreStockMovements(storeID, storeStockID, &filter)
+	if err != nil {
+		if errors.Is(err, types.ErrStockNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StockMovement)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StockMovementGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, movements, filter.Pagination)
+}
+
+func (h *StockMovementHandler) GetWarehouseStockMovements(c *gin.Context) {
+	stockMaterialID, err := utils.ParseParam(c, "stockMaterialId")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StockMovement)
+		return
+	}
+
+	warehouseID, errH := h.regionService.CheckRegionWarehouse(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, errH.Status())
+		return
+	}
+
+	var filter types.StockMovementsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.StockMovement{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	movements, err := h.service.GetWarehouseStockMovements(warehouseID, stockMaterialID, &filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StockMovementGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, movements, filter.Pagination)
+}
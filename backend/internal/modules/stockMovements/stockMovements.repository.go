@@ -0,0 +1,103 @@
+package stockMovements
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// StockMovementRepository is the only writer of the movement ledger, the movements are created and never updated or deleted
+type StockMovementRepository interface {
+	CloneWithTransaction(tx *gorm.DB) StockMovementRepository
+
+	CreateMovements(movements ...data.StockMovement) error
+	GetStoreStock(storeID, storeStockID uint) (*data.StoreStock, error)
+	GetStockMovements(filter *types.StockMovementsFilter) ([]data.StockMovement, error)
+}
+
+type stockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{db: db}
+}
+
+func (r *stockMovementRepository) CloneWithTransaction(tx *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{db: tx}
+}
+
+// CreateMovements skips the movements not changing the quantity, so the callers may pass every touched stock
+func (r *stockMovementRepository) CreateMovements(movements ...data.StockMovement) error {
+	toCreate := make([]data.StockMovement, 0, len(movements))
+	for _, movement := range movements {
+		if movement.Quantity != 0 {
+			toCreate = append(toCreate, movement)
+		}
+	}
+
+	if len(toCreate) == 0 {
+		return nil
+	}
+
+	if err := r.db.Create(&toCreate).Error; err != nil {
+		return fmt.Errorf("failed to create stock movements: %w", err)
+	}
+	return nil
+}
+
+func (r *stockMovementRepository) GetStoreStock(storeID, storeStockID uint) (*data.StoreStock, error) {
+	var stock data.StoreStock
+	err := r.db.Where("id = ? AND store_id = ?", storeStockID, storeID).First(&stock).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStockNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store stock %d: %w", storeStockID, err)
+	}
+	return &stock, nil
+}
+
+func (r *stockMovementRepository) GetStockMovements(filter *types.StockMovementsFilter) ([]data.StockMovement, error) {
+	var movements []data.StockMovement
+
+	query := r.db.Model(&data.StockMovement{}).
+		Preload("Employee")
+
+	if filter.StoreID != nil {
+		query = query.Where("stock_movements.store_id = ?", *filter.StoreID)
+	}
+	if filter.IngredientID != nil {
+		query = query.Where("stock_movements.ingredient_id = ?", *filter.IngredientID)
+	}
+	if filter.WarehouseID != nil {
+		query = query.Where("stock_movements.warehouse_id = ?", *filter.WarehouseID)
+	}
+	if filter.StockMaterialID != nil {
+		query = query.Where("stock_movements.stock_material_id = ?", *filter.StockMaterialID)
+	}
+	if filter.SourceType != nil {
+		query = query.Where("stock_movements.source_type = ?", *filter.SourceType)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("stock_movements.created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("stock_movements.created_at <= ?", *filter.EndDate)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.StockMovement{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&movements).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch stock movements: %w", err)
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,62 @@
+package stockMovements
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
+	"go.uber.org/zap"
+)
+
+type StockMovementService interface {
+	GetStoreStockMovements(storeID, storeStockID uint, filter *types.StockMovementsFilter) ([]types.StockMovementDTO, error)
+	GetWarehouseStockMovements(warehouseID, stockMaterialID uint, filter *types.StockMovementsFilter) ([]types.StockMovementDTO, error)
+}
+
+type stockMovementService struct {
+	repo   StockMovementRepository
+	logger *zap.SugaredLogger
+}
+
+func NewStockMovementService(repo StockMovementRepository, logger *zap.SugaredLogger) StockMovementService {
+	return &stockMovementService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetStoreStockMovements lists the movements of the ingredient of the store stock, the store stock must belong to the store
+func (s *stockMovementService) GetStoreStockMovements(storeID, storeStockID uint, filter *types.StockMovementsFilter) ([]types.StockMovementDTO, error) {
+	stock, err := s.repo.GetStoreStock(storeID, storeStockID)
+	if err != nil {
+		if errors.Is(err, types.ErrStockNotFound) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchStockMovements, err))
+		return nil, types.ErrFailedToFetchStockMovements
+	}
+
+	filter.StoreID = &storeID
+	filter.IngredientID = &stock.IngredientID
+	return s.getStockMovements(filter)
+}
+
+func (s *stockMovementService) GetWarehouseStockMovements(warehouseID, stockMaterialID uint, filter *types.StockMovementsFilter) ([]types.StockMovementDTO, error) {
+	filter.WarehouseID = &warehouseID
+	filter.StockMaterialID = &stockMaterialID
+	return s.getStockMovements(filter)
+}
+
+func (s *stockMovementService) getStockMovements(filter *types.StockMovementsFilter) ([]types.StockMovementDTO, error) {
+	movements, err := s.repo.GetStockMovements(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchStockMovements, err))
+		return nil, types.ErrFailedToFetchStockMovements
+	}
+
+	responses := make([]types.StockMovementDTO, len(movements))
+	for i := range movements {
+		responses[i] = types.ConvertToStockMovementDTO(&movements[i])
+	}
+	return responses, nil
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+func ConvertToStockMovementDTO(movement *data.StockMovement) StockMovementDTO {
+	dto := StockMovementDTO{
+		ID:             movement.ID,
+		SourceType:     movement.SourceType,
+		SourceID:       movement.SourceID,
+		QuantityBefore: movement.QuantityBefore,
+		QuantityAfter:  movement.QuantityAfter,
+		Quantity:       movement.Quantity,
+		CreatedAt:      movement.CreatedAt,
+	}
+
+	if movement.Employee != nil {
+		dto.Employee = &StockMovementEmployeeDTO{
+			ID:        movement.Employee.ID,
+			FirstName: movement.Employee.FirstName,
+			LastName:  movement.Employee.LastName,
+		}
+	}
+
+	return dto
+}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrStockNotFound = moduleErrors.NewModuleError(errors.New("stock not found"))
+
+	ErrFailedToFetchStockMovements = moduleErrors.NewModuleError(errors.New("failed to fetch stock movements"))
+)
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500StockMovementGet = localization.NewResponseKey(http.StatusInternalServerError, data.StockMovementComponent, data.GetOperation.ToString())
+
+	Response400StockMovement = localization.NewResponseKey(http.StatusBadRequest, data.StockMovementComponent)
+	Response404StockMovement = localization.NewResponseKey(http.StatusNotFound, data.StockMovementComponent)
+)
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type StockMovementDTO struct {
+	ID             uint                         `json:"id"`
+	SourceType     data.StockMovementSourceType `json:"sourceType"`
+	SourceID       *uint                        `json:"sourceId"`
+	QuantityBefore float64                      `json:"quantityBefore"`
+	QuantityAfter  float64                      `json:"quantityAfter"`
+	Quantity       float64                      `json:"quantity"`
+	Employee       *StockMovementEmployeeDTO    `json:"employee"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+}
+
+type StockMovementEmployeeDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type StockMovementsFilter struct {
+	utils.BaseFilter
+	StoreID         *uint                         `form:"-"`
+	IngredientID    *uint                         `form:"-"`
+	WarehouseID     *uint                         `form:"-"`
+	StockMaterialID *uint                         `form:"-"`
+	SourceType      *data.StockMovementSourceType `form:"sourceType" binding:"omitempty,oneof=ORDER ORDER_REFUND STORE_PROVISION STOCK_REQUEST SUPPLIER_DELIVERY STOCKTAKE MANUAL"`
+	StartDate       *time.Time                    `form:"startDate" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndDate         *time.Time                    `form:"endDate" time_format:"2006-01-02T15:04:05Z07:00"`
+}
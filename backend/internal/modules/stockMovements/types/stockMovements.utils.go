@@ -0,0 +1,36 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// NewStoreStockMovement records the change of the store stock of the ingredient, the quantities are in the ingredient units
+func NewStoreStockMovement(source data.StockMovementSource, storeID, ingredientID uint, before, after float64) data.StockMovement {
+	movement := newStockMovement(source, before, after)
+	movement.StoreID = &storeID
+	movement.IngredientID = &ingredientID
+	return movement
+}
+
+// NewWarehouseStockMovement records the change of the warehouse stock of the stock material, the quantities are in packages
+func NewWarehouseStockMovement(source data.StockMovementSource, warehouseID, stockMaterialID uint, before, after float64) data.StockMovement {
+	movement := newStockMovement(source, before, after)
+	movement.WarehouseID = &warehouseID
+	movement.StockMaterialID = &stockMaterialID
+	return movement
+}
+
+func newStockMovement(source data.StockMovementSource, before, after float64) data.StockMovement {
+	before = utils.RoundToDecimal(before, 2)
+	after = utils.RoundToDecimal(after, 2)
+
+	return data.StockMovement{
+		SourceType:     source.Type,
+		SourceID:       source.ID,
+		EmployeeID:     source.EmployeeID,
+		QuantityBefore: before,
+		QuantityAfter:  after,
+		Quantity:       utils.RoundToDecimal(after-before, 2),
+	}
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStoreStockMovement(t *testing.T) {
+	orderID, employeeID := uint(5), uint(9)
+	source := data.StockMovementSource{Type: data.StockMovementSourceOrder, ID: &orderID, EmployeeID: &employeeID}
+
+	movement := NewStoreStockMovement(source, 1, 2, 10.5, 10.2)
+
+	assert.Equal(t, uint(1), *movement.StoreID)
+	assert.Equal(t, uint(2), *movement.IngredientID)
+	assert.Nil(t, movement.WarehouseID)
+	assert.Nil(t, movement.StockMaterialID)
+	assert.Equal(t, data.StockMovementSourceOrder, movement.SourceType)
+	assert.Equal(t, orderID, *movement.SourceID)
+	assert.Equal(t, employeeID, *movement.EmployeeID)
+	assert.Equal(t, 10.5, movement.QuantityBefore)
+	assert.Equal(t, 10.2, movement.QuantityAfter)
+	assert.Equal(t, -0.3, movement.Quantity)
+}
+
+func TestNewWarehouseStockMovement(t *testing.T) {
+	source := data.StockMovementSource{Type: data.StockMovementSourceManual}
+
+	movement := NewWarehouseStockMovement(source, 3, 4, 1.004, 3.5)
+
+	assert.Equal(t, uint(3), *movement.WarehouseID)
+	assert.Equal(t, uint(4), *movement.StockMaterialID)
+	assert.Nil(t, movement.StoreID)
+	assert.Nil(t, movement.IngredientID)
+	assert.Nil(t, movement.SourceID)
+	assert.Nil(t, movement.EmployeeID)
+	assert.Equal(t, 1.0, movement.QuantityBefore)
+	assert.Equal(t, 2.5, movement.Quantity)
+}
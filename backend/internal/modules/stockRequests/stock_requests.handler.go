@@ -10,6 +10,7 @@ import (
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/gin-gonic/gin"
@@ -174,7 +175,13 @@ func (h *StockRequestHandler) AcceptWithChangeStatus(c *gin.Context) {
 		return
 	}
 
-	request, err := h.service.AcceptStockRequestWithChange(stockRequestID, dto)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	request, err := h.service.AcceptStockRequestWithChange(stockRequestID, employeeID, dto)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StockRequest)
 		return
@@ -210,7 +217,13 @@ func (h *StockRequestHandler) RejectStoreStatus(c *gin.Context) {
 		return
 	}
 
-	request, err := h.service.RejectStockRequestByStore(stockRequestID, dto)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	request, err := h.service.RejectStockRequestByStore(stockRequestID, employeeID, dto)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StockRequest)
 		return
@@ -307,7 +320,13 @@ func (h *StockRequestHandler) SetInDeliveryStatus(c *gin.Context) {
 		return
 	}
 
-	request, err := h.service.SetInDeliveryStatus(stockRequestID)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	request, err := h.service.SetInDeliveryStatus(stockRequestID, employeeID)
 	if err != nil {
 		if errors.Is(err, types.ErrInsufficientStock) {
 			localization.SendLocalizedResponseWithKey(c, types.Response400StockRequestInsufficientStock)
@@ -339,7 +358,13 @@ func (h *StockRequestHandler) SetCompletedStatus(c *gin.Context) {
 		return
 	}
 
-	request, err := h.service.SetCompletedStatus(uint(stockRequestID))
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	request, err := h.service.SetCompletedStatus(stockRequestID, employeeID)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StockRequest)
 		return
@@ -444,6 +469,28 @@ func (h *StockRequestHandler) GetLastCreatedStockRequest(c *gin.Context) {
 	utils.SendSuccessResponse(c, request)
 }
 
+func (h *StockRequestHandler) GetReplenishmentSuggestion(c *gin.Context) {
+	var query types.ReplenishmentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := h.franchiseeService.CheckFranchiseeStore(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	suggestion, err := h.service.GetReplenishmentSuggestion(storeID, query)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StockRequestReplenishment)
+		return
+	}
+
+	utils.SendSuccessResponse(c, suggestion)
+}
+
 func (h *StockRequestHandler) AddStockMaterialToCart(c *gin.Context) {
 	storeID, errH := h.franchiseeService.CheckFranchiseeStore(c)
 	if errH != nil {
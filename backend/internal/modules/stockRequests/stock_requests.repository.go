@@ -7,10 +7,14 @@ import (
 	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	stockMovementsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
+	warehouseStockTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const DefaultLowStockThreshold = 100
@@ -29,9 +33,9 @@ type StockRequestRepository interface {
 	AddWarehouseComment(requestID uint, comment string) error
 	AddDetails(stockRequestID uint, details []types.StockRequestDetails) error
 
-	DeductWarehouseStock(stockMaterialID, warehouseID uint, quantityInPackages float64) (*data.WarehouseStock, error)
-	UpsertToStoreStock(storeID, stockMaterialID uint, quantityInPackages float64) error
-	ReturnWarehouseStock(stockMaterialID, warehouseID uint, quantity float64) (*data.WarehouseStock, error)
+	DeductWarehouseStock(ingredient *data.StockRequestIngredient, warehouseID uint, source data.StockMovementSource) (*data.WarehouseStock, error)
+	UpsertToStoreStock(storeID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) error
+	ReturnWarehouseStock(stockMaterialID, warehouseID uint, quantity float64, lots []data.StockRequestIngredientLot, source data.StockMovementSource) (*data.WarehouseStock, error)
 	GetWarehouseStockQuantity(warehouseID, stockMaterialID uint) (float64, error)
 	GetStoreWarehouse(storeID uint) (*data.Store, error)
 
@@ -43,19 +47,25 @@ type StockRequestRepository interface {
 
 	CountStockRequestsInLast24Hours(storeID uint) (int64, error)
 	CountFinalizedStockRequestsInLast24Hours(storeID uint) (int64, error)
+
+	GetStoreIngredientConsumption(storeID uint, from, to time.Time) (map[uint]float64, error)
+	GetStoreStocksForReplenishment(storeID uint) ([]data.StoreStock, error)
+	GetReplenishmentStockMaterials(warehouseID uint, ingredientIDs []uint) ([]data.StockMaterial, error)
+	GetStoreOpenRequestIngredients(storeID uint) ([]data.StockRequestIngredient, error)
 	CloneWithTransaction(tx *gorm.DB) StockRequestRepository
 }
 
 type stockRequestRepository struct {
-	db *gorm.DB
+	db                *gorm.DB
+	stockMovementRepo stockMovements.StockMovementRepository
 }
 
-func NewStockRequestRepository(db *gorm.DB) StockRequestRepository {
-	return &stockRequestRepository{db: db}
+func NewStockRequestRepository(db *gorm.DB, stockMovementRepo stockMovements.StockMovementRepository) StockRequestRepository {
+	return &stockRequestRepository{db: db, stockMovementRepo: stockMovementRepo}
 }
 
 func (r *stockRequestRepository) CloneWithTransaction(tx *gorm.DB) StockRequestRepository {
-	return &stockRequestRepository{db: tx}
+	return &stockRequestRepository{db: tx, stockMovementRepo: r.stockMovementRepo.CloneWithTransaction(tx)}
 }
 
 func (r *stockRequestRepository) CreateStockRequest(stockRequest *data.StockRequest) error {
@@ -89,7 +99,8 @@ func (r *stockRequestRepository) GetStockRequests(filter types.GetStockRequestsF
 		Preload("Ingredients.StockMaterial.Ingredient").
 		Preload("Ingredients.StockMaterial.Ingredient.Unit").
 		Preload("Ingredients.StockMaterial.Ingredient.IngredientCategory").
-		Preload("Ingredients.StockMaterial.Unit")
+		Preload("Ingredients.StockMaterial.Unit").
+		Preload("Ingredients.Lots")
 
 	// Apply basic filters
 	if filter.StoreID != nil {
@@ -150,6 +161,7 @@ func (r *stockRequestRepository) GetStockRequestByID(requestID uint) (*data.Stoc
 		Preload("Ingredients.StockMaterial.Ingredient.Unit").
 		Preload("Ingredients.StockMaterial.Ingredient.IngredientCategory").
 		Preload("Ingredients.StockMaterial.Unit").
+		Preload("Ingredients.Lots").
 		First(&stockRequest, requestID).
 		Error
 	if err != nil {
@@ -166,19 +178,56 @@ func (r *stockRequestRepository) UpdateStockRequestStatus(stockRequest *data.Sto
 	return r.db.Model(&data.StockRequest{}).Where("id = ?", stockRequest.ID).Update("status", stockRequest.Status).Error
 }
 
-func (r *stockRequestRepository) DeductWarehouseStock(stockMaterialID, warehouseID uint, quantity float64) (*data.WarehouseStock, error) {
+// DeductWarehouseStock takes the requested material from the lots expiring first and links the picked lots to the ingredient
+func (r *stockRequestRepository) DeductWarehouseStock(ingredient *data.StockRequestIngredient, warehouseID uint, source data.StockMovementSource) (*data.WarehouseStock, error) {
 	var updatedStock data.WarehouseStock
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Model(&data.WarehouseStock{}).
-			Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
-			Update("quantity", gorm.Expr("quantity - ?", quantity)).Error; err != nil {
+			Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, ingredient.StockMaterialID).
+			Update("quantity", gorm.Expr("quantity - ?", ingredient.Quantity)).Error; err != nil {
 			return fmt.Errorf("failed to update stock quantity: %w", err)
 		}
-		if err := tx.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
+		if err := tx.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, ingredient.StockMaterialID).
 			First(&updatedStock).Error; err != nil {
 			return fmt.Errorf("failed to fetch updated stock: %w", err)
 		}
+
+		movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, ingredient.StockMaterialID,
+			updatedStock.Quantity+ingredient.Quantity, updatedStock.Quantity)
+		if err := r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement); err != nil {
+			return err
+		}
+
+		var lots []data.WarehouseStockLot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("warehouse_id = ? AND stock_material_id = ? AND quantity > 0", warehouseID, ingredient.StockMaterialID).
+			Find(&lots).Error; err != nil {
+			return fmt.Errorf("failed to lock the lots of stock material ID %d: %w", ingredient.StockMaterialID, err)
+		}
+
+		// the quantity the lots do not cover was kept before the lots were tracked and is sent without a lot
+		allocations, _ := warehouseStockTypes.AllocateFEFO(lots, ingredient.Quantity)
+		ingredient.Lots = make([]data.StockRequestIngredientLot, len(allocations))
+		for i, allocation := range allocations {
+			if err := tx.Model(&data.WarehouseStockLot{}).
+				Where("id = ?", allocation.LotID).
+				Update("quantity", gorm.Expr("quantity - ?", allocation.Quantity)).Error; err != nil {
+				return fmt.Errorf("failed to deduct from lot ID %d: %w", allocation.LotID, err)
+			}
+			ingredient.Lots[i] = data.StockRequestIngredientLot{
+				StockRequestIngredientID: ingredient.ID,
+				LotID:                    allocation.LotID,
+				Quantity:                 allocation.Quantity,
+				ExpirationDate:           allocation.ExpirationDate,
+			}
+		}
+
+		if len(ingredient.Lots) > 0 {
+			if err := tx.Create(&ingredient.Lots).Error; err != nil {
+				return fmt.Errorf("failed to link the lots to stock request ingredient ID %d: %w", ingredient.ID, err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -188,7 +237,7 @@ func (r *stockRequestRepository) DeductWarehouseStock(stockMaterialID, warehouse
 	return &updatedStock, nil
 }
 
-func (r *stockRequestRepository) UpsertToStoreStock(storeID, stockMaterialID uint, quantityInPackages float64) error {
+func (r *stockRequestRepository) UpsertToStoreStock(storeID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) error {
 	var stockMaterial data.StockMaterial
 	if err := r.db.
 		Preload("Ingredient").
@@ -205,23 +254,34 @@ func (r *stockRequestRepository) UpsertToStoreStock(storeID, stockMaterialID uin
 		quantityInUnits = stockMaterial.Size * quantityInPackages
 	}
 
-	var storeStock data.StoreStock
-	err := r.db.Where("store_id = ? AND ingredient_id = ?", storeID, stockMaterial.IngredientID).First(&storeStock).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			newStock := data.StoreStock{
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var storeStock data.StoreStock
+		var before float64
+		err := tx.Where("store_id = ? AND ingredient_id = ?", storeID, stockMaterial.IngredientID).First(&storeStock).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			storeStock = data.StoreStock{
 				StoreID:           storeID,
 				IngredientID:      stockMaterial.IngredientID,
 				Quantity:          quantityInUnits,
 				LowStockThreshold: DefaultLowStockThreshold,
 			}
-			return r.db.Create(&newStock).Error
+			if err := tx.Create(&storeStock).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			before = storeStock.Quantity
+			storeStock.Quantity += quantityInUnits
+			if err := tx.Save(&storeStock).Error; err != nil {
+				return err
+			}
 		}
-		return err
-	}
 
-	storeStock.Quantity += quantityInUnits
-	return r.db.Save(&storeStock).Error
+		movement := stockMovementsTypes.NewStoreStockMovement(source, storeID, stockMaterial.IngredientID, before, storeStock.Quantity)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
+	})
 }
 
 func (r *stockRequestRepository) GetLastStockRequestDate(storeID uint) (*time.Time, error) {
@@ -372,7 +432,8 @@ func (r *stockRequestRepository) AddDetails(stockRequestID uint, newDetails []ty
 	return nil
 }
 
-func (r *stockRequestRepository) ReturnWarehouseStock(stockMaterialID, warehouseID uint, quantity float64) (*data.WarehouseStock, error) {
+// ReturnWarehouseStock puts the quantity back to the warehouse, the given lots get back the quantities picked from them
+func (r *stockRequestRepository) ReturnWarehouseStock(stockMaterialID, warehouseID uint, quantity float64, lots []data.StockRequestIngredientLot, source data.StockMovementSource) (*data.WarehouseStock, error) {
 	var updatedStock data.WarehouseStock
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Model(&data.WarehouseStock{}).
@@ -380,11 +441,20 @@ func (r *stockRequestRepository) ReturnWarehouseStock(stockMaterialID, warehouse
 			Update("quantity", gorm.Expr("quantity + ?", quantity)).Error; err != nil {
 			return fmt.Errorf("failed to return stock quantity: %w", err)
 		}
+		for _, lot := range lots {
+			if err := tx.Model(&data.WarehouseStockLot{}).
+				Where("id = ?", lot.LotID).
+				Update("quantity", gorm.Expr("quantity + ?", lot.Quantity)).Error; err != nil {
+				return fmt.Errorf("failed to return stock to lot ID %d: %w", lot.LotID, err)
+			}
+		}
 		if err := tx.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
 			First(&updatedStock).Error; err != nil {
 			return fmt.Errorf("failed to fetch updated warehouse stock: %w", err)
 		}
-		return nil
+
+		movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, stockMaterialID, updatedStock.Quantity-quantity, updatedStock.Quantity)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
 	})
 	if err != nil {
 		return nil, err
@@ -417,3 +487,111 @@ func (r *stockRequestRepository) CountFinalizedStockRequestsInLast24Hours(storeI
 		Count(&count).Error
 	return count, err
 }
+
+// GetStoreIngredientConsumption sums the ingredients used by the suborders completed in the store within the period.
+// The usage follows the inventory deduction on the completion: the ingredients of the product size,
+// of its default additives and of the chosen additives, taken from the current recipes
+func (r *stockRequestRepository) GetStoreIngredientConsumption(storeID uint, from, to time.Time) (map[uint]float64, error) {
+	var rows []struct {
+		IngredientID uint
+		Quantity     float64
+	}
+
+	err := r.db.Raw(`
+		WITH completed_suborders AS (
+			SELECT s.id, sps.product_size_id
+			FROM suborders s
+			JOIN orders o ON o.id = s.order_id
+			JOIN store_product_sizes sps ON sps.id = s.store_product_size_id
+			WHERE o.store_id = ? AND s.status = ? AND s.completed_at >= ? AND s.completed_at < ?
+				AND s.deleted_at IS NULL AND o.deleted_at IS NULL
+		)
+		SELECT usage.ingredient_id, SUM(usage.quantity) AS quantity
+		FROM (
+			SELECT psi.ingredient_id, psi.quantity
+			FROM completed_suborders cs
+			JOIN product_size_ingredients psi ON psi.product_size_id = cs.product_size_id AND psi.deleted_at IS NULL
+			UNION ALL
+			SELECT ai.ingredient_id, ai.quantity
+			FROM completed_suborders cs
+			JOIN product_size_additives psa ON psa.product_size_id = cs.product_size_id AND psa.is_default = TRUE AND psa.deleted_at IS NULL
+			JOIN additive_ingredients ai ON ai.additive_id = psa.additive_id AND ai.deleted_at IS NULL
+			UNION ALL
+			SELECT ai.ingredient_id, ai.quantity
+			FROM completed_suborders cs
+			JOIN suborder_additives sa ON sa.suborder_id = cs.id AND sa.deleted_at IS NULL
+			JOIN store_additives sta ON sta.id = sa.store_additive_id
+			JOIN additive_ingredients ai ON ai.additive_id = sta.additive_id AND ai.deleted_at IS NULL
+		) usage
+		GROUP BY usage.ingredient_id`,
+		storeID, data.SubOrderStatusCompleted, from, to,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the ingredient consumption of store %d: %w", storeID, err)
+	}
+
+	consumption := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		consumption[row.IngredientID] = row.Quantity
+	}
+	return consumption, nil
+}
+
+func (r *stockRequestRepository) GetStoreStocksForReplenishment(storeID uint) ([]data.StoreStock, error) {
+	var stocks []data.StoreStock
+	err := r.db.
+		Preload("Ingredient.Unit").
+		Preload("Ingredient.IngredientCategory").
+		Where("store_id = ?", storeID).
+		Order("ingredient_id").
+		Find(&stocks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the stocks of store %d: %w", storeID, err)
+	}
+	return stocks, nil
+}
+
+// GetStoreOpenRequestIngredients returns the materials of the store requests which are not delivered or rejected yet
+func (r *stockRequestRepository) GetStoreOpenRequestIngredients(storeID uint) ([]data.StockRequestIngredient, error) {
+	var ingredients []data.StockRequestIngredient
+	err := r.db.
+		Joins("JOIN stock_requests ON stock_requests.id = stock_request_ingredients.stock_request_id").
+		Preload("StockMaterial.Unit").
+		Preload("StockMaterial.Ingredient.Unit").
+		Where("stock_requests.store_id = ? AND stock_requests.deleted_at IS NULL", storeID).
+		Where("stock_requests.status IN (?)", []data.StockRequestStatus{
+			data.StockRequestCreated,
+			data.StockRequestProcessed,
+			data.StockRequestInDelivery,
+			data.StockRequestAcceptedWithChange,
+		}).
+		Find(&ingredients).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the open stock requests of store %d: %w", storeID, err)
+	}
+	return ingredients, nil
+}
+
+// GetReplenishmentStockMaterials returns the active stock materials of the ingredients,
+// the materials the warehouse holds more of come first
+func (r *stockRequestRepository) GetReplenishmentStockMaterials(warehouseID uint, ingredientIDs []uint) ([]data.StockMaterial, error) {
+	var materials []data.StockMaterial
+	if len(ingredientIDs) == 0 {
+		return materials, nil
+	}
+
+	err := r.db.
+		Select("stock_materials.*").
+		Joins("LEFT JOIN warehouse_stocks ws ON ws.stock_material_id = stock_materials.id AND ws.warehouse_id = ? AND ws.deleted_at IS NULL", warehouseID).
+		Preload("Unit").
+		Preload("StockMaterialCategory").
+		Preload("Ingredient.Unit").
+		Preload("Ingredient.IngredientCategory").
+		Where("stock_materials.ingredient_id IN (?) AND stock_materials.is_active = TRUE", ingredientIDs).
+		Order("COALESCE(ws.quantity, 0) DESC, stock_materials.id").
+		Find(&materials).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the stock materials of warehouse %d: %w", warehouseID, err)
+	}
+	return materials, nil
+}
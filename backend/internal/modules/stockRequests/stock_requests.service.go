@@ -3,6 +3,7 @@ package stockRequests
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
 	storeInventoryManagersTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers/types"
@@ -26,18 +27,20 @@ type StockRequestService interface {
 	GetStockRequestByID(id uint) (types.StockRequestResponse, error)
 
 	// statuses
-	RejectStockRequestByStore(requestID uint, dto types.RejectStockRequestStatusDTO) (*data.StockRequest, error)
+	RejectStockRequestByStore(requestID, employeeID uint, dto types.RejectStockRequestStatusDTO) (*data.StockRequest, error)
 	RejectStockRequestByWarehouse(requestID uint, dto types.RejectStockRequestStatusDTO) (*data.StockRequest, error)
 	SetProcessedStatus(requestID uint) (*data.StockRequest, error)
-	SetInDeliveryStatus(requestID uint) (*data.StockRequest, error)
-	SetCompletedStatus(requestID uint) (*data.StockRequest, error)
-	AcceptStockRequestWithChange(requestID uint, dto types.AcceptWithChangeRequestStatusDTO) (*data.StockRequest, error)
+	SetInDeliveryStatus(requestID, employeeID uint) (*data.StockRequest, error)
+	SetCompletedStatus(requestID, employeeID uint) (*data.StockRequest, error)
+	AcceptStockRequestWithChange(requestID, employeeID uint, dto types.AcceptWithChangeRequestStatusDTO) (*data.StockRequest, error)
 
 	UpdateStockRequest(requestID uint, items []types.StockRequestStockMaterialDTO) (*data.StockRequest, error)
 
 	DeleteStockRequest(requestID uint) (*data.StockRequest, error)
 	GetLastCreatedStockRequest(storeID uint) (*types.StockRequestResponse, error)
 	AddStockMaterialToCart(storeID uint, dto types.StockRequestStockMaterialDTO) (*data.StockRequest, error)
+
+	GetReplenishmentSuggestion(storeID uint, query types.ReplenishmentQuery) (*types.StoreReplenishmentDTO, error)
 }
 
 type stockRequestService struct {
@@ -134,6 +137,47 @@ func (s *stockRequestService) CreateStockRequest(storeID uint, req types.CreateS
 	return stockRequest.ID, store.Name, nil
 }
 
+// GetReplenishmentSuggestion averages the consumption of the store ingredients over the history days
+// and pre-fills a stock request covering the coverage days on top of the low stock thresholds,
+// the quantities already requested by the open stock requests are not suggested again. Nothing is saved
+func (s *stockRequestService) GetReplenishmentSuggestion(storeID uint, query types.ReplenishmentQuery) (*types.StoreReplenishmentDTO, error) {
+	query.ApplyDefaults()
+
+	store, err := s.repo.GetStoreWarehouse(storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch store warehouse for store ID %d: %w", storeID, err)
+	}
+
+	stocks, err := s.repo.GetStoreStocksForReplenishment(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	consumption, err := s.repo.GetStoreIngredientConsumption(storeID, query.HistoryStart(now), now)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredientIDs := make([]uint, len(stocks))
+	for i, stock := range stocks {
+		ingredientIDs[i] = stock.IngredientID
+	}
+
+	materials, err := s.repo.GetReplenishmentStockMaterials(store.WarehouseID, ingredientIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	openIngredients, err := s.repo.GetStoreOpenRequestIngredients(storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := types.RequestedIngredientQuantities(openIngredients)
+	return types.BuildStoreReplenishment(query, stocks, consumption, requested, types.PickReplenishmentMaterials(materials)), nil
+}
+
 func (s *stockRequestService) GetStockRequests(filter types.GetStockRequestsFilter) ([]types.StockRequestResponse, error) {
 	requests, err := s.repo.GetStockRequests(filter)
 	if err != nil {
@@ -156,7 +200,7 @@ func (s *stockRequestService) GetStockRequestByID(id uint) (types.StockRequestRe
 	return types.ToStockRequestResponse(request), nil
 }
 
-func (s *stockRequestService) RejectStockRequestByStore(requestID uint, dto types.RejectStockRequestStatusDTO) (*data.StockRequest, error) {
+func (s *stockRequestService) RejectStockRequestByStore(requestID, employeeID uint, dto types.RejectStockRequestStatusDTO) (*data.StockRequest, error) {
 	request, err := s.repo.GetStockRequestByID(requestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch stock request: %w", err)
@@ -166,7 +210,7 @@ func (s *stockRequestService) RejectStockRequestByStore(requestID uint, dto type
 		return nil, fmt.Errorf("invalid status transition from %s to %s", request.Status, data.StockRequestRejectedByStore)
 	}
 
-	if err := s.handleRejectedByStoreStatus(request, dto.Comment); err != nil {
+	if err := s.handleRejectedByStoreStatus(request, dto.Comment, stockRequestMovementSource(request.ID, employeeID)); err != nil {
 		return nil, err
 	}
 
@@ -268,7 +312,7 @@ func (s *stockRequestService) SetProcessedStatus(requestID uint) (*data.StockReq
 	return request, nil
 }
 
-func (s *stockRequestService) SetInDeliveryStatus(requestID uint) (*data.StockRequest, error) {
+func (s *stockRequestService) SetInDeliveryStatus(requestID, employeeID uint) (*data.StockRequest, error) {
 	request, err := s.repo.GetStockRequestByID(requestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch stock request: %w", err)
@@ -278,7 +322,7 @@ func (s *stockRequestService) SetInDeliveryStatus(requestID uint) (*data.StockRe
 		return nil, fmt.Errorf("invalid status transition from %s to %s", request.Status, data.StockRequestInDelivery)
 	}
 
-	if err := s.handleInDeliveryStatus(request); err != nil {
+	if err := s.handleInDeliveryStatus(request, stockRequestMovementSource(request.ID, employeeID)); err != nil {
 		return nil, err
 	}
 
@@ -303,7 +347,7 @@ func (s *stockRequestService) SetInDeliveryStatus(requestID uint) (*data.StockRe
 	return request, nil
 }
 
-func (s *stockRequestService) SetCompletedStatus(requestID uint) (*data.StockRequest, error) {
+func (s *stockRequestService) SetCompletedStatus(requestID, employeeID uint) (*data.StockRequest, error) {
 	request, err := s.repo.GetStockRequestByID(requestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch stock request: %w", err)
@@ -313,7 +357,7 @@ func (s *stockRequestService) SetCompletedStatus(requestID uint) (*data.StockReq
 		return nil, fmt.Errorf("invalid status transition from %s to %s", request.Status, data.StockRequestCompleted)
 	}
 
-	ingredientIDs, err := s.transactionManager.HandleCompleteStockRequest(request)
+	ingredientIDs, err := s.transactionManager.HandleCompleteStockRequest(request, stockRequestMovementSource(request.ID, employeeID))
 	if err != nil {
 		return nil, err
 	}
@@ -350,7 +394,7 @@ func (s *stockRequestService) SetCompletedStatus(requestID uint) (*data.StockReq
 	return request, nil
 }
 
-func (s *stockRequestService) AcceptStockRequestWithChange(requestID uint, dto types.AcceptWithChangeRequestStatusDTO) (*data.StockRequest, error) {
+func (s *stockRequestService) AcceptStockRequestWithChange(requestID, employeeID uint, dto types.AcceptWithChangeRequestStatusDTO) (*data.StockRequest, error) {
 	request, err := s.repo.GetStockRequestByID(requestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch stock request: %w", err)
@@ -365,7 +409,7 @@ func (s *stockRequestService) AcceptStockRequestWithChange(requestID uint, dto t
 		return nil, fmt.Errorf("invalid status transition from %s to %s", request.Status, data.StockRequestAcceptedWithChange)
 	}
 
-	ingredientIDs, err := s.transactionManager.HandleAcceptedWithChange(request, store.ID, dto.Items, dto.Comment)
+	ingredientIDs, err := s.transactionManager.HandleAcceptedWithChange(request, store.ID, dto.Items, dto.Comment, stockRequestMovementSource(request.ID, employeeID))
 	if err != nil {
 		return nil, err
 	}
@@ -402,8 +446,8 @@ func (s *stockRequestService) AcceptStockRequestWithChange(requestID uint, dto t
 	return request, nil
 }
 
-func (s *stockRequestService) handleInDeliveryStatus(request *data.StockRequest) error {
-	for _, ingredient := range request.Ingredients {
+func (s *stockRequestService) handleInDeliveryStatus(request *data.StockRequest, source data.StockMovementSource) error {
+	for i, ingredient := range request.Ingredients {
 		stockQuantity, err := s.repo.GetWarehouseStockQuantity(request.WarehouseID, ingredient.StockMaterialID)
 		if err != nil {
 			return fmt.Errorf("failed to fetch warehouse stock for stock material ID %d: %w", ingredient.StockMaterialID, err)
@@ -426,7 +470,7 @@ func (s *stockRequestService) handleInDeliveryStatus(request *data.StockRequest)
 			return types.ErrInsufficientStock
 		}
 
-		updatedStock, err := s.repo.DeductWarehouseStock(ingredient.StockMaterialID, request.WarehouseID, ingredient.Quantity)
+		updatedStock, err := s.repo.DeductWarehouseStock(&request.Ingredients[i], request.WarehouseID, source)
 		if err != nil {
 			return fmt.Errorf("failed to deduct warehouse stock for stock material ID %d: %w", ingredient.StockMaterialID, err)
 		}
@@ -441,6 +485,14 @@ func (s *stockRequestService) handleInDeliveryStatus(request *data.StockRequest)
 	return nil
 }
 
+func stockRequestMovementSource(requestID, employeeID uint) data.StockMovementSource {
+	return data.StockMovementSource{
+		Type:       data.StockMovementSourceStockRequest,
+		ID:         &requestID,
+		EmployeeID: &employeeID,
+	}
+}
+
 func findOriginalIngredient(ingredients []data.StockRequestIngredient, stockMaterialID uint) *data.StockRequestIngredient {
 	for _, ingredient := range ingredients {
 		if ingredient.StockMaterialID == stockMaterialID {
@@ -460,7 +512,7 @@ func (s *stockRequestService) handleRejectedByWarehouseStatus(request *data.Stoc
 	return nil
 }
 
-func (s *stockRequestService) handleRejectedByStoreStatus(request *data.StockRequest, comment *string) error {
+func (s *stockRequestService) handleRejectedByStoreStatus(request *data.StockRequest, comment *string, source data.StockMovementSource) error {
 	if comment != nil {
 		if err := s.repo.AddStoreComment(request.ID, *comment); err != nil {
 			return fmt.Errorf("failed to add rejection comment for request ID %d: %w", request.ID, err)
@@ -468,7 +520,7 @@ func (s *stockRequestService) handleRejectedByStoreStatus(request *data.StockReq
 	}
 
 	for _, ingredient := range request.Ingredients {
-		_, err := s.repo.ReturnWarehouseStock(ingredient.StockMaterialID, request.WarehouseID, ingredient.Quantity)
+		_, err := s.repo.ReturnWarehouseStock(ingredient.StockMaterialID, request.WarehouseID, ingredient.Quantity, ingredient.Lots, source)
 		if err != nil {
 			return fmt.Errorf("failed to return stock for material ID %d: %w", ingredient.StockMaterialID, err)
 		}
@@ -7,16 +7,18 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
+	warehouseStockTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock/types"
 	"gorm.io/gorm"
 )
 
 type TransactionManager interface {
-	HandleCompleteStockRequest(request *data.StockRequest) (ingredientIDs []uint, err error)
+	HandleCompleteStockRequest(request *data.StockRequest, source data.StockMovementSource) (ingredientIDs []uint, err error)
 	HandleAcceptedWithChange(
 		request *data.StockRequest,
 		storeID uint,
 		items []types.StockRequestStockMaterialDTO,
 		comment *string,
+		source data.StockMovementSource,
 	) (ingredientIDs []uint, err error)
 }
 
@@ -38,7 +40,7 @@ func NewTransactionManager(
 	}
 }
 
-func (m *transactionManager) HandleCompleteStockRequest(request *data.StockRequest) (ingredientIDs []uint, err error) {
+func (m *transactionManager) HandleCompleteStockRequest(request *data.StockRequest, source data.StockMovementSource) (ingredientIDs []uint, err error) {
 	if request == nil {
 		return nil, fmt.Errorf("request is nil")
 	}
@@ -52,14 +54,14 @@ func (m *transactionManager) HandleCompleteStockRequest(request *data.StockReque
 
 			dates := types.UpdateIngredientDates{
 				DeliveredDate:  time.Now(),
-				ExpirationDate: time.Now().AddDate(0, 0, ingredient.StockMaterial.ExpirationPeriodInDays),
+				ExpirationDate: ingredientExpirationDate(ingredient.Lots, ingredient.StockMaterial.ExpirationPeriodInDays),
 			}
 
 			if err := repoTx.UpdateStockRequestIngredientDates(ingredient.ID, &dates); err != nil {
 				return fmt.Errorf("failed to update ingredient dates for stock material ID %d: %w", ingredient.StockMaterialID, err)
 			}
 
-			if err := repoTx.UpsertToStoreStock(request.StoreID, ingredient.StockMaterialID, ingredient.Quantity); err != nil {
+			if err := repoTx.UpsertToStoreStock(request.StoreID, ingredient.StockMaterialID, ingredient.Quantity, source); err != nil {
 				return fmt.Errorf("failed to update store warehouse stock for stock material ID %d: %w", ingredient.StockMaterialID, err)
 			}
 		}
@@ -93,6 +95,7 @@ func (m *transactionManager) HandleAcceptedWithChange(
 	storeID uint,
 	items []types.StockRequestStockMaterialDTO,
 	comment *string,
+	source data.StockMovementSource,
 ) (ingredientIDs []uint, err error) {
 	var updatedIngredients []data.StockRequestIngredient
 	var changeDetails []types.StockRequestDetails
@@ -119,7 +122,10 @@ func (m *transactionManager) HandleAcceptedWithChange(
 		for _, item := range items {
 			originalIngredient := findOriginalIngredient(request.Ingredients, item.StockMaterialID)
 
+			var keptLots []data.StockRequestIngredientLot
 			if originalIngredient != nil {
+				keptLots = originalIngredient.Lots
+
 				if originalIngredient.Quantity != item.Quantity {
 					requestDetails := types.StockRequestDetails{
 						OriginalMaterialName: originalIngredient.StockMaterial.Name,
@@ -131,7 +137,9 @@ func (m *transactionManager) HandleAcceptedWithChange(
 					// If accepted quantity is lower, return the difference to the warehouse.
 					if originalIngredient.Quantity > item.Quantity {
 						diff := originalIngredient.Quantity - item.Quantity
-						_, err := repoTx.ReturnWarehouseStock(item.StockMaterialID, request.WarehouseID, diff)
+						var releasedLots []data.StockRequestIngredientLot
+						releasedLots, keptLots = warehouseStockTypes.ReleaseLots(originalIngredient.Lots, diff)
+						_, err := repoTx.ReturnWarehouseStock(item.StockMaterialID, request.WarehouseID, diff, releasedLots, source)
 						if err != nil {
 							return fmt.Errorf("failed to return excess stock for material ID %d: %w", item.StockMaterialID, err)
 						}
@@ -146,7 +154,7 @@ func (m *transactionManager) HandleAcceptedWithChange(
 			}
 
 			if item.Quantity > 0 {
-				if err := repoTx.UpsertToStoreStock(storeID, item.StockMaterialID, item.Quantity); err != nil {
+				if err := repoTx.UpsertToStoreStock(storeID, item.StockMaterialID, item.Quantity, source); err != nil {
 					return fmt.Errorf("failed to add stock to store warehouse for stock material ID %d: %w", item.StockMaterialID, err)
 				}
 			}
@@ -156,7 +164,8 @@ func (m *transactionManager) HandleAcceptedWithChange(
 				StockMaterialID: item.StockMaterialID,
 				Quantity:        item.Quantity,
 				DeliveredDate:   time.Now(),
-				ExpirationDate:  time.Now().AddDate(0, 0, materialMap[item.StockMaterialID].ExpirationPeriodInDays),
+				ExpirationDate:  ingredientExpirationDate(keptLots, materialMap[item.StockMaterialID].ExpirationPeriodInDays),
+				Lots:            relinkLots(keptLots),
 			})
 		}
 
@@ -190,3 +199,25 @@ func (m *transactionManager) HandleAcceptedWithChange(
 
 	return ingredientIDs, nil
 }
+
+// ingredientExpirationDate is the earliest expiration of the lots the material was picked from,
+// the expiration period of the material is used when it was not picked from a lot
+func ingredientExpirationDate(lots []data.StockRequestIngredientLot, expirationPeriodInDays int) time.Time {
+	if earliest := warehouseStockTypes.EarliestLotExpiration(lots); earliest != nil {
+		return *earliest
+	}
+	return time.Now().AddDate(0, 0, expirationPeriodInDays)
+}
+
+// relinkLots copies the lots for the recreated ingredient, the old rows are removed together with the replaced ingredient
+func relinkLots(lots []data.StockRequestIngredientLot) []data.StockRequestIngredientLot {
+	relinked := make([]data.StockRequestIngredientLot, len(lots))
+	for i, lot := range lots {
+		relinked[i] = data.StockRequestIngredientLot{
+			LotID:          lot.LotID,
+			Quantity:       lot.Quantity,
+			ExpirationDate: lot.ExpirationDate,
+		}
+	}
+	return relinked
+}
@@ -20,6 +20,7 @@ func ToStockRequestResponse(request *data.StockRequest) StockRequestResponse {
 		items[i] = StockRequestMaterial{
 			StockMaterial: *stockMaterialTypes.ConvertStockMaterialToStockMaterialResponse(&ingredient.StockMaterial),
 			Quantity:      ingredient.Quantity,
+			Lots:          toStockRequestMaterialLots(ingredient.Lots),
 		}
 	}
 
@@ -53,7 +54,7 @@ func ToStockRequestResponse(request *data.StockRequest) StockRequestResponse {
 			ContactPhone:    request.Store.ContactPhone,
 			ContactEmail:    request.Store.ContactEmail,
 			FacilityAddress: facilityAddress,
-			StoreHours:      request.Store.StoreHours,
+			Timezone:        request.Store.Timezone,
 		},
 		Warehouse:      *warehouseTypes.ToWarehouseDTO(request.Warehouse),
 		Status:         request.Status,
@@ -63,6 +64,21 @@ func ToStockRequestResponse(request *data.StockRequest) StockRequestResponse {
 	}
 }
 
+func toStockRequestMaterialLots(lots []data.StockRequestIngredientLot) []StockRequestMaterialLot {
+	if len(lots) == 0 {
+		return nil
+	}
+	result := make([]StockRequestMaterialLot, len(lots))
+	for i, lot := range lots {
+		result[i] = StockRequestMaterialLot{
+			LotID:          lot.LotID,
+			Quantity:       lot.Quantity,
+			ExpirationDate: lot.ExpirationDate,
+		}
+	}
+	return result
+}
+
 func getAutogeneratedComments(details []StockRequestDetails) *localization.LocalizedMessage {
 	var mismatchComments []localization.LocalizedMessage
 	var unexpectedComments []localization.LocalizedMessage
@@ -0,0 +1,39 @@
+package types
+
+import (
+	ingredientTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+)
+
+const (
+	DefaultReplenishmentCoverageDays = 7
+	DefaultReplenishmentHistoryDays  = 28
+)
+
+// ReplenishmentQuery sets the days the suggested quantities should last and the days of history the consumption is averaged over
+type ReplenishmentQuery struct {
+	CoverageDays int `form:"coverageDays" binding:"omitempty,min=1,max=90"`
+	HistoryDays  int `form:"historyDays" binding:"omitempty,min=1,max=365"`
+}
+
+// StoreReplenishmentDTO is a suggestion only, the store reviews the pre-filled stock request and submits it as a usual one
+type StoreReplenishmentDTO struct {
+	CoverageDays int                         `json:"coverageDays"`
+	HistoryDays  int                         `json:"historyDays"`
+	Items        []StoreReplenishmentItemDTO `json:"items"`
+	StockRequest CreateStockRequestDTO       `json:"stockRequest"`
+}
+
+// StoreReplenishmentItemDTO keeps the quantities in the units of the ingredient and the suggested quantity in packages of the stock material,
+// the stock material is empty when the warehouse of the store has no active material for the ingredient
+type StoreReplenishmentItemDTO struct {
+	Ingredient              ingredientTypes.IngredientDTO         `json:"ingredient"`
+	Quantity                float64                               `json:"quantity"`
+	SafetyStock             float64                               `json:"safetyStock"`
+	AverageDailyConsumption float64                               `json:"averageDailyConsumption"`
+	TargetQuantity          float64                               `json:"targetQuantity"`
+	RequestedQuantity       float64                               `json:"requestedQuantity"`
+	NeededQuantity          float64                               `json:"neededQuantity"`
+	StockMaterial           *stockMaterialTypes.StockMaterialsDTO `json:"stockMaterial"`
+	Packages                float64                               `json:"packages"`
+}
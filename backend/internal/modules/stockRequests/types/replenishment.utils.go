@@ -0,0 +1,119 @@
+package types
+
+import (
+	"math"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	ingredientTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+func (q *ReplenishmentQuery) ApplyDefaults() {
+	if q.CoverageDays <= 0 {
+		q.CoverageDays = DefaultReplenishmentCoverageDays
+	}
+	if q.HistoryDays <= 0 {
+		q.HistoryDays = DefaultReplenishmentHistoryDays
+	}
+}
+
+// HistoryStart is the beginning of the period the consumption is averaged over
+func (q *ReplenishmentQuery) HistoryStart(now time.Time) time.Time {
+	return now.AddDate(0, 0, -q.HistoryDays)
+}
+
+func AverageDailyConsumption(consumed float64, historyDays int) float64 {
+	if historyDays <= 0 {
+		return 0
+	}
+	return consumed / float64(historyDays)
+}
+
+// ReplenishmentTarget is the quantity lasting for the coverage days with the safety stock kept on top of it
+func ReplenishmentTarget(averageDailyConsumption float64, coverageDays int, safetyStock float64) float64 {
+	return averageDailyConsumption*float64(coverageDays) + safetyStock
+}
+
+// IngredientQuantityPerPackage is the quantity of the ingredient one package of the stock material adds to the store stock
+func IngredientQuantityPerPackage(material *data.StockMaterial) float64 {
+	if material.Ingredient.UnitID != material.UnitID && material.Ingredient.Unit.ConversionFactor > 0 {
+		return material.Unit.ConversionFactor / material.Ingredient.Unit.ConversionFactor * material.Size
+	}
+	return material.Size
+}
+
+// PackagesToCover rounds the needed quantity up to whole packages
+func PackagesToCover(quantity, perPackage float64) float64 {
+	if quantity <= 0 || perPackage <= 0 {
+		return 0
+	}
+	return math.Ceil(utils.RoundToDecimal(quantity/perPackage, 4))
+}
+
+// RequestedIngredientQuantities sums the ingredient quantities the requested packages add to the store stock
+func RequestedIngredientQuantities(ingredients []data.StockRequestIngredient) map[uint]float64 {
+	requested := make(map[uint]float64)
+	for i := range ingredients {
+		material := &ingredients[i].StockMaterial
+		requested[material.IngredientID] += ingredients[i].Quantity * IngredientQuantityPerPackage(material)
+	}
+	return requested
+}
+
+// BuildStoreReplenishment suggests the ingredients which fall short of the target and the packages to request for them.
+// The consumption is the total used quantity of every ingredient over the history days, the requested quantities
+// are on the way to the store already, the materials are the stock materials of the store warehouse chosen for the ingredients
+func BuildStoreReplenishment(query ReplenishmentQuery, stocks []data.StoreStock, consumption, requested map[uint]float64, materials map[uint]*data.StockMaterial) *StoreReplenishmentDTO {
+	result := &StoreReplenishmentDTO{
+		CoverageDays: query.CoverageDays,
+		HistoryDays:  query.HistoryDays,
+		Items:        []StoreReplenishmentItemDTO{},
+		StockRequest: CreateStockRequestDTO{StockMaterials: []StockRequestStockMaterialDTO{}},
+	}
+
+	for i := range stocks {
+		stock := &stocks[i]
+		averageDaily := AverageDailyConsumption(consumption[stock.IngredientID], query.HistoryDays)
+		target := ReplenishmentTarget(averageDaily, query.CoverageDays, stock.LowStockThreshold)
+		needed := utils.RoundToDecimal(target-stock.Quantity-requested[stock.IngredientID], 2)
+		if needed <= 0 {
+			continue
+		}
+
+		item := StoreReplenishmentItemDTO{
+			Ingredient:              *ingredientTypes.ConvertToIngredientResponseDTO(&stock.Ingredient),
+			Quantity:                stock.Quantity,
+			SafetyStock:             stock.LowStockThreshold,
+			AverageDailyConsumption: utils.RoundToDecimal(averageDaily, 2),
+			TargetQuantity:          utils.RoundToDecimal(target, 2),
+			RequestedQuantity:       utils.RoundToDecimal(requested[stock.IngredientID], 2),
+			NeededQuantity:          needed,
+		}
+
+		if material, ok := materials[stock.IngredientID]; ok {
+			item.StockMaterial = stockMaterialTypes.ConvertStockMaterialToStockMaterialResponse(material)
+			item.Packages = PackagesToCover(needed, IngredientQuantityPerPackage(material))
+			result.StockRequest.StockMaterials = append(result.StockRequest.StockMaterials, StockRequestStockMaterialDTO{
+				StockMaterialID: material.ID,
+				Quantity:        item.Packages,
+			})
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
+
+// PickReplenishmentMaterials keeps the first material of every ingredient, the materials are expected in the order of preference
+func PickReplenishmentMaterials(materials []data.StockMaterial) map[uint]*data.StockMaterial {
+	picked := make(map[uint]*data.StockMaterial, len(materials))
+	for i := range materials {
+		if _, ok := picked[materials[i].IngredientID]; !ok {
+			picked[materials[i].IngredientID] = &materials[i]
+		}
+	}
+	return picked
+}
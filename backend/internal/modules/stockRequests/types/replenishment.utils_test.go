@@ -0,0 +1,110 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngredientQuantityPerPackage(t *testing.T) {
+	liter := data.Unit{BaseEntity: data.BaseEntity{ID: 1}, ConversionFactor: 1}
+	milliliter := data.Unit{BaseEntity: data.BaseEntity{ID: 2}, ConversionFactor: 0.001}
+
+	t.Run("Package in the ingredient unit should give its size", func(t *testing.T) {
+		material := &data.StockMaterial{UnitID: liter.ID, Unit: liter, Size: 2, Ingredient: data.Ingredient{UnitID: liter.ID, Unit: liter}}
+		assert.Equal(t, 2.0, IngredientQuantityPerPackage(material))
+	})
+
+	t.Run("Package in another unit should be converted to the ingredient unit", func(t *testing.T) {
+		material := &data.StockMaterial{UnitID: liter.ID, Unit: liter, Size: 2, Ingredient: data.Ingredient{UnitID: milliliter.ID, Unit: milliliter}}
+		assert.Equal(t, 2000.0, IngredientQuantityPerPackage(material))
+	})
+}
+
+func TestPackagesToCover(t *testing.T) {
+	assert.Equal(t, 3.0, PackagesToCover(5, 2))
+	assert.Equal(t, 2.0, PackagesToCover(0.6, 0.3))
+	assert.Equal(t, 0.0, PackagesToCover(0, 2))
+	assert.Equal(t, 0.0, PackagesToCover(5, 0))
+}
+
+func TestBuildStoreReplenishment(t *testing.T) {
+	unit := data.Unit{BaseEntity: data.BaseEntity{ID: 1}, ConversionFactor: 1}
+	milk, beans, syrup := uint(1), uint(2), uint(3)
+	stocks := []data.StoreStock{
+		{IngredientID: milk, Ingredient: data.Ingredient{BaseEntity: data.BaseEntity{ID: milk}, UnitID: unit.ID, Unit: unit}, Quantity: 4, LowStockThreshold: 2},
+		{IngredientID: beans, Ingredient: data.Ingredient{BaseEntity: data.BaseEntity{ID: beans}, UnitID: unit.ID, Unit: unit}, Quantity: 50, LowStockThreshold: 1},
+		{IngredientID: syrup, Ingredient: data.Ingredient{BaseEntity: data.BaseEntity{ID: syrup}, UnitID: unit.ID, Unit: unit}, Quantity: 0, LowStockThreshold: 1},
+	}
+	consumption := map[uint]float64{milk: 28, beans: 14}
+	materials := map[uint]*data.StockMaterial{
+		milk:  {BaseEntity: data.BaseEntity{ID: 10}, IngredientID: milk, UnitID: unit.ID, Unit: unit, Size: 2, Ingredient: stocks[0].Ingredient},
+		beans: {BaseEntity: data.BaseEntity{ID: 20}, IngredientID: beans, UnitID: unit.ID, Unit: unit, Size: 1, Ingredient: stocks[1].Ingredient},
+	}
+
+	result := BuildStoreReplenishment(ReplenishmentQuery{CoverageDays: 7, HistoryDays: 14}, stocks, consumption, nil, materials)
+
+	t.Run("Only the ingredients short of the target should be suggested", func(t *testing.T) {
+		assert.Len(t, result.Items, 2)
+		assert.Equal(t, milk, result.Items[0].Ingredient.ID)
+		assert.Equal(t, syrup, result.Items[1].Ingredient.ID)
+	})
+
+	t.Run("Needed quantity should cover the coverage days and the safety stock", func(t *testing.T) {
+		item := result.Items[0]
+		assert.Equal(t, 2.0, item.AverageDailyConsumption)
+		assert.Equal(t, 16.0, item.TargetQuantity)
+		assert.Equal(t, 12.0, item.NeededQuantity)
+		assert.Equal(t, 6.0, item.Packages)
+	})
+
+	t.Run("Ingredient without a stock material should stay out of the stock request", func(t *testing.T) {
+		assert.Nil(t, result.Items[1].StockMaterial)
+		assert.Equal(t, []StockRequestStockMaterialDTO{{StockMaterialID: 10, Quantity: 6}}, result.StockRequest.StockMaterials)
+	})
+}
+
+func TestBuildStoreReplenishmentWithOpenRequests(t *testing.T) {
+	unit := data.Unit{BaseEntity: data.BaseEntity{ID: 1}, ConversionFactor: 1}
+	milk := data.Ingredient{BaseEntity: data.BaseEntity{ID: 1}, UnitID: unit.ID, Unit: unit}
+	material := data.StockMaterial{BaseEntity: data.BaseEntity{ID: 10}, IngredientID: milk.ID, UnitID: unit.ID, Unit: unit, Size: 2, Ingredient: milk}
+	stocks := []data.StoreStock{{IngredientID: milk.ID, Ingredient: milk, Quantity: 4, LowStockThreshold: 2}}
+	consumption := map[uint]float64{milk.ID: 28}
+	materials := map[uint]*data.StockMaterial{milk.ID: &material}
+
+	t.Run("Requested packages should be converted to the ingredient quantity", func(t *testing.T) {
+		requested := RequestedIngredientQuantities([]data.StockRequestIngredient{
+			{StockMaterialID: material.ID, StockMaterial: material, Quantity: 2},
+			{StockMaterialID: material.ID, StockMaterial: material, Quantity: 1},
+		})
+		assert.Equal(t, map[uint]float64{milk.ID: 6}, requested)
+	})
+
+	t.Run("Requested quantity should be subtracted from the needed one", func(t *testing.T) {
+		result := BuildStoreReplenishment(ReplenishmentQuery{CoverageDays: 7, HistoryDays: 14}, stocks, consumption, map[uint]float64{milk.ID: 6}, materials)
+
+		assert.Len(t, result.Items, 1)
+		assert.Equal(t, 6.0, result.Items[0].RequestedQuantity)
+		assert.Equal(t, 6.0, result.Items[0].NeededQuantity)
+		assert.Equal(t, 3.0, result.Items[0].Packages)
+	})
+
+	t.Run("Ingredient covered by the open requests should not be suggested", func(t *testing.T) {
+		result := BuildStoreReplenishment(ReplenishmentQuery{CoverageDays: 7, HistoryDays: 14}, stocks, consumption, map[uint]float64{milk.ID: 12}, materials)
+
+		assert.Empty(t, result.Items)
+	})
+}
+
+func TestPickReplenishmentMaterials(t *testing.T) {
+	materials := []data.StockMaterial{
+		{BaseEntity: data.BaseEntity{ID: 3}, IngredientID: 1},
+		{BaseEntity: data.BaseEntity{ID: 1}, IngredientID: 1},
+		{BaseEntity: data.BaseEntity{ID: 2}, IngredientID: 2},
+	}
+
+	picked := PickReplenishmentMaterials(materials)
+	assert.Equal(t, uint(3), picked[1].ID)
+	assert.Equal(t, uint(2), picked[2].ID)
+}
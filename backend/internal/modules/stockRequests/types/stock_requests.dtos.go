@@ -51,6 +51,14 @@ type StockRequestResponse struct {
 type StockRequestMaterial struct {
 	StockMaterial stockMaterialTypes.StockMaterialsDTO `json:"stockMaterial"`
 	Quantity      float64                              `json:"quantity"`
+	Lots          []StockRequestMaterialLot            `json:"lots,omitempty"`
+}
+
+// StockRequestMaterialLot is the part of the requested quantity picked from the warehouse lot
+type StockRequestMaterialLot struct {
+	LotID          uint      `json:"lotId"`
+	Quantity       float64   `json:"quantity"`
+	ExpirationDate time.Time `json:"expirationDate"`
 }
 
 type GetStockRequestsFilter struct {
@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/types"
+	"github.com/tealeg/xlsx"
+)
+
+var (
+	kazHeaders = []string{"Атауы", "Штрихкод", "Өлшем бірлігі", "Күтілген", "Саналған", "Айырмашылық", "Айырмашылық %", "Себебі", "Түсініктеме"}
+	rusHeaders = []string{"Наименование", "Штрихкод", "Единица измерения", "Ожидалось", "Посчитано", "Расхождение", "Расхождение %", "Причина", "Комментарий"}
+	engHeaders = []string{"Name", "Barcode", "Unit", "Expected", "Counted", "Variance", "Variance %", "Reason", "Comment"}
+
+	reasonLabels = map[string]map[data.StockAdjustmentReason]string{
+		"kk": {
+			data.StockAdjustmentReasonCountCorrection: "Санақ түзетуі",
+			data.StockAdjustmentReasonDamaged:         "Бүлінген",
+			data.StockAdjustmentReasonExpired:         "Мерзімі өткен",
+			data.StockAdjustmentReasonWaste:           "Шығын",
+			data.StockAdjustmentReasonTheft:           "Ұрлық",
+			data.StockAdjustmentReasonUnrecorded:      "Тіркелмеген қозғалыс",
+		},
+		"ru": {
+			data.StockAdjustmentReasonCountCorrection: "Корректировка пересчёта",
+			data.StockAdjustmentReasonDamaged:         "Повреждение",
+			data.StockAdjustmentReasonExpired:         "Истёк срок годности",
+			data.StockAdjustmentReasonWaste:           "Списание",
+			data.StockAdjustmentReasonTheft:           "Кража",
+			data.StockAdjustmentReasonUnrecorded:      "Неучтённое движение",
+		},
+		"en": {
+			data.StockAdjustmentReasonCountCorrection: "Count correction",
+			data.StockAdjustmentReasonDamaged:         "Damaged",
+			data.StockAdjustmentReasonExpired:         "Expired",
+			data.StockAdjustmentReasonWaste:           "Waste",
+			data.StockAdjustmentReasonTheft:           "Theft",
+			data.StockAdjustmentReasonUnrecorded:      "Unrecorded movement",
+		},
+	}
+)
+
+// GenerateStocktakeExcel writes the variance report of the stocktake, the uncounted lines keep the counted columns empty
+func GenerateStocktakeExcel(stocktake *types.StocktakeDTO, language string) ([]byte, error) {
+	headers := rusHeaders
+	switch language {
+	case "kk":
+		headers = kazHeaders
+	case "en":
+		headers = engHeaders
+	default:
+		language = "ru"
+	}
+
+	file := xlsx.NewFile()
+
+	sheet, err := file.AddSheet(fmt.Sprintf("ST-%d", stocktake.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	headerRow := sheet.AddRow()
+	for _, header := range headers {
+		headerRow.AddCell().Value = header
+	}
+	setHeadersStyle(headerRow)
+
+	for _, line := range stocktake.Lines {
+		row := sheet.AddRow()
+		row.AddCell().Value = line.Name
+		row.AddCell().Value = line.Barcode
+		row.AddCell().Value = line.Unit
+		row.AddCell().SetFloat(line.ExpectedQuantity)
+		addOptionalFloat(row, line.CountedQuantity)
+		addOptionalFloat(row, line.Variance)
+		addOptionalFloat(row, line.VariancePercent)
+
+		reasonCell := row.AddCell()
+		if line.Reason != nil {
+			reasonCell.Value = reasonLabels[language][*line.Reason]
+		}
+		row.AddCell().Value = line.Comment
+	}
+
+	for i := range headers {
+		if err := sheet.SetColWidth(i, i, 20); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := file.Write(buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func addOptionalFloat(row *xlsx.Row, value *float64) {
+	cell := row.AddCell()
+	if value != nil {
+		cell.SetFloat(*value)
+	}
+}
+
+func setHeadersStyle(headerRow *xlsx.Row) {
+	style := xlsx.NewStyle()
+	style.Font.Bold = true
+	style.Fill.FgColor = "C6C6C6"
+	style.Fill.PatternType = "solid"
+
+	for _, cell := range headerRow.Cells {
+		cell.SetStyle(style)
+	}
+}
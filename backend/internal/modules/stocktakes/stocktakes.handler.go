@@ -0,0 +1,316 @@
+package stocktakes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/export"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type StocktakeHandler struct {
+	service           StocktakeService
+	franchiseeService franchisees.FranchiseeService
+	regionService     regions.RegionService
+	auditService      audit.AuditService
+}
+
+func NewStocktakeHandler(
+	service StocktakeService,
+	franchiseeService franchisees.FranchiseeService,
+	regionService regions.RegionService,
+	auditService audit.AuditService,
+) *StocktakeHandler {
+	return &StocktakeHandler{
+		service:           service,
+		franchiseeService: franchiseeService,
+		regionService:     regionService,
+		auditService:      auditService,
+	}
+}
+
+func (h *StocktakeHandler) CreateStocktake(c *gin.Context) {
+	var dto types.CreateStocktakeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	stocktake, err := h.service.CreateStocktake(facility, employeeID, &dto)
+	if err != nil {
+		sendStocktakeError(c, err, types.Response500StocktakeCreate)
+		return
+	}
+
+	details := stocktakeAuditDetails(stocktake)
+	go func() {
+		if facility.IsStore() {
+			action := types.CreateStoreStocktakeAuditFactory(details, &dto, *facility.StoreID)
+			_ = h.auditService.RecordEmployeeAction(c, &action)
+			return
+		}
+		action := types.CreateWarehouseStocktakeAuditFactory(details, &dto, *facility.WarehouseID)
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SendResponseWithStatus(c, stocktake, http.StatusCreated)
+}
+
+func (h *StocktakeHandler) GetStocktakes(c *gin.Context) {
+	var filter types.StocktakesFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.Stocktake{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return
+	}
+	filter.StoreID = facility.StoreID
+	filter.WarehouseID = facility.WarehouseID
+
+	stocktakes, err := h.service.GetStocktakes(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StocktakeGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, stocktakes, filter.Pagination)
+}
+
+func (h *StocktakeHandler) GetStocktakeByID(c *gin.Context) {
+	stocktake, ok := h.getStocktake(c)
+	if !ok {
+		return
+	}
+
+	utils.SendSuccessResponse(c, stocktake)
+}
+
+// SubmitCounts records the counts of the lines, the counters may send the counts in several batches while the stocktake is in progress
+func (h *StocktakeHandler) SubmitCounts(c *gin.Context) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Stocktake)
+		return
+	}
+
+	var dto types.SubmitStocktakeCountsDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	stocktake, err := h.service.SubmitCounts(id, facility, employeeID, &dto)
+	if err != nil {
+		sendStocktakeError(c, err, types.Response500StocktakeUpdate)
+		return
+	}
+
+	utils.SendSuccessResponse(c, stocktake)
+}
+
+func (h *StocktakeHandler) SubmitStocktake(c *gin.Context) {
+	h.setStocktakeStatus(c, data.StocktakeStatusSubmitted, types.Response200StocktakeSubmit)
+}
+
+// RecountStocktake sends the submitted stocktake back to the counters
+func (h *StocktakeHandler) RecountStocktake(c *gin.Context) {
+	h.setStocktakeStatus(c, data.StocktakeStatusInProgress, types.Response200StocktakeRecount)
+}
+
+func (h *StocktakeHandler) CancelStocktake(c *gin.Context) {
+	h.setStocktakeStatus(c, data.StocktakeStatusCancelled, types.Response200StocktakeCancel)
+}
+
+func (h *StocktakeHandler) ApproveStocktake(c *gin.Context) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Stocktake)
+		return
+	}
+
+	var dto types.ApproveStocktakeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return
+	}
+
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	stocktake, err := h.service.ApproveStocktake(id, facility, employeeID, &dto)
+	if err != nil {
+		sendStocktakeError(c, err, types.Response500StocktakeApprove)
+		return
+	}
+
+	status := stocktake.Status
+	h.recordUpdateStocktakeAudit(c, facility, stocktake, &types.StocktakePayloads{ApproveStocktakeDTO: &dto, Status: &status})
+	localization.SendLocalizedResponseWithKey(c, types.Response200StocktakeApprove)
+}
+
+func (h *StocktakeHandler) ExportStocktakeXLSX(c *gin.Context) {
+	var query types.ExportStocktakeQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	stocktake, ok := h.getStocktake(c)
+	if !ok {
+		return
+	}
+
+	excelData, err := export.GenerateStocktakeExcel(stocktake, query.Language)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StocktakeExport)
+		return
+	}
+
+	filename := fmt.Sprintf("stocktake_%d.xlsx", stocktake.ID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelData)
+}
+
+// getFacility resolves the store or the warehouse of the employee, the stocktakes are never shared between them
+func (h *StocktakeHandler) getFacility(c *gin.Context) (types.StocktakeFacility, bool) {
+	if storeID, errH := h.franchiseeService.CheckFranchiseeStore(c); errH == nil {
+		return types.StocktakeFacility{StoreID: &storeID}, true
+	}
+
+	if warehouseID, errH := h.regionService.CheckRegionWarehouse(c); errH == nil {
+		return types.StocktakeFacility{WarehouseID: &warehouseID}, true
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response403Stocktake)
+	return types.StocktakeFacility{}, false
+}
+
+func (h *StocktakeHandler) getStocktake(c *gin.Context) (*types.StocktakeDTO, bool) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Stocktake)
+		return nil, false
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return nil, false
+	}
+
+	stocktake, err := h.service.GetStocktakeByID(id, facility)
+	if err != nil {
+		sendStocktakeError(c, err, types.Response500StocktakeGet)
+		return nil, false
+	}
+
+	return stocktake, true
+}
+
+func (h *StocktakeHandler) setStocktakeStatus(c *gin.Context, status data.StocktakeStatus, successKey *localization.ResponseKey) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400Stocktake)
+		return
+	}
+
+	facility, ok := h.getFacility(c)
+	if !ok {
+		return
+	}
+
+	stocktake, err := h.service.SetStocktakeStatus(id, facility, status)
+	if err != nil {
+		sendStocktakeError(c, err, types.Response500StocktakeUpdate)
+		return
+	}
+
+	h.recordUpdateStocktakeAudit(c, facility, stocktake, &types.StocktakePayloads{Status: &status})
+	localization.SendLocalizedResponseWithKey(c, successKey)
+}
+
+func (h *StocktakeHandler) recordUpdateStocktakeAudit(c *gin.Context, facility types.StocktakeFacility, stocktake *types.StocktakeDTO, payload *types.StocktakePayloads) {
+	details := stocktakeAuditDetails(stocktake)
+
+	go func() {
+		if facility.IsStore() {
+			action := types.UpdateStoreStocktakeAuditFactory(details, payload, *facility.StoreID)
+			_ = h.auditService.RecordEmployeeAction(c, &action)
+			return
+		}
+		action := types.UpdateWarehouseStocktakeAuditFactory(details, payload, *facility.WarehouseID)
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+}
+
+func stocktakeAuditDetails(stocktake *types.StocktakeDTO) *data.BaseDetails {
+	return &data.BaseDetails{
+		ID:   stocktake.ID,
+		Name: fmt.Sprintf("#%d", stocktake.ID),
+	}
+}
+
+func sendStocktakeError(c *gin.Context, err error, fallback *localization.ResponseKey) {
+	switch {
+	case errors.Is(err, types.ErrStocktakeNotFound):
+		localization.SendLocalizedResponseWithKey(c, types.Response404Stocktake)
+	case errors.Is(err, types.ErrStocktakeLineNotFound):
+		localization.SendLocalizedResponseWithKey(c, types.Response404StocktakeLine)
+	case errors.Is(err, types.ErrBarcodeNotInStocktake):
+		localization.SendLocalizedResponseWithKey(c, types.Response404StocktakeBarcode)
+	case errors.Is(err, types.ErrInvalidStocktakeCount):
+		localization.SendLocalizedResponseWithKey(c, types.Response400StocktakeCount)
+	case errors.Is(err, types.ErrNothingToCount):
+		localization.SendLocalizedResponseWithKey(c, types.Response400StocktakeEmpty)
+	case errors.Is(err, types.ErrNothingCounted):
+		localization.SendLocalizedResponseWithKey(c, types.Response400StocktakeCounted)
+	case errors.Is(err, types.ErrStocktakeAlreadyOpen):
+		localization.SendLocalizedResponseWithKey(c, types.Response409Stocktake)
+	case errors.Is(err, types.ErrInvalidStatusTransition):
+		localization.SendLocalizedResponseWithKey(c, types.Response409StocktakeStatus)
+	default:
+		localization.SendLocalizedResponseWithKey(c, fallback)
+	}
+}
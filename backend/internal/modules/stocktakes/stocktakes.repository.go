@@ -0,0 +1,272 @@
+package stocktakes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	stockMovementsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StocktakeRepository interface {
+	CloneWithTransaction(tx *gorm.DB) StocktakeRepository
+
+	HasOpenStocktake(facility types.StocktakeFacility) (bool, error)
+	GetStoreStocks(storeID uint, ingredientIDs []uint) ([]data.StoreStock, error)
+	GetWarehouseStocks(warehouseID uint, stockMaterialIDs []uint) ([]data.WarehouseStock, error)
+
+	CreateStocktake(stocktake *data.Stocktake) error
+	GetStocktakeByID(id uint, facility types.StocktakeFacility) (*data.Stocktake, error)
+	GetStocktakeForUpdate(id uint, facility types.StocktakeFacility) (*data.Stocktake, error)
+	GetStocktakes(filter *types.StocktakesFilter) ([]data.Stocktake, error)
+	SaveCounts(lines []*data.StocktakeLine) error
+	SaveReviews(lines []data.StocktakeLine) error
+	UpdateStatus(stocktake *data.Stocktake, from data.StocktakeStatus) error
+
+	AdjustStoreStock(storeID, ingredientID uint, quantity float64, source data.StockMovementSource) (float64, error)
+	GetWarehouseStockForUpdate(warehouseID, stockMaterialID uint) (*data.WarehouseStock, error)
+	CreateStockAdjustments(adjustments []data.StockAdjustment) error
+}
+
+type stocktakeRepository struct {
+	db                *gorm.DB
+	stockMovementRepo stockMovements.StockMovementRepository
+}
+
+func NewStocktakeRepository(db *gorm.DB, stockMovementRepo stockMovements.StockMovementRepository) StocktakeRepository {
+	return &stocktakeRepository{db: db, stockMovementRepo: stockMovementRepo}
+}
+
+func (r *stocktakeRepository) CloneWithTransaction(tx *gorm.DB) StocktakeRepository {
+	return &stocktakeRepository{db: tx, stockMovementRepo: r.stockMovementRepo.CloneWithTransaction(tx)}
+}
+
+func scopeFacility(query *gorm.DB, facility types.StocktakeFacility) *gorm.DB {
+	if facility.StoreID != nil {
+		return query.Where("stocktakes.store_id = ?", *facility.StoreID)
+	}
+	return query.Where("stocktakes.warehouse_id = ?", *facility.WarehouseID)
+}
+
+func (r *stocktakeRepository) HasOpenStocktake(facility types.StocktakeFacility) (bool, error) {
+	var count int64
+	err := scopeFacility(r.db.Model(&data.Stocktake{}), facility).
+		Where("stocktakes.status IN (?)", []data.StocktakeStatus{data.StocktakeStatusInProgress, data.StocktakeStatusSubmitted}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check the open stocktakes: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *stocktakeRepository) GetStoreStocks(storeID uint, ingredientIDs []uint) ([]data.StoreStock, error) {
+	var stocks []data.StoreStock
+	query := r.db.Where("store_id = ?", storeID)
+	if len(ingredientIDs) > 0 {
+		query = query.Where("ingredient_id IN (?)", ingredientIDs)
+	}
+	if err := query.Order("ingredient_id").Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch the stocks of store %d: %w", storeID, err)
+	}
+	return stocks, nil
+}
+
+func (r *stocktakeRepository) GetWarehouseStocks(warehouseID uint, stockMaterialIDs []uint) ([]data.WarehouseStock, error) {
+	var stocks []data.WarehouseStock
+	query := r.db.Where("warehouse_id = ?", warehouseID)
+	if len(stockMaterialIDs) > 0 {
+		query = query.Where("stock_material_id IN (?)", stockMaterialIDs)
+	}
+	if err := query.Order("stock_material_id").Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch the stocks of warehouse %d: %w", warehouseID, err)
+	}
+	return stocks, nil
+}
+
+func (r *stocktakeRepository) CreateStocktake(stocktake *data.Stocktake) error {
+	return r.db.Create(stocktake).Error
+}
+
+func (r *stocktakeRepository) GetStocktakeByID(id uint, facility types.StocktakeFacility) (*data.Stocktake, error) {
+	var stocktake data.Stocktake
+	err := scopeFacility(r.db.Model(&data.Stocktake{}), facility).
+		Preload("Store").
+		Preload("Warehouse").
+		Preload("StartedBy").
+		Preload("ApprovedBy").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("stocktake_lines.id")
+		}).
+		Preload("Lines.Ingredient.Unit").
+		Preload("Lines.StockMaterial.Unit").
+		Preload("Lines.CountedBy").
+		Where("stocktakes.id = ?", id).
+		First(&stocktake).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStocktakeNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch stocktake with ID %d: %w", id, err)
+	}
+	return &stocktake, nil
+}
+
+// GetStocktakeForUpdate locks the stocktake until the end of the transaction, so the counts and the approval do not overlap
+func (r *stocktakeRepository) GetStocktakeForUpdate(id uint, facility types.StocktakeFacility) (*data.Stocktake, error) {
+	var stocktake data.Stocktake
+	err := scopeFacility(r.db.Model(&data.Stocktake{}), facility).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stocktakes.id = ?", id).
+		First(&stocktake).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStocktakeNotFound
+		}
+		return nil, fmt.Errorf("failed to lock stocktake with ID %d: %w", id, err)
+	}
+
+	if err := r.db.Where("stocktake_id = ?", stocktake.ID).Order("id").Find(&stocktake.Lines).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch the lines of stocktake %d: %w", id, err)
+	}
+	return &stocktake, nil
+}
+
+func (r *stocktakeRepository) GetStocktakes(filter *types.StocktakesFilter) ([]data.Stocktake, error) {
+	var stocktakes []data.Stocktake
+
+	query := r.db.Model(&data.Stocktake{}).
+		Preload("Store").
+		Preload("Warehouse").
+		Preload("StartedBy").
+		Preload("ApprovedBy").
+		Preload("Lines")
+
+	if filter.StoreID != nil {
+		query = query.Where("stocktakes.store_id = ?", *filter.StoreID)
+	}
+	if filter.WarehouseID != nil {
+		query = query.Where("stocktakes.warehouse_id = ?", *filter.WarehouseID)
+	}
+	if filter.Status != nil {
+		query = query.Where("stocktakes.status = ?", *filter.Status)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.Stocktake{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&stocktakes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch stocktakes: %w", err)
+	}
+
+	return stocktakes, nil
+}
+
+func (r *stocktakeRepository) SaveCounts(lines []*data.StocktakeLine) error {
+	for _, line := range lines {
+		err := r.db.Model(&data.StocktakeLine{}).
+			Where("id = ?", line.ID).
+			Updates(map[string]interface{}{
+				"counted_quantity": line.CountedQuantity,
+				"counted_by_id":    line.CountedByID,
+				"counted_at":       line.CountedAt,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to save the count of stocktake line %d: %w", line.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *stocktakeRepository) SaveReviews(lines []data.StocktakeLine) error {
+	for _, line := range lines {
+		if line.Reason == nil {
+			continue
+		}
+		err := r.db.Model(&data.StocktakeLine{}).
+			Where("id = ?", line.ID).
+			Updates(map[string]interface{}{
+				"reason":  line.Reason,
+				"comment": line.Comment,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to save the review of stocktake line %d: %w", line.ID, err)
+		}
+	}
+	return nil
+}
+
+// UpdateStatus moves the stocktake from the given status, the stocktake changed in the meantime is left as it is
+func (r *stocktakeRepository) UpdateStatus(stocktake *data.Stocktake, from data.StocktakeStatus) error {
+	res := r.db.Model(&data.Stocktake{}).
+		Where("id = ? AND status = ?", stocktake.ID, from).
+		Updates(map[string]interface{}{
+			"status":         stocktake.Status,
+			"comment":        stocktake.Comment,
+			"approved_by_id": stocktake.ApprovedByID,
+			"submitted_at":   stocktake.SubmittedAt,
+			"approved_at":    stocktake.ApprovedAt,
+			"cancelled_at":   stocktake.CancelledAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update the status of stocktake %d: %w", stocktake.ID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return types.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// AdjustStoreStock changes the store stock by the quantity and returns the applied change,
+// the stock is not taken below zero when it was used after the stocktake had started
+func (r *stocktakeRepository) AdjustStoreStock(storeID, ingredientID uint, quantity float64, source data.StockMovementSource) (float64, error) {
+	var stock data.StoreStock
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("store_id = ? AND ingredient_id = ?", storeID, ingredientID).
+		First(&stock).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock the stock of ingredient %d in store %d: %w", ingredientID, storeID, err)
+	}
+
+	applied := utils.RoundToDecimal(max(stock.Quantity+quantity, 0)-stock.Quantity, 2)
+	if applied == 0 {
+		return 0, nil
+	}
+
+	err = r.db.Model(&data.StoreStock{}).
+		Where("id = ?", stock.ID).
+		Update("quantity", gorm.Expr("quantity + ?", applied)).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to adjust the stock of ingredient %d in store %d: %w", ingredientID, storeID, err)
+	}
+
+	movement := stockMovementsTypes.NewStoreStockMovement(source, storeID, ingredientID, stock.Quantity, stock.Quantity+applied)
+	if err := r.stockMovementRepo.CreateMovements(movement); err != nil {
+		return 0, err
+	}
+	return applied, nil
+}
+
+func (r *stocktakeRepository) GetWarehouseStockForUpdate(warehouseID, stockMaterialID uint) (*data.WarehouseStock, error) {
+	var stock data.WarehouseStock
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
+		First(&stock).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the stock of material %d in warehouse %d: %w", stockMaterialID, warehouseID, err)
+	}
+	return &stock, nil
+}
+
+func (r *stocktakeRepository) CreateStockAdjustments(adjustments []data.StockAdjustment) error {
+	if len(adjustments) == 0 {
+		return nil
+	}
+	return r.db.Create(&adjustments).Error
+}
@@ -0,0 +1,250 @@
+package stocktakes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
+	storeInventoryManagersTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type StocktakeService interface {
+	CreateStocktake(facility types.StocktakeFacility, employeeID uint, dto *types.CreateStocktakeDTO) (*types.StocktakeDTO, error)
+	GetStocktakes(filter *types.StocktakesFilter) ([]types.StocktakeDTO, error)
+	GetStocktakeByID(id uint, facility types.StocktakeFacility) (*types.StocktakeDTO, error)
+	SubmitCounts(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.SubmitStocktakeCountsDTO) (*types.StocktakeDTO, error)
+	SetStocktakeStatus(id uint, facility types.StocktakeFacility, status data.StocktakeStatus) (*types.StocktakeDTO, error)
+	ApproveStocktake(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.ApproveStocktakeDTO) (*types.StocktakeDTO, error)
+}
+
+type stocktakeService struct {
+	repo                      StocktakeRepository
+	transactionManager        TransactionManager
+	stockMaterialService      stockMaterial.StockMaterialService
+	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository
+	logger                    *zap.SugaredLogger
+}
+
+func NewStocktakeService(
+	repo StocktakeRepository,
+	transactionManager TransactionManager,
+	stockMaterialService stockMaterial.StockMaterialService,
+	storeInventoryManagerRepo storeInventoryManagers.StoreInventoryManagerRepository,
+	logger *zap.SugaredLogger,
+) StocktakeService {
+	return &stocktakeService{
+		repo:                      repo,
+		transactionManager:        transactionManager,
+		stockMaterialService:      stockMaterialService,
+		storeInventoryManagerRepo: storeInventoryManagerRepo,
+		logger:                    logger,
+	}
+}
+
+// CreateStocktake starts the count of the facility and freezes the expected quantities of its stock,
+// a facility counts one stocktake at a time
+func (s *stocktakeService) CreateStocktake(facility types.StocktakeFacility, employeeID uint, dto *types.CreateStocktakeDTO) (*types.StocktakeDTO, error) {
+	open, err := s.repo.HasOpenStocktake(facility)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStocktake, err))
+		return nil, types.ErrFailedToCreateStocktake
+	}
+	if open {
+		return nil, types.ErrStocktakeAlreadyOpen
+	}
+
+	var lines []data.StocktakeLine
+	if facility.IsStore() {
+		stocks, err := s.repo.GetStoreStocks(*facility.StoreID, dto.ItemIDs)
+		if err != nil {
+			s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStocktake, err))
+			return nil, types.ErrFailedToCreateStocktake
+		}
+		lines = types.NewStoreStocktakeLines(stocks)
+	} else {
+		stocks, err := s.repo.GetWarehouseStocks(*facility.WarehouseID, dto.ItemIDs)
+		if err != nil {
+			s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStocktake, err))
+			return nil, types.ErrFailedToCreateStocktake
+		}
+		lines = types.NewWarehouseStocktakeLines(stocks)
+	}
+
+	if len(lines) == 0 {
+		return nil, types.ErrNothingToCount
+	}
+
+	stocktake := &data.Stocktake{
+		StoreID:     facility.StoreID,
+		WarehouseID: facility.WarehouseID,
+		Status:      data.StocktakeStatusInProgress,
+		StartedByID: employeeID,
+		Lines:       lines,
+	}
+	if dto.Comment != nil {
+		stocktake.Comment = strings.TrimSpace(*dto.Comment)
+	}
+
+	if err := s.repo.CreateStocktake(stocktake); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStocktake, err))
+		return nil, types.ErrFailedToCreateStocktake
+	}
+
+	return s.GetStocktakeByID(stocktake.ID, facility)
+}
+
+func (s *stocktakeService) GetStocktakes(filter *types.StocktakesFilter) ([]types.StocktakeDTO, error) {
+	stocktakes, err := s.repo.GetStocktakes(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchStocktakes, err))
+		return nil, types.ErrFailedToFetchStocktakes
+	}
+
+	responses := make([]types.StocktakeDTO, len(stocktakes))
+	for i := range stocktakes {
+		responses[i] = types.ConvertToStocktakeDTO(&stocktakes[i], false)
+	}
+	return responses, nil
+}
+
+func (s *stocktakeService) GetStocktakeByID(id uint, facility types.StocktakeFacility) (*types.StocktakeDTO, error) {
+	stocktake, err := s.repo.GetStocktakeByID(id, facility)
+	if err != nil {
+		if !errors.Is(err, types.ErrStocktakeNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	response := types.ConvertToStocktakeDTO(stocktake, true)
+	return &response, nil
+}
+
+// SubmitCounts records the counts of the lines, the scanned barcodes are resolved to the stock materials first
+func (s *stocktakeService) SubmitCounts(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.SubmitStocktakeCountsDTO) (*types.StocktakeDTO, error) {
+	materials := make(map[string]*stockMaterialTypes.StockMaterialsDTO)
+	for _, count := range dto.Counts {
+		if count.Barcode == nil {
+			continue
+		}
+		if _, ok := materials[*count.Barcode]; ok {
+			continue
+		}
+
+		material, err := s.stockMaterialService.RetrieveStockMaterialByBarcode(*count.Barcode)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, stockMaterialTypes.ErrStockMaterialBarcodeNotFound) {
+				return nil, types.ErrBarcodeNotInStocktake
+			}
+			s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdateStocktake, err))
+			return nil, types.ErrFailedToUpdateStocktake
+		}
+		materials[*count.Barcode] = material
+	}
+
+	if err := s.transactionManager.RecordCounts(id, facility, employeeID, dto.Counts, materials); err != nil {
+		if errors.Is(err, types.ErrStocktakeNotFound) ||
+			errors.Is(err, types.ErrStocktakeLineNotFound) ||
+			errors.Is(err, types.ErrBarcodeNotInStocktake) ||
+			errors.Is(err, types.ErrInvalidStocktakeCount) ||
+			errors.Is(err, types.ErrInvalidStatusTransition) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdateStocktake, err))
+		return nil, types.ErrFailedToUpdateStocktake
+	}
+
+	return s.GetStocktakeByID(id, facility)
+}
+
+// SetStocktakeStatus submits the counted stocktake for the review, sends it back to recount or cancels it.
+// The cancelled stocktake leaves the stock as it is
+func (s *stocktakeService) SetStocktakeStatus(id uint, facility types.StocktakeFacility, status data.StocktakeStatus) (*types.StocktakeDTO, error) {
+	stocktake, err := s.repo.GetStocktakeByID(id, facility)
+	if err != nil {
+		if !errors.Is(err, types.ErrStocktakeNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	from := stocktake.Status
+	// the approval posts the variances and goes through ApproveStocktake only
+	if status == data.StocktakeStatusApproved {
+		return nil, types.ErrInvalidStatusTransition
+	}
+	if err := types.ValidateStatusTransition(from, status); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	stocktake.Status = status
+	switch status {
+	case data.StocktakeStatusSubmitted:
+		if types.SummarizeLines(stocktake.Lines).CountedLines == 0 {
+			return nil, types.ErrNothingCounted
+		}
+		stocktake.SubmittedAt = &now
+	case data.StocktakeStatusInProgress:
+		stocktake.SubmittedAt = nil
+	case data.StocktakeStatusCancelled:
+		stocktake.CancelledAt = &now
+	}
+
+	if err := s.repo.UpdateStatus(stocktake, from); err != nil {
+		if errors.Is(err, types.ErrInvalidStatusTransition) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdateStocktake, err))
+		return nil, types.ErrFailedToUpdateStocktake
+	}
+
+	return s.GetStocktakeByID(id, facility)
+}
+
+// ApproveStocktake posts the reviewed variances, the store inventory is recalculated for the adjusted ingredients afterwards
+func (s *stocktakeService) ApproveStocktake(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.ApproveStocktakeDTO) (*types.StocktakeDTO, error) {
+	stocktake, err := s.transactionManager.ApproveStocktake(id, facility, employeeID, dto)
+	if err != nil {
+		if errors.Is(err, types.ErrStocktakeNotFound) ||
+			errors.Is(err, types.ErrStocktakeLineNotFound) ||
+			errors.Is(err, types.ErrInvalidStatusTransition) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToApproveStocktake, err))
+		return nil, types.ErrFailedToApproveStocktake
+	}
+
+	if facility.IsStore() {
+		var ingredientIDs []uint
+		for _, line := range stocktake.Lines {
+			if variance, ok := types.LineVariance(line); ok && variance != 0 {
+				ingredientIDs = append(ingredientIDs, *line.IngredientID)
+			}
+		}
+
+		if len(ingredientIDs) > 0 {
+			go func() {
+				err := s.storeInventoryManagerRepo.RecalculateStoreInventory(*facility.StoreID,
+					&storeInventoryManagersTypes.RecalculateInput{
+						IngredientIDs: ingredientIDs,
+					})
+				if err != nil {
+					wrappedErr := utils.WrapError("error recalculating stock", err)
+					s.logger.Error(wrappedErr)
+				}
+			}()
+		}
+	}
+
+	return s.GetStocktakeByID(id, facility)
+}
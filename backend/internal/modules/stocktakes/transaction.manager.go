@@ -0,0 +1,189 @@
+package stocktakes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type TransactionManager interface {
+	RecordCounts(id uint, facility types.StocktakeFacility, employeeID uint, counts []types.StocktakeCountDTO, materials map[string]*stockMaterialTypes.StockMaterialsDTO) error
+	ApproveStocktake(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.ApproveStocktakeDTO) (*data.Stocktake, error)
+}
+
+type transactionManager struct {
+	db                 *gorm.DB
+	repo               StocktakeRepository
+	warehouseStockRepo warehouseStock.WarehouseStockRepository
+}
+
+func NewTransactionManager(
+	db *gorm.DB,
+	repo StocktakeRepository,
+	warehouseStockRepo warehouseStock.WarehouseStockRepository,
+) TransactionManager {
+	return &transactionManager{
+		db:                 db,
+		repo:               repo,
+		warehouseStockRepo: warehouseStockRepo,
+	}
+}
+
+// RecordCounts saves the counts of a stocktake in progress, the materials are the scanned barcodes resolved beforehand
+func (m *transactionManager) RecordCounts(
+	id uint,
+	facility types.StocktakeFacility,
+	employeeID uint,
+	counts []types.StocktakeCountDTO,
+	materials map[string]*stockMaterialTypes.StockMaterialsDTO,
+) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+
+		stocktake, err := repoTx.GetStocktakeForUpdate(id, facility)
+		if err != nil {
+			return err
+		}
+		if stocktake.Status != data.StocktakeStatusInProgress {
+			return types.ErrInvalidStatusTransition
+		}
+
+		now := time.Now().UTC()
+		counted := make(map[uint]*data.StocktakeLine, len(counts))
+		for _, count := range counts {
+			var line *data.StocktakeLine
+			quantity := count.Quantity
+
+			switch {
+			case count.LineID != nil:
+				line = types.FindLineByID(stocktake.Lines, *count.LineID)
+				if line == nil {
+					return types.ErrStocktakeLineNotFound
+				}
+			case count.Barcode != nil:
+				material, ok := materials[*count.Barcode]
+				if !ok {
+					return types.ErrBarcodeNotInStocktake
+				}
+				line, quantity = types.FindLineByMaterial(stocktake.Lines, material, count.Quantity)
+				if line == nil {
+					return types.ErrBarcodeNotInStocktake
+				}
+			default:
+				return types.ErrInvalidStocktakeCount
+			}
+
+			types.ApplyCount(line, quantity, count.Add, employeeID, now)
+			counted[line.ID] = line
+		}
+
+		lines := make([]*data.StocktakeLine, 0, len(counted))
+		for _, line := range counted {
+			lines = append(lines, line)
+		}
+		return repoTx.SaveCounts(lines)
+	})
+}
+
+// ApproveStocktake posts the variances of the submitted stocktake to the stock of the facility together with the adjustments.
+// The variance is applied on top of the current stock, so the usage since the stocktake had started is kept
+func (m *transactionManager) ApproveStocktake(id uint, facility types.StocktakeFacility, employeeID uint, dto *types.ApproveStocktakeDTO) (*data.Stocktake, error) {
+	var stocktake *data.Stocktake
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		repoTx := m.repo.CloneWithTransaction(tx)
+		warehouseStockRepoTx := m.warehouseStockRepo.CloneWithTransaction(tx)
+
+		var err error
+		stocktake, err = repoTx.GetStocktakeForUpdate(id, facility)
+		if err != nil {
+			return err
+		}
+
+		from := stocktake.Status
+		if err := types.ValidateStatusTransition(from, data.StocktakeStatusApproved); err != nil {
+			return err
+		}
+
+		if err := types.ApplyReviews(stocktake.Lines, dto.Lines); err != nil {
+			return err
+		}
+		if err := repoTx.SaveReviews(stocktake.Lines); err != nil {
+			return err
+		}
+
+		source := data.StockMovementSource{
+			Type:       data.StockMovementSourceStocktake,
+			ID:         &stocktake.ID,
+			EmployeeID: &employeeID,
+		}
+
+		var adjustments []data.StockAdjustment
+		for _, adjustment := range types.BuildAdjustments(stocktake, employeeID) {
+			var applied float64
+			if adjustment.IngredientID != nil {
+				applied, err = repoTx.AdjustStoreStock(*stocktake.StoreID, *adjustment.IngredientID, adjustment.Quantity, source)
+			} else {
+				applied, err = adjustWarehouseStock(repoTx, warehouseStockRepoTx, *stocktake.WarehouseID, *adjustment.StockMaterialID, adjustment.Quantity, source)
+			}
+			if err != nil {
+				return err
+			}
+
+			if applied != 0 {
+				adjustment.Quantity = applied
+				adjustments = append(adjustments, adjustment)
+			}
+		}
+
+		if err := repoTx.CreateStockAdjustments(adjustments); err != nil {
+			return fmt.Errorf("failed to create the stock adjustments of stocktake %d: %w", stocktake.ID, err)
+		}
+
+		now := time.Now().UTC()
+		stocktake.Status = data.StocktakeStatusApproved
+		stocktake.ApprovedByID = &employeeID
+		stocktake.ApprovedAt = &now
+		if dto.Comment != nil {
+			stocktake.Comment = strings.TrimSpace(*dto.Comment)
+		}
+		return repoTx.UpdateStatus(stocktake, from)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stocktake, nil
+}
+
+// adjustWarehouseStock sets the warehouse stock through the warehouse stock repository, so the lots follow the change
+func adjustWarehouseStock(
+	repo StocktakeRepository,
+	warehouseStockRepo warehouseStock.WarehouseStockRepository,
+	warehouseID, stockMaterialID uint,
+	quantity float64,
+	source data.StockMovementSource,
+) (float64, error) {
+	stock, err := repo.GetWarehouseStockForUpdate(warehouseID, stockMaterialID)
+	if err != nil {
+		return 0, err
+	}
+
+	target := utils.RoundToDecimal(max(stock.Quantity+quantity, 0), 2)
+	applied := utils.RoundToDecimal(target-stock.Quantity, 2)
+	if applied == 0 {
+		return 0, nil
+	}
+
+	if _, err := warehouseStockRepo.UpdateStockQuantity(stockMaterialID, warehouseID, target, source); err != nil {
+		return 0, err
+	}
+	return applied, nil
+}
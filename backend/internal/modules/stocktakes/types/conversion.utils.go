@@ -0,0 +1,87 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+// ConvertToStocktakeDTO converts the stocktake, the lines are left out of the lists and only summarized there
+func ConvertToStocktakeDTO(stocktake *data.Stocktake, withLines bool) StocktakeDTO {
+	dto := StocktakeDTO{
+		ID:          stocktake.ID,
+		StoreID:     stocktake.StoreID,
+		WarehouseID: stocktake.WarehouseID,
+		Status:      stocktake.Status,
+		Comment:     stocktake.Comment,
+		StartedBy:   convertToStocktakeEmployeeDTO(&stocktake.StartedBy),
+		Summary:     SummarizeLines(stocktake.Lines),
+		SubmittedAt: stocktake.SubmittedAt,
+		ApprovedAt:  stocktake.ApprovedAt,
+		CancelledAt: stocktake.CancelledAt,
+		CreatedAt:   stocktake.CreatedAt,
+		UpdatedAt:   stocktake.UpdatedAt,
+	}
+
+	switch {
+	case stocktake.Store != nil:
+		dto.FacilityName = stocktake.Store.Name
+	case stocktake.Warehouse != nil:
+		dto.FacilityName = stocktake.Warehouse.Name
+	}
+
+	if stocktake.ApprovedBy != nil {
+		approvedBy := convertToStocktakeEmployeeDTO(stocktake.ApprovedBy)
+		dto.ApprovedBy = &approvedBy
+	}
+
+	if withLines {
+		dto.Lines = make([]StocktakeLineDTO, len(stocktake.Lines))
+		for i := range stocktake.Lines {
+			dto.Lines[i] = ConvertToStocktakeLineDTO(&stocktake.Lines[i])
+		}
+	}
+
+	return dto
+}
+
+func ConvertToStocktakeLineDTO(line *data.StocktakeLine) StocktakeLineDTO {
+	dto := StocktakeLineDTO{
+		ID:               line.ID,
+		IngredientID:     line.IngredientID,
+		StockMaterialID:  line.StockMaterialID,
+		ExpectedQuantity: line.ExpectedQuantity,
+		CountedQuantity:  line.CountedQuantity,
+		VariancePercent:  VariancePercent(*line),
+		Reason:           line.Reason,
+		Comment:          line.Comment,
+		CountedAt:        line.CountedAt,
+	}
+
+	switch {
+	case line.StockMaterial != nil:
+		dto.Name = line.StockMaterial.Name
+		dto.Barcode = line.StockMaterial.Barcode
+		dto.Unit = line.StockMaterial.Unit.Name
+	case line.Ingredient != nil:
+		dto.Name = line.Ingredient.Name
+		dto.Unit = line.Ingredient.Unit.Name
+	}
+
+	if variance, ok := LineVariance(*line); ok {
+		dto.Variance = &variance
+	}
+
+	if line.CountedBy != nil {
+		countedBy := convertToStocktakeEmployeeDTO(line.CountedBy)
+		dto.CountedBy = &countedBy
+	}
+
+	return dto
+}
+
+func convertToStocktakeEmployeeDTO(employee *data.Employee) StocktakeEmployeeDTO {
+	return StocktakeEmployeeDTO{
+		ID:        employee.ID,
+		FirstName: employee.FirstName,
+		LastName:  employee.LastName,
+	}
+}
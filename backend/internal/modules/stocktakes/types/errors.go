@@ -0,0 +1,23 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrStocktakeNotFound       = moduleErrors.NewModuleError(errors.New("stocktake not found"))
+	ErrStocktakeLineNotFound   = moduleErrors.NewModuleError(errors.New("stocktake line not found"))
+	ErrBarcodeNotInStocktake   = moduleErrors.NewModuleError(errors.New("scanned barcode matches no line of the stocktake"))
+	ErrInvalidStocktakeCount   = moduleErrors.NewModuleError(errors.New("stocktake count must point to either a line or a barcode"))
+	ErrStocktakeAlreadyOpen    = moduleErrors.NewModuleError(errors.New("facility already has a stocktake in progress"))
+	ErrNothingToCount          = moduleErrors.NewModuleError(errors.New("facility has no stock to count"))
+	ErrNothingCounted          = moduleErrors.NewModuleError(errors.New("stocktake has no counted lines"))
+	ErrInvalidStatusTransition = moduleErrors.NewModuleError(errors.New("stocktake can not be moved to the requested status"))
+
+	ErrFailedToCreateStocktake  = moduleErrors.NewModuleError(errors.New("failed to create stocktake"))
+	ErrFailedToFetchStocktakes  = moduleErrors.NewModuleError(errors.New("failed to fetch stocktakes"))
+	ErrFailedToUpdateStocktake  = moduleErrors.NewModuleError(errors.New("failed to update stocktake"))
+	ErrFailedToApproveStocktake = moduleErrors.NewModuleError(errors.New("failed to approve stocktake"))
+)
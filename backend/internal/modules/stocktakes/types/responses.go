@@ -0,0 +1,32 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500StocktakeCreate  = localization.NewResponseKey(http.StatusInternalServerError, data.StocktakeComponent, data.CreateOperation.ToString())
+	Response500StocktakeGet     = localization.NewResponseKey(http.StatusInternalServerError, data.StocktakeComponent, data.GetOperation.ToString())
+	Response500StocktakeUpdate  = localization.NewResponseKey(http.StatusInternalServerError, data.StocktakeComponent, data.UpdateOperation.ToString())
+	Response500StocktakeApprove = localization.NewResponseKey(http.StatusInternalServerError, data.StocktakeComponent, "APPROVE")
+	Response500StocktakeExport  = localization.NewResponseKey(http.StatusInternalServerError, data.StocktakeComponent, "EXPORT")
+
+	Response400Stocktake        = localization.NewResponseKey(http.StatusBadRequest, data.StocktakeComponent)
+	Response400StocktakeCount   = localization.NewResponseKey(http.StatusBadRequest, data.StocktakeComponent, "COUNT")
+	Response400StocktakeEmpty   = localization.NewResponseKey(http.StatusBadRequest, data.StocktakeComponent, "EMPTY")
+	Response400StocktakeCounted = localization.NewResponseKey(http.StatusBadRequest, data.StocktakeComponent, "NOTHING_COUNTED")
+	Response403Stocktake        = localization.NewResponseKey(http.StatusForbidden, data.StocktakeComponent)
+	Response404Stocktake        = localization.NewResponseKey(http.StatusNotFound, data.StocktakeComponent)
+	Response404StocktakeLine    = localization.NewResponseKey(http.StatusNotFound, data.StocktakeComponent, "LINE")
+	Response404StocktakeBarcode = localization.NewResponseKey(http.StatusNotFound, data.StocktakeComponent, "BARCODE")
+	Response409Stocktake        = localization.NewResponseKey(http.StatusConflict, data.StocktakeComponent)
+	Response409StocktakeStatus  = localization.NewResponseKey(http.StatusConflict, data.StocktakeComponent, "STATUS")
+
+	Response200StocktakeSubmit  = localization.NewResponseKey(http.StatusOK, data.StocktakeComponent, "SUBMIT")
+	Response200StocktakeRecount = localization.NewResponseKey(http.StatusOK, data.StocktakeComponent, "RECOUNT")
+	Response200StocktakeApprove = localization.NewResponseKey(http.StatusOK, data.StocktakeComponent, "APPROVE")
+	Response200StocktakeCancel  = localization.NewResponseKey(http.StatusOK, data.StocktakeComponent, "CANCEL")
+)
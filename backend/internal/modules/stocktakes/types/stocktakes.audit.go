@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+type StocktakePayloads struct {
+	ApproveStocktakeDTO *ApproveStocktakeDTO  `json:"approveStocktakeDTO,omitempty"`
+	Status              *data.StocktakeStatus `json:"status,omitempty"`
+}
+
+var (
+	CreateStoreStocktakeAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.StoreStocktakeComponent, &CreateStocktakeDTO{})
+
+	UpdateStoreStocktakeAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.UpdateOperation, data.StoreStocktakeComponent, &StocktakePayloads{})
+
+	CreateWarehouseStocktakeAuditFactory = shared.NewAuditWarehouseActionExtendedFactory(
+		data.CreateOperation, data.WarehouseStocktakeComponent, &CreateStocktakeDTO{})
+
+	UpdateWarehouseStocktakeAuditFactory = shared.NewAuditWarehouseActionExtendedFactory(
+		data.UpdateOperation, data.WarehouseStocktakeComponent, &StocktakePayloads{})
+)
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// StocktakeFacility is the store or the warehouse counting its stock, exactly one of them is set
+type StocktakeFacility struct {
+	StoreID     *uint
+	WarehouseID *uint
+}
+
+// CreateStocktakeDTO starts a count of the given ingredients of a store or stock materials of a warehouse,
+// the whole stock of the facility is counted when no items are given
+type CreateStocktakeDTO struct {
+	Comment *string `json:"comment" binding:"omitempty,max=1000"`
+	ItemIDs []uint  `json:"itemIds" binding:"omitempty,dive,gt=0"`
+}
+
+// StocktakeCountDTO is a counted quantity of a line. A scanned barcode counts packages of the stock material,
+// they are converted to the ingredient units in stores. The quantity is added to the previous count when add is set
+type StocktakeCountDTO struct {
+	LineID   *uint   `json:"lineId" binding:"omitempty,gt=0"`
+	Barcode  *string `json:"barcode" binding:"omitempty,min=1"`
+	Quantity float64 `json:"quantity" binding:"gte=0"`
+	Add      bool    `json:"add"`
+}
+
+type SubmitStocktakeCountsDTO struct {
+	Counts []StocktakeCountDTO `json:"counts" binding:"required,min=1,dive"`
+}
+
+// ApproveStocktakeDTO posts the variances of a submitted stocktake,
+// the variances without a reviewed reason are posted as count corrections
+type ApproveStocktakeDTO struct {
+	Comment *string                  `json:"comment" binding:"omitempty,max=1000"`
+	Lines   []StocktakeLineReviewDTO `json:"lines" binding:"omitempty,dive"`
+}
+
+type StocktakeLineReviewDTO struct {
+	LineID  uint                       `json:"lineId" binding:"required"`
+	Reason  data.StockAdjustmentReason `json:"reason" binding:"required,oneof=COUNT_CORRECTION DAMAGED EXPIRED WASTE THEFT UNRECORDED_MOVEMENT"`
+	Comment *string                    `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type StocktakeDTO struct {
+	ID           uint                  `json:"id"`
+	StoreID      *uint                 `json:"storeId"`
+	WarehouseID  *uint                 `json:"warehouseId"`
+	FacilityName string                `json:"facilityName"`
+	Status       data.StocktakeStatus  `json:"status"`
+	Comment      string                `json:"comment"`
+	StartedBy    StocktakeEmployeeDTO  `json:"startedBy"`
+	ApprovedBy   *StocktakeEmployeeDTO `json:"approvedBy"`
+	Summary      StocktakeSummaryDTO   `json:"summary"`
+	Lines        []StocktakeLineDTO    `json:"lines,omitempty"`
+	SubmittedAt  *time.Time            `json:"submittedAt"`
+	ApprovedAt   *time.Time            `json:"approvedAt"`
+	CancelledAt  *time.Time            `json:"cancelledAt"`
+	CreatedAt    time.Time             `json:"createdAt"`
+	UpdatedAt    time.Time             `json:"updatedAt"`
+}
+
+type StocktakeEmployeeDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type StocktakeSummaryDTO struct {
+	TotalLines    int `json:"totalLines"`
+	CountedLines  int `json:"countedLines"`
+	VarianceLines int `json:"varianceLines"`
+}
+
+// StocktakeLineDTO keeps the variance empty until the line is counted, a shortage is negative
+type StocktakeLineDTO struct {
+	ID               uint                        `json:"id"`
+	IngredientID     *uint                       `json:"ingredientId"`
+	StockMaterialID  *uint                       `json:"stockMaterialId"`
+	Name             string                      `json:"name"`
+	Barcode          string                      `json:"barcode,omitempty"`
+	Unit             string                      `json:"unit"`
+	ExpectedQuantity float64                     `json:"expectedQuantity"`
+	CountedQuantity  *float64                    `json:"countedQuantity"`
+	Variance         *float64                    `json:"variance"`
+	VariancePercent  *float64                    `json:"variancePercent"`
+	Reason           *data.StockAdjustmentReason `json:"reason"`
+	Comment          string                      `json:"comment"`
+	CountedBy        *StocktakeEmployeeDTO       `json:"countedBy"`
+	CountedAt        *time.Time                  `json:"countedAt"`
+}
+
+type StocktakesFilter struct {
+	utils.BaseFilter
+	StoreID     *uint                 `form:"-"`
+	WarehouseID *uint                 `form:"-"`
+	Status      *data.StocktakeStatus `form:"status" binding:"omitempty,oneof=IN_PROGRESS SUBMITTED APPROVED CANCELLED"`
+}
+
+type ExportStocktakeQuery struct {
+	Language string `form:"language" binding:"omitempty,oneof=kk ru en"`
+}
@@ -0,0 +1,189 @@
+package types
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+var stocktakeTransitions = map[data.StocktakeStatus][]data.StocktakeStatus{
+	data.StocktakeStatusInProgress: {data.StocktakeStatusSubmitted, data.StocktakeStatusCancelled},
+	data.StocktakeStatusSubmitted:  {data.StocktakeStatusInProgress, data.StocktakeStatusApproved, data.StocktakeStatusCancelled},
+}
+
+// ValidateStatusTransition checks the stocktake may move to the status, a submitted stocktake may be sent back to recount
+func ValidateStatusTransition(from, to data.StocktakeStatus) error {
+	for _, status := range stocktakeTransitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+	return ErrInvalidStatusTransition
+}
+
+func (f StocktakeFacility) IsStore() bool {
+	return f.StoreID != nil
+}
+
+// NewStoreStocktakeLines freezes the quantities of the store stocks
+func NewStoreStocktakeLines(stocks []data.StoreStock) []data.StocktakeLine {
+	lines := make([]data.StocktakeLine, len(stocks))
+	for i, stock := range stocks {
+		ingredientID := stock.IngredientID
+		lines[i] = data.StocktakeLine{
+			IngredientID:     &ingredientID,
+			ExpectedQuantity: utils.RoundToDecimal(stock.Quantity, 2),
+		}
+	}
+	return lines
+}
+
+// NewWarehouseStocktakeLines freezes the quantities of the warehouse stocks
+func NewWarehouseStocktakeLines(stocks []data.WarehouseStock) []data.StocktakeLine {
+	lines := make([]data.StocktakeLine, len(stocks))
+	for i, stock := range stocks {
+		stockMaterialID := stock.StockMaterialID
+		lines[i] = data.StocktakeLine{
+			StockMaterialID:  &stockMaterialID,
+			ExpectedQuantity: utils.RoundToDecimal(stock.Quantity, 2),
+		}
+	}
+	return lines
+}
+
+// PackageQuantity is the quantity of the ingredient in one package of the stock material
+func PackageQuantity(material *stockMaterialTypes.StockMaterialsDTO) float64 {
+	if material.Ingredient.Unit.ID != material.Unit.ID && material.Ingredient.Unit.ConversionFactor > 0 {
+		return material.Unit.ConversionFactor / material.Ingredient.Unit.ConversionFactor * material.Size
+	}
+	return material.Size
+}
+
+// FindLineByID returns the line of the stocktake, nil when the stocktake has no such line
+func FindLineByID(lines []data.StocktakeLine, lineID uint) *data.StocktakeLine {
+	for i := range lines {
+		if lines[i].ID == lineID {
+			return &lines[i]
+		}
+	}
+	return nil
+}
+
+// FindLineByMaterial returns the line counting the scanned stock material and the counted quantity of the line,
+// the packages are counted in the ingredient units in stores and as they are in warehouses
+func FindLineByMaterial(lines []data.StocktakeLine, material *stockMaterialTypes.StockMaterialsDTO, packages float64) (*data.StocktakeLine, float64) {
+	for i := range lines {
+		line := &lines[i]
+		switch {
+		case line.StockMaterialID != nil && *line.StockMaterialID == material.ID:
+			return line, packages
+		case line.IngredientID != nil && *line.IngredientID == material.Ingredient.ID:
+			return line, utils.RoundToDecimal(packages*PackageQuantity(material), 2)
+		}
+	}
+	return nil, 0
+}
+
+// ApplyCount records the counted quantity of the line, the quantity is added to the previous count when add is set
+func ApplyCount(line *data.StocktakeLine, quantity float64, add bool, employeeID uint, countedAt time.Time) {
+	counted := quantity
+	if add && line.CountedQuantity != nil {
+		counted += *line.CountedQuantity
+	}
+	counted = utils.RoundToDecimal(counted, 2)
+
+	line.CountedQuantity = &counted
+	line.CountedByID = &employeeID
+	line.CountedAt = &countedAt
+}
+
+// LineVariance is the counted quantity less the expected one, false when the line is not counted yet
+func LineVariance(line data.StocktakeLine) (float64, bool) {
+	if line.CountedQuantity == nil {
+		return 0, false
+	}
+	return utils.RoundToDecimal(*line.CountedQuantity-line.ExpectedQuantity, 2), true
+}
+
+// VariancePercent is the variance relative to the expected quantity, nil when nothing was expected
+func VariancePercent(line data.StocktakeLine) *float64 {
+	variance, ok := LineVariance(line)
+	if !ok || line.ExpectedQuantity == 0 {
+		return nil
+	}
+	percent := utils.RoundToDecimal(variance/math.Abs(line.ExpectedQuantity)*100, 2)
+	return &percent
+}
+
+func SummarizeLines(lines []data.StocktakeLine) StocktakeSummaryDTO {
+	summary := StocktakeSummaryDTO{TotalLines: len(lines)}
+	for _, line := range lines {
+		variance, ok := LineVariance(line)
+		if !ok {
+			continue
+		}
+		summary.CountedLines++
+		if variance != 0 {
+			summary.VarianceLines++
+		}
+	}
+	return summary
+}
+
+// ApplyReviews sets the reasons chosen by the manager, the counted lines with a variance and no reason become count corrections
+func ApplyReviews(lines []data.StocktakeLine, reviews []StocktakeLineReviewDTO) error {
+	for _, review := range reviews {
+		line := FindLineByID(lines, review.LineID)
+		if line == nil {
+			return ErrStocktakeLineNotFound
+		}
+		reason := review.Reason
+		line.Reason = &reason
+		if review.Comment != nil {
+			line.Comment = strings.TrimSpace(*review.Comment)
+		}
+	}
+
+	for i := range lines {
+		if variance, ok := LineVariance(lines[i]); ok && variance != 0 && lines[i].Reason == nil {
+			reason := data.StockAdjustmentReasonCountCorrection
+			lines[i].Reason = &reason
+		}
+	}
+	return nil
+}
+
+// BuildAdjustments turns the variances of the reviewed lines into the stock adjustments of the facility,
+// the uncounted lines and the lines without a variance are left out
+func BuildAdjustments(stocktake *data.Stocktake, employeeID uint) []data.StockAdjustment {
+	var adjustments []data.StockAdjustment
+	for _, line := range stocktake.Lines {
+		variance, ok := LineVariance(line)
+		if !ok || variance == 0 {
+			continue
+		}
+
+		reason := data.StockAdjustmentReasonCountCorrection
+		if line.Reason != nil {
+			reason = *line.Reason
+		}
+
+		stocktakeID := stocktake.ID
+		adjustments = append(adjustments, data.StockAdjustment{
+			StoreID:         stocktake.StoreID,
+			WarehouseID:     stocktake.WarehouseID,
+			IngredientID:    line.IngredientID,
+			StockMaterialID: line.StockMaterialID,
+			StocktakeID:     &stocktakeID,
+			Quantity:        variance,
+			Reason:          reason,
+			Comment:         line.Comment,
+			EmployeeID:      employeeID,
+		})
+	}
+	return adjustments
+}
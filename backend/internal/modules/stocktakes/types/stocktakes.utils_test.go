@@ -0,0 +1,126 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	ingredientTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients/types"
+	unitTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/units/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func counted(quantity float64) *float64 {
+	return &quantity
+}
+
+func TestValidateStatusTransition(t *testing.T) {
+	assert.NoError(t, ValidateStatusTransition(data.StocktakeStatusInProgress, data.StocktakeStatusSubmitted))
+	assert.NoError(t, ValidateStatusTransition(data.StocktakeStatusSubmitted, data.StocktakeStatusInProgress))
+	assert.NoError(t, ValidateStatusTransition(data.StocktakeStatusSubmitted, data.StocktakeStatusApproved))
+	assert.ErrorIs(t, ValidateStatusTransition(data.StocktakeStatusInProgress, data.StocktakeStatusApproved), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, ValidateStatusTransition(data.StocktakeStatusApproved, data.StocktakeStatusCancelled), ErrInvalidStatusTransition)
+}
+
+func TestFindLineByMaterial(t *testing.T) {
+	material := &stockMaterialTypes.StockMaterialsDTO{
+		ID:   7,
+		Size: 2,
+		Unit: unitTypes.UnitsDTO{ID: 1, ConversionFactor: 1},
+		Ingredient: ingredientTypes.IngredientDTO{
+			ID:   3,
+			Unit: unitTypes.UnitsDTO{ID: 2, ConversionFactor: 0.001},
+		},
+	}
+
+	t.Run("Store line should count the packages in the ingredient units", func(t *testing.T) {
+		ingredientID := uint(3)
+		lines := []data.StocktakeLine{{BaseEntity: data.BaseEntity{ID: 1}, IngredientID: &ingredientID}}
+
+		line, quantity := FindLineByMaterial(lines, material, 1.5)
+		assert.Equal(t, uint(1), line.ID)
+		assert.Equal(t, 3000.0, quantity)
+	})
+
+	t.Run("Warehouse line should count the packages", func(t *testing.T) {
+		stockMaterialID := uint(7)
+		lines := []data.StocktakeLine{{BaseEntity: data.BaseEntity{ID: 2}, StockMaterialID: &stockMaterialID}}
+
+		line, quantity := FindLineByMaterial(lines, material, 1.5)
+		assert.Equal(t, uint(2), line.ID)
+		assert.Equal(t, 1.5, quantity)
+	})
+
+	t.Run("Unknown material should match no line", func(t *testing.T) {
+		other := uint(8)
+		line, _ := FindLineByMaterial([]data.StocktakeLine{{StockMaterialID: &other}}, material, 1)
+		assert.Nil(t, line)
+	})
+}
+
+func TestApplyCount(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	line := data.StocktakeLine{ExpectedQuantity: 10}
+
+	ApplyCount(&line, 4, true, 1, now)
+	ApplyCount(&line, 2.5, true, 2, now)
+	assert.Equal(t, 6.5, *line.CountedQuantity)
+	assert.Equal(t, uint(2), *line.CountedByID)
+
+	ApplyCount(&line, 9, false, 1, now)
+	assert.Equal(t, 9.0, *line.CountedQuantity)
+}
+
+func TestLineVariance(t *testing.T) {
+	_, ok := LineVariance(data.StocktakeLine{ExpectedQuantity: 5})
+	assert.False(t, ok)
+
+	variance, ok := LineVariance(data.StocktakeLine{ExpectedQuantity: 5, CountedQuantity: counted(3.5)})
+	assert.True(t, ok)
+	assert.Equal(t, -1.5, variance)
+
+	assert.Equal(t, -30.0, *VariancePercent(data.StocktakeLine{ExpectedQuantity: 5, CountedQuantity: counted(3.5)}))
+	assert.Nil(t, VariancePercent(data.StocktakeLine{ExpectedQuantity: 0, CountedQuantity: counted(2)}))
+}
+
+func TestBuildAdjustments(t *testing.T) {
+	storeID, flour, milk, sugar := uint(1), uint(10), uint(11), uint(12)
+	stocktake := &data.Stocktake{
+		BaseEntity: data.BaseEntity{ID: 5},
+		StoreID:    &storeID,
+		Lines: []data.StocktakeLine{
+			{BaseEntity: data.BaseEntity{ID: 1}, IngredientID: &flour, ExpectedQuantity: 10, CountedQuantity: counted(2)},
+			{BaseEntity: data.BaseEntity{ID: 2}, IngredientID: &milk, ExpectedQuantity: 4, CountedQuantity: counted(4)},
+			{BaseEntity: data.BaseEntity{ID: 3}, IngredientID: &sugar, ExpectedQuantity: 3},
+		},
+	}
+
+	t.Run("Review of an unknown line should be rejected", func(t *testing.T) {
+		err := ApplyReviews(stocktake.Lines, []StocktakeLineReviewDTO{{LineID: 9, Reason: data.StockAdjustmentReasonTheft}})
+		assert.ErrorIs(t, err, ErrStocktakeLineNotFound)
+	})
+
+	comment := " spilled "
+	err := ApplyReviews(stocktake.Lines, []StocktakeLineReviewDTO{{LineID: 1, Reason: data.StockAdjustmentReasonWaste, Comment: &comment}})
+	assert.NoError(t, err)
+
+	adjustments := BuildAdjustments(stocktake, 42)
+
+	t.Run("Only the counted variances should be posted", func(t *testing.T) {
+		assert.Len(t, adjustments, 1)
+		assert.Equal(t, flour, *adjustments[0].IngredientID)
+		assert.Equal(t, -8.0, adjustments[0].Quantity)
+		assert.Equal(t, uint(5), *adjustments[0].StocktakeID)
+		assert.Equal(t, uint(42), adjustments[0].EmployeeID)
+	})
+
+	t.Run("Reviewed reason should travel with the adjustment", func(t *testing.T) {
+		assert.Equal(t, data.StockAdjustmentReasonWaste, adjustments[0].Reason)
+		assert.Equal(t, "spilled", adjustments[0].Comment)
+	})
+
+	t.Run("Summary should count the counted and the varying lines", func(t *testing.T) {
+		assert.Equal(t, StocktakeSummaryDTO{TotalLines: 3, CountedLines: 2, VarianceLines: 1}, SummarizeLines(stocktake.Lines))
+	})
+}
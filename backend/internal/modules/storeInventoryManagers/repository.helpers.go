@@ -182,6 +182,120 @@ func bulkSaveStoreProvisions(
 	return nil
 }
 
+func restoreStoreStocks(
+	tx *gorm.DB,
+	storeID uint,
+	restoredIngredientQtyMap map[uint]float64,
+) (map[uint]*data.StoreStock, error) {
+	if len(restoredIngredientQtyMap) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(restoredIngredientQtyMap))
+	for id := range restoredIngredientQtyMap {
+		ids = append(ids, id)
+	}
+
+	stocks, err := getRelevantStoreStocks(tx, storeID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	stockMap := make(map[uint]*data.StoreStock, len(stocks))
+	for i := range stocks {
+		s := &stocks[i]
+		stockMap[s.IngredientID] = s
+	}
+
+	var toSave []data.StoreStock
+	result := make(map[uint]*data.StoreStock, len(restoredIngredientQtyMap))
+	for ingrID, qty := range restoredIngredientQtyMap {
+		s, ok := stockMap[ingrID]
+		if !ok {
+			return nil, fmt.Errorf("stock not found for ingredient ID %d", ingrID)
+		}
+		s.Quantity += qty
+		toSave = append(toSave, *s)
+		result[ingrID] = s
+	}
+
+	if err := bulkSaveStoreStocks(tx, toSave); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// restoreStoreProvisions returns volume to the latest non-expired store provisions,
+// never exceeding their initial volume. Volume that does not fit anywhere is dropped.
+func restoreStoreProvisions(
+	tx *gorm.DB,
+	storeID uint,
+	restoredProvisionVolMap map[uint]float64,
+) (map[uint][]*data.StoreProvision, error) {
+	if len(restoredProvisionVolMap) == 0 {
+		return nil, nil
+	}
+
+	provIDs := make([]uint, 0, len(restoredProvisionVolMap))
+	for id := range restoredProvisionVolMap {
+		provIDs = append(provIDs, id)
+	}
+
+	var allProv []data.StoreProvision
+	err := tx.Model(&data.StoreProvision{}).
+		Where("store_id = ?", storeID).
+		Where("provision_id IN ?", provIDs).
+		Where("status IN ?", []data.StoreProvisionStatus{
+			data.STORE_PROVISION_STATUS_COMPLETED,
+			data.STORE_PROVISION_STATUS_EMPTY,
+		}).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now().UTC()).
+		Order("expires_at DESC").
+		Find(&allProv).Error
+	if err != nil {
+		return nil, err
+	}
+
+	group := make(map[uint][]*data.StoreProvision, len(restoredProvisionVolMap))
+	for i := range allProv {
+		p := &allProv[i]
+		group[p.ProvisionID] = append(group[p.ProvisionID], p)
+	}
+
+	result := make(map[uint][]*data.StoreProvision, len(restoredProvisionVolMap))
+	var toSave []data.StoreProvision
+
+	for provID, vol := range restoredProvisionVolMap {
+		remain := vol
+		for _, p := range group[provID] {
+			if remain <= 0 {
+				break
+			}
+			delta := p.InitialVolume - p.Volume
+			if delta > remain {
+				delta = remain
+			}
+			if delta <= 0 {
+				continue
+			}
+
+			p.Volume += delta
+			remain -= delta
+			p.Status = data.STORE_PROVISION_STATUS_COMPLETED
+
+			toSave = append(toSave, *p)
+			result[provID] = append(result[provID], p)
+		}
+	}
+
+	if err := bulkSaveStoreProvisions(tx, toSave); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func recalculateStoreProducts(
 	tx *gorm.DB,
 	storeProductIDs []uint,
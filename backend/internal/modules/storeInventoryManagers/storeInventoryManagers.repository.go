@@ -7,6 +7,8 @@ import (
 	ingredientTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients/types"
 	storeProvisionsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/provisions/storeProvisions/types"
 	provisionsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/provisions/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	stockMovementsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers/types"
 	storeStocksTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
@@ -30,8 +32,9 @@ type StoreInventoryManagerRepository interface {
 		frozenInventory *types.FrozenInventory,
 	) error
 
-	DeductStoreInventory(storeID uint, inventory *types.InventoryUsage) (*types.DeductedInventoryMap, error)
-	DeductStoreStocksByStoreProvision(storeProvision *data.StoreProvision) (map[uint]*data.StoreStock, error)
+	DeductStoreInventory(storeID uint, inventory *types.InventoryUsage, source data.StockMovementSource) (*types.DeductedInventoryMap, error)
+	DeductStoreStocksByStoreProvision(storeProvision *data.StoreProvision, employeeID uint) (map[uint]*data.StoreStock, error)
+	RestoreStoreInventory(storeID uint, inventory *types.InventoryUsage, source data.StockMovementSource) (*types.DeductedInventoryMap, error)
 
 	RecalculateStoreAdditives(storeAdditiveIDs []uint, storeID uint, frozenInventory *types.FrozenInventory) error
 	RecalculateStoreInventory(storeID uint, input *types.RecalculateInput) error
@@ -41,20 +44,24 @@ type StoreInventoryManagerRepository interface {
 }
 
 type storeInventoryManagerRepository struct {
-	db *gorm.DB
+	db                *gorm.DB
+	stockMovementRepo stockMovements.StockMovementRepository
 }
 
 func NewStoreInventoryManagerRepository(
 	db *gorm.DB,
+	stockMovementRepo stockMovements.StockMovementRepository,
 ) StoreInventoryManagerRepository {
 	return &storeInventoryManagerRepository{
-		db: db,
+		db:                db,
+		stockMovementRepo: stockMovementRepo,
 	}
 }
 
 func (r *storeInventoryManagerRepository) CloneWithTransaction(tx *gorm.DB) StoreInventoryManagerRepository {
 	return &storeInventoryManagerRepository{
-		db: tx,
+		db:                tx,
+		stockMovementRepo: r.stockMovementRepo.CloneWithTransaction(tx),
 	}
 }
 
@@ -92,7 +99,7 @@ func (r *storeInventoryManagerRepository) GetProvisionsByIDs(provisionIDs []uint
 	return provisions, nil
 }
 
-func (r *storeInventoryManagerRepository) DeductStoreInventory(storeID uint, inventory *types.InventoryUsage) (*types.DeductedInventoryMap, error) {
+func (r *storeInventoryManagerRepository) DeductStoreInventory(storeID uint, inventory *types.InventoryUsage, source data.StockMovementSource) (*types.DeductedInventoryMap, error) {
 	deductedInventory := &types.DeductedInventoryMap{
 		IngredientStoreStockMap:     make(map[uint]*data.StoreStock),
 		ProvisionStoreProvisionsMap: make(map[uint][]*data.StoreProvision),
@@ -105,6 +112,9 @@ func (r *storeInventoryManagerRepository) DeductStoreInventory(storeID uint, inv
 		if err != nil {
 			return err
 		}
+		if err := r.recordStoreStockMovements(tx, storeID, deductedInventory.IngredientStoreStockMap, inventory.Ingredients, -1, source); err != nil {
+			return err
+		}
 		deductedInventory.ProvisionStoreProvisionsMap, err = deductStoreProvisions(tx, storeID, inventory.Provisions)
 		if err != nil {
 			return err
@@ -118,7 +128,36 @@ func (r *storeInventoryManagerRepository) DeductStoreInventory(storeID uint, inv
 	return deductedInventory, nil
 }
 
-func (r *storeInventoryManagerRepository) DeductStoreStocksByStoreProvision(storeProvision *data.StoreProvision) (map[uint]*data.StoreStock, error) {
+func (r *storeInventoryManagerRepository) RestoreStoreInventory(storeID uint, inventory *types.InventoryUsage, source data.StockMovementSource) (*types.DeductedInventoryMap, error) {
+	restoredInventory := &types.DeductedInventoryMap{
+		IngredientStoreStockMap:     make(map[uint]*data.StoreStock),
+		ProvisionStoreProvisionsMap: make(map[uint][]*data.StoreProvision),
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		restoredInventory.IngredientStoreStockMap, err = restoreStoreStocks(tx, storeID, inventory.Ingredients)
+		if err != nil {
+			return err
+		}
+		if err := r.recordStoreStockMovements(tx, storeID, restoredInventory.IngredientStoreStockMap, inventory.Ingredients, 1, source); err != nil {
+			return err
+		}
+		restoredInventory.ProvisionStoreProvisionsMap, err = restoreStoreProvisions(tx, storeID, inventory.Provisions)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restoredInventory, nil
+}
+
+func (r *storeInventoryManagerRepository) DeductStoreStocksByStoreProvision(storeProvision *data.StoreProvision, employeeID uint) (map[uint]*data.StoreStock, error) {
 	if storeProvision == nil || storeProvision.StoreID == 0 || storeProvision.ID == 0 {
 		return nil, fmt.Errorf("invalid input parameters")
 	}
@@ -128,19 +167,25 @@ func (r *storeInventoryManagerRepository) DeductStoreStocksByStoreProvision(stor
 		return nil, err
 	}
 
-	deductedStocks := make(map[uint]*data.StoreStock)
-	requiredIngredientQtyMap := make(map[uint]float64)
+	requiredIngredientQtyMap := make(map[uint]float64, len(storeProvisionIngredients))
+	for _, spIngredient := range storeProvisionIngredients {
+		requiredIngredientQtyMap[spIngredient.IngredientID] += spIngredient.Quantity
+	}
+
+	source := data.StockMovementSource{
+		Type:       data.StockMovementSourceStoreProvision,
+		ID:         &storeProvision.ID,
+		EmployeeID: &employeeID,
+	}
+
+	var deductedStocks map[uint]*data.StoreStock
 	err = r.db.Transaction(func(tx *gorm.DB) error {
 		var err error
-		for _, spIngredient := range storeProvisionIngredients {
-			requiredIngredientQtyMap[spIngredient.IngredientID] = spIngredient.Quantity
-
-			deductedStocks, err = deductStoreStocks(tx, storeProvision.StoreID, requiredIngredientQtyMap)
-			if err != nil {
-				return err
-			}
+		deductedStocks, err = deductStoreStocks(tx, storeProvision.StoreID, requiredIngredientQtyMap)
+		if err != nil {
+			return err
 		}
-		return nil
+		return r.recordStoreStockMovements(tx, storeProvision.StoreID, deductedStocks, requiredIngredientQtyMap, -1, source)
 	})
 	if err != nil {
 		return nil, err
@@ -149,6 +194,23 @@ func (r *storeInventoryManagerRepository) DeductStoreStocksByStoreProvision(stor
 	return deductedStocks, nil
 }
 
+// recordStoreStockMovements writes the movements of the changed stocks, the stocks hold the quantities after the change by the sign
+func (r *storeInventoryManagerRepository) recordStoreStockMovements(
+	tx *gorm.DB,
+	storeID uint,
+	stocks map[uint]*data.StoreStock,
+	changes map[uint]float64,
+	sign float64,
+	source data.StockMovementSource,
+) error {
+	movements := make([]data.StockMovement, 0, len(stocks))
+	for ingredientID, stock := range stocks {
+		before := stock.Quantity - sign*changes[ingredientID]
+		movements = append(movements, stockMovementsTypes.NewStoreStockMovement(source, storeID, ingredientID, before, stock.Quantity))
+	}
+	return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movements...)
+}
+
 func (r *storeInventoryManagerRepository) RecalculateStoreInventory(storeID uint, input *types.RecalculateInput) error {
 	if storeID == 0 {
 		return errors.New("failed to recalculate with invalid input parameters")
@@ -0,0 +1,211 @@
+package storeStations
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStations/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type StoreStationHandler struct {
+	service      StoreStationService
+	auditService audit.AuditService
+}
+
+func NewStoreStationHandler(service StoreStationService, auditService audit.AuditService) *StoreStationHandler {
+	return &StoreStationHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *StoreStationHandler) CreateStoreStation(c *gin.Context) {
+	var dto types.CreateStoreStationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	station, err := h.service.CreateStation(storeID, &dto)
+	if err != nil {
+		sendStationSaveError(c, err, types.Response500StoreStationCreate)
+		return
+	}
+
+	action := types.CreateStoreStationAuditFactory(
+		&data.BaseDetails{
+			ID:   station.ID,
+			Name: station.Name,
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SendResponseWithStatus(c, station, http.StatusCreated)
+}
+
+func (h *StoreStationHandler) GetStoreStations(c *gin.Context) {
+	var filter types.StoreStationsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.StoreStation{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+	filter.StoreID = &storeID
+
+	stations, err := h.service.GetStations(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStationGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, stations, filter.Pagination)
+}
+
+func (h *StoreStationHandler) UpdateStoreStation(c *gin.Context) {
+	stationID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StoreStation)
+		return
+	}
+
+	var dto types.UpdateStoreStationDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	station, err := h.service.UpdateStation(stationID, storeID, &dto)
+	if err != nil {
+		if errors.Is(err, types.ErrStoreStationNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreStation)
+			return
+		}
+		sendStationSaveError(c, err, types.Response500StoreStationUpdate)
+		return
+	}
+
+	action := types.UpdateStoreStationAuditFactory(
+		&data.BaseDetails{
+			ID:   station.ID,
+			Name: station.Name,
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200StoreStationUpdate)
+}
+
+func (h *StoreStationHandler) DeleteStoreStation(c *gin.Context) {
+	stationID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StoreStation)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	station, err := h.service.GetStationByID(stationID, storeID)
+	if err != nil {
+		if errors.Is(err, types.ErrStoreStationNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreStation)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStationGet)
+		return
+	}
+
+	if err := h.service.DeleteStation(stationID, storeID); err != nil {
+		if errors.Is(err, types.ErrStoreStationNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreStation)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStationDelete)
+		return
+	}
+
+	action := types.DeleteStoreStationAuditFactory(
+		&data.BaseDetails{
+			ID:   station.ID,
+			Name: station.Name,
+		},
+		struct{}{}, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200StoreStationDelete)
+}
+
+// GetStoreStationsThroughput reports the suborders completed by every station of the store, the unrouted ones are reported without a station
+func (h *StoreStationHandler) GetStoreStationsThroughput(c *gin.Context) {
+	var filter types.StationThroughputFilter
+	if err := c.ShouldBindQuery(&filter); err != nil || filter.EndDate.Before(filter.StartDate) {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+	filter.StoreID = storeID
+
+	throughput, err := h.service.GetStationsThroughput(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStationGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, throughput)
+}
+
+func sendStationSaveError(c *gin.Context, err error, fallback *localization.ResponseKey) {
+	switch {
+	case errors.Is(err, types.ErrInvalidStationCategories):
+		localization.SendLocalizedResponseWithKey(c, types.Response400StoreStationCategories)
+	case errors.Is(err, types.ErrStationCategoryTaken):
+		localization.SendLocalizedResponseWithKey(c, types.Response409StoreStationCategory)
+	case errors.Is(err, types.ErrStoreStationExists):
+		localization.SendLocalizedResponseWithKey(c, types.Response409StoreStation)
+	default:
+		localization.SendLocalizedResponseWithKey(c, fallback)
+	}
+}
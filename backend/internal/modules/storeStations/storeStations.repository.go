@@ -0,0 +1,161 @@
+package storeStations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStations/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type StoreStationRepository interface {
+	CreateStation(station *data.StoreStation) error
+	GetStationByID(id, storeID uint) (*data.StoreStation, error)
+	GetStations(filter *types.StoreStationsFilter) ([]data.StoreStation, error)
+	UpdateStation(station *data.StoreStation, categories []data.StoreStationCategory) error
+	DeleteStation(id, storeID uint) error
+	GetStationsThroughput(filter *types.StationThroughputFilter) ([]types.StationThroughput, error)
+}
+
+type storeStationRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreStationRepository(db *gorm.DB) StoreStationRepository {
+	return &storeStationRepository{db: db}
+}
+
+func (r *storeStationRepository) CreateStation(station *data.StoreStation) error {
+	return mapStationError(r.db.Create(station).Error)
+}
+
+func (r *storeStationRepository) GetStationByID(id, storeID uint) (*data.StoreStation, error) {
+	var station data.StoreStation
+	err := r.db.Preload("Categories").
+		Where("id = ? AND store_id = ?", id, storeID).
+		First(&station).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreStationNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store station with ID %d: %w", id, err)
+	}
+	return &station, nil
+}
+
+func (r *storeStationRepository) GetStations(filter *types.StoreStationsFilter) ([]data.StoreStation, error) {
+	var stations []data.StoreStation
+
+	query := r.db.Model(&data.StoreStation{}).Preload("Categories")
+
+	if filter.StoreID != nil {
+		query = query.Where("store_id = ?", *filter.StoreID)
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+*filter.Search+"%")
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.StoreStation{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&stations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch store stations: %w", err)
+	}
+
+	return stations, nil
+}
+
+// UpdateStation saves the name of the station and replaces its categories when they are given
+func (r *storeStationRepository) UpdateStation(station *data.StoreStation, categories []data.StoreStationCategory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&data.StoreStation{}).
+			Where("id = ?", station.ID).
+			Update("name", station.Name).Error
+		if err != nil {
+			return mapStationError(err)
+		}
+
+		if categories == nil {
+			return nil
+		}
+
+		if err := tx.Unscoped().Where("station_id = ?", station.ID).Delete(&data.StoreStationCategory{}).Error; err != nil {
+			return fmt.Errorf("failed to delete categories of station %d: %w", station.ID, err)
+		}
+
+		for i := range categories {
+			categories[i].StationID = station.ID
+		}
+		return mapStationError(tx.Create(&categories).Error)
+	})
+}
+
+// DeleteStation releases the categories of the station, their new suborders are not routed until another station takes them
+func (r *storeStationRepository) DeleteStation(id, storeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND store_id = ?", id, storeID).Delete(&data.StoreStation{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return types.ErrStoreStationNotFound
+		}
+
+		return tx.Unscoped().Where("station_id = ?", id).Delete(&data.StoreStationCategory{}).Error
+	})
+}
+
+// GetStationsThroughput counts the suborders completed on the local dates of the store by station,
+// the preparation time is measured from the start of the suborder preparation
+func (r *storeStationRepository) GetStationsThroughput(filter *types.StationThroughputFilter) ([]types.StationThroughput, error) {
+	var results []types.StationThroughput
+
+	err := r.db.Raw(`
+		SELECT
+			suborders.station_id,
+			store_stations.name AS station_name,
+			COUNT(*) AS completed_suborders,
+			COALESCE(AVG(EXTRACT(EPOCH FROM (suborders.completed_at - suborders.started_at))), 0) AS average_preparation_seconds,
+			COALESCE(AVG(EXTRACT(EPOCH FROM (suborders.completed_at - orders.created_at))), 0) AS average_completion_seconds
+		FROM suborders
+		JOIN orders ON orders.id = suborders.order_id
+		JOIN stores ON stores.id = orders.store_id
+		LEFT JOIN store_stations ON store_stations.id = suborders.station_id
+		WHERE orders.store_id = ?
+			AND suborders.status = ?
+			AND suborders.deleted_at IS NULL
+			AND (suborders.completed_at AT TIME ZONE stores.timezone) >= ?::date
+			AND (suborders.completed_at AT TIME ZONE stores.timezone) < ?::date + 1
+		GROUP BY suborders.station_id, store_stations.name
+		ORDER BY store_stations.name NULLS LAST`,
+		filter.StoreID,
+		data.SubOrderStatusCompleted,
+		filter.StartDate.Format(time.DateOnly),
+		filter.EndDate.Format(time.DateOnly),
+	).Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the throughput of the stations of store %d: %w", filter.StoreID, err)
+	}
+
+	return results, nil
+}
+
+func mapStationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if strings.Contains(err.Error(), "23505") {
+		if strings.Contains(err.Error(), "unique_store_station_category") {
+			return types.ErrStationCategoryTaken
+		}
+		return types.ErrStoreStationExists
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+package storeStations
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStations/types"
+	"go.uber.org/zap"
+)
+
+type StoreStationService interface {
+	CreateStation(storeID uint, dto *types.CreateStoreStationDTO) (*types.StoreStationDTO, error)
+	GetStationByID(id, storeID uint) (*types.StoreStationDTO, error)
+	GetStations(filter *types.StoreStationsFilter) ([]types.StoreStationDTO, error)
+	UpdateStation(id, storeID uint, dto *types.UpdateStoreStationDTO) (*types.StoreStationDTO, error)
+	DeleteStation(id, storeID uint) error
+	GetStationsThroughput(filter *types.StationThroughputFilter) ([]types.StationThroughputDTO, error)
+}
+
+type storeStationService struct {
+	repo   StoreStationRepository
+	logger *zap.SugaredLogger
+}
+
+func NewStoreStationService(repo StoreStationRepository, logger *zap.SugaredLogger) StoreStationService {
+	return &storeStationService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *storeStationService) CreateStation(storeID uint, dto *types.CreateStoreStationDTO) (*types.StoreStationDTO, error) {
+	categories, err := types.ToStationCategories(storeID, dto.MachineCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	station := &data.StoreStation{
+		Name:       strings.TrimSpace(dto.Name),
+		StoreID:    storeID,
+		Categories: categories,
+	}
+	if err := s.repo.CreateStation(station); err != nil {
+		if errors.Is(err, types.ErrStationCategoryTaken) || errors.Is(err, types.ErrStoreStationExists) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStoreStation, err))
+		return nil, types.ErrFailedToCreateStoreStation
+	}
+
+	response := types.ConvertToStoreStationDTO(station)
+	return &response, nil
+}
+
+func (s *storeStationService) GetStationByID(id, storeID uint) (*types.StoreStationDTO, error) {
+	station, err := s.repo.GetStationByID(id, storeID)
+	if err != nil {
+		if !errors.Is(err, types.ErrStoreStationNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	response := types.ConvertToStoreStationDTO(station)
+	return &response, nil
+}
+
+func (s *storeStationService) GetStations(filter *types.StoreStationsFilter) ([]types.StoreStationDTO, error) {
+	stations, err := s.repo.GetStations(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchStoreStations, err))
+		return nil, types.ErrFailedToFetchStoreStations
+	}
+
+	responses := make([]types.StoreStationDTO, len(stations))
+	for i := range stations {
+		responses[i] = types.ConvertToStoreStationDTO(&stations[i])
+	}
+	return responses, nil
+}
+
+func (s *storeStationService) UpdateStation(id, storeID uint, dto *types.UpdateStoreStationDTO) (*types.StoreStationDTO, error) {
+	station, err := s.repo.GetStationByID(id, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Name != nil {
+		station.Name = strings.TrimSpace(*dto.Name)
+	}
+
+	var categories []data.StoreStationCategory
+	if dto.MachineCategories != nil {
+		if categories, err = types.ToStationCategories(storeID, dto.MachineCategories); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateStation(station, categories); err != nil {
+		if errors.Is(err, types.ErrStationCategoryTaken) || errors.Is(err, types.ErrStoreStationExists) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdateStoreStation, err))
+		return nil, types.ErrFailedToUpdateStoreStation
+	}
+
+	if categories != nil {
+		station.Categories = categories
+	}
+	response := types.ConvertToStoreStationDTO(station)
+	return &response, nil
+}
+
+func (s *storeStationService) DeleteStation(id, storeID uint) error {
+	if err := s.repo.DeleteStation(id, storeID); err != nil {
+		if errors.Is(err, types.ErrStoreStationNotFound) {
+			return err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToDeleteStoreStation, err))
+		return types.ErrFailedToDeleteStoreStation
+	}
+	return nil
+}
+
+func (s *storeStationService) GetStationsThroughput(filter *types.StationThroughputFilter) ([]types.StationThroughputDTO, error) {
+	throughput, err := s.repo.GetStationsThroughput(filter)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, types.ErrFailedToFetchStoreStations
+	}
+
+	responses := make([]types.StationThroughputDTO, len(throughput))
+	for i := range throughput {
+		responses[i] = types.ConvertToStationThroughputDTO(&throughput[i])
+	}
+	return responses, nil
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+func ConvertToStoreStationDTO(station *data.StoreStation) StoreStationDTO {
+	categories := make([]data.MachineCategory, len(station.Categories))
+	for i, category := range station.Categories {
+		categories[i] = category.MachineCategory
+	}
+
+	return StoreStationDTO{
+		ID:                station.ID,
+		Name:              station.Name,
+		StoreID:           station.StoreID,
+		MachineCategories: categories,
+		CreatedAt:         station.CreatedAt,
+	}
+}
+
+// ToStationCategories checks the machine categories of the station, each of them is subscribed once
+func ToStationCategories(storeID uint, machineCategories []data.MachineCategory) ([]data.StoreStationCategory, error) {
+	if len(machineCategories) == 0 {
+		return nil, ErrInvalidStationCategories
+	}
+
+	seen := make(map[data.MachineCategory]bool, len(machineCategories))
+	categories := make([]data.StoreStationCategory, len(machineCategories))
+	for i, machineCategory := range machineCategories {
+		// the products of the OTHERS category are prepared at a station too
+		valid := data.IsValidMachineCategory(machineCategory) || machineCategory == data.OTHERS
+		if !valid || seen[machineCategory] {
+			return nil, ErrInvalidStationCategories
+		}
+		seen[machineCategory] = true
+
+		categories[i] = data.StoreStationCategory{
+			StoreID:         storeID,
+			MachineCategory: machineCategory,
+		}
+	}
+	return categories, nil
+}
+
+func ConvertToStationThroughputDTO(throughput *StationThroughput) StationThroughputDTO {
+	dto := StationThroughputDTO{
+		StationID:                 throughput.StationID,
+		CompletedSuborders:        throughput.CompletedSuborders,
+		AveragePreparationSeconds: throughput.AveragePreparationSeconds,
+		AverageCompletionSeconds:  throughput.AverageCompletionSeconds,
+	}
+	if throughput.StationName != nil {
+		dto.StationName = *throughput.StationName
+	}
+	return dto
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStationCategories(t *testing.T) {
+	t.Run("Categories should be bound to the store", func(t *testing.T) {
+		categories, err := ToStationCategories(3, []data.MachineCategory{data.COFFEE, data.TEA, data.OTHERS})
+
+		assert.NoError(t, err)
+		assert.Len(t, categories, 3)
+		assert.Equal(t, uint(3), categories[1].StoreID)
+		assert.Equal(t, data.TEA, categories[1].MachineCategory)
+	})
+
+	t.Run("Repeated or unknown categories should be rejected", func(t *testing.T) {
+		_, err := ToStationCategories(3, []data.MachineCategory{data.COFFEE, data.COFFEE})
+		assert.ErrorIs(t, err, ErrInvalidStationCategories)
+
+		_, err = ToStationCategories(3, []data.MachineCategory{"JUICE"})
+		assert.ErrorIs(t, err, ErrInvalidStationCategories)
+
+		_, err = ToStationCategories(3, nil)
+		assert.ErrorIs(t, err, ErrInvalidStationCategories)
+	})
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrStoreStationNotFound       = moduleErrors.NewModuleError(errors.New("store station not found"))
+	ErrInvalidStationCategories   = moduleErrors.NewModuleError(errors.New("invalid or repeated machine categories of the station"))
+	ErrStationCategoryTaken       = moduleErrors.NewModuleError(errors.New("machine category is already prepared by another station of the store"))
+	ErrStoreStationExists         = moduleErrors.NewModuleError(errors.New("store station with the same name already exists"))
+	ErrFailedToCreateStoreStation = moduleErrors.NewModuleError(errors.New("failed to create store station"))
+	ErrFailedToUpdateStoreStation = moduleErrors.NewModuleError(errors.New("failed to update store station"))
+	ErrFailedToDeleteStoreStation = moduleErrors.NewModuleError(errors.New("failed to delete store station"))
+	ErrFailedToFetchStoreStations = moduleErrors.NewModuleError(errors.New("failed to fetch store stations"))
+)
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500StoreStationCreate = localization.NewResponseKey(http.StatusInternalServerError, data.StoreStationComponent, data.CreateOperation.ToString())
+	Response500StoreStationGet    = localization.NewResponseKey(http.StatusInternalServerError, data.StoreStationComponent, data.GetOperation.ToString())
+	Response500StoreStationUpdate = localization.NewResponseKey(http.StatusInternalServerError, data.StoreStationComponent, data.UpdateOperation.ToString())
+	Response500StoreStationDelete = localization.NewResponseKey(http.StatusInternalServerError, data.StoreStationComponent, data.DeleteOperation.ToString())
+
+	Response400StoreStation           = localization.NewResponseKey(http.StatusBadRequest, data.StoreStationComponent)
+	Response400StoreStationCategories = localization.NewResponseKey(http.StatusBadRequest, data.StoreStationComponent, "CATEGORIES")
+	Response404StoreStation           = localization.NewResponseKey(http.StatusNotFound, data.StoreStationComponent)
+	Response409StoreStation           = localization.NewResponseKey(http.StatusConflict, data.StoreStationComponent)
+	Response409StoreStationCategory   = localization.NewResponseKey(http.StatusConflict, data.StoreStationComponent, "CATEGORY_TAKEN")
+
+	Response200StoreStationUpdate = localization.NewResponseKey(http.StatusOK, data.StoreStationComponent, data.UpdateOperation.ToString())
+	Response200StoreStationDelete = localization.NewResponseKey(http.StatusOK, data.StoreStationComponent, data.DeleteOperation.ToString())
+)
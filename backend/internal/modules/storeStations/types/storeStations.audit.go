@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	CreateStoreStationAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.StoreStationComponent, &CreateStoreStationDTO{})
+
+	UpdateStoreStationAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.UpdateOperation, data.StoreStationComponent, &UpdateStoreStationDTO{})
+
+	DeleteStoreStationAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.DeleteOperation, data.StoreStationComponent, struct{}{})
+)
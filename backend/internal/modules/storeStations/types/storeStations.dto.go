@@ -0,0 +1,56 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type CreateStoreStationDTO struct {
+	Name              string                 `json:"name" binding:"required,max=255"`
+	MachineCategories []data.MachineCategory `json:"machineCategories" binding:"required,min=1"`
+}
+
+type UpdateStoreStationDTO struct {
+	Name              *string                `json:"name" binding:"omitempty,min=1,max=255"`
+	MachineCategories []data.MachineCategory `json:"machineCategories" binding:"omitempty,min=1"` // replaces the categories when set
+}
+
+type StoreStationDTO struct {
+	ID                uint                   `json:"id"`
+	Name              string                 `json:"name"`
+	StoreID           uint                   `json:"storeId"`
+	MachineCategories []data.MachineCategory `json:"machineCategories"`
+	CreatedAt         time.Time              `json:"createdAt"`
+}
+
+type StoreStationsFilter struct {
+	StoreID *uint   `form:"storeId" binding:"omitempty"`
+	Search  *string `form:"search"`
+	utils.BaseFilter
+}
+
+// StationThroughputFilter takes the local dates of the store, both ends are included
+type StationThroughputFilter struct {
+	StartDate time.Time `form:"startDate" binding:"required" time_format:"2006-01-02"`
+	EndDate   time.Time `form:"endDate" binding:"required" time_format:"2006-01-02"`
+	StoreID   uint      `form:"-"`
+}
+
+// StationThroughput is the row of the throughput report, a nil station stands for the suborders not routed to any station
+type StationThroughput struct {
+	StationID                 *uint
+	StationName               *string
+	CompletedSuborders        int
+	AveragePreparationSeconds float64
+	AverageCompletionSeconds  float64
+}
+
+type StationThroughputDTO struct {
+	StationID                 *uint   `json:"stationId"`
+	StationName               string  `json:"stationName"`
+	CompletedSuborders        int     `json:"completedSuborders"`
+	AveragePreparationSeconds float64 `json:"averagePreparationSeconds"` // from the start of the preparation
+	AverageCompletionSeconds  float64 `json:"averageCompletionSeconds"`  // from the creation of the order
+}
@@ -1,6 +1,7 @@
 package storeStocks
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -86,7 +87,13 @@ func (h *StoreStockHandler) AddStoreStock(c *gin.Context) {
 		return
 	}
 
-	id, err := h.service.AddStock(storeID, &dto)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := h.service.AddStock(storeID, employeeID, &dto)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStock)
 		return
@@ -120,7 +127,13 @@ func (h *StoreStockHandler) AddMultipleStoreStock(c *gin.Context) {
 		return
 	}
 
-	IDs, err := h.service.AddMultipleStock(storeID, &dto)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	IDs, err := h.service.AddMultipleStock(storeID, employeeID, &dto)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStock)
 		return
@@ -241,7 +254,13 @@ func (h *StoreStockHandler) UpdateStoreStockById(c *gin.Context) {
 		return
 	}
 
-	err = h.service.UpdateStockById(storeID, uint(stockId), &input)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	err = h.service.UpdateStockById(storeID, uint(stockId), employeeID, &input)
 	if err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500StoreStock)
 		return
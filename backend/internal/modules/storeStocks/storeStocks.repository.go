@@ -9,25 +9,28 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
 
 	ingredientTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/ingredients/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	stockMovementsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StoreStockRepository interface {
 	GetAvailableIngredientsToAdd(storeID uint, filter *ingredientTypes.IngredientFilter) ([]data.Ingredient, error)
-	AddStock(storeID uint, dto *types.AddStoreStockDTO) (uint, error)
+	AddStock(storeID uint, dto *types.AddStoreStockDTO, source data.StockMovementSource) (uint, error)
 	AddMultipleStocks(stocks []data.StoreStock) ([]uint, error)
-	AddOrSaveStock(storeID uint, dto *types.AddStoreStockDTO) (uint, error)
+	AddOrSaveStock(storeID uint, dto *types.AddStoreStockDTO, source data.StockMovementSource) (uint, error)
 	GetStockList(storeID uint, query *types.GetStockFilterQuery) ([]data.StoreStock, error)
 	GetStockListByIDs(storeID uint, IDs []uint) ([]data.StoreStock, error)
 	GetRawStockByID(storeID, stockID uint) (*data.StoreStock, error)
 	GetStockById(stockID uint, filter *contexts.StoreContextFilter) (*data.StoreStock, error)
 	GetAllStockList(storeID uint) ([]data.StoreStock, error)
-	SaveStock(storeStock *data.StoreStock) error
+	SaveStock(storeStock *data.StoreStock, source data.StockMovementSource) error
 	DeleteStockById(storeID, stockID uint) error
 	WithTransaction(txFunc func(txRepo StoreStockRepository) error) error
 	CloneWithTransaction(tx *gorm.DB) StoreStockRepository
@@ -38,11 +41,12 @@ type StoreStockRepository interface {
 }
 
 type storeStockRepository struct {
-	db *gorm.DB
+	db                *gorm.DB
+	stockMovementRepo stockMovements.StockMovementRepository
 }
 
-func NewStoreStockRepository(db *gorm.DB) StoreStockRepository {
-	return &storeStockRepository{db: db}
+func NewStoreStockRepository(db *gorm.DB, stockMovementRepo stockMovements.StockMovementRepository) StoreStockRepository {
+	return &storeStockRepository{db: db, stockMovementRepo: stockMovementRepo}
 }
 
 func (r *storeStockRepository) WithTransaction(txFunc func(txRepo StoreStockRepository) error) error {
@@ -67,7 +71,8 @@ func (r *storeStockRepository) WithTransaction(txFunc func(txRepo StoreStockRepo
 
 func (r *storeStockRepository) CloneWithTransaction(tx *gorm.DB) StoreStockRepository {
 	return &storeStockRepository{
-		db: tx,
+		db:                tx,
+		stockMovementRepo: r.stockMovementRepo.CloneWithTransaction(tx),
 	}
 }
 
@@ -108,7 +113,7 @@ func (r *storeStockRepository) GetAvailableIngredientsToAdd(storeID uint, filter
 	return ingredients, nil
 }
 
-func (r *storeStockRepository) AddStock(storeID uint, dto *types.AddStoreStockDTO) (uint, error) {
+func (r *storeStockRepository) AddStock(storeID uint, dto *types.AddStoreStockDTO, source data.StockMovementSource) (uint, error) {
 	var existingStock data.StoreStock
 	err := r.db.
 		Where("store_id = ? AND ingredient_id = ?", storeID, dto.IngredientID).
@@ -130,9 +135,16 @@ func (r *storeStockRepository) AddStock(storeID uint, dto *types.AddStoreStockDT
 	}
 
 	storeStock := types.AddToStock(*dto, storeID)
-	err = r.db.Create(&storeStock).Error
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&storeStock).Error; err != nil {
+			return fmt.Errorf("failed to create store stock: %w", err)
+		}
+
+		movement := stockMovementsTypes.NewStoreStockMovement(source, storeID, storeStock.IngredientID, 0, storeStock.Quantity)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to create store stock: %w", err)
+		return 0, err
 	}
 
 	return storeStock.ID, nil
@@ -152,38 +164,51 @@ func (r *storeStockRepository) AddMultipleStocks(stocks []data.StoreStock) ([]ui
 	return stockIDs, nil
 }
 
-func (r *storeStockRepository) AddOrSaveStock(storeID uint, dto *types.AddStoreStockDTO) (uint, error) {
-	var existingStock data.StoreStock
-	err := r.db.
-		Where("store_id = ? AND ingredient_id = ?", storeID, dto.IngredientID).
-		First(&existingStock).Error
-
-	if err == nil {
-		existingStock.Quantity += dto.Quantity
-		existingStock.LowStockThreshold = dto.LowStockThreshold
-		err = r.db.Save(&existingStock).Error
-		if err != nil {
-			return 0, fmt.Errorf("failed to update store stock: %w", err)
+func (r *storeStockRepository) AddOrSaveStock(storeID uint, dto *types.AddStoreStockDTO, source data.StockMovementSource) (uint, error) {
+	var stockID uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var existingStock data.StoreStock
+		err := tx.
+			Where("store_id = ? AND ingredient_id = ?", storeID, dto.IngredientID).
+			First(&existingStock).Error
+
+		if err == nil {
+			before := existingStock.Quantity
+			existingStock.Quantity += dto.Quantity
+			existingStock.LowStockThreshold = dto.LowStockThreshold
+			if err := tx.Save(&existingStock).Error; err != nil {
+				return fmt.Errorf("failed to update store stock: %w", err)
+			}
+			stockID = existingStock.ID
+
+			movement := stockMovementsTypes.NewStoreStockMovement(source, storeID, existingStock.IngredientID, before, existingStock.Quantity)
+			return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
 		}
-		return existingStock.ID, nil
-	}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		storeStock := data.StoreStock{
-			StoreID:           storeID,
-			IngredientID:      dto.IngredientID,
-			Quantity:          dto.Quantity,
-			LowStockThreshold: dto.LowStockThreshold,
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			storeStock := data.StoreStock{
+				StoreID:           storeID,
+				IngredientID:      dto.IngredientID,
+				Quantity:          dto.Quantity,
+				LowStockThreshold: dto.LowStockThreshold,
+			}
+
+			if err := tx.Create(&storeStock).Error; err != nil {
+				return fmt.Errorf("failed to create store stock: %w", err)
+			}
+			stockID = storeStock.ID
+
+			movement := stockMovementsTypes.NewStoreStockMovement(source, storeID, storeStock.IngredientID, 0, storeStock.Quantity)
+			return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
 		}
 
-		err = r.db.Create(&storeStock).Error
-		if err != nil {
-			return 0, fmt.Errorf("failed to create store stock: %w", err)
-		}
-		return storeStock.ID, nil
+		return fmt.Errorf("failed to add or update stock: %w", err)
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return 0, fmt.Errorf("failed to add or update stock: %w", err)
+	return stockID, nil
 }
 
 func (r *storeStockRepository) GetStockList(storeID uint, filter *types.GetStockFilterQuery) ([]data.StoreStock, error) {
@@ -319,22 +344,35 @@ func (r *storeStockRepository) GetStockById(stockId uint, filter *contexts.Store
 	return &StoreStock, nil
 }
 
-func (r *storeStockRepository) SaveStock(storeStock *data.StoreStock) error {
+// SaveStock saves the store stock and records the change of its quantity against the stored one
+func (r *storeStockRepository) SaveStock(storeStock *data.StoreStock, source data.StockMovementSource) error {
 	if storeStock == nil || storeStock.ID == 0 {
 		return fmt.Errorf("not enough data to save store stock")
 	}
 
-	updRes := r.db.Save(storeStock)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var before float64
+		err := tx.Model(&data.StoreStock{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", storeStock.ID).
+			Pluck("quantity", &before).Error
+		if err != nil {
+			return utils.WrapError("failed to fetch store stock", err)
+		}
 
-	if updRes.Error != nil {
-		return utils.WrapError("failed to update store stock", updRes.Error)
-	}
+		updRes := tx.Save(storeStock)
 
-	if updRes.RowsAffected == 0 {
-		return fmt.Errorf("update attempt had no changes for stockId=%d with storeId=%d", storeStock.ID, storeStock.StoreID)
-	}
+		if updRes.Error != nil {
+			return utils.WrapError("failed to update store stock", updRes.Error)
+		}
 
-	return nil
+		if updRes.RowsAffected == 0 {
+			return fmt.Errorf("update attempt had no changes for stockId=%d with storeId=%d", storeStock.ID, storeStock.StoreID)
+		}
+
+		movement := stockMovementsTypes.NewStoreStockMovement(source, storeStock.StoreID, storeStock.IngredientID, before, storeStock.Quantity)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
+	})
 }
 
 func (r *storeStockRepository) DeleteStockById(storeId, stockId uint) error {
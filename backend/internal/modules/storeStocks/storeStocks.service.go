@@ -21,12 +21,12 @@ import (
 
 type StoreStockService interface {
 	GetAvailableIngredientsToAdd(storeID uint, filter *ingredientTypes.IngredientFilter) ([]ingredientTypes.IngredientDTO, error)
-	AddStock(storeId uint, dto *types.AddStoreStockDTO) (uint, error)
-	AddMultipleStock(storeId uint, dto *types.AddMultipleStoreStockDTO) ([]uint, error)
+	AddStock(storeId, employeeID uint, dto *types.AddStoreStockDTO) (uint, error)
+	AddMultipleStock(storeId, employeeID uint, dto *types.AddMultipleStoreStockDTO) ([]uint, error)
 	GetStockList(storeId uint, query *types.GetStockFilterQuery) ([]types.StoreStockDTO, error)
 	GetStockListByIDs(storeId uint, stockIds []uint) ([]types.StoreStockDTO, error)
 	GetStockById(stockId uint, filter *contexts.StoreContextFilter) (*types.StoreStockDTO, error)
-	UpdateStockById(storeId, stockId uint, input *types.UpdateStoreStockDTO) error
+	UpdateStockById(storeId, stockId, employeeID uint, input *types.UpdateStoreStockDTO) error
 	DeleteStockById(storeId, stockId uint) error
 
 	CheckStockNotifications(storeID uint, stock data.StoreStock) error
@@ -48,14 +48,15 @@ func NewStoreStockService(repo StoreStockRepository, storeInventoryManagerRepo s
 	}
 }
 
-func (s *storeStockService) AddMultipleStock(storeId uint, dto *types.AddMultipleStoreStockDTO) ([]uint, error) {
+func (s *storeStockService) AddMultipleStock(storeId, employeeID uint, dto *types.AddMultipleStoreStockDTO) ([]uint, error) {
 	var IDs []uint
+	source := manualStockMovementSource(employeeID)
 
 	// Start a transaction
 	err := s.repo.WithTransaction(func(txRepo StoreStockRepository) error {
 		for _, stock := range dto.IngredientStocks {
 			// Add or update stock for each ingredient
-			id, err := txRepo.AddOrSaveStock(storeId, &stock)
+			id, err := txRepo.AddOrSaveStock(storeId, &stock, source)
 			if err != nil {
 				wrappedErr := utils.WrapError("error adding/updating stock element", err)
 				s.logger.Error(wrappedErr)
@@ -80,8 +81,8 @@ func (s *storeStockService) AddMultipleStock(storeId uint, dto *types.AddMultipl
 	return IDs, nil
 }
 
-func (s *storeStockService) AddStock(storeId uint, dto *types.AddStoreStockDTO) (uint, error) {
-	id, err := s.repo.AddStock(storeId, dto)
+func (s *storeStockService) AddStock(storeId, employeeID uint, dto *types.AddStoreStockDTO) (uint, error) {
+	id, err := s.repo.AddStock(storeId, dto, manualStockMovementSource(employeeID))
 	if err != nil {
 		wrappedErr := utils.WrapError("error adding new stock element", err)
 		s.logger.Error(wrappedErr)
@@ -141,7 +142,7 @@ func (s *storeStockService) GetStockById(stockId uint, filter *contexts.StoreCon
 	return &stockDto, nil
 }
 
-func (s *storeStockService) UpdateStockById(storeId, stockId uint, input *types.UpdateStoreStockDTO) error {
+func (s *storeStockService) UpdateStockById(storeId, stockId, employeeID uint, input *types.UpdateStoreStockDTO) error {
 	storeStock, err := s.repo.GetRawStockByID(storeId, stockId)
 	if err != nil {
 		return err
@@ -154,7 +155,7 @@ func (s *storeStockService) UpdateStockById(storeId, stockId uint, input *types.
 		return wrappedErr
 	}
 
-	err = s.repo.SaveStock(storeStock)
+	err = s.repo.SaveStock(storeStock, manualStockMovementSource(employeeID))
 	if err != nil {
 		wrappedErr := utils.WrapError("error updating stock", err)
 		s.logger.Error(wrappedErr)
@@ -301,3 +302,10 @@ func (s *storeStockService) checkStockAndNotify(storeId, stockId uint) error {
 
 	return nil
 }
+
+func manualStockMovementSource(employeeID uint) data.StockMovementSource {
+	return data.StockMovementSource{
+		Type:       data.StockMovementSourceManual,
+		EmployeeID: &employeeID,
+	}
+}
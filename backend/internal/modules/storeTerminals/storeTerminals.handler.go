@@ -0,0 +1,132 @@
+package storeTerminals
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type StoreTerminalHandler struct {
+	service      StoreTerminalService
+	auditService audit.AuditService
+}
+
+func NewStoreTerminalHandler(service StoreTerminalService, auditService audit.AuditService) *StoreTerminalHandler {
+	return &StoreTerminalHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// CreateStoreTerminal registers the terminal and stores its device token in a cookie of the browser it was registered from
+func (h *StoreTerminalHandler) CreateStoreTerminal(c *gin.Context) {
+	var dto types.CreateStoreTerminalDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	terminal, err := h.service.CreateTerminal(storeID, &dto)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreTerminalCreate)
+		return
+	}
+
+	action := types.CreateStoreTerminalAuditFactory(
+		&data.BaseDetails{
+			ID:   terminal.ID,
+			Name: terminal.Name,
+		},
+		&dto, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SetCookie(c, types.TERMINAL_TOKEN_COOKIE_KEY, terminal.Token, types.TerminalTokenCookieTTL)
+	utils.SendResponseWithStatus(c, terminal, http.StatusCreated)
+}
+
+func (h *StoreTerminalHandler) GetStoreTerminals(c *gin.Context) {
+	var filter types.StoreTerminalsFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.StoreTerminal{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+	filter.StoreID = &storeID
+
+	terminals, err := h.service.GetTerminals(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreTerminalGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, terminals, filter.Pagination)
+}
+
+func (h *StoreTerminalHandler) DeleteStoreTerminal(c *gin.Context) {
+	terminalID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400StoreTerminal)
+		return
+	}
+
+	storeID, errH := contexts.GetStoreId(c)
+	if errH != nil {
+		utils.SendErrorWithStatus(c, errH.Error(), errH.Status())
+		return
+	}
+
+	terminal, err := h.service.GetTerminalByID(terminalID, storeID)
+	if err != nil {
+		if errors.Is(err, types.ErrStoreTerminalNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreTerminal)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreTerminalGet)
+		return
+	}
+
+	if err := h.service.DeleteTerminal(terminalID, storeID); err != nil {
+		if errors.Is(err, types.ErrStoreTerminalNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreTerminal)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreTerminalDelete)
+		return
+	}
+
+	action := types.DeleteStoreTerminalAuditFactory(
+		&data.BaseDetails{
+			ID:   terminal.ID,
+			Name: terminal.Name,
+		},
+		struct{}{}, storeID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200StoreTerminalDelete)
+}
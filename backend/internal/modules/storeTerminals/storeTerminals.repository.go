@@ -0,0 +1,103 @@
+package storeTerminals
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type StoreTerminalRepository interface {
+	CreateTerminal(terminal *data.StoreTerminal) error
+	GetTerminalByID(id, storeID uint) (*data.StoreTerminal, error)
+	GetTerminalByTokenHash(tokenHash string) (*data.StoreTerminal, error)
+	GetTerminals(filter *types.StoreTerminalsFilter) ([]data.StoreTerminal, error)
+	UpdateLastUsedAt(id uint, lastUsedAt time.Time) error
+	DeleteTerminal(id, storeID uint) error
+}
+
+type storeTerminalRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreTerminalRepository(db *gorm.DB) StoreTerminalRepository {
+	return &storeTerminalRepository{db: db}
+}
+
+func (r *storeTerminalRepository) CreateTerminal(terminal *data.StoreTerminal) error {
+	return r.db.Create(terminal).Error
+}
+
+func (r *storeTerminalRepository) GetTerminalByID(id, storeID uint) (*data.StoreTerminal, error) {
+	var terminal data.StoreTerminal
+	err := r.db.Where("id = ? AND store_id = ?", id, storeID).First(&terminal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreTerminalNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store terminal with ID %d: %w", id, err)
+	}
+	return &terminal, nil
+}
+
+func (r *storeTerminalRepository) GetTerminalByTokenHash(tokenHash string) (*data.StoreTerminal, error) {
+	var terminal data.StoreTerminal
+	err := r.db.Preload("Store").Where("token_hash = ?", tokenHash).First(&terminal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrStoreTerminalNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch store terminal by token: %w", err)
+	}
+	return &terminal, nil
+}
+
+func (r *storeTerminalRepository) GetTerminals(filter *types.StoreTerminalsFilter) ([]data.StoreTerminal, error) {
+	var terminals []data.StoreTerminal
+
+	query := r.db.Model(&data.StoreTerminal{})
+
+	if filter.StoreID != nil {
+		query = query.Where("store_id = ?", *filter.StoreID)
+	}
+
+	if filter.Search != nil && *filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+*filter.Search+"%")
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.StoreTerminal{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&terminals).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch store terminals: %w", err)
+	}
+
+	return terminals, nil
+}
+
+func (r *storeTerminalRepository) UpdateLastUsedAt(id uint, lastUsedAt time.Time) error {
+	return r.db.Model(&data.StoreTerminal{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).Error
+}
+
+// DeleteTerminal revokes the device token of the terminal and closes the session of its operator
+func (r *storeTerminalRepository) DeleteTerminal(id, storeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND store_id = ?", id, storeID).Delete(&data.StoreTerminal{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return types.ErrStoreTerminalNotFound
+		}
+
+		return tx.Unscoped().Where("store_terminal_id = ?", id).Delete(&data.EmployeeToken{}).Error
+	})
+}
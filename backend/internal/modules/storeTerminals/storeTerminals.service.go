@@ -0,0 +1,91 @@
+package storeTerminals
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals/types"
+	"go.uber.org/zap"
+)
+
+type StoreTerminalService interface {
+	CreateTerminal(storeID uint, dto *types.CreateStoreTerminalDTO) (*types.CreatedStoreTerminalDTO, error)
+	GetTerminalByID(id, storeID uint) (*types.StoreTerminalDTO, error)
+	GetTerminals(filter *types.StoreTerminalsFilter) ([]types.StoreTerminalDTO, error)
+	DeleteTerminal(id, storeID uint) error
+}
+
+type storeTerminalService struct {
+	repo   StoreTerminalRepository
+	logger *zap.SugaredLogger
+}
+
+func NewStoreTerminalService(repo StoreTerminalRepository, logger *zap.SugaredLogger) StoreTerminalService {
+	return &storeTerminalService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateTerminal registers a shared device of the store, the returned token is its only credential
+func (s *storeTerminalService) CreateTerminal(storeID uint, dto *types.CreateStoreTerminalDTO) (*types.CreatedStoreTerminalDTO, error) {
+	token, tokenHash, err := types.GenerateTerminalToken()
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStoreTerminal, err))
+		return nil, types.ErrFailedToCreateStoreTerminal
+	}
+
+	terminal := &data.StoreTerminal{
+		Name:      dto.Name,
+		TokenHash: tokenHash,
+		StoreID:   storeID,
+	}
+	if err := s.repo.CreateTerminal(terminal); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateStoreTerminal, err))
+		return nil, types.ErrFailedToCreateStoreTerminal
+	}
+
+	return &types.CreatedStoreTerminalDTO{
+		StoreTerminalDTO: types.ConvertToStoreTerminalDTO(terminal),
+		Token:            token,
+	}, nil
+}
+
+func (s *storeTerminalService) GetTerminalByID(id, storeID uint) (*types.StoreTerminalDTO, error) {
+	terminal, err := s.repo.GetTerminalByID(id, storeID)
+	if err != nil {
+		if !errors.Is(err, types.ErrStoreTerminalNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	response := types.ConvertToStoreTerminalDTO(terminal)
+	return &response, nil
+}
+
+func (s *storeTerminalService) GetTerminals(filter *types.StoreTerminalsFilter) ([]types.StoreTerminalDTO, error) {
+	terminals, err := s.repo.GetTerminals(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchStoreTerminals, err))
+		return nil, types.ErrFailedToFetchStoreTerminals
+	}
+
+	responses := make([]types.StoreTerminalDTO, len(terminals))
+	for i := range terminals {
+		responses[i] = types.ConvertToStoreTerminalDTO(&terminals[i])
+	}
+	return responses, nil
+}
+
+func (s *storeTerminalService) DeleteTerminal(id, storeID uint) error {
+	if err := s.repo.DeleteTerminal(id, storeID); err != nil {
+		if errors.Is(err, types.ErrStoreTerminalNotFound) {
+			return err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToDeleteStoreTerminal, err))
+		return types.ErrFailedToDeleteStoreTerminal
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+const terminalTokenBytes = 32
+
+func ConvertToStoreTerminalDTO(terminal *data.StoreTerminal) StoreTerminalDTO {
+	return StoreTerminalDTO{
+		ID:         terminal.ID,
+		Name:       terminal.Name,
+		StoreID:    terminal.StoreID,
+		LastUsedAt: terminal.LastUsedAt,
+		CreatedAt:  terminal.CreatedAt,
+	}
+}
+
+// GenerateTerminalToken returns the device token of a terminal and the hash to store
+func GenerateTerminalToken() (token, hash string, err error) {
+	buf := make([]byte, terminalTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashTerminalToken(token), nil
+}
+
+func HashTerminalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTerminalToken(t *testing.T) {
+	token, hash, err := GenerateTerminalToken()
+	assert.NoError(t, err)
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, HashTerminalToken(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	otherToken, otherHash, err := GenerateTerminalToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, otherToken)
+	assert.NotEqual(t, hash, otherHash)
+}
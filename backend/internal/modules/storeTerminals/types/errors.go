@@ -0,0 +1,14 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrStoreTerminalNotFound       = moduleErrors.NewModuleError(errors.New("store terminal not found"))
+	ErrFailedToCreateStoreTerminal = moduleErrors.NewModuleError(errors.New("failed to create store terminal"))
+	ErrFailedToDeleteStoreTerminal = moduleErrors.NewModuleError(errors.New("failed to delete store terminal"))
+	ErrFailedToFetchStoreTerminals = moduleErrors.NewModuleError(errors.New("failed to fetch store terminals"))
+)
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500StoreTerminalCreate = localization.NewResponseKey(http.StatusInternalServerError, data.StoreTerminalComponent, data.CreateOperation.ToString())
+	Response500StoreTerminalGet    = localization.NewResponseKey(http.StatusInternalServerError, data.StoreTerminalComponent, data.GetOperation.ToString())
+	Response500StoreTerminalDelete = localization.NewResponseKey(http.StatusInternalServerError, data.StoreTerminalComponent, data.DeleteOperation.ToString())
+
+	Response400StoreTerminal = localization.NewResponseKey(http.StatusBadRequest, data.StoreTerminalComponent)
+	Response404StoreTerminal = localization.NewResponseKey(http.StatusNotFound, data.StoreTerminalComponent)
+
+	Response200StoreTerminalDelete = localization.NewResponseKey(http.StatusOK, data.StoreTerminalComponent, data.DeleteOperation.ToString())
+)
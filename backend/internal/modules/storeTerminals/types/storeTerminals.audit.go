@@ -0,0 +1,14 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	CreateStoreTerminalAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.CreateOperation, data.StoreTerminalComponent, &CreateStoreTerminalDTO{})
+
+	DeleteStoreTerminalAuditFactory = shared.NewAuditStoreActionExtendedFactory(
+		data.DeleteOperation, data.StoreTerminalComponent, struct{}{})
+)
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+const (
+	TERMINAL_TOKEN_HEADER     = "X-Terminal-Token"
+	TERMINAL_TOKEN_COOKIE_KEY = "ZEEP_STORE_TERMINAL"
+
+	TerminalTokenCookieTTL = 365 * 24 * time.Hour
+)
+
+type CreateStoreTerminalDTO struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+type StoreTerminalDTO struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	StoreID    uint       `json:"storeId"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreatedStoreTerminalDTO carries the device token of the new terminal, it is not stored and can not be shown again
+type CreatedStoreTerminalDTO struct {
+	StoreTerminalDTO
+	Token string `json:"token"`
+}
+
+type StoreTerminalsFilter struct {
+	StoreID *uint   `form:"storeId" binding:"omitempty"`
+	Search  *string `form:"search"`
+	utils.BaseFilter
+}
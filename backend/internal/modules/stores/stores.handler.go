@@ -56,6 +56,10 @@ func (h *StoreHandler) CreateStore(c *gin.Context) {
 
 	id, err := h.service.CreateStore(&storeDTO)
 	if err != nil {
+		if errors.Is(err, types.ErrInvalidStoreHours) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400StoreHours)
+			return
+		}
 		localization.SendLocalizedResponseWithKey(c, types.Response500StoreCreate)
 		return
 	}
@@ -142,6 +146,10 @@ func (h *StoreHandler) UpdateStore(c *gin.Context) {
 
 	err = h.service.UpdateStore(uint(storeID), &dto)
 	if err != nil {
+		if errors.Is(err, types.ErrInvalidStoreHours) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400StoreHours)
+			return
+		}
 		localization.SendLocalizedResponseWithKey(c, types.Response500StoreUpdate)
 		return
 	}
@@ -195,3 +203,74 @@ func (h *StoreHandler) DeleteStore(c *gin.Context) {
 
 	localization.SendLocalizedResponseWithKey(c, types.Response200StoreDelete)
 }
+
+func (h *StoreHandler) GetHoursExceptions(c *gin.Context) {
+	storeID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	exceptions, err := h.service.GetHoursExceptions(storeID)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreHoursExceptionGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, exceptions)
+}
+
+func (h *StoreHandler) CreateHoursException(c *gin.Context) {
+	storeID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	var dto types.CreateStoreHoursExceptionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	if _, err := h.service.CreateHoursException(storeID, &dto); err != nil {
+		switch {
+		case errors.Is(err, types.ErrInvalidStoreHours):
+			localization.SendLocalizedResponseWithKey(c, types.Response400StoreHours)
+		case errors.Is(err, types.ErrStoreNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404Store)
+		case errors.Is(err, types.ErrHoursExceptionExists):
+			localization.SendLocalizedResponseWithKey(c, types.Response409StoreHoursException)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500StoreHoursExceptionSave)
+		}
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response201StoreHoursException)
+}
+
+func (h *StoreHandler) DeleteHoursException(c *gin.Context) {
+	storeID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	exceptionID, err := utils.ParseParam(c, "exceptionId")
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteHoursException(storeID, exceptionID); err != nil {
+		if errors.Is(err, types.ErrHoursExceptionNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404StoreHoursException)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500StoreHoursExceptionSave)
+		return
+	}
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200StoreHoursExceptionDel)
+}
@@ -31,6 +31,10 @@ type StoreRepository interface {
 	CreateFacilityAddress(facilityAddress *data.FacilityAddress) (*data.FacilityAddress, error)
 	GetFacilityAddressByAddress(address string) (*data.FacilityAddress, error)
 	CloneWithTransaction(tx *gorm.DB) StoreRepository
+
+	GetHoursExceptions(storeID uint) ([]data.StoreHoursException, error)
+	CreateHoursException(exception *data.StoreHoursException) (uint, error)
+	DeleteHoursException(storeID, exceptionID uint) error
 }
 
 type storeRepository struct {
@@ -55,7 +59,8 @@ func (r *storeRepository) GetAllStores(filter *types.StoreFilter) ([]data.Store,
 		Preload("Warehouse").
 		Preload("Warehouse.Region").
 		Preload("Warehouse.FacilityAddress").
-		Preload("FacilityAddress")
+		Preload("FacilityAddress").
+		Scopes(preloadSchedule)
 
 	if filter == nil {
 		return nil, errors.New("filter is nil")
@@ -93,7 +98,8 @@ func (r *storeRepository) GetAllStoresForNotifications() ([]data.Store, error) {
 		Preload("Franchisee").
 		Preload("Warehouse").
 		Preload("Warehouse.Region").
-		Preload("Warehouse.FacilityAddress")
+		Preload("Warehouse.FacilityAddress").
+		Scopes(preloadSchedule)
 
 	if err := query.Find(&stores).Error; err != nil {
 		return nil, err
@@ -124,6 +130,7 @@ func (r *storeRepository) GetStoreWithDetailsByID(storeID uint) (*data.Store, er
 		Preload("Warehouse").
 		Preload("Warehouse.Region").
 		Preload("Warehouse.FacilityAddress").
+		Scopes(preloadSchedule).
 		Where("id = ?", storeID).First(&store).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, types.ErrStoreNotFound
@@ -140,7 +147,8 @@ func (r *storeRepository) GetStores(filter *types.StoreFilter) ([]data.Store, er
 		Preload("Franchisee").
 		Preload("Warehouse").
 		Preload("Warehouse.Region").
-		Preload("Warehouse.FacilityAddress")
+		Preload("Warehouse.FacilityAddress").
+		Scopes(preloadSchedule)
 
 	if filter == nil {
 		return nil, errors.New("filter is nil")
@@ -184,6 +192,21 @@ func (r *storeRepository) UpdateStore(storeID uint, updateModels *types.StoreUpd
 			return err
 		}
 
+		if updateModels.WorkingHours != nil {
+			if err := tx.Unscoped().Where("store_id = ?", storeID).Delete(&data.StoreWorkingHours{}).Error; err != nil {
+				return err
+			}
+
+			for i := range updateModels.WorkingHours {
+				updateModels.WorkingHours[i].StoreID = storeID
+			}
+			if len(updateModels.WorkingHours) > 0 {
+				if err := tx.Create(&updateModels.WorkingHours).Error; err != nil {
+					return err
+				}
+			}
+		}
+
 		if updateModels.FacilityAddress != nil {
 			if err := tx.Save(&updateModels.FacilityAddress).Error; err != nil {
 				return err
@@ -241,3 +264,47 @@ func (r *storeRepository) UpdateStoreSyncTime(storeID uint) (time.Time, error) {
 
 	return currentTime, err
 }
+
+// preloadSchedule loads the weekly hours and the exceptions starting from yesterday, which may still last after the midnight
+func preloadSchedule(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("WorkingHours").
+		Preload("HoursExceptions", "date >= CURRENT_DATE - 1", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		})
+}
+
+func (r *storeRepository) GetHoursExceptions(storeID uint) ([]data.StoreHoursException, error) {
+	var exceptions []data.StoreHoursException
+	err := r.db.
+		Where("store_id = ? AND date >= CURRENT_DATE - 1", storeID).
+		Order("date ASC").
+		Find(&exceptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch hours exceptions of store %d: %w", storeID, err)
+	}
+	return exceptions, nil
+}
+
+func (r *storeRepository) CreateHoursException(exception *data.StoreHoursException) (uint, error) {
+	if err := r.db.Create(exception).Error; err != nil {
+		if strings.Contains(err.Error(), "23505") {
+			return 0, types.ErrHoursExceptionExists
+		}
+		return 0, fmt.Errorf("failed to create hours exception of store %d: %w", exception.StoreID, err)
+	}
+	return exception.ID, nil
+}
+
+func (r *storeRepository) DeleteHoursException(storeID, exceptionID uint) error {
+	result := r.db.Unscoped().
+		Where("id = ? AND store_id = ?", exceptionID, storeID).
+		Delete(&data.StoreHoursException{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete hours exception %d: %w", exceptionID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return types.ErrHoursExceptionNotFound
+	}
+	return nil
+}
@@ -18,6 +18,10 @@ type StoreService interface {
 	GetStores(filter *types.StoreFilter) ([]types.StoreDTO, error)
 	UpdateStore(storeId uint, storeDTO *types.UpdateStoreDTO) error
 	DeleteStore(storeID uint, hardDelete bool) error
+
+	GetHoursExceptions(storeID uint) ([]types.StoreHoursExceptionDTO, error)
+	CreateHoursException(storeID uint, dto *types.CreateStoreHoursExceptionDTO) (uint, error)
+	DeleteHoursException(storeID, exceptionID uint) error
 }
 
 type storeService struct {
@@ -67,9 +71,14 @@ func (s *storeService) GetAllStores(filter *types.StoreFilter) ([]types.StoreDTO
 		return nil, err
 	}
 
-	storeDTOs := make([]types.StoreDTO, len(stores))
-	for i, store := range stores {
-		storeDTOs[i] = *types.MapToStoreDTO(&store)
+	// the list is not paginated, so the stores are filtered by the hours here instead of the query
+	storeDTOs := make([]types.StoreDTO, 0, len(stores))
+	for _, store := range stores {
+		storeDTO := types.MapToStoreDTO(&store)
+		if filter.IsOpen != nil && storeDTO.IsOpen != *filter.IsOpen {
+			continue
+		}
+		storeDTOs = append(storeDTOs, *storeDTO)
 	}
 
 	return storeDTOs, nil
@@ -156,3 +165,49 @@ func (s *storeService) DeleteStore(storeID uint, hardDelete bool) error {
 
 	return nil
 }
+
+func (s *storeService) GetHoursExceptions(storeID uint) ([]types.StoreHoursExceptionDTO, error) {
+	exceptions, err := s.repo.GetHoursExceptions(storeID)
+	if err != nil {
+		s.logger.Error(err)
+		return nil, err
+	}
+
+	return types.ConvertToStoreHoursExceptionDTOs(exceptions), nil
+}
+
+func (s *storeService) CreateHoursException(storeID uint, dto *types.CreateStoreHoursExceptionDTO) (uint, error) {
+	exception, err := types.ValidateHoursException(storeID, dto)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := s.repo.GetStoreByID(storeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, types.ErrStoreNotFound
+		}
+		wrappedErr := fmt.Errorf("failed to get store: %w", err)
+		s.logger.Error(wrappedErr)
+		return 0, wrappedErr
+	}
+
+	id, err := s.repo.CreateHoursException(exception)
+	if err != nil {
+		if !errors.Is(err, types.ErrHoursExceptionExists) {
+			s.logger.Error(err)
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *storeService) DeleteHoursException(storeID, exceptionID uint) error {
+	if err := s.repo.DeleteHoursException(storeID, exceptionID); err != nil {
+		if !errors.Is(err, types.ErrHoursExceptionNotFound) {
+			s.logger.Error(err)
+		}
+		return err
+	}
+	return nil
+}
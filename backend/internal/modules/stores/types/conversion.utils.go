@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	facilityAddressesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/facilityAddresses/types"
 	franchiseesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees/types"
@@ -18,14 +20,49 @@ func MapToStoreDTO(store *data.Store) *StoreDTO {
 	warehouse := warehouseTypes.ToWarehouseDTO(store.Warehouse)
 
 	return &StoreDTO{
-		ID:              store.ID,
-		Name:            store.Name,
-		Franchisee:      franchisee,
-		IsActive:        store.IsActive,
-		Warehouse:       *warehouse,
-		ContactPhone:    store.ContactPhone,
-		ContactEmail:    store.ContactEmail,
-		StoreHours:      store.StoreHours,
-		FacilityAddress: facilityAddressDTO,
+		ID:                 store.ID,
+		Name:               store.Name,
+		Franchisee:         franchisee,
+		IsActive:           store.IsActive,
+		Warehouse:          *warehouse,
+		ContactPhone:       store.ContactPhone,
+		ContactEmail:       store.ContactEmail,
+		Timezone:           store.Timezone,
+		WorkingHours:       ConvertToStoreWorkingHoursDTOs(store.WorkingHours),
+		HoursExceptions:    ConvertToStoreHoursExceptionDTOs(store.HoursExceptions),
+		IsOpen:             NewStoreSchedule(store).IsOpen(time.Now()),
+		PickupSlotCapacity: store.PickupSlotCapacity,
+		FacilityAddress:    facilityAddressDTO,
+	}
+}
+
+func ConvertToStoreWorkingHoursDTOs(workingHours []data.StoreWorkingHours) []StoreWorkingHoursDTO {
+	dtos := make([]StoreWorkingHoursDTO, len(workingHours))
+	for i, hours := range workingHours {
+		dtos[i] = StoreWorkingHoursDTO{
+			Day:     hours.Day.ToString(),
+			OpenAt:  hours.OpenAt,
+			CloseAt: hours.CloseAt,
+		}
+	}
+	return dtos
+}
+
+func ConvertToStoreHoursExceptionDTO(exception *data.StoreHoursException) StoreHoursExceptionDTO {
+	return StoreHoursExceptionDTO{
+		ID:       exception.ID,
+		Date:     exception.Date.Format(ExceptionDateLayout),
+		IsClosed: exception.IsClosed,
+		OpenAt:   exception.OpenAt,
+		CloseAt:  exception.CloseAt,
+		Reason:   exception.Reason,
+	}
+}
+
+func ConvertToStoreHoursExceptionDTOs(exceptions []data.StoreHoursException) []StoreHoursExceptionDTO {
+	dtos := make([]StoreHoursExceptionDTO, len(exceptions))
+	for i := range exceptions {
+		dtos[i] = ConvertToStoreHoursExceptionDTO(&exceptions[i])
 	}
+	return dtos
 }
@@ -13,4 +13,8 @@ var (
 	ErrFailedUpdateStore = moduleErrors.NewModuleError(errors.New("failed to update store"))
 	ErrFailedDeleteStore = moduleErrors.NewModuleError(errors.New("failed to delete store"))
 	ErrStoreNotFound     = moduleErrors.NewModuleError(errors.New("store not found"))
+
+	ErrInvalidStoreHours      = moduleErrors.NewModuleError(errors.New("invalid store hours or timezone"))
+	ErrHoursExceptionExists   = moduleErrors.NewModuleError(errors.New("store hours exception already exists for the date"))
+	ErrHoursExceptionNotFound = moduleErrors.NewModuleError(errors.New("store hours exception not found"))
 )
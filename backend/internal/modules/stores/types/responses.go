@@ -20,4 +20,12 @@ var (
 	// 200 Success responses
 	Response200StoreUpdate = localization.NewResponseKey(200, data.StoreComponent, data.UpdateOperation.ToString())
 	Response200StoreDelete = localization.NewResponseKey(200, data.StoreComponent, data.DeleteOperation.ToString())
+
+	Response400StoreHours              = localization.NewResponseKey(400, data.StoreComponent, "HOURS")
+	Response201StoreHoursException     = localization.NewResponseKey(201, data.StoreComponent, "HOURS_EXCEPTION")
+	Response200StoreHoursExceptionDel  = localization.NewResponseKey(200, data.StoreComponent, "HOURS_EXCEPTION", data.DeleteOperation.ToString())
+	Response404StoreHoursException     = localization.NewResponseKey(404, data.StoreComponent, "HOURS_EXCEPTION")
+	Response409StoreHoursException     = localization.NewResponseKey(409, data.StoreComponent, "HOURS_EXCEPTION")
+	Response500StoreHoursExceptionGet  = localization.NewResponseKey(500, data.StoreComponent, "HOURS_EXCEPTION", data.GetOperation.ToString())
+	Response500StoreHoursExceptionSave = localization.NewResponseKey(500, data.StoreComponent, "HOURS_EXCEPTION", data.CreateOperation.ToString())
 )
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+const (
+	ExceptionDateLayout  = time.DateOnly
+	DefaultStoreTimezone = "UTC"
+)
+
+var weekdays = map[time.Weekday]data.Weekday{
+	time.Monday:    data.Monday,
+	time.Tuesday:   data.Tuesday,
+	time.Wednesday: data.Wednesday,
+	time.Thursday:  data.Thursday,
+	time.Friday:    data.Friday,
+	time.Saturday:  data.Saturday,
+	time.Sunday:    data.Sunday,
+}
+
+// StoreSchedule tells whether a store is open using its weekly hours and the exception dates in the store timezone
+type StoreSchedule struct {
+	Location   *time.Location
+	weekly     map[data.Weekday]*utils.StoreHours
+	exceptions map[string]*utils.StoreHours // nil hours for a closed date
+}
+
+// StoreLocation loads the store timezone, the unknown ones fall back to UTC
+func StoreLocation(timezone string) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+	return location
+}
+
+// IsLocalHour tells whether the store local time is within the hour, the hourly jobs use it to run once a day for every store
+func IsLocalHour(timezone string, now time.Time, hour int) bool {
+	return now.In(StoreLocation(timezone)).Hour() == hour
+}
+
+// StartOfLocalDay returns the midnight of the date in the store timezone
+func StartOfLocalDay(date time.Time, location *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
+
+// NewStoreSchedule expects the working hours and the exceptions preloaded, the malformed ones are ignored
+func NewStoreSchedule(store *data.Store) *StoreSchedule {
+	schedule := &StoreSchedule{
+		Location:   StoreLocation(store.Timezone),
+		weekly:     make(map[data.Weekday]*utils.StoreHours, len(store.WorkingHours)),
+		exceptions: make(map[string]*utils.StoreHours, len(store.HoursExceptions)),
+	}
+
+	for _, workingHours := range store.WorkingHours {
+		if hours, err := utils.NewStoreHours(workingHours.OpenAt, workingHours.CloseAt); err == nil {
+			schedule.weekly[workingHours.Day] = hours
+		}
+	}
+
+	for _, exception := range store.HoursExceptions {
+		date := exception.Date.Format(ExceptionDateLayout)
+		if exception.IsClosed || exception.OpenAt == nil || exception.CloseAt == nil {
+			schedule.exceptions[date] = nil
+			continue
+		}
+		if hours, err := utils.NewStoreHours(*exception.OpenAt, *exception.CloseAt); err == nil {
+			schedule.exceptions[date] = hours
+		}
+	}
+
+	return schedule
+}
+
+// IsSet is false for the stores without the weekly hours, they are treated as always open
+func (s *StoreSchedule) IsSet() bool {
+	return len(s.weekly) > 0
+}
+
+// HoursOn returns the hours of the date in the store timezone, nil on a day off
+func (s *StoreSchedule) HoursOn(day time.Time) *utils.StoreHours {
+	day = day.In(s.Location)
+	if hours, ok := s.exceptions[day.Format(ExceptionDateLayout)]; ok {
+		return hours
+	}
+	return s.weekly[weekdays[day.Weekday()]]
+}
+
+// IsOpen also checks the hours of the previous day, which may last after the midnight
+func (s *StoreSchedule) IsOpen(t time.Time) bool {
+	if !s.IsSet() {
+		return true
+	}
+
+	local := t.In(s.Location)
+	for _, day := range []time.Time{local, local.AddDate(0, 0, -1)} {
+		hours := s.HoursOn(day)
+		if hours == nil {
+			continue
+		}
+
+		openAt, closeAt := hours.Window(day)
+		if !local.Before(openAt) && local.Before(closeAt) {
+			return true
+		}
+	}
+	return false
+}
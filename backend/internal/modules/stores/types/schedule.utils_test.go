@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreSchedule(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+
+	store := &data.Store{
+		Timezone: "Asia/Tashkent",
+		WorkingHours: []data.StoreWorkingHours{
+			{Day: data.Sunday, OpenAt: "09:00:00.000000", CloseAt: "18:00:00.000000"},
+			{Day: data.Monday, OpenAt: "09:00", CloseAt: "18:00"},
+		},
+		HoursExceptions: []data.StoreHoursException{
+			{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), OpenAt: strPtr("12:00"), CloseAt: strPtr("14:00")},
+		},
+	}
+	schedule := NewStoreSchedule(store)
+	location := schedule.Location
+
+	t.Run("Weekly hours should be checked in the store timezone", func(t *testing.T) {
+		assert.True(t, schedule.IsOpen(time.Date(2026, 10, 18, 9, 30, 0, 0, location)))
+		assert.False(t, schedule.IsOpen(time.Date(2026, 10, 18, 8, 59, 0, 0, location)))
+		assert.False(t, schedule.IsOpen(time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("Exception should override the weekly hours", func(t *testing.T) {
+		assert.False(t, schedule.IsOpen(time.Date(2026, 10, 19, 10, 0, 0, 0, location)))
+		assert.True(t, schedule.IsOpen(time.Date(2026, 10, 19, 13, 0, 0, 0, location)))
+	})
+
+	t.Run("Day without hours should be closed", func(t *testing.T) {
+		assert.False(t, schedule.IsOpen(time.Date(2026, 10, 20, 12, 0, 0, 0, location)))
+	})
+
+	t.Run("Store without the schedule should be always open", func(t *testing.T) {
+		assert.True(t, NewStoreSchedule(&data.Store{}).IsOpen(time.Now()))
+	})
+
+	t.Run("Working hours should be validated", func(t *testing.T) {
+		_, err := ValidateWorkingHours([]StoreWorkingHoursDTO{{Day: "MONDAY", OpenAt: "20:00", CloseAt: "02:00"}})
+		assert.NoError(t, err)
+
+		_, err = ValidateWorkingHours([]StoreWorkingHoursDTO{{Day: "MONDAY", OpenAt: "25:00", CloseAt: "02:00"}})
+		assert.ErrorIs(t, err, ErrInvalidStoreHours)
+
+		_, err = ValidateWorkingHours([]StoreWorkingHoursDTO{
+			{Day: "MONDAY", OpenAt: "08:00", CloseAt: "18:00"},
+			{Day: "MONDAY", OpenAt: "09:00", CloseAt: "19:00"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidStoreHours)
+	})
+}
+
+func TestStoreLocalTime(t *testing.T) {
+	now := time.Date(2026, 10, 18, 19, 10, 0, 0, time.UTC)
+
+	t.Run("Local hour should follow the store timezone", func(t *testing.T) {
+		assert.True(t, IsLocalHour("Asia/Tashkent", now, 0))
+		assert.True(t, IsLocalHour("", now, 19))
+		assert.False(t, IsLocalHour("America/New_York", now, 0))
+	})
+
+	t.Run("Local day should start at the store midnight", func(t *testing.T) {
+		location := StoreLocation("Asia/Tashkent")
+		startOfDay := StartOfLocalDay(now.In(location), location)
+
+		assert.Equal(t, "2026-10-19 00:00:00", startOfDay.Format(time.DateTime))
+		assert.Equal(t, time.Date(2026, 10, 18, 19, 0, 0, 0, time.UTC), startOfDay.UTC())
+	})
+
+	t.Run("Unknown timezone should fall back to UTC", func(t *testing.T) {
+		assert.Equal(t, time.UTC, StoreLocation("Mars/Olympus"))
+	})
+}
@@ -8,37 +8,68 @@ import (
 )
 
 type CreateStoreDTO struct {
-	Name            string                                                  `json:"name" binding:"required"`
-	FranchiseeID    *uint                                                   `json:"franchiseeId"`
-	WarehouseID     uint                                                    `json:"warehouseId" binding:"required"`
-	FacilityAddress facilityAddressesTypes.CreateOrUpdateFacilityAddressDTO `json:"facilityAddress" binding:"required"`
-	IsActive        bool                                                    `json:"isActive" binding:"required"`
-	ContactPhone    string                                                  `json:"contactPhone" binding:"required"`
-	ContactEmail    string                                                  `json:"contactEmail" binding:"required"`
-	StoreHours      string                                                  `json:"storeHours" binding:"required"`
+	Name               string                                                  `json:"name" binding:"required"`
+	FranchiseeID       *uint                                                   `json:"franchiseeId"`
+	WarehouseID        uint                                                    `json:"warehouseId" binding:"required"`
+	FacilityAddress    facilityAddressesTypes.CreateOrUpdateFacilityAddressDTO `json:"facilityAddress" binding:"required"`
+	IsActive           bool                                                    `json:"isActive" binding:"required"`
+	ContactPhone       string                                                  `json:"contactPhone" binding:"required"`
+	ContactEmail       string                                                  `json:"contactEmail" binding:"required"`
+	Timezone           string                                                  `json:"timezone"`
+	WorkingHours       []StoreWorkingHoursDTO                                  `json:"workingHours" binding:"required,min=1,dive"`
+	PickupSlotCapacity *int                                                    `json:"pickupSlotCapacity" binding:"omitempty,gte=0"`
 }
 
 type UpdateStoreDTO struct {
-	Name            string                                                   `json:"name"`
-	FranchiseeID    *uint                                                    `json:"franchiseeId"`
-	WarehouseID     *uint                                                    `json:"warehouseId"`
-	FacilityAddress *facilityAddressesTypes.CreateOrUpdateFacilityAddressDTO `json:"facilityAddress"`
-	IsActive        *bool                                                    `json:"isActive"`
-	ContactPhone    string                                                   `json:"contactPhone"`
-	ContactEmail    string                                                   `json:"contactEmail"`
-	StoreHours      string                                                   `json:"storeHours"`
+	Name               string                                                   `json:"name"`
+	FranchiseeID       *uint                                                    `json:"franchiseeId"`
+	WarehouseID        *uint                                                    `json:"warehouseId"`
+	FacilityAddress    *facilityAddressesTypes.CreateOrUpdateFacilityAddressDTO `json:"facilityAddress"`
+	IsActive           *bool                                                    `json:"isActive"`
+	ContactPhone       string                                                   `json:"contactPhone"`
+	ContactEmail       string                                                   `json:"contactEmail"`
+	Timezone           string                                                   `json:"timezone"`
+	WorkingHours       []StoreWorkingHoursDTO                                   `json:"workingHours" binding:"omitempty,dive"` // replaces the weekly schedule when set
+	PickupSlotCapacity *int                                                     `json:"pickupSlotCapacity" binding:"omitempty,gte=0"`
 }
 
 type StoreDTO struct {
-	ID              uint                                       `json:"id"`
-	Name            string                                     `json:"name"`
-	Franchisee      *franchiseesTypes.FranchiseeDTO            `json:"franchisee,omitempty"`
-	Warehouse       warehouseTypes.WarehouseDTO                `json:"warehouse"`
-	FacilityAddress *facilityAddressesTypes.FacilityAddressDTO `json:"facilityAddress"`
-	IsActive        bool                                       `json:"isActive"`
-	ContactPhone    string                                     `json:"contactPhone"`
-	ContactEmail    string                                     `json:"contactEmail"`
-	StoreHours      string                                     `json:"storeHours"`
+	ID                 uint                                       `json:"id"`
+	Name               string                                     `json:"name"`
+	Franchisee         *franchiseesTypes.FranchiseeDTO            `json:"franchisee,omitempty"`
+	Warehouse          warehouseTypes.WarehouseDTO                `json:"warehouse"`
+	FacilityAddress    *facilityAddressesTypes.FacilityAddressDTO `json:"facilityAddress"`
+	IsActive           bool                                       `json:"isActive"`
+	ContactPhone       string                                     `json:"contactPhone"`
+	ContactEmail       string                                     `json:"contactEmail"`
+	Timezone           string                                     `json:"timezone"`
+	WorkingHours       []StoreWorkingHoursDTO                     `json:"workingHours"`
+	HoursExceptions    []StoreHoursExceptionDTO                   `json:"hoursExceptions,omitempty"`
+	IsOpen             bool                                       `json:"isOpen"`
+	PickupSlotCapacity int                                        `json:"pickupSlotCapacity"`
+}
+
+type StoreWorkingHoursDTO struct {
+	Day     string `json:"day" binding:"required"`
+	OpenAt  string `json:"openAt" binding:"required"`
+	CloseAt string `json:"closeAt" binding:"required"`
+}
+
+type CreateStoreHoursExceptionDTO struct {
+	Date     string  `json:"date" binding:"required"` // YYYY-MM-DD
+	IsClosed bool    `json:"isClosed"`
+	OpenAt   *string `json:"openAt"`
+	CloseAt  *string `json:"closeAt"`
+	Reason   string  `json:"reason" binding:"max=255"`
+}
+
+type StoreHoursExceptionDTO struct {
+	ID       uint    `json:"id"`
+	Date     string  `json:"date"`
+	IsClosed bool    `json:"isClosed"`
+	OpenAt   *string `json:"openAt,omitempty"`
+	CloseAt  *string `json:"closeAt,omitempty"`
+	Reason   string  `json:"reason"`
 }
 
 type StoreFilter struct {
@@ -47,4 +78,5 @@ type StoreFilter struct {
 	FranchiseeID *uint   `form:"franchiseeId"`
 	WarehouseID  *uint   `json:"warehouseId"`
 	Search       *string `form:"search"`
+	IsOpen       *bool   `form:"isOpen"`
 }
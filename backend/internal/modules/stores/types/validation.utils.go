@@ -1,6 +1,9 @@
 package types
 
 import (
+	"strings"
+	"time"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
 	facilityAddressesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/facilityAddresses/types"
@@ -10,11 +13,14 @@ import (
 type StoreUpdateModels struct {
 	Store           *data.Store
 	FacilityAddress *data.FacilityAddress
+	WorkingHours    []data.StoreWorkingHours // nil keeps the current schedule
 }
 
 func UpdateStoreFields(dto *UpdateStoreDTO, store *data.Store, facilityAddress *data.FacilityAddress) (*StoreUpdateModels, error) {
 	store.Warehouse = data.Warehouse{}
 	store.Franchisee = nil
+	store.WorkingHours = nil
+	store.HoursExceptions = nil
 
 	if dto.Name != "" {
 		store.Name = dto.Name
@@ -40,8 +46,22 @@ func UpdateStoreFields(dto *UpdateStoreDTO, store *data.Store, facilityAddress *
 		}
 		store.ContactEmail = dto.ContactEmail
 	}
-	if dto.StoreHours != "" {
-		store.StoreHours = dto.StoreHours
+	if dto.Timezone != "" {
+		if err := validateTimezone(dto.Timezone); err != nil {
+			return nil, err
+		}
+		store.Timezone = dto.Timezone
+	}
+
+	var workingHours []data.StoreWorkingHours
+	if dto.WorkingHours != nil {
+		var err error
+		if workingHours, err = ValidateWorkingHours(dto.WorkingHours); err != nil {
+			return nil, err
+		}
+	}
+	if dto.PickupSlotCapacity != nil {
+		store.PickupSlotCapacity = *dto.PickupSlotCapacity
 	}
 	if dto.FacilityAddress != nil && dto.FacilityAddress.Address != "" {
 		facilityAddress = facilityAddressesTypes.MapToFacilityAddressModel(dto.FacilityAddress, facilityAddress)
@@ -50,6 +70,7 @@ func UpdateStoreFields(dto *UpdateStoreDTO, store *data.Store, facilityAddress *
 	return &StoreUpdateModels{
 		Store:           store,
 		FacilityAddress: facilityAddress,
+		WorkingHours:    workingHours,
 	}, nil
 }
 
@@ -71,7 +92,83 @@ func CreateStoreFields(dto *CreateStoreDTO) (*data.Store, error) {
 	}
 	store.ContactEmail = dto.ContactEmail
 
-	store.StoreHours = dto.StoreHours
+	store.Timezone = DefaultStoreTimezone
+	if dto.Timezone != "" {
+		if err := validateTimezone(dto.Timezone); err != nil {
+			return nil, err
+		}
+		store.Timezone = dto.Timezone
+	}
+
+	workingHours, err := ValidateWorkingHours(dto.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+	store.WorkingHours = workingHours
+
+	if dto.PickupSlotCapacity != nil {
+		store.PickupSlotCapacity = *dto.PickupSlotCapacity
+	}
 
 	return store, nil
 }
+
+// ValidateWorkingHours allows one opening window per weekday, the missing weekdays are days off
+func ValidateWorkingHours(dtos []StoreWorkingHoursDTO) ([]data.StoreWorkingHours, error) {
+	workingHours := make([]data.StoreWorkingHours, len(dtos))
+	days := make(map[data.Weekday]bool, len(dtos))
+
+	for i, dto := range dtos {
+		day, err := data.ToWeekday(dto.Day)
+		if err != nil || days[day] {
+			return nil, ErrInvalidStoreHours
+		}
+		days[day] = true
+
+		if _, err := utils.NewStoreHours(dto.OpenAt, dto.CloseAt); err != nil {
+			return nil, ErrInvalidStoreHours
+		}
+
+		workingHours[i] = data.StoreWorkingHours{
+			Day:     day,
+			OpenAt:  dto.OpenAt,
+			CloseAt: dto.CloseAt,
+		}
+	}
+
+	return workingHours, nil
+}
+
+func ValidateHoursException(storeID uint, dto *CreateStoreHoursExceptionDTO) (*data.StoreHoursException, error) {
+	date, err := time.Parse(ExceptionDateLayout, dto.Date)
+	if err != nil {
+		return nil, ErrInvalidStoreHours
+	}
+
+	exception := &data.StoreHoursException{
+		StoreID:  storeID,
+		Date:     date,
+		IsClosed: dto.IsClosed,
+		Reason:   strings.TrimSpace(dto.Reason),
+	}
+
+	if !dto.IsClosed {
+		if dto.OpenAt == nil || dto.CloseAt == nil {
+			return nil, ErrInvalidStoreHours
+		}
+		if _, err := utils.NewStoreHours(*dto.OpenAt, *dto.CloseAt); err != nil {
+			return nil, ErrInvalidStoreHours
+		}
+		exception.OpenAt = dto.OpenAt
+		exception.CloseAt = dto.CloseAt
+	}
+
+	return exception, nil
+}
+
+func validateTimezone(timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || strings.EqualFold(timezone, "Local") {
+		return ErrInvalidStoreHours
+	}
+	return nil
+}
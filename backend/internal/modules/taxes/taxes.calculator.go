@@ -0,0 +1,79 @@
+package taxes
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// calculateTaxes applies the most specific active rate to every item, the items without a rate are not taxed
+func calculateTaxes(taxRates []data.TaxRate, items []types.TaxableItem) []types.AppliedTax {
+	taxes := make([]types.AppliedTax, 0, len(items))
+	for _, item := range items {
+		taxRate := resolveTaxRate(taxRates, item.ProductCategoryID)
+		if taxRate == nil {
+			continue
+		}
+
+		taxes = append(taxes, types.AppliedTax{
+			Index:     item.Index,
+			TaxRateID: taxRate.ID,
+			Rate:      taxRate.Rate,
+			Mode:      taxRate.Mode,
+			Amount:    calculateTax(item.Amount, taxRate.Rate, taxRate.Mode),
+		})
+	}
+	return taxes
+}
+
+// resolveTaxRate picks the rate with the narrowest scope: franchisee rates override region rates,
+// region rates override global ones, and a category rate overrides the general rate of the same level.
+// Rates of equal specificity are resolved by the lowest ID.
+func resolveTaxRate(taxRates []data.TaxRate, productCategoryID uint) *data.TaxRate {
+	var best *data.TaxRate
+	bestScore := -1
+
+	for i := range taxRates {
+		taxRate := &taxRates[i]
+		if !taxRate.IsActive {
+			continue
+		}
+		if taxRate.ProductCategoryID != nil && *taxRate.ProductCategoryID != productCategoryID {
+			continue
+		}
+
+		score := taxRateSpecificity(taxRate)
+		if score > bestScore || (score == bestScore && taxRate.ID < best.ID) {
+			best = taxRate
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+func taxRateSpecificity(taxRate *data.TaxRate) int {
+	score := 0
+	switch {
+	case taxRate.FranchiseeID != nil:
+		score += 4
+	case taxRate.RegionID != nil:
+		score += 2
+	}
+	if taxRate.ProductCategoryID != nil {
+		score++
+	}
+	return score
+}
+
+// calculateTax returns the tax part of an inclusive amount or the tax to add to an exclusive one
+func calculateTax(amount, rate float64, mode data.TaxMode) float64 {
+	if amount <= 0 || rate <= 0 {
+		return 0
+	}
+
+	if mode == data.TaxModeExclusive {
+		return utils.RoundToDecimal(amount*rate/100, 2)
+	}
+	return utils.RoundToDecimal(amount*rate/(100+rate), 2)
+}
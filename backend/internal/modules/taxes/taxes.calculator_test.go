@@ -0,0 +1,91 @@
+package taxes
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateTaxes(t *testing.T) {
+	uintPtr := func(v uint) *uint { return &v }
+
+	items := []types.TaxableItem{
+		{Index: 0, ProductCategoryID: 1, Amount: 1120},
+		{Index: 1, ProductCategoryID: 2, Amount: 1000},
+	}
+
+	tests := []struct {
+		description string
+		taxRates    []data.TaxRate
+		expected    map[int]types.AppliedTax
+	}{
+		{
+			description: "Items without a rate should not be taxed",
+			taxRates:    nil,
+			expected:    map[int]types.AppliedTax{},
+		},
+		{
+			description: "Inclusive rate should extract the tax from the amount",
+			taxRates: []data.TaxRate{
+				{BaseEntity: data.BaseEntity{ID: 1}, Rate: 12, Mode: data.TaxModeInclusive, IsActive: true},
+			},
+			expected: map[int]types.AppliedTax{
+				0: {Index: 0, TaxRateID: 1, Rate: 12, Mode: data.TaxModeInclusive, Amount: 120},
+				1: {Index: 1, TaxRateID: 1, Rate: 12, Mode: data.TaxModeInclusive, Amount: 107.14},
+			},
+		},
+		{
+			description: "Exclusive rate should add the tax on top of the amount",
+			taxRates: []data.TaxRate{
+				{BaseEntity: data.BaseEntity{ID: 1}, Rate: 10, Mode: data.TaxModeExclusive, IsActive: true},
+			},
+			expected: map[int]types.AppliedTax{
+				0: {Index: 0, TaxRateID: 1, Rate: 10, Mode: data.TaxModeExclusive, Amount: 112},
+				1: {Index: 1, TaxRateID: 1, Rate: 10, Mode: data.TaxModeExclusive, Amount: 100},
+			},
+		},
+		{
+			description: "Category rate should override the general rate of the same scope",
+			taxRates: []data.TaxRate{
+				{BaseEntity: data.BaseEntity{ID: 1}, Rate: 12, Mode: data.TaxModeInclusive, IsActive: true},
+				{BaseEntity: data.BaseEntity{ID: 2}, Rate: 0, Mode: data.TaxModeInclusive, IsActive: true, ProductCategoryID: uintPtr(2)},
+			},
+			expected: map[int]types.AppliedTax{
+				0: {Index: 0, TaxRateID: 1, Rate: 12, Mode: data.TaxModeInclusive, Amount: 120},
+				1: {Index: 1, TaxRateID: 2, Rate: 0, Mode: data.TaxModeInclusive, Amount: 0},
+			},
+		},
+		{
+			description: "Franchisee rate should override the region category rate",
+			taxRates: []data.TaxRate{
+				{BaseEntity: data.BaseEntity{ID: 1}, Rate: 12, Mode: data.TaxModeInclusive, IsActive: true, RegionID: uintPtr(1), ProductCategoryID: uintPtr(1)},
+				{BaseEntity: data.BaseEntity{ID: 2}, Rate: 10, Mode: data.TaxModeExclusive, IsActive: true, FranchiseeID: uintPtr(1)},
+			},
+			expected: map[int]types.AppliedTax{
+				0: {Index: 0, TaxRateID: 2, Rate: 10, Mode: data.TaxModeExclusive, Amount: 112},
+				1: {Index: 1, TaxRateID: 2, Rate: 10, Mode: data.TaxModeExclusive, Amount: 100},
+			},
+		},
+		{
+			description: "Inactive rate should be ignored",
+			taxRates: []data.TaxRate{
+				{BaseEntity: data.BaseEntity{ID: 1}, Rate: 12, Mode: data.TaxModeInclusive, IsActive: false},
+			},
+			expected: map[int]types.AppliedTax{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			taxes := calculateTaxes(tt.taxRates, items)
+
+			actual := make(map[int]types.AppliedTax, len(taxes))
+			for _, tax := range taxes {
+				actual[tax.Index] = tax
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package taxes
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TaxHandler struct {
+	service      TaxService
+	auditService audit.AuditService
+}
+
+func NewTaxHandler(service TaxService, auditService audit.AuditService) *TaxHandler {
+	return &TaxHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *TaxHandler) CreateTaxRate(c *gin.Context) {
+	var dto types.CreateTaxRateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	taxRate, err := h.service.CreateTaxRate(&dto)
+	if err != nil {
+		if isTaxRateValidationError(err) {
+			localization.SendLocalizedResponseWithKey(c, types.Response400TaxRate)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateCreate)
+		return
+	}
+
+	action := types.CreateTaxRateAuditFactory(
+		&data.BaseDetails{
+			ID:   taxRate.ID,
+			Name: taxRate.Name,
+		})
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response201TaxRate)
+}
+
+func (h *TaxHandler) GetTaxRateByID(c *gin.Context) {
+	taxRateID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400TaxRate)
+		return
+	}
+
+	taxRate, err := h.service.GetTaxRateByID(taxRateID)
+	if err != nil {
+		if errors.Is(err, types.ErrTaxRateNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404TaxRate)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateGet)
+		return
+	}
+
+	utils.SendSuccessResponse(c, taxRate)
+}
+
+func (h *TaxHandler) GetTaxRates(c *gin.Context) {
+	var filter types.TaxRatesFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.TaxRate{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	taxRates, err := h.service.GetTaxRates(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, taxRates, filter.Pagination)
+}
+
+func (h *TaxHandler) UpdateTaxRate(c *gin.Context) {
+	taxRateID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400TaxRate)
+		return
+	}
+
+	var dto types.UpdateTaxRateDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	taxRate, err := h.service.UpdateTaxRate(taxRateID, &dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrTaxRateNotFound):
+			localization.SendLocalizedResponseWithKey(c, types.Response404TaxRate)
+		case isTaxRateValidationError(err):
+			localization.SendLocalizedResponseWithKey(c, types.Response400TaxRate)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateUpdate)
+		}
+		return
+	}
+
+	action := types.UpdateTaxRateAuditFactory(
+		&data.BaseDetails{
+			ID:   taxRate.ID,
+			Name: taxRate.Name,
+		}, &dto)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200TaxRateUpdate)
+}
+
+func (h *TaxHandler) DeleteTaxRate(c *gin.Context) {
+	taxRateID, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400TaxRate)
+		return
+	}
+
+	taxRate, err := h.service.GetTaxRateByID(taxRateID)
+	if err != nil {
+		if errors.Is(err, types.ErrTaxRateNotFound) {
+			localization.SendLocalizedResponseWithKey(c, types.Response404TaxRate)
+			return
+		}
+		localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateGet)
+		return
+	}
+
+	if err := h.service.DeleteTaxRate(taxRateID); err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500TaxRateDelete)
+		return
+	}
+
+	action := types.DeleteTaxRateAuditFactory(
+		&data.BaseDetails{
+			ID:   taxRate.ID,
+			Name: taxRate.Name,
+		})
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	localization.SendLocalizedResponseWithKey(c, types.Response200TaxRateDelete)
+}
+
+func isTaxRateValidationError(err error) bool {
+	return errors.Is(err, types.ErrInvalidTaxMode) ||
+		errors.Is(err, types.ErrInvalidTaxRate) ||
+		errors.Is(err, types.ErrInvalidTaxScope)
+}
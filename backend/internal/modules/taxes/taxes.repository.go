@@ -0,0 +1,116 @@
+package taxes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type TaxRepository interface {
+	CreateTaxRate(taxRate *data.TaxRate) error
+	GetTaxRateByID(id uint) (*data.TaxRate, error)
+	GetTaxRates(filter *types.TaxRatesFilter) ([]data.TaxRate, error)
+	SaveTaxRate(taxRate *data.TaxRate) error
+	DeleteTaxRate(id uint) error
+
+	GetActiveStoreTaxRates(storeID uint) ([]data.TaxRate, error)
+}
+
+type taxRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &taxRepository{db: db}
+}
+
+func (r *taxRepository) CreateTaxRate(taxRate *data.TaxRate) error {
+	return r.db.Create(taxRate).Error
+}
+
+func (r *taxRepository) GetTaxRateByID(id uint) (*data.TaxRate, error) {
+	var taxRate data.TaxRate
+	if err := r.db.First(&taxRate, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrTaxRateNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch tax rate with ID %d: %w", id, err)
+	}
+	return &taxRate, nil
+}
+
+func (r *taxRepository) GetTaxRates(filter *types.TaxRatesFilter) ([]data.TaxRate, error) {
+	var taxRates []data.TaxRate
+
+	query := r.db.Model(&data.TaxRate{})
+
+	if filter.Search != nil && *filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+*filter.Search+"%")
+	}
+
+	if filter.Mode != nil {
+		query = query.Where("mode = ?", *filter.Mode)
+	}
+
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	if filter.RegionID != nil {
+		query = query.Where("region_id = ?", *filter.RegionID)
+	}
+
+	if filter.FranchiseeID != nil {
+		query = query.Where("franchisee_id = ?", *filter.FranchiseeID)
+	}
+
+	if filter.ProductCategoryID != nil {
+		query = query.Where("product_category_id = ?", *filter.ProductCategoryID)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.TaxRate{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&taxRates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch tax rates: %w", err)
+	}
+
+	return taxRates, nil
+}
+
+func (r *taxRepository) SaveTaxRate(taxRate *data.TaxRate) error {
+	return r.db.Omit("Region", "Franchisee", "ProductCategory").Save(taxRate).Error
+}
+
+func (r *taxRepository) DeleteTaxRate(id uint) error {
+	return r.db.Delete(&data.TaxRate{}, id).Error
+}
+
+// GetActiveStoreTaxRates returns the active global rates and the rates of the store region and franchisee,
+// the store region is the region of its warehouse
+func (r *taxRepository) GetActiveStoreTaxRates(storeID uint) ([]data.TaxRate, error) {
+	var taxRates []data.TaxRate
+
+	err := r.db.
+		Where("is_active = ?", true).
+		Where("region_id IS NULL OR region_id = (?)",
+			r.db.Model(&data.Store{}).
+				Select("warehouses.region_id").
+				Joins("JOIN warehouses ON warehouses.id = stores.warehouse_id").
+				Where("stores.id = ?", storeID)).
+		Where("franchisee_id IS NULL OR franchisee_id = (?)",
+			r.db.Model(&data.Store{}).Select("franchisee_id").Where("id = ?", storeID)).
+		Order("id").
+		Find(&taxRates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active tax rates of store %d: %w", storeID, err)
+	}
+
+	return taxRates, nil
+}
@@ -0,0 +1,116 @@
+package taxes
+
+import (
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes/types"
+	"go.uber.org/zap"
+)
+
+type TaxService interface {
+	CreateTaxRate(dto *types.CreateTaxRateDTO) (*types.TaxRateDTO, error)
+	GetTaxRateByID(id uint) (*types.TaxRateDTO, error)
+	GetTaxRates(filter *types.TaxRatesFilter) ([]types.TaxRateDTO, error)
+	UpdateTaxRate(id uint, dto *types.UpdateTaxRateDTO) (*types.TaxRateDTO, error)
+	DeleteTaxRate(id uint) error
+
+	CalculateTaxes(storeID uint, items []types.TaxableItem) ([]types.AppliedTax, error)
+}
+
+type taxService struct {
+	repo   TaxRepository
+	logger *zap.SugaredLogger
+}
+
+func NewTaxService(repo TaxRepository, logger *zap.SugaredLogger) TaxService {
+	return &taxService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (s *taxService) CreateTaxRate(dto *types.CreateTaxRateDTO) (*types.TaxRateDTO, error) {
+	taxRate := types.ConvertToTaxRateModel(dto)
+	if err := types.ValidateTaxRate(taxRate); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTaxRate(taxRate); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreateTaxRate, err))
+		return nil, types.ErrFailedToCreateTaxRate
+	}
+
+	response := types.ConvertToTaxRateDTO(taxRate)
+	return &response, nil
+}
+
+func (s *taxService) GetTaxRateByID(id uint) (*types.TaxRateDTO, error) {
+	taxRate, err := s.repo.GetTaxRateByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := types.ConvertToTaxRateDTO(taxRate)
+	return &response, nil
+}
+
+func (s *taxService) GetTaxRates(filter *types.TaxRatesFilter) ([]types.TaxRateDTO, error) {
+	taxRates, err := s.repo.GetTaxRates(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchTaxRates, err))
+		return nil, types.ErrFailedToFetchTaxRates
+	}
+
+	responses := make([]types.TaxRateDTO, len(taxRates))
+	for i := range taxRates {
+		responses[i] = types.ConvertToTaxRateDTO(&taxRates[i])
+	}
+	return responses, nil
+}
+
+func (s *taxService) UpdateTaxRate(id uint, dto *types.UpdateTaxRateDTO) (*types.TaxRateDTO, error) {
+	taxRate, err := s.repo.GetTaxRateByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	types.ApplyUpdateTaxRateDTO(taxRate, dto)
+	if err := types.ValidateTaxRate(taxRate); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SaveTaxRate(taxRate); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdateTaxRate, err))
+		return nil, types.ErrFailedToUpdateTaxRate
+	}
+
+	response := types.ConvertToTaxRateDTO(taxRate)
+	return &response, nil
+}
+
+func (s *taxService) DeleteTaxRate(id uint) error {
+	if _, err := s.repo.GetTaxRateByID(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteTaxRate(id); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToDeleteTaxRate, err))
+		return types.ErrFailedToDeleteTaxRate
+	}
+	return nil
+}
+
+func (s *taxService) CalculateTaxes(storeID uint, items []types.TaxableItem) ([]types.AppliedTax, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	taxRates, err := s.repo.GetActiveStoreTaxRates(storeID)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to calculate taxes: %w", err)
+		s.logger.Error(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	return calculateTaxes(taxRates, items), nil
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+func ConvertToTaxRateModel(dto *CreateTaxRateDTO) *data.TaxRate {
+	isActive := true
+	if dto.IsActive != nil {
+		isActive = *dto.IsActive
+	}
+
+	return &data.TaxRate{
+		Name:              dto.Name,
+		Rate:              dto.Rate,
+		Mode:              dto.Mode,
+		IsActive:          isActive,
+		RegionID:          dto.RegionID,
+		FranchiseeID:      dto.FranchiseeID,
+		ProductCategoryID: dto.ProductCategoryID,
+	}
+}
+
+func ApplyUpdateTaxRateDTO(taxRate *data.TaxRate, dto *UpdateTaxRateDTO) {
+	if dto.Name != nil {
+		taxRate.Name = *dto.Name
+	}
+	if dto.Rate != nil {
+		taxRate.Rate = *dto.Rate
+	}
+	if dto.Mode != nil {
+		taxRate.Mode = *dto.Mode
+	}
+	if dto.IsActive != nil {
+		taxRate.IsActive = *dto.IsActive
+	}
+	if dto.RegionID != nil {
+		taxRate.RegionID = dto.RegionID
+	}
+	if dto.FranchiseeID != nil {
+		taxRate.FranchiseeID = dto.FranchiseeID
+	}
+	if dto.ProductCategoryID != nil {
+		taxRate.ProductCategoryID = dto.ProductCategoryID
+	}
+}
+
+func ConvertToTaxRateDTO(taxRate *data.TaxRate) TaxRateDTO {
+	return TaxRateDTO{
+		ID:                taxRate.ID,
+		Name:              taxRate.Name,
+		Rate:              taxRate.Rate,
+		Mode:              taxRate.Mode,
+		IsActive:          taxRate.IsActive,
+		RegionID:          taxRate.RegionID,
+		FranchiseeID:      taxRate.FranchiseeID,
+		ProductCategoryID: taxRate.ProductCategoryID,
+		CreatedAt:         taxRate.CreatedAt,
+		UpdatedAt:         taxRate.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrTaxRateNotFound       = moduleErrors.NewModuleError(errors.New("tax rate not found"))
+	ErrInvalidTaxMode        = moduleErrors.NewModuleError(errors.New("invalid tax mode"))
+	ErrInvalidTaxRate        = moduleErrors.NewModuleError(errors.New("tax rate must be between 0 and 100 percent"))
+	ErrInvalidTaxScope       = moduleErrors.NewModuleError(errors.New("tax rate can be set either for a region or for a franchisee"))
+	ErrFailedToCreateTaxRate = moduleErrors.NewModuleError(errors.New("failed to create tax rate"))
+	ErrFailedToUpdateTaxRate = moduleErrors.NewModuleError(errors.New("failed to update tax rate"))
+	ErrFailedToDeleteTaxRate = moduleErrors.NewModuleError(errors.New("failed to delete tax rate"))
+	ErrFailedToFetchTaxRates = moduleErrors.NewModuleError(errors.New("failed to fetch tax rates"))
+)
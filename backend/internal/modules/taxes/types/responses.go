@@ -0,0 +1,22 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500TaxRateCreate = localization.NewResponseKey(http.StatusInternalServerError, data.TaxRateComponent, data.CreateOperation.ToString())
+	Response500TaxRateGet    = localization.NewResponseKey(http.StatusInternalServerError, data.TaxRateComponent, data.GetOperation.ToString())
+	Response500TaxRateUpdate = localization.NewResponseKey(http.StatusInternalServerError, data.TaxRateComponent, data.UpdateOperation.ToString())
+	Response500TaxRateDelete = localization.NewResponseKey(http.StatusInternalServerError, data.TaxRateComponent, data.DeleteOperation.ToString())
+
+	Response400TaxRate = localization.NewResponseKey(http.StatusBadRequest, data.TaxRateComponent)
+	Response404TaxRate = localization.NewResponseKey(http.StatusNotFound, data.TaxRateComponent)
+
+	Response201TaxRate       = localization.NewResponseKey(http.StatusCreated, data.TaxRateComponent)
+	Response200TaxRateUpdate = localization.NewResponseKey(http.StatusOK, data.TaxRateComponent, data.UpdateOperation.ToString())
+	Response200TaxRateDelete = localization.NewResponseKey(http.StatusOK, data.TaxRateComponent, data.DeleteOperation.ToString())
+)
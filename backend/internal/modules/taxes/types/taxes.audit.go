@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+var (
+	CreateTaxRateAuditFactory = shared.NewAuditActionBaseFactory(
+		data.CreateOperation, data.TaxRateComponent)
+
+	UpdateTaxRateAuditFactory = shared.NewAuditActionExtendedFactory(
+		data.UpdateOperation, data.TaxRateComponent, &UpdateTaxRateDTO{})
+
+	DeleteTaxRateAuditFactory = shared.NewAuditActionBaseFactory(
+		data.DeleteOperation, data.TaxRateComponent)
+)
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type CreateTaxRateDTO struct {
+	Name              string       `json:"name" binding:"required,max=255"`
+	Rate              float64      `json:"rate" binding:"gte=0,lte=100"`
+	Mode              data.TaxMode `json:"mode" binding:"required"`
+	IsActive          *bool        `json:"isActive"`
+	RegionID          *uint        `json:"regionId" binding:"omitempty,gt=0"`
+	FranchiseeID      *uint        `json:"franchiseeId" binding:"omitempty,gt=0"`
+	ProductCategoryID *uint        `json:"productCategoryId" binding:"omitempty,gt=0"`
+}
+
+type UpdateTaxRateDTO struct {
+	Name              *string       `json:"name" binding:"omitempty,max=255"`
+	Rate              *float64      `json:"rate" binding:"omitempty,gte=0,lte=100"`
+	Mode              *data.TaxMode `json:"mode"`
+	IsActive          *bool         `json:"isActive"`
+	RegionID          *uint         `json:"regionId" binding:"omitempty,gt=0"`
+	FranchiseeID      *uint         `json:"franchiseeId" binding:"omitempty,gt=0"`
+	ProductCategoryID *uint         `json:"productCategoryId" binding:"omitempty,gt=0"`
+}
+
+type TaxRateDTO struct {
+	ID                uint         `json:"id"`
+	Name              string       `json:"name"`
+	Rate              float64      `json:"rate"`
+	Mode              data.TaxMode `json:"mode"`
+	IsActive          bool         `json:"isActive"`
+	RegionID          *uint        `json:"regionId,omitempty"`
+	FranchiseeID      *uint        `json:"franchiseeId,omitempty"`
+	ProductCategoryID *uint        `json:"productCategoryId,omitempty"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+type TaxRatesFilter struct {
+	Search            *string       `form:"search"`
+	Mode              *data.TaxMode `form:"mode"`
+	IsActive          *bool         `form:"isActive"`
+	RegionID          *uint         `form:"regionId"`
+	FranchiseeID      *uint         `form:"franchiseeId"`
+	ProductCategoryID *uint         `form:"productCategoryId"`
+	utils.BaseFilter
+}
+
+// TaxableItem is a single priced unit of an order, Index links the calculated tax back to the caller's item
+type TaxableItem struct {
+	Index             int
+	ProductCategoryID uint
+	Amount            float64
+}
+
+type AppliedTax struct {
+	Index     int
+	TaxRateID uint
+	Rate      float64
+	Mode      data.TaxMode
+	Amount    float64
+}
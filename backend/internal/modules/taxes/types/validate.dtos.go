@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+)
+
+// ValidateTaxRate checks the rate and its scope, it is used for both created and updated rates
+func ValidateTaxRate(taxRate *data.TaxRate) error {
+	if !data.IsValidTaxMode(taxRate.Mode) {
+		return ErrInvalidTaxMode
+	}
+
+	if taxRate.Rate < 0 || taxRate.Rate > 100 {
+		return ErrInvalidTaxRate
+	}
+
+	if taxRate.RegionID != nil && taxRate.FranchiseeID != nil {
+		return ErrInvalidTaxScope
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
+	"github.com/tealeg/xlsx"
+)
+
+var (
+	kazHeaders = []string{"Материал", "Штрихкод", "Өлшем бірлігі", "Саны", "Бағасы", "Сомасы", "Қабылданды"}
+	rusHeaders = []string{"Материал", "Штрихкод", "Единица измерения", "Количество", "Цена", "Сумма", "Принято"}
+	engHeaders = []string{"Material", "Barcode", "Unit", "Quantity", "Price", "Total", "Received"}
+
+	totalLabels = map[string]string{"kk": "Барлығы", "ru": "Итого", "en": "Total"}
+)
+
+// GeneratePurchaseOrderExcel writes the lines of the order, the sheet is sent to the supplier together with the PDF
+func GeneratePurchaseOrderExcel(order *types.PurchaseOrderDTO, language string) ([]byte, error) {
+	headers := rusHeaders
+	switch language {
+	case "kk":
+		headers = kazHeaders
+	case "en":
+		headers = engHeaders
+	default:
+		language = "ru"
+	}
+
+	file := xlsx.NewFile()
+
+	sheet, err := file.AddSheet(fmt.Sprintf("PO-%d", order.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	headerRow := sheet.AddRow()
+	for _, header := range headers {
+		headerRow.AddCell().Value = header
+	}
+	setHeadersStyle(headerRow)
+
+	for _, line := range order.Lines {
+		row := sheet.AddRow()
+		row.AddCell().Value = line.StockMaterial.Name
+		row.AddCell().Value = line.StockMaterial.Barcode
+		row.AddCell().Value = line.StockMaterial.Unit.Name
+		row.AddCell().SetFloat(line.Quantity)
+		row.AddCell().SetFloat(line.UnitPrice)
+		row.AddCell().SetFloat(line.Total)
+		row.AddCell().SetFloat(line.ReceivedQuantity)
+	}
+
+	totalRow := sheet.AddRow()
+	totalRow.AddCell().Value = totalLabels[language]
+	for range 4 {
+		totalRow.AddCell()
+	}
+	totalRow.AddCell().SetFloat(order.Total)
+
+	for i := range headers {
+		if err := sheet.SetColWidth(i, i, 20); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := file.Write(buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func setHeadersStyle(headerRow *xlsx.Row) {
+	style := xlsx.NewStyle()
+	style.Font.Bold = true
+	style.Fill.FgColor = "C6C6C6"
+	style.Fill.PatternType = "solid"
+
+	for _, cell := range headerRow.Cells {
+		cell.SetStyle(style)
+	}
+}
@@ -0,0 +1,280 @@
+package purchaseOrders
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
+	stockRequestsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/export"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderHandler struct {
+	service      PurchaseOrderService
+	auditService audit.AuditService
+}
+
+func NewPurchaseOrderHandler(service PurchaseOrderService, auditService audit.AuditService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
+	var dto types.CreatePurchaseOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	order, err := h.service.CreatePurchaseOrder(warehouseID, &dto)
+	if err != nil {
+		sendPurchaseOrderError(c, err, types.Response500PurchaseOrderCreate)
+		return
+	}
+
+	action := types.CreatePurchaseOrderAuditFactory(
+		&data.BaseDetails{
+			ID:   order.ID,
+			Name: order.Supplier.Name,
+		},
+		&dto, warehouseID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+
+	utils.SendResponseWithStatus(c, order, http.StatusCreated)
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrders(c *gin.Context) {
+	var filter types.PurchaseOrdersFilter
+	if err := utils.ParseQueryWithBaseFilter(c, &filter, &data.PurchaseOrder{}); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+	filter.WarehouseID = &warehouseID
+
+	orders, err := h.service.GetPurchaseOrders(&filter)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PurchaseOrderGet)
+		return
+	}
+
+	utils.SendSuccessResponseWithPagination(c, orders, filter.Pagination)
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseSuggestions(c *gin.Context) {
+	var query stockRequestsTypes.ReplenishmentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	suggestions, err := h.service.GetPurchaseSuggestions(warehouseID, query)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PurchaseOrderSuggest)
+		return
+	}
+
+	utils.SendSuccessResponse(c, suggestions)
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrderByID(c *gin.Context) {
+	order, ok := h.getPurchaseOrder(c)
+	if !ok {
+		return
+	}
+
+	utils.SendSuccessResponse(c, order)
+}
+
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400PurchaseOrder)
+		return
+	}
+
+	var dto types.UpdatePurchaseOrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingJSON)
+		return
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	order, err := h.service.UpdatePurchaseOrder(id, warehouseID, &dto)
+	if err != nil {
+		sendPurchaseOrderError(c, err, types.Response500PurchaseOrderUpdate)
+		return
+	}
+
+	h.recordUpdatePurchaseOrderAudit(c, order, &types.PurchaseOrderPayloads{UpdatePurchaseOrderDTO: &dto})
+	localization.SendLocalizedResponseWithKey(c, types.Response200PurchaseOrderUpdate)
+}
+
+func (h *PurchaseOrderHandler) SendPurchaseOrder(c *gin.Context) {
+	h.setPurchaseOrderStatus(c, data.PurchaseOrderStatusSent, types.Response200PurchaseOrderSend)
+}
+
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(c *gin.Context) {
+	h.setPurchaseOrderStatus(c, data.PurchaseOrderStatusCancelled, types.Response200PurchaseOrderCancel)
+}
+
+// ClosePurchaseOrder completes a partially received order, the outstanding quantities are not expected anymore
+func (h *PurchaseOrderHandler) ClosePurchaseOrder(c *gin.Context) {
+	h.setPurchaseOrderStatus(c, data.PurchaseOrderStatusReceived, types.Response200PurchaseOrderClose)
+}
+
+func (h *PurchaseOrderHandler) ExportPurchaseOrderPDF(c *gin.Context) {
+	order, ok := h.getPurchaseOrder(c)
+	if !ok {
+		return
+	}
+
+	pdfData, err := pdf.GeneratePDFPurchaseOrder(types.ToPDFPurchaseOrder(order))
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PurchaseOrderExport)
+		return
+	}
+
+	filename := fmt.Sprintf("purchase_order_%d.pdf", order.ID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", fmt.Sprintf("%d", len(pdfData)))
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
+
+func (h *PurchaseOrderHandler) ExportPurchaseOrderXLSX(c *gin.Context) {
+	var query types.ExportPurchaseOrderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		localization.SendLocalizedResponseWithKey(c, localization.ErrMessageBindingQuery)
+		return
+	}
+
+	order, ok := h.getPurchaseOrder(c)
+	if !ok {
+		return
+	}
+
+	excelData, err := export.GeneratePurchaseOrderExcel(order, query.Language)
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response500PurchaseOrderExport)
+		return
+	}
+
+	filename := fmt.Sprintf("purchase_order_%d.xlsx", order.ID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", excelData)
+}
+
+func (h *PurchaseOrderHandler) getPurchaseOrder(c *gin.Context) (*types.PurchaseOrderDTO, bool) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400PurchaseOrder)
+		return nil, false
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	order, err := h.service.GetPurchaseOrderByID(id, warehouseID)
+	if err != nil {
+		sendPurchaseOrderError(c, err, types.Response500PurchaseOrderGet)
+		return nil, false
+	}
+
+	return order, true
+}
+
+func (h *PurchaseOrderHandler) setPurchaseOrderStatus(c *gin.Context, status data.PurchaseOrderStatus, successKey *localization.ResponseKey) {
+	id, err := utils.ParseParam(c, "id")
+	if err != nil {
+		localization.SendLocalizedResponseWithKey(c, types.Response400PurchaseOrder)
+		return
+	}
+
+	warehouseID, errH := contexts.GetWarehouseId(c)
+	if errH != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	order, err := h.service.SetPurchaseOrderStatus(id, warehouseID, status)
+	if err != nil {
+		sendPurchaseOrderError(c, err, types.Response500PurchaseOrderUpdate)
+		return
+	}
+
+	h.recordUpdatePurchaseOrderAudit(c, order, &types.PurchaseOrderPayloads{Status: &status})
+	localization.SendLocalizedResponseWithKey(c, successKey)
+}
+
+func (h *PurchaseOrderHandler) recordUpdatePurchaseOrderAudit(c *gin.Context, order *types.PurchaseOrderDTO, payload *types.PurchaseOrderPayloads) {
+	action := types.UpdatePurchaseOrderAuditFactory(
+		&data.BaseDetails{
+			ID:   order.ID,
+			Name: order.Supplier.Name,
+		},
+		payload, order.WarehouseID,
+	)
+
+	go func() {
+		_ = h.auditService.RecordEmployeeAction(c, &action)
+	}()
+}
+
+func sendPurchaseOrderError(c *gin.Context, err error, fallback *localization.ResponseKey) {
+	switch {
+	case errors.Is(err, types.ErrPurchaseOrderNotFound):
+		localization.SendLocalizedResponseWithKey(c, types.Response404PurchaseOrder)
+	case errors.Is(err, supplierTypes.ErrSupplierNotFound):
+		localization.SendLocalizedResponseWithKey(c, types.Response404PurchaseOrderSupplier)
+	case errors.Is(err, types.ErrInvalidPurchaseOrderLines):
+		localization.SendLocalizedResponseWithKey(c, types.Response400PurchaseOrderLines)
+	case errors.Is(err, types.ErrMaterialNotSupplied):
+		localization.SendLocalizedResponseWithKey(c, types.Response400PurchaseOrderMaterialSupplied)
+	case errors.Is(err, types.ErrInvalidStatusTransition), errors.Is(err, types.ErrPurchaseOrderNotEditable):
+		localization.SendLocalizedResponseWithKey(c, types.Response409PurchaseOrderStatus)
+	default:
+		localization.SendLocalizedResponseWithKey(c, fallback)
+	}
+}
@@ -0,0 +1,313 @@
+package purchaseOrders
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	CloneWithTransaction(tx *gorm.DB) PurchaseOrderRepository
+
+	CreatePurchaseOrder(order *data.PurchaseOrder) error
+	GetPurchaseOrderByID(id, warehouseID uint) (*data.PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(id, warehouseID uint) (*data.PurchaseOrder, error)
+	GetPurchaseOrders(filter *types.PurchaseOrdersFilter) ([]data.PurchaseOrder, error)
+	UpdateDraft(order *data.PurchaseOrder, lines []data.PurchaseOrderLine) error
+	UpdateStatus(order *data.PurchaseOrder, from data.PurchaseOrderStatus) error
+	SaveReceipt(order *data.PurchaseOrder) error
+
+	GetPurchaseStocks(warehouseID uint, from time.Time) ([]types.PurchaseStock, error)
+	GetSupplierMaterialsByStockMaterials(stockMaterialIDs []uint) ([]data.SupplierMaterial, error)
+}
+
+type purchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+func (r *purchaseOrderRepository) CloneWithTransaction(tx *gorm.DB) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: tx}
+}
+
+func (r *purchaseOrderRepository) CreatePurchaseOrder(order *data.PurchaseOrder) error {
+	return r.db.Create(order).Error
+}
+
+func (r *purchaseOrderRepository) GetPurchaseOrderByID(id, warehouseID uint) (*data.PurchaseOrder, error) {
+	var order data.PurchaseOrder
+	err := r.preloadPurchaseOrder(r.db).
+		Where("id = ? AND warehouse_id = ?", id, warehouseID).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch purchase order with ID %d: %w", id, err)
+	}
+	return &order, nil
+}
+
+// GetPurchaseOrderForUpdate locks the order until the end of the transaction, the receipts of the order are applied one after another
+func (r *purchaseOrderRepository) GetPurchaseOrderForUpdate(id, warehouseID uint) (*data.PurchaseOrder, error) {
+	var order data.PurchaseOrder
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND warehouse_id = ?", id, warehouseID).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, types.ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to lock purchase order with ID %d: %w", id, err)
+	}
+
+	if err := r.db.Where("purchase_order_id = ?", order.ID).Find(&order.Lines).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch the lines of purchase order %d: %w", id, err)
+	}
+	return &order, nil
+}
+
+func (r *purchaseOrderRepository) GetPurchaseOrders(filter *types.PurchaseOrdersFilter) ([]data.PurchaseOrder, error) {
+	var orders []data.PurchaseOrder
+
+	query := r.preloadPurchaseOrder(r.db.Model(&data.PurchaseOrder{}))
+
+	if filter.WarehouseID != nil {
+		query = query.Where("purchase_orders.warehouse_id = ?", *filter.WarehouseID)
+	}
+	if filter.SupplierID != nil {
+		query = query.Where("purchase_orders.supplier_id = ?", *filter.SupplierID)
+	}
+	if filter.Status != nil {
+		query = query.Where("purchase_orders.status = ?", *filter.Status)
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		search := "%" + *filter.Search + "%"
+		query = query.Where("(purchase_orders.comment ILIKE ? OR purchase_orders.supplier_id IN (SELECT id FROM suppliers WHERE name ILIKE ?))", search, search)
+	}
+
+	query, err := utils.ApplySortedPaginationForModel(query, filter.Pagination, filter.Sort, &data.PurchaseOrder{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch purchase orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// UpdateDraft saves the details of a draft order and replaces its lines when they are given
+func (r *purchaseOrderRepository) UpdateDraft(order *data.PurchaseOrder, lines []data.PurchaseOrderLine) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&data.PurchaseOrder{}).
+			Where("id = ? AND status = ?", order.ID, data.PurchaseOrderStatusDraft).
+			Updates(map[string]interface{}{
+				"comment":                order.Comment,
+				"expected_delivery_date": order.ExpectedDeliveryDate,
+				"total":                  order.Total,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("failed to update purchase order %d: %w", order.ID, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return types.ErrPurchaseOrderNotEditable
+		}
+
+		if lines == nil {
+			return nil
+		}
+
+		if err := tx.Unscoped().Where("purchase_order_id = ?", order.ID).Delete(&data.PurchaseOrderLine{}).Error; err != nil {
+			return fmt.Errorf("failed to delete the lines of purchase order %d: %w", order.ID, err)
+		}
+
+		for i := range lines {
+			lines[i].PurchaseOrderID = order.ID
+		}
+		return tx.Create(&lines).Error
+	})
+}
+
+// UpdateStatus moves the order from the given status, the order changed in the meantime is left as it is
+func (r *purchaseOrderRepository) UpdateStatus(order *data.PurchaseOrder, from data.PurchaseOrderStatus) error {
+	res := r.db.Model(&data.PurchaseOrder{}).
+		Where("id = ? AND status = ?", order.ID, from).
+		Updates(map[string]interface{}{
+			"status":       order.Status,
+			"sent_at":      order.SentAt,
+			"received_at":  order.ReceivedAt,
+			"cancelled_at": order.CancelledAt,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update the status of purchase order %d: %w", order.ID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return types.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// SaveReceipt saves the received quantities of the lines and the status of the order locked by GetPurchaseOrderForUpdate
+func (r *purchaseOrderRepository) SaveReceipt(order *data.PurchaseOrder) error {
+	for _, line := range order.Lines {
+		err := r.db.Model(&data.PurchaseOrderLine{}).
+			Where("id = ?", line.ID).
+			Update("received_quantity", line.ReceivedQuantity).Error
+		if err != nil {
+			return fmt.Errorf("failed to update the received quantity of purchase order line %d: %w", line.ID, err)
+		}
+	}
+
+	err := r.db.Model(&data.PurchaseOrder{}).
+		Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"status":      order.Status,
+			"received_at": order.ReceivedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update the status of purchase order %d: %w", order.ID, err)
+	}
+	return nil
+}
+
+type materialQuantity struct {
+	StockMaterialID uint
+	Quantity        float64
+}
+
+// GetPurchaseStocks collects the active stock materials the warehouse holds or sent to the stores since the date.
+// The outflow counts the requests which left the warehouse, the ordered quantity is outstanding in the open purchase orders
+func (r *purchaseOrderRepository) GetPurchaseStocks(warehouseID uint, from time.Time) ([]types.PurchaseStock, error) {
+	var outflow []materialQuantity
+	err := r.db.Model(&data.StockRequestIngredient{}).
+		Select("stock_request_ingredients.stock_material_id, SUM(stock_request_ingredients.quantity) AS quantity").
+		Where("stock_request_ingredients.stock_request_id IN (?)", r.db.Model(&data.StockRequest{}).
+			Select("id").
+			Where("warehouse_id = ? AND status IN (?) AND updated_at >= ?", warehouseID, []data.StockRequestStatus{
+				data.StockRequestInDelivery,
+				data.StockRequestCompleted,
+				data.StockRequestAcceptedWithChange,
+			}, from)).
+		Group("stock_request_ingredients.stock_material_id").
+		Scan(&outflow).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the outflow of warehouse %d: %w", warehouseID, err)
+	}
+
+	var stocks []materialQuantity
+	err = r.db.Model(&data.WarehouseStock{}).
+		Select("stock_material_id, quantity").
+		Where("warehouse_id = ?", warehouseID).
+		Scan(&stocks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the stocks of warehouse %d: %w", warehouseID, err)
+	}
+
+	var ordered []materialQuantity
+	err = r.db.Model(&data.PurchaseOrderLine{}).
+		Select("purchase_order_lines.stock_material_id, SUM(GREATEST(purchase_order_lines.quantity - purchase_order_lines.received_quantity, 0)) AS quantity").
+		Where("purchase_order_lines.purchase_order_id IN (?)", r.db.Model(&data.PurchaseOrder{}).
+			Select("id").
+			Where("warehouse_id = ? AND status IN (?)", warehouseID, []data.PurchaseOrderStatus{
+				data.PurchaseOrderStatusDraft,
+				data.PurchaseOrderStatusSent,
+				data.PurchaseOrderStatusPartiallyReceived,
+			})).
+		Group("purchase_order_lines.stock_material_id").
+		Scan(&ordered).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the ordered quantities of warehouse %d: %w", warehouseID, err)
+	}
+
+	byMaterial := make(map[uint]*types.PurchaseStock)
+	entry := func(stockMaterialID uint) *types.PurchaseStock {
+		stock, ok := byMaterial[stockMaterialID]
+		if !ok {
+			stock = &types.PurchaseStock{}
+			byMaterial[stockMaterialID] = stock
+		}
+		return stock
+	}
+	for _, row := range stocks {
+		entry(row.StockMaterialID).Quantity += row.Quantity
+	}
+	for _, row := range outflow {
+		entry(row.StockMaterialID).Outflow = row.Quantity
+	}
+	for _, row := range ordered {
+		entry(row.StockMaterialID).OrderedQuantity = row.Quantity
+	}
+
+	if len(byMaterial) == 0 {
+		return []types.PurchaseStock{}, nil
+	}
+
+	ids := make([]uint, 0, len(byMaterial))
+	for id := range byMaterial {
+		ids = append(ids, id)
+	}
+
+	var materials []data.StockMaterial
+	err = r.db.
+		Preload("Unit").
+		Preload("StockMaterialCategory").
+		Preload("Ingredient.Unit").
+		Preload("Ingredient.IngredientCategory").
+		Where("id IN (?) AND is_active = TRUE", ids).
+		Order("id").
+		Find(&materials).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the stock materials of warehouse %d: %w", warehouseID, err)
+	}
+
+	result := make([]types.PurchaseStock, len(materials))
+	for i, material := range materials {
+		result[i] = *byMaterial[material.ID]
+		result[i].Material = material
+	}
+	return result, nil
+}
+
+func (r *purchaseOrderRepository) GetSupplierMaterialsByStockMaterials(stockMaterialIDs []uint) ([]data.SupplierMaterial, error) {
+	var supplierMaterials []data.SupplierMaterial
+	if len(stockMaterialIDs) == 0 {
+		return supplierMaterials, nil
+	}
+
+	err := r.db.
+		Preload("Supplier").
+		Preload("SupplierPrices").
+		Where("stock_material_id IN (?)", stockMaterialIDs).
+		Find(&supplierMaterials).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the suppliers of the stock materials: %w", err)
+	}
+	return supplierMaterials, nil
+}
+
+func (r *purchaseOrderRepository) preloadPurchaseOrder(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Warehouse").
+		Preload("Supplier").
+		Preload("Deliveries").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Preload("Lines.StockMaterial").
+		Preload("Lines.StockMaterial.Unit").
+		Preload("Lines.StockMaterial.StockMaterialCategory").
+		Preload("Lines.StockMaterial.Ingredient").
+		Preload("Lines.StockMaterial.Ingredient.Unit").
+		Preload("Lines.StockMaterial.Ingredient.IngredientCategory")
+}
@@ -0,0 +1,215 @@
+package purchaseOrders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	stockRequestsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/supplier"
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
+	"go.uber.org/zap"
+)
+
+type PurchaseOrderService interface {
+	CreatePurchaseOrder(warehouseID uint, dto *types.CreatePurchaseOrderDTO) (*types.PurchaseOrderDTO, error)
+	GetPurchaseOrderByID(id, warehouseID uint) (*types.PurchaseOrderDTO, error)
+	GetPurchaseOrders(filter *types.PurchaseOrdersFilter) ([]types.PurchaseOrderDTO, error)
+	UpdatePurchaseOrder(id, warehouseID uint, dto *types.UpdatePurchaseOrderDTO) (*types.PurchaseOrderDTO, error)
+	SetPurchaseOrderStatus(id, warehouseID uint, status data.PurchaseOrderStatus) (*types.PurchaseOrderDTO, error)
+	GetPurchaseSuggestions(warehouseID uint, query stockRequestsTypes.ReplenishmentQuery) (*types.PurchaseSuggestionsDTO, error)
+}
+
+type purchaseOrderService struct {
+	repo         PurchaseOrderRepository
+	supplierRepo supplier.SupplierRepository
+	logger       *zap.SugaredLogger
+}
+
+func NewPurchaseOrderService(repo PurchaseOrderRepository, supplierRepo supplier.SupplierRepository, logger *zap.SugaredLogger) PurchaseOrderService {
+	return &purchaseOrderService{
+		repo:         repo,
+		supplierRepo: supplierRepo,
+		logger:       logger,
+	}
+}
+
+func (s *purchaseOrderService) CreatePurchaseOrder(warehouseID uint, dto *types.CreatePurchaseOrderDTO) (*types.PurchaseOrderDTO, error) {
+	if _, err := s.supplierRepo.GetSupplierByID(dto.SupplierID); err != nil {
+		if errors.Is(err, supplierTypes.ErrSupplierNotFound) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreatePurchaseOrder, err))
+		return nil, types.ErrFailedToCreatePurchaseOrder
+	}
+
+	lines, total, err := s.priceLines(dto.SupplierID, dto.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &data.PurchaseOrder{
+		WarehouseID:          warehouseID,
+		SupplierID:           dto.SupplierID,
+		Status:               data.PurchaseOrderStatusDraft,
+		ExpectedDeliveryDate: dto.ExpectedDeliveryDate,
+		Total:                total,
+		Lines:                lines,
+	}
+	if dto.Comment != nil {
+		order.Comment = strings.TrimSpace(*dto.Comment)
+	}
+
+	if err := s.repo.CreatePurchaseOrder(order); err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToCreatePurchaseOrder, err))
+		return nil, types.ErrFailedToCreatePurchaseOrder
+	}
+
+	return s.GetPurchaseOrderByID(order.ID, warehouseID)
+}
+
+func (s *purchaseOrderService) GetPurchaseOrderByID(id, warehouseID uint) (*types.PurchaseOrderDTO, error) {
+	order, err := s.repo.GetPurchaseOrderByID(id, warehouseID)
+	if err != nil {
+		if !errors.Is(err, types.ErrPurchaseOrderNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	response := types.ConvertToPurchaseOrderDTO(order)
+	return &response, nil
+}
+
+func (s *purchaseOrderService) GetPurchaseOrders(filter *types.PurchaseOrdersFilter) ([]types.PurchaseOrderDTO, error) {
+	orders, err := s.repo.GetPurchaseOrders(filter)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToFetchPurchaseOrders, err))
+		return nil, types.ErrFailedToFetchPurchaseOrders
+	}
+
+	responses := make([]types.PurchaseOrderDTO, len(orders))
+	for i := range orders {
+		responses[i] = types.ConvertToPurchaseOrderDTO(&orders[i])
+	}
+	return responses, nil
+}
+
+// UpdatePurchaseOrder changes a draft order, the new lines are priced with the current supplier prices
+func (s *purchaseOrderService) UpdatePurchaseOrder(id, warehouseID uint, dto *types.UpdatePurchaseOrderDTO) (*types.PurchaseOrderDTO, error) {
+	order, err := s.repo.GetPurchaseOrderByID(id, warehouseID)
+	if err != nil {
+		if !errors.Is(err, types.ErrPurchaseOrderNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	if order.Status != data.PurchaseOrderStatusDraft {
+		return nil, types.ErrPurchaseOrderNotEditable
+	}
+
+	if dto.Comment != nil {
+		order.Comment = strings.TrimSpace(*dto.Comment)
+	}
+	if dto.ExpectedDeliveryDate != nil {
+		order.ExpectedDeliveryDate = dto.ExpectedDeliveryDate
+	}
+
+	var lines []data.PurchaseOrderLine
+	if dto.Lines != nil {
+		lines, order.Total, err = s.priceLines(order.SupplierID, dto.Lines)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateDraft(order, lines); err != nil {
+		if errors.Is(err, types.ErrPurchaseOrderNotEditable) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdatePurchaseOrder, err))
+		return nil, types.ErrFailedToUpdatePurchaseOrder
+	}
+
+	return s.GetPurchaseOrderByID(id, warehouseID)
+}
+
+// SetPurchaseOrderStatus sends, cancels or closes the order. Closing the partially received order accepts the shortage
+func (s *purchaseOrderService) SetPurchaseOrderStatus(id, warehouseID uint, status data.PurchaseOrderStatus) (*types.PurchaseOrderDTO, error) {
+	order, err := s.repo.GetPurchaseOrderByID(id, warehouseID)
+	if err != nil {
+		if !errors.Is(err, types.ErrPurchaseOrderNotFound) {
+			s.logger.Error(err)
+		}
+		return nil, err
+	}
+
+	from := order.Status
+	if err := types.ValidateStatusTransition(from, status); err != nil {
+		return nil, err
+	}
+	// the sent order is received by the deliveries, only the rest of a partially received order is closed by hand
+	if status == data.PurchaseOrderStatusReceived && from != data.PurchaseOrderStatusPartiallyReceived {
+		return nil, types.ErrInvalidStatusTransition
+	}
+
+	now := time.Now().UTC()
+	order.Status = status
+	switch status {
+	case data.PurchaseOrderStatusSent:
+		order.SentAt = &now
+	case data.PurchaseOrderStatusReceived:
+		order.ReceivedAt = &now
+	case data.PurchaseOrderStatusCancelled:
+		order.CancelledAt = &now
+	}
+
+	if err := s.repo.UpdateStatus(order, from); err != nil {
+		if errors.Is(err, types.ErrInvalidStatusTransition) {
+			return nil, err
+		}
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToUpdatePurchaseOrder, err))
+		return nil, types.ErrFailedToUpdatePurchaseOrder
+	}
+
+	return s.GetPurchaseOrderByID(id, warehouseID)
+}
+
+// GetPurchaseSuggestions averages what the warehouse sent to the stores over the history days and suggests the purchases
+// covering the coverage days on top of the safety stock, nothing is ordered until the suggested orders are created
+func (s *purchaseOrderService) GetPurchaseSuggestions(warehouseID uint, query stockRequestsTypes.ReplenishmentQuery) (*types.PurchaseSuggestionsDTO, error) {
+	query.ApplyDefaults()
+
+	stocks, err := s.repo.GetPurchaseStocks(warehouseID, query.HistoryStart(time.Now().UTC()))
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToSuggestPurchases, err))
+		return nil, types.ErrFailedToSuggestPurchases
+	}
+
+	stockMaterialIDs := make([]uint, len(stocks))
+	for i, stock := range stocks {
+		stockMaterialIDs[i] = stock.Material.ID
+	}
+
+	supplierMaterials, err := s.repo.GetSupplierMaterialsByStockMaterials(stockMaterialIDs)
+	if err != nil {
+		s.logger.Error(fmt.Errorf("%w: %w", types.ErrFailedToSuggestPurchases, err))
+		return nil, types.ErrFailedToSuggestPurchases
+	}
+
+	return types.BuildPurchaseSuggestions(query, stocks, supplierMaterials), nil
+}
+
+func (s *purchaseOrderService) priceLines(supplierID uint, lines []types.PurchaseOrderLineInput) ([]data.PurchaseOrderLine, float64, error) {
+	supplierMaterials, err := s.supplierRepo.GetMaterialsBySupplier(supplierID)
+	if err != nil {
+		s.logger.Errorf("failed to fetch the materials of supplier %d: %v", supplierID, err)
+		return nil, 0, types.ErrFailedToFetchSupplierPrices
+	}
+
+	return types.PriceLines(lines, supplierMaterials)
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils/pdf"
+)
+
+func ConvertToPurchaseOrderDTO(order *data.PurchaseOrder) PurchaseOrderDTO {
+	lines := make([]PurchaseOrderLineDTO, len(order.Lines))
+	var receivedTotal float64
+	for i, line := range order.Lines {
+		lines[i] = ConvertToPurchaseOrderLineDTO(line)
+		receivedTotal += line.ReceivedQuantity * line.UnitPrice
+	}
+
+	deliveryIDs := make([]uint, len(order.Deliveries))
+	for i, delivery := range order.Deliveries {
+		deliveryIDs[i] = delivery.ID
+	}
+
+	return PurchaseOrderDTO{
+		ID:                   order.ID,
+		WarehouseID:          order.WarehouseID,
+		WarehouseName:        order.Warehouse.Name,
+		Supplier:             supplierTypes.ToSupplierResponse(order.Supplier),
+		Status:               order.Status,
+		Comment:              order.Comment,
+		ExpectedDeliveryDate: order.ExpectedDeliveryDate,
+		Total:                order.Total,
+		ReceivedTotal:        utils.RoundToDecimal(receivedTotal, 2),
+		Lines:                lines,
+		DeliveryIDs:          deliveryIDs,
+		SentAt:               order.SentAt,
+		ReceivedAt:           order.ReceivedAt,
+		CancelledAt:          order.CancelledAt,
+		CreatedAt:            order.CreatedAt,
+		UpdatedAt:            order.UpdatedAt,
+	}
+}
+
+func ConvertToPurchaseOrderLineDTO(line data.PurchaseOrderLine) PurchaseOrderLineDTO {
+	return PurchaseOrderLineDTO{
+		ID:                    line.ID,
+		StockMaterial:         *stockMaterialTypes.ConvertStockMaterialToStockMaterialResponse(&line.StockMaterial),
+		Quantity:              line.Quantity,
+		ReceivedQuantity:      line.ReceivedQuantity,
+		OutstandingQuantity:   OutstandingQuantity(line),
+		OverDeliveredQuantity: OverDeliveredQuantity(line),
+		UnitPrice:             line.UnitPrice,
+		Total:                 utils.RoundToDecimal(line.Quantity*line.UnitPrice, 2),
+	}
+}
+
+func ToPDFPurchaseOrder(order *PurchaseOrderDTO) pdf.PDFPurchaseOrderDetails {
+	const dateLayout = "2006-01-02 15:04"
+
+	details := pdf.PDFPurchaseOrderDetails{
+		OrderID:         order.ID,
+		Status:          string(order.Status),
+		WarehouseName:   order.WarehouseName,
+		SupplierName:    order.Supplier.Name,
+		SupplierPhone:   order.Supplier.ContactPhone,
+		SupplierEmail:   order.Supplier.ContactEmail,
+		SupplierAddress: strings.TrimSpace(order.Supplier.City + " " + order.Supplier.Address),
+		CreatedAt:       order.CreatedAt.Format(dateLayout),
+		Comment:         order.Comment,
+		Lines:           make([]pdf.PDFPurchaseOrderLine, len(order.Lines)),
+		Total:           order.Total,
+	}
+	if order.SentAt != nil {
+		details.SentAt = order.SentAt.Format(dateLayout)
+	}
+	if order.ExpectedDeliveryDate != nil {
+		details.ExpectedDeliveryDate = order.ExpectedDeliveryDate.Format(time.DateOnly)
+	}
+
+	for i, line := range order.Lines {
+		details.Lines[i] = pdf.PDFPurchaseOrderLine{
+			Name:      line.StockMaterial.Name,
+			Barcode:   line.StockMaterial.Barcode,
+			Unit:      line.StockMaterial.Unit.Name,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			Total:     line.Total,
+		}
+	}
+
+	return details
+}
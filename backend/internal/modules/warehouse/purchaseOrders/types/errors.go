@@ -0,0 +1,33 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/errors/moduleErrors"
+)
+
+var (
+	ErrPurchaseOrderNotFound         = moduleErrors.NewModuleError(errors.New("purchase order not found"))
+	ErrInvalidPurchaseOrderLines     = moduleErrors.NewModuleError(errors.New("purchase order lines are empty or repeat a stock material"))
+	ErrMaterialNotSupplied           = moduleErrors.NewModuleError(errors.New("stock material is not provided by the supplier"))
+	ErrInvalidStatusTransition       = moduleErrors.NewModuleError(errors.New("purchase order can not be moved to the requested status"))
+	ErrPurchaseOrderNotEditable      = moduleErrors.NewModuleError(errors.New("only draft purchase orders can be changed"))
+	ErrPurchaseOrderNotReceivable    = moduleErrors.NewModuleError(errors.New("purchase order is not awaiting a delivery"))
+	ErrMaterialNotOrdered            = moduleErrors.NewModuleError(errors.New("delivered stock material is not ordered in the purchase order"))
+	ErrPurchaseOrderSupplierMismatch = moduleErrors.NewModuleError(errors.New("delivery supplier differs from the purchase order supplier"))
+
+	ErrFailedToCreatePurchaseOrder = moduleErrors.NewModuleError(errors.New("failed to create purchase order"))
+	ErrFailedToUpdatePurchaseOrder = moduleErrors.NewModuleError(errors.New("failed to update purchase order"))
+	ErrFailedToFetchPurchaseOrders = moduleErrors.NewModuleError(errors.New("failed to fetch purchase orders"))
+	ErrFailedToExportPurchaseOrder = moduleErrors.NewModuleError(errors.New("failed to export purchase order"))
+	ErrFailedToFetchSupplierPrices = moduleErrors.NewModuleError(errors.New("failed to fetch supplier prices"))
+	ErrFailedToSuggestPurchases    = moduleErrors.NewModuleError(errors.New("failed to suggest purchases"))
+)
+
+// IsReceiptError reports whether the delivery was rejected by the purchase order it was received against
+func IsReceiptError(err error) bool {
+	return errors.Is(err, ErrPurchaseOrderNotFound) ||
+		errors.Is(err, ErrPurchaseOrderNotReceivable) ||
+		errors.Is(err, ErrMaterialNotOrdered) ||
+		errors.Is(err, ErrPurchaseOrderSupplierMismatch)
+}
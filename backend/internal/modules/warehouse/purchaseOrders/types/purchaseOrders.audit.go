@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit/shared"
+)
+
+type PurchaseOrderPayloads struct {
+	UpdatePurchaseOrderDTO *UpdatePurchaseOrderDTO   `json:"updatePurchaseOrderDTO,omitempty"`
+	Status                 *data.PurchaseOrderStatus `json:"status,omitempty"`
+}
+
+var (
+	CreatePurchaseOrderAuditFactory = shared.NewAuditWarehouseActionExtendedFactory(
+		data.CreateOperation, data.PurchaseOrderComponent, &CreatePurchaseOrderDTO{})
+
+	UpdatePurchaseOrderAuditFactory = shared.NewAuditWarehouseActionExtendedFactory(
+		data.UpdateOperation, data.PurchaseOrderComponent, &PurchaseOrderPayloads{})
+)
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+type CreatePurchaseOrderDTO struct {
+	SupplierID           uint                     `json:"supplierId" binding:"required"`
+	Comment              *string                  `json:"comment" binding:"omitempty,max=1000"`
+	ExpectedDeliveryDate *time.Time               `json:"expectedDeliveryDate"`
+	Lines                []PurchaseOrderLineInput `json:"lines" binding:"required,min=1,dive"`
+}
+
+// UpdatePurchaseOrderDTO changes a draft order, the lines are replaced and priced again when set
+type UpdatePurchaseOrderDTO struct {
+	Comment              *string                  `json:"comment" binding:"omitempty,max=1000"`
+	ExpectedDeliveryDate *time.Time               `json:"expectedDeliveryDate"`
+	Lines                []PurchaseOrderLineInput `json:"lines" binding:"omitempty,min=1,dive"`
+}
+
+type PurchaseOrderLineInput struct {
+	StockMaterialID uint    `json:"stockMaterialId" binding:"required"`
+	Quantity        float64 `json:"quantity" binding:"required,gt=0"`
+}
+
+type PurchaseOrderDTO struct {
+	ID                   uint                           `json:"id"`
+	WarehouseID          uint                           `json:"warehouseId"`
+	WarehouseName        string                         `json:"warehouseName"`
+	Supplier             supplierTypes.SupplierResponse `json:"supplier"`
+	Status               data.PurchaseOrderStatus       `json:"status"`
+	Comment              string                         `json:"comment"`
+	ExpectedDeliveryDate *time.Time                     `json:"expectedDeliveryDate"`
+	Total                float64                        `json:"total"`
+	ReceivedTotal        float64                        `json:"receivedTotal"`
+	Lines                []PurchaseOrderLineDTO         `json:"lines"`
+	DeliveryIDs          []uint                         `json:"deliveryIds"`
+	SentAt               *time.Time                     `json:"sentAt"`
+	ReceivedAt           *time.Time                     `json:"receivedAt"`
+	CancelledAt          *time.Time                     `json:"cancelledAt"`
+	CreatedAt            time.Time                      `json:"createdAt"`
+	UpdatedAt            time.Time                      `json:"updatedAt"`
+}
+
+// PurchaseOrderLineDTO shows the shortage of the line as the outstanding quantity and the excess as the over-delivered one
+type PurchaseOrderLineDTO struct {
+	ID                    uint                                 `json:"id"`
+	StockMaterial         stockMaterialTypes.StockMaterialsDTO `json:"stockMaterial"`
+	Quantity              float64                              `json:"quantity"`
+	ReceivedQuantity      float64                              `json:"receivedQuantity"`
+	OutstandingQuantity   float64                              `json:"outstandingQuantity"`
+	OverDeliveredQuantity float64                              `json:"overDeliveredQuantity"`
+	UnitPrice             float64                              `json:"unitPrice"`
+	Total                 float64                              `json:"total"`
+}
+
+type PurchaseOrdersFilter struct {
+	utils.BaseFilter
+	WarehouseID *uint                     `form:"-"`
+	SupplierID  *uint                     `form:"supplierId"`
+	Status      *data.PurchaseOrderStatus `form:"status" binding:"omitempty,oneof=DRAFT SENT PARTIALLY_RECEIVED RECEIVED CANCELLED"`
+	Search      *string                   `form:"search"`
+}
+
+type ExportPurchaseOrderQuery struct {
+	Language string `form:"language" binding:"omitempty,oneof=kk ru en"`
+}
+
+// ReceivedMaterial is a stock material of a supplier delivery received against a purchase order
+type ReceivedMaterial struct {
+	StockMaterialID uint
+	Quantity        float64
+}
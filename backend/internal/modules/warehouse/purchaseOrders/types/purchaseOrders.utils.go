@@ -0,0 +1,136 @@
+package types
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+var purchaseOrderTransitions = map[data.PurchaseOrderStatus][]data.PurchaseOrderStatus{
+	data.PurchaseOrderStatusDraft:             {data.PurchaseOrderStatusSent, data.PurchaseOrderStatusCancelled},
+	data.PurchaseOrderStatusSent:              {data.PurchaseOrderStatusPartiallyReceived, data.PurchaseOrderStatusReceived, data.PurchaseOrderStatusCancelled},
+	data.PurchaseOrderStatusPartiallyReceived: {data.PurchaseOrderStatusPartiallyReceived, data.PurchaseOrderStatusReceived},
+}
+
+// ValidateStatusTransition checks the order may move to the status, an order is not cancelled once a part of it was received
+func ValidateStatusTransition(from, to data.PurchaseOrderStatus) error {
+	for _, status := range purchaseOrderTransitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+	return ErrInvalidStatusTransition
+}
+
+// IsAwaitingDelivery reports whether the deliveries can be received against the order
+func IsAwaitingDelivery(status data.PurchaseOrderStatus) bool {
+	return status == data.PurchaseOrderStatusSent || status == data.PurchaseOrderStatusPartiallyReceived
+}
+
+// PriceLines prices the ordered materials with the latest price of the supplier,
+// the materials the supplier does not provide are rejected
+func PriceLines(lines []PurchaseOrderLineInput, supplierMaterials []data.SupplierMaterial) ([]data.PurchaseOrderLine, float64, error) {
+	if len(lines) == 0 {
+		return nil, 0, ErrInvalidPurchaseOrderLines
+	}
+
+	prices := make(map[uint]float64, len(supplierMaterials))
+	for _, material := range supplierMaterials {
+		if price, ok := latestSupplierPrice(material.SupplierPrices); ok {
+			prices[material.StockMaterialID] = price
+		}
+	}
+
+	seen := make(map[uint]bool, len(lines))
+	result := make([]data.PurchaseOrderLine, len(lines))
+	var total float64
+	for i, line := range lines {
+		if seen[line.StockMaterialID] || line.Quantity <= 0 {
+			return nil, 0, ErrInvalidPurchaseOrderLines
+		}
+		seen[line.StockMaterialID] = true
+
+		price, ok := prices[line.StockMaterialID]
+		if !ok {
+			return nil, 0, ErrMaterialNotSupplied
+		}
+
+		result[i] = data.PurchaseOrderLine{
+			StockMaterialID: line.StockMaterialID,
+			Quantity:        line.Quantity,
+			UnitPrice:       price,
+		}
+		total += line.Quantity * price
+	}
+
+	return result, utils.RoundToDecimal(total, 2), nil
+}
+
+func latestSupplierPrice(prices []data.SupplierPrice) (float64, bool) {
+	if len(prices) == 0 {
+		return 0, false
+	}
+
+	latest := prices[0]
+	for _, price := range prices[1:] {
+		if price.ID > latest.ID {
+			latest = price
+		}
+	}
+	return latest.BasePrice, true
+}
+
+// ApplyReceipt adds the delivered quantities to the lines of the order, the order is received once every line is fully delivered.
+// A line may receive more than ordered, the materials which were not ordered are rejected
+func ApplyReceipt(order *data.PurchaseOrder, received []ReceivedMaterial, now time.Time) error {
+	if !IsAwaitingDelivery(order.Status) {
+		return ErrPurchaseOrderNotReceivable
+	}
+
+	lineIndexes := make(map[uint]int, len(order.Lines))
+	for i, line := range order.Lines {
+		lineIndexes[line.StockMaterialID] = i
+	}
+
+	for _, material := range received {
+		i, ok := lineIndexes[material.StockMaterialID]
+		if !ok {
+			return ErrMaterialNotOrdered
+		}
+		order.Lines[i].ReceivedQuantity = utils.RoundToDecimal(order.Lines[i].ReceivedQuantity+material.Quantity, 2)
+	}
+
+	order.Status = data.PurchaseOrderStatusReceived
+	for _, line := range order.Lines {
+		if OutstandingQuantity(line) > 0 {
+			order.Status = data.PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+
+	if order.Status == data.PurchaseOrderStatusReceived {
+		order.ReceivedAt = &now
+	}
+	return nil
+}
+
+// OutstandingQuantity is the ordered quantity of the line which was not delivered yet
+func OutstandingQuantity(line data.PurchaseOrderLine) float64 {
+	return max(utils.RoundToDecimal(line.Quantity-line.ReceivedQuantity, 2), 0)
+}
+
+// OverDeliveredQuantity is the quantity delivered above the ordered one
+func OverDeliveredQuantity(line data.PurchaseOrderLine) float64 {
+	return max(utils.RoundToDecimal(line.ReceivedQuantity-line.Quantity, 2), 0)
+}
+
+// LinePrice is the unit price of the ordered material, it is used for the delivered materials received without a price
+func LinePrice(order *data.PurchaseOrder, stockMaterialID uint) float64 {
+	for _, line := range order.Lines {
+		if line.StockMaterialID == stockMaterialID {
+			return line.UnitPrice
+		}
+	}
+	return 0
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceLines(t *testing.T) {
+	milk, beans, cups := uint(1), uint(2), uint(3)
+	supplierMaterials := []data.SupplierMaterial{
+		{StockMaterialID: milk, SupplierPrices: []data.SupplierPrice{
+			{BaseEntity: data.BaseEntity{ID: 2}, BasePrice: 450},
+			{BaseEntity: data.BaseEntity{ID: 1}, BasePrice: 400},
+		}},
+		{StockMaterialID: beans, SupplierPrices: []data.SupplierPrice{{BaseEntity: data.BaseEntity{ID: 3}, BasePrice: 9000}}},
+		{StockMaterialID: cups},
+	}
+
+	t.Run("Lines should be priced with the latest supplier price", func(t *testing.T) {
+		lines, total, err := PriceLines([]PurchaseOrderLineInput{
+			{StockMaterialID: milk, Quantity: 10},
+			{StockMaterialID: beans, Quantity: 2.5},
+		}, supplierMaterials)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 450.0, lines[0].UnitPrice)
+		assert.Equal(t, 9000.0, lines[1].UnitPrice)
+		assert.Equal(t, 27000.0, total)
+	})
+
+	t.Run("Material without a supplier price should be rejected", func(t *testing.T) {
+		_, _, err := PriceLines([]PurchaseOrderLineInput{{StockMaterialID: cups, Quantity: 1}}, supplierMaterials)
+		assert.ErrorIs(t, err, ErrMaterialNotSupplied)
+
+		_, _, err = PriceLines([]PurchaseOrderLineInput{{StockMaterialID: 99, Quantity: 1}}, supplierMaterials)
+		assert.ErrorIs(t, err, ErrMaterialNotSupplied)
+	})
+
+	t.Run("Repeated material should be rejected", func(t *testing.T) {
+		_, _, err := PriceLines([]PurchaseOrderLineInput{
+			{StockMaterialID: milk, Quantity: 1},
+			{StockMaterialID: milk, Quantity: 2},
+		}, supplierMaterials)
+		assert.ErrorIs(t, err, ErrInvalidPurchaseOrderLines)
+	})
+}
+
+func TestValidateStatusTransition(t *testing.T) {
+	assert.NoError(t, ValidateStatusTransition(data.PurchaseOrderStatusDraft, data.PurchaseOrderStatusSent))
+	assert.NoError(t, ValidateStatusTransition(data.PurchaseOrderStatusSent, data.PurchaseOrderStatusCancelled))
+	assert.NoError(t, ValidateStatusTransition(data.PurchaseOrderStatusPartiallyReceived, data.PurchaseOrderStatusReceived))
+
+	assert.ErrorIs(t, ValidateStatusTransition(data.PurchaseOrderStatusPartiallyReceived, data.PurchaseOrderStatusCancelled), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, ValidateStatusTransition(data.PurchaseOrderStatusReceived, data.PurchaseOrderStatusSent), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, ValidateStatusTransition(data.PurchaseOrderStatusCancelled, data.PurchaseOrderStatusSent), ErrInvalidStatusTransition)
+}
+
+func TestApplyReceipt(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	milk, beans := uint(1), uint(2)
+
+	newOrder := func() *data.PurchaseOrder {
+		return &data.PurchaseOrder{
+			Status: data.PurchaseOrderStatusSent,
+			Lines: []data.PurchaseOrderLine{
+				{StockMaterialID: milk, Quantity: 10, UnitPrice: 450},
+				{StockMaterialID: beans, Quantity: 2, UnitPrice: 9000},
+			},
+		}
+	}
+
+	t.Run("Short delivery should leave the order partially received", func(t *testing.T) {
+		order := newOrder()
+		err := ApplyReceipt(order, []ReceivedMaterial{{StockMaterialID: milk, Quantity: 6}}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, data.PurchaseOrderStatusPartiallyReceived, order.Status)
+		assert.Nil(t, order.ReceivedAt)
+		assert.Equal(t, 4.0, OutstandingQuantity(order.Lines[0]))
+		assert.Equal(t, 2.0, OutstandingQuantity(order.Lines[1]))
+	})
+
+	t.Run("Order should be received once every line is delivered, the excess is tracked", func(t *testing.T) {
+		order := newOrder()
+		assert.NoError(t, ApplyReceipt(order, []ReceivedMaterial{{StockMaterialID: milk, Quantity: 6.1}}, now))
+		assert.NoError(t, ApplyReceipt(order, []ReceivedMaterial{
+			{StockMaterialID: milk, Quantity: 4.2},
+			{StockMaterialID: beans, Quantity: 2},
+		}, now))
+
+		assert.Equal(t, data.PurchaseOrderStatusReceived, order.Status)
+		assert.Equal(t, &now, order.ReceivedAt)
+		assert.Equal(t, 0.3, OverDeliveredQuantity(order.Lines[0]))
+		assert.Equal(t, 0.0, OutstandingQuantity(order.Lines[0]))
+		assert.Equal(t, 0.0, OverDeliveredQuantity(order.Lines[1]))
+	})
+
+	t.Run("Material which was not ordered should be rejected", func(t *testing.T) {
+		err := ApplyReceipt(newOrder(), []ReceivedMaterial{{StockMaterialID: 99, Quantity: 1}}, now)
+		assert.ErrorIs(t, err, ErrMaterialNotOrdered)
+	})
+
+	t.Run("Order which is not awaiting a delivery should be rejected", func(t *testing.T) {
+		order := newOrder()
+		order.Status = data.PurchaseOrderStatusDraft
+		err := ApplyReceipt(order, []ReceivedMaterial{{StockMaterialID: milk, Quantity: 1}}, now)
+		assert.ErrorIs(t, err, ErrPurchaseOrderNotReceivable)
+	})
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+)
+
+// PurchaseSuggestionsDTO groups the suggested purchases by the supplier with the lowest price,
+// nothing is ordered until the reviewed purchase order of a supplier is created as a draft
+type PurchaseSuggestionsDTO struct {
+	CoverageDays int                             `json:"coverageDays"`
+	HistoryDays  int                             `json:"historyDays"`
+	Suppliers    []SupplierPurchaseSuggestionDTO `json:"suppliers"`
+	Unsupplied   []PurchaseSuggestionLineDTO     `json:"unsupplied"`
+}
+
+type SupplierPurchaseSuggestionDTO struct {
+	Supplier      supplierTypes.SupplierResponse `json:"supplier"`
+	Lines         []PurchaseSuggestionLineDTO    `json:"lines"`
+	Total         float64                        `json:"total"`
+	PurchaseOrder CreatePurchaseOrderDTO         `json:"purchaseOrder"`
+}
+
+// PurchaseSuggestionLineDTO keeps every quantity in packages of the stock material,
+// the ordered quantity is still expected from the open purchase orders
+type PurchaseSuggestionLineDTO struct {
+	StockMaterial           stockMaterialTypes.StockMaterialsDTO `json:"stockMaterial"`
+	Quantity                float64                              `json:"quantity"`
+	OrderedQuantity         float64                              `json:"orderedQuantity"`
+	SafetyStock             float64                              `json:"safetyStock"`
+	AverageDailyConsumption float64                              `json:"averageDailyConsumption"`
+	TargetQuantity          float64                              `json:"targetQuantity"`
+	SuggestedQuantity       float64                              `json:"suggestedQuantity"`
+	UnitPrice               float64                              `json:"unitPrice"`
+	Total                   float64                              `json:"total"`
+}
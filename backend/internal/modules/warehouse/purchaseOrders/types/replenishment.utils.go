@@ -0,0 +1,110 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	stockRequestsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
+	supplierTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/supplier/types"
+	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// PurchaseStock is the stock material of the warehouse the suggestion starts from. The quantities are in packages,
+// the outflow is the quantity sent to the stores over the history days
+type PurchaseStock struct {
+	Material        data.StockMaterial
+	Quantity        float64
+	OrderedQuantity float64
+	Outflow         float64
+}
+
+type supplierOffer struct {
+	supplierID uint
+	supplier   data.Supplier
+	price      float64
+}
+
+// BuildPurchaseSuggestions suggests the stock materials falling short of the target after the open purchase orders are received,
+// every material is bought from the supplier with the lowest latest price
+func BuildPurchaseSuggestions(query stockRequestsTypes.ReplenishmentQuery, stocks []PurchaseStock, supplierMaterials []data.SupplierMaterial) *PurchaseSuggestionsDTO {
+	offers := cheapestOffers(supplierMaterials)
+
+	result := &PurchaseSuggestionsDTO{
+		CoverageDays: query.CoverageDays,
+		HistoryDays:  query.HistoryDays,
+		Suppliers:    []SupplierPurchaseSuggestionDTO{},
+		Unsupplied:   []PurchaseSuggestionLineDTO{},
+	}
+	groups := make(map[uint]*SupplierPurchaseSuggestionDTO)
+
+	for i := range stocks {
+		stock := &stocks[i]
+		averageDaily := stockRequestsTypes.AverageDailyConsumption(stock.Outflow, query.HistoryDays)
+		target := stockRequestsTypes.ReplenishmentTarget(averageDaily, query.CoverageDays, stock.Material.SafetyStock)
+		suggested := stockRequestsTypes.PackagesToCover(target-stock.Quantity-stock.OrderedQuantity, 1)
+		if suggested <= 0 {
+			continue
+		}
+
+		line := PurchaseSuggestionLineDTO{
+			StockMaterial:           *stockMaterialTypes.ConvertStockMaterialToStockMaterialResponse(&stock.Material),
+			Quantity:                stock.Quantity,
+			OrderedQuantity:         stock.OrderedQuantity,
+			SafetyStock:             stock.Material.SafetyStock,
+			AverageDailyConsumption: utils.RoundToDecimal(averageDaily, 2),
+			TargetQuantity:          utils.RoundToDecimal(target, 2),
+			SuggestedQuantity:       suggested,
+		}
+
+		offer, ok := offers[stock.Material.ID]
+		if !ok {
+			result.Unsupplied = append(result.Unsupplied, line)
+			continue
+		}
+
+		line.UnitPrice = offer.price
+		line.Total = utils.RoundToDecimal(suggested*offer.price, 2)
+
+		group, ok := groups[offer.supplierID]
+		if !ok {
+			group = &SupplierPurchaseSuggestionDTO{
+				Supplier:      supplierTypes.ToSupplierResponse(offer.supplier),
+				PurchaseOrder: CreatePurchaseOrderDTO{SupplierID: offer.supplierID},
+			}
+			groups[offer.supplierID] = group
+		}
+		group.Lines = append(group.Lines, line)
+		group.Total = utils.RoundToDecimal(group.Total+line.Total, 2)
+		group.PurchaseOrder.Lines = append(group.PurchaseOrder.Lines, PurchaseOrderLineInput{
+			StockMaterialID: stock.Material.ID,
+			Quantity:        suggested,
+		})
+	}
+
+	for _, group := range groups {
+		result.Suppliers = append(result.Suppliers, *group)
+	}
+	sort.Slice(result.Suppliers, func(i, j int) bool {
+		return result.Suppliers[i].PurchaseOrder.SupplierID < result.Suppliers[j].PurchaseOrder.SupplierID
+	})
+
+	return result
+}
+
+// cheapestOffers picks the supplier with the lowest latest price for every stock material, the earlier supplier wins a tie
+func cheapestOffers(supplierMaterials []data.SupplierMaterial) map[uint]supplierOffer {
+	offers := make(map[uint]supplierOffer)
+	for _, material := range supplierMaterials {
+		price, ok := latestSupplierPrice(material.SupplierPrices)
+		if !ok {
+			continue
+		}
+
+		current, exists := offers[material.StockMaterialID]
+		if !exists || price < current.price || (price == current.price && material.SupplierID < current.supplierID) {
+			offers[material.StockMaterialID] = supplierOffer{supplierID: material.SupplierID, supplier: material.Supplier, price: price}
+		}
+	}
+	return offers
+}
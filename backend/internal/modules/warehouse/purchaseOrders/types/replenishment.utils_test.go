@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	stockRequestsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPurchaseSuggestions(t *testing.T) {
+	milk, beans, cups, lids := uint(1), uint(2), uint(3), uint(4)
+	stocks := []PurchaseStock{
+		{Material: data.StockMaterial{BaseEntity: data.BaseEntity{ID: milk}, SafetyStock: 10}, Quantity: 5, OrderedQuantity: 3, Outflow: 28},
+		{Material: data.StockMaterial{BaseEntity: data.BaseEntity{ID: beans}, SafetyStock: 2}, Quantity: 40, Outflow: 14},
+		{Material: data.StockMaterial{BaseEntity: data.BaseEntity{ID: cups}, SafetyStock: 1.5}, Quantity: 0},
+		{Material: data.StockMaterial{BaseEntity: data.BaseEntity{ID: lids}, SafetyStock: 1}, Quantity: 0},
+	}
+	supplierMaterials := []data.SupplierMaterial{
+		{StockMaterialID: milk, SupplierID: 2, Supplier: data.Supplier{BaseEntity: data.BaseEntity{ID: 2}}, SupplierPrices: []data.SupplierPrice{{BaseEntity: data.BaseEntity{ID: 1}, BasePrice: 400}}},
+		{StockMaterialID: milk, SupplierID: 1, Supplier: data.Supplier{BaseEntity: data.BaseEntity{ID: 1}}, SupplierPrices: []data.SupplierPrice{
+			{BaseEntity: data.BaseEntity{ID: 2}, BasePrice: 450},
+			{BaseEntity: data.BaseEntity{ID: 3}, BasePrice: 380},
+		}},
+		{StockMaterialID: cups, SupplierID: 2, Supplier: data.Supplier{BaseEntity: data.BaseEntity{ID: 2}}, SupplierPrices: []data.SupplierPrice{{BaseEntity: data.BaseEntity{ID: 4}, BasePrice: 10}}},
+	}
+
+	result := BuildPurchaseSuggestions(stockRequestsTypes.ReplenishmentQuery{CoverageDays: 7, HistoryDays: 14}, stocks, supplierMaterials)
+
+	t.Run("Materials should be bought from the supplier with the lowest latest price", func(t *testing.T) {
+		assert.Len(t, result.Suppliers, 2)
+		assert.Equal(t, uint(1), result.Suppliers[0].PurchaseOrder.SupplierID)
+		assert.Equal(t, []PurchaseOrderLineInput{{StockMaterialID: milk, Quantity: 16}}, result.Suppliers[0].PurchaseOrder.Lines)
+		assert.Equal(t, 6080.0, result.Suppliers[0].Total)
+
+		assert.Equal(t, uint(2), result.Suppliers[1].PurchaseOrder.SupplierID)
+		assert.Equal(t, []PurchaseOrderLineInput{{StockMaterialID: cups, Quantity: 2}}, result.Suppliers[1].PurchaseOrder.Lines)
+	})
+
+	t.Run("Ordered quantity should be counted as available", func(t *testing.T) {
+		line := result.Suppliers[0].Lines[0]
+		assert.Equal(t, 2.0, line.AverageDailyConsumption)
+		assert.Equal(t, 24.0, line.TargetQuantity)
+		assert.Equal(t, 16.0, line.SuggestedQuantity)
+	})
+
+	t.Run("Material without a supplier price should be left unsupplied", func(t *testing.T) {
+		assert.Len(t, result.Unsupplied, 1)
+		assert.Equal(t, lids, result.Unsupplied[0].StockMaterial.ID)
+	})
+}
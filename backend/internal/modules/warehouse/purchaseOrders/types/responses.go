@@ -0,0 +1,30 @@
+package types
+
+import (
+	"net/http"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/localization"
+)
+
+var (
+	Response500PurchaseOrderCreate  = localization.NewResponseKey(http.StatusInternalServerError, data.PurchaseOrderComponent, data.CreateOperation.ToString())
+	Response500PurchaseOrderGet     = localization.NewResponseKey(http.StatusInternalServerError, data.PurchaseOrderComponent, data.GetOperation.ToString())
+	Response500PurchaseOrderUpdate  = localization.NewResponseKey(http.StatusInternalServerError, data.PurchaseOrderComponent, data.UpdateOperation.ToString())
+	Response500PurchaseOrderExport  = localization.NewResponseKey(http.StatusInternalServerError, data.PurchaseOrderComponent, "EXPORT")
+	Response500PurchaseOrderSuggest = localization.NewResponseKey(http.StatusInternalServerError, data.PurchaseOrderComponent, "SUGGEST")
+
+	Response400PurchaseOrder                 = localization.NewResponseKey(http.StatusBadRequest, data.PurchaseOrderComponent)
+	Response400PurchaseOrderLines            = localization.NewResponseKey(http.StatusBadRequest, data.PurchaseOrderComponent, "LINES")
+	Response400PurchaseOrderMaterialSupplied = localization.NewResponseKey(http.StatusBadRequest, data.PurchaseOrderComponent, "MATERIAL_NOT_SUPPLIED")
+	Response400PurchaseOrderMaterialOrdered  = localization.NewResponseKey(http.StatusBadRequest, data.PurchaseOrderComponent, "MATERIAL_NOT_ORDERED")
+	Response400PurchaseOrderSupplier         = localization.NewResponseKey(http.StatusBadRequest, data.PurchaseOrderComponent, "SUPPLIER_MISMATCH")
+	Response404PurchaseOrder                 = localization.NewResponseKey(http.StatusNotFound, data.PurchaseOrderComponent)
+	Response404PurchaseOrderSupplier         = localization.NewResponseKey(http.StatusNotFound, data.PurchaseOrderComponent, "SUPPLIER")
+	Response409PurchaseOrderStatus           = localization.NewResponseKey(http.StatusConflict, data.PurchaseOrderComponent, "STATUS")
+
+	Response200PurchaseOrderUpdate = localization.NewResponseKey(http.StatusOK, data.PurchaseOrderComponent, data.UpdateOperation.ToString())
+	Response200PurchaseOrderSend   = localization.NewResponseKey(http.StatusOK, data.PurchaseOrderComponent, "SEND")
+	Response200PurchaseOrderCancel = localization.NewResponseKey(http.StatusOK, data.PurchaseOrderComponent, "CANCEL")
+	Response200PurchaseOrderClose  = localization.NewResponseKey(http.StatusOK, data.PurchaseOrderComponent, "CLOSE")
+)
@@ -0,0 +1,78 @@
+package warehouseStock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders"
+	purchaseOrderTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
+	"gorm.io/gorm"
+)
+
+type TransactionManager interface {
+	ReceivePurchaseOrderDelivery(delivery *data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, employeeID *uint) error
+}
+
+type transactionManager struct {
+	db                *gorm.DB
+	repo              WarehouseStockRepository
+	purchaseOrderRepo purchaseOrders.PurchaseOrderRepository
+}
+
+func NewTransactionManager(
+	db *gorm.DB,
+	repo WarehouseStockRepository,
+	purchaseOrderRepo purchaseOrders.PurchaseOrderRepository,
+) TransactionManager {
+	return &transactionManager{
+		db:                db,
+		repo:              repo,
+		purchaseOrderRepo: purchaseOrderRepo,
+	}
+}
+
+// ReceivePurchaseOrderDelivery records the delivery together with the received quantities of the purchase order it fulfils.
+// The materials delivered without a price are priced as ordered
+func (m *transactionManager) ReceivePurchaseOrderDelivery(delivery *data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, employeeID *uint) error {
+	if delivery.PurchaseOrderID == nil {
+		return fmt.Errorf("delivery is not linked to a purchase order")
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		purchaseOrderRepoTx := m.purchaseOrderRepo.CloneWithTransaction(tx)
+
+		order, err := purchaseOrderRepoTx.GetPurchaseOrderForUpdate(*delivery.PurchaseOrderID, delivery.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		if delivery.SupplierID == 0 {
+			delivery.SupplierID = order.SupplierID
+		}
+		if delivery.SupplierID != order.SupplierID {
+			return purchaseOrderTypes.ErrPurchaseOrderSupplierMismatch
+		}
+
+		received := make([]purchaseOrderTypes.ReceivedMaterial, len(materials))
+		for i := range materials {
+			if materials[i].Price <= 0 {
+				materials[i].Price = purchaseOrderTypes.LinePrice(order, materials[i].StockMaterialID)
+			}
+			received[i] = purchaseOrderTypes.ReceivedMaterial{
+				StockMaterialID: materials[i].StockMaterialID,
+				Quantity:        materials[i].Quantity,
+			}
+		}
+
+		if err := purchaseOrderTypes.ApplyReceipt(order, received, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		if err := purchaseOrderRepoTx.SaveReceipt(order); err != nil {
+			return err
+		}
+
+		return m.repo.CloneWithTransaction(tx).RecordDeliveriesAndUpdateStock(*delivery, materials, delivery.WarehouseID, employeeID)
+	})
+}
@@ -23,11 +23,12 @@ func DeliveriesToDeliveryResponses(deliveries []data.SupplierWarehouseDelivery)
 		}
 
 		response[i] = WarehouseDeliveryDTO{
-			ID:           delivery.ID,
-			Supplier:     supplierTypes.ToSupplierResponse(delivery.Supplier),
-			Warehouse:    *warehouseTypes.ToWarehouseDTO(delivery.Warehouse),
-			Materials:    materials,
-			DeliveryDate: delivery.DeliveryDate,
+			ID:              delivery.ID,
+			Supplier:        supplierTypes.ToSupplierResponse(delivery.Supplier),
+			Warehouse:       *warehouseTypes.ToWarehouseDTO(delivery.Warehouse),
+			Materials:       materials,
+			DeliveryDate:    delivery.DeliveryDate,
+			PurchaseOrderID: delivery.PurchaseOrderID,
 		}
 	}
 	return response
@@ -47,11 +48,12 @@ func ToDeliveryResponse(delivery data.SupplierWarehouseDelivery) WarehouseDelive
 	}
 
 	return WarehouseDeliveryDTO{
-		ID:           delivery.ID,
-		Supplier:     supplierTypes.ToSupplierResponse(delivery.Supplier),
-		Warehouse:    *warehouseTypes.ToWarehouseDTO(delivery.Warehouse),
-		Materials:    materials,
-		DeliveryDate: delivery.DeliveryDate,
+		ID:              delivery.ID,
+		Supplier:        supplierTypes.ToSupplierResponse(delivery.Supplier),
+		Warehouse:       *warehouseTypes.ToWarehouseDTO(delivery.Warehouse),
+		Materials:       materials,
+		DeliveryDate:    delivery.DeliveryDate,
+		PurchaseOrderID: delivery.PurchaseOrderID,
 	}
 }
 
@@ -62,5 +64,26 @@ func ToWarehouseStockResponse(stock data.AggregatedWarehouseStock) WarehouseStoc
 		},
 		Quantity:               stock.TotalQuantity,
 		EarliestExpirationDate: stock.EarliestExpirationDate,
+		Lots:                   ToWarehouseStockLotDTOs(stock.Lots),
 	}
 }
+
+func ToWarehouseStockLotDTOs(lots []data.WarehouseStockLot) []WarehouseStockLotDTO {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	dtos := make([]WarehouseStockLotDTO, len(lots))
+	for i, lot := range lots {
+		dtos[i] = WarehouseStockLotDTO{
+			ID:                 lot.ID,
+			DeliveryMaterialID: lot.DeliveryMaterialID,
+			Barcode:            lot.Barcode,
+			ExpirationDate:     lot.ExpirationDate,
+			ReceivedQuantity:   lot.ReceivedQuantity,
+			Quantity:           lot.Quantity,
+			CreatedAt:          lot.CreatedAt,
+		}
+	}
+	return dtos
+}
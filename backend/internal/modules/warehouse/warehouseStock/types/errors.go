@@ -18,6 +18,7 @@ var (
 	// Stock material update errors
 	ErrAddWarehouseStockMaterial = moduleErrors.NewModuleError(errors.New("failed to add warehouse stock material"))
 	ErrDeductFromStock           = moduleErrors.NewModuleError(errors.New("failed to deduct from stock"))
+	ErrInsufficientStock         = moduleErrors.NewModuleError(errors.New("insufficient warehouse stock"))
 	ErrEmptyStocks               = moduleErrors.NewModuleError(errors.New("stocks cannot be empty"))
 	ErrUpdateStock               = moduleErrors.NewModuleError(errors.New("failed to update warehouse stock"))
 	ErrUpdateStockQuantity       = moduleErrors.NewModuleError(errors.New("failed to update warehouse stock quantity"))
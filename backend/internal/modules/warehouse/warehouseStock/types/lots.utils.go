@@ -0,0 +1,109 @@
+package types
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
+)
+
+// LotAllocation is the quantity picked from the lot
+type LotAllocation struct {
+	LotID          uint
+	Quantity       float64
+	ExpirationDate time.Time
+}
+
+// SortLotsFEFO orders the lots first-expired-first-out, the lot received earlier goes first within the same expiration
+func SortLotsFEFO(lots []data.WarehouseStockLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		if !lots[i].ExpirationDate.Equal(lots[j].ExpirationDate) {
+			return lots[i].ExpirationDate.Before(lots[j].ExpirationDate)
+		}
+		return lots[i].ID < lots[j].ID
+	})
+}
+
+// AllocateFEFO picks the quantity from the lots expiring first. The part the lots do not cover is returned as the shortage,
+// it is the stock which was kept before the lots were tracked
+func AllocateFEFO(lots []data.WarehouseStockLot, quantity float64) ([]LotAllocation, float64) {
+	sorted := make([]data.WarehouseStockLot, len(lots))
+	copy(sorted, lots)
+	SortLotsFEFO(sorted)
+
+	var allocations []LotAllocation
+	remaining := utils.RoundToDecimal(quantity, 2)
+	for _, lot := range sorted {
+		if remaining <= 0 {
+			break
+		}
+		if lot.Quantity <= 0 {
+			continue
+		}
+
+		picked := min(lot.Quantity, remaining)
+		allocations = append(allocations, LotAllocation{
+			LotID:          lot.ID,
+			Quantity:       picked,
+			ExpirationDate: lot.ExpirationDate,
+		})
+		remaining = utils.RoundToDecimal(remaining-picked, 2)
+	}
+
+	return allocations, max(remaining, 0)
+}
+
+// CalculateUntrackedQuantity returns the part of the stock quantity no lot accounts for,
+// it is the stock which was kept before the lots were tracked
+func CalculateUntrackedQuantity(stockQuantity float64, lots []data.WarehouseStockLot) float64 {
+	tracked := 0.0
+	for _, lot := range lots {
+		tracked += max(lot.Quantity, 0)
+	}
+	return utils.RoundToDecimal(max(stockQuantity-tracked, 0), 2)
+}
+
+// ReleaseLots takes the quantity back from the picked lots starting from the one expiring last,
+// so the material kept by the store is still the one expiring first
+func ReleaseLots(lots []data.StockRequestIngredientLot, quantity float64) (released, kept []data.StockRequestIngredientLot) {
+	sorted := make([]data.StockRequestIngredientLot, len(lots))
+	copy(sorted, lots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ExpirationDate.After(sorted[j].ExpirationDate)
+	})
+
+	remaining := utils.RoundToDecimal(quantity, 2)
+	for _, lot := range sorted {
+		if remaining <= 0 {
+			kept = append(kept, lot)
+			continue
+		}
+
+		taken := min(lot.Quantity, remaining)
+		remaining = utils.RoundToDecimal(remaining-taken, 2)
+
+		releasedLot := lot
+		releasedLot.Quantity = taken
+		released = append(released, releasedLot)
+
+		if rest := utils.RoundToDecimal(lot.Quantity-taken, 2); rest > 0 {
+			keptLot := lot
+			keptLot.Quantity = rest
+			kept = append(kept, keptLot)
+		}
+	}
+
+	return released, kept
+}
+
+// EarliestLotExpiration is the expiration of the material picked from the lots, nil when it was not picked from a lot
+func EarliestLotExpiration(lots []data.StockRequestIngredientLot) *time.Time {
+	var earliest *time.Time
+	for i := range lots {
+		if earliest == nil || lots[i].ExpirationDate.Before(*earliest) {
+			earliest = &lots[i].ExpirationDate
+		}
+	}
+	return earliest
+}
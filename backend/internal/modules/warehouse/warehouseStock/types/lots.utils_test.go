@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateFEFO(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	lots := []data.WarehouseStockLot{
+		{BaseEntity: data.BaseEntity{ID: 1}, Quantity: 5, ExpirationDate: now.AddDate(0, 0, 10)},
+		{BaseEntity: data.BaseEntity{ID: 2}, Quantity: 3, ExpirationDate: now.AddDate(0, 0, 2)},
+		{BaseEntity: data.BaseEntity{ID: 3}, Quantity: 0, ExpirationDate: now.AddDate(0, 0, 1)},
+		{BaseEntity: data.BaseEntity{ID: 4}, Quantity: 4, ExpirationDate: now.AddDate(0, 0, 2)},
+	}
+
+	t.Run("Lots expiring first should be picked first", func(t *testing.T) {
+		allocations, shortage := AllocateFEFO(lots, 8)
+		assert.Equal(t, []LotAllocation{
+			{LotID: 2, Quantity: 3, ExpirationDate: now.AddDate(0, 0, 2)},
+			{LotID: 4, Quantity: 4, ExpirationDate: now.AddDate(0, 0, 2)},
+			{LotID: 1, Quantity: 1, ExpirationDate: now.AddDate(0, 0, 10)},
+		}, allocations)
+		assert.Equal(t, 0.0, shortage)
+	})
+
+	t.Run("Quantity over the lots should be returned as the shortage", func(t *testing.T) {
+		allocations, shortage := AllocateFEFO(lots, 15)
+		assert.Len(t, allocations, 3)
+		assert.Equal(t, 3.0, shortage)
+	})
+
+	t.Run("Given lots should keep their order", func(t *testing.T) {
+		assert.Equal(t, uint(1), lots[0].ID)
+	})
+}
+
+func TestCalculateUntrackedQuantity(t *testing.T) {
+	lots := []data.WarehouseStockLot{
+		{BaseEntity: data.BaseEntity{ID: 1}, Quantity: 5},
+		{BaseEntity: data.BaseEntity{ID: 2}, Quantity: 2.5},
+	}
+
+	t.Run("Stock over the lots should be untracked", func(t *testing.T) {
+		assert.Equal(t, 2.5, CalculateUntrackedQuantity(10, lots))
+	})
+
+	t.Run("Stock covered by the lots should have nothing untracked", func(t *testing.T) {
+		assert.Equal(t, 0.0, CalculateUntrackedQuantity(7.5, lots))
+		assert.Equal(t, 0.0, CalculateUntrackedQuantity(6, lots))
+	})
+}
+
+func TestReleaseLots(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	lots := []data.StockRequestIngredientLot{
+		{LotID: 1, Quantity: 3, ExpirationDate: now.AddDate(0, 0, 2)},
+		{LotID: 2, Quantity: 2, ExpirationDate: now.AddDate(0, 0, 10)},
+	}
+
+	released, kept := ReleaseLots(lots, 3)
+
+	assert.Equal(t, []data.StockRequestIngredientLot{
+		{LotID: 2, Quantity: 2, ExpirationDate: now.AddDate(0, 0, 10)},
+		{LotID: 1, Quantity: 1, ExpirationDate: now.AddDate(0, 0, 2)},
+	}, released)
+	assert.Equal(t, []data.StockRequestIngredientLot{
+		{LotID: 1, Quantity: 2, ExpirationDate: now.AddDate(0, 0, 2)},
+	}, kept)
+}
+
+func TestEarliestLotExpiration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, EarliestLotExpiration(nil))
+
+	earliest := EarliestLotExpiration([]data.StockRequestIngredientLot{
+		{LotID: 1, ExpirationDate: now.AddDate(0, 0, 5)},
+		{LotID: 2, ExpirationDate: now.AddDate(0, 0, 1)},
+	})
+	assert.Equal(t, now.AddDate(0, 0, 1), *earliest)
+}
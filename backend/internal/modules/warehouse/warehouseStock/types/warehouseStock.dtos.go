@@ -11,8 +11,9 @@ import (
 )
 
 type ReceiveWarehouseDelivery struct {
-	SupplierID uint                            `json:"supplierId"`
-	Materials  []ReceiveWarehouseStockMaterial `json:"materials"`
+	SupplierID      uint                            `json:"supplierId"`
+	PurchaseOrderID *uint                           `json:"purchaseOrderId"` // the purchase order fulfilled by the delivery, the supplier may be omitted then
+	Materials       []ReceiveWarehouseStockMaterial `json:"materials"`
 }
 
 type ReceiveWarehouseStockMaterial struct {
@@ -22,11 +23,12 @@ type ReceiveWarehouseStockMaterial struct {
 }
 
 type WarehouseDeliveryDTO struct {
-	ID           uint                                `json:"id"`
-	Supplier     supplierTypes.SupplierResponse      `json:"supplier"`
-	Warehouse    warehouseTypes.WarehouseDTO         `json:"warehouse"`
-	Materials    []WarehouseDeliveryStockMaterialDTO `json:"materials"`
-	DeliveryDate time.Time                           `json:"deliveryDate"`
+	ID              uint                                `json:"id"`
+	Supplier        supplierTypes.SupplierResponse      `json:"supplier"`
+	Warehouse       warehouseTypes.WarehouseDTO         `json:"warehouse"`
+	Materials       []WarehouseDeliveryStockMaterialDTO `json:"materials"`
+	DeliveryDate    time.Time                           `json:"deliveryDate"`
+	PurchaseOrderID *uint                               `json:"purchaseOrderId"`
 }
 
 type WarehouseDeliveryStockMaterialDTO struct {
@@ -61,6 +63,7 @@ type GetWarehouseStockFilterQuery struct {
 type UpdateWarehouseStockDTO struct {
 	Quantity       *float64   `json:"quantity" binding:"omitempty,gte=0"`
 	ExpirationDate *time.Time `json:"expirationDate" binding:"omitempty"`
+	LotID          *uint      `json:"lotId" binding:"required_with=ExpirationDate"` // the lot the expiration date is corrected for
 }
 
 type AddWarehouseStockMaterial struct {
@@ -75,9 +78,21 @@ type AdjustWarehouseStock struct {
 }
 
 type WarehouseStockResponse struct {
-	StockMaterial          StockMaterialResponse `json:"stockMaterial"`
-	Quantity               float64               `json:"quantity"`
-	EarliestExpirationDate *time.Time            `json:"earliestExpirationDate,omitempty"`
+	StockMaterial          StockMaterialResponse  `json:"stockMaterial"`
+	Quantity               float64                `json:"quantity"`
+	EarliestExpirationDate *time.Time             `json:"earliestExpirationDate,omitempty"`
+	Lots                   []WarehouseStockLotDTO `json:"lots,omitempty"`
+}
+
+// WarehouseStockLotDTO is a lot still holding stock, the lots are listed in the order they are picked
+type WarehouseStockLotDTO struct {
+	ID                 uint      `json:"id"`
+	DeliveryMaterialID *uint     `json:"deliveryMaterialId"`
+	Barcode            string    `json:"barcode"`
+	ExpirationDate     time.Time `json:"expirationDate"`
+	ReceivedQuantity   float64   `json:"receivedQuantity"`
+	Quantity           float64   `json:"quantity"`
+	CreatedAt          time.Time `json:"createdAt"`
 }
 
 type StockMaterialResponse struct {
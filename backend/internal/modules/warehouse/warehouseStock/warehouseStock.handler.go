@@ -1,6 +1,7 @@
 package warehouseStock
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
@@ -8,6 +9,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse"
+	purchaseOrderTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock/types"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/localization"
@@ -56,8 +58,25 @@ func (h *WarehouseStockHandler) ReceiveInventory(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.ReceiveInventory(warehouseID, req); err != nil {
-		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockReceive)
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.ReceiveInventory(warehouseID, employeeID, req); err != nil {
+		switch {
+		case errors.Is(err, purchaseOrderTypes.ErrPurchaseOrderNotFound):
+			localization.SendLocalizedResponseWithKey(c, purchaseOrderTypes.Response404PurchaseOrder)
+		case errors.Is(err, purchaseOrderTypes.ErrPurchaseOrderNotReceivable):
+			localization.SendLocalizedResponseWithKey(c, purchaseOrderTypes.Response409PurchaseOrderStatus)
+		case errors.Is(err, purchaseOrderTypes.ErrMaterialNotOrdered):
+			localization.SendLocalizedResponseWithKey(c, purchaseOrderTypes.Response400PurchaseOrderMaterialOrdered)
+		case errors.Is(err, purchaseOrderTypes.ErrPurchaseOrderSupplierMismatch):
+			localization.SendLocalizedResponseWithKey(c, purchaseOrderTypes.Response400PurchaseOrderSupplier)
+		default:
+			localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockReceive)
+		}
 		return
 	}
 
@@ -111,7 +130,13 @@ func (h *WarehouseStockHandler) AddToStock(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddWarehouseStockMaterial(req); err != nil {
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.AddWarehouseStockMaterial(employeeID, req); err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockAddMaterial)
 		return
 	}
@@ -126,7 +151,13 @@ func (h *WarehouseStockHandler) DeductFromStock(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeductFromStock(req); err != nil {
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeductFromStock(employeeID, req); err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockDeductStock)
 		return
 	}
@@ -220,7 +251,13 @@ func (h *WarehouseStockHandler) UpdateStock(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.UpdateStock(warehouseID, stockMaterialID, dto); err != nil {
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.UpdateStock(warehouseID, stockMaterialID, employeeID, dto); err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockUpdate)
 		return
 	}
@@ -242,7 +279,13 @@ func (h *WarehouseStockHandler) AddWarehouseStocks(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddWarehouseStocks(warehouseID, req); err != nil {
+	employeeID, err := contexts.GetEmployeeIDFromCtx(c)
+	if err != nil {
+		localization.SendLocalizedResponseWithStatus(c, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.AddWarehouseStocks(warehouseID, employeeID, req); err != nil {
 		localization.SendLocalizedResponseWithKey(c, types.Response500WarehouseStockAddStocks)
 		return
 	}
@@ -9,50 +9,78 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware/contexts"
 
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
+	stockMovementsTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock/types"
 	"github.com/Global-Optima/zeep-web/backend/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WarehouseStockRepository interface {
-	RecordDeliveriesAndUpdateStock(delivery data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, warehouseID uint) error
+	CloneWithTransaction(tx *gorm.DB) WarehouseStockRepository
+
+	RecordDeliveriesAndUpdateStock(delivery data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, warehouseID uint, employeeID *uint) error
 
 	GetDeliveryByID(deliveryID uint, delivery *data.SupplierWarehouseDelivery) error
 	GetDeliveries(filter types.WarehouseDeliveryFilter) ([]data.SupplierWarehouseDelivery, error)
 
 	ConvertInventoryItemsToStockRequest(items []types.ReceiveWarehouseStockMaterial) ([]data.StockRequestIngredient, error)
 
-	AddToWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64) error
-	DeductFromWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64) (*data.WarehouseStock, error)
+	AddToWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) error
+	DeductFromWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) (*data.WarehouseStock, error)
 	GetWarehouseStock(filter *types.GetWarehouseStockFilterQuery) ([]data.AggregatedWarehouseStock, error)
 	GetWarehouseStockMaterialDetails(stockMaterialID uint, filter *contexts.WarehouseContextFilter) (*data.AggregatedWarehouseStock, error)
-	AddWarehouseStocks(warehouseID uint, stocks []data.WarehouseStock) error
+	AddWarehouseStocks(warehouseID uint, stocks []data.WarehouseStock, source data.StockMovementSource) error
 
 	UpdateWarehouseStock(stock *data.WarehouseStock) error
 	GetWarehouseStockByID(warehouseID, stockMaterialID uint) (*data.WarehouseStock, error)
 	GetWarehouseStocksForNotifications(warehouseID uint) ([]data.WarehouseStock, error)
-	UpdateStockQuantity(stockID uint, warehouseID uint, quantity float64) (*data.WarehouseStock, error)
-	UpdateExpirationDate(stockMaterialID, warehouseID uint, newExpirationDate time.Time) error
+	UpdateStockQuantity(stockID uint, warehouseID uint, quantity float64, source data.StockMovementSource) (*data.WarehouseStock, error)
+	UpdateExpirationDate(lotID, stockMaterialID, warehouseID uint, newExpirationDate time.Time) error
 
 	GetAvailableToAddStockMaterials(storeID uint, filter *types.AvailableStockMaterialFilter) ([]data.StockMaterial, error)
 
 	FindEarliestExpirationDateForStock(stockMaterialID uint, filter *contexts.WarehouseContextFilter) (*time.Time, error)
+	GetStockLots(stockMaterialID uint, filter *contexts.WarehouseContextFilter) ([]data.WarehouseStockLot, error)
 }
 
+// expiringLotsCondition keeps the stocks holding a lot which expires before the given date
+const expiringLotsCondition = `EXISTS (
+	SELECT 1 FROM warehouse_stock_lots
+	WHERE warehouse_stock_lots.warehouse_id = warehouse_stocks.warehouse_id
+		AND warehouse_stock_lots.stock_material_id = warehouse_stocks.stock_material_id
+		AND warehouse_stock_lots.quantity > 0
+		AND warehouse_stock_lots.deleted_at IS NULL
+		AND warehouse_stock_lots.expiration_date <= ?
+)`
+
 type warehouseStockRepository struct {
-	db *gorm.DB
+	db                *gorm.DB
+	stockMovementRepo stockMovements.StockMovementRepository
+}
+
+func NewWarehouseStockRepository(db *gorm.DB, stockMovementRepo stockMovements.StockMovementRepository) WarehouseStockRepository {
+	return &warehouseStockRepository{db: db, stockMovementRepo: stockMovementRepo}
 }
 
-func NewWarehouseStockRepository(db *gorm.DB) WarehouseStockRepository {
-	return &warehouseStockRepository{db: db}
+func (r *warehouseStockRepository) CloneWithTransaction(tx *gorm.DB) WarehouseStockRepository {
+	return &warehouseStockRepository{db: tx, stockMovementRepo: r.stockMovementRepo.CloneWithTransaction(tx)}
 }
 
-func (r *warehouseStockRepository) RecordDeliveriesAndUpdateStock(delivery data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, warehouseID uint) error {
+// RecordDeliveriesAndUpdateStock records the delivery and adds its materials to the stock, the movements point to the delivery
+func (r *warehouseStockRepository) RecordDeliveriesAndUpdateStock(delivery data.SupplierWarehouseDelivery, materials []data.SupplierWarehouseDeliveryMaterial, warehouseID uint, employeeID *uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&delivery).Error; err != nil {
 			return fmt.Errorf("failed to create delivery: %w", err)
 		}
 
+		source := data.StockMovementSource{
+			Type:       data.StockMovementSourceSupplierDelivery,
+			ID:         &delivery.ID,
+			EmployeeID: employeeID,
+		}
+
 		for i := range materials {
 			materials[i].DeliveryID = delivery.ID
 		}
@@ -63,7 +91,7 @@ func (r *warehouseStockRepository) RecordDeliveriesAndUpdateStock(delivery data.
 		}
 
 		for _, material := range materials {
-			if err := r.updateOrInsertStock(tx, warehouseID, material); err != nil {
+			if err := r.updateOrInsertStock(tx, warehouseID, material, source); err != nil {
 				return fmt.Errorf("failed to update or insert stock for stock_material_id %d: %w", material.StockMaterialID, err)
 			}
 		}
@@ -79,14 +107,10 @@ func (r *warehouseStockRepository) recordDeliveryMaterials(tx *gorm.DB, material
 	return nil
 }
 
-func (r *warehouseStockRepository) updateOrInsertStock(tx *gorm.DB, warehouseID uint, material data.SupplierWarehouseDeliveryMaterial) error {
-	stock := data.WarehouseStock{
-		WarehouseID:     warehouseID,
-		StockMaterialID: material.StockMaterialID,
-	}
-
-	if err := tx.FirstOrCreate(&stock, "warehouse_id = ? AND stock_material_id = ?", warehouseID, material.StockMaterialID).Error; err != nil {
-		return fmt.Errorf("failed to find or create warehouse stock: %w", err)
+func (r *warehouseStockRepository) updateOrInsertStock(tx *gorm.DB, warehouseID uint, material data.SupplierWarehouseDeliveryMaterial, source data.StockMovementSource) error {
+	stock, err := r.lockOrCreateStock(tx, warehouseID, material.StockMaterialID)
+	if err != nil {
+		return err
 	}
 
 	if err := tx.Model(&data.WarehouseStock{}).
@@ -95,7 +119,21 @@ func (r *warehouseStockRepository) updateOrInsertStock(tx *gorm.DB, warehouseID
 		return fmt.Errorf("failed to update warehouse stock quantity: %w", err)
 	}
 
-	return nil
+	lot := data.WarehouseStockLot{
+		WarehouseID:        warehouseID,
+		StockMaterialID:    material.StockMaterialID,
+		DeliveryMaterialID: &material.ID,
+		Barcode:            material.Barcode,
+		ExpirationDate:     material.ExpirationDate,
+		ReceivedQuantity:   material.Quantity,
+		Quantity:           material.Quantity,
+	}
+	if err := tx.Create(&lot).Error; err != nil {
+		return fmt.Errorf("failed to create warehouse stock lot: %w", err)
+	}
+
+	movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, material.StockMaterialID, stock.Quantity, stock.Quantity+material.Quantity)
+	return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
 }
 
 func (r *warehouseStockRepository) GetDeliveryByID(deliveryID uint, delivery *data.SupplierWarehouseDelivery) error {
@@ -184,37 +222,53 @@ func (r *warehouseStockRepository) ConvertInventoryItemsToStockRequest(items []t
 	return converted, nil
 }
 
-func (r *warehouseStockRepository) AddToWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64) error {
-	stock := &data.WarehouseStock{}
-	if err := r.db.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).First(stock).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			stock = &data.WarehouseStock{
-				WarehouseID:     warehouseID,
-				StockMaterialID: stockMaterialID,
-				Quantity:        quantityInPackages,
-			}
-			return r.db.Create(stock).Error
+func (r *warehouseStockRepository) AddToWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		stock, err := r.lockOrCreateStock(tx, warehouseID, stockMaterialID)
+		if err != nil {
+			return err
+		}
+
+		before := stock.Quantity
+		if err := tx.Model(stock).Update("quantity", gorm.Expr("quantity + ?", quantityInPackages)).Error; err != nil {
+			return fmt.Errorf("failed to add stock for StockMaterialID %d in WarehouseID %d: %w", stockMaterialID, warehouseID, err)
 		}
-		return types.ErrAddWarehouseStockMaterial
-	}
 
-	return r.db.Model(stock).Update("quantity", gorm.Expr("quantity + ?", quantityInPackages)).Error
+		if err := r.addManualLot(tx, warehouseID, stockMaterialID, quantityInPackages); err != nil {
+			return err
+		}
+
+		movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, stockMaterialID, before, before+quantityInPackages)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
+	})
 }
 
-func (r *warehouseStockRepository) DeductFromWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64) (*data.WarehouseStock, error) {
+func (r *warehouseStockRepository) DeductFromWarehouseStock(warehouseID, stockMaterialID uint, quantityInPackages float64, source data.StockMovementSource) (*data.WarehouseStock, error) {
 	stock := &data.WarehouseStock{}
-	if err := r.db.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).First(stock).Error; err != nil {
-		return nil, fmt.Errorf("failed to fetch warehouse stock: %w", err)
-	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := r.lockStock(tx, warehouseID, stockMaterialID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch warehouse stock: %w", err)
+		}
 
-	if stock.Quantity < quantityInPackages {
-		return nil, fmt.Errorf("insufficient stock for StockMaterialID %d in WarehouseID %d: available %.2f, requested %.2f", stockMaterialID, warehouseID, stock.Quantity, quantityInPackages)
-	}
+		if locked.Quantity < quantityInPackages {
+			return fmt.Errorf("insufficient stock for StockMaterialID %d in WarehouseID %d: available %.2f, requested %.2f: %w", stockMaterialID, warehouseID, locked.Quantity, quantityInPackages, types.ErrInsufficientStock)
+		}
 
-	if err := r.db.Model(stock).
-		Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
-		Update("quantity", gorm.Expr("quantity - ?", quantityInPackages)).Error; err != nil {
-		return nil, fmt.Errorf("failed to deduct stock for StockMaterialID %d in WarehouseID %d: %w", stockMaterialID, warehouseID, err)
+		if err := tx.Model(locked).
+			Update("quantity", gorm.Expr("quantity - ?", quantityInPackages)).Error; err != nil {
+			return fmt.Errorf("failed to deduct stock for StockMaterialID %d in WarehouseID %d: %w", stockMaterialID, warehouseID, err)
+		}
+
+		if _, err := r.consumeLots(tx, locked, quantityInPackages); err != nil {
+			return err
+		}
+
+		movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, stockMaterialID, locked.Quantity, locked.Quantity-quantityInPackages)
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err := r.db.Preload("StockMaterial").Preload("Warehouse").Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).First(stock).Error; err != nil {
@@ -230,17 +284,17 @@ func (r *warehouseStockRepository) GetWarehouseStock(filter *types.GetWarehouseS
 		return nil, fmt.Errorf("failed to fetch warehouse stocks: %w", err)
 	}
 
-	materials, err := r.getDeliveryMaterials(filter)
+	lots, err := r.getStockLots(filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch delivery materials: %w", err)
+		return nil, fmt.Errorf("failed to fetch stock lots: %w", err)
 	}
 
-	materialMap := make(map[uint][]data.SupplierWarehouseDeliveryMaterial)
-	for _, material := range materials {
-		materialMap[material.StockMaterialID] = append(materialMap[material.StockMaterialID], material)
+	lotMap := make(map[uint][]data.WarehouseStockLot)
+	for _, lot := range lots {
+		lotMap[lot.StockMaterialID] = append(lotMap[lot.StockMaterialID], lot)
 	}
 
-	aggregatedStocks := r.aggregateWarehouseStocks(warehouseStocks, materialMap)
+	aggregatedStocks := r.aggregateWarehouseStocks(warehouseStocks, lotMap)
 
 	return aggregatedStocks, nil
 }
@@ -251,9 +305,9 @@ func (r *warehouseStockRepository) GetWarehouseStockMaterialDetails(stockMateria
 		return nil, fmt.Errorf("failed to fetch warehouse stock: %w", err)
 	}
 
-	earliestExpirationDate, err := r.FindEarliestExpirationDateForStock(stockMaterialID, filter)
+	lots, err := r.GetStockLots(stockMaterialID, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch earliest expiration date: %w", err)
+		return nil, err
 	}
 
 	return &data.AggregatedWarehouseStock{
@@ -261,20 +315,21 @@ func (r *warehouseStockRepository) GetWarehouseStockMaterialDetails(stockMateria
 		StockMaterialID:        warehouseStock.StockMaterialID,
 		StockMaterial:          warehouseStock.StockMaterial,
 		TotalQuantity:          warehouseStock.Quantity,
-		EarliestExpirationDate: earliestExpirationDate,
+		EarliestExpirationDate: r.findEarliestLotExpirationDate(lots),
+		Lots:                   lots,
 	}, nil
 }
 
 // helper functions
-func (r *warehouseStockRepository) findEarliestMaterialExpirationDate(materials []data.SupplierWarehouseDeliveryMaterial) *time.Time {
-	if len(materials) == 0 {
+func (r *warehouseStockRepository) findEarliestLotExpirationDate(lots []data.WarehouseStockLot) *time.Time {
+	if len(lots) == 0 {
 		return nil
 	}
 
 	var earliest *time.Time
-	for _, material := range materials {
-		if earliest == nil || material.ExpirationDate.Before(*earliest) {
-			earliest = &material.ExpirationDate
+	for _, lot := range lots {
+		if earliest == nil || lot.ExpirationDate.Before(*earliest) {
+			earliest = &lot.ExpirationDate
 		}
 	}
 
@@ -286,30 +341,12 @@ func (r *warehouseStockRepository) findEarliestMaterialExpirationDate(materials
 	return &utcTime
 }
 
+// FindEarliestExpirationDateForStock returns the expiration of the lot the material is picked from next
 func (r *warehouseStockRepository) FindEarliestExpirationDateForStock(stockMaterialID uint, filter *contexts.WarehouseContextFilter) (*time.Time, error) {
 	var earliestExpirationDate sql.NullTime
-	query := r.db.Model(&data.SupplierWarehouseDeliveryMaterial{}).
-		Where(&data.SupplierWarehouseDeliveryMaterial{StockMaterialID: stockMaterialID}).
-		Select("MIN(supplier_warehouse_delivery_materials.expiration_date) AS earliest_expiration_date")
-
-	if filter != nil {
-		if filter.WarehouseID != nil {
-			query.Where(&data.SupplierWarehouseDeliveryMaterial{
-				Delivery: data.SupplierWarehouseDelivery{
-					WarehouseID: *filter.WarehouseID,
-				},
-			})
-		}
-
-		if filter.RegionID != nil {
-			query.Joins("JOIN supplier_warehouse_deliveries ON supplier_warehouse_deliveries.id = supplier_warehouse_delivery_materials.delivery_id").
-				Where(&data.SupplierWarehouseDeliveryMaterial{
-					Delivery: data.SupplierWarehouseDelivery{
-						Warehouse: data.Warehouse{RegionID: *filter.RegionID},
-					},
-				})
-		}
-	}
+	query := r.filterLots(r.db.Model(&data.WarehouseStockLot{}), filter).
+		Where("warehouse_stock_lots.stock_material_id = ? AND warehouse_stock_lots.quantity > 0", stockMaterialID).
+		Select("MIN(warehouse_stock_lots.expiration_date) AS earliest_expiration_date")
 
 	err := query.Scan(&earliestExpirationDate).Error
 	if err != nil {
@@ -327,16 +364,127 @@ func (r *warehouseStockRepository) FindEarliestExpirationDateForStock(stockMater
 	return &UTCTime, nil
 }
 
+// GetStockLots returns the lots of the material still holding stock in the order they are picked
+func (r *warehouseStockRepository) GetStockLots(stockMaterialID uint, filter *contexts.WarehouseContextFilter) ([]data.WarehouseStockLot, error) {
+	var lots []data.WarehouseStockLot
+	err := r.filterLots(r.db.Model(&data.WarehouseStockLot{}), filter).
+		Where("warehouse_stock_lots.stock_material_id = ? AND warehouse_stock_lots.quantity > 0", stockMaterialID).
+		Order("warehouse_stock_lots.expiration_date, warehouse_stock_lots.id").
+		Find(&lots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the lots of stock material ID %d: %w", stockMaterialID, err)
+	}
+	return lots, nil
+}
+
+func (r *warehouseStockRepository) filterLots(query *gorm.DB, filter *contexts.WarehouseContextFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.WarehouseID != nil {
+		query = query.Where("warehouse_stock_lots.warehouse_id = ?", *filter.WarehouseID)
+	}
+	if filter.RegionID != nil {
+		query = query.Where("warehouse_stock_lots.warehouse_id IN (SELECT id FROM warehouses WHERE region_id = ?)", *filter.RegionID)
+	}
+	return query
+}
+
+// addManualLot records the stock added by hand as a lot expiring after the expiration period of the material
+func (r *warehouseStockRepository) addManualLot(tx *gorm.DB, warehouseID, stockMaterialID uint, quantity float64) error {
+	if quantity <= 0 {
+		return nil
+	}
+
+	var stockMaterial data.StockMaterial
+	if err := tx.Select("id", "barcode", "expiration_period_in_days").First(&stockMaterial, stockMaterialID).Error; err != nil {
+		return fmt.Errorf("failed to fetch stock material ID %d: %w", stockMaterialID, err)
+	}
+
+	lot := data.WarehouseStockLot{
+		WarehouseID:      warehouseID,
+		StockMaterialID:  stockMaterialID,
+		Barcode:          stockMaterial.Barcode,
+		ExpirationDate:   time.Now().AddDate(0, 0, stockMaterial.ExpirationPeriodInDays),
+		ReceivedQuantity: quantity,
+		Quantity:         quantity,
+	}
+	if err := tx.Create(&lot).Error; err != nil {
+		return fmt.Errorf("failed to create warehouse stock lot: %w", err)
+	}
+	return nil
+}
+
+// lockStock locks the stock of the material, so the quantity it holds stays the same until the change is committed
+func (r *warehouseStockRepository) lockStock(tx *gorm.DB, warehouseID, stockMaterialID uint) (*data.WarehouseStock, error) {
+	var stock data.WarehouseStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stockMaterialID).
+		First(&stock).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
+
+// lockOrCreateStock creates the empty stock of the material unless the warehouse already keeps it and locks it,
+// concurrent deliveries of a new material end up in the same stock
+func (r *warehouseStockRepository) lockOrCreateStock(tx *gorm.DB, warehouseID, stockMaterialID uint) (*data.WarehouseStock, error) {
+	stock := data.WarehouseStock{
+		WarehouseID:     warehouseID,
+		StockMaterialID: stockMaterialID,
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "warehouse_id"}, {Name: "stock_material_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(&stock).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create warehouse stock for stock material ID %d: %w", stockMaterialID, err)
+	}
+
+	locked, err := r.lockStock(tx, warehouseID, stockMaterialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock warehouse stock for stock material ID %d: %w", stockMaterialID, err)
+	}
+	return locked, nil
+}
+
+// consumeLots takes the quantity from the lots of the locked stock first-expired-first-out,
+// only the quantity kept before the lots were tracked may be taken without a lot
+func (r *warehouseStockRepository) consumeLots(tx *gorm.DB, stock *data.WarehouseStock, quantity float64) ([]types.LotAllocation, error) {
+	var lots []data.WarehouseStockLot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("warehouse_id = ? AND stock_material_id = ? AND quantity > 0", stock.WarehouseID, stock.StockMaterialID).
+		Find(&lots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the lots of stock material ID %d: %w", stock.StockMaterialID, err)
+	}
+
+	allocations, shortage := types.AllocateFEFO(lots, quantity)
+	if untracked := types.CalculateUntrackedQuantity(stock.Quantity, lots); shortage > untracked {
+		return nil, fmt.Errorf("lots of stock material ID %d in warehouse ID %d are short of %.2f: %w", stock.StockMaterialID, stock.WarehouseID, utils.RoundToDecimal(shortage-untracked, 2), types.ErrInsufficientStock)
+	}
+
+	for _, allocation := range allocations {
+		err := tx.Model(&data.WarehouseStockLot{}).
+			Where("id = ?", allocation.LotID).
+			Update("quantity", gorm.Expr("quantity - ?", allocation.Quantity)).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to deduct from lot ID %d: %w", allocation.LotID, err)
+		}
+	}
+	return allocations, nil
+}
+
 func (r *warehouseStockRepository) aggregateWarehouseStocks(
 	warehouseStocks []data.WarehouseStock,
-	materialMap map[uint][]data.SupplierWarehouseDeliveryMaterial,
+	lotMap map[uint][]data.WarehouseStockLot,
 ) []data.AggregatedWarehouseStock {
 	var aggregatedStocks []data.AggregatedWarehouseStock
 
 	for _, stock := range warehouseStocks {
-		materials := materialMap[stock.StockMaterialID]
-
-		earliestExpirationDate := r.findEarliestMaterialExpirationDate(materials)
+		earliestExpirationDate := r.findEarliestLotExpirationDate(lotMap[stock.StockMaterialID])
 
 		aggregatedStocks = append(aggregatedStocks, data.AggregatedWarehouseStock{
 			WarehouseID:            stock.WarehouseID,
@@ -350,26 +498,24 @@ func (r *warehouseStockRepository) aggregateWarehouseStocks(
 	return aggregatedStocks
 }
 
-func (r *warehouseStockRepository) getDeliveryMaterials(filter *types.GetWarehouseStockFilterQuery) ([]data.SupplierWarehouseDeliveryMaterial, error) {
-	var materials []data.SupplierWarehouseDeliveryMaterial
+func (r *warehouseStockRepository) getStockLots(filter *types.GetWarehouseStockFilterQuery) ([]data.WarehouseStockLot, error) {
+	var lots []data.WarehouseStockLot
 
-	query := r.db.Model(&data.SupplierWarehouseDeliveryMaterial{}).
-		Preload("StockMaterial")
+	query := r.db.Model(&data.WarehouseStockLot{}).Where("quantity > 0")
 
 	if filter.WarehouseID != nil {
-		query = query.Joins("JOIN supplier_warehouse_deliveries ON supplier_warehouse_deliveries.id = supplier_warehouse_delivery_materials.delivery_id").
-			Where("supplier_warehouse_deliveries.warehouse_id = ?", *filter.WarehouseID)
+		query = query.Where("warehouse_id = ?", *filter.WarehouseID)
 	}
 
 	if filter.StockMaterialID != nil {
 		query = query.Where("stock_material_id = ?", *filter.StockMaterialID)
 	}
 
-	if err := query.Find(&materials).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("failed to fetch delivery materials: %w", err)
+	if err := query.Find(&lots).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch stock lots: %w", err)
 	}
 
-	return materials, nil
+	return lots, nil
 }
 
 func (r *warehouseStockRepository) getWarehouseStock(stockMaterialID uint, filter *contexts.WarehouseContextFilter) (*data.WarehouseStock, error) {
@@ -438,29 +584,12 @@ func (r *warehouseStockRepository) getWarehouseStocksWithPagination(filter *type
 
 	// Filter by expiration-related conditions
 	if filter.IsExpiring != nil && *filter.IsExpiring {
-		query = query.Joins(`
-			LEFT JOIN supplier_warehouse_delivery_materials
-			ON supplier_warehouse_delivery_materials.stock_material_id = warehouse_stocks.stock_material_id
-		`).
-			Where(`
-				supplier_warehouse_delivery_materials.expiration_date IS NOT NULL AND
-				supplier_warehouse_delivery_materials.expiration_date <= NOW() + INTERVAL '7 days'
-			`).
-			Group("warehouse_stocks.id")
+		query = query.Where(expiringLotsCondition, time.Now().AddDate(0, 0, 7))
 	}
 
 	// Filter by expiration days
 	if filter.ExpirationDays != nil {
-		expirationThreshold := time.Now().AddDate(0, 0, *filter.ExpirationDays)
-		query = query.Joins(`
-			LEFT JOIN supplier_warehouse_delivery_materials
-			ON supplier_warehouse_delivery_materials.stock_material_id = warehouse_stocks.stock_material_id
-		`).
-			Where(`
-				supplier_warehouse_delivery_materials.expiration_date IS NOT NULL AND
-				supplier_warehouse_delivery_materials.expiration_date <= ?
-			`, expirationThreshold).
-			Group("warehouse_stocks.id")
+		query = query.Where(expiringLotsCondition, time.Now().AddDate(0, 0, *filter.ExpirationDays))
 	}
 
 	// Filter by category
@@ -494,38 +623,27 @@ func (r *warehouseStockRepository) getWarehouseStocksWithPagination(filter *type
 	return warehouseStocks, nil
 }
 
-func (r *warehouseStockRepository) UpdateExpirationDate(stockMaterialID, warehouseID uint, newExpirationDate time.Time) error {
-	var deliveryMaterialIDs []uint
-	err := r.db.Model(&data.SupplierWarehouseDeliveryMaterial{}).
-		Joins("JOIN supplier_warehouse_deliveries ON supplier_warehouse_deliveries.id = supplier_warehouse_delivery_materials.delivery_id").
-		Where("supplier_warehouse_deliveries.warehouse_id = ? AND supplier_warehouse_delivery_materials.stock_material_id = ?", warehouseID, stockMaterialID).
-		Pluck("supplier_warehouse_delivery_materials.id", &deliveryMaterialIDs).Error
-	if err != nil {
-		return fmt.Errorf("failed to identify delivery materials to update: %w", err)
+// UpdateExpirationDate corrects the expiration date of a single lot, the delivery keeps the date it was received with
+func (r *warehouseStockRepository) UpdateExpirationDate(lotID, stockMaterialID, warehouseID uint, newExpirationDate time.Time) error {
+	result := r.db.Model(&data.WarehouseStockLot{}).
+		Where("id = ? AND warehouse_id = ? AND stock_material_id = ?", lotID, warehouseID, stockMaterialID).
+		Update("expiration_date", newExpirationDate)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update the expiration date of lot ID %d: %w", lotID, result.Error)
 	}
 
-	if len(deliveryMaterialIDs) == 0 {
-		return fmt.Errorf("no deliveries found for stock material ID %d in warehouse ID %d", stockMaterialID, warehouseID)
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("lot ID %d not found for stock material ID %d in warehouse ID %d", lotID, stockMaterialID, warehouseID)
 	}
-
-	err = r.db.Model(&data.SupplierWarehouseDeliveryMaterial{}).
-		Where("id IN ?", deliveryMaterialIDs).
-		Update("expiration_date", newExpirationDate).Error
-	if err != nil {
-		return fmt.Errorf("failed to update expiration date: %w", err)
-	}
-
 	return nil
 }
 
-func (r *warehouseStockRepository) UpdateStockQuantity(stockMaterialID, warehouseID uint, quantity float64) (*data.WarehouseStock, error) {
+func (r *warehouseStockRepository) UpdateStockQuantity(stockMaterialID, warehouseID uint, quantity float64, source data.StockMovementSource) (*data.WarehouseStock, error) {
 	var updatedStock data.WarehouseStock
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
-		var warehouseStock data.WarehouseStock
-		if err := tx.Model(&data.WarehouseStock{}).
-			Where("stock_material_id = ? AND warehouse_id = ?", stockMaterialID, warehouseID).
-			First(&warehouseStock).Error; err != nil {
+		warehouseStock, err := r.lockStock(tx, warehouseID, stockMaterialID)
+		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf("stock material ID %d not found in warehouse ID %d", stockMaterialID, warehouseID)
 			}
@@ -538,6 +656,23 @@ func (r *warehouseStockRepository) UpdateStockQuantity(stockMaterialID, warehous
 			return fmt.Errorf("failed to update quantity for stock material ID %d in warehouse ID %d: %w", stockMaterialID, warehouseID, err)
 		}
 
+		// the surplus becomes a new lot, the shortage is taken from the lots expiring first
+		difference := utils.RoundToDecimal(quantity-warehouseStock.Quantity, 2)
+		if difference > 0 {
+			if err := r.addManualLot(tx, warehouseID, stockMaterialID, difference); err != nil {
+				return err
+			}
+		} else if difference < 0 {
+			if _, err := r.consumeLots(tx, warehouseStock, -difference); err != nil {
+				return err
+			}
+		}
+
+		movement := stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, stockMaterialID, warehouseStock.Quantity, quantity)
+		if err := r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movement); err != nil {
+			return err
+		}
+
 		if err := tx.Model(&data.WarehouseStock{}).Preload("StockMaterial").
 			Where("stock_material_id = ? AND warehouse_id = ?", stockMaterialID, warehouseID).
 			First(&updatedStock).Error; err != nil {
@@ -553,29 +688,27 @@ func (r *warehouseStockRepository) UpdateStockQuantity(stockMaterialID, warehous
 	return &updatedStock, nil
 }
 
-func (r *warehouseStockRepository) AddWarehouseStocks(warehouseID uint, stocks []data.WarehouseStock) error {
+func (r *warehouseStockRepository) AddWarehouseStocks(warehouseID uint, stocks []data.WarehouseStock, source data.StockMovementSource) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		movements := make([]data.StockMovement, 0, len(stocks))
 		for _, stock := range stocks {
-			var existingStock data.WarehouseStock
-			err := tx.Where("warehouse_id = ? AND stock_material_id = ?", warehouseID, stock.StockMaterialID).
-				First(&existingStock).Error
+			existingStock, err := r.lockOrCreateStock(tx, warehouseID, stock.StockMaterialID)
+			if err != nil {
+				return err
+			}
 
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				return fmt.Errorf("failed to check existing warehouse stock: %w", err)
+			before := existingStock.Quantity
+			if err := tx.Model(existingStock).Update("quantity", gorm.Expr("quantity + ?", stock.Quantity)).Error; err != nil {
+				return fmt.Errorf("failed to update existing warehouse stock: %w", err)
 			}
 
-			if existingStock.ID > 0 {
-				existingStock.Quantity += stock.Quantity
-				if err := tx.Save(&existingStock).Error; err != nil {
-					return fmt.Errorf("failed to update existing warehouse stock: %w", err)
-				}
-			} else {
-				if err := tx.Create(&stock).Error; err != nil {
-					return fmt.Errorf("failed to insert new warehouse stock: %w", err)
-				}
+			if err := r.addManualLot(tx, warehouseID, stock.StockMaterialID, stock.Quantity); err != nil {
+				return err
 			}
+
+			movements = append(movements, stockMovementsTypes.NewWarehouseStockMovement(source, warehouseID, stock.StockMaterialID, before, before+stock.Quantity))
 		}
-		return nil
+		return r.stockMovementRepo.CloneWithTransaction(tx).CreateMovements(movements...)
 	})
 }
 
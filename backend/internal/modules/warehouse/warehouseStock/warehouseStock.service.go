@@ -9,6 +9,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/notifications/details"
+	purchaseOrderTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
 	stockMaterialTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/types"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock/types"
@@ -16,16 +17,16 @@ import (
 )
 
 type WarehouseStockService interface {
-	ReceiveInventory(warehouseID uint, req types.ReceiveWarehouseDelivery) error
+	ReceiveInventory(warehouseID, employeeID uint, req types.ReceiveWarehouseDelivery) error
 	GetDeliveries(filter types.WarehouseDeliveryFilter) ([]types.WarehouseDeliveryDTO, error)
 	GetDeliveryByID(id uint) (*types.WarehouseDeliveryDTO, error)
 
-	AddWarehouseStockMaterial(req types.AdjustWarehouseStock) error
-	AddWarehouseStocks(warehouseID uint, req []types.AddWarehouseStockMaterial) error
-	DeductFromStock(req types.AdjustWarehouseStock) error
+	AddWarehouseStockMaterial(employeeID uint, req types.AdjustWarehouseStock) error
+	AddWarehouseStocks(warehouseID, employeeID uint, req []types.AddWarehouseStockMaterial) error
+	DeductFromStock(employeeID uint, req types.AdjustWarehouseStock) error
 	GetStock(query *types.GetWarehouseStockFilterQuery) ([]types.WarehouseStockResponse, error)
 	GetStockMaterialDetails(stockMaterialID uint, filter *contexts.WarehouseContextFilter) (*types.WarehouseStockResponse, error)
-	UpdateStock(warehouseID, stockMaterialID uint, dto types.UpdateWarehouseStockDTO) error
+	UpdateStock(warehouseID, stockMaterialID, employeeID uint, dto types.UpdateWarehouseStockDTO) error
 
 	CheckStockNotifications(warehouseID uint, stock data.WarehouseStock) error
 
@@ -34,25 +35,28 @@ type WarehouseStockService interface {
 
 type warehouseStockService struct {
 	repo                WarehouseStockRepository
+	transactionManager  TransactionManager
 	stockMaterialRepo   stockMaterial.StockMaterialRepository
 	notificationService notifications.NotificationService
 	logger              *zap.SugaredLogger
 }
 
 func NewWarehouseStockService(repo WarehouseStockRepository,
+	transactionManager TransactionManager,
 	stockMaterialRepo stockMaterial.StockMaterialRepository,
 	notificationService notifications.NotificationService,
 	logger *zap.SugaredLogger,
 ) WarehouseStockService {
 	return &warehouseStockService{
 		repo:                repo,
+		transactionManager:  transactionManager,
 		stockMaterialRepo:   stockMaterialRepo,
 		notificationService: notificationService,
 		logger:              logger,
 	}
 }
 
-func (s *warehouseStockService) ReceiveInventory(warehouseID uint, req types.ReceiveWarehouseDelivery) error {
+func (s *warehouseStockService) ReceiveInventory(warehouseID, employeeID uint, req types.ReceiveWarehouseDelivery) error {
 	stockMaterialIDs := make([]uint, len(req.Materials))
 	for i, material := range req.Materials {
 		stockMaterialIDs[i] = material.StockMaterialID
@@ -69,9 +73,10 @@ func (s *warehouseStockService) ReceiveInventory(warehouseID uint, req types.Rec
 	}
 
 	delivery := data.SupplierWarehouseDelivery{
-		SupplierID:   req.SupplierID,
-		WarehouseID:  warehouseID,
-		DeliveryDate: time.Now(),
+		SupplierID:      req.SupplierID,
+		WarehouseID:     warehouseID,
+		DeliveryDate:    time.Now(),
+		PurchaseOrderID: req.PurchaseOrderID,
 	}
 
 	materials := make([]data.SupplierWarehouseDeliveryMaterial, len(req.Materials))
@@ -90,8 +95,15 @@ func (s *warehouseStockService) ReceiveInventory(warehouseID uint, req types.Rec
 		}
 	}
 
-	err = s.repo.RecordDeliveriesAndUpdateStock(delivery, materials, warehouseID)
+	if req.PurchaseOrderID != nil {
+		err = s.transactionManager.ReceivePurchaseOrderDelivery(&delivery, materials, &employeeID)
+	} else {
+		err = s.repo.RecordDeliveriesAndUpdateStock(delivery, materials, warehouseID, &employeeID)
+	}
 	if err != nil {
+		if purchaseOrderTypes.IsReceiptError(err) {
+			return err
+		}
 		s.logger.Errorf("failed to receive inventory: %v", err)
 		return types.ErrFailedToRecordDeliveries
 	}
@@ -120,16 +132,16 @@ func (s *warehouseStockService) GetDeliveryByID(id uint) (*types.WarehouseDelive
 	return &response, nil
 }
 
-func (s *warehouseStockService) AddWarehouseStockMaterial(req types.AdjustWarehouseStock) error {
-	if err := s.repo.AddToWarehouseStock(req.WarehouseID, req.StockMaterialID, req.Quantity); err != nil {
+func (s *warehouseStockService) AddWarehouseStockMaterial(employeeID uint, req types.AdjustWarehouseStock) error {
+	if err := s.repo.AddToWarehouseStock(req.WarehouseID, req.StockMaterialID, req.Quantity, manualStockMovementSource(employeeID)); err != nil {
 		s.logger.Errorf("failed to add warehouse stock material: %v", err)
 		return types.ErrAddWarehouseStockMaterial
 	}
 	return nil
 }
 
-func (s *warehouseStockService) DeductFromStock(req types.AdjustWarehouseStock) error {
-	stock, err := s.repo.DeductFromWarehouseStock(req.WarehouseID, req.StockMaterialID, req.Quantity)
+func (s *warehouseStockService) DeductFromStock(employeeID uint, req types.AdjustWarehouseStock) error {
+	stock, err := s.repo.DeductFromWarehouseStock(req.WarehouseID, req.StockMaterialID, req.Quantity, manualStockMovementSource(employeeID))
 	if err != nil {
 		s.logger.Errorf("failed to deduct from stock: %v", err)
 		return types.ErrDeductFromStock
@@ -155,7 +167,7 @@ func (s *warehouseStockService) GetStock(query *types.GetWarehouseStockFilterQue
 	return responses, nil
 }
 
-func (s *warehouseStockService) AddWarehouseStocks(warehouseID uint, req []types.AddWarehouseStockMaterial) error {
+func (s *warehouseStockService) AddWarehouseStocks(warehouseID, employeeID uint, req []types.AddWarehouseStockMaterial) error {
 	if len(req) == 0 {
 		return types.ErrEmptyStocks
 	}
@@ -168,7 +180,7 @@ func (s *warehouseStockService) AddWarehouseStocks(warehouseID uint, req []types
 			Quantity:        dto.Quantity,
 		})
 	}
-	if err := s.repo.AddWarehouseStocks(warehouseID, stocks); err != nil {
+	if err := s.repo.AddWarehouseStocks(warehouseID, stocks, manualStockMovementSource(employeeID)); err != nil {
 		s.logger.Errorf("failed to add warehouse stocks: %v", err)
 		return types.ErrAddWarehouseStockMaterial
 	}
@@ -185,13 +197,13 @@ func (s *warehouseStockService) GetStockMaterialDetails(stockMaterialID uint, fi
 	return &details, nil
 }
 
-func (s *warehouseStockService) UpdateStock(warehouseID, stockMaterialID uint, dto types.UpdateWarehouseStockDTO) error {
+func (s *warehouseStockService) UpdateStock(warehouseID, stockMaterialID, employeeID uint, dto types.UpdateWarehouseStockDTO) error {
 	if dto.Quantity == nil && dto.ExpirationDate == nil {
 		return types.ErrNothingToUpdate
 	}
 
 	if dto.Quantity != nil {
-		stock, err := s.repo.UpdateStockQuantity(stockMaterialID, warehouseID, *dto.Quantity)
+		stock, err := s.repo.UpdateStockQuantity(stockMaterialID, warehouseID, *dto.Quantity, manualStockMovementSource(employeeID))
 		if err != nil {
 			s.logger.Errorf("failed to update stock quantity: %v", err)
 			return types.ErrUpdateStockQuantity
@@ -202,7 +214,7 @@ func (s *warehouseStockService) UpdateStock(warehouseID, stockMaterialID uint, d
 	}
 
 	if dto.ExpirationDate != nil {
-		if err := s.repo.UpdateExpirationDate(stockMaterialID, warehouseID, *dto.ExpirationDate); err != nil {
+		if err := s.repo.UpdateExpirationDate(*dto.LotID, stockMaterialID, warehouseID, *dto.ExpirationDate); err != nil {
 			s.logger.Errorf("failed to update expiration date: %v", err)
 			return types.ErrUpdateExpiration
 		}
@@ -280,3 +292,10 @@ func (s *warehouseStockService) GetAvailableToAddStockMaterials(storeID uint, qu
 	}
 	return stockMaterialResponses, nil
 }
+
+func manualStockMovementSource(employeeID uint) data.StockMovementSource {
+	return data.StockMovementSource{
+		Type:       data.StockMovementSourceManual,
+		EmployeeID: &employeeID,
+	}
+}
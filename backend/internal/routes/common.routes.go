@@ -8,6 +8,7 @@ import (
 	storeEmployees "github.com/Global-Optima/zeep-web/backend/internal/modules/employees/storeEmployees"
 	warehouseEmployees "github.com/Global-Optima/zeep-web/backend/internal/modules/employees/warehouseEmployees"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/franchisees"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse"
@@ -21,11 +22,18 @@ func (r *Router) RegisterAuthenticationRoutes(handler *auth.AuthenticationHandle
 			customersRoutes.POST("/register", handler.CustomerRegister)
 			customersRoutes.POST("/login", handler.CustomerLogin)
 			customersRoutes.POST("/logout", handler.CustomerLogout)
+			customersRoutes.POST("/verification/send", handler.SendCustomerVerificationCode)
+			customersRoutes.POST("/verification/verify", handler.VerifyCustomerPhone)
+			customersRoutes.POST("/password-reset/send", handler.SendCustomerPasswordResetCode)
+			customersRoutes.POST("/password-reset/confirm", handler.ResetCustomerPassword)
 		}
 
 		employeesRoutes := router.Group("/employees")
 		{
 			employeesRoutes.POST("/login", handler.EmployeeLogin)
+			employeesRoutes.POST("/refresh", handler.RefreshEmployeeToken)
+			employeesRoutes.GET("/terminal", handler.GetTerminal)
+			employeesRoutes.POST("/pin-login", handler.EmployeePinLogin)
 			employeesRoutes.POST("/logout", handler.EmployeeLogout)
 		}
 	}
@@ -75,3 +83,18 @@ func (r *Router) RegisterCommonRegionsRoutes(handler *regions.RegionHandler) {
 		router.GET("", handler.GetAllRegions)
 	}
 }
+
+func (r *Router) RegisterPaymentWebhookRoutes(handler *orders.OrderHandler) {
+	router := r.CommonRoutes.Group("/payments/webhook")
+	{
+		router.POST("/:provider", handler.HandlePaymentWebhook)
+	}
+}
+
+func (r *Router) RegisterStatusBoardRoutes(handler *orders.OrderHandler) {
+	router := r.CommonRoutes.Group("/status-boards/:storeId") // public TV boards of the stores
+	{
+		router.GET("", handler.GetStatusBoard)
+		router.GET("/ws", handler.ServeStatusBoardWS)
+	}
+}
@@ -1 +1,47 @@
 package routes
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders"
+)
+
+func (r *Router) RegisterCustomerRoutes(handler *customers.CustomerHandler) {
+	router := r.CustomerRoutes.Group("/customer/profile")
+	{
+		router.GET("", handler.GetMyProfile)
+		router.PUT("", handler.UpdateMyProfile)
+		router.PUT("/password", handler.UpdateMyPassword)
+		router.DELETE("", handler.DeleteMyAccount)
+	}
+
+	addressesRouter := r.CustomerRoutes.Group("/customer/addresses")
+	{
+		addressesRouter.GET("", handler.GetMyAddresses)
+		addressesRouter.POST("", handler.CreateMyAddress)
+		addressesRouter.PUT("/:id", handler.UpdateMyAddress)
+		addressesRouter.DELETE("/:id", handler.DeleteMyAddress)
+	}
+}
+
+func (r *Router) RegisterCustomerBonusRoutes(handler *bonuses.BonusHandler) {
+	router := r.CustomerRoutes.Group("/customer/bonuses")
+	{
+		router.GET("", handler.GetMyBalance)
+		router.GET("/history", handler.GetMyBonuses)
+	}
+}
+
+func (r *Router) RegisterCustomerOrderRoutes(handler *orders.OrderHandler) {
+	router := r.CustomerRoutes.Group("/customer/orders")
+	{
+		router.GET("", handler.GetMyOrders)
+		router.POST("", handler.CreateMyOrder)
+		router.GET("/active", handler.GetMyActiveOrders)
+		router.GET("/ws", handler.ServeCustomerWS)
+		router.GET("/pickup-slots", handler.GetPickupSlots)
+		router.GET("/:orderId", handler.GetMyOrderDetails)
+		router.POST("/:orderId/reorder", handler.Reorder)
+		router.POST("/:orderId/payment", handler.CreateMyPaymentIntent)
+	}
+}
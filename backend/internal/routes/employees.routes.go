@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"slices"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/data"
 	"github.com/Global-Optima/zeep-web/backend/internal/middleware"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/additives"
@@ -9,6 +11,7 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/analytics"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/audit"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/categories"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/employees"
 	adminEmployees "github.com/Global-Optima/zeep-web/backend/internal/modules/employees/adminEmployees"
 	franchiseeEmployees "github.com/Global-Optima/zeep-web/backend/internal/modules/employees/franchiseeEmployees"
@@ -24,18 +27,27 @@ import (
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/product/recipes"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/product/storeProducts"
 	productTechnicalMap "github.com/Global-Optima/zeep-web/backend/internal/modules/product/technicalMap"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/promotions"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/provisions"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/provisions/storeProvisions"
 	provisionsTechnicalMap "github.com/Global-Optima/zeep-web/backend/internal/modules/provisions/technicalMap"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/receipts"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/regions"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/shifts"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockMovements"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stockRequests"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stocktakes"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeInventoryManagers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStations"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeSynchronizers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeTerminals"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/supplier"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/taxes"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/units"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/purchaseOrders"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/stockMaterial/stockMaterialCategory"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/warehouse/warehouseStock"
@@ -156,6 +168,10 @@ func (r *Router) RegisterStoresRoutes(handler *stores.StoreHandler) {
 		router.POST("", middleware.EmployeeRoleMiddleware(data.FranchiseePermissions...), handler.CreateStore)       // franchise owner, manager
 		router.PUT("/:id", middleware.EmployeeRoleMiddleware(data.FranchiseePermissions...), handler.UpdateStore)    // franchise owner, manager
 		router.DELETE("/:id", middleware.EmployeeRoleMiddleware(data.FranchiseePermissions...), handler.DeleteStore) // franchise owner, manager
+
+		router.GET("/:id/hours-exceptions", handler.GetHoursExceptions)
+		router.POST("/:id/hours-exceptions", middleware.EmployeeRoleMiddleware(data.FranchiseePermissions...), handler.CreateHoursException)
+		router.DELETE("/:id/hours-exceptions/:exceptionId", middleware.EmployeeRoleMiddleware(data.FranchiseePermissions...), handler.DeleteHoursException)
 	}
 }
 
@@ -220,6 +236,12 @@ func (r *Router) RegisterEmployeesRoutes(
 		router.GET("/roles", handler.GetAllRoles)
 		router.PUT("/:id/password", middleware.EmployeeRoleMiddleware(), handler.UpdatePassword)
 		router.PUT("/:id/reassign", middleware.EmployeeRoleMiddleware(), handler.ReassignEmployeeType)
+		router.GET("/current/sessions", handler.GetCurrentEmployeeSessions)
+		router.DELETE("/current/sessions", handler.RevokeOtherCurrentEmployeeSessions)
+		router.DELETE("/current/sessions/:sessionId", handler.RevokeCurrentEmployeeSession)
+		router.PUT("/current/pin", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.UpdateCurrentEmployeePin)
+		router.GET("/:id/sessions", middleware.EmployeeRoleMiddleware(), handler.GetEmployeeSessions)
+		router.DELETE("/:id/sessions", middleware.EmployeeRoleMiddleware(), handler.RevokeEmployeeSessions)
 
 		storeEmployeeRouter := router.Group("/store")
 		{
@@ -228,6 +250,8 @@ func (r *Router) RegisterEmployeesRoutes(
 			storeEmployeeRouter.POST("", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), storeEmployeeHandler.CreateStoreEmployee)
 			storeEmployeeRouter.PUT("/:id", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), storeEmployeeHandler.UpdateStoreEmployee)
 			storeEmployeeRouter.DELETE("/:employeeId", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), storeEmployeeHandler.DeleteStoreEmployee)
+			storeEmployeeRouter.GET("/:id/sessions", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), storeEmployeeHandler.GetStoreEmployeeSessions)
+			storeEmployeeRouter.DELETE("/:employeeId/sessions", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), storeEmployeeHandler.RevokeStoreEmployeeSessions)
 		}
 
 		warehouseEmployeeRouter := router.Group("/warehouse") // warehouse and region managers
@@ -237,15 +261,19 @@ func (r *Router) RegisterEmployeesRoutes(
 			warehouseEmployeeRouter.POST("", middleware.EmployeeRoleMiddleware(data.RegionPermissions...), warehouseEmployeeHandler.CreateWarehouseEmployee)
 			warehouseEmployeeRouter.PUT("/:id", middleware.EmployeeRoleMiddleware(data.RegionPermissions...), warehouseEmployeeHandler.UpdateWarehouseEmployee)
 			warehouseEmployeeRouter.DELETE("/:employeeId", middleware.EmployeeRoleMiddleware(data.RegionPermissions...), warehouseEmployeeHandler.DeleteWarehouseEmployee)
+			warehouseEmployeeRouter.GET("/:id/sessions", middleware.EmployeeRoleMiddleware(data.RegionPermissions...), warehouseEmployeeHandler.GetWarehouseEmployeeSessions)
+			warehouseEmployeeRouter.DELETE("/:employeeId/sessions", middleware.EmployeeRoleMiddleware(data.RegionPermissions...), warehouseEmployeeHandler.RevokeWarehouseEmployeeSessions)
 		}
 
 		franchiseeEmployeeRouter := router.Group("/franchisee")
 		{
-			franchiseeEmployeeRouter.GET("", middleware.EmployeeRoleMiddleware(data.FranchiseeReadPermissions...), franchiseeEmployeeHandler.GetFranchiseeEmployees)        // owner, all franchisee
-			franchiseeEmployeeRouter.GET("/:id", middleware.EmployeeRoleMiddleware(data.FranchiseeReadPermissions...), franchiseeEmployeeHandler.GetFranchiseeEmployeeByID) // owner, all franchisee
-			franchiseeEmployeeRouter.POST("", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.CreateFranchiseeEmployee)               // franchise owner
-			franchiseeEmployeeRouter.PUT("/:id", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.UpdateFranchiseeEmployee)            // franchise owner
-			franchiseeEmployeeRouter.DELETE("/:employeeId", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.DeleteFranchiseeEmployee) // franchise owner
+			franchiseeEmployeeRouter.GET("", middleware.EmployeeRoleMiddleware(data.FranchiseeReadPermissions...), franchiseeEmployeeHandler.GetFranchiseeEmployees)                         // owner, all franchisee
+			franchiseeEmployeeRouter.GET("/:id", middleware.EmployeeRoleMiddleware(data.FranchiseeReadPermissions...), franchiseeEmployeeHandler.GetFranchiseeEmployeeByID)                  // owner, all franchisee
+			franchiseeEmployeeRouter.POST("", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.CreateFranchiseeEmployee)                                // franchise owner
+			franchiseeEmployeeRouter.PUT("/:id", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.UpdateFranchiseeEmployee)                             // franchise owner
+			franchiseeEmployeeRouter.DELETE("/:employeeId", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.DeleteFranchiseeEmployee)                  // franchise owner
+			franchiseeEmployeeRouter.GET("/:id/sessions", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.GetFranchiseeEmployeeSessions)               // franchise owner
+			franchiseeEmployeeRouter.DELETE("/:employeeId/sessions", middleware.EmployeeRoleMiddleware(data.RoleFranchiseOwner), franchiseeEmployeeHandler.RevokeFranchiseeEmployeeSessions) // franchise owner
 		}
 
 		regionEmployeeRouter := router.Group("/region")
@@ -279,17 +307,33 @@ func (r *Router) RegisterOrderRoutes(handler *orders.OrderHandler) {
 		router.GET("/:orderId", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetOrderDetails) // all franchise, stores
 		router.POST("/check-name", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CheckCustomerName)
 		router.POST("/:orderId/payment/fail", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.FailOrderPayment)
-		router.POST("/:orderId/payment/success", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.SuccessOrderPayment)
+		router.POST("/:orderId/payment/intent", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CreatePaymentIntent)
+		router.POST("/:orderId/refund", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.RefundOrder)
+		router.POST("/:orderId/cancel", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CancelOrder)
+		router.POST("/:orderId/courier", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.AssignCourier)
+		router.POST("/:orderId/delivered", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CompleteDelivery)
+		router.GET("/cancellations", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetOrderCancellations)
+		router.POST("/cancellations/:cancellationId/review", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.ReviewOrderCancellation)
 		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetOrders)                   // all franchise, stores
 		router.GET("/ws", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.ServeWS)                      // Store manager and barista
 		router.GET("/kiosk", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.GetAllBaristaOrders)       // Store manager and barista
 		router.GET("/:orderId/suborders", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.GetSubOrders) // Store manager and barista
 
 		router.GET("/export", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.ExportOrders) // franchise and store management
+		router.GET("/payment-reconciliation/export", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.ExportPaymentReconciliation)
 		router.PUT("/suborders/:subOrderId/status-change", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.SetNextSubOrderStatus)
 	}
 }
 
+func (r *Router) RegisterReceiptRoutes(handler *receipts.ReceiptHandler) {
+	router := r.EmployeeRoutes.Group("/orders/:orderId/receipts")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetOrderReceipts)           // franchise and store all roles
+		router.GET("/:receiptId", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetReceipt)      // json, pdf or escpos by the format query
+		router.POST("/:receiptId/print", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.PrintReceipt) // reprints are marked as duplicates
+	}
+}
+
 func (r *Router) RegisterSupplierRoutes(handler *supplier.SupplierHandler) {
 	router := r.EmployeeRoutes.Group("/suppliers")
 	{
@@ -304,6 +348,68 @@ func (r *Router) RegisterSupplierRoutes(handler *supplier.SupplierHandler) {
 	}
 }
 
+func (r *Router) RegisterPromotionRoutes(handler *promotions.PromotionHandler) {
+	router := r.EmployeeRoutes.Group("/promotions")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetPromotions)        // franchise and store all roles
+		router.GET("/:id", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetPromotionByID) // franchise and store all roles
+		router.POST("", middleware.EmployeeRoleMiddleware(), handler.CreatePromotion)
+		router.PUT("/:id", middleware.EmployeeRoleMiddleware(), handler.UpdatePromotion)
+		router.DELETE("/:id", middleware.EmployeeRoleMiddleware(), handler.DeletePromotion)
+	}
+}
+
+func (r *Router) RegisterTaxRoutes(handler *taxes.TaxHandler) {
+	router := r.EmployeeRoutes.Group("/taxes")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.FranchiseeAndRegionPermissions...), handler.GetTaxRates)        // franchise and region managers
+		router.GET("/:id", middleware.EmployeeRoleMiddleware(data.FranchiseeAndRegionPermissions...), handler.GetTaxRateByID) // franchise and region managers
+		router.POST("", middleware.EmployeeRoleMiddleware(), handler.CreateTaxRate)
+		router.PUT("/:id", middleware.EmployeeRoleMiddleware(), handler.UpdateTaxRate)
+		router.DELETE("/:id", middleware.EmployeeRoleMiddleware(), handler.DeleteTaxRate)
+	}
+}
+
+func (r *Router) RegisterShiftRoutes(handler *shifts.ShiftHandler) {
+	router := r.EmployeeRoutes.Group("/shifts")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetShifts)                             // franchise and store all roles
+		router.GET("/current", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.GetCurrentShift)                   // Store manager and barista
+		router.GET("/:shiftId/report", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetShiftReport)        // X report of the open shift, Z report of the closed one
+		router.GET("/:shiftId/report/pdf", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetShiftReportPDF) // franchise and store all roles
+		router.POST("/open", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.OpenShift)                           // Store manager and barista
+		router.POST("/:shiftId/close", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CloseShift)                // barista closes only own shift
+	}
+}
+
+func (r *Router) RegisterStoreTerminalRoutes(handler *storeTerminals.StoreTerminalHandler) {
+	router := r.EmployeeRoutes.Group("/store-terminals")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.GetStoreTerminals)
+		router.POST("", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.CreateStoreTerminal)
+		router.DELETE("/:id", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.DeleteStoreTerminal)
+	}
+}
+
+func (r *Router) RegisterStoreStationRoutes(handler *storeStations.StoreStationHandler) {
+	router := r.EmployeeRoutes.Group("/store-stations")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetStoreStations)
+		router.GET("/throughput", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetStoreStationsThroughput)
+		router.POST("", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.CreateStoreStation)
+		router.PUT("/:id", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.UpdateStoreStation)
+		router.DELETE("/:id", middleware.EmployeeRoleMiddleware(data.StoreManagementPermissions...), handler.DeleteStoreStation)
+	}
+}
+
+func (r *Router) RegisterBonusRoutes(handler *bonuses.BonusHandler) {
+	router := r.EmployeeRoutes.Group("/customers/:id/bonuses") // franchise and store all roles
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetCustomerBalance)
+		router.GET("/history", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetCustomerBonuses)
+	}
+}
+
 func (r *Router) RegisterStoreWarehouseRoutes(handler *storeStocks.StoreStockHandler) {
 	router := r.EmployeeRoutes.Group("/store-stocks") // Franchise and store all roles
 	{
@@ -387,6 +493,26 @@ func (r *Router) RegisterWarehouseRoutes(handler *warehouse.WarehouseHandler, wa
 	}
 }
 
+func (r *Router) RegisterPurchaseOrderRoutes(handler *purchaseOrders.PurchaseOrderHandler) {
+	router := r.EmployeeRoutes.Group("/purchase-orders")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.GetPurchaseOrders)                  // Region and warehouses
+		router.GET("/suggestions", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.GetPurchaseSuggestions) // Region and warehouses
+		router.GET("/:id", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.GetPurchaseOrderByID)           // Region and warehouses
+		router.GET("/:id/pdf", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.ExportPurchaseOrderPDF)     // Region and warehouses
+		router.GET("/:id/xlsx", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.ExportPurchaseOrderXLSX)   // Region and warehouses
+		router.POST("", middleware.EmployeeRoleMiddleware(data.WarehousePermissions...), handler.CreatePurchaseOrder)                   // Warehouse all roles
+		router.PUT("/:id", middleware.EmployeeRoleMiddleware(data.WarehousePermissions...), handler.UpdatePurchaseOrder)                // Warehouse all roles
+
+		statusGroup := router.Group("/:id/status")
+		{
+			statusGroup.PATCH("/sent", middleware.EmployeeRoleMiddleware(data.WarehousePermissions...), handler.SendPurchaseOrder)        // Warehouse
+			statusGroup.PATCH("/cancelled", middleware.EmployeeRoleMiddleware(data.WarehousePermissions...), handler.CancelPurchaseOrder) // Warehouse
+			statusGroup.PATCH("/closed", middleware.EmployeeRoleMiddleware(data.WarehousePermissions...), handler.ClosePurchaseOrder)     // Warehouse
+		}
+	}
+}
+
 func (r *Router) RegisterStockRequestRoutes(handler *stockRequests.StockRequestHandler) {
 	stockRequestReadPermissions := append(data.StoreAndWarehousePermissions, data.FranchiseeAndRegionPermissions...)
 
@@ -395,6 +521,7 @@ func (r *Router) RegisterStockRequestRoutes(handler *stockRequests.StockRequestH
 		router.GET("", middleware.EmployeeRoleMiddleware(stockRequestReadPermissions...), handler.GetStockRequests)                              // Store and warehouses all roles
 		router.GET("/:requestId", middleware.EmployeeRoleMiddleware(stockRequestReadPermissions...), handler.GetStockRequestByID)                // Store and warehouses all roles
 		router.GET("/current", middleware.EmployeeRoleMiddleware(data.StoreAndWarehousePermissions...), handler.GetLastCreatedStockRequest)      // Store and warehouses all roles
+		router.GET("/replenishment", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.GetReplenishmentSuggestion)            // Store all roles
 		router.POST("", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.CreateStockRequest)                                 // Store all roles
 		router.POST("/add-material-to-latest-cart", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.AddStockMaterialToCart) // Store all roles
 		router.PUT("/:requestId", middleware.EmployeeRoleMiddleware(data.StorePermissions...), handler.UpdateStockRequest)                       // Store all roles
@@ -412,6 +539,36 @@ func (r *Router) RegisterStockRequestRoutes(handler *stockRequests.StockRequestH
 	}
 }
 
+func (r *Router) RegisterStocktakeRoutes(handler *stocktakes.StocktakeHandler) {
+	stocktakeReadPermissions := slices.Concat(data.StoreAndWarehousePermissions, data.FranchiseeAndRegionPermissions)
+	stocktakeManagementPermissions := slices.Concat(data.StoreManagementPermissions, data.WarehouseManagementPermissions)
+
+	router := r.EmployeeRoutes.Group("/stocktakes")
+	{
+		router.GET("", middleware.EmployeeRoleMiddleware(stocktakeReadPermissions...), handler.GetStocktakes)                     // Store and warehouses all roles
+		router.GET("/:id", middleware.EmployeeRoleMiddleware(stocktakeReadPermissions...), handler.GetStocktakeByID)              // Store and warehouses all roles
+		router.GET("/:id/xlsx", middleware.EmployeeRoleMiddleware(stocktakeReadPermissions...), handler.ExportStocktakeXLSX)      // Store and warehouses all roles
+		router.POST("", middleware.EmployeeRoleMiddleware(stocktakeManagementPermissions...), handler.CreateStocktake)            // Store and warehouse managers
+		router.POST("/:id/counts", middleware.EmployeeRoleMiddleware(data.StoreAndWarehousePermissions...), handler.SubmitCounts) // Store and warehouses all roles
+
+		statusGroup := router.Group("/:id/status")
+		{
+			statusGroup.PATCH("/submitted", middleware.EmployeeRoleMiddleware(data.StoreAndWarehousePermissions...), handler.SubmitStocktake) // Store and warehouses all roles
+			statusGroup.PATCH("/in-progress", middleware.EmployeeRoleMiddleware(stocktakeManagementPermissions...), handler.RecountStocktake) // Store and warehouse managers
+			statusGroup.PATCH("/approved", middleware.EmployeeRoleMiddleware(stocktakeManagementPermissions...), handler.ApproveStocktake)    // Store and warehouse managers
+			statusGroup.PATCH("/cancelled", middleware.EmployeeRoleMiddleware(stocktakeManagementPermissions...), handler.CancelStocktake)    // Store and warehouse managers
+		}
+	}
+}
+
+func (r *Router) RegisterStockMovementRoutes(handler *stockMovements.StockMovementHandler) {
+	router := r.EmployeeRoutes.Group("/stock-movements")
+	{
+		router.GET("/store-stocks/:id", middleware.EmployeeRoleMiddleware(data.StoreReadPermissions...), handler.GetStoreStockMovements)                          // Franchise and store all roles
+		router.GET("/warehouse-stocks/:stockMaterialId", middleware.EmployeeRoleMiddleware(data.WarehouseReadPermissions...), handler.GetWarehouseStockMovements) // Region and warehouses all roles
+	}
+}
+
 func (r *Router) RegisterProvisionsRoutes(handler *provisions.ProvisionHandler, provisionTechMapHandler *provisionsTechnicalMap.TechnicalMapHandler) {
 	router := r.EmployeeRoutes.Group("/provisions")
 	{
@@ -457,5 +614,6 @@ func (r *Router) RegisterAnalyticRoutes(handler *analytics.AnalyticsHandler) {
 		router.GET("/summary", handler.GetSummary)
 		router.GET("/sales-by-month", handler.GetSalesByMonth)
 		router.GET("/popular-products", handler.GetPopularProducts)
+		router.GET("/discounts", handler.GetDiscountsByPromotion)
 	}
 }
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/bonuses"
+	"go.uber.org/zap"
+)
+
+type BonusCronTasks struct {
+	bonusService bonuses.BonusService
+	logger       *zap.SugaredLogger
+}
+
+func NewBonusCronTasks(bonusService bonuses.BonusService, logger *zap.SugaredLogger) *BonusCronTasks {
+	return &BonusCronTasks{
+		bonusService: bonusService,
+		logger:       logger,
+	}
+}
+
+func (tasks *BonusCronTasks) ExpireBonuses() {
+	tasks.logger.Info("Running ExpireBonuses...")
+
+	if err := tasks.bonusService.ExpireBonuses(); err != nil {
+		tasks.logger.Errorf("Failed to expire bonuses: %v", err)
+		return
+	}
+
+	tasks.logger.Info("Expire Bonuses completed.")
+}
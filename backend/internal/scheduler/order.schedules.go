@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders"
+	"go.uber.org/zap"
+)
+
+type OrderCronTasks struct {
+	orderService orders.OrderService
+	logger       *zap.SugaredLogger
+}
+
+func NewOrderCronTasks(orderService orders.OrderService, logger *zap.SugaredLogger) *OrderCronTasks {
+	return &OrderCronTasks{
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+// ReleaseScheduledOrders runs every minute, so it logs only the failures
+func (tasks *OrderCronTasks) ReleaseScheduledOrders() {
+	if err := tasks.orderService.ReleaseScheduledOrders(); err != nil {
+		tasks.logger.Errorf("Failed to release scheduled pickup orders: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/orders"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+	"go.uber.org/zap"
+)
+
+// orderPaymentsReconciliationHour is the store local hour, when the previous day is reconciled
+const orderPaymentsReconciliationHour = 0
+
+type PaymentCronTasks struct {
+	orderService orders.OrderService
+	storeService stores.StoreService
+	logger       *zap.SugaredLogger
+}
+
+func NewPaymentCronTasks(orderService orders.OrderService, storeService stores.StoreService, logger *zap.SugaredLogger) *PaymentCronTasks {
+	return &PaymentCronTasks{
+		orderService: orderService,
+		storeService: storeService,
+		logger:       logger,
+	}
+}
+
+func (tasks *PaymentCronTasks) ReconcileTransactions() {
+	tasks.logger.Info("Running ReconcileTransactions...")
+
+	if err := tasks.orderService.ReconcileTransactions(); err != nil {
+		tasks.logger.Errorf("Failed to reconcile payment transactions: %v", err)
+		return
+	}
+
+	tasks.logger.Info("Reconcile Transactions completed.")
+}
+
+// ReconcileOrderPayments checks the orders of the previous local day of the stores, where the new day has just started, against their transactions
+func (tasks *PaymentCronTasks) ReconcileOrderPayments() {
+	tasks.logger.Info("Running ReconcileOrderPayments...")
+
+	storesList, err := tasks.storeService.GetAllStoresForNotifications()
+	if err != nil {
+		tasks.logger.Errorf("Failed to fetch stores: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, store := range storesAtLocalHour(storesList, now, orderPaymentsReconciliationHour) {
+		date := now.In(storesTypes.StoreLocation(store.Timezone)).AddDate(0, 0, -1)
+		if err := tasks.orderService.ReconcileOrderPayments(date, &store.ID); err != nil {
+			tasks.logger.Errorf("Failed to reconcile order payments of store %d for %s: %v", store.ID, date.Format(time.DateOnly), err)
+		}
+	}
+
+	tasks.logger.Info("Reconcile Order Payments completed.")
+}
@@ -25,9 +25,12 @@ func NewCronManager(enabled bool, logger *zap.SugaredLogger) *CronManager {
 type CronJob string
 
 const (
+	MinutelyJob   CronJob = "MINUTELY"
 	HalfHourlyJob CronJob = "HALF_HOURLY"
 	HourlyJob     CronJob = "HOURLY"
 	DailyJob      CronJob = "DAILY"
+	// StoreDailyJob runs at the start of every hour, the task handles the stores at the wanted local hour
+	StoreDailyJob CronJob = "STORE_DAILY"
 )
 
 func (cm *CronManager) RegisterJob(interval CronJob, task func(), timeUTC ...string) error {
@@ -37,6 +40,11 @@ func (cm *CronManager) RegisterJob(interval CronJob, task func(), timeUTC ...str
 	}
 
 	switch interval {
+	case MinutelyJob:
+		_, err := cm.scheduler.Every(1).Minute().Do(task)
+		if err != nil {
+			return err
+		}
 	case HalfHourlyJob:
 		_, err := cm.scheduler.Every(30).Minute().Do(task)
 		if err != nil {
@@ -60,6 +68,11 @@ func (cm *CronManager) RegisterJob(interval CronJob, task func(), timeUTC ...str
 				return err
 			}
 		}
+	case StoreDailyJob:
+		_, err := cm.scheduler.Cron("0 * * * *").Do(task)
+		if err != nil {
+			return err
+		}
 	default:
 		cm.logger.Errorf("unsupported interval: %s", interval)
 		return fmt.Errorf("unsupported interval: %s", interval)
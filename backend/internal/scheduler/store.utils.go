@@ -0,0 +1,18 @@
+package scheduler
+
+import (
+	"time"
+
+	storesTypes "github.com/Global-Optima/zeep-web/backend/internal/modules/stores/types"
+)
+
+// storesAtLocalHour picks the stores where it is the given hour now, so the store daily jobs run once a day at the store local time
+func storesAtLocalHour(stores []storesTypes.StoreDTO, now time.Time, hour int) []storesTypes.StoreDTO {
+	var result []storesTypes.StoreDTO
+	for _, store := range stores {
+		if storesTypes.IsLocalHour(store.Timezone, now, hour) {
+			result = append(result, store)
+		}
+	}
+	return result
+}
@@ -54,7 +54,7 @@ func (tasks *StoreProvisionCronTasks) CheckStoreProvisionNotifications() {
 			continue
 		}
 
-		expiredStoreProvisionIDs, provisionIDsToRecalculate, err := tasks.formExpirationDetailsAndNotify(storeProvisionList)
+		expiredStoreProvisionIDs, provisionIDsToRecalculate, err := tasks.formExpirationDetailsAndNotify(storeProvisionList, store.IsOpen)
 		if err != nil {
 			tasks.logger.Errorf("failed to send store provision expiration notification: %v", err)
 		}
@@ -80,6 +80,7 @@ func (tasks *StoreProvisionCronTasks) CheckStoreProvisionNotifications() {
 
 func (tasks *StoreProvisionCronTasks) formExpirationDetailsAndNotify(
 	storeProvisionList []data.StoreProvision,
+	notify bool, // the provisions of the closed stores are expired without the notifications
 ) (expiredProvisionIDs []uint, provisionIDs []uint, err error) {
 	provisionIDSet := make(map[uint]struct{})
 
@@ -90,6 +91,10 @@ func (tasks *StoreProvisionCronTasks) formExpirationDetailsAndNotify(
 			provisionIDs = append(provisionIDs, storeProvision.ProvisionID)
 		}
 
+		if !notify {
+			continue
+		}
+
 		spDetails := &details.StoreProvisionExpirationDetails{
 			BaseNotificationDetails: details.BaseNotificationDetails{
 				ID:           storeProvision.StoreID,
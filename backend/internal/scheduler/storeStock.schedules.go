@@ -1,11 +1,16 @@
 package scheduler
 
 import (
+	"time"
+
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/storeStocks"
 	"github.com/Global-Optima/zeep-web/backend/internal/modules/stores"
 	"go.uber.org/zap"
 )
 
+// stockNotificationsHour is the store local hour of the daily stock check, in the morning most of the stores are open
+const stockNotificationsHour = 9
+
 type StoreStockCronTasks struct {
 	storeStockService storeStocks.StoreStockService
 	storeStockRepo    storeStocks.StoreStockRepository
@@ -31,7 +36,12 @@ func (tasks *StoreStockCronTasks) CheckStockNotifications() {
 		return
 	}
 
-	for _, store := range storesList {
+	for _, store := range storesAtLocalHour(storesList, time.Now(), stockNotificationsHour) {
+		if !store.IsOpen {
+			tasks.logger.Infof("Skipping closed store %d", store.ID)
+			continue
+		}
+
 		processedStocks := make(map[uint]bool)
 
 		stockList, err := tasks.storeStockRepo.GetAllStockList(store.ID)
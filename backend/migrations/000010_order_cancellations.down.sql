@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_suborders_cancellation_id;
+
+ALTER TABLE suborders
+    DROP COLUMN IF EXISTS cancellation_id;
+
+DROP TABLE IF EXISTS order_cancellations;
@@ -0,0 +1,20 @@
+CREATE TABLE order_cancellations (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL,
+    comment TEXT,
+    status VARCHAR(50) NOT NULL,
+    requested_by_id INT NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    reviewed_by_id INT REFERENCES employees(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_order_cancellations_order_id ON order_cancellations(order_id);
+
+ALTER TABLE suborders
+    ADD COLUMN cancellation_id INT REFERENCES order_cancellations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_suborders_cancellation_id ON suborders(cancellation_id);
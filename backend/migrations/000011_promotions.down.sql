@@ -0,0 +1,12 @@
+ALTER TABLE product_sizes
+    ADD COLUMN IF NOT EXISTS discount_id INT;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_total;
+
+ALTER TABLE suborders
+    DROP COLUMN IF EXISTS discount_amount;
+
+DROP TABLE IF EXISTS suborder_discounts;
+
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,52 @@
+CREATE TABLE promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    type VARCHAR(50) NOT NULL,
+    value DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (value >= 0),
+    buy_quantity INT CHECK (buy_quantity > 0),
+    get_quantity INT CHECK (get_quantity > 0),
+    start_time VARCHAR(5),
+    end_time VARCHAR(5),
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    franchisee_id INT REFERENCES franchisees(id) ON DELETE CASCADE,
+    store_id INT REFERENCES stores(id) ON DELETE CASCADE,
+    product_category_id INT REFERENCES product_categories(id) ON DELETE CASCADE,
+    product_size_id INT REFERENCES product_sizes(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_promotions_franchisee_id ON promotions(franchisee_id);
+CREATE INDEX idx_promotions_store_id ON promotions(store_id);
+CREATE INDEX idx_promotions_product_category_id ON promotions(product_category_id);
+CREATE INDEX idx_promotions_product_size_id ON promotions(product_size_id);
+CREATE INDEX idx_promotions_active_period ON promotions(starts_at, ends_at) WHERE deleted_at IS NULL AND is_active;
+
+CREATE TABLE suborder_discounts (
+    id SERIAL PRIMARY KEY,
+    suborder_id INT NOT NULL REFERENCES suborders(id) ON DELETE CASCADE,
+    promotion_id INT REFERENCES promotions(id) ON DELETE SET NULL,
+    promotion_name VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount >= 0),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_suborder_discounts_suborder_id ON suborder_discounts(suborder_id);
+CREATE INDEX idx_suborder_discounts_promotion_id ON suborder_discounts(promotion_id);
+
+ALTER TABLE suborders
+    ADD COLUMN discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (discount_amount >= 0);
+
+ALTER TABLE orders
+    ADD COLUMN discount_total DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (discount_total >= 0);
+
+-- discounts are linked from promotions now
+ALTER TABLE product_sizes
+    DROP COLUMN IF EXISTS discount_id;
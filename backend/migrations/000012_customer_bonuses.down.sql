@@ -0,0 +1,15 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS bonuses_redeemed;
+
+DROP INDEX IF EXISTS idx_referrals_referee_id;
+ALTER TABLE referrals DROP COLUMN IF EXISTS rewarded_at;
+
+DROP INDEX IF EXISTS idx_bonuses_expires_at;
+DROP INDEX IF EXISTS idx_bonuses_referral_id;
+DROP INDEX IF EXISTS idx_bonuses_order_id;
+DROP INDEX IF EXISTS idx_bonuses_customer_id;
+
+ALTER TABLE bonuses
+    DROP COLUMN IF EXISTS referral_id,
+    DROP COLUMN IF EXISTS order_id,
+    DROP COLUMN IF EXISTS type,
+    DROP COLUMN IF EXISTS remaining;
@@ -0,0 +1,18 @@
+ALTER TABLE bonuses
+    ADD COLUMN remaining DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (remaining >= 0),
+    ADD COLUMN type VARCHAR(50) NOT NULL DEFAULT 'ACCRUAL',
+    ADD COLUMN order_id INT REFERENCES orders(id) ON DELETE SET NULL,
+    ADD COLUMN referral_id INT REFERENCES referrals(id) ON DELETE SET NULL;
+
+-- bonuses issued before the ledger are unspent credits
+UPDATE bonuses SET remaining = COALESCE(bonuses, 0);
+
+CREATE INDEX idx_bonuses_customer_id ON bonuses(customer_id);
+CREATE INDEX idx_bonuses_order_id ON bonuses(order_id);
+CREATE INDEX idx_bonuses_referral_id ON bonuses(referral_id);
+CREATE INDEX idx_bonuses_expires_at ON bonuses(expires_at) WHERE deleted_at IS NULL AND remaining > 0;
+
+ALTER TABLE referrals ADD COLUMN rewarded_at TIMESTAMPTZ;
+CREATE INDEX idx_referrals_referee_id ON referrals(referee_id);
+
+ALTER TABLE orders ADD COLUMN bonuses_redeemed DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (bonuses_redeemed >= 0);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_orders_courier_id;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS delivered_at,
+    DROP COLUMN IF EXISTS courier_id,
+    DROP COLUMN IF EXISTS delivery_distance,
+    DROP COLUMN IF EXISTS delivery_fee;
@@ -0,0 +1,7 @@
+ALTER TABLE orders
+    ADD COLUMN delivery_fee DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (delivery_fee >= 0),
+    ADD COLUMN delivery_distance DECIMAL(10, 2),
+    ADD COLUMN courier_id INT REFERENCES store_employees(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    ADD COLUMN delivered_at TIMESTAMPTZ;
+
+CREATE INDEX idx_orders_courier_id ON orders(courier_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_unreconciled;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS reconciled_at,
+    DROP COLUMN IF EXISTS reconciliation_status,
+    DROP COLUMN IF EXISTS provider;
+
+DROP TABLE IF EXISTS payment_intents;
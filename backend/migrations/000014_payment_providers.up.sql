@@ -0,0 +1,22 @@
+CREATE TABLE payment_intents (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    external_id VARCHAR(64) UNIQUE NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_payment_intents_order_id ON payment_intents(order_id);
+
+-- transactions stored before the providers were introduced come from the POS terminals
+ALTER TABLE transactions
+    ADD COLUMN provider VARCHAR(50) NOT NULL DEFAULT 'POS',
+    ADD COLUMN reconciliation_status VARCHAR(50),
+    ADD COLUMN reconciled_at TIMESTAMPTZ;
+
+CREATE INDEX idx_transactions_unreconciled ON transactions(provider, created_at) WHERE reconciled_at IS NULL AND deleted_at IS NULL;
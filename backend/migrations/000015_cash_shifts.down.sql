@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_cash_shift_id;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS cash_shift_id;
+
+DROP TABLE IF EXISTS cash_shifts;
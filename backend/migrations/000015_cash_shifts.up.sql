@@ -0,0 +1,26 @@
+CREATE TABLE cash_shifts (
+    id SERIAL PRIMARY KEY,
+    store_id INT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    store_employee_id INT NOT NULL REFERENCES store_employees(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    opening_float DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (opening_float >= 0),
+    counted_cash DECIMAL(10, 2),
+    expected_cash DECIMAL(10, 2),
+    cash_variance DECIMAL(10, 2),
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_cash_shifts_store_id ON cash_shifts(store_id);
+CREATE INDEX idx_cash_shifts_store_employee_id ON cash_shifts(store_employee_id);
+
+-- an employee works with a single open shift at a time
+CREATE UNIQUE INDEX idx_cash_shifts_open_employee ON cash_shifts(store_employee_id) WHERE status = 'OPEN' AND deleted_at IS NULL;
+
+ALTER TABLE orders
+    ADD COLUMN cash_shift_id INT REFERENCES cash_shifts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_orders_cash_shift_id ON orders(cash_shift_id);
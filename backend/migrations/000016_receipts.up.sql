@@ -0,0 +1,53 @@
+CREATE TABLE receipt_sequences (
+    store_id INT PRIMARY KEY REFERENCES stores(id) ON DELETE CASCADE,
+    last_number INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE receipts (
+    id SERIAL PRIMARY KEY,
+    store_id INT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    transaction_id INT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    number INT NOT NULL,
+    subtotal DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    discount_total DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    delivery_fee DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    bonuses_paid DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(10, 2) NOT NULL,
+    tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    payment_method VARCHAR(50) NOT NULL,
+    card_mask VARCHAR(16),
+    print_count INT NOT NULL DEFAULT 0,
+    last_printed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_receipts_store_id ON receipts(store_id);
+CREATE INDEX idx_receipts_order_id ON receipts(order_id);
+
+-- a single receipt is issued per payment or refund transaction
+CREATE UNIQUE INDEX idx_receipts_transaction_id ON receipts(transaction_id);
+CREATE UNIQUE INDEX idx_receipts_store_number ON receipts(store_id, number);
+
+CREATE TABLE receipt_items (
+    id SERIAL PRIMARY KEY,
+    receipt_id INT NOT NULL REFERENCES receipts(id) ON DELETE CASCADE,
+    suborder_id INT REFERENCES suborders(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    size VARCHAR(100),
+    additives VARCHAR(1024),
+    price DECIMAL(10, 2) NOT NULL,
+    discount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(10, 2) NOT NULL,
+    tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_receipt_items_receipt_id ON receipt_items(receipt_id);
+CREATE INDEX idx_receipt_items_suborder_id ON receipt_items(suborder_id);
@@ -0,0 +1,24 @@
+ALTER TABLE receipts
+    ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0;
+
+UPDATE receipts
+SET tax_rate = items.tax_rate
+FROM (
+    SELECT receipt_id, MAX(tax_rate) AS tax_rate
+    FROM receipt_items
+    GROUP BY receipt_id
+) items
+WHERE items.receipt_id = receipts.id;
+
+ALTER TABLE receipt_items
+    DROP COLUMN IF EXISTS tax_rate;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_total;
+
+ALTER TABLE suborders
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS tax_mode,
+    DROP COLUMN IF EXISTS tax_rate;
+
+DROP TABLE IF EXISTS tax_rates;
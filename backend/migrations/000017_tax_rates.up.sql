@@ -0,0 +1,39 @@
+CREATE TABLE tax_rates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    rate DECIMAL(5, 2) NOT NULL CHECK (rate >= 0 AND rate <= 100),
+    mode VARCHAR(20) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    region_id INT REFERENCES regions(id) ON DELETE CASCADE,
+    franchisee_id INT REFERENCES franchisees(id) ON DELETE CASCADE,
+    product_category_id INT REFERENCES product_categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    -- a rate belongs either to a region or to a franchisee
+    CHECK (region_id IS NULL OR franchisee_id IS NULL)
+);
+
+CREATE INDEX idx_tax_rates_region_id ON tax_rates(region_id);
+CREATE INDEX idx_tax_rates_franchisee_id ON tax_rates(franchisee_id);
+CREATE INDEX idx_tax_rates_product_category_id ON tax_rates(product_category_id);
+
+ALTER TABLE suborders
+    ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_mode VARCHAR(20) NOT NULL DEFAULT 'INCLUSIVE',
+    ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (tax_amount >= 0);
+
+ALTER TABLE orders
+    ADD COLUMN tax_total DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (tax_total >= 0);
+
+-- receipts keep the rate of every item instead of a single rate
+ALTER TABLE receipt_items
+    ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0;
+
+UPDATE receipt_items
+SET tax_rate = receipts.tax_rate
+FROM receipts
+WHERE receipts.id = receipt_items.receipt_id;
+
+ALTER TABLE receipts
+    DROP COLUMN IF EXISTS tax_rate;
@@ -0,0 +1,15 @@
+DELETE FROM employee_tokens;
+
+DROP INDEX IF EXISTS idx_employee_tokens_employee_id;
+DROP INDEX IF EXISTS idx_employee_tokens_previous_refresh_token_hash;
+DROP INDEX IF EXISTS idx_employee_tokens_refresh_token_hash;
+
+ALTER TABLE employee_tokens
+    DROP COLUMN ip_address,
+    DROP COLUMN user_agent,
+    DROP COLUMN device_name,
+    DROP COLUMN last_used_at,
+    DROP COLUMN previous_refresh_token_hash,
+    DROP COLUMN refresh_token_hash,
+    ADD COLUMN token VARCHAR(255) NOT NULL UNIQUE,
+    ADD CONSTRAINT employee_tokens_employee_id_key UNIQUE (employee_id);
@@ -0,0 +1,17 @@
+-- every login gets its own session, the existing single-device tokens are dropped and the employees log in again
+DELETE FROM employee_tokens;
+
+ALTER TABLE employee_tokens
+    DROP CONSTRAINT IF EXISTS employee_tokens_employee_id_key,
+    DROP CONSTRAINT IF EXISTS employee_tokens_token_key,
+    DROP COLUMN token,
+    ADD COLUMN refresh_token_hash VARCHAR(64) NOT NULL,
+    ADD COLUMN previous_refresh_token_hash VARCHAR(64),
+    ADD COLUMN last_used_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN device_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_employee_tokens_refresh_token_hash ON employee_tokens(refresh_token_hash);
+CREATE INDEX idx_employee_tokens_previous_refresh_token_hash ON employee_tokens(previous_refresh_token_hash);
+CREATE INDEX idx_employee_tokens_employee_id ON employee_tokens(employee_id);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS suborder_status_changes;
+
+DROP INDEX IF EXISTS idx_employee_tokens_store_terminal_id;
+
+ALTER TABLE employee_tokens
+    DROP COLUMN IF EXISTS store_terminal_id;
+
+ALTER TABLE store_employees
+    DROP COLUMN IF EXISTS pin_locked_until,
+    DROP COLUMN IF EXISTS pin_failed_attempts,
+    DROP COLUMN IF EXISTS pin_hash;
+
+DROP TABLE IF EXISTS store_terminals;
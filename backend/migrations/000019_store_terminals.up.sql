@@ -0,0 +1,37 @@
+CREATE TABLE store_terminals (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    store_id INT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_store_terminals_token_hash ON store_terminals(token_hash);
+CREATE INDEX idx_store_terminals_store_id ON store_terminals(store_id);
+
+ALTER TABLE store_employees
+    ADD COLUMN pin_hash VARCHAR(255),
+    ADD COLUMN pin_failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN pin_locked_until TIMESTAMPTZ;
+
+-- the PIN sessions are bound to the terminal, switching the operator closes the session of the previous one
+ALTER TABLE employee_tokens
+    ADD COLUMN store_terminal_id INT REFERENCES store_terminals(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_employee_tokens_store_terminal_id ON employee_tokens(store_terminal_id);
+
+CREATE TABLE suborder_status_changes (
+    id SERIAL PRIMARY KEY,
+    suborder_id INT NOT NULL REFERENCES suborders(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    store_employee_id INT REFERENCES store_employees(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_suborder_status_changes_suborder_id ON suborder_status_changes(suborder_id);
+CREATE INDEX idx_suborder_status_changes_store_employee_id ON suborder_status_changes(store_employee_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_verification_codes_customer_purpose;
+
+ALTER TABLE verification_codes
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS purpose;
@@ -0,0 +1,5 @@
+ALTER TABLE verification_codes
+    ADD COLUMN purpose VARCHAR(30) NOT NULL DEFAULT 'PHONE_VERIFICATION',
+    ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_verification_codes_customer_purpose ON verification_codes(customer_id, purpose);
@@ -0,0 +1,8 @@
+ALTER TABLE stores
+    DROP COLUMN IF EXISTS pickup_slot_capacity;
+
+DROP INDEX IF EXISTS idx_orders_store_pickup_at;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS released_at,
+    DROP COLUMN IF EXISTS pickup_at;
@@ -0,0 +1,9 @@
+-- pickup_at is the scheduled pickup slot of a customer order, NULL means "as soon as possible"
+ALTER TABLE orders
+    ADD COLUMN pickup_at TIMESTAMPTZ,
+    ADD COLUMN released_at TIMESTAMPTZ;
+
+CREATE INDEX idx_orders_store_pickup_at ON orders(store_id, pickup_at) WHERE pickup_at IS NOT NULL;
+
+ALTER TABLE stores
+    ADD COLUMN pickup_slot_capacity INT NOT NULL DEFAULT 10 CHECK (pickup_slot_capacity >= 0);
@@ -0,0 +1,14 @@
+ALTER TABLE stores
+	ADD COLUMN store_hours VARCHAR(255);
+
+UPDATE stores s
+SET store_hours = to_char(h.open_at, 'HH24:MI') || '-' || to_char(h.close_at, 'HH24:MI')
+FROM store_working_hours h
+WHERE h.store_id = s.id AND h.day = 'MONDAY' AND h.deleted_at IS NULL;
+
+ALTER TABLE stores
+	DROP COLUMN IF EXISTS timezone;
+
+DROP TABLE IF EXISTS store_hours_exceptions;
+
+DROP TABLE IF EXISTS store_working_hours;
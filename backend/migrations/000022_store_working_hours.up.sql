@@ -0,0 +1,55 @@
+-- Weekly schedule of the stores, a weekday without a row is a day off
+CREATE TABLE
+	store_working_hours (
+		id SERIAL PRIMARY KEY,
+		store_id INT NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+		day VARCHAR(15) NOT NULL,
+		open_at TIME NOT NULL,
+		close_at TIME NOT NULL,
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		deleted_at TIMESTAMPTZ
+	);
+
+CREATE UNIQUE INDEX unique_store_working_hours_day ON store_working_hours (store_id, day) WHERE deleted_at IS NULL;
+
+-- Holidays and special hours overriding the weekly schedule on a date
+CREATE TABLE
+	store_hours_exceptions (
+		id SERIAL PRIMARY KEY,
+		store_id INT NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+		date DATE NOT NULL,
+		is_closed BOOLEAN NOT NULL DEFAULT FALSE,
+		open_at TIME,
+		close_at TIME,
+		reason VARCHAR(255),
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		deleted_at TIMESTAMPTZ,
+		CHECK (is_closed OR (open_at IS NOT NULL AND close_at IS NOT NULL))
+	);
+
+CREATE UNIQUE INDEX unique_store_hours_exception_date ON store_hours_exceptions (store_id, date) WHERE deleted_at IS NULL;
+
+ALTER TABLE stores
+	ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- The free-form hours like "8:00-20:00" become the same hours on every day,
+-- the ones which cannot be parsed are left unset and the store is treated as always open
+INSERT INTO store_working_hours (store_id, day, open_at, close_at)
+SELECT s.id, d.day,
+	make_time(h.parts[1]::INT % 24, COALESCE(h.parts[2], '0')::INT, 0),
+	make_time(h.parts[3]::INT % 24, COALESCE(h.parts[4], '0')::INT, 0)
+FROM stores s
+CROSS JOIN LATERAL (
+	SELECT regexp_match(s.store_hours, '^\s*(\d{1,2})(?::(\d{2}))?\s*-\s*(\d{1,2})(?::(\d{2}))?\s*$') AS parts
+) h
+CROSS JOIN (
+	VALUES ('MONDAY'), ('TUESDAY'), ('WEDNESDAY'), ('THURSDAY'), ('FRIDAY'), ('SATURDAY'), ('SUNDAY')
+) AS d (day)
+WHERE h.parts IS NOT NULL
+	AND h.parts[1]::INT <= 24 AND h.parts[3]::INT <= 24
+	AND COALESCE(h.parts[2], '0')::INT < 60 AND COALESCE(h.parts[4], '0')::INT < 60;
+
+ALTER TABLE stores
+	DROP COLUMN store_hours;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_suborders_station_id;
+
+ALTER TABLE suborders
+    DROP COLUMN IF EXISTS station_id;
+
+DROP TABLE IF EXISTS store_station_categories;
+
+DROP TABLE IF EXISTS store_stations;
@@ -0,0 +1,31 @@
+CREATE TABLE store_stations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    store_id INT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_store_stations_store_id ON store_stations(store_id);
+CREATE UNIQUE INDEX unique_store_station_name ON store_stations(store_id, name) WHERE deleted_at IS NULL;
+
+-- a machine category of the store is prepared by one station at most
+CREATE TABLE store_station_categories (
+    id SERIAL PRIMARY KEY,
+    station_id INT NOT NULL REFERENCES store_stations(id) ON DELETE CASCADE,
+    store_id INT NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    machine_category VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_store_station_categories_station_id ON store_station_categories(station_id);
+CREATE UNIQUE INDEX unique_store_station_category ON store_station_categories(store_id, machine_category) WHERE deleted_at IS NULL;
+
+-- the station is kept on the suborder for the throughput reports, the routing is not changed by a later reconfiguration
+ALTER TABLE suborders
+    ADD COLUMN station_id INT REFERENCES store_stations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_suborders_station_id ON suborders(station_id);
@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS estimated_ready_at;
+
+ALTER TABLE suborders
+    DROP COLUMN IF EXISTS started_at;
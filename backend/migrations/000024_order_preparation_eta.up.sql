@@ -0,0 +1,16 @@
+ALTER TABLE suborders
+    ADD COLUMN started_at TIMESTAMPTZ;
+
+-- the start of the preparation of the existing suborders is taken from their status history
+UPDATE suborders
+SET started_at = changes.started_at
+FROM (
+    SELECT suborder_id, MIN(created_at) AS started_at
+    FROM suborder_status_changes
+    WHERE status = 'PREPARING' AND deleted_at IS NULL
+    GROUP BY suborder_id
+) AS changes
+WHERE suborders.id = changes.suborder_id;
+
+ALTER TABLE orders
+    ADD COLUMN estimated_ready_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_supplier_warehouse_deliveries_purchase_order_id;
+
+ALTER TABLE supplier_warehouse_deliveries
+    DROP COLUMN IF EXISTS purchase_order_id;
+
+DROP TABLE IF EXISTS purchase_order_lines;
+
+DROP TABLE IF EXISTS purchase_orders;
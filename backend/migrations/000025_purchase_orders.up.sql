@@ -0,0 +1,39 @@
+CREATE TABLE purchase_orders (
+    id SERIAL PRIMARY KEY,
+    warehouse_id INT NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    supplier_id INT NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    expected_delivery_date TIMESTAMPTZ,
+    total DECIMAL(12,2) NOT NULL DEFAULT 0,
+    sent_at TIMESTAMPTZ,
+    received_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_purchase_orders_warehouse_id ON purchase_orders(warehouse_id);
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders(supplier_id);
+
+-- the received quantity may exceed the ordered one, the difference is the over-delivery of the line
+CREATE TABLE purchase_order_lines (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    stock_material_id INT NOT NULL REFERENCES stock_materials(id) ON DELETE CASCADE,
+    quantity DECIMAL(10,2) NOT NULL CHECK (quantity > 0),
+    received_quantity DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_price DECIMAL(10,2) NOT NULL CHECK (unit_price >= 0),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_purchase_order_lines_purchase_order_id ON purchase_order_lines(purchase_order_id);
+CREATE INDEX idx_purchase_order_lines_stock_material_id ON purchase_order_lines(stock_material_id);
+
+ALTER TABLE supplier_warehouse_deliveries
+    ADD COLUMN purchase_order_id INT REFERENCES purchase_orders(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_supplier_warehouse_deliveries_purchase_order_id ON supplier_warehouse_deliveries(purchase_order_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_request_ingredient_lots;
+
+DROP TABLE IF EXISTS warehouse_stock_lots;
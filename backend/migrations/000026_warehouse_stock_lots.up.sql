@@ -0,0 +1,50 @@
+CREATE TABLE warehouse_stock_lots (
+    id SERIAL PRIMARY KEY,
+    warehouse_id INT NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    stock_material_id INT NOT NULL REFERENCES stock_materials(id) ON DELETE CASCADE,
+    delivery_material_id INT REFERENCES supplier_warehouse_delivery_materials(id) ON DELETE SET NULL,
+    barcode VARCHAR(255) NOT NULL,
+    expiration_date TIMESTAMPTZ NOT NULL,
+    received_quantity DECIMAL(10,2) NOT NULL,
+    quantity DECIMAL(10,2) NOT NULL CHECK (quantity >= 0),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_warehouse_stock_lots_warehouse_id ON warehouse_stock_lots(warehouse_id);
+CREATE INDEX idx_warehouse_stock_lots_stock_material_id ON warehouse_stock_lots(stock_material_id);
+CREATE INDEX idx_warehouse_stock_lots_delivery_material_id ON warehouse_stock_lots(delivery_material_id);
+
+CREATE TABLE stock_request_ingredient_lots (
+    id SERIAL PRIMARY KEY,
+    stock_request_ingredient_id INT NOT NULL REFERENCES stock_request_ingredients(id) ON DELETE CASCADE,
+    lot_id INT NOT NULL REFERENCES warehouse_stock_lots(id) ON DELETE CASCADE,
+    quantity DECIMAL(10,2) NOT NULL CHECK (quantity > 0),
+    expiration_date TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_stock_request_ingredient_lots_stock_request_ingredient_id ON stock_request_ingredient_lots(stock_request_ingredient_id);
+CREATE INDEX idx_stock_request_ingredient_lots_lot_id ON stock_request_ingredient_lots(lot_id);
+
+-- the stock kept before the lots were tracked becomes a single lot expiring with the earliest delivery of the material
+INSERT INTO warehouse_stock_lots (warehouse_id, stock_material_id, barcode, expiration_date, received_quantity, quantity)
+SELECT ws.warehouse_id,
+       ws.stock_material_id,
+       sm.barcode,
+       COALESCE(
+           (SELECT MIN(swdm.expiration_date)
+            FROM supplier_warehouse_delivery_materials swdm
+            JOIN supplier_warehouse_deliveries swd ON swd.id = swdm.delivery_id
+            WHERE swd.warehouse_id = ws.warehouse_id AND swdm.stock_material_id = ws.stock_material_id
+              AND swdm.deleted_at IS NULL),
+           CURRENT_TIMESTAMP + sm.expiration_period_in_days * INTERVAL '1 day'
+       ),
+       ws.quantity,
+       ws.quantity
+FROM warehouse_stocks ws
+JOIN stock_materials sm ON sm.id = ws.stock_material_id
+WHERE ws.quantity > 0 AND ws.deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stock_adjustments;
+
+DROP TABLE IF EXISTS stocktake_lines;
+
+DROP TABLE IF EXISTS stocktakes;
@@ -0,0 +1,72 @@
+CREATE TABLE stocktakes (
+    id SERIAL PRIMARY KEY,
+    store_id INT REFERENCES stores(id) ON DELETE CASCADE,
+    warehouse_id INT REFERENCES warehouses(id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    started_by_id INT NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    approved_by_id INT REFERENCES employees(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMPTZ,
+    approved_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    CHECK ((store_id IS NULL) <> (warehouse_id IS NULL))
+);
+
+CREATE INDEX idx_stocktakes_store_id ON stocktakes(store_id);
+CREATE INDEX idx_stocktakes_warehouse_id ON stocktakes(warehouse_id);
+CREATE INDEX idx_stocktakes_started_by_id ON stocktakes(started_by_id);
+CREATE INDEX idx_stocktakes_approved_by_id ON stocktakes(approved_by_id);
+
+-- a facility counts its stock in one stocktake at a time
+CREATE UNIQUE INDEX uq_stocktakes_open_store ON stocktakes(store_id)
+    WHERE status IN ('IN_PROGRESS', 'SUBMITTED') AND deleted_at IS NULL;
+CREATE UNIQUE INDEX uq_stocktakes_open_warehouse ON stocktakes(warehouse_id)
+    WHERE status IN ('IN_PROGRESS', 'SUBMITTED') AND deleted_at IS NULL;
+
+CREATE TABLE stocktake_lines (
+    id SERIAL PRIMARY KEY,
+    stocktake_id INT NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    ingredient_id INT REFERENCES ingredients(id) ON DELETE CASCADE,
+    stock_material_id INT REFERENCES stock_materials(id) ON DELETE CASCADE,
+    expected_quantity DECIMAL(10,2) NOT NULL,
+    counted_quantity DECIMAL(10,2) CHECK (counted_quantity >= 0),
+    counted_by_id INT REFERENCES employees(id) ON DELETE SET NULL,
+    reason VARCHAR(30),
+    comment TEXT NOT NULL DEFAULT '',
+    counted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    CHECK ((ingredient_id IS NULL) <> (stock_material_id IS NULL))
+);
+
+CREATE INDEX idx_stocktake_lines_stocktake_id ON stocktake_lines(stocktake_id);
+CREATE INDEX idx_stocktake_lines_ingredient_id ON stocktake_lines(ingredient_id);
+CREATE INDEX idx_stocktake_lines_stock_material_id ON stocktake_lines(stock_material_id);
+CREATE INDEX idx_stocktake_lines_counted_by_id ON stocktake_lines(counted_by_id);
+
+CREATE TABLE stock_adjustments (
+    id SERIAL PRIMARY KEY,
+    store_id INT REFERENCES stores(id) ON DELETE CASCADE,
+    warehouse_id INT REFERENCES warehouses(id) ON DELETE CASCADE,
+    ingredient_id INT REFERENCES ingredients(id) ON DELETE CASCADE,
+    stock_material_id INT REFERENCES stock_materials(id) ON DELETE CASCADE,
+    stocktake_id INT REFERENCES stocktakes(id) ON DELETE SET NULL,
+    quantity DECIMAL(10,2) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    employee_id INT NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_stock_adjustments_store_id ON stock_adjustments(store_id);
+CREATE INDEX idx_stock_adjustments_warehouse_id ON stock_adjustments(warehouse_id);
+CREATE INDEX idx_stock_adjustments_ingredient_id ON stock_adjustments(ingredient_id);
+CREATE INDEX idx_stock_adjustments_stock_material_id ON stock_adjustments(stock_material_id);
+CREATE INDEX idx_stock_adjustments_stocktake_id ON stock_adjustments(stocktake_id);
+CREATE INDEX idx_stock_adjustments_employee_id ON stock_adjustments(employee_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS stock_movements_append_only ON stock_movements;
+DROP FUNCTION IF EXISTS reject_stock_movement_change();
+DROP TABLE IF EXISTS stock_movements;
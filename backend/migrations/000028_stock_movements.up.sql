@@ -0,0 +1,35 @@
+CREATE TABLE stock_movements (
+    id SERIAL PRIMARY KEY,
+    store_id INT REFERENCES stores(id) ON DELETE RESTRICT,
+    ingredient_id INT REFERENCES ingredients(id) ON DELETE RESTRICT,
+    warehouse_id INT REFERENCES warehouses(id) ON DELETE RESTRICT,
+    stock_material_id INT REFERENCES stock_materials(id) ON DELETE RESTRICT,
+    source_type VARCHAR(30) NOT NULL,
+    source_id INT,
+    quantity_before DECIMAL(10,2) NOT NULL,
+    quantity_after DECIMAL(10,2) NOT NULL,
+    quantity DECIMAL(10,2) NOT NULL,
+    employee_id INT REFERENCES employees(id) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (
+        (store_id IS NOT NULL AND ingredient_id IS NOT NULL AND warehouse_id IS NULL AND stock_material_id IS NULL)
+        OR (store_id IS NULL AND ingredient_id IS NULL AND warehouse_id IS NOT NULL AND stock_material_id IS NOT NULL)
+    )
+);
+
+-- the ledger is read per stock item, newest first
+CREATE INDEX idx_stock_movements_store_item ON stock_movements(store_id, ingredient_id, created_at DESC);
+CREATE INDEX idx_stock_movements_warehouse_item ON stock_movements(warehouse_id, stock_material_id, created_at DESC);
+CREATE INDEX idx_stock_movements_source ON stock_movements(source_type, source_id);
+CREATE INDEX idx_stock_movements_employee_id ON stock_movements(employee_id);
+
+-- the ledger is append-only, a wrong movement is corrected by a new one
+CREATE FUNCTION reject_stock_movement_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only: % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_append_only
+    BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION reject_stock_movement_change();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_cash_shift_id;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS cash_shift_id;
+
+ALTER TABLE cash_shifts
+    DROP COLUMN IF EXISTS report;
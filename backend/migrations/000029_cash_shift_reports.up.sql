@@ -0,0 +1,14 @@
+-- the Z report is saved on close, so the later changes of the orders do not rewrite it
+ALTER TABLE cash_shifts
+    ADD COLUMN report JSONB;
+
+-- the refunds belong to the shift open when the money is returned, not to the shift of the sale
+ALTER TABLE transactions
+    ADD COLUMN cash_shift_id INT REFERENCES cash_shifts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_transactions_cash_shift_id ON transactions(cash_shift_id);
+
+UPDATE transactions
+SET cash_shift_id = orders.cash_shift_id
+FROM orders
+WHERE orders.id = transactions.order_id;
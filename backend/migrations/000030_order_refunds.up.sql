@@ -0,0 +1,19 @@
+-- the refund is recorded before the provider returns the money, so a refund paid out by the provider is never lost
+CREATE TABLE order_refunds (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    suborder_ids JSONB NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    provider_transaction_id VARCHAR(64),
+    transaction_id INT REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_order_refunds_order_id ON order_refunds(order_id);
+CREATE INDEX idx_order_refunds_transaction_id ON order_refunds(transaction_id);
+
+-- one refund of an order is processed at a time
+CREATE UNIQUE INDEX unique_pending_order_refund ON order_refunds(order_id) WHERE status = 'PENDING' AND deleted_at IS NULL;
@@ -1,5 +1,14 @@
 package utils
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const earthRadiusKm = 6371.0
+
 func IsValidLatitude(lat float64) bool {
 	return lat >= -90 && lat <= 90
 }
@@ -7,3 +16,33 @@ func IsValidLatitude(lat float64) bool {
 func IsValidLongitude(lon float64) bool {
 	return lon >= -180 && lon <= 180
 }
+
+// ParseCoordinates parses coordinates stored as strings, e.g. in customer addresses
+func ParseCoordinates(latitude, longitude string) (float64, float64, error) {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	if err != nil || !IsValidLatitude(lat) {
+		return 0, 0, fmt.Errorf("invalid latitude %q", latitude)
+	}
+
+	lon, err := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if err != nil || !IsValidLongitude(lon) {
+		return 0, 0, fmt.Errorf("invalid longitude %q", longitude)
+	}
+
+	return lat, lon, nil
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(deg float64) float64 {
+		return deg * math.Pi / 180
+	}
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return earthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -0,0 +1,132 @@
+package escpos
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	esc = 0x1B
+	gs  = 0x1D
+
+	AlignLeft   byte = 0
+	AlignCenter byte = 1
+	AlignRight  byte = 2
+
+	// codePagePC866 is the Cyrillic code page of the Epson compatible printers
+	codePagePC866 byte = 17
+)
+
+// kazakhLetters are not present in PC866, they are printed as the closest Russian letters
+var kazakhLetters = strings.NewReplacer(
+	"Ә", "А", "ә", "а",
+	"Ғ", "Г", "ғ", "г",
+	"Қ", "К", "қ", "к",
+	"Ң", "Н", "ң", "н",
+	"Ө", "О", "ө", "о",
+	"Ұ", "У", "ұ", "у",
+	"Ү", "У", "ү", "у",
+	"Һ", "Х", "һ", "х",
+	"І", "И", "і", "и",
+)
+
+// Builder composes the ESC/POS commands of a thermal printer document
+type Builder struct {
+	buffer  bytes.Buffer
+	width   int
+	encoder *encoding.Encoder
+}
+
+func NewBuilder(width int) *Builder {
+	b := &Builder{
+		width:   width,
+		encoder: encoding.ReplaceUnsupported(charmap.CodePage866.NewEncoder()),
+	}
+	b.buffer.Write([]byte{esc, '@'})
+	b.buffer.Write([]byte{esc, 't', codePagePC866})
+	return b
+}
+
+func (b *Builder) Align(align byte) *Builder {
+	b.buffer.Write([]byte{esc, 'a', align})
+	return b
+}
+
+func (b *Builder) Bold(enabled bool) *Builder {
+	var flag byte
+	if enabled {
+		flag = 1
+	}
+	b.buffer.Write([]byte{esc, 'E', flag})
+	return b
+}
+
+func (b *Builder) DoubleSize(enabled bool) *Builder {
+	var size byte
+	if enabled {
+		size = 0x11
+	}
+	b.buffer.Write([]byte{gs, '!', size})
+	return b
+}
+
+// Line prints the text and moves to the next line
+func (b *Builder) Line(text string) *Builder {
+	b.writeText(text)
+	b.buffer.WriteByte('\n')
+	return b
+}
+
+// Columns prints the left text and the right text aligned to the edges of the line,
+// the left text is cut when both do not fit
+func (b *Builder) Columns(left, right string) *Builder {
+	rightLength := utf8.RuneCountInString(right)
+	leftLength := utf8.RuneCountInString(left)
+
+	maxLeft := b.width - rightLength - 1
+	if maxLeft < 0 {
+		maxLeft = 0
+	}
+	if leftLength > maxLeft {
+		left = string([]rune(left)[:maxLeft])
+		leftLength = maxLeft
+	}
+
+	padding := b.width - leftLength - rightLength
+	if padding < 1 {
+		padding = 1
+	}
+
+	return b.Line(left + strings.Repeat(" ", padding) + right)
+}
+
+func (b *Builder) Separator() *Builder {
+	return b.Line(strings.Repeat("-", b.width))
+}
+
+func (b *Builder) Feed(lines byte) *Builder {
+	b.buffer.Write([]byte{esc, 'd', lines})
+	return b
+}
+
+// Cut feeds the paper to the cutter and cuts it partially
+func (b *Builder) Cut() *Builder {
+	b.buffer.Write([]byte{gs, 'V', 66, 0})
+	return b
+}
+
+func (b *Builder) Bytes() []byte {
+	return b.buffer.Bytes()
+}
+
+func (b *Builder) writeText(text string) {
+	encoded, err := b.encoder.String(kazakhLetters.Replace(text))
+	if err != nil {
+		encoded = text
+	}
+	b.buffer.WriteString(encoded)
+}
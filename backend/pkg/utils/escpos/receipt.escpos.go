@@ -0,0 +1,101 @@
+package escpos
+
+import "fmt"
+
+type EscPosReceiptDetails struct {
+	Title         string
+	Number        string
+	StoreName     string
+	StoreAddress  string
+	StorePhone    string
+	OrderNumber   string
+	IssuedAt      string
+	Items         []EscPosReceiptItem
+	Subtotal      float64
+	DiscountTotal float64
+	DeliveryFee   float64
+	BonusesPaid   float64
+	Total         float64
+	Taxes         []EscPosReceiptTax
+	PaymentMethod string
+	CardMask      string
+	IsDuplicate   bool
+}
+
+type EscPosReceiptTax struct {
+	Rate   float64
+	Amount float64
+}
+
+type EscPosReceiptItem struct {
+	Name      string
+	Additives string
+	Price     float64
+	Discount  float64
+}
+
+// GenerateEscPosReceipt renders the receipt as ESC/POS commands for a thermal printer
+func GenerateEscPosReceipt(details EscPosReceiptDetails, width int) []byte {
+	b := NewBuilder(width)
+
+	b.Align(AlignCenter).Bold(true).Line(details.StoreName).Bold(false)
+	if details.StoreAddress != "" {
+		b.Line(details.StoreAddress)
+	}
+	if details.StorePhone != "" {
+		b.Line(details.StorePhone)
+	}
+	b.Feed(1)
+
+	b.DoubleSize(true).Line(details.Title).DoubleSize(false)
+	if details.IsDuplicate {
+		b.Bold(true).Line("DUPLICATE").Bold(false)
+	}
+
+	b.Align(AlignLeft).Separator()
+	b.Columns("Receipt", details.Number)
+	b.Columns("Order", details.OrderNumber)
+	b.Columns("Date", details.IssuedAt)
+	b.Separator()
+
+	for _, item := range details.Items {
+		b.Columns(item.Name, formatAmount(item.Price))
+		if item.Additives != "" {
+			b.Line("  + " + item.Additives)
+		}
+		if item.Discount > 0 {
+			b.Columns("  Discount", formatAmount(-item.Discount))
+		}
+	}
+	b.Separator()
+
+	b.Columns("Subtotal", formatAmount(details.Subtotal))
+	if details.DiscountTotal > 0 {
+		b.Columns("Discount", formatAmount(-details.DiscountTotal))
+	}
+	if details.DeliveryFee > 0 {
+		b.Columns("Delivery", formatAmount(details.DeliveryFee))
+	}
+	if details.BonusesPaid > 0 {
+		b.Columns("Paid with bonuses", formatAmount(-details.BonusesPaid))
+	}
+	b.Bold(true).Columns("TOTAL", formatAmount(details.Total)).Bold(false)
+	for _, tax := range details.Taxes {
+		b.Columns(fmt.Sprintf("VAT %g%%", tax.Rate), formatAmount(tax.Amount))
+	}
+	b.Separator()
+
+	b.Columns("Payment", details.PaymentMethod)
+	if details.CardMask != "" {
+		b.Columns("Card", details.CardMask)
+	}
+
+	b.Feed(1).Align(AlignCenter).Line("Thank you!")
+	b.Feed(3).Cut()
+
+	return b.Bytes()
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
@@ -13,6 +13,25 @@ type PDFReceiptDetails struct {
 	OrderDate string
 	Total     float64
 	SubOrders []PDFSubOrder
+
+	// Fiscal receipt details, the receipt number is left empty for a plain order receipt
+	ReceiptNumber string
+	ReceiptTitle  string
+	StoreName     string
+	StoreAddress  string
+	Subtotal      float64
+	DiscountTotal float64
+	DeliveryFee   float64
+	BonusesPaid   float64
+	Taxes         []PDFReceiptTax
+	PaymentMethod string
+	CardMask      string
+	IsDuplicate   bool
+}
+
+type PDFReceiptTax struct {
+	Rate   float64
+	Amount float64
 }
 
 type PDFSubOrder struct {
@@ -21,6 +40,7 @@ type PDFSubOrder struct {
 	Price       float64
 	Status      string
 	Additives   []PDFAdditive
+	Note        string
 }
 
 type PDFAdditive struct {
@@ -33,12 +53,34 @@ func GeneratePDFReceipt(details PDFReceiptDetails) ([]byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
 	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(0, 10, fmt.Sprintf("Receipt for Order #%d", details.OrderID))
+	if details.ReceiptNumber != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("%s #%s", details.ReceiptTitle, details.ReceiptNumber))
+	} else {
+		pdf.Cell(0, 10, fmt.Sprintf("Receipt for Order #%d", details.OrderID))
+	}
 	pdf.Ln(10)
 
+	if details.IsDuplicate {
+		pdf.Cell(0, 10, "DUPLICATE")
+		pdf.Ln(10)
+	}
+
 	pdf.SetFont("Arial", "", 12)
-	pdf.Cell(0, 10, fmt.Sprintf("Store ID: %d", details.StoreID))
-	pdf.Ln(8)
+	if details.StoreName != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("Store: %s", details.StoreName))
+		pdf.Ln(8)
+		if details.StoreAddress != "" {
+			pdf.Cell(0, 10, fmt.Sprintf("Address: %s", details.StoreAddress))
+			pdf.Ln(8)
+		}
+	} else {
+		pdf.Cell(0, 10, fmt.Sprintf("Store ID: %d", details.StoreID))
+		pdf.Ln(8)
+	}
+	if details.ReceiptNumber != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("Order: #%d", details.OrderID))
+		pdf.Ln(8)
+	}
 	pdf.Cell(0, 10, fmt.Sprintf("Order Date: %s", details.OrderDate))
 	pdf.Ln(8)
 	pdf.Cell(0, 10, fmt.Sprintf("Total: %.2f", details.Total))
@@ -57,8 +99,14 @@ func GeneratePDFReceipt(details PDFReceiptDetails) ([]byte, error) {
 
 		// Display suborder status
 		pdf.SetFont("Arial", "I", 10)
-		pdf.Cell(160, 10, fmt.Sprintf("Status: %s", suborder.Status))
-		pdf.Ln(6)
+		if suborder.Status != "" {
+			pdf.Cell(160, 10, fmt.Sprintf("Status: %s", suborder.Status))
+			pdf.Ln(6)
+		}
+		if suborder.Note != "" {
+			pdf.Cell(160, 10, suborder.Note)
+			pdf.Ln(6)
+		}
 
 		// Display additives, if any
 		if len(suborder.Additives) > 0 {
@@ -74,6 +122,32 @@ func GeneratePDFReceipt(details PDFReceiptDetails) ([]byte, error) {
 		pdf.Ln(4) // Add spacing between suborders
 	}
 
+	if details.ReceiptNumber != "" {
+		writeSection(pdf, "Payment")
+		writeAmountLine(pdf, "Subtotal", details.Subtotal)
+		if details.DiscountTotal > 0 {
+			writeAmountLine(pdf, "Discount", -details.DiscountTotal)
+		}
+		if details.DeliveryFee > 0 {
+			writeAmountLine(pdf, "Delivery", details.DeliveryFee)
+		}
+		if details.BonusesPaid > 0 {
+			writeAmountLine(pdf, "Paid with bonuses", -details.BonusesPaid)
+		}
+		writeAmountLine(pdf, "Total", details.Total)
+		for _, tax := range details.Taxes {
+			writeAmountLine(pdf, fmt.Sprintf("VAT %g%%", tax.Rate), tax.Amount)
+		}
+		pdf.Cell(120, 10, "Payment method")
+		pdf.Cell(40, 10, details.PaymentMethod)
+		pdf.Ln(8)
+		if details.CardMask != "" {
+			pdf.Cell(120, 10, "Card")
+			pdf.Cell(40, 10, details.CardMask)
+			pdf.Ln(8)
+		}
+	}
+
 	// Write PDF to a buffer
 	var buffer bytes.Buffer
 	err := pdf.Output(&buffer)
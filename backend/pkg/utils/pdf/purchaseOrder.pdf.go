@@ -0,0 +1,112 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+type PDFPurchaseOrderDetails struct {
+	OrderID              uint
+	Status               string
+	WarehouseName        string
+	SupplierName         string
+	SupplierPhone        string
+	SupplierEmail        string
+	SupplierAddress      string
+	CreatedAt            string
+	SentAt               string
+	ExpectedDeliveryDate string
+	Comment              string
+	Lines                []PDFPurchaseOrderLine
+	Total                float64
+}
+
+type PDFPurchaseOrderLine struct {
+	Name      string
+	Barcode   string
+	Unit      string
+	Quantity  float64
+	UnitPrice float64
+	Total     float64
+}
+
+// GeneratePDFPurchaseOrder generates the purchase order PDF sent to the supplier
+func GeneratePDFPurchaseOrder(details PDFPurchaseOrderDetails) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, fmt.Sprintf("Purchase Order #%d", details.OrderID))
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(0, 10, fmt.Sprintf("Status: %s", details.Status))
+	pdf.Ln(8)
+	pdf.Cell(0, 10, fmt.Sprintf("Created At: %s", details.CreatedAt))
+	pdf.Ln(8)
+	if details.SentAt != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("Sent At: %s", details.SentAt))
+		pdf.Ln(8)
+	}
+	if details.ExpectedDeliveryDate != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("Expected Delivery: %s", details.ExpectedDeliveryDate))
+		pdf.Ln(8)
+	}
+	pdf.Ln(4)
+
+	writeSection(pdf, "Supplier")
+	pdf.Cell(0, 10, details.SupplierName)
+	pdf.Ln(8)
+	for _, contact := range []string{details.SupplierPhone, details.SupplierEmail, details.SupplierAddress} {
+		if contact != "" {
+			pdf.Cell(0, 10, contact)
+			pdf.Ln(8)
+		}
+	}
+	pdf.Ln(4)
+
+	writeSection(pdf, "Deliver To")
+	pdf.Cell(0, 10, details.WarehouseName)
+	pdf.Ln(12)
+
+	writeSection(pdf, "Items")
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(70, 8, "Material", "1", 0, "", false, 0, "")
+	pdf.CellFormat(40, 8, "Barcode", "1", 0, "", false, 0, "")
+	pdf.CellFormat(25, 8, "Quantity", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(25, 8, "Price", "1", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 8, "Total", "1", 1, "R", false, 0, "")
+
+	pdf.SetFont("Arial", "", 11)
+	for _, line := range details.Lines {
+		name := line.Name
+		if line.Unit != "" {
+			name = fmt.Sprintf("%s (%s)", line.Name, line.Unit)
+		}
+		pdf.CellFormat(70, 8, name, "1", 0, "", false, 0, "")
+		pdf.CellFormat(40, 8, line.Barcode, "1", 0, "", false, 0, "")
+		pdf.CellFormat(25, 8, fmt.Sprintf("%.2f", line.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 8, fmt.Sprintf("%.2f", line.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 8, fmt.Sprintf("%.2f", line.Total), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "B", 12)
+	writeAmountLine(pdf, "Total", details.Total)
+
+	if details.Comment != "" {
+		pdf.Ln(4)
+		writeSection(pdf, "Comment")
+		pdf.MultiCell(0, 8, details.Comment, "", "", false)
+	}
+
+	// Write PDF to a buffer
+	var buffer bytes.Buffer
+	err := pdf.Output(&buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,118 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+type PDFShiftReportDetails struct {
+	ReportType   string
+	ShiftID      uint
+	StoreName    string
+	EmployeeName string
+	OpenedAt     string
+	ClosedAt     string
+	GeneratedAt  string
+
+	OrdersCount     int
+	SalesTotal      float64
+	Payments        []PDFPaymentMethodTotal
+	PaymentsTotal   float64
+	Refunds         []PDFPaymentMethodTotal
+	RefundsTotal    float64
+	CancelledCount  int
+	CancelledAmount float64
+
+	OpeningFloat float64
+	CashPayments float64
+	CashRefunds  float64
+	ExpectedCash float64
+	CountedCash  *float64
+	CashVariance *float64
+}
+
+type PDFPaymentMethodTotal struct {
+	PaymentMethod string
+	Count         int
+	Amount        float64
+}
+
+// GeneratePDFShiftReport generates an X or Z report PDF for a cash shift
+func GeneratePDFShiftReport(details PDFShiftReportDetails) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, fmt.Sprintf("%s-report for Shift #%d", details.ReportType, details.ShiftID))
+	pdf.Ln(10)
+
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(0, 10, fmt.Sprintf("Store: %s", details.StoreName))
+	pdf.Ln(8)
+	pdf.Cell(0, 10, fmt.Sprintf("Employee: %s", details.EmployeeName))
+	pdf.Ln(8)
+	pdf.Cell(0, 10, fmt.Sprintf("Opened At: %s", details.OpenedAt))
+	pdf.Ln(8)
+	if details.ClosedAt != "" {
+		pdf.Cell(0, 10, fmt.Sprintf("Closed At: %s", details.ClosedAt))
+		pdf.Ln(8)
+	}
+	pdf.Cell(0, 10, fmt.Sprintf("Generated At: %s", details.GeneratedAt))
+	pdf.Ln(12)
+
+	writeSection(pdf, "Sales")
+	writeAmountLine(pdf, fmt.Sprintf("Orders (%d)", details.OrdersCount), details.SalesTotal)
+	writeAmountLine(pdf, fmt.Sprintf("Cancelled orders (%d)", details.CancelledCount), details.CancelledAmount)
+	pdf.Ln(4)
+
+	writeSection(pdf, "Payments")
+	writeMethodTotals(pdf, details.Payments)
+	writeAmountLine(pdf, "Total", details.PaymentsTotal)
+	pdf.Ln(4)
+
+	writeSection(pdf, "Refunds")
+	writeMethodTotals(pdf, details.Refunds)
+	writeAmountLine(pdf, "Total", details.RefundsTotal)
+	pdf.Ln(4)
+
+	writeSection(pdf, "Cash")
+	writeAmountLine(pdf, "Opening float", details.OpeningFloat)
+	writeAmountLine(pdf, "Cash payments", details.CashPayments)
+	writeAmountLine(pdf, "Cash refunds", details.CashRefunds)
+	writeAmountLine(pdf, "Expected cash", details.ExpectedCash)
+	if details.CountedCash != nil {
+		writeAmountLine(pdf, "Counted cash", *details.CountedCash)
+	}
+	if details.CashVariance != nil {
+		writeAmountLine(pdf, "Variance", *details.CashVariance)
+	}
+
+	// Write PDF to a buffer
+	var buffer bytes.Buffer
+	err := pdf.Output(&buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeSection(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont("Arial", "B", 14)
+	pdf.Cell(0, 10, title)
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 12)
+}
+
+func writeAmountLine(pdf *gofpdf.Fpdf, label string, amount float64) {
+	pdf.Cell(120, 10, label)
+	pdf.Cell(40, 10, fmt.Sprintf("%.2f", amount))
+	pdf.Ln(8)
+}
+
+func writeMethodTotals(pdf *gofpdf.Fpdf, totals []PDFPaymentMethodTotal) {
+	for _, total := range totals {
+		writeAmountLine(pdf, fmt.Sprintf("%s (%d)", total.PaymentMethod, total.Count), total.Amount)
+	}
+}
@@ -0,0 +1,26 @@
+package sms
+
+import (
+	"go.uber.org/zap"
+)
+
+// LogSender writes the messages to the log instead of sending them, nothing is kept in memory.
+// The messages carry the verification codes, so it is allowed in the development and test environments only.
+type LogSender struct {
+	logger *zap.SugaredLogger
+}
+
+func NewLogSender(logger *zap.SugaredLogger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Name() ProviderName {
+	return ProviderLog
+}
+
+func (s *LogSender) Send(phone, message string) error {
+	if s.logger != nil {
+		s.logger.Infof("SMS to %s: %s", phone, message)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type ProviderName string
+
+const (
+	ProviderLog ProviderName = "LOG"
+)
+
+var (
+	ErrUnknownProvider    = errors.New("unknown SMS provider")
+	ErrProviderNotAllowed = errors.New("SMS provider is allowed in the development and test environments only")
+)
+
+// Sender delivers text messages to the phones in the E.164 format.
+// A provider of an SMS gateway implements it and is registered in NewSender.
+type Sender interface {
+	Name() ProviderName
+	Send(phone, message string) error
+}
+
+// NewSender refuses the LOG provider unless isDevelopment is set, so the codes never end up in the production logs
+func NewSender(providerName string, isDevelopment bool, logger *zap.SugaredLogger) (Sender, error) {
+	switch ProviderName(providerName) {
+	case ProviderLog:
+		if !isDevelopment {
+			return nil, fmt.Errorf("%w: %s", ErrProviderNotAllowed, providerName)
+		}
+		return NewLogSender(logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, providerName)
+	}
+}
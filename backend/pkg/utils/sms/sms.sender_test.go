@@ -0,0 +1,26 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSender(t *testing.T) {
+	t.Run("Log provider should be allowed in development", func(t *testing.T) {
+		sender, err := NewSender(string(ProviderLog), true, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderLog, sender.Name())
+		assert.NoError(t, sender.Send("+77001234567", "code"))
+	})
+
+	t.Run("Log provider should be refused outside development", func(t *testing.T) {
+		_, err := NewSender(string(ProviderLog), false, nil)
+		assert.ErrorIs(t, err, ErrProviderNotAllowed)
+	})
+
+	t.Run("Unknown provider should be refused", func(t *testing.T) {
+		_, err := NewSender("PIGEON", true, nil)
+		assert.ErrorIs(t, err, ErrUnknownProvider)
+	})
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StoreHours is the daily opening window of a store as offsets from the midnight.
+// Close may exceed 24 hours for the stores open after the midnight, e.g. from 20:00 to 02:00.
+type StoreHours struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// NewStoreHours parses the "HH:MM" or "HH:MM:SS" times, a closing not after the opening means the next day.
+// The fractional seconds of the postgres time values are ignored.
+func NewStoreHours(openAt, closeAt string) (*StoreHours, error) {
+	open, err := parseTimeOfDay(openAt)
+	if err != nil {
+		return nil, err
+	}
+
+	closeTime, err := parseTimeOfDay(closeAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if closeTime <= open {
+		closeTime += 24 * time.Hour
+	}
+
+	return &StoreHours{Open: open, Close: closeTime}, nil
+}
+
+// Window returns the opening and closing time of the store on the day of the given time
+func (h *StoreHours) Window(day time.Time) (time.Time, time.Time) {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return midnight.Add(h.Open), midnight.Add(h.Close)
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	trimmed, _, _ := strings.Cut(strings.TrimSpace(value), ".")
+	parts := strings.Split(trimmed, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+
+	var units [3]int
+	limits := [3]int{24, 59, 59}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || len(part) > 2 || n < 0 || n > limits[i] {
+			return 0, fmt.Errorf("invalid time %q", value)
+		}
+		units[i] = n
+	}
+
+	if units[0] == 24 && (units[1] != 0 || units[2] != 0) {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+
+	return time.Duration(units[0])*time.Hour + time.Duration(units[1])*time.Minute + time.Duration(units[2])*time.Second, nil
+}
@@ -906,7 +906,7 @@ INSERT INTO
         is_active,
         contact_phone,
         contact_email,
-        store_hours,
+        timezone,
         last_inventory_sync_at,
         created_at,
         updated_at
@@ -920,7 +920,7 @@ VALUES
         true,
         '+79001112233',
         'central@example.com',
-        '8:00-20:00',
+        'Asia/Almaty',
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP
@@ -933,7 +933,7 @@ VALUES
         true,
         '+79002223344',
         'corner@example.com',
-        '9:00-22:00',
+        'Asia/Almaty',
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP
@@ -946,7 +946,7 @@ VALUES
         true,
         '+79003334455',
         'smallstore@example.com',
-        '8:00-18:00',
+        'Asia/Almaty',
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP
@@ -959,7 +959,7 @@ VALUES
         true,
         '+79004445566',
         'citycoffee@example.com',
-        '7:00-23:00',
+        'Asia/Almaty',
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP
@@ -972,12 +972,39 @@ VALUES
         true,
         '+79004445577',
         'newcafe@example.com',
-        '7:00-23:00',
+        'Asia/Almaty',
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP,
         CURRENT_TIMESTAMP
     );
 
+INSERT INTO
+    store_working_hours (store_id, day, open_at, close_at)
+SELECT
+    h.store_id,
+    d.day,
+    h.open_at::TIME,
+    h.close_at::TIME
+FROM
+    (
+        VALUES
+            (1, '8:00', '20:00'),
+            (2, '9:00', '22:00'),
+            (3, '8:00', '18:00'),
+            (4, '7:00', '23:00'),
+            (5, '7:00', '23:00')
+    ) AS h (store_id, open_at, close_at)
+    CROSS JOIN (
+        VALUES
+            ('MONDAY'),
+            ('TUESDAY'),
+            ('WEDNESDAY'),
+            ('THURSDAY'),
+            ('FRIDAY'),
+            ('SATURDAY'),
+            ('SUNDAY')
+    ) AS d (day);
+
 -- Assigned to 'Астанинский склад'
 -- Insert into StoreAdditives store 1 additives are loaded later in the script
 INSERT INTO
@@ -89,10 +89,10 @@ INSERT INTO warehouses (facility_address_id, region_id, name)
 VALUES (1, 1, 'Main Warehouse');
 
 -- 14. Insert into stores
-INSERT INTO stores (name, facility_address_id, franchisee_id, warehouse_id, is_active, contact_phone, contact_email, store_hours)
+INSERT INTO stores (name, facility_address_id, franchisee_id, warehouse_id, is_active, contact_phone, contact_email)
 VALUES
-  ('Test Store', 1, 1, 1, true, '+1234567890', 'store@test.com', '8-20'),
-  ('Second Store', 2, 1, 1, true, '+1987654321', 'store2@test.com', '9-21');
+  ('Test Store', 1, 1, 1, true, '+1234567890', 'store@test.com'),
+  ('Second Store', 2, 1, 1, true, '+1987654321', 'store2@test.com');
 
 -- 15. Insert into store_additives
 INSERT INTO store_additives (additive_id, store_id, store_price)
@@ -0,0 +1,75 @@
+package functional
+
+import (
+	"testing"
+
+	"github.com/Global-Optima/zeep-web/backend/internal/data"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers"
+	"github.com/Global-Optima/zeep-web/backend/internal/modules/customers/types"
+	"github.com/Global-Optima/zeep-web/backend/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var container = tests.NewTestContainer()
+
+func setupCustomerRepositoryTest(t *testing.T) (customers.CustomerRepository, *gorm.DB) {
+	db := container.GetDB()
+	if err := tests.TruncateAllTables(db); err != nil {
+		t.Fatalf("TruncateAllTables error: %v", err)
+	}
+
+	return customers.NewCustomerRepository(db), db
+}
+
+func createTestCustomer(t *testing.T, db *gorm.DB, phone string, addresses ...string) *data.Customer {
+	customer := &data.Customer{
+		FirstName: "Aigerim",
+		LastName:  "Sarsenova",
+		Password:  "hashed-password",
+		Phone:     phone,
+	}
+	require.NoError(t, db.Create(customer).Error)
+
+	for _, address := range addresses {
+		require.NoError(t, db.Create(&data.CustomerAddress{CustomerID: customer.ID, Address: address}).Error)
+	}
+	return customer
+}
+
+func TestCustomerRepository_DeleteCustomer(t *testing.T) {
+	repo, db := setupCustomerRepositoryTest(t)
+
+	t.Run("Success - Personal data is erased", func(t *testing.T) {
+		customer := createTestCustomer(t, db, "+77001234567", "Abay 10", "Dostyk 5")
+
+		require.NoError(t, repo.DeleteCustomer(customer.ID))
+
+		var deleted data.Customer
+		require.NoError(t, db.Unscoped().First(&deleted, customer.ID).Error)
+		assert.True(t, deleted.DeletedAt.Valid)
+		assert.Empty(t, deleted.FirstName)
+		assert.Empty(t, deleted.LastName)
+		assert.Empty(t, deleted.Password)
+		assert.Equal(t, types.AnonymizedPhone(customer.ID), deleted.Phone)
+
+		var addressesCount int64
+		require.NoError(t, db.Unscoped().Model(&data.CustomerAddress{}).Where("customer_id = ?", customer.ID).Count(&addressesCount).Error)
+		assert.Zero(t, addressesCount)
+	})
+
+	t.Run("Success - Phone can be registered again", func(t *testing.T) {
+		customer := createTestCustomer(t, db, "+77007654321")
+		require.NoError(t, repo.DeleteCustomer(customer.ID))
+
+		createTestCustomer(t, db, "+77007654321")
+	})
+
+	t.Run("Failure - Customer already deleted", func(t *testing.T) {
+		customer := createTestCustomer(t, db, "+77001112233")
+		require.NoError(t, repo.DeleteCustomer(customer.ID))
+
+		assert.ErrorIs(t, repo.DeleteCustomer(customer.ID), types.ErrCustomerNotFound)
+	})
+}
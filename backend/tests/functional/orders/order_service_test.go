@@ -161,12 +161,12 @@ func uintPtr(u uint) *uint {
 
 // 	testCases := []struct {
 // 		name          string
-// 		filter        types.OrdersTimeZoneFilter
+// 		filter        types.BaristaOrdersFilter
 // 		expectedCount int
 // 	}{
 // 		{
 // 			name: "Valid orders using local timezone",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID:          uintPtr(1),
 // 				TimeZoneLocation: stringPtr(localLocation.String()),
 // 			},
@@ -175,7 +175,7 @@ func uintPtr(u uint) *uint {
 // 		},
 // 		{
 // 			name: "No orders returned for alternative timezone",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID:          uintPtr(1),
 // 				TimeZoneLocation: stringPtr(altTimezone),
 // 			},
@@ -185,7 +185,7 @@ func uintPtr(u uint) *uint {
 // 		},
 // 		{
 // 			name: "No orders for non-existent store",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID: uintPtr(999),
 // 			},
 // 			expectedCount: 0,
@@ -241,12 +241,12 @@ func uintPtr(u uint) *uint {
 
 // 	testCases := []struct {
 // 		name           string
-// 		filter         types.OrdersTimeZoneFilter
+// 		filter         types.BaristaOrdersFilter
 // 		expectedCounts types.OrderStatusesCountDTO
 // 	}{
 // 		{
 // 			name: "Valid statuses using local timezone for store 1",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID:          uintPtr(1),
 // 				TimeZoneLocation: stringPtr(localLocation.String()),
 // 			},
@@ -263,7 +263,7 @@ func uintPtr(u uint) *uint {
 // 		},
 // 		{
 // 			name: "No orders returned using alternative timezone for store 1",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID:          uintPtr(1),
 // 				TimeZoneLocation: stringPtr(altTimezone),
 // 			},
@@ -280,7 +280,7 @@ func uintPtr(u uint) *uint {
 // 		},
 // 		{
 // 			name: "No orders for non-existent store",
-// 			filter: types.OrdersTimeZoneFilter{
+// 			filter: types.BaristaOrdersFilter{
 // 				StoreID: uintPtr(999),
 // 			},
 // 			expectedCounts: types.OrderStatusesCountDTO{
@@ -551,21 +551,21 @@ func TestOrderService_AdvanceSubOrderStatus(t *testing.T) {
 	assert.Len(t, suborderIDs, 2, "Expected two suborders inserted")
 
 	// Advance the second suborder from PENDING to PREPARING.
-	dto, err := module.Service.SetNextSubOrderStatus(suborderIDs[1], nil)
+	dto, err := module.Service.SetNextSubOrderStatus(suborderIDs[1], 1, 1, nil)
 	assert.NoError(t, err, "Advancing suborder status should succeed")
 	assert.Equal(t, data.SubOrderStatusPreparing, dto.Status, "Suborder should transition to PREPARING")
 
 	// Advance the same suborder from PREPARING to COMPLETED.
-	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[1], nil)
+	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[1], 1, 1, nil)
 	assert.NoError(t, err, "Advancing suborder status again should succeed")
 	assert.Equal(t, data.SubOrderStatusCompleted, dto.Status, "Suborder should transition to COMPLETED")
 
-	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[0], nil)
+	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[0], 1, 1, nil)
 	assert.NoError(t, err, "Advancing suborder status should succeed")
 	assert.Equal(t, data.SubOrderStatusPreparing, dto.Status, "Suborder should transition to PREPARING")
 
 	// Advance the same suborder from PREPARING to COMPLETED.
-	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[0], nil)
+	dto, err = module.Service.SetNextSubOrderStatus(suborderIDs[0], 1, 1, nil)
 	assert.NoError(t, err, "Advancing suborder status again should succeed")
 	assert.Equal(t, data.SubOrderStatusCompleted, dto.Status, "Suborder should transition to COMPLETED")
 
@@ -573,3 +573,113 @@ func TestOrderService_AdvanceSubOrderStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, data.OrderStatusCompleted, order.Status, "Order status should be COMPLETED when all suborders are complete")
 }
+
+func TestOrderService_RefundOrder(t *testing.T) {
+	db := resetTestData(t)
+	module := tests.GetOrdersModule()
+
+	orderID, suborderIDs := insertTestOrderWithTwoSuborders(t, db)
+	assert.Len(t, suborderIDs, 2, "Expected two suborders inserted")
+
+	refundTransaction := func(transactionID string, amount float64) types.TransactionDTO {
+		return types.TransactionDTO{
+			Bin:           "123456789012",
+			TransactionID: transactionID,
+			PaymentMethod: "CARD",
+			Amount:        amount,
+			Currency:      "KZT",
+		}
+	}
+
+	// Refunding more than the suborder price must be rejected.
+	_, err := module.Service.RefundOrder(orderID, 1, 1, &types.RefundOrderDTO{
+		SuborderIDs: []uint{suborderIDs[0]},
+		Transaction: refundTransaction("REFUND-1", 100),
+	})
+	assert.ErrorIs(t, err, types.ErrRefundAmountExceeded)
+
+	// Partial refund keeps the order active.
+	order, err := module.Service.RefundOrder(orderID, 1, 1, &types.RefundOrderDTO{
+		SuborderIDs:      []uint{suborderIDs[0]},
+		RestoreInventory: true,
+		Transaction:      refundTransaction("REFUND-2", 3.30),
+	})
+	assert.NoError(t, err, "Partial refund should succeed")
+	assert.Equal(t, data.OrderStatusPending, order.Status, "Order should stay PENDING after a partial refund")
+
+	// The same suborder can not be refunded twice.
+	_, err = module.Service.RefundOrder(orderID, 1, 1, &types.RefundOrderDTO{
+		SuborderIDs: []uint{suborderIDs[0]},
+		Transaction: refundTransaction("REFUND-3", 3.30),
+	})
+	assert.ErrorIs(t, err, types.ErrSuborderNotRefundable)
+
+	// Refunding the rest marks the whole order as refunded.
+	order, err = module.Service.RefundOrder(orderID, 1, 1, &types.RefundOrderDTO{
+		Transaction: refundTransaction("REFUND-4", 3.30),
+	})
+	assert.NoError(t, err, "Full refund should succeed")
+	assert.Equal(t, data.OrderStatusRefunded, order.Status, "Order should be REFUNDED when all suborders are refunded")
+
+	var refunds int64
+	db.Model(&data.Transaction{}).
+		Where("order_id = ? AND type = ?", orderID, data.TransactionTypeRefund).
+		Count(&refunds)
+	assert.Equal(t, int64(2), refunds, "Expected a refund transaction per successful refund")
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	db := resetTestData(t)
+	module := tests.GetOrdersModule()
+
+	orderID, suborderIDs := insertTestOrderWithTwoSuborders(t, db)
+	assert.Len(t, suborderIDs, 2, "Expected two suborders inserted")
+
+	err := db.Model(&data.Suborder{}).
+		Where("id = ?", suborderIDs[1]).
+		Update("status", data.SubOrderStatusPreparing).Error
+	assert.NoError(t, err, "Failed to start preparing the second suborder")
+
+	barista := &types.CancellationRequester{EmployeeID: 1}
+
+	// Unknown reasons are rejected.
+	_, err = module.Service.CancelOrder(orderID, 1, barista, &types.CancelOrderDTO{Reason: "UNKNOWN"})
+	assert.ErrorIs(t, err, types.ErrInvalidCancellationReason)
+
+	// Pending suborders are cancelled right away.
+	cancellation, err := module.Service.CancelOrder(orderID, 1, barista, &types.CancelOrderDTO{
+		SuborderIDs: []uint{suborderIDs[0]},
+		Reason:      data.OrderCancellationReasonWrongDrink,
+	})
+	assert.NoError(t, err, "Cancelling a pending suborder should succeed")
+	assert.Equal(t, data.OrderCancellationStatusApproved, cancellation.Status)
+
+	// Suborders in preparation wait for the manager approval.
+	cancellation, err = module.Service.CancelOrder(orderID, 1, barista, &types.CancelOrderDTO{
+		Reason: data.OrderCancellationReasonCustomerLeft,
+	})
+	assert.NoError(t, err, "Requesting cancellation of a preparing suborder should succeed")
+	assert.Equal(t, data.OrderCancellationStatusPendingApproval, cancellation.Status)
+	assert.Equal(t, []uint{suborderIDs[1]}, cancellation.SuborderIDs)
+
+	_, err = module.Service.CancelOrder(orderID, 1, barista, &types.CancelOrderDTO{
+		Reason: data.OrderCancellationReasonCustomerLeft,
+	})
+	assert.ErrorIs(t, err, types.ErrCancellationPending)
+
+	cancellation, err = module.Service.ReviewOrderCancellation(cancellation.ID, 1, 1, &types.ReviewOrderCancellationDTO{
+		Status: data.OrderCancellationStatusApproved,
+	})
+	assert.NoError(t, err, "Approving the cancellation should succeed")
+	assert.Equal(t, data.OrderCancellationStatusApproved, cancellation.Status)
+
+	_, err = module.Service.ReviewOrderCancellation(cancellation.ID, 1, 1, &types.ReviewOrderCancellationDTO{
+		Status: data.OrderCancellationStatusRejected,
+	})
+	assert.ErrorIs(t, err, types.ErrCancellationReviewed)
+
+	var order data.Order
+	err = db.First(&order, orderID).Error
+	assert.NoError(t, err)
+	assert.Equal(t, data.OrderStatusCancelled, order.Status, "Order should be CANCELLED when all suborders are cancelled")
+}
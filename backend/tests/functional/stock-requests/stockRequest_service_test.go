@@ -15,6 +15,8 @@ import (
 
 var container = tests.NewTestContainer()
 
+const testEmployeeID uint = 1
+
 func uintPtr(u uint) *uint {
 	return &u
 }
@@ -131,7 +133,7 @@ func TestStockRequestService_RejectStockRequestByStore(t *testing.T) {
 		dto := types.RejectStockRequestStatusDTO{
 			Comment: stringPtr("Store rejects the request"),
 		}
-		req, err := service.RejectStockRequestByStore(id, dto)
+		req, err := service.RejectStockRequestByStore(id, testEmployeeID, dto)
 		assert.NoError(t, err)
 		assert.Equal(t, data.StockRequestRejectedByStore, req.Status, "Status should change to REJECTED_BY_STORE")
 	})
@@ -141,7 +143,7 @@ func TestStockRequestService_RejectStockRequestByStore(t *testing.T) {
 		dto := types.RejectStockRequestStatusDTO{
 			Comment: stringPtr("Store rejects the request"),
 		}
-		_, err := service.RejectStockRequestByStore(id, dto)
+		_, err := service.RejectStockRequestByStore(id, testEmployeeID, dto)
 		assert.Error(t, err, "Store rejection from CREATED status should error")
 		assert.True(t, strings.Contains(err.Error(), "invalid status transition"),
 			"Error should mention invalid status transition")
@@ -228,7 +230,7 @@ func TestStockRequestService_SetInDeliveryStatus(t *testing.T) {
 		err := db.Model(&data.StockRequest{}).Where("id = ?", id).
 			Update("status", data.StockRequestProcessed).Error
 		assert.NoError(t, err)
-		req, err := service.SetInDeliveryStatus(id)
+		req, err := service.SetInDeliveryStatus(id, testEmployeeID)
 		assert.NoError(t, err)
 		assert.Equal(t, data.StockRequestInDelivery, req.Status, "Status should change to IN_DELIVERY")
 	})
@@ -240,7 +242,7 @@ func TestStockRequestService_SetInDeliveryStatus(t *testing.T) {
 
 		err = db.Exec("UPDATE warehouse_stocks SET quantity = ? WHERE warehouse_id = ? AND stock_material_id = ?", 0, 1, 1).Error
 		assert.NoError(t, err)
-		_, err = service.SetInDeliveryStatus(id)
+		_, err = service.SetInDeliveryStatus(id, testEmployeeID)
 		assert.Error(t, err, "Transition to IN_DELIVERY should fail due to insufficient stock")
 	})
 }
@@ -255,7 +257,7 @@ func TestStockRequestService_SetCompletedStatus(t *testing.T) {
 		err := db.Model(&data.StockRequest{}).Where("id = ?", id).
 			Update("status", data.StockRequestInDelivery).Error
 		assert.NoError(t, err)
-		req, err := service.SetCompletedStatus(id)
+		req, err := service.SetCompletedStatus(id, testEmployeeID)
 		assert.NoError(t, err)
 		assert.Equal(t, data.StockRequestCompleted, req.Status, "Status should change to COMPLETED")
 	})
@@ -277,7 +279,7 @@ func TestStockRequestService_AcceptStockRequestWithChange(t *testing.T) {
 				{StockMaterialID: 1, Quantity: 5},
 			},
 		}
-		req, err := service.AcceptStockRequestWithChange(id, dto)
+		req, err := service.AcceptStockRequestWithChange(id, testEmployeeID, dto)
 		assert.NoError(t, err)
 		assert.Equal(t, data.StockRequestAcceptedWithChange, req.Status, "Status should change to ACCEPTED_WITH_CHANGE")
 	})
@@ -292,7 +294,7 @@ func TestStockRequestService_AcceptStockRequestWithChange(t *testing.T) {
 				{StockMaterialID: 2, Quantity: 5},
 			},
 		}
-		req, err := service.AcceptStockRequestWithChange(id, dto)
+		req, err := service.AcceptStockRequestWithChange(id, testEmployeeID, dto)
 		assert.NoError(t, err)
 		assert.Equal(t, data.StockRequestAcceptedWithChange, req.Status, "Status should change to ACCEPTED_WITH_CHANGE")
 	})
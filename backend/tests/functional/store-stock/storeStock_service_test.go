@@ -18,6 +18,8 @@ import (
 
 var container = tests.NewTestContainer()
 
+const testEmployeeID uint = 1
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -36,7 +38,7 @@ func setupStoreStockTest(t *testing.T) (storeStocks.StoreStockService, *gorm.DB)
 }
 
 func createTestStock(t *testing.T, service storeStocks.StoreStockService, storeID uint, dto types.AddStoreStockDTO) uint {
-	id, err := service.AddStock(storeID, &dto)
+	id, err := service.AddStock(storeID, testEmployeeID, &dto)
 	assert.NoError(t, err, "AddStock should succeed")
 	assert.NotZero(t, id, "Returned stock ID should not be zero")
 	return id
@@ -64,7 +66,7 @@ func TestStoreStockService_AddStock(t *testing.T) {
 			Quantity:          100,
 			LowStockThreshold: 50,
 		}
-		id, err := service.AddStock(storeID, &dto)
+		id, err := service.AddStock(storeID, testEmployeeID, &dto)
 		assert.NoError(t, err)
 		stockDto, err := service.GetStockById(id, &contexts.StoreContextFilter{StoreID: &storeID})
 		assert.NoError(t, err)
@@ -79,7 +81,7 @@ func TestStoreStockService_AddStock(t *testing.T) {
 			LowStockThreshold: 50,
 		}
 
-		_, err := service.AddStock(1, &dto)
+		_, err := service.AddStock(1, testEmployeeID, &dto)
 		assert.Error(t, err, "Should not allow duplicate stock for same ingredient")
 		assert.True(t, strings.Contains(err.Error(), "already exists"),
 			"Error should indicate stock already exists")
@@ -91,7 +93,7 @@ func TestStoreStockService_AddStock(t *testing.T) {
 			Quantity:          100,
 			LowStockThreshold: 50,
 		}
-		_, err := service.AddStock(storeID, &dto)
+		_, err := service.AddStock(storeID, testEmployeeID, &dto)
 		assert.Error(t, err, "Should error if ingredient does not exist")
 	})
 }
@@ -107,7 +109,7 @@ func TestStoreStockService_AddMultipleStock(t *testing.T) {
 			Quantity:          100,
 			LowStockThreshold: 50,
 		}
-		id1, err := service.AddStock(storeID, &dto1)
+		id1, err := service.AddStock(storeID, testEmployeeID, &dto1)
 		assert.NoError(t, err)
 
 		multiDto := types.AddMultipleStoreStockDTO{
@@ -116,7 +118,7 @@ func TestStoreStockService_AddMultipleStock(t *testing.T) {
 				{IngredientID: 2, Quantity: 200, LowStockThreshold: 100},
 			},
 		}
-		ids, err := service.AddMultipleStock(storeID, &multiDto)
+		ids, err := service.AddMultipleStock(storeID, testEmployeeID, &multiDto)
 		assert.NoError(t, err)
 		assert.Len(t, ids, 2, "Should return two stock IDs")
 
@@ -190,7 +192,7 @@ func TestStoreStockService_UpdateStockById(t *testing.T) {
 			Quantity:          floatPtr(80),
 			LowStockThreshold: floatPtr(40),
 		}
-		err := service.UpdateStockById(storeID, id, &updateDTO)
+		err := service.UpdateStockById(storeID, id, testEmployeeID, &updateDTO)
 		assert.NoError(t, err)
 		stock, err := service.GetStockById(id, &contexts.StoreContextFilter{StoreID: &storeID})
 		assert.NoError(t, err)
@@ -203,7 +205,7 @@ func TestStoreStockService_UpdateStockById(t *testing.T) {
 			Quantity:          floatPtr(80),
 			LowStockThreshold: floatPtr(40),
 		}
-		err := service.UpdateStockById(1, 9999, &updateDTO)
+		err := service.UpdateStockById(1, 9999, testEmployeeID, &updateDTO)
 		assert.Error(t, err, "Updating a non-existent stock should error")
 	})
 }
@@ -294,6 +294,14 @@ func ResetTestDatabase(db *gorm.DB) error {
 		"warehouse_stocks",
 		"supplier_warehouse_delivery_materials",
 		"supplier_warehouse_deliveries",
+		"stock_movements",
+		"stock_adjustments",
+		"stocktake_lines",
+		"stocktakes",
+		"stock_request_ingredient_lots",
+		"warehouse_stock_lots",
+		"purchase_order_lines",
+		"purchase_orders",
 		"supplier_prices",
 		"supplier_materials",
 		"suppliers",
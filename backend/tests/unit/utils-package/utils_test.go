@@ -77,6 +77,28 @@ func TestIsValidLatitudeLongitude(t *testing.T) {
 	}, testCases[2:])
 }
 
+func TestParseCoordinates(t *testing.T) {
+	testCases := []testUtils.TestCase{
+		{Name: "Valid Coordinates", InputArgs: []interface{}{"43.238949", " 76.889709"}, Expected: []float64{43.238949, 76.889709}, ShouldFail: false, SetupMock: nil},
+		{Name: "Empty Latitude", InputArgs: []interface{}{"", "76.889709"}, Expected: nil, ShouldFail: true, SetupMock: nil},
+		{Name: "Invalid Longitude", InputArgs: []interface{}{"43.238949", "190"}, Expected: nil, ShouldFail: true, SetupMock: nil},
+	}
+
+	testUtils.TestRunner(t, func(args ...interface{}) (interface{}, error) {
+		lat, lon, err := utils.ParseCoordinates(args[0].(string), args[1].(string))
+		if err != nil {
+			return nil, err
+		}
+		return []float64{lat, lon}, nil
+	}, testCases)
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Almaty - Astana
+	assert.InDelta(t, 970, utils.DistanceKm(43.238949, 76.889709, 51.169392, 71.449074), 5)
+	assert.Zero(t, utils.DistanceKm(43.238949, 76.889709, 43.238949, 76.889709))
+}
+
 func TestParseParam(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -15,6 +15,7 @@ export enum NotificationEventType {
 	OUT_OF_STOCK = 'OUT_OF_STOCK',
 	NEW_STOCK_REQUEST = 'NEW_STOCK_REQUEST',
 	PRICE_CHANGE = 'PRICE_CHANGE',
+	PAYMENT_RECONCILIATION_MISMATCH = 'PAYMENT_RECONCILIATION_MISMATCH',
 }
 
 export interface NotificationDTO {
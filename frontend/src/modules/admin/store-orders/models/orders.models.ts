@@ -176,6 +176,17 @@ export interface TransactionDTO {
 	icc?: string
 }
 
+export interface PaymentIntentDTO {
+	id: number
+	orderId: number
+	provider: string
+	externalId: string
+	amount: number
+	currency: string
+	status: string
+	details?: Record<string, string>
+}
+
 export interface OrdersTimeZoneFilter {
 	storeId?: number
 	timezone?: string
@@ -1,5 +1,4 @@
 import { apiClient } from '@/core/config/axios-instance.config'
-import { encryptPayload, type EncryptedData } from '@/core/services/aes.service'
 import type { PaginatedResponse } from '@/core/utils/pagination.utils'
 import { buildRequestFilter } from '@/core/utils/request-filters.utils'
 import { saveAs } from 'file-saver'
@@ -11,14 +10,13 @@ import type {
 	OrdersExportFilterQuery,
 	OrdersFilterQuery,
 	OrdersTimeZoneFilter,
+	PaymentIntentDTO,
 	SuborderDTO,
 	ToggleNextSuborderStatusOptions,
-	TransactionDTO,
 } from '../models/orders.models'
+import { OrderStatus } from '../models/orders.models'
 
 class OrderService {
-	private readonly paymentSecret = import.meta.env.VITE_PAYMENT_SECRET as string
-
 	async getAllOrders(filter?: OrdersFilterQuery) {
 		try {
 			const response = await apiClient.get<PaginatedResponse<OrderDTO[]>>('/orders', {
@@ -73,29 +71,31 @@ class OrderService {
 		}
 	}
 
-	async successOrderPayment(orderId: number, dto: TransactionDTO): Promise<{ orderId: number }> {
+	async createPaymentIntent(orderId: number) {
 		try {
-			// Load secret key from environment variables
-			if (!this.paymentSecret) {
-				throw new Error('Payment is not defined in environment variables')
-			}
-
-			// Encrypt the TransactionDTO
-			const encryptedData: EncryptedData = encryptPayload(dto, this.paymentSecret)
-
-			// Send encrypted payload to the backend
-			const res = await apiClient.post<{ orderId: number }>(
-				`/orders/${orderId}/payment/success`,
-				encryptedData,
-			)
-
-			return res.data
+			return apiClient
+				.post<PaymentIntentDTO>(`/orders/${orderId}/payment/intent`)
+				.then(res => res.data)
 		} catch (error) {
-			console.error('Failed to complete order payment:', error)
+			console.error('Failed to create payment intent:', error)
 			throw error
 		}
 	}
 
+	// The terminal result reaches the server only by the signed webhook of the terminal integration,
+	// so the kiosk waits until the order leaves the waiting for payment status
+	async waitForOrderPayment(orderId: number, timeout = 60_000, interval = 2_000) {
+		const deadline = Date.now() + timeout
+		while (Date.now() < deadline) {
+			const order = await this.getOrderById(orderId)
+			if (order.status !== OrderStatus.WAITING_FOR_PAYMENT) {
+				return order
+			}
+			await new Promise(resolve => setTimeout(resolve, interval))
+		}
+		throw new Error(`Payment of the order ${orderId} was not confirmed in time`)
+	}
+
 	async checkCustomerName(orderDTO: CheckCustomerName) {
 		try {
 			return apiClient.post<void>('/orders/check-name', orderDTO).then(res => res.data)
@@ -1,4 +1,5 @@
 import type { PaginationParams } from '@/core/utils/pagination.utils'
+import type { StoreWorkingHoursDTO } from '@/modules/admin/stores/models/stores.models'
 
 export interface StoresFilter extends PaginationParams {
 	search?: string
@@ -15,7 +16,8 @@ export interface CreateStoreDTO {
 	isActive: boolean
 	contactPhone: string
 	contactEmail: string
-	storeHours: string
+	timezone: string
+	workingHours: StoreWorkingHoursDTO[]
 }
 
 export interface UpdateStoreDTO {
@@ -28,5 +30,6 @@ export interface UpdateStoreDTO {
 	isActive: boolean
 	contactPhone: string
 	contactEmail: string
-	storeHours: string
+	timezone: string
+	workingHours: StoreWorkingHoursDTO[]
 }
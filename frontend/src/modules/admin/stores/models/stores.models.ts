@@ -13,5 +13,44 @@ export interface StoreDTO {
 	isActive: boolean
 	contactPhone: string
 	contactEmail: string
-	storeHours: string
+	timezone: string
+	workingHours: StoreWorkingHoursDTO[]
+	hoursExceptions?: StoreHoursExceptionDTO[]
+	isOpen: boolean
+	pickupSlotCapacity: number
+}
+
+export enum StoreWeekday {
+	MONDAY = 'MONDAY',
+	TUESDAY = 'TUESDAY',
+	WEDNESDAY = 'WEDNESDAY',
+	THURSDAY = 'THURSDAY',
+	FRIDAY = 'FRIDAY',
+	SATURDAY = 'SATURDAY',
+	SUNDAY = 'SUNDAY',
+}
+
+export const STORE_WEEKDAYS_FORMATTED: Record<StoreWeekday, string> = {
+	[StoreWeekday.MONDAY]: 'Понедельник',
+	[StoreWeekday.TUESDAY]: 'Вторник',
+	[StoreWeekday.WEDNESDAY]: 'Среда',
+	[StoreWeekday.THURSDAY]: 'Четверг',
+	[StoreWeekday.FRIDAY]: 'Пятница',
+	[StoreWeekday.SATURDAY]: 'Суббота',
+	[StoreWeekday.SUNDAY]: 'Воскресенье',
+}
+
+export interface StoreWorkingHoursDTO {
+	day: StoreWeekday
+	openAt: string
+	closeAt: string
+}
+
+export interface StoreHoursExceptionDTO {
+	id: number
+	date: string
+	isClosed: boolean
+	openAt?: string
+	closeAt?: string
+	reason: string
 }
@@ -13,6 +13,18 @@ export interface WarehouseStockMaterialDetailsDTO {
 	stockMaterial: StockMaterialsDTO
 	quantity: number
 	earliestExpirationDate?: string
+	lots?: WarehouseStockLotDTO[]
+}
+
+// The lots are listed in the order they are picked, the first one expires the earliest
+export interface WarehouseStockLotDTO {
+	id: number
+	deliveryMaterialId?: number
+	barcode: string
+	expirationDate: string
+	receivedQuantity: number
+	quantity: number
+	createdAt: string
 }
 
 export interface GetWarehouseStockFilter extends PaginationParams {
@@ -34,6 +46,7 @@ export interface AvailableWarehouseStockMaterialsFilter extends PaginationParams
 export interface UpdateWarehouseStockDTO {
 	quantity: number
 	expirationDate?: Date
+	lotId?: number
 }
 
 export interface AddMultipleWarehouseStockDTO {
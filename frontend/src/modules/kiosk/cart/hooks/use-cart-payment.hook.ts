@@ -7,7 +7,7 @@ import { getKaspiConfig, KaspiService } from '@/core/integrations/kaspi.service'
 import { ordersService } from '@/modules/admin/store-orders/services/orders.service'
 
 // Types
-import type { OrderDTO, TransactionDTO } from '@/modules/admin/store-orders/models/orders.models'
+import type { OrderDTO } from '@/modules/admin/store-orders/models/orders.models'
 import { PaymentMethod } from '@/modules/kiosk/cart/models/kiosk-cart.models'
 
 const PAYMENT_TIMEOUT = 120_000 // 2 minutes
@@ -35,14 +35,8 @@ export function useCartPayment(order: OrderDTO | null, onProceed: () => void, on
 	 * Mutations
 	 * ------------------------------------- */
 	const successOrderPaymentMutation = useMutation({
-		mutationFn: ({
-			order,
-			transactionDTO,
-		}: {
-			order: OrderDTO
-			transactionDTO: TransactionDTO
-		}) => {
-			return ordersService.successOrderPayment(order.id, transactionDTO)
+		mutationFn: (order: OrderDTO) => {
+			return ordersService.waitForOrderPayment(order.id)
 		},
 		onSuccess: async () => {
 			console.log('Calling onProceed...')
@@ -75,6 +69,9 @@ export function useCartPayment(order: OrderDTO | null, onProceed: () => void, on
 			const kaspiConfig = getKaspiConfig()
 			if (!kaspiConfig) throw new Error('Kaspi config not found')
 
+			// the terminal integration confirms this intent by the signed webhook
+			await ordersService.createPaymentIntent(order.id)
+
 			const kaspiService = new KaspiService(kaspiConfig)
 			const transaction = isTest
 				? await kaspiService.awaitPaymentTest()
@@ -87,10 +84,7 @@ export function useCartPayment(order: OrderDTO | null, onProceed: () => void, on
 				throw new Error('Transaction data missing: ' + transaction)
 			}
 
-			await successOrderPaymentMutation.mutateAsync({
-				order,
-				transactionDTO: transaction,
-			})
+			await successOrderPaymentMutation.mutateAsync(order)
 		} catch (err) {
 			console.error('Payment wait failed:', err)
 			toast({